@@ -5,21 +5,50 @@ import (
 	"log"
 	"log/slog"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/dzakaammar/event-scheduling-example/cmd/pkg"
 	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/agenda"
 	"github.com/dzakaammar/event-scheduling-example/internal/app"
+	"github.com/dzakaammar/event-scheduling-example/internal/booking"
+	"github.com/dzakaammar/event-scheduling-example/internal/calendar"
+	"github.com/dzakaammar/event-scheduling-example/internal/changefeed"
 	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/email"
+	"github.com/dzakaammar/event-scheduling-example/internal/group"
+	"github.com/dzakaammar/event-scheduling-example/internal/health"
+	"github.com/dzakaammar/event-scheduling-example/internal/notification"
+	"github.com/dzakaammar/event-scheduling-example/internal/outbox"
 	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/dzakaammar/event-scheduling-example/internal/resource"
 	"github.com/dzakaammar/event-scheduling-example/internal/scheduling"
+	"github.com/dzakaammar/event-scheduling-example/internal/webhook"
+	"github.com/dzakaammar/event-scheduling-example/sql/migrations"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
 	"go.opentelemetry.io/otel"
 )
 
 func main() {
-	log.Fatalf("Error running grpc server: %v", run())
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			log.Fatalf("Error running migration: %v", err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "reminders" {
+		if err := runReminders(); err != nil {
+			log.Fatalf("Error running reminder worker: %v", err)
+		}
+		return
+	}
+
+	if err := run(); err != nil {
+		log.Fatalf("Error running grpc server: %v", err)
+	}
 }
 
 func run() error {
@@ -48,26 +77,189 @@ func run() error {
 		_ = dbConn.Close()
 	}()
 
+	err = prepareSchema(context.Background(), dbConn, cfg.AutoMigrate)
+	if err != nil {
+		return err
+	}
+
 	var repo core.EventRepository
 	{
 		repo = postgresql.NewEventRepository(dbConn)
 		repo = postgresql.NewInstrumentation(repo)
 	}
 
+	var historyRepo core.EventHistoryRepository
+	{
+		historyRepo = postgresql.NewEventHistoryRepository(dbConn)
+		historyRepo = postgresql.NewEventHistoryInstrumentation(historyRepo)
+	}
+
+	var keyRepo core.IdempotencyKeyRepository
+	{
+		keyRepo = postgresql.NewIdempotencyKeyRepository(dbConn)
+		keyRepo = postgresql.NewIdempotencyKeyInstrumentation(keyRepo)
+	}
+
+	var webhookRepo core.WebhookRepository
+	{
+		webhookRepo = postgresql.NewWebhookRepository(dbConn)
+		webhookRepo = postgresql.NewWebhookInstrumentation(webhookRepo)
+	}
+
+	var outboxRepo core.OutboxRepository
+	{
+		outboxRepo = postgresql.NewOutboxRepository(dbConn)
+		outboxRepo = postgresql.NewOutboxInstrumentation(outboxRepo)
+	}
+
+	var preferenceRepo core.NotificationPreferenceRepository
+	{
+		preferenceRepo = postgresql.NewNotificationPreferenceRepository(dbConn)
+		preferenceRepo = postgresql.NewNotificationPreferenceInstrumentation(preferenceRepo)
+	}
+
+	var digestRepo core.NotificationDigestRepository
+	{
+		digestRepo = postgresql.NewNotificationDigestRepository(dbConn)
+		digestRepo = postgresql.NewNotificationDigestInstrumentation(digestRepo)
+	}
+
+	var calendarRepo core.CalendarRepository
+	{
+		calendarRepo = postgresql.NewCalendarRepository(dbConn)
+		calendarRepo = postgresql.NewCalendarInstrumentation(calendarRepo)
+	}
+
+	var groupRepo core.GroupRepository
+	{
+		groupRepo = postgresql.NewGroupRepository(dbConn)
+		groupRepo = postgresql.NewGroupInstrumentation(groupRepo)
+	}
+
+	var resourceRepo core.ResourceRepository
+	{
+		resourceRepo = postgresql.NewResourceRepository(dbConn)
+		resourceRepo = postgresql.NewResourceInstrumentation(resourceRepo)
+	}
+
+	var bookingPageRepo core.BookingPageRepository
+	{
+		bookingPageRepo = postgresql.NewBookingPageRepository(dbConn)
+		bookingPageRepo = postgresql.NewBookingPageInstrumentation(bookingPageRepo)
+	}
+
+	changeBroker := changefeed.NewBroker(cfg.ChangeFeedBacklog)
+
 	var svc core.SchedulingService
 	{
-		svc = scheduling.NewService(repo)
+		svc = scheduling.NewService(repo, historyRepo, calendarRepo, groupRepo, changeBroker)
+		svc = scheduling.NewIdempotency(svc, keyRepo, cfg.IdempotencyKeyTTL)
 		svc = scheduling.NewInstrumentation(svc)
 	}
 
-	grpcServer := app.NewGRPCServer(svc)
+	var webhookSvc core.WebhookService
+	{
+		webhookSvc = webhook.NewService(webhookRepo)
+		webhookSvc = webhook.NewInstrumentation(webhookSvc)
+	}
+
+	var preferenceSvc core.NotificationPreferenceService
+	{
+		preferenceSvc = notification.NewService(preferenceRepo, repo)
+		preferenceSvc = notification.NewInstrumentation(preferenceSvc)
+	}
+
+	var agendaSvc core.AgendaService
+	{
+		agendaSvc = agenda.NewService(repo, preferenceRepo)
+		agendaSvc = agenda.NewInstrumentation(agendaSvc)
+	}
+
+	var calendarSvc core.CalendarService
+	{
+		calendarSvc = calendar.NewService(calendarRepo, repo, groupRepo, changeBroker)
+		calendarSvc = calendar.NewInstrumentation(calendarSvc)
+	}
+
+	var groupSvc core.GroupService
+	{
+		groupSvc = group.NewService(groupRepo, repo, changeBroker)
+		groupSvc = group.NewInstrumentation(groupSvc)
+	}
+
+	var resourceSvc core.ResourceService
+	{
+		resourceSvc = resource.NewService(resourceRepo)
+		resourceSvc = resource.NewInstrumentation(resourceSvc)
+	}
+
+	var bookingSvc core.BookingService
+	{
+		bookingSvc = booking.NewService(bookingPageRepo, changeBroker)
+		bookingSvc = booking.NewInstrumentation(bookingSvc)
+	}
+
+	// the workers are stopped once the server stopped, the database is closed after all of them returned
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	var wg sync.WaitGroup
+	startWorker := func(run func(ctx context.Context)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			run(workerCtx)
+		}()
+	}
+
+	purger := scheduling.NewPurger(repo, keyRepo, cfg.DeletedEventRetention, cfg.PurgeInterval)
+	startWorker(purger.Run)
+
+	dispatcher := webhook.NewDispatcher(webhookRepo, nil, cfg.WebhookDispatchInterval, cfg.WebhookMaxAttempts, cfg.WebhookRetryBackoff)
+	startWorker(dispatcher.Run)
+
+	publishers := outbox.Publishers{outbox.NewLogPublisher(), dispatcher}
+	if cfg.SMTPAddress != "" {
+		emailNotifier := newEmailNotifier(cfg, preferenceRepo, digestRepo)
+		publishers = append(publishers, emailNotifier)
+
+		digester := email.NewDigester(digestRepo, emailNotifier, cfg.EmailDigestInterval)
+		startWorker(digester.Run)
+	}
+	relay := outbox.NewRelay(outboxRepo, publishers, cfg.OutboxRelayInterval, cfg.OutboxRetention)
+	startWorker(relay.Run)
+
+	schemaProbe, err := postgresql.SchemaProbe(dbConn, migrations.FS)
+	if err != nil {
+		return err
+	}
+	healthMonitor := health.NewMonitor(map[string]health.Probe{
+		"postgres": postgresql.PingProbe(dbConn),
+		"schema":   schemaProbe,
+	}, cfg.HealthCheckInterval)
+	startWorker(healthMonitor.Run)
+
+	grpcServer := app.NewGRPCServer(svc, webhookSvc, preferenceSvc, agendaSvc, calendarSvc, groupSvc, resourceSvc, bookingSvc, healthMonitor)
 	waitForSignal := pkg.GracefulShutdown(func() error {
 		return grpcServer.Start(cfg.GRPCAddress)
 	})
 
 	waitForSignal()
 
+	// end the watches, otherwise stopping gracefully waits for the clients to hang up
+	changeBroker.Close()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	return grpcServer.Stop(ctx)
+	err = grpcServer.Stop(ctx)
+
+	stopWorkers()
+	wg.Wait()
+
+	return err
+}
+
+func newEmailNotifier(cfg internal.Config, preferences core.NotificationPreferenceRepository, digests core.NotificationDigestRepository) *email.Notifier {
+	client := email.NewSMTPClient(cfg.SMTPAddress, cfg.SMTPUsername, cfg.SMTPPassword)
+	return email.NewNotifier(client, email.AddressFormat(cfg.EmailAddressFormat), preferences, digests, cfg.EmailFrom)
 }
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/dzakaammar/event-scheduling-example/sql/migrations"
+	"github.com/jmoiron/sqlx"
+)
+
+var errMigrateUsage = errors.New("usage: migrate up [N] | down [N] | status | force VERSION")
+
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	cfg, err := internal.LoadConfig(".")
+	if err != nil {
+		return err
+	}
+
+	dbConn, err := sqlx.Open("pgx", cfg.DbSource)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = dbConn.Close()
+	}()
+
+	migrator, err := postgresql.NewMigrator(context.Background(), dbConn, migrations.FS)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = migrator.Close()
+	}()
+
+	switch args[0] {
+	case "up":
+		steps, err := parseSteps(args[1:], 0)
+		if err != nil {
+			return err
+		}
+		err = migrator.Up(steps)
+		if err != nil {
+			return err
+		}
+	case "down":
+		steps, err := parseSteps(args[1:], 1)
+		if err != nil {
+			return err
+		}
+		err = migrator.Down(steps)
+		if err != nil {
+			return err
+		}
+	case "force":
+		if len(args) != 2 {
+			return errMigrateUsage
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid version %q: %w", args[1], err)
+		}
+		err = migrator.Force(version)
+		if err != nil {
+			return err
+		}
+	case "status":
+	default:
+		return errMigrateUsage
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("version: %d, latest: %d, dirty: %t\n", status.Version, status.Latest, status.Dirty)
+	return nil
+}
+
+func parseSteps(args []string, defaultSteps int) (int, error) {
+	if len(args) == 0 {
+		return defaultSteps, nil
+	}
+	if len(args) > 1 {
+		return 0, errMigrateUsage
+	}
+
+	steps, err := strconv.Atoi(args[0])
+	if err != nil || steps <= 0 {
+		return 0, fmt.Errorf("invalid number of steps %q", args[0])
+	}
+	return steps, nil
+}
+
+// prepareSchema applies pending migrations when autoMigrate is set, and refuses to continue
+// when the database schema is dirty or newer than the migrations embedded in this binary.
+func prepareSchema(ctx context.Context, dbConn *sqlx.DB, autoMigrate bool) error {
+	migrator, err := postgresql.NewMigrator(ctx, dbConn, migrations.FS)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = migrator.Close()
+	}()
+
+	if autoMigrate {
+		return migrator.Up(0)
+	}
+
+	err = migrator.EnsureCompatible()
+	if err != nil {
+		return err
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+	if status.Pending() {
+		slog.Warn("database schema has pending migrations", "version", status.Version, "latest", status.Latest)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/dzakaammar/event-scheduling-example/cmd/pkg"
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/agenda"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/dzakaammar/event-scheduling-example/internal/reminder"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel"
+)
+
+// runReminders runs the reminder and the agenda workers on their own, any number of them can run
+// side by side with the grpc servers.
+func runReminders() error {
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		AddSource: true,
+		Level:     slog.LevelInfo,
+	})))
+
+	cfg, err := internal.LoadConfig(".")
+	if err != nil {
+		return err
+	}
+
+	tp, err := pkg.InitTracerProvider(cfg.OTLPEndpoint, "reminder-worker")
+	if err != nil {
+		return err
+	}
+	otel.SetTracerProvider(tp)
+
+	dbConn, err := sqlx.Open("pgx", cfg.DbSource)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = dbConn.Close()
+	}()
+
+	err = prepareSchema(context.Background(), dbConn, false)
+	if err != nil {
+		return err
+	}
+
+	var eventRepo core.EventRepository
+	{
+		eventRepo = postgresql.NewEventRepository(dbConn)
+		eventRepo = postgresql.NewInstrumentation(eventRepo)
+	}
+
+	var reminderRepo core.ReminderRepository
+	{
+		reminderRepo = postgresql.NewReminderRepository(dbConn)
+		reminderRepo = postgresql.NewReminderInstrumentation(reminderRepo)
+	}
+
+	var preferenceRepo core.NotificationPreferenceRepository
+	{
+		preferenceRepo = postgresql.NewNotificationPreferenceRepository(dbConn)
+		preferenceRepo = postgresql.NewNotificationPreferenceInstrumentation(preferenceRepo)
+	}
+
+	var agendaRepo core.AgendaRepository
+	{
+		agendaRepo = postgresql.NewAgendaRepository(dbConn)
+		agendaRepo = postgresql.NewAgendaInstrumentation(agendaRepo)
+	}
+
+	var agendaSvc core.AgendaService
+	{
+		agendaSvc = agenda.NewService(eventRepo, preferenceRepo)
+		agendaSvc = agenda.NewInstrumentation(agendaSvc)
+	}
+
+	notifiers := reminder.Notifiers{
+		core.ReminderChannel_Email: reminder.NewLogNotifier(),
+		core.ReminderChannel_Push:  reminder.NewLogNotifier(),
+	}
+	if cfg.SMTPAddress != "" {
+		notifiers[core.ReminderChannel_Email] = newEmailNotifier(
+			cfg,
+			preferenceRepo,
+			postgresql.NewNotificationDigestRepository(dbConn),
+		)
+	}
+
+	// the workers stop on a signal, the database is closed once both of them returned
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	agendaWorker := agenda.NewWorker(agendaRepo, agendaSvc, notifiers, cfg.AgendaTime, cfg.AgendaInterval)
+	worker := reminder.NewWorker(reminderRepo, eventRepo, preferenceRepo, notifiers, cfg.ReminderInterval)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		agendaWorker.Run(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		worker.Run(ctx)
+	}()
+	wg.Wait()
+
+	slog.Info("reminder worker stopped")
+	return nil
+}
@@ -14,7 +14,8 @@ func GracefulShutdown(fn func() error) func() {
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 
-	errCh := make(chan error, 1)
+	// both senders fit in, so that the one left over never blocks on a waiter that has returned
+	errCh := make(chan error, 2)
 
 	go func() {
 		if err := fn(); err != nil {
@@ -28,7 +29,6 @@ func GracefulShutdown(fn func() error) func() {
 	}()
 
 	return func() {
-		defer close(errCh)
 		err := <-errCh
 		slog.Error(err.Error())
 	}
@@ -20,12 +20,62 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	API_CreateEvent_FullMethodName     = "/proto.v1.API/CreateEvent"
-	API_UpdateEvent_FullMethodName     = "/proto.v1.API/UpdateEvent"
-	API_DeleteEventByID_FullMethodName = "/proto.v1.API/DeleteEventByID"
-	API_FindEventByID_FullMethodName   = "/proto.v1.API/FindEventByID"
-	API_Check_FullMethodName           = "/proto.v1.API/Check"
-	API_Watch_FullMethodName           = "/proto.v1.API/Watch"
+	API_CreateEvent_FullMethodName                  = "/proto.v1.API/CreateEvent"
+	API_UpdateEvent_FullMethodName                  = "/proto.v1.API/UpdateEvent"
+	API_DeleteEventByID_FullMethodName              = "/proto.v1.API/DeleteEventByID"
+	API_FindEventByID_FullMethodName                = "/proto.v1.API/FindEventByID"
+	API_ListEvents_FullMethodName                   = "/proto.v1.API/ListEvents"
+	API_SearchEvents_FullMethodName                 = "/proto.v1.API/SearchEvents"
+	API_BatchMutateEvents_FullMethodName            = "/proto.v1.API/BatchMutateEvents"
+	API_RestoreEvent_FullMethodName                 = "/proto.v1.API/RestoreEvent"
+	API_ListDeletedEvents_FullMethodName            = "/proto.v1.API/ListDeletedEvents"
+	API_RespondInvitation_FullMethodName            = "/proto.v1.API/RespondInvitation"
+	API_ListWaitlist_FullMethodName                 = "/proto.v1.API/ListWaitlist"
+	API_SetReminders_FullMethodName                 = "/proto.v1.API/SetReminders"
+	API_ListEventHistory_FullMethodName             = "/proto.v1.API/ListEventHistory"
+	API_CreateWebhook_FullMethodName                = "/proto.v1.API/CreateWebhook"
+	API_UpdateWebhook_FullMethodName                = "/proto.v1.API/UpdateWebhook"
+	API_DeleteWebhook_FullMethodName                = "/proto.v1.API/DeleteWebhook"
+	API_FindWebhookByID_FullMethodName              = "/proto.v1.API/FindWebhookByID"
+	API_ListWebhooks_FullMethodName                 = "/proto.v1.API/ListWebhooks"
+	API_ListWebhookDeliveries_FullMethodName        = "/proto.v1.API/ListWebhookDeliveries"
+	API_GetNotificationPreference_FullMethodName    = "/proto.v1.API/GetNotificationPreference"
+	API_UpdateNotificationPreference_FullMethodName = "/proto.v1.API/UpdateNotificationPreference"
+	API_MuteEvent_FullMethodName                    = "/proto.v1.API/MuteEvent"
+	API_UnmuteEvent_FullMethodName                  = "/proto.v1.API/UnmuteEvent"
+	API_GetAgenda_FullMethodName                    = "/proto.v1.API/GetAgenda"
+	API_CreateCalendar_FullMethodName               = "/proto.v1.API/CreateCalendar"
+	API_UpdateCalendar_FullMethodName               = "/proto.v1.API/UpdateCalendar"
+	API_DeleteCalendar_FullMethodName               = "/proto.v1.API/DeleteCalendar"
+	API_FindCalendarByID_FullMethodName             = "/proto.v1.API/FindCalendarByID"
+	API_ListCalendars_FullMethodName                = "/proto.v1.API/ListCalendars"
+	API_MoveEvent_FullMethodName                    = "/proto.v1.API/MoveEvent"
+	API_ShareCalendar_FullMethodName                = "/proto.v1.API/ShareCalendar"
+	API_UnshareCalendar_FullMethodName              = "/proto.v1.API/UnshareCalendar"
+	API_ListCalendarGrants_FullMethodName           = "/proto.v1.API/ListCalendarGrants"
+	API_CreateGroup_FullMethodName                  = "/proto.v1.API/CreateGroup"
+	API_UpdateGroup_FullMethodName                  = "/proto.v1.API/UpdateGroup"
+	API_DeleteGroup_FullMethodName                  = "/proto.v1.API/DeleteGroup"
+	API_FindGroupByID_FullMethodName                = "/proto.v1.API/FindGroupByID"
+	API_ListGroups_FullMethodName                   = "/proto.v1.API/ListGroups"
+	API_AddGroupMembers_FullMethodName              = "/proto.v1.API/AddGroupMembers"
+	API_RemoveGroupMembers_FullMethodName           = "/proto.v1.API/RemoveGroupMembers"
+	API_CreateResource_FullMethodName               = "/proto.v1.API/CreateResource"
+	API_UpdateResource_FullMethodName               = "/proto.v1.API/UpdateResource"
+	API_DeleteResource_FullMethodName               = "/proto.v1.API/DeleteResource"
+	API_FindResourceByID_FullMethodName             = "/proto.v1.API/FindResourceByID"
+	API_SearchResources_FullMethodName              = "/proto.v1.API/SearchResources"
+	API_GetResourceFreeBusy_FullMethodName          = "/proto.v1.API/GetResourceFreeBusy"
+	API_CreateBookingPage_FullMethodName            = "/proto.v1.API/CreateBookingPage"
+	API_UpdateBookingPage_FullMethodName            = "/proto.v1.API/UpdateBookingPage"
+	API_DeleteBookingPage_FullMethodName            = "/proto.v1.API/DeleteBookingPage"
+	API_ListBookingPages_FullMethodName             = "/proto.v1.API/ListBookingPages"
+	API_FindBookingPageByID_FullMethodName          = "/proto.v1.API/FindBookingPageByID"
+	API_ListSlots_FullMethodName                    = "/proto.v1.API/ListSlots"
+	API_BookSlot_FullMethodName                     = "/proto.v1.API/BookSlot"
+	API_WatchEvents_FullMethodName                  = "/proto.v1.API/WatchEvents"
+	API_Check_FullMethodName                        = "/proto.v1.API/Check"
+	API_Watch_FullMethodName                        = "/proto.v1.API/Watch"
 )
 
 // APIClient is the client API for API service.
@@ -38,6 +88,62 @@ type APIClient interface {
 	UpdateEvent(ctx context.Context, in *UpdateEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	DeleteEventByID(ctx context.Context, in *DeleteEventByIDRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	FindEventByID(ctx context.Context, in *FindEventByIDRequest, opts ...grpc.CallOption) (*FindEventByIDResponse, error)
+	ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error)
+	SearchEvents(ctx context.Context, in *SearchEventsRequest, opts ...grpc.CallOption) (*SearchEventsResponse, error)
+	BatchMutateEvents(ctx context.Context, in *BatchMutateEventsRequest, opts ...grpc.CallOption) (*BatchMutateEventsResponse, error)
+	RestoreEvent(ctx context.Context, in *RestoreEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListDeletedEvents(ctx context.Context, in *ListDeletedEventsRequest, opts ...grpc.CallOption) (*ListDeletedEventsResponse, error)
+	RespondInvitation(ctx context.Context, in *RespondInvitationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListWaitlist(ctx context.Context, in *ListWaitlistRequest, opts ...grpc.CallOption) (*ListWaitlistResponse, error)
+	SetReminders(ctx context.Context, in *SetRemindersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListEventHistory(ctx context.Context, in *ListEventHistoryRequest, opts ...grpc.CallOption) (*ListEventHistoryResponse, error)
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error)
+	UpdateWebhook(ctx context.Context, in *UpdateWebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	FindWebhookByID(ctx context.Context, in *FindWebhookByIDRequest, opts ...grpc.CallOption) (*FindWebhookByIDResponse, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	GetNotificationPreference(ctx context.Context, in *GetNotificationPreferenceRequest, opts ...grpc.CallOption) (*GetNotificationPreferenceResponse, error)
+	UpdateNotificationPreference(ctx context.Context, in *UpdateNotificationPreferenceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	MuteEvent(ctx context.Context, in *MuteEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UnmuteEvent(ctx context.Context, in *UnmuteEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetAgenda(ctx context.Context, in *GetAgendaRequest, opts ...grpc.CallOption) (*GetAgendaResponse, error)
+	CreateCalendar(ctx context.Context, in *CreateCalendarRequest, opts ...grpc.CallOption) (*CreateCalendarResponse, error)
+	UpdateCalendar(ctx context.Context, in *UpdateCalendarRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteCalendar(ctx context.Context, in *DeleteCalendarRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	FindCalendarByID(ctx context.Context, in *FindCalendarByIDRequest, opts ...grpc.CallOption) (*FindCalendarByIDResponse, error)
+	ListCalendars(ctx context.Context, in *ListCalendarsRequest, opts ...grpc.CallOption) (*ListCalendarsResponse, error)
+	MoveEvent(ctx context.Context, in *MoveEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ShareCalendar(ctx context.Context, in *ShareCalendarRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UnshareCalendar(ctx context.Context, in *UnshareCalendarRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListCalendarGrants(ctx context.Context, in *ListCalendarGrantsRequest, opts ...grpc.CallOption) (*ListCalendarGrantsResponse, error)
+	CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error)
+	UpdateGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	FindGroupByID(ctx context.Context, in *FindGroupByIDRequest, opts ...grpc.CallOption) (*FindGroupByIDResponse, error)
+	ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error)
+	AddGroupMembers(ctx context.Context, in *ChangeGroupMembersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveGroupMembers(ctx context.Context, in *ChangeGroupMembersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateResource(ctx context.Context, in *CreateResourceRequest, opts ...grpc.CallOption) (*CreateResourceResponse, error)
+	UpdateResource(ctx context.Context, in *UpdateResourceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteResource(ctx context.Context, in *DeleteResourceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	FindResourceByID(ctx context.Context, in *FindResourceByIDRequest, opts ...grpc.CallOption) (*FindResourceByIDResponse, error)
+	// SearchResources finds the resources by type, capacity and availability
+	SearchResources(ctx context.Context, in *SearchResourcesRequest, opts ...grpc.CallOption) (*SearchResourcesResponse, error)
+	// GetResourceFreeBusy returns when a resource is booked
+	GetResourceFreeBusy(ctx context.Context, in *GetResourceFreeBusyRequest, opts ...grpc.CallOption) (*GetResourceFreeBusyResponse, error)
+	CreateBookingPage(ctx context.Context, in *CreateBookingPageRequest, opts ...grpc.CallOption) (*CreateBookingPageResponse, error)
+	UpdateBookingPage(ctx context.Context, in *UpdateBookingPageRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteBookingPage(ctx context.Context, in *DeleteBookingPageRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListBookingPages(ctx context.Context, in *ListBookingPagesRequest, opts ...grpc.CallOption) (*ListBookingPagesResponse, error)
+	// FindBookingPageByID is public, the page is shown to whoever books a slot
+	FindBookingPageByID(ctx context.Context, in *FindBookingPageByIDRequest, opts ...grpc.CallOption) (*FindBookingPageByIDResponse, error)
+	// ListSlots is public, it lists the open slots computed from the schedules of the owner
+	ListSlots(ctx context.Context, in *ListSlotsRequest, opts ...grpc.CallOption) (*ListSlotsResponse, error)
+	// BookSlot is public, it fails with ABORTED when the slot was taken in the meantime
+	BookSlot(ctx context.Context, in *BookSlotRequest, opts ...grpc.CallOption) (*BookSlotResponse, error)
+	// WatchEvents streams the changes of the events the caller created or is invited to
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (API_WatchEventsClient, error)
 	Check(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error)
 	Watch(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (API_WatchClient, error)
 }
@@ -90,171 +196,1803 @@ func (c *aPIClient) FindEventByID(ctx context.Context, in *FindEventByIDRequest,
 	return out, nil
 }
 
-func (c *aPIClient) Check(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error) {
+func (c *aPIClient) ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(HealthCheckResponse)
-	err := c.cc.Invoke(ctx, API_Check_FullMethodName, in, out, cOpts...)
+	out := new(ListEventsResponse)
+	err := c.cc.Invoke(ctx, API_ListEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) SearchEvents(ctx context.Context, in *SearchEventsRequest, opts ...grpc.CallOption) (*SearchEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchEventsResponse)
+	err := c.cc.Invoke(ctx, API_SearchEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) BatchMutateEvents(ctx context.Context, in *BatchMutateEventsRequest, opts ...grpc.CallOption) (*BatchMutateEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchMutateEventsResponse)
+	err := c.cc.Invoke(ctx, API_BatchMutateEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) RestoreEvent(ctx context.Context, in *RestoreEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_RestoreEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListDeletedEvents(ctx context.Context, in *ListDeletedEventsRequest, opts ...grpc.CallOption) (*ListDeletedEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeletedEventsResponse)
+	err := c.cc.Invoke(ctx, API_ListDeletedEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) RespondInvitation(ctx context.Context, in *RespondInvitationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_RespondInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListWaitlist(ctx context.Context, in *ListWaitlistRequest, opts ...grpc.CallOption) (*ListWaitlistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWaitlistResponse)
+	err := c.cc.Invoke(ctx, API_ListWaitlist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) SetReminders(ctx context.Context, in *SetRemindersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_SetReminders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListEventHistory(ctx context.Context, in *ListEventHistoryRequest, opts ...grpc.CallOption) (*ListEventHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEventHistoryResponse)
+	err := c.cc.Invoke(ctx, API_ListEventHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateWebhookResponse)
+	err := c.cc.Invoke(ctx, API_CreateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) UpdateWebhook(ctx context.Context, in *UpdateWebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_UpdateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_DeleteWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) FindWebhookByID(ctx context.Context, in *FindWebhookByIDRequest, opts ...grpc.CallOption) (*FindWebhookByIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindWebhookByIDResponse)
+	err := c.cc.Invoke(ctx, API_FindWebhookByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, API_ListWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, API_ListWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetNotificationPreference(ctx context.Context, in *GetNotificationPreferenceRequest, opts ...grpc.CallOption) (*GetNotificationPreferenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNotificationPreferenceResponse)
+	err := c.cc.Invoke(ctx, API_GetNotificationPreference_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) UpdateNotificationPreference(ctx context.Context, in *UpdateNotificationPreferenceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_UpdateNotificationPreference_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) MuteEvent(ctx context.Context, in *MuteEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_MuteEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) UnmuteEvent(ctx context.Context, in *UnmuteEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_UnmuteEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetAgenda(ctx context.Context, in *GetAgendaRequest, opts ...grpc.CallOption) (*GetAgendaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAgendaResponse)
+	err := c.cc.Invoke(ctx, API_GetAgenda_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) CreateCalendar(ctx context.Context, in *CreateCalendarRequest, opts ...grpc.CallOption) (*CreateCalendarResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCalendarResponse)
+	err := c.cc.Invoke(ctx, API_CreateCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) UpdateCalendar(ctx context.Context, in *UpdateCalendarRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_UpdateCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DeleteCalendar(ctx context.Context, in *DeleteCalendarRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_DeleteCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) FindCalendarByID(ctx context.Context, in *FindCalendarByIDRequest, opts ...grpc.CallOption) (*FindCalendarByIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindCalendarByIDResponse)
+	err := c.cc.Invoke(ctx, API_FindCalendarByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListCalendars(ctx context.Context, in *ListCalendarsRequest, opts ...grpc.CallOption) (*ListCalendarsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCalendarsResponse)
+	err := c.cc.Invoke(ctx, API_ListCalendars_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) MoveEvent(ctx context.Context, in *MoveEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_MoveEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ShareCalendar(ctx context.Context, in *ShareCalendarRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_ShareCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) UnshareCalendar(ctx context.Context, in *UnshareCalendarRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_UnshareCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListCalendarGrants(ctx context.Context, in *ListCalendarGrantsRequest, opts ...grpc.CallOption) (*ListCalendarGrantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCalendarGrantsResponse)
+	err := c.cc.Invoke(ctx, API_ListCalendarGrants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateGroupResponse)
+	err := c.cc.Invoke(ctx, API_CreateGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) UpdateGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_UpdateGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_DeleteGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) FindGroupByID(ctx context.Context, in *FindGroupByIDRequest, opts ...grpc.CallOption) (*FindGroupByIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindGroupByIDResponse)
+	err := c.cc.Invoke(ctx, API_FindGroupByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGroupsResponse)
+	err := c.cc.Invoke(ctx, API_ListGroups_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *aPIClient) AddGroupMembers(ctx context.Context, in *ChangeGroupMembersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_AddGroupMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) RemoveGroupMembers(ctx context.Context, in *ChangeGroupMembersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_RemoveGroupMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) CreateResource(ctx context.Context, in *CreateResourceRequest, opts ...grpc.CallOption) (*CreateResourceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateResourceResponse)
+	err := c.cc.Invoke(ctx, API_CreateResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) UpdateResource(ctx context.Context, in *UpdateResourceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_UpdateResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DeleteResource(ctx context.Context, in *DeleteResourceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_DeleteResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) FindResourceByID(ctx context.Context, in *FindResourceByIDRequest, opts ...grpc.CallOption) (*FindResourceByIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindResourceByIDResponse)
+	err := c.cc.Invoke(ctx, API_FindResourceByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) SearchResources(ctx context.Context, in *SearchResourcesRequest, opts ...grpc.CallOption) (*SearchResourcesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResourcesResponse)
+	err := c.cc.Invoke(ctx, API_SearchResources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetResourceFreeBusy(ctx context.Context, in *GetResourceFreeBusyRequest, opts ...grpc.CallOption) (*GetResourceFreeBusyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetResourceFreeBusyResponse)
+	err := c.cc.Invoke(ctx, API_GetResourceFreeBusy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) CreateBookingPage(ctx context.Context, in *CreateBookingPageRequest, opts ...grpc.CallOption) (*CreateBookingPageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateBookingPageResponse)
+	err := c.cc.Invoke(ctx, API_CreateBookingPage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) UpdateBookingPage(ctx context.Context, in *UpdateBookingPageRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_UpdateBookingPage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DeleteBookingPage(ctx context.Context, in *DeleteBookingPageRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, API_DeleteBookingPage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListBookingPages(ctx context.Context, in *ListBookingPagesRequest, opts ...grpc.CallOption) (*ListBookingPagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBookingPagesResponse)
+	err := c.cc.Invoke(ctx, API_ListBookingPages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) FindBookingPageByID(ctx context.Context, in *FindBookingPageByIDRequest, opts ...grpc.CallOption) (*FindBookingPageByIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindBookingPageByIDResponse)
+	err := c.cc.Invoke(ctx, API_FindBookingPageByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListSlots(ctx context.Context, in *ListSlotsRequest, opts ...grpc.CallOption) (*ListSlotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSlotsResponse)
+	err := c.cc.Invoke(ctx, API_ListSlots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) BookSlot(ctx context.Context, in *BookSlotRequest, opts ...grpc.CallOption) (*BookSlotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BookSlotResponse)
+	err := c.cc.Invoke(ctx, API_BookSlot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (API_WatchEventsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[0], API_WatchEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIWatchEventsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_WatchEventsClient interface {
+	Recv() (*EventChange, error)
+	grpc.ClientStream
+}
+
+type aPIWatchEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIWatchEventsClient) Recv() (*EventChange, error) {
+	m := new(EventChange)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) Check(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HealthCheckResponse)
+	err := c.cc.Invoke(ctx, API_Check_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Watch(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (API_WatchClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[1], API_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIWatchClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_WatchClient interface {
+	Recv() (*HealthCheckResponse, error)
+	grpc.ClientStream
+}
+
+type aPIWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIWatchClient) Recv() (*HealthCheckResponse, error) {
+	m := new(HealthCheckResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// APIServer is the server API for API service.
+// All implementations must embed UnimplementedAPIServer
+// for forward compatibility
+//
+// API
+type APIServer interface {
+	CreateEvent(context.Context, *CreateEventRequest) (*CreateEventResponse, error)
+	UpdateEvent(context.Context, *UpdateEventRequest) (*emptypb.Empty, error)
+	DeleteEventByID(context.Context, *DeleteEventByIDRequest) (*emptypb.Empty, error)
+	FindEventByID(context.Context, *FindEventByIDRequest) (*FindEventByIDResponse, error)
+	ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error)
+	SearchEvents(context.Context, *SearchEventsRequest) (*SearchEventsResponse, error)
+	BatchMutateEvents(context.Context, *BatchMutateEventsRequest) (*BatchMutateEventsResponse, error)
+	RestoreEvent(context.Context, *RestoreEventRequest) (*emptypb.Empty, error)
+	ListDeletedEvents(context.Context, *ListDeletedEventsRequest) (*ListDeletedEventsResponse, error)
+	RespondInvitation(context.Context, *RespondInvitationRequest) (*emptypb.Empty, error)
+	ListWaitlist(context.Context, *ListWaitlistRequest) (*ListWaitlistResponse, error)
+	SetReminders(context.Context, *SetRemindersRequest) (*emptypb.Empty, error)
+	ListEventHistory(context.Context, *ListEventHistoryRequest) (*ListEventHistoryResponse, error)
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error)
+	UpdateWebhook(context.Context, *UpdateWebhookRequest) (*emptypb.Empty, error)
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*emptypb.Empty, error)
+	FindWebhookByID(context.Context, *FindWebhookByIDRequest) (*FindWebhookByIDResponse, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	GetNotificationPreference(context.Context, *GetNotificationPreferenceRequest) (*GetNotificationPreferenceResponse, error)
+	UpdateNotificationPreference(context.Context, *UpdateNotificationPreferenceRequest) (*emptypb.Empty, error)
+	MuteEvent(context.Context, *MuteEventRequest) (*emptypb.Empty, error)
+	UnmuteEvent(context.Context, *UnmuteEventRequest) (*emptypb.Empty, error)
+	GetAgenda(context.Context, *GetAgendaRequest) (*GetAgendaResponse, error)
+	CreateCalendar(context.Context, *CreateCalendarRequest) (*CreateCalendarResponse, error)
+	UpdateCalendar(context.Context, *UpdateCalendarRequest) (*emptypb.Empty, error)
+	DeleteCalendar(context.Context, *DeleteCalendarRequest) (*emptypb.Empty, error)
+	FindCalendarByID(context.Context, *FindCalendarByIDRequest) (*FindCalendarByIDResponse, error)
+	ListCalendars(context.Context, *ListCalendarsRequest) (*ListCalendarsResponse, error)
+	MoveEvent(context.Context, *MoveEventRequest) (*emptypb.Empty, error)
+	ShareCalendar(context.Context, *ShareCalendarRequest) (*emptypb.Empty, error)
+	UnshareCalendar(context.Context, *UnshareCalendarRequest) (*emptypb.Empty, error)
+	ListCalendarGrants(context.Context, *ListCalendarGrantsRequest) (*ListCalendarGrantsResponse, error)
+	CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error)
+	UpdateGroup(context.Context, *UpdateGroupRequest) (*emptypb.Empty, error)
+	DeleteGroup(context.Context, *DeleteGroupRequest) (*emptypb.Empty, error)
+	FindGroupByID(context.Context, *FindGroupByIDRequest) (*FindGroupByIDResponse, error)
+	ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error)
+	AddGroupMembers(context.Context, *ChangeGroupMembersRequest) (*emptypb.Empty, error)
+	RemoveGroupMembers(context.Context, *ChangeGroupMembersRequest) (*emptypb.Empty, error)
+	CreateResource(context.Context, *CreateResourceRequest) (*CreateResourceResponse, error)
+	UpdateResource(context.Context, *UpdateResourceRequest) (*emptypb.Empty, error)
+	DeleteResource(context.Context, *DeleteResourceRequest) (*emptypb.Empty, error)
+	FindResourceByID(context.Context, *FindResourceByIDRequest) (*FindResourceByIDResponse, error)
+	// SearchResources finds the resources by type, capacity and availability
+	SearchResources(context.Context, *SearchResourcesRequest) (*SearchResourcesResponse, error)
+	// GetResourceFreeBusy returns when a resource is booked
+	GetResourceFreeBusy(context.Context, *GetResourceFreeBusyRequest) (*GetResourceFreeBusyResponse, error)
+	CreateBookingPage(context.Context, *CreateBookingPageRequest) (*CreateBookingPageResponse, error)
+	UpdateBookingPage(context.Context, *UpdateBookingPageRequest) (*emptypb.Empty, error)
+	DeleteBookingPage(context.Context, *DeleteBookingPageRequest) (*emptypb.Empty, error)
+	ListBookingPages(context.Context, *ListBookingPagesRequest) (*ListBookingPagesResponse, error)
+	// FindBookingPageByID is public, the page is shown to whoever books a slot
+	FindBookingPageByID(context.Context, *FindBookingPageByIDRequest) (*FindBookingPageByIDResponse, error)
+	// ListSlots is public, it lists the open slots computed from the schedules of the owner
+	ListSlots(context.Context, *ListSlotsRequest) (*ListSlotsResponse, error)
+	// BookSlot is public, it fails with ABORTED when the slot was taken in the meantime
+	BookSlot(context.Context, *BookSlotRequest) (*BookSlotResponse, error)
+	// WatchEvents streams the changes of the events the caller created or is invited to
+	WatchEvents(*WatchEventsRequest, API_WatchEventsServer) error
+	Check(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
+	Watch(*HealthCheckRequest, API_WatchServer) error
+	mustEmbedUnimplementedAPIServer()
+}
+
+// UnimplementedAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedAPIServer struct {
+}
+
+func (UnimplementedAPIServer) CreateEvent(context.Context, *CreateEventRequest) (*CreateEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEvent not implemented")
+}
+func (UnimplementedAPIServer) UpdateEvent(context.Context, *UpdateEventRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEvent not implemented")
+}
+func (UnimplementedAPIServer) DeleteEventByID(context.Context, *DeleteEventByIDRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEventByID not implemented")
+}
+func (UnimplementedAPIServer) FindEventByID(context.Context, *FindEventByIDRequest) (*FindEventByIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindEventByID not implemented")
+}
+func (UnimplementedAPIServer) ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEvents not implemented")
+}
+func (UnimplementedAPIServer) SearchEvents(context.Context, *SearchEventsRequest) (*SearchEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchEvents not implemented")
+}
+func (UnimplementedAPIServer) BatchMutateEvents(context.Context, *BatchMutateEventsRequest) (*BatchMutateEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchMutateEvents not implemented")
+}
+func (UnimplementedAPIServer) RestoreEvent(context.Context, *RestoreEventRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreEvent not implemented")
+}
+func (UnimplementedAPIServer) ListDeletedEvents(context.Context, *ListDeletedEventsRequest) (*ListDeletedEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedEvents not implemented")
+}
+func (UnimplementedAPIServer) RespondInvitation(context.Context, *RespondInvitationRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondInvitation not implemented")
+}
+func (UnimplementedAPIServer) ListWaitlist(context.Context, *ListWaitlistRequest) (*ListWaitlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWaitlist not implemented")
+}
+func (UnimplementedAPIServer) SetReminders(context.Context, *SetRemindersRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetReminders not implemented")
+}
+func (UnimplementedAPIServer) ListEventHistory(context.Context, *ListEventHistoryRequest) (*ListEventHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEventHistory not implemented")
+}
+func (UnimplementedAPIServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedAPIServer) UpdateWebhook(context.Context, *UpdateWebhookRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWebhook not implemented")
+}
+func (UnimplementedAPIServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedAPIServer) FindWebhookByID(context.Context, *FindWebhookByIDRequest) (*FindWebhookByIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindWebhookByID not implemented")
+}
+func (UnimplementedAPIServer) ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedAPIServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedAPIServer) GetNotificationPreference(context.Context, *GetNotificationPreferenceRequest) (*GetNotificationPreferenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNotificationPreference not implemented")
+}
+func (UnimplementedAPIServer) UpdateNotificationPreference(context.Context, *UpdateNotificationPreferenceRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNotificationPreference not implemented")
+}
+func (UnimplementedAPIServer) MuteEvent(context.Context, *MuteEventRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MuteEvent not implemented")
+}
+func (UnimplementedAPIServer) UnmuteEvent(context.Context, *UnmuteEventRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnmuteEvent not implemented")
+}
+func (UnimplementedAPIServer) GetAgenda(context.Context, *GetAgendaRequest) (*GetAgendaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgenda not implemented")
+}
+func (UnimplementedAPIServer) CreateCalendar(context.Context, *CreateCalendarRequest) (*CreateCalendarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCalendar not implemented")
+}
+func (UnimplementedAPIServer) UpdateCalendar(context.Context, *UpdateCalendarRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCalendar not implemented")
+}
+func (UnimplementedAPIServer) DeleteCalendar(context.Context, *DeleteCalendarRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCalendar not implemented")
+}
+func (UnimplementedAPIServer) FindCalendarByID(context.Context, *FindCalendarByIDRequest) (*FindCalendarByIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindCalendarByID not implemented")
+}
+func (UnimplementedAPIServer) ListCalendars(context.Context, *ListCalendarsRequest) (*ListCalendarsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCalendars not implemented")
+}
+func (UnimplementedAPIServer) MoveEvent(context.Context, *MoveEventRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveEvent not implemented")
+}
+func (UnimplementedAPIServer) ShareCalendar(context.Context, *ShareCalendarRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareCalendar not implemented")
+}
+func (UnimplementedAPIServer) UnshareCalendar(context.Context, *UnshareCalendarRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnshareCalendar not implemented")
+}
+func (UnimplementedAPIServer) ListCalendarGrants(context.Context, *ListCalendarGrantsRequest) (*ListCalendarGrantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCalendarGrants not implemented")
+}
+func (UnimplementedAPIServer) CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGroup not implemented")
+}
+func (UnimplementedAPIServer) UpdateGroup(context.Context, *UpdateGroupRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGroup not implemented")
+}
+func (UnimplementedAPIServer) DeleteGroup(context.Context, *DeleteGroupRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGroup not implemented")
+}
+func (UnimplementedAPIServer) FindGroupByID(context.Context, *FindGroupByIDRequest) (*FindGroupByIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindGroupByID not implemented")
+}
+func (UnimplementedAPIServer) ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGroups not implemented")
+}
+func (UnimplementedAPIServer) AddGroupMembers(context.Context, *ChangeGroupMembersRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddGroupMembers not implemented")
+}
+func (UnimplementedAPIServer) RemoveGroupMembers(context.Context, *ChangeGroupMembersRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveGroupMembers not implemented")
+}
+func (UnimplementedAPIServer) CreateResource(context.Context, *CreateResourceRequest) (*CreateResourceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateResource not implemented")
+}
+func (UnimplementedAPIServer) UpdateResource(context.Context, *UpdateResourceRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateResource not implemented")
+}
+func (UnimplementedAPIServer) DeleteResource(context.Context, *DeleteResourceRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteResource not implemented")
+}
+func (UnimplementedAPIServer) FindResourceByID(context.Context, *FindResourceByIDRequest) (*FindResourceByIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindResourceByID not implemented")
+}
+func (UnimplementedAPIServer) SearchResources(context.Context, *SearchResourcesRequest) (*SearchResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchResources not implemented")
+}
+func (UnimplementedAPIServer) GetResourceFreeBusy(context.Context, *GetResourceFreeBusyRequest) (*GetResourceFreeBusyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResourceFreeBusy not implemented")
+}
+func (UnimplementedAPIServer) CreateBookingPage(context.Context, *CreateBookingPageRequest) (*CreateBookingPageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBookingPage not implemented")
+}
+func (UnimplementedAPIServer) UpdateBookingPage(context.Context, *UpdateBookingPageRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBookingPage not implemented")
+}
+func (UnimplementedAPIServer) DeleteBookingPage(context.Context, *DeleteBookingPageRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBookingPage not implemented")
+}
+func (UnimplementedAPIServer) ListBookingPages(context.Context, *ListBookingPagesRequest) (*ListBookingPagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBookingPages not implemented")
+}
+func (UnimplementedAPIServer) FindBookingPageByID(context.Context, *FindBookingPageByIDRequest) (*FindBookingPageByIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindBookingPageByID not implemented")
+}
+func (UnimplementedAPIServer) ListSlots(context.Context, *ListSlotsRequest) (*ListSlotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSlots not implemented")
+}
+func (UnimplementedAPIServer) BookSlot(context.Context, *BookSlotRequest) (*BookSlotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BookSlot not implemented")
+}
+func (UnimplementedAPIServer) WatchEvents(*WatchEventsRequest, API_WatchEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedAPIServer) Check(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
+}
+func (UnimplementedAPIServer) Watch(*HealthCheckRequest, API_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedAPIServer) mustEmbedUnimplementedAPIServer() {}
+
+// UnsafeAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to APIServer will
+// result in compilation errors.
+type UnsafeAPIServer interface {
+	mustEmbedUnimplementedAPIServer()
+}
+
+func RegisterAPIServer(s grpc.ServiceRegistrar, srv APIServer) {
+	s.RegisterService(&API_ServiceDesc, srv)
+}
+
+func _API_CreateEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).CreateEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_CreateEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CreateEvent(ctx, req.(*CreateEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_UpdateEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).UpdateEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_UpdateEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).UpdateEvent(ctx, req.(*UpdateEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_DeleteEventByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEventByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).DeleteEventByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_DeleteEventByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DeleteEventByID(ctx, req.(*DeleteEventByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_FindEventByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindEventByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).FindEventByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_FindEventByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).FindEventByID(ctx, req.(*FindEventByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_ListEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListEvents(ctx, req.(*ListEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_SearchEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).SearchEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_SearchEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SearchEvents(ctx, req.(*SearchEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_BatchMutateEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchMutateEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).BatchMutateEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_BatchMutateEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).BatchMutateEvents(ctx, req.(*BatchMutateEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_RestoreEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).RestoreEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_RestoreEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).RestoreEvent(ctx, req.(*RestoreEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListDeletedEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListDeletedEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_ListDeletedEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListDeletedEvents(ctx, req.(*ListDeletedEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_RespondInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).RespondInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_RespondInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).RespondInvitation(ctx, req.(*RespondInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListWaitlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWaitlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListWaitlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_ListWaitlist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListWaitlist(ctx, req.(*ListWaitlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_SetReminders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRemindersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).SetReminders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_SetReminders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SetReminders(ctx, req.(*SetRemindersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListEventHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEventHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListEventHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_ListEventHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListEventHistory(ctx, req.(*ListEventHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_UpdateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).UpdateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_UpdateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).UpdateWebhook(ctx, req.(*UpdateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_FindWebhookByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindWebhookByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).FindWebhookByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_FindWebhookByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).FindWebhookByID(ctx, req.(*FindWebhookByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetNotificationPreference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotificationPreferenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetNotificationPreference(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetNotificationPreference_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetNotificationPreference(ctx, req.(*GetNotificationPreferenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_UpdateNotificationPreference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNotificationPreferenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).UpdateNotificationPreference(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_UpdateNotificationPreference_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).UpdateNotificationPreference(ctx, req.(*UpdateNotificationPreferenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_MuteEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuteEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).MuteEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_MuteEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).MuteEvent(ctx, req.(*MuteEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_UnmuteEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnmuteEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).UnmuteEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_UnmuteEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).UnmuteEvent(ctx, req.(*UnmuteEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetAgenda_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAgendaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetAgenda(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetAgenda_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetAgenda(ctx, req.(*GetAgendaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_CreateCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).CreateCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_CreateCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CreateCalendar(ctx, req.(*CreateCalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_UpdateCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).UpdateCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_UpdateCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).UpdateCalendar(ctx, req.(*UpdateCalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_DeleteCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).DeleteCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_DeleteCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DeleteCalendar(ctx, req.(*DeleteCalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_FindCalendarByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindCalendarByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).FindCalendarByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_FindCalendarByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).FindCalendarByID(ctx, req.(*FindCalendarByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListCalendars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCalendarsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListCalendars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_ListCalendars_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListCalendars(ctx, req.(*ListCalendarsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_MoveEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).MoveEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_MoveEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).MoveEvent(ctx, req.(*MoveEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ShareCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareCalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ShareCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_ShareCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ShareCalendar(ctx, req.(*ShareCalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_UnshareCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareCalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).UnshareCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_UnshareCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).UnshareCalendar(ctx, req.(*UnshareCalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListCalendarGrants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCalendarGrantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListCalendarGrants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_ListCalendarGrants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListCalendarGrants(ctx, req.(*ListCalendarGrantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).CreateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_CreateGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CreateGroup(ctx, req.(*CreateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_UpdateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).UpdateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_UpdateGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).UpdateGroup(ctx, req.(*UpdateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_DeleteGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).DeleteGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_DeleteGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DeleteGroup(ctx, req.(*DeleteGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_FindGroupByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindGroupByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).FindGroupByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_FindGroupByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).FindGroupByID(ctx, req.(*FindGroupByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_ListGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListGroups(ctx, req.(*ListGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_AddGroupMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeGroupMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).AddGroupMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_AddGroupMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).AddGroupMembers(ctx, req.(*ChangeGroupMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_RemoveGroupMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeGroupMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).RemoveGroupMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_RemoveGroupMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).RemoveGroupMembers(ctx, req.(*ChangeGroupMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_CreateResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).CreateResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_CreateResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CreateResource(ctx, req.(*CreateResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_UpdateResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).UpdateResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_UpdateResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).UpdateResource(ctx, req.(*UpdateResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_DeleteResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).DeleteResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_DeleteResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DeleteResource(ctx, req.(*DeleteResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_FindResourceByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindResourceByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).FindResourceByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_FindResourceByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).FindResourceByID(ctx, req.(*FindResourceByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *aPIClient) Watch(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (API_WatchClient, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[0], API_Watch_FullMethodName, cOpts...)
-	if err != nil {
+func _API_SearchResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchResourcesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &aPIWatchClient{ClientStream: stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(APIServer).SearchResources(ctx, in)
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_SearchResources_FullMethodName,
 	}
-	return x, nil
-}
-
-type API_WatchClient interface {
-	Recv() (*HealthCheckResponse, error)
-	grpc.ClientStream
-}
-
-type aPIWatchClient struct {
-	grpc.ClientStream
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SearchResources(ctx, req.(*SearchResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *aPIWatchClient) Recv() (*HealthCheckResponse, error) {
-	m := new(HealthCheckResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func _API_GetResourceFreeBusy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResourceFreeBusyRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
-}
-
-// APIServer is the server API for API service.
-// All implementations must embed UnimplementedAPIServer
-// for forward compatibility
-//
-// API
-type APIServer interface {
-	CreateEvent(context.Context, *CreateEventRequest) (*CreateEventResponse, error)
-	UpdateEvent(context.Context, *UpdateEventRequest) (*emptypb.Empty, error)
-	DeleteEventByID(context.Context, *DeleteEventByIDRequest) (*emptypb.Empty, error)
-	FindEventByID(context.Context, *FindEventByIDRequest) (*FindEventByIDResponse, error)
-	Check(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error)
-	Watch(*HealthCheckRequest, API_WatchServer) error
-	mustEmbedUnimplementedAPIServer()
-}
-
-// UnimplementedAPIServer must be embedded to have forward compatible implementations.
-type UnimplementedAPIServer struct {
+	if interceptor == nil {
+		return srv.(APIServer).GetResourceFreeBusy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetResourceFreeBusy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetResourceFreeBusy(ctx, req.(*GetResourceFreeBusyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (UnimplementedAPIServer) CreateEvent(context.Context, *CreateEventRequest) (*CreateEventResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateEvent not implemented")
-}
-func (UnimplementedAPIServer) UpdateEvent(context.Context, *UpdateEventRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateEvent not implemented")
-}
-func (UnimplementedAPIServer) DeleteEventByID(context.Context, *DeleteEventByIDRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteEventByID not implemented")
-}
-func (UnimplementedAPIServer) FindEventByID(context.Context, *FindEventByIDRequest) (*FindEventByIDResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method FindEventByID not implemented")
-}
-func (UnimplementedAPIServer) Check(context.Context, *HealthCheckRequest) (*HealthCheckResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
-}
-func (UnimplementedAPIServer) Watch(*HealthCheckRequest, API_WatchServer) error {
-	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+func _API_CreateBookingPage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBookingPageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).CreateBookingPage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_CreateBookingPage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).CreateBookingPage(ctx, req.(*CreateBookingPageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedAPIServer) mustEmbedUnimplementedAPIServer() {}
 
-// UnsafeAPIServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to APIServer will
-// result in compilation errors.
-type UnsafeAPIServer interface {
-	mustEmbedUnimplementedAPIServer()
+func _API_UpdateBookingPage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBookingPageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).UpdateBookingPage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_UpdateBookingPage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).UpdateBookingPage(ctx, req.(*UpdateBookingPageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterAPIServer(s grpc.ServiceRegistrar, srv APIServer) {
-	s.RegisterService(&API_ServiceDesc, srv)
+func _API_DeleteBookingPage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBookingPageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).DeleteBookingPage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_DeleteBookingPage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).DeleteBookingPage(ctx, req.(*DeleteBookingPageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _API_CreateEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateEventRequest)
+func _API_ListBookingPages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBookingPagesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(APIServer).CreateEvent(ctx, in)
+		return srv.(APIServer).ListBookingPages(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: API_CreateEvent_FullMethodName,
+		FullMethod: API_ListBookingPages_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).CreateEvent(ctx, req.(*CreateEventRequest))
+		return srv.(APIServer).ListBookingPages(ctx, req.(*ListBookingPagesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _API_UpdateEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateEventRequest)
+func _API_FindBookingPageByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindBookingPageByIDRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(APIServer).UpdateEvent(ctx, in)
+		return srv.(APIServer).FindBookingPageByID(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: API_UpdateEvent_FullMethodName,
+		FullMethod: API_FindBookingPageByID_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).UpdateEvent(ctx, req.(*UpdateEventRequest))
+		return srv.(APIServer).FindBookingPageByID(ctx, req.(*FindBookingPageByIDRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _API_DeleteEventByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteEventByIDRequest)
+func _API_ListSlots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSlotsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(APIServer).DeleteEventByID(ctx, in)
+		return srv.(APIServer).ListSlots(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: API_DeleteEventByID_FullMethodName,
+		FullMethod: API_ListSlots_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).DeleteEventByID(ctx, req.(*DeleteEventByIDRequest))
+		return srv.(APIServer).ListSlots(ctx, req.(*ListSlotsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _API_FindEventByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FindEventByIDRequest)
+func _API_BookSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookSlotRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(APIServer).FindEventByID(ctx, in)
+		return srv.(APIServer).BookSlot(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: API_FindEventByID_FullMethodName,
+		FullMethod: API_BookSlot_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(APIServer).FindEventByID(ctx, req.(*FindEventByIDRequest))
+		return srv.(APIServer).BookSlot(ctx, req.(*BookSlotRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).WatchEvents(m, &aPIWatchEventsServer{ServerStream: stream})
+}
+
+type API_WatchEventsServer interface {
+	Send(*EventChange) error
+	grpc.ServerStream
+}
+
+type aPIWatchEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIWatchEventsServer) Send(m *EventChange) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _API_Check_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(HealthCheckRequest)
 	if err := dec(in); err != nil {
@@ -317,12 +2055,213 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FindEventByID",
 			Handler:    _API_FindEventByID_Handler,
 		},
+		{
+			MethodName: "ListEvents",
+			Handler:    _API_ListEvents_Handler,
+		},
+		{
+			MethodName: "SearchEvents",
+			Handler:    _API_SearchEvents_Handler,
+		},
+		{
+			MethodName: "BatchMutateEvents",
+			Handler:    _API_BatchMutateEvents_Handler,
+		},
+		{
+			MethodName: "RestoreEvent",
+			Handler:    _API_RestoreEvent_Handler,
+		},
+		{
+			MethodName: "ListDeletedEvents",
+			Handler:    _API_ListDeletedEvents_Handler,
+		},
+		{
+			MethodName: "RespondInvitation",
+			Handler:    _API_RespondInvitation_Handler,
+		},
+		{
+			MethodName: "ListWaitlist",
+			Handler:    _API_ListWaitlist_Handler,
+		},
+		{
+			MethodName: "SetReminders",
+			Handler:    _API_SetReminders_Handler,
+		},
+		{
+			MethodName: "ListEventHistory",
+			Handler:    _API_ListEventHistory_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _API_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "UpdateWebhook",
+			Handler:    _API_UpdateWebhook_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _API_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "FindWebhookByID",
+			Handler:    _API_FindWebhookByID_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _API_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _API_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "GetNotificationPreference",
+			Handler:    _API_GetNotificationPreference_Handler,
+		},
+		{
+			MethodName: "UpdateNotificationPreference",
+			Handler:    _API_UpdateNotificationPreference_Handler,
+		},
+		{
+			MethodName: "MuteEvent",
+			Handler:    _API_MuteEvent_Handler,
+		},
+		{
+			MethodName: "UnmuteEvent",
+			Handler:    _API_UnmuteEvent_Handler,
+		},
+		{
+			MethodName: "GetAgenda",
+			Handler:    _API_GetAgenda_Handler,
+		},
+		{
+			MethodName: "CreateCalendar",
+			Handler:    _API_CreateCalendar_Handler,
+		},
+		{
+			MethodName: "UpdateCalendar",
+			Handler:    _API_UpdateCalendar_Handler,
+		},
+		{
+			MethodName: "DeleteCalendar",
+			Handler:    _API_DeleteCalendar_Handler,
+		},
+		{
+			MethodName: "FindCalendarByID",
+			Handler:    _API_FindCalendarByID_Handler,
+		},
+		{
+			MethodName: "ListCalendars",
+			Handler:    _API_ListCalendars_Handler,
+		},
+		{
+			MethodName: "MoveEvent",
+			Handler:    _API_MoveEvent_Handler,
+		},
+		{
+			MethodName: "ShareCalendar",
+			Handler:    _API_ShareCalendar_Handler,
+		},
+		{
+			MethodName: "UnshareCalendar",
+			Handler:    _API_UnshareCalendar_Handler,
+		},
+		{
+			MethodName: "ListCalendarGrants",
+			Handler:    _API_ListCalendarGrants_Handler,
+		},
+		{
+			MethodName: "CreateGroup",
+			Handler:    _API_CreateGroup_Handler,
+		},
+		{
+			MethodName: "UpdateGroup",
+			Handler:    _API_UpdateGroup_Handler,
+		},
+		{
+			MethodName: "DeleteGroup",
+			Handler:    _API_DeleteGroup_Handler,
+		},
+		{
+			MethodName: "FindGroupByID",
+			Handler:    _API_FindGroupByID_Handler,
+		},
+		{
+			MethodName: "ListGroups",
+			Handler:    _API_ListGroups_Handler,
+		},
+		{
+			MethodName: "AddGroupMembers",
+			Handler:    _API_AddGroupMembers_Handler,
+		},
+		{
+			MethodName: "RemoveGroupMembers",
+			Handler:    _API_RemoveGroupMembers_Handler,
+		},
+		{
+			MethodName: "CreateResource",
+			Handler:    _API_CreateResource_Handler,
+		},
+		{
+			MethodName: "UpdateResource",
+			Handler:    _API_UpdateResource_Handler,
+		},
+		{
+			MethodName: "DeleteResource",
+			Handler:    _API_DeleteResource_Handler,
+		},
+		{
+			MethodName: "FindResourceByID",
+			Handler:    _API_FindResourceByID_Handler,
+		},
+		{
+			MethodName: "SearchResources",
+			Handler:    _API_SearchResources_Handler,
+		},
+		{
+			MethodName: "GetResourceFreeBusy",
+			Handler:    _API_GetResourceFreeBusy_Handler,
+		},
+		{
+			MethodName: "CreateBookingPage",
+			Handler:    _API_CreateBookingPage_Handler,
+		},
+		{
+			MethodName: "UpdateBookingPage",
+			Handler:    _API_UpdateBookingPage_Handler,
+		},
+		{
+			MethodName: "DeleteBookingPage",
+			Handler:    _API_DeleteBookingPage_Handler,
+		},
+		{
+			MethodName: "ListBookingPages",
+			Handler:    _API_ListBookingPages_Handler,
+		},
+		{
+			MethodName: "FindBookingPageByID",
+			Handler:    _API_FindBookingPageByID_Handler,
+		},
+		{
+			MethodName: "ListSlots",
+			Handler:    _API_ListSlots_Handler,
+		},
+		{
+			MethodName: "BookSlot",
+			Handler:    _API_BookSlot_Handler,
+		},
 		{
 			MethodName: "Check",
 			Handler:    _API_Check_Handler,
 		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _API_WatchEvents_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Watch",
 			Handler:       _API_Watch_Handler,
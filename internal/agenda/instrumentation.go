@@ -0,0 +1,33 @@
+package agenda
+
+import (
+	"context"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Instrumentation struct {
+	next   core.AgendaService
+	tracer trace.Tracer
+}
+
+func NewInstrumentation(next core.AgendaService) *Instrumentation {
+	return &Instrumentation{
+		next:   next,
+		tracer: otel.Tracer("agenda-service"),
+	}
+}
+
+func (i *Instrumentation) GetAgenda(ctx context.Context, req *core.GetAgendaRequest) (*core.Agenda, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "get-agenda")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	agenda, err := i.next.GetAgenda(ctx, req)
+	return agenda, err
+}
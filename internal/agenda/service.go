@@ -0,0 +1,55 @@
+package agenda
+
+import (
+	"context"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+type Service struct {
+	events      core.EventRepository
+	preferences core.NotificationPreferenceRepository
+}
+
+func NewService(events core.EventRepository, preferences core.NotificationPreferenceRepository) *Service {
+	return &Service{
+		events:      events,
+		preferences: preferences,
+	}
+}
+
+// GetAgenda expands the agenda of the actor in the timezone of their notification preference, so
+// that it shows the same day as the one they get in the morning.
+func (s *Service) GetAgenda(ctx context.Context, req *core.GetAgendaRequest) (*core.Agenda, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	preference, err := s.preferences.FindByUserID(ctx, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(preference.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	day := time.Now().In(loc)
+	if req.Date != "" {
+		day, err = time.ParseInLocation(core.DateLayout, req.Date, loc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// only the events with an occurrence on the day are loaded, the declined ones are left out
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+	events, err := s.events.ListInvolved(ctx, req.ActorID, from, from.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	return core.NewAgenda(req.ActorID, day, events), nil
+}
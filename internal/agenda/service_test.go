@@ -0,0 +1,204 @@
+package agenda_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/agenda"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// newEvent is created by user 1 and recurs daily at 10:00 UTC for an hour, user 2 confirmed and
+// user 3 declined.
+func newEvent(id string) core.Event {
+	return core.Event{
+		ID:        id,
+		Title:     "Standup " + id,
+		CreatedBy: "1",
+		Schedules: []core.Schedule{
+			{
+				ID:                "s" + id,
+				EventID:           id,
+				StartTime:         time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC).Unix(),
+				DurationInMinutes: 60,
+				RecurringType:     core.RecurringType_Daily,
+				RecurringInterval: int64(24 * time.Hour.Seconds()),
+			},
+		},
+		Invitations: []core.Invitation{
+			{ID: "i2", EventID: id, UserID: 2, Status: core.InvitationStatus_Confirmed},
+			{ID: "i3", EventID: id, UserID: 3, Status: core.InvitationStatus_Declined},
+		},
+	}
+}
+
+func TestService_GetAgenda(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	assert.NoError(t, err)
+
+	preference := func(userID string, timezone string) *core.NotificationPreference {
+		p := core.DefaultNotificationPreference(userID)
+		p.Timezone = timezone
+		return p
+	}
+
+	tests := []struct {
+		name      string
+		req       *core.GetAgendaRequest
+		repoMock  func(events *mock.MockEventRepository, preferences *mock.MockNotificationPreferenceRepository)
+		want      *core.Agenda
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "OK - the occurrence of the day in the timezone of the user",
+			req:  &core.GetAgendaRequest{ActorID: "1", Date: "2026-01-05"},
+			repoMock: func(events *mock.MockEventRepository, preferences *mock.MockNotificationPreferenceRepository) {
+				preferences.EXPECT().FindByUserID(gomock.Any(), "1").Return(preference("1", "Asia/Jakarta"), nil)
+				events.EXPECT().ListInvolved(gomock.Any(), "1", time.Date(2026, 1, 5, 0, 0, 0, 0, jakarta), time.Date(2026, 1, 6, 0, 0, 0, 0, jakarta)).
+					Return([]core.Event{newEvent("123")}, nil)
+			},
+			want: &core.Agenda{
+				UserID: "1",
+				Date:   time.Date(2026, 1, 5, 0, 0, 0, 0, jakarta),
+				Items: []core.AgendaItem{
+					{
+						EventID:   "123",
+						Title:     "Standup 123",
+						CreatedBy: "1",
+						Start:     time.Date(2026, 1, 5, 17, 0, 0, 0, jakarta),
+						End:       time.Date(2026, 1, 5, 18, 0, 0, 0, jakarta),
+						Status:    core.InvitationStatus_Unknown,
+					},
+				},
+			},
+		},
+		{
+			name: "OK - the occurrences are earliest first",
+			req:  &core.GetAgendaRequest{ActorID: "1", Date: "2026-01-05"},
+			repoMock: func(events *mock.MockEventRepository, preferences *mock.MockNotificationPreferenceRepository) {
+				later := newEvent("456")
+				later.Schedules[0].StartTime = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC).Unix()
+				preferences.EXPECT().FindByUserID(gomock.Any(), "1").Return(preference("1", "UTC"), nil)
+				events.EXPECT().ListInvolved(gomock.Any(), "1", gomock.Any(), gomock.Any()).Return([]core.Event{later, newEvent("123")}, nil)
+			},
+			want: &core.Agenda{
+				UserID: "1",
+				Date:   time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+				Items: []core.AgendaItem{
+					{
+						EventID:   "123",
+						Title:     "Standup 123",
+						CreatedBy: "1",
+						Start:     time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC),
+						End:       time.Date(2026, 1, 5, 11, 0, 0, 0, time.UTC),
+					},
+					{
+						EventID:   "456",
+						Title:     "Standup 456",
+						CreatedBy: "1",
+						Start:     time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC),
+						End:       time.Date(2026, 1, 5, 13, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		{
+			name: "OK - an invited user sees their response",
+			req:  &core.GetAgendaRequest{ActorID: "2", Date: "2026-01-05"},
+			repoMock: func(events *mock.MockEventRepository, preferences *mock.MockNotificationPreferenceRepository) {
+				preferences.EXPECT().FindByUserID(gomock.Any(), "2").Return(preference("2", "UTC"), nil)
+				events.EXPECT().ListInvolved(gomock.Any(), "2", gomock.Any(), gomock.Any()).Return([]core.Event{newEvent("123")}, nil)
+			},
+			want: &core.Agenda{
+				UserID: "2",
+				Date:   time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+				Items: []core.AgendaItem{
+					{
+						EventID:   "123",
+						Title:     "Standup 123",
+						CreatedBy: "1",
+						Start:     time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC),
+						End:       time.Date(2026, 1, 5, 11, 0, 0, 0, time.UTC),
+						Status:    core.InvitationStatus_Confirmed,
+					},
+				},
+			},
+		},
+		{
+			name: "OK - the events the user declined are left out",
+			req:  &core.GetAgendaRequest{ActorID: "3", Date: "2026-01-05"},
+			repoMock: func(events *mock.MockEventRepository, preferences *mock.MockNotificationPreferenceRepository) {
+				preferences.EXPECT().FindByUserID(gomock.Any(), "3").Return(preference("3", "UTC"), nil)
+				events.EXPECT().ListInvolved(gomock.Any(), "3", gomock.Any(), gomock.Any()).Return([]core.Event{newEvent("123")}, nil)
+			},
+			want: &core.Agenda{
+				UserID: "3",
+				Date:   time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+				Items:  []core.AgendaItem{},
+			},
+		},
+		{
+			name: "OK - nothing before the event starts",
+			req:  &core.GetAgendaRequest{ActorID: "1", Date: "2025-12-31"},
+			repoMock: func(events *mock.MockEventRepository, preferences *mock.MockNotificationPreferenceRepository) {
+				preferences.EXPECT().FindByUserID(gomock.Any(), "1").Return(preference("1", "UTC"), nil)
+				events.EXPECT().ListInvolved(gomock.Any(), "1", gomock.Any(), gomock.Any()).Return([]core.Event{newEvent("123")}, nil)
+			},
+			want: &core.Agenda{
+				UserID: "1",
+				Date:   time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+				Items:  []core.AgendaItem{},
+			},
+		},
+		{
+			name:      "Not OK - invalid date",
+			req:       &core.GetAgendaRequest{ActorID: "1", Date: "05/01/2026"},
+			repoMock:  func(events *mock.MockEventRepository, preferences *mock.MockNotificationPreferenceRepository) {},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name:      "Not OK - invalid actor id",
+			req:       &core.GetAgendaRequest{Date: "2026-01-05"},
+			repoMock:  func(events *mock.MockEventRepository, preferences *mock.MockNotificationPreferenceRepository) {},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - error listing the events",
+			req:  &core.GetAgendaRequest{ActorID: "1", Date: "2026-01-05"},
+			repoMock: func(events *mock.MockEventRepository, preferences *mock.MockNotificationPreferenceRepository) {
+				preferences.EXPECT().FindByUserID(gomock.Any(), "1").Return(preference("1", "UTC"), nil)
+				events.EXPECT().ListInvolved(gomock.Any(), "1", gomock.Any(), gomock.Any()).Return(nil, errors.New("error")) //nolint:goerr113
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			events := mock.NewMockEventRepository(ctrl)
+			preferences := mock.NewMockNotificationPreferenceRepository(ctrl)
+			tt.repoMock(events, preferences)
+
+			got, err := agenda.NewService(events, preferences).GetAgenda(context.Background(), tt.req)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
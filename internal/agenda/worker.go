@@ -0,0 +1,151 @@
+package agenda
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+const (
+	defaultWorkerInterval = time.Minute
+	defaultMorning        = "07:00"
+	maxAttempts           = 5
+	retryBackoff          = time.Minute
+
+	// lease must outlast a round: a delivery claimed by a worker that went away is claimed again
+	// once its lease expires
+	lease          = time.Minute
+	claimBatchSize = 100
+)
+
+// Worker sends the users their agenda of the day every morning, in their timezone.
+//
+// Every round logs a delivery per user and channel once the morning of the user has come, then
+// sends the due deliveries through the notifier. The deliveries are unique per user, day and
+// channel, so an agenda is sent once a day no matter how many workers run. An agenda with nothing
+// on it is skipped.
+type Worker struct {
+	agendas  core.AgendaRepository
+	svc      core.AgendaService
+	notifier core.Notifier
+	// morning is the time of the day the agendas are sent at, e.g: "07:00"
+	morning  string
+	interval time.Duration
+}
+
+func NewWorker(agendas core.AgendaRepository, svc core.AgendaService, notifier core.Notifier, morning string, interval time.Duration) *Worker {
+	if morning == "" {
+		morning = defaultMorning
+	}
+	if interval <= 0 {
+		interval = defaultWorkerInterval
+	}
+
+	return &Worker{
+		agendas:  agendas,
+		svc:      svc,
+		notifier: notifier,
+		morning:  morning,
+		interval: interval,
+	}
+}
+
+// Run logs and sends the due agendas on every interval, until ctx is done.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		logged, err := w.agendas.ScheduleDue(ctx, time.Now(), w.morning)
+		if err != nil && ctx.Err() == nil {
+			slog.Error(err.Error())
+		} else if logged > 0 {
+			slog.Debug("scheduled agendas", "count", logged)
+		}
+
+		sent, err := w.SendDue(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Error(err.Error())
+		} else if sent > 0 {
+			slog.Info("attempted agenda deliveries", "count", sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDue sends the deliveries that are due, and returns how many were attempted.
+func (w *Worker) SendDue(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	deliveries, err := w.agendas.ClaimDueDeliveries(ctx, now, now.Add(lease), claimBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for index := range deliveries {
+		err = w.Send(ctx, &deliveries[index])
+		if ctx.Err() != nil {
+			return index, ctx.Err()
+		}
+		if err != nil {
+			slog.Error("failed to send agenda", "delivery_id", deliveries[index].ID, "error", err.Error())
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// Send expands the agenda of the delivery and makes an attempt of it, then records its outcome. A
+// failed attempt is retried after a backoff that doubles on every attempt, the delivery fails once
+// it runs out of attempts.
+func (w *Worker) Send(ctx context.Context, delivery *core.AgendaDelivery) error {
+	agenda, err := w.svc.GetAgenda(ctx, &core.GetAgendaRequest{
+		ActorID: delivery.UserID,
+		Date:    delivery.Date.Format(core.DateLayout),
+	})
+	if err != nil {
+		// the delivery is claimed again once its lease expires
+		return err
+	}
+
+	if len(agenda.Items) == 0 {
+		delivery.Status = core.AgendaDeliveryStatus_Skipped
+		return w.agendas.UpdateDelivery(ctx, delivery)
+	}
+
+	err = w.notifier.Notify(ctx, &core.Notification{
+		ID:          strconv.FormatInt(delivery.ID, 10),
+		Channel:     delivery.Channel,
+		RecipientID: delivery.UserID,
+		Agenda:      agenda,
+	})
+	if ctx.Err() != nil {
+		// the worker is stopping, the delivery is claimed again once its lease expires
+		return ctx.Err()
+	}
+
+	now := time.Now()
+	delivery.Attempts++
+	switch {
+	case err == nil:
+		delivery.Status = core.AgendaDeliveryStatus_Sent
+		delivery.LastError = ""
+		delivery.SentAt = &now
+	case delivery.Attempts >= maxAttempts:
+		delivery.Status = core.AgendaDeliveryStatus_Failed
+		delivery.LastError = err.Error()
+	default:
+		delivery.Status = core.AgendaDeliveryStatus_Pending
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = now.Add(retryBackoff << (delivery.Attempts - 1))
+	}
+
+	return w.agendas.UpdateDelivery(ctx, delivery)
+}
@@ -0,0 +1,164 @@
+package agenda_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/agenda"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorker_Send(t *testing.T) {
+	date := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	fullAgenda := &core.Agenda{
+		UserID: "2",
+		Date:   date,
+		Items: []core.AgendaItem{
+			{EventID: "123", Title: "Standup", Start: date.Add(10 * time.Hour), End: date.Add(11 * time.Hour)},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		delivery     core.AgendaDelivery
+		svcMock      func(svc *mock.MockAgendaService)
+		notifierMock func(notifier *mock.MockNotifier)
+		wantUpdate   bool
+		wantStatus   core.AgendaDeliveryStatus
+		wantAttempts int
+		wantRetryIn  time.Duration
+		wantErr      bool
+	}{
+		{
+			name:     "OK - sent",
+			delivery: core.AgendaDelivery{ID: 1, UserID: "2", Date: date, Channel: core.ReminderChannel_Email},
+			svcMock: func(svc *mock.MockAgendaService) {
+				svc.EXPECT().GetAgenda(gomock.Any(), &core.GetAgendaRequest{ActorID: "2", Date: "2026-01-05"}).Return(fullAgenda, nil)
+			},
+			notifierMock: func(notifier *mock.MockNotifier) {
+				notifier.EXPECT().Notify(gomock.Any(), &core.Notification{
+					ID:          "1",
+					Channel:     core.ReminderChannel_Email,
+					RecipientID: "2",
+					Agenda:      fullAgenda,
+				}).Return(nil)
+			},
+			wantUpdate:   true,
+			wantStatus:   core.AgendaDeliveryStatus_Sent,
+			wantAttempts: 1,
+		},
+		{
+			name:     "OK - skipped when there's nothing on the agenda",
+			delivery: core.AgendaDelivery{ID: 1, UserID: "2", Date: date, Channel: core.ReminderChannel_Email},
+			svcMock: func(svc *mock.MockAgendaService) {
+				svc.EXPECT().GetAgenda(gomock.Any(), gomock.Any()).Return(&core.Agenda{UserID: "2", Date: date, Items: []core.AgendaItem{}}, nil)
+			},
+			notifierMock: func(notifier *mock.MockNotifier) {},
+			wantUpdate:   true,
+			wantStatus:   core.AgendaDeliveryStatus_Skipped,
+		},
+		{
+			name:     "OK - retried later with a backoff",
+			delivery: core.AgendaDelivery{ID: 1, UserID: "2", Date: date, Channel: core.ReminderChannel_Email, Attempts: 1},
+			svcMock: func(svc *mock.MockAgendaService) {
+				svc.EXPECT().GetAgenda(gomock.Any(), gomock.Any()).Return(fullAgenda, nil)
+			},
+			notifierMock: func(notifier *mock.MockNotifier) {
+				notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(errors.New("mail server is down")) //nolint:goerr113
+			},
+			wantUpdate:   true,
+			wantStatus:   core.AgendaDeliveryStatus_Pending,
+			wantAttempts: 2,
+			wantRetryIn:  2 * time.Minute,
+		},
+		{
+			name:     "OK - failed after the last attempt",
+			delivery: core.AgendaDelivery{ID: 1, UserID: "2", Date: date, Channel: core.ReminderChannel_Email, Attempts: 4},
+			svcMock: func(svc *mock.MockAgendaService) {
+				svc.EXPECT().GetAgenda(gomock.Any(), gomock.Any()).Return(fullAgenda, nil)
+			},
+			notifierMock: func(notifier *mock.MockNotifier) {
+				notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(errors.New("mail server is down")) //nolint:goerr113
+			},
+			wantUpdate:   true,
+			wantStatus:   core.AgendaDeliveryStatus_Failed,
+			wantAttempts: 5,
+		},
+		{
+			name:     "Not OK - error expanding the agenda",
+			delivery: core.AgendaDelivery{ID: 1, UserID: "2", Date: date, Channel: core.ReminderChannel_Email},
+			svcMock: func(svc *mock.MockAgendaService) {
+				svc.EXPECT().GetAgenda(gomock.Any(), gomock.Any()).Return(nil, errors.New("error")) //nolint:goerr113
+			},
+			notifierMock: func(notifier *mock.MockNotifier) {},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := mock.NewMockAgendaService(ctrl)
+			tt.svcMock(svc)
+			notifier := mock.NewMockNotifier(ctrl)
+			tt.notifierMock(notifier)
+
+			agendas := mock.NewMockAgendaRepository(ctrl)
+			if tt.wantUpdate {
+				agendas.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, delivery *core.AgendaDelivery) error {
+						assert.Equal(t, tt.wantStatus, delivery.Status)
+						assert.Equal(t, tt.wantAttempts, delivery.Attempts)
+						if tt.wantRetryIn > 0 {
+							assert.WithinDuration(t, time.Now().Add(tt.wantRetryIn), delivery.NextAttemptAt, time.Second)
+						}
+						switch tt.wantStatus {
+						case core.AgendaDeliveryStatus_Sent:
+							assert.NotNil(t, delivery.SentAt)
+						case core.AgendaDeliveryStatus_Skipped:
+							assert.Nil(t, delivery.SentAt)
+						default:
+							assert.NotEmpty(t, delivery.LastError)
+						}
+						return nil
+					})
+			}
+
+			w := agenda.NewWorker(agendas, svc, notifier, "07:00", time.Second)
+			err := w.Send(context.Background(), &tt.delivery)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWorker_SendDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	date := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	agendas := mock.NewMockAgendaRepository(ctrl)
+	agendas.EXPECT().ClaimDueDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]core.AgendaDelivery{
+		{ID: 1, UserID: "1", Date: date, Channel: core.ReminderChannel_Email},
+		{ID: 2, UserID: "2", Date: date, Channel: core.ReminderChannel_Email},
+	}, nil)
+	// a delivery that fails is claimed again once its lease expires, the others are still sent
+	agendas.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).Return(nil)
+
+	svc := mock.NewMockAgendaService(ctrl)
+	svc.EXPECT().GetAgenda(gomock.Any(), &core.GetAgendaRequest{ActorID: "1", Date: "2026-01-05"}).Return(nil, errors.New("error")) //nolint:goerr113
+	svc.EXPECT().GetAgenda(gomock.Any(), &core.GetAgendaRequest{ActorID: "2", Date: "2026-01-05"}).Return(&core.Agenda{UserID: "2", Date: date}, nil)
+
+	got, err := agenda.NewWorker(agendas, svc, mock.NewMockNotifier(ctrl), "07:00", time.Second).SendDue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, got)
+}
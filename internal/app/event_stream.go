@@ -0,0 +1,126 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	v1 "github.com/dzakaammar/event-scheduling-example/gen/go/proto/v1"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+)
+
+const sseHeartbeatInterval = 15 * time.Second
+
+// eventStreamHandler relays WatchEvents as server-sent events. Each change is sent with its cursor
+// as the event id, so that a reconnecting EventSource resumes after it through Last-Event-ID.
+// Browsers can't set headers on an EventSource, hence the authorization may also be given as
+// the access_token query parameter. The streams end when shutdown is done.
+func eventStreamHandler(client v1.APIClient, mux *runtime.ServeMux, heartbeat time.Duration, shutdown context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, marshaler := runtime.MarshalerForRequest(mux, r)
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		stop := context.AfterFunc(shutdown, cancel)
+		defer stop()
+
+		authorization := r.Header.Get("Authorization")
+		if authorization == "" {
+			authorization = r.URL.Query().Get("access_token")
+		}
+		if authorization != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "Authorization", authorization)
+		}
+
+		cursor := r.Header.Get("Last-Event-ID")
+		if cursor == "" {
+			cursor = r.URL.Query().Get("cursor")
+		}
+
+		stream, err := client.WatchEvents(ctx, &v1.WatchEventsRequest{Cursor: cursor})
+		if err == nil {
+			err = waitForWatch(stream)
+		}
+		if err != nil {
+			runtime.HTTPError(ctx, mux, marshaler, w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		changes := make(chan *v1.EventChange)
+		errs := make(chan error, 1)
+		go func() {
+			for {
+				change, err := stream.Recv()
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				select {
+				case changes <- change:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// comments are ignored by EventSource, they only keep the connection alive
+				_, err = fmt.Fprint(w, ": heartbeat\n\n")
+			case change := <-changes:
+				var data []byte
+				data, err = marshaler.Marshal(change)
+				if err == nil {
+					_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", change.GetCursor(), change.GetOperation(), data)
+				}
+			case err := <-errs:
+				// the EventSource reconnects on its own from the last event id
+				_, _ = fmt.Fprintf(w, "event: error\ndata: %q\n\n", err.Error())
+				flusher.Flush()
+				return
+			}
+
+			if err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// waitForWatch waits until the backend has established the watch, it sends the headers then.
+// When the watch fails instead, there are no headers and the error is returned by Recv.
+func waitForWatch(stream v1.API_WatchEventsClient) error {
+	md, err := stream.Header()
+	if err != nil {
+		return err
+	}
+
+	if md == nil {
+		_, err = stream.Recv()
+		return err
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/fs"
 	"net/http"
@@ -13,12 +14,15 @@ import (
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	_ "google.golang.org/genproto/googleapis/rpc/errdetails" // registers the error detail types rendered in error bodies
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type GRPCGatewayServer struct {
-	srv *http.Server
+	srv  *http.Server
+	conn *grpc.ClientConn
 }
 
 func NewGRPCGatewayServer(grpcTarget string, swagger fs.FS, openAPIYAMLFile []byte) (*GRPCGatewayServer, error) {
@@ -27,6 +31,17 @@ func NewGRPCGatewayServer(grpcTarget string, swagger fs.FS, openAPIYAMLFile []by
 		return nil, err
 	}
 
+	// the routes served by the gateway itself share one connection to the grpc server
+	conn, err := grpc.NewClient(grpcTarget,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	healthClient := healthpb.NewHealthClient(conn)
+	streams, endStreams := context.WithCancel(context.Background())
+
 	r := chi.NewRouter()
 	r.Use(cors.AllowAll().Handler)
 
@@ -35,6 +50,12 @@ func NewGRPCGatewayServer(grpcTarget string, swagger fs.FS, openAPIYAMLFile []by
 		api.Mount("/api", gatewayHandler)
 	})
 
+	// not traced, a span lasting as long as the stream isn't useful
+	r.Get("/api/v1/events/stream", eventStreamHandler(v1.NewAPIClient(conn), gatewayHandler, sseHeartbeatInterval, streams))
+
+	r.Get("/healthz", healthHandler(healthClient, false))
+	r.Get("/readyz", healthHandler(healthClient, true))
+
 	r.Handle("/static/*", http.StripPrefix("/static/", http.FileServer(http.FS(swagger))))
 	r.Get("/openapiv2.yaml", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/x-yaml")
@@ -42,11 +63,16 @@ func NewGRPCGatewayServer(grpcTarget string, swagger fs.FS, openAPIYAMLFile []by
 		_, _ = w.Write(openAPIYAMLFile)
 	})
 
+	srv := &http.Server{
+		Handler:           r,
+		ReadHeaderTimeout: 200 * time.Millisecond,
+	}
+	// the streams never become idle, end them so that shutting down doesn't wait for the clients
+	srv.RegisterOnShutdown(endStreams)
+
 	return &GRPCGatewayServer{
-		srv: &http.Server{
-			Handler:           r,
-			ReadHeaderTimeout: 200 * time.Millisecond,
-		},
+		srv:  srv,
+		conn: conn,
 	}, nil
 }
 
@@ -57,11 +83,54 @@ func (g *GRPCGatewayServer) Start(address string) error {
 }
 
 func (g *GRPCGatewayServer) Stop(ctx context.Context) error {
+	defer func() {
+		_ = g.conn.Close()
+	}()
 	return g.srv.Shutdown(ctx)
 }
 
-func grpcGatewayHandler(grpcServerTarget string) (http.Handler, error) {
-	handler := runtime.NewServeMux()
+const healthCheckTimeout = time.Second
+
+// healthHandler reports the health of the grpc server. The gateway is alive as long as the grpc server
+// answers, it is ready only when the grpc server is serving.
+func healthHandler(client healthpb.HealthClient, readiness bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+		defer cancel()
+
+		statusCode := http.StatusOK
+		servingStatus := healthpb.HealthCheckResponse_UNKNOWN
+
+		res, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+		if err != nil {
+			statusCode = http.StatusServiceUnavailable
+		} else {
+			servingStatus = res.GetStatus()
+			if readiness && servingStatus != healthpb.HealthCheckResponse_SERVING {
+				statusCode = http.StatusServiceUnavailable
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"status": servingStatus.String(),
+		})
+	}
+}
+
+// incomingHeaderMatcher forwards the Idempotency-Key header as is, on top of the headers forwarded by default.
+func incomingHeaderMatcher(key string) (string, bool) {
+	if http.CanonicalHeaderKey(key) == "Idempotency-Key" {
+		return key, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+func grpcGatewayHandler(grpcServerTarget string) (*runtime.ServeMux, error) {
+	handler := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+	)
 
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
@@ -0,0 +1,825 @@
+package app_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	v1 "github.com/dzakaammar/event-scheduling-example/gen/go/proto/v1"
+	"github.com/dzakaammar/event-scheduling-example/internal/agenda"
+	"github.com/dzakaammar/event-scheduling-example/internal/app"
+	"github.com/dzakaammar/event-scheduling-example/internal/changefeed"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/health"
+	"github.com/dzakaammar/event-scheduling-example/internal/mock"
+	"github.com/dzakaammar/event-scheduling-example/internal/notification"
+	"github.com/dzakaammar/event-scheduling-example/internal/scheduling"
+	"github.com/dzakaammar/event-scheduling-example/internal/webhook"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+type errorBody struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Details []struct {
+		Type            string `json:"@type"`
+		FieldViolations []struct {
+			Field       string `json:"field"`
+			Description string `json:"description"`
+		} `json:"fieldViolations"`
+	} `json:"details"`
+}
+
+func freeAddress(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+	return lis.Addr().String()
+}
+
+func startServers(t *testing.T, svc core.SchedulingService) string {
+	t.Helper()
+	return startServersWithHealth(t, svc, health.NewMonitor(nil, 0))
+}
+
+func startServersWithHealth(t *testing.T, svc core.SchedulingService, healthMonitor *health.Monitor) string {
+	t.Helper()
+	return startAPIServers(t, svc, nil, nil, nil, nil, nil, nil, nil, healthMonitor)
+}
+
+func startAPIServers(t *testing.T, svc core.SchedulingService, webhookSvc core.WebhookService, preferenceSvc core.NotificationPreferenceService, agendaSvc core.AgendaService, calendarSvc core.CalendarService, groupSvc core.GroupService, resourceSvc core.ResourceService, bookingSvc core.BookingService, healthMonitor *health.Monitor) string {
+	t.Helper()
+
+	grpcAddress := freeAddress(t)
+	grpcServer := app.NewGRPCServer(svc, webhookSvc, preferenceSvc, agendaSvc, calendarSvc, groupSvc, resourceSvc, bookingSvc, healthMonitor)
+	go func() {
+		_ = grpcServer.Start(grpcAddress)
+	}()
+
+	gatewayAddress := freeAddress(t)
+	gatewayServer, err := app.NewGRPCGatewayServer(grpcAddress, fstest.MapFS{}, nil)
+	require.NoError(t, err)
+	go func() {
+		_ = gatewayServer.Start(gatewayAddress)
+	}()
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = gatewayServer.Stop(ctx)
+		_ = grpcServer.Stop(ctx)
+	})
+
+	baseURL := "http://" + gatewayAddress
+	require.Eventually(t, func() bool {
+		res, err := http.Get(baseURL + "/openapiv2.yaml")
+		if err != nil {
+			return false
+		}
+		_ = res.Body.Close()
+		return true
+	}, 2*time.Second, 20*time.Millisecond)
+
+	return baseURL
+}
+
+func TestGRPCGatewayServer_ErrorBody(t *testing.T) {
+	const validEvent = `{
+		"title": "test",
+		"description": "test description",
+		"timezone": "Asia/Jakarta",
+		"schedule": [{"start_time": "2022-01-01T00:00:00+07:00", "end_time": "2022-01-01T01:00:00+07:00"}]
+	}`
+
+	tests := []struct {
+		name           string
+		repoMock       func(repo *mock.MockEventRepository)
+		method         string
+		path           string
+		body           string
+		wantStatusCode int
+		wantFields     []string
+	}{
+		{
+			name:           "validator errors are reported as field violations",
+			repoMock:       func(repo *mock.MockEventRepository) {},
+			method:         http.MethodPost,
+			path:           "/api/v1/events",
+			body:           strings.Replace(validEvent, `"title": "test"`, `"title": ""`, 1),
+			wantStatusCode: http.StatusBadRequest,
+			wantFields:     []string{"event.title"},
+		},
+		{
+			name:           "schedule parsing errors point at the schedule field",
+			repoMock:       func(repo *mock.MockEventRepository) {},
+			method:         http.MethodPost,
+			path:           "/api/v1/events",
+			body:           strings.Replace(validEvent, `"end_time": "2022-01-01T01:00:00+07:00"`, `"end_time": "invalid"`, 1),
+			wantStatusCode: http.StatusBadRequest,
+			wantFields:     []string{"event.schedule[0].end_time"},
+		},
+		{
+			name:           "invalid timezone points at the timezone field",
+			repoMock:       func(repo *mock.MockEventRepository) {},
+			method:         http.MethodPost,
+			path:           "/api/v1/events",
+			body:           strings.Replace(validEvent, `"timezone": "Asia/Jakarta"`, `"timezone": "invalid"`, 1),
+			wantStatusCode: http.StatusBadRequest,
+			wantFields:     []string{"event.timezone"},
+		},
+		{
+			name:           "invalid client supplied id points at the event id field",
+			repoMock:       func(repo *mock.MockEventRepository) {},
+			method:         http.MethodPost,
+			path:           "/api/v1/events?event_id=-invalid",
+			body:           validEvent,
+			wantStatusCode: http.StatusBadRequest,
+			wantFields:     []string{"event_id"},
+		},
+		{
+			name: "taken client supplied id is reported as already exists",
+			repoMock: func(repo *mock.MockEventRepository) {
+				repo.EXPECT().Store(gomock.Any(), gomock.Any(), gomock.Any()).Return(core.ErrEventAlreadyExists)
+			},
+			method:         http.MethodPost,
+			path:           "/api/v1/events?event_id=taken",
+			body:           validEvent,
+			wantStatusCode: http.StatusConflict,
+		},
+		{
+			name:           "malformed page token points at the page token field",
+			repoMock:       func(repo *mock.MockEventRepository) {},
+			method:         http.MethodGet,
+			path:           "/api/v1/events?page_token=%25%25",
+			wantStatusCode: http.StatusBadRequest,
+			wantFields:     []string{"page_token"},
+		},
+		{
+			name:           "invalid timezone filter points at the timezone field",
+			repoMock:       func(repo *mock.MockEventRepository) {},
+			method:         http.MethodGet,
+			path:           "/api/v1/events?timezone=invalid",
+			wantStatusCode: http.StatusBadRequest,
+			wantFields:     []string{"timezone"},
+		},
+		{
+			name:           "empty search query points at the query field",
+			repoMock:       func(repo *mock.MockEventRepository) {},
+			method:         http.MethodGet,
+			path:           "/api/v1/events:search?query=%20",
+			wantStatusCode: http.StatusBadRequest,
+			wantFields:     []string{"query"},
+		},
+		{
+			name:           "empty batch points at the mutations field",
+			repoMock:       func(repo *mock.MockEventRepository) {},
+			method:         http.MethodPost,
+			path:           "/api/v1/events:batchMutate",
+			body:           `{"mutations": [], "atomic": true}`,
+			wantStatusCode: http.StatusBadRequest,
+			wantFields:     []string{"mutations"},
+		},
+		{
+			name: "missing event is reported as not found",
+			repoMock: func(repo *mock.MockEventRepository) {
+				repo.EXPECT().FindByID(gomock.Any(), "123").Return(nil, core.ErrEventNotFound)
+			},
+			method:         http.MethodGet,
+			path:           "/api/v1/events/123",
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockEventRepository(ctrl)
+			tt.repoMock(repo)
+			baseURL := startServers(t, scheduling.NewService(repo, mock.NewMockEventHistoryRepository(ctrl), mock.NewMockCalendarRepository(ctrl), mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0)))
+
+			req, err := http.NewRequest(tt.method, baseURL+tt.path, strings.NewReader(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "1")
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			var body errorBody
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+
+			var gotFields []string
+			for _, detail := range body.Details {
+				assert.Equal(t, "type.googleapis.com/google.rpc.BadRequest", detail.Type)
+				for _, violation := range detail.FieldViolations {
+					gotFields = append(gotFields, violation.Field)
+				}
+			}
+			for _, field := range tt.wantFields {
+				assert.Contains(t, gotFields, field)
+			}
+		})
+	}
+}
+
+func TestGRPCGatewayServer_IdempotencyKeyHeader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mock.NewMockSchedulingService(ctrl)
+	svc.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, req *core.CreateEventRequest) error {
+			assert.Equal(t, "retry-123", req.IdempotencyKey)
+			return nil
+		})
+	baseURL := startServers(t, svc)
+
+	body := `{
+		"title": "test",
+		"timezone": "Asia/Jakarta",
+		"schedule": [{"start_time": "2022-01-01T00:00:00+07:00", "end_time": "2022-01-01T01:00:00+07:00"}]
+	}`
+	req, err := http.NewRequest(http.MethodPost, baseURL+"/api/v1/events", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "1")
+	req.Header.Set("Idempotency-Key", "retry-123")
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestGRPCGatewayServer_Webhooks(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name           string
+		repoMock       func(repo *mock.MockWebhookRepository)
+		method         string
+		path           string
+		body           string
+		wantStatusCode int
+		wantFields     []string
+		wantBody       string
+	}{
+		{
+			name:           "invalid url points at the url field",
+			repoMock:       func(repo *mock.MockWebhookRepository) {},
+			method:         http.MethodPost,
+			path:           "/api/v1/webhooks",
+			body:           `{"url": "ftp://example.com", "secret": "0123456789abcdef", "event_types": ["CREATE"]}`,
+			wantStatusCode: http.StatusBadRequest,
+			wantFields:     []string{"webhook.url"},
+		},
+		{
+			name: "webhook of another user is reported as not found",
+			repoMock: func(repo *mock.MockWebhookRepository) {
+				repo.EXPECT().FindByID(gomock.Any(), "abc").Return(&core.Webhook{ID: "abc", OwnerID: "2"}, nil)
+			},
+			method:         http.MethodGet,
+			path:           "/api/v1/webhooks/abc",
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "deliveries are listed without the secret of the webhook",
+			repoMock: func(repo *mock.MockWebhookRepository) {
+				repo.EXPECT().FindByID(gomock.Any(), "abc").Return(&core.Webhook{ID: "abc", OwnerID: "1", Secret: "0123456789abcdef"}, nil)
+				repo.EXPECT().FindDeliveries(gomock.Any(), "abc", int64(0), core.DefaultPageSize+1).Return([]core.WebhookDelivery{
+					{
+						ID:             7,
+						WebhookID:      "abc",
+						EventID:        "123",
+						EventType:      core.HistoryOperation_Create,
+						Status:         core.WebhookDeliveryStatus_Failed,
+						Attempts:       8,
+						ResponseStatus: http.StatusInternalServerError,
+						CreatedAt:      now,
+					},
+				}, nil)
+			},
+			method:         http.MethodGet,
+			path:           "/api/v1/webhooks/abc/deliveries",
+			wantStatusCode: http.StatusOK,
+			wantBody:       `"status":"DELIVERY_FAILED"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockWebhookRepository(ctrl)
+			tt.repoMock(repo)
+			baseURL := startAPIServers(t, mock.NewMockSchedulingService(ctrl), webhook.NewService(repo), nil, nil, nil, nil, nil, nil, health.NewMonitor(nil, 0))
+
+			req, err := http.NewRequest(tt.method, baseURL+tt.path, strings.NewReader(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "1")
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			raw, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.NotContains(t, string(raw), "0123456789abcdef")
+			if tt.wantBody != "" {
+				assert.Contains(t, string(raw), tt.wantBody)
+			}
+
+			var body errorBody
+			require.NoError(t, json.Unmarshal(raw, &body))
+
+			var gotFields []string
+			for _, detail := range body.Details {
+				for _, violation := range detail.FieldViolations {
+					gotFields = append(gotFields, violation.Field)
+				}
+			}
+			for _, field := range tt.wantFields {
+				assert.Contains(t, gotFields, field)
+			}
+		})
+	}
+}
+
+func TestGRPCGatewayServer_NotificationPreference(t *testing.T) {
+	tests := []struct {
+		name           string
+		repoMock       func(repo *mock.MockNotificationPreferenceRepository)
+		eventRepoMock  func(repo *mock.MockEventRepository)
+		method         string
+		path           string
+		body           string
+		wantStatusCode int
+		wantFields     []string
+		wantBody       string
+	}{
+		{
+			name: "the default preference is returned to a user who hasn't set one",
+			repoMock: func(repo *mock.MockNotificationPreferenceRepository) {
+				repo.EXPECT().FindByUserID(gomock.Any(), "1").Return(core.DefaultNotificationPreference("1"), nil)
+			},
+			method:         http.MethodGet,
+			path:           "/api/v1/me/notification-preference",
+			wantStatusCode: http.StatusOK,
+			wantBody:       `"delivery":"IMMEDIATE"`,
+		},
+		{
+			name: "the preference is replaced",
+			repoMock: func(repo *mock.MockNotificationPreferenceRepository) {
+				repo.EXPECT().Upsert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, p *core.NotificationPreference) error {
+					assert.Equal(t, "1", p.UserID)
+					assert.Equal(t, []core.ReminderChannel{core.ReminderChannel_Email}, p.Channels)
+					assert.Equal(t, core.NotificationDelivery_Digest, p.Delivery)
+					assert.Equal(t, "22:00", p.QuietHoursStart)
+					return nil
+				})
+			},
+			method:         http.MethodPut,
+			path:           "/api/v1/me/notification-preference",
+			body:           `{"channels": ["EMAIL"], "timezone": "Asia/Jakarta", "quiet_hours_start": "22:00", "quiet_hours_end": "07:00", "delivery": "DIGEST", "digest_time": "08:00"}`,
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "invalid quiet hours point at their field",
+			method:         http.MethodPut,
+			path:           "/api/v1/me/notification-preference",
+			body:           `{"channels": ["EMAIL"], "timezone": "Asia/Jakarta", "quiet_hours_start": "late", "quiet_hours_end": "07:00", "delivery": "IMMEDIATE", "digest_time": "08:00"}`,
+			wantStatusCode: http.StatusBadRequest,
+			wantFields:     []string{"preference.quiet_hours_start"},
+		},
+		{
+			name: "an event the user isn't involved in can't be muted",
+			eventRepoMock: func(repo *mock.MockEventRepository) {
+				repo.EXPECT().FindByID(gomock.Any(), "123").Return(&core.Event{ID: "123", CreatedBy: "2"}, nil)
+			},
+			method:         http.MethodPut,
+			path:           "/api/v1/events/123/mute",
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "the event is unmuted",
+			repoMock: func(repo *mock.MockNotificationPreferenceRepository) {
+				repo.EXPECT().UnmuteEvent(gomock.Any(), "1", "123").Return(nil)
+			},
+			method:         http.MethodDelete,
+			path:           "/api/v1/events/123/mute",
+			wantStatusCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockNotificationPreferenceRepository(ctrl)
+			if tt.repoMock != nil {
+				tt.repoMock(repo)
+			}
+			eventRepo := mock.NewMockEventRepository(ctrl)
+			if tt.eventRepoMock != nil {
+				tt.eventRepoMock(eventRepo)
+			}
+			baseURL := startAPIServers(t, mock.NewMockSchedulingService(ctrl), nil, notification.NewService(repo, eventRepo), nil, nil, nil, nil, nil, health.NewMonitor(nil, 0))
+
+			req, err := http.NewRequest(tt.method, baseURL+tt.path, strings.NewReader(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "1")
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			raw, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			if tt.wantBody != "" {
+				assert.Contains(t, string(raw), tt.wantBody)
+			}
+
+			var body errorBody
+			require.NoError(t, json.Unmarshal(raw, &body))
+
+			var gotFields []string
+			for _, detail := range body.Details {
+				for _, violation := range detail.FieldViolations {
+					gotFields = append(gotFields, violation.Field)
+				}
+			}
+			for _, field := range tt.wantFields {
+				assert.Contains(t, gotFields, field)
+			}
+		})
+	}
+}
+
+func TestGRPCGatewayServer_Agenda(t *testing.T) {
+	tests := []struct {
+		name           string
+		repoMock       func(events *mock.MockEventRepository, preferences *mock.MockNotificationPreferenceRepository)
+		path           string
+		wantStatusCode int
+		wantBody       []string
+		wantFields     []string
+	}{
+		{
+			name: "the agenda of the day in the timezone of the user",
+			repoMock: func(events *mock.MockEventRepository, preferences *mock.MockNotificationPreferenceRepository) {
+				preference := core.DefaultNotificationPreference("1")
+				preference.Timezone = "Asia/Jakarta"
+				preferences.EXPECT().FindByUserID(gomock.Any(), "1").Return(preference, nil)
+				events.EXPECT().ListInvolved(gomock.Any(), "1", gomock.Any(), gomock.Any()).Return([]core.Event{
+					{
+						ID:        "123",
+						Title:     "Standup",
+						CreatedBy: "1",
+						Schedules: []core.Schedule{
+							{ID: "s1", EventID: "123", StartTime: time.Date(2026, 1, 5, 3, 0, 0, 0, time.UTC).Unix(), DurationInMinutes: 30},
+						},
+					},
+				}, nil)
+			},
+			path:           "/api/v1/me/agenda?date=2026-01-05",
+			wantStatusCode: http.StatusOK,
+			wantBody: []string{
+				`"date":"2026-01-05"`,
+				`"timezone":"Asia/Jakarta"`,
+				`"startTime":"2026-01-05T10:00:00+07:00"`,
+				`"endTime":"2026-01-05T10:30:00+07:00"`,
+			},
+		},
+		{
+			name:           "an invalid date points at its field",
+			path:           "/api/v1/me/agenda?date=tomorrow",
+			wantStatusCode: http.StatusBadRequest,
+			wantFields:     []string{"date"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			events := mock.NewMockEventRepository(ctrl)
+			preferences := mock.NewMockNotificationPreferenceRepository(ctrl)
+			if tt.repoMock != nil {
+				tt.repoMock(events, preferences)
+			}
+			baseURL := startAPIServers(t, mock.NewMockSchedulingService(ctrl), nil, nil, agenda.NewService(events, preferences), nil, nil, nil, nil, health.NewMonitor(nil, 0))
+
+			req, err := http.NewRequest(http.MethodGet, baseURL+tt.path, nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "1")
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+
+			raw, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			for _, want := range tt.wantBody {
+				assert.Contains(t, string(raw), want)
+			}
+
+			var body errorBody
+			require.NoError(t, json.Unmarshal(raw, &body))
+
+			var gotFields []string
+			for _, detail := range body.Details {
+				for _, violation := range detail.FieldViolations {
+					gotFields = append(gotFields, violation.Field)
+				}
+			}
+			for _, field := range tt.wantFields {
+				assert.Contains(t, gotFields, field)
+			}
+		})
+	}
+}
+
+func TestGRPCGatewayServer_Health(t *testing.T) {
+	tests := []struct {
+		name            string
+		probeErr        error
+		wantHealthzCode int
+		wantReadyzCode  int
+		wantStatus      string
+	}{
+		{
+			name:            "backend is serving",
+			wantHealthzCode: http.StatusOK,
+			wantReadyzCode:  http.StatusOK,
+			wantStatus:      "SERVING",
+		},
+		{
+			name:            "backend dependency is down",
+			probeErr:        errors.New("database is unreachable"), //nolint:goerr113
+			wantHealthzCode: http.StatusOK,
+			wantReadyzCode:  http.StatusServiceUnavailable,
+			wantStatus:      "NOT_SERVING",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			healthMonitor := health.NewMonitor(map[string]health.Probe{
+				"db": health.ProbeFunc(func(context.Context) error { return tt.probeErr }),
+			}, time.Hour)
+			healthMonitor.Check(context.Background())
+			baseURL := startServersWithHealth(t, mock.NewMockSchedulingService(ctrl), healthMonitor)
+
+			for path, wantCode := range map[string]int{"/healthz": tt.wantHealthzCode, "/readyz": tt.wantReadyzCode} {
+				res, err := http.Get(baseURL + path)
+				require.NoError(t, err)
+
+				var body struct {
+					Status string `json:"status"`
+				}
+				require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+				_ = res.Body.Close()
+
+				assert.Equal(t, wantCode, res.StatusCode, path)
+				assert.Equal(t, tt.wantStatus, body.Status, path)
+			}
+		})
+	}
+}
+
+func TestGRPCServer_HealthWatch(t *testing.T) {
+	var probeErr error
+	healthMonitor := health.NewMonitor(map[string]health.Probe{
+		"db": health.ProbeFunc(func(context.Context) error { return probeErr }),
+	}, time.Hour)
+	healthMonitor.Check(context.Background())
+
+	grpcAddress := freeAddress(t)
+	grpcServer := app.NewGRPCServer(mock.NewMockSchedulingService(gomock.NewController(t)), nil, nil, nil, nil, nil, nil, nil, healthMonitor)
+	go func() {
+		_ = grpcServer.Start(grpcAddress)
+	}()
+
+	conn, err := grpc.NewClient(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	stream, err := healthpb.NewHealthClient(conn).Watch(ctx, &healthpb.HealthCheckRequest{}, grpc.WaitForReady(true))
+	require.NoError(t, err)
+
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.GetStatus())
+
+	probeErr = errors.New("database is unreachable") //nolint:goerr113
+	healthMonitor.Check(context.Background())
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, res.GetStatus())
+
+	// stopping ends the watch instead of waiting for the client to go away
+	require.NoError(t, grpcServer.Stop(ctx))
+	_, err = stream.Recv()
+	assert.Error(t, err)
+}
+
+func TestGRPCServer_WatchEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockEventRepository(ctrl)
+	repo.EXPECT().Store(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	grpcAddress := freeAddress(t)
+	grpcServer := app.NewGRPCServer(scheduling.NewService(repo, mock.NewMockEventHistoryRepository(ctrl), mock.NewMockCalendarRepository(ctrl), mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0)), nil, nil, nil, nil, nil, nil, nil, health.NewMonitor(nil, 0))
+	go func() {
+		_ = grpcServer.Start(grpcAddress)
+	}()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = grpcServer.Stop(ctx)
+	})
+
+	conn, err := grpc.NewClient(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := v1.NewAPIClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	watchCtx := metadata.AppendToOutgoingContext(ctx, "Authorization", "2")
+	stream, err := client.WatchEvents(watchCtx, &v1.WatchEventsRequest{}, grpc.WaitForReady(true))
+	require.NoError(t, err)
+
+	// the headers are sent once the watch is established
+	_, err = stream.Header()
+	require.NoError(t, err)
+
+	created, err := client.CreateEvent(metadata.AppendToOutgoingContext(ctx, "Authorization", "1"), &v1.CreateEventRequest{
+		Event: &v1.Event{
+			Title:       "test",
+			Description: "test description",
+			Timezone:    "Asia/Jakarta",
+			Attendees:   []int32{2},
+			Schedule: []*v1.Schedule{
+				{StartTime: "2022-01-01T00:00:00+07:00", EndTime: "2022-01-01T01:00:00+07:00"},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	change, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, created.GetId(), change.GetEventId())
+	assert.Equal(t, v1.HistoryOperation_CREATE, change.GetOperation())
+	assert.Equal(t, "test", change.GetEvent().GetTitle())
+	assert.NotEmpty(t, change.GetCursor())
+}
+
+// readServerSentEvent reads the fields of the next event, skipping the comments.
+func readServerSentEvent(t *testing.T, reader *bufio.Reader) map[string]string {
+	t.Helper()
+
+	fields := make(map[string]string)
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "" && len(fields) > 0:
+			return fields
+		case line == "" || strings.HasPrefix(line, ":"):
+			continue
+		}
+
+		name, value, _ := strings.Cut(line, ": ")
+		fields[name] = value
+	}
+}
+
+func TestGRPCGatewayServer_EventStream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockEventRepository(ctrl)
+	repo.EXPECT().DeleteByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+	repo.EXPECT().FindByID(gomock.Any(), gomock.Any()).Times(2).
+		DoAndReturn(func(_ context.Context, id string) (*core.Event, error) {
+			return &core.Event{ID: id, CreatedBy: "1", Timezone: "Asia/Jakarta"}, nil
+		})
+	svc := scheduling.NewService(repo, mock.NewMockEventHistoryRepository(ctrl), mock.NewMockCalendarRepository(ctrl), mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0))
+	baseURL := startServers(t, svc)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	openStream := func(lastEventID string) (*http.Response, *bufio.Reader) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/api/v1/events/stream?access_token=1", nil)
+		require.NoError(t, err)
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = res.Body.Close()
+		})
+		return res, bufio.NewReader(res.Body)
+	}
+
+	res, reader := openStream("")
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	require.NoError(t, svc.DeleteEventByID(context.Background(), &core.DeleteEventByIDRequest{ActorID: "1", EventID: "first"}))
+	first := readServerSentEvent(t, reader)
+	assert.Equal(t, "DELETE", first["event"])
+	assert.NotEmpty(t, first["id"])
+	assert.Contains(t, first["data"], `"first"`)
+
+	// a change made while disconnected is delivered on resume
+	_ = res.Body.Close()
+	require.NoError(t, svc.DeleteEventByID(context.Background(), &core.DeleteEventByIDRequest{ActorID: "1", EventID: "second"}))
+
+	res, reader = openStream(first["id"])
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	second := readServerSentEvent(t, reader)
+	assert.Contains(t, second["data"], `"second"`)
+}
+
+func TestGRPCGatewayServer_EventStream_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		header         http.Header
+		wantStatusCode int
+	}{
+		{
+			name:           "unauthorized",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "malformed cursor",
+			header:         http.Header{"Authorization": {"1"}, "Last-Event-Id": {"%%"}},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "expired cursor",
+			header:         http.Header{"Authorization": {"1"}, "Last-Event-Id": {"b3RoZXIsMQ"}},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := scheduling.NewService(mock.NewMockEventRepository(ctrl), mock.NewMockEventHistoryRepository(ctrl), mock.NewMockCalendarRepository(ctrl), mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0))
+			baseURL := startServers(t, svc)
+
+			req, err := http.NewRequest(http.MethodGet, baseURL+"/api/v1/events/stream"+tt.query, nil)
+			require.NoError(t, err)
+			for key, values := range tt.header {
+				req.Header[key] = values
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode)
+			var body errorBody
+			assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+		})
+	}
+}
@@ -8,19 +8,22 @@ import (
 	v1 "github.com/dzakaammar/event-scheduling-example/gen/go/proto/v1"
 	"github.com/dzakaammar/event-scheduling-example/internal/core"
 	"github.com/dzakaammar/event-scheduling-example/internal/endpoint"
+	"github.com/dzakaammar/event-scheduling-example/internal/health"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
 type GRPCServer struct {
-	srv *grpc.Server
+	srv    *grpc.Server
+	health *health.Monitor
 }
 
-func NewGRPCServer(schedulingSvc core.SchedulingService) *GRPCServer {
-	endpoint := endpoint.NewGRPCEndpoint(schedulingSvc)
+func NewGRPCServer(schedulingSvc core.SchedulingService, webhookSvc core.WebhookService, preferenceSvc core.NotificationPreferenceService, agendaSvc core.AgendaService, calendarSvc core.CalendarService, groupSvc core.GroupService, resourceSvc core.ResourceService, bookingSvc core.BookingService, healthMonitor *health.Monitor) *GRPCServer {
+	apiEndpoint := endpoint.NewGRPCEndpoint(schedulingSvc, webhookSvc, preferenceSvc, agendaSvc, calendarSvc, groupSvc, resourceSvc, bookingSvc, healthMonitor)
 
 	srv := grpc.NewServer(
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
@@ -28,11 +31,13 @@ func NewGRPCServer(schedulingSvc core.SchedulingService) *GRPCServer {
 			grpc_ctxtags.UnaryServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
 		),
 	)
-	v1.RegisterAPIServer(srv, endpoint)
+	v1.RegisterAPIServer(srv, apiEndpoint)
+	healthpb.RegisterHealthServer(srv, endpoint.NewHealthEndpoint(healthMonitor))
 	reflection.Register(srv)
 
 	return &GRPCServer{
-		srv: srv,
+		srv:    srv,
+		health: healthMonitor,
 	}
 }
 
@@ -47,6 +52,9 @@ func (g *GRPCServer) Start(address string) error {
 }
 
 func (g *GRPCServer) Stop(ctx context.Context) error {
+	// let the watchers know before the connections are drained
+	g.health.Shutdown()
+
 	ch := make(chan struct{})
 
 	go func() {
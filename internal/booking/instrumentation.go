@@ -0,0 +1,105 @@
+package booking
+
+import (
+	"context"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Instrumentation struct {
+	next   core.BookingService
+	tracer trace.Tracer
+}
+
+func NewInstrumentation(next core.BookingService) *Instrumentation {
+	return &Instrumentation{
+		next:   next,
+		tracer: otel.Tracer("booking-service"),
+	}
+}
+
+func (i *Instrumentation) CreateBookingPage(ctx context.Context, req *core.CreateBookingPageRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "create-booking-page")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.CreateBookingPage(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) UpdateBookingPage(ctx context.Context, req *core.UpdateBookingPageRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "update-booking-page")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.UpdateBookingPage(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) DeleteBookingPage(ctx context.Context, req *core.DeleteBookingPageRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "delete-booking-page")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.DeleteBookingPage(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) ListBookingPages(ctx context.Context, req *core.ListBookingPagesRequest) ([]core.BookingPage, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "list-booking-pages")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	pages, err := i.next.ListBookingPages(ctx, req)
+	return pages, err
+}
+
+func (i *Instrumentation) FindBookingPageByID(ctx context.Context, req *core.FindBookingPageByIDRequest) (*core.BookingPage, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-booking-page-by-id")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	page, err := i.next.FindBookingPageByID(ctx, req)
+	return page, err
+}
+
+func (i *Instrumentation) ListSlots(ctx context.Context, req *core.ListSlotsRequest) ([]core.Slot, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "list-slots")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	slots, err := i.next.ListSlots(ctx, req)
+	return slots, err
+}
+
+func (i *Instrumentation) BookSlot(ctx context.Context, req *core.BookSlotRequest) (*core.Booking, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "book-slot")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	booking, err := i.next.BookSlot(ctx, req)
+	return booking, err
+}
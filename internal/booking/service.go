@@ -0,0 +1,170 @@
+package booking
+
+import (
+	"context"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/satori/uuid"
+)
+
+type Service struct {
+	pages   core.BookingPageRepository
+	changes core.EventChangeBroker
+}
+
+func NewService(pages core.BookingPageRepository, changes core.EventChangeBroker) *Service {
+	return &Service{
+		pages:   pages,
+		changes: changes,
+	}
+}
+
+func (s *Service) CreateBookingPage(ctx context.Context, req *core.CreateBookingPageRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	req.Page.OwnerID = req.ActorID
+	return s.pages.Store(ctx, req.Page)
+}
+
+func (s *Service) UpdateBookingPage(ctx context.Context, req *core.UpdateBookingPageRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	page, err := s.findOwned(ctx, req.ActorID, req.Page.ID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	req.Page.OwnerID = page.OwnerID
+	req.Page.CreatedAt = page.CreatedAt
+	req.Page.UpdatedAt = &now
+	return s.pages.Update(ctx, req.Page)
+}
+
+// DeleteBookingPage deletes the page, the events booked through it are kept.
+func (s *Service) DeleteBookingPage(ctx context.Context, req *core.DeleteBookingPageRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.findOwned(ctx, req.ActorID, req.PageID)
+	if err != nil {
+		return err
+	}
+
+	return s.pages.DeleteByID(ctx, req.PageID)
+}
+
+func (s *Service) ListBookingPages(ctx context.Context, req *core.ListBookingPagesRequest) ([]core.BookingPage, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.pages.FindByOwner(ctx, req.ActorID)
+}
+
+func (s *Service) FindBookingPageByID(ctx context.Context, req *core.FindBookingPageByIDRequest) (*core.BookingPage, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.pages.FindByID(ctx, req.PageID)
+}
+
+func (s *Service) ListSlots(ctx context.Context, req *core.ListSlotsRequest) ([]core.Slot, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.pages.FindByID(ctx, req.PageID)
+	if err != nil {
+		return nil, err
+	}
+
+	busyFrom, busyTo := page.BusyWindow(req.From, req.To)
+	busy, err := s.pages.FindBusySchedules(ctx, page.OwnerID, busyFrom, busyTo)
+	if err != nil {
+		return nil, err
+	}
+
+	// the days at both ends of the window are in it only in part, their bookings count all the same
+	booked, err := s.pages.FindBookedStarts(ctx, page.ID, req.From.Add(-24*time.Hour), req.To.Add(24*time.Hour))
+	if err != nil {
+		return nil, err
+	}
+
+	starts := page.Slots(req.From, req.To, time.Now(), busy, booked)
+	slots := make([]core.Slot, len(starts))
+	for index, start := range starts {
+		slots[index] = core.Slot{
+			Start: start,
+			End:   start.Add(time.Duration(page.DurationMinutes) * time.Minute),
+		}
+	}
+	return slots, nil
+}
+
+// BookSlot creates the event on behalf of the owner of the page, who is the actor of the change.
+// The slot is checked again when the booking is stored, so a slot taken in the meantime fails
+// with core.ErrSlotUnavailable.
+func (s *Service) BookSlot(ctx context.Context, req *core.BookSlotRequest) (*core.Booking, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.pages.FindByID(ctx, req.PageID)
+	if err != nil {
+		return nil, err
+	}
+
+	event := core.NewBookingEvent(page, req.Start, req.Guest, req.Notes)
+	err = event.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	booking := &core.Booking{
+		ID:        uuid.NewV4().String(),
+		PageID:    page.ID,
+		EventID:   event.ID,
+		Start:     req.Start,
+		Guest:     event.Guests[0],
+		CreatedAt: time.Now(),
+	}
+
+	change := core.NewEventChange(event.ID, page.OwnerID, core.HistoryOperation_Create, nil, event)
+	err = s.pages.Book(ctx, page, booking, event, core.NewJournal(change, nil, event))
+	if err != nil {
+		return nil, err
+	}
+
+	s.changes.Publish(change)
+
+	return booking, nil
+}
+
+// findOwned returns the page if the actor owns it. The pages are public, so the others are denied
+// rather than told it doesn't exist.
+func (s *Service) findOwned(ctx context.Context, actorID string, id string) (*core.BookingPage, error) {
+	page, err := s.pages.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if page.OwnerID != actorID {
+		return nil, core.ErrPermissionDenied
+	}
+	return page, nil
+}
@@ -0,0 +1,331 @@
+package booking_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/booking"
+	"github.com/dzakaammar/event-scheduling-example/internal/changefeed"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestBookingPage(ownerID string) *core.BookingPage {
+	page := core.NewBookingPage(ownerID)
+	page.ID = "page1"
+	page.Title = "Intro call"
+	page.Timezone = "UTC"
+	page.DurationMinutes = 60
+	page.Rules = []core.AvailabilityRule{
+		{Weekday: time.Monday, Start: "09:00", End: "13:00"},
+	}
+	return page
+}
+
+// nextMonday returns the midnight of a Monday in UTC that is more than a week away, so that its
+// slots are bookable whenever the tests run.
+func nextMonday() time.Time {
+	day := time.Now().UTC().AddDate(0, 0, 8)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	for day.Weekday() != time.Monday {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day
+}
+
+func TestService_UpdateBookingPage(t *testing.T) {
+	type fields struct {
+		pagesMock func(ctrl *gomock.Controller) core.BookingPageRepository
+	}
+	type args struct {
+		req *core.UpdateBookingPageRequest
+	}
+	update := newTestBookingPage("")
+	update.Title = "Demo"
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		wantErrIs error
+	}{
+		{
+			name: "OK - by the owner",
+			fields: fields{
+				pagesMock: func(ctrl *gomock.Controller) core.BookingPageRepository {
+					pages := mock.NewMockBookingPageRepository(ctrl)
+					pages.EXPECT().FindByID(gomock.Any(), "page1").Return(newTestBookingPage("1"), nil)
+					pages.EXPECT().Update(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, page *core.BookingPage) error {
+							assert.Equal(t, "Demo", page.Title)
+							assert.Equal(t, "1", page.OwnerID)
+							assert.NotNil(t, page.UpdatedAt)
+							return nil
+						})
+					return pages
+				},
+			},
+			args: args{
+				req: &core.UpdateBookingPageRequest{ActorID: "1", Page: update},
+			},
+		},
+		{
+			name: "Not OK - not the owner",
+			fields: fields{
+				pagesMock: func(ctrl *gomock.Controller) core.BookingPageRepository {
+					pages := mock.NewMockBookingPageRepository(ctrl)
+					pages.EXPECT().FindByID(gomock.Any(), "page1").Return(newTestBookingPage("1"), nil)
+					return pages
+				},
+			},
+			args: args{
+				req: &core.UpdateBookingPageRequest{ActorID: "2", Page: update},
+			},
+			wantErrIs: core.ErrPermissionDenied,
+		},
+		{
+			name: "Not OK - a rule ends before it starts",
+			fields: fields{
+				pagesMock: func(ctrl *gomock.Controller) core.BookingPageRepository {
+					return mock.NewMockBookingPageRepository(ctrl)
+				},
+			},
+			args: args{
+				req: &core.UpdateBookingPageRequest{ActorID: "1", Page: func() *core.BookingPage {
+					page := newTestBookingPage("")
+					page.Rules[0].End = "08:00"
+					return page
+				}()},
+			},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := booking.NewService(tt.fields.pagesMock(ctrl), changefeed.NewBroker(0))
+			err := s.UpdateBookingPage(context.Background(), tt.args.req)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_ListSlots(t *testing.T) {
+	type fields struct {
+		pagesMock func(ctrl *gomock.Controller) core.BookingPageRepository
+	}
+	type args struct {
+		req *core.ListSlotsRequest
+	}
+	monday := nextMonday()
+	hour := func(h int) time.Time {
+		return monday.Add(time.Duration(h) * time.Hour)
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		want      []core.Slot
+		wantErrIs error
+	}{
+		{
+			name: "OK - the slots too close to the events of the owner are left out",
+			fields: fields{
+				pagesMock: func(ctrl *gomock.Controller) core.BookingPageRepository {
+					page := newTestBookingPage("1")
+					page.BufferAfterMinutes = 30
+
+					pages := mock.NewMockBookingPageRepository(ctrl)
+					pages.EXPECT().FindByID(gomock.Any(), "page1").Return(page, nil)
+					pages.EXPECT().FindBusySchedules(gomock.Any(), "1", monday, monday.Add(24*time.Hour+90*time.Minute)).Return([]core.Schedule{
+						{StartTime: hour(11).Unix(), DurationInMinutes: 30},
+					}, nil)
+					pages.EXPECT().FindBookedStarts(gomock.Any(), "page1", monday.Add(-24*time.Hour), monday.Add(48*time.Hour)).Return(nil, nil)
+					return pages
+				},
+			},
+			args: args{
+				req: &core.ListSlotsRequest{PageID: "page1", From: monday, To: monday.Add(24 * time.Hour)},
+			},
+			want: []core.Slot{
+				{Start: hour(9), End: hour(10)},
+				{Start: hour(12), End: hour(13)},
+			},
+		},
+		{
+			name: "OK - the days with as many bookings as the maximum are left out",
+			fields: fields{
+				pagesMock: func(ctrl *gomock.Controller) core.BookingPageRepository {
+					page := newTestBookingPage("1")
+					page.MaxPerDay = 1
+
+					pages := mock.NewMockBookingPageRepository(ctrl)
+					pages.EXPECT().FindByID(gomock.Any(), "page1").Return(page, nil)
+					pages.EXPECT().FindBusySchedules(gomock.Any(), "1", gomock.Any(), gomock.Any()).Return(nil, nil)
+					pages.EXPECT().FindBookedStarts(gomock.Any(), "page1", gomock.Any(), gomock.Any()).Return([]time.Time{hour(10)}, nil)
+					return pages
+				},
+			},
+			args: args{
+				req: &core.ListSlotsRequest{PageID: "page1", From: monday, To: monday.Add(24 * time.Hour)},
+			},
+			want: []core.Slot{},
+		},
+		{
+			name: "Not OK - the window is too long",
+			fields: fields{
+				pagesMock: func(ctrl *gomock.Controller) core.BookingPageRepository {
+					return mock.NewMockBookingPageRepository(ctrl)
+				},
+			},
+			args: args{
+				req: &core.ListSlotsRequest{PageID: "page1", From: monday, To: monday.Add(core.MaxSlotWindow + time.Hour)},
+			},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - not found",
+			fields: fields{
+				pagesMock: func(ctrl *gomock.Controller) core.BookingPageRepository {
+					pages := mock.NewMockBookingPageRepository(ctrl)
+					pages.EXPECT().FindByID(gomock.Any(), "page1").Return(nil, core.ErrBookingPageNotFound)
+					return pages
+				},
+			},
+			args: args{
+				req: &core.ListSlotsRequest{PageID: "page1", From: monday, To: monday.Add(24 * time.Hour)},
+			},
+			wantErrIs: core.ErrBookingPageNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := booking.NewService(tt.fields.pagesMock(ctrl), changefeed.NewBroker(0))
+			got, err := s.ListSlots(context.Background(), tt.args.req)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_BookSlot(t *testing.T) {
+	type fields struct {
+		pagesMock func(ctrl *gomock.Controller) core.BookingPageRepository
+	}
+	type args struct {
+		req *core.BookSlotRequest
+	}
+	start := nextMonday().Add(10 * time.Hour)
+	guest := core.Guest{Name: "Ana", Email: "ana@example.org"}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "OK - the event of the owner invites the guest",
+			fields: fields{
+				pagesMock: func(ctrl *gomock.Controller) core.BookingPageRepository {
+					pages := mock.NewMockBookingPageRepository(ctrl)
+					pages.EXPECT().FindByID(gomock.Any(), "page1").Return(newTestBookingPage("1"), nil)
+					pages.EXPECT().Book(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, _ *core.BookingPage, b *core.Booking, event *core.Event, journal core.Journal) error {
+							assert.Equal(t, event.ID, b.EventID)
+							assert.Equal(t, "1", event.CreatedBy)
+							assert.Equal(t, "Intro call with Ana", event.Title)
+							assert.Equal(t, start.Unix(), event.Schedules[0].StartTime)
+							assert.Equal(t, []core.Guest{{EventID: event.ID, Name: "Ana", Email: "ana@example.org"}}, event.Guests)
+							assert.Equal(t, core.HistoryOperation_Create, journal.History.Operation)
+							assert.Equal(t, "1", journal.History.ActorID)
+							assert.Len(t, journal.Outbox, 1)
+							assert.Equal(t, "1", journal.Outbox[0].ActorID)
+							return nil
+						})
+					return pages
+				},
+			},
+			args: args{
+				req: &core.BookSlotRequest{PageID: "page1", Start: start, Guest: guest},
+			},
+		},
+		{
+			name: "Not OK - the slot was taken",
+			fields: fields{
+				pagesMock: func(ctrl *gomock.Controller) core.BookingPageRepository {
+					pages := mock.NewMockBookingPageRepository(ctrl)
+					pages.EXPECT().FindByID(gomock.Any(), "page1").Return(newTestBookingPage("1"), nil)
+					pages.EXPECT().Book(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(core.ErrSlotUnavailable)
+					return pages
+				},
+			},
+			args: args{
+				req: &core.BookSlotRequest{PageID: "page1", Start: start, Guest: guest},
+			},
+			wantErrIs: core.ErrSlotUnavailable,
+		},
+		{
+			name: "Not OK - invalid email",
+			fields: fields{
+				pagesMock: func(ctrl *gomock.Controller) core.BookingPageRepository {
+					return mock.NewMockBookingPageRepository(ctrl)
+				},
+			},
+			args: args{
+				req: &core.BookSlotRequest{PageID: "page1", Start: start, Guest: core.Guest{Name: "Ana", Email: "ana"}},
+			},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - error from repo",
+			fields: fields{
+				pagesMock: func(ctrl *gomock.Controller) core.BookingPageRepository {
+					pages := mock.NewMockBookingPageRepository(ctrl)
+					pages.EXPECT().FindByID(gomock.Any(), "page1").Return(nil, errors.New("error")) //nolint:goerr113
+					return pages
+				},
+			},
+			args: args{
+				req: &core.BookSlotRequest{PageID: "page1", Start: start, Guest: guest},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			got, err := booking.NewService(tt.fields.pagesMock(ctrl), changefeed.NewBroker(0)).BookSlot(context.Background(), tt.args.req)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "page1", got.PageID)
+			assert.Equal(t, start, got.Start)
+		})
+	}
+}
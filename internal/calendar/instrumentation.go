@@ -0,0 +1,129 @@
+package calendar
+
+import (
+	"context"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Instrumentation struct {
+	next   core.CalendarService
+	tracer trace.Tracer
+}
+
+func NewInstrumentation(next core.CalendarService) *Instrumentation {
+	return &Instrumentation{
+		next:   next,
+		tracer: otel.Tracer("calendar-service"),
+	}
+}
+
+func (i *Instrumentation) CreateCalendar(ctx context.Context, req *core.CreateCalendarRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "create-calendar")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.CreateCalendar(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) UpdateCalendar(ctx context.Context, req *core.UpdateCalendarRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "update-calendar")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.UpdateCalendar(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) DeleteCalendar(ctx context.Context, req *core.DeleteCalendarRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "delete-calendar")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.DeleteCalendar(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) FindCalendarByID(ctx context.Context, req *core.FindCalendarByIDRequest) (*core.Calendar, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-calendar-by-id")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	calendar, err := i.next.FindCalendarByID(ctx, req)
+	return calendar, err
+}
+
+func (i *Instrumentation) ListCalendars(ctx context.Context, req *core.ListCalendarsRequest) ([]core.Calendar, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "list-calendars")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	calendars, err := i.next.ListCalendars(ctx, req)
+	return calendars, err
+}
+
+func (i *Instrumentation) MoveEvent(ctx context.Context, req *core.MoveEventRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "move-event")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.MoveEvent(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) ShareCalendar(ctx context.Context, req *core.ShareCalendarRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "share-calendar")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.ShareCalendar(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) UnshareCalendar(ctx context.Context, req *core.UnshareCalendarRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "unshare-calendar")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.UnshareCalendar(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) ListCalendarGrants(ctx context.Context, req *core.ListCalendarGrantsRequest) ([]core.CalendarGrant, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "list-calendar-grants")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	grants, err := i.next.ListCalendarGrants(ctx, req)
+	return grants, err
+}
@@ -0,0 +1,285 @@
+package calendar
+
+import (
+	"context"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+type Service struct {
+	calendars core.CalendarRepository
+	events    core.EventRepository
+	groups    core.GroupRepository
+	changes   core.EventChangeBroker
+}
+
+func NewService(calendars core.CalendarRepository, events core.EventRepository, groups core.GroupRepository, changes core.EventChangeBroker) *Service {
+	return &Service{
+		calendars: calendars,
+		events:    events,
+		groups:    groups,
+		changes:   changes,
+	}
+}
+
+func (s *Service) CreateCalendar(ctx context.Context, req *core.CreateCalendarRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	req.Calendar.OwnerID = req.ActorID
+	// a user gets their default calendar along with their first event, they can't make another one
+	req.Calendar.IsDefault = false
+	return s.calendars.Store(ctx, req.Calendar)
+}
+
+func (s *Service) UpdateCalendar(ctx context.Context, req *core.UpdateCalendarRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	calendar, err := s.findWithRole(ctx, req.ActorID, req.Calendar.ID, core.CalendarRole_Manage)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	calendar.Name = req.Calendar.Name
+	calendar.Color = req.Calendar.Color
+	calendar.Timezone = req.Calendar.Timezone
+	calendar.Description = req.Calendar.Description
+	calendar.UpdatedAt = &now
+
+	err = calendar.Validate()
+	if err != nil {
+		return err
+	}
+
+	return s.calendars.Update(ctx, calendar)
+}
+
+// DeleteCalendar deletes a calendar other than the default one, its events are moved to the
+// default calendar. Only the owner can delete it, managing it isn't enough.
+func (s *Service) DeleteCalendar(ctx context.Context, req *core.DeleteCalendarRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	calendar, err := s.findWithRole(ctx, req.ActorID, req.CalendarID, core.CalendarRole_Manage)
+	if err != nil {
+		return err
+	}
+
+	if calendar.OwnerID != req.ActorID {
+		return core.ErrPermissionDenied
+	}
+
+	if calendar.IsDefault {
+		return core.ErrDefaultCalendar
+	}
+
+	var changes []core.EventChange
+	err = s.calendars.DeleteByID(ctx, req.CalendarID, func(before, after *core.Event) core.Journal {
+		change := core.NewEventChange(before.ID, req.ActorID, core.HistoryOperation_Update, before, after)
+		changes = append(changes, change)
+		return moveJournal(change, before, after)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		s.changes.Publish(change)
+	}
+	return nil
+}
+
+func (s *Service) FindCalendarByID(ctx context.Context, req *core.FindCalendarByIDRequest) (*core.Calendar, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.findWithRole(ctx, req.ActorID, req.CalendarID, core.CalendarRole_FreeBusy)
+}
+
+// ListCalendars returns the calendars of the actor, the default one first, followed by the
+// calendars shared with them.
+func (s *Service) ListCalendars(ctx context.Context, req *core.ListCalendarsRequest) ([]core.Calendar, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	calendars, err := s.calendars.FindByOwner(ctx, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	for index := range calendars {
+		calendars[index].AccessRole = core.CalendarRole_Manage
+	}
+
+	shared, err := s.calendars.FindSharedWith(ctx, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(calendars, shared...), nil
+}
+
+// MoveEvent moves an event to another calendar of its creator. The creator can move it, and so
+// can a delegate who can write to both calendars.
+func (s *Service) MoveEvent(ctx context.Context, req *core.MoveEventRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	event, err := s.events.FindByID(ctx, req.EventID)
+	if err != nil {
+		return err
+	}
+
+	if event.CreatedBy != req.ActorID {
+		role, err := s.calendars.FindRole(ctx, event.CalendarID, req.ActorID)
+		if err != nil {
+			return err
+		}
+		if role == "" {
+			return core.ErrEventNotFound
+		}
+		if !role.Includes(core.CalendarRole_Write) {
+			return core.ErrPermissionDenied
+		}
+	}
+
+	calendar, err := s.findWithRole(ctx, req.ActorID, req.CalendarID, core.CalendarRole_Write)
+	if err != nil {
+		return err
+	}
+
+	if calendar.OwnerID != event.CreatedBy {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "CalendarID", "an event can only be moved to a calendar of its creator")
+	}
+
+	if event.CalendarID == calendar.ID {
+		return nil
+	}
+
+	now := time.Now()
+	moved := *event
+	moved.CalendarID = calendar.ID
+	moved.UpdatedAt = &now
+
+	change := core.NewEventChange(event.ID, req.ActorID, core.HistoryOperation_Update, event, &moved)
+	if event.CreatedBy != req.ActorID {
+		change.OnBehalfOf = event.CreatedBy
+	}
+	err = s.calendars.MoveEvent(ctx, &moved, moveJournal(change, event, &moved))
+	if err != nil {
+		return err
+	}
+
+	s.changes.Publish(change)
+	return nil
+}
+
+// moveJournal is the journal of the move of an event to another calendar. The calendar isn't one of
+// the fields tracked by the history, so its change is added on its own.
+func moveJournal(change core.EventChange, before, after *core.Event) core.Journal {
+	journal := core.NewJournal(change, before, after)
+	journal.History.Changes["calendar_id"] = core.FieldChange{Before: before.CalendarID, After: after.CalendarID}
+	return journal
+}
+
+// ShareCalendar gives a role on the calendar to a user, or to a group the actor owns or is a member
+// of, or changes the role they have. A group gives the role to each of its members.
+func (s *Service) ShareCalendar(ctx context.Context, req *core.ShareCalendarRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	calendar, err := s.findWithRole(ctx, req.ActorID, req.Grant.CalendarID, core.CalendarRole_Manage)
+	if err != nil {
+		return err
+	}
+
+	switch req.Grant.GranteeType {
+	case core.GranteeType_User:
+		if req.Grant.GranteeID == calendar.OwnerID {
+			return internal.WrapFieldErr(internal.ErrValidationFailed, "Grant.GranteeID", "the owner of the calendar already manages it")
+		}
+	case core.GranteeType_Group:
+		group, err := s.groups.FindByID(ctx, req.Grant.GranteeID)
+		if err != nil {
+			return err
+		}
+		if !group.VisibleTo(req.ActorID) {
+			return core.ErrGroupNotFound
+		}
+	}
+
+	req.Grant.GrantedBy = req.ActorID
+	req.Grant.CreatedAt = time.Now()
+	return s.calendars.Grant(ctx, req.Grant)
+}
+
+func (s *Service) UnshareCalendar(ctx context.Context, req *core.UnshareCalendarRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.findWithRole(ctx, req.ActorID, req.CalendarID, core.CalendarRole_Manage)
+	if err != nil {
+		return err
+	}
+
+	return s.calendars.Revoke(ctx, req.CalendarID, req.GranteeType, req.GranteeID)
+}
+
+func (s *Service) ListCalendarGrants(ctx context.Context, req *core.ListCalendarGrantsRequest) ([]core.CalendarGrant, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.findWithRole(ctx, req.ActorID, req.CalendarID, core.CalendarRole_Manage)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.calendars.FindGrants(ctx, req.CalendarID)
+}
+
+// findWithRole returns the calendar to a user who has at least the role on it, along with their
+// role. It is reported as not found to the users it isn't shared with, so that the IDs of the
+// calendars of other users can't be probed.
+func (s *Service) findWithRole(ctx context.Context, actorID string, id string, role core.CalendarRole) (*core.Calendar, error) {
+	calendar, err := s.calendars.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar.AccessRole = core.CalendarRole_Manage
+	if calendar.OwnerID != actorID {
+		calendar.AccessRole, err = s.calendars.FindRole(ctx, id, actorID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if calendar.AccessRole == "" {
+		return nil, core.ErrCalendarNotFound
+	}
+	if !calendar.AccessRole.Includes(role) {
+		return nil, core.ErrPermissionDenied
+	}
+	return calendar, nil
+}
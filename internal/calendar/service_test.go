@@ -0,0 +1,524 @@
+package calendar_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/calendar"
+	"github.com/dzakaammar/event-scheduling-example/internal/changefeed"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCalendar(ownerID string) *core.Calendar {
+	c := core.NewCalendar(ownerID)
+	c.Name = "Work"
+	c.Color = "#1A73E8"
+	c.Timezone = "Asia/Jakarta"
+	return c
+}
+
+func TestService_CreateCalendar(t *testing.T) {
+	type fields struct {
+		repoMock func(ctrl *gomock.Controller) core.CalendarRepository
+	}
+	type args struct {
+		req *core.CreateCalendarRequest
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "OK - owned by the actor and never a default one",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					repo := mock.NewMockCalendarRepository(ctrl)
+					repo.EXPECT().Store(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, c *core.Calendar) error {
+							assert.Equal(t, "1", c.OwnerID)
+							assert.False(t, c.IsDefault)
+							return nil
+						})
+					return repo
+				},
+			},
+			args: args{
+				req: &core.CreateCalendarRequest{ActorID: "1", Calendar: func() *core.Calendar {
+					c := newTestCalendar("")
+					c.IsDefault = true
+					return c
+				}()},
+			},
+		},
+		{
+			name: "Not OK - invalid color",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					return mock.NewMockCalendarRepository(ctrl)
+				},
+			},
+			args: args{
+				req: &core.CreateCalendarRequest{ActorID: "1", Calendar: func() *core.Calendar {
+					c := newTestCalendar("1")
+					c.Color = "blue"
+					return c
+				}()},
+			},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - invalid timezone",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					return mock.NewMockCalendarRepository(ctrl)
+				},
+			},
+			args: args{
+				req: &core.CreateCalendarRequest{ActorID: "1", Calendar: func() *core.Calendar {
+					c := newTestCalendar("1")
+					c.Timezone = "Mars/Olympus"
+					return c
+				}()},
+			},
+			wantErrIs: internal.ErrInvalidTimezone,
+		},
+		{
+			name: "Not OK - error from repo",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					repo := mock.NewMockCalendarRepository(ctrl)
+					repo.EXPECT().Store(gomock.Any(), gomock.Any()).Return(errors.New("error")) //nolint:goerr113
+					return repo
+				},
+			},
+			args: args{
+				req: &core.CreateCalendarRequest{ActorID: "1", Calendar: newTestCalendar("")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			err := calendar.NewService(tt.fields.repoMock(ctrl), nil, nil, nil).CreateCalendar(context.Background(), tt.args.req)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_UpdateCalendar(t *testing.T) {
+	type fields struct {
+		repoMock func(ctrl *gomock.Controller) core.CalendarRepository
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantErrIs error
+	}{
+		{
+			name: "OK - the fields are replaced",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					repo := mock.NewMockCalendarRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "cal1").Return(newTestCalendar("1"), nil)
+					repo.EXPECT().Update(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, c *core.Calendar) error {
+							assert.Equal(t, "Personal", c.Name)
+							assert.Equal(t, "", c.Color)
+							assert.Equal(t, "Europe/Berlin", c.Timezone)
+							assert.Equal(t, "1", c.OwnerID)
+							assert.NotNil(t, c.UpdatedAt)
+							return nil
+						})
+					return repo
+				},
+			},
+		},
+		{
+			name: "Not OK - calendar of another user",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					repo := mock.NewMockCalendarRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "cal1").Return(newTestCalendar("2"), nil)
+					repo.EXPECT().FindRole(gomock.Any(), "cal1", "1").Return(core.CalendarRole(""), nil)
+					return repo
+				},
+			},
+			wantErrIs: core.ErrCalendarNotFound,
+		},
+		{
+			name: "Not OK - delegate without manage access",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					repo := mock.NewMockCalendarRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "cal1").Return(newTestCalendar("2"), nil)
+					repo.EXPECT().FindRole(gomock.Any(), "cal1", "1").Return(core.CalendarRole_Write, nil)
+					return repo
+				},
+			},
+			wantErrIs: core.ErrPermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			err := calendar.NewService(tt.fields.repoMock(ctrl), nil, nil, nil).UpdateCalendar(context.Background(), &core.UpdateCalendarRequest{
+				ActorID:  "1",
+				Calendar: &core.Calendar{ID: "cal1", Name: "Personal", Timezone: "Europe/Berlin"},
+			})
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_DeleteCalendar(t *testing.T) {
+	type fields struct {
+		repoMock func(ctrl *gomock.Controller) core.CalendarRepository
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantErrIs error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					repo := mock.NewMockCalendarRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "cal1").Return(newTestCalendar("1"), nil)
+					repo.EXPECT().DeleteByID(gomock.Any(), "cal1", gomock.Any()).
+						DoAndReturn(func(_ context.Context, _ string, journal func(before, after *core.Event) core.Journal) error {
+							got := journal(&core.Event{ID: "123", CreatedBy: "1", CalendarID: "cal1"}, &core.Event{ID: "123", CreatedBy: "1", CalendarID: "default"})
+							assert.Equal(t, core.HistoryOperation_Update, got.History.Operation)
+							assert.Equal(t, core.FieldChange{Before: "cal1", After: "default"}, got.History.Changes["calendar_id"])
+							if assert.Len(t, got.Outbox, 1) {
+								assert.Equal(t, core.DomainEventType_EventUpdated, got.Outbox[0].Type)
+							}
+							return nil
+						})
+					return repo
+				},
+			},
+		},
+		{
+			name: "Not OK - default calendar",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					repo := mock.NewMockCalendarRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "cal1").Return(core.NewDefaultCalendar("1", "Asia/Jakarta"), nil)
+					return repo
+				},
+			},
+			wantErrIs: core.ErrDefaultCalendar,
+		},
+		{
+			name: "Not OK - calendar of another user",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					repo := mock.NewMockCalendarRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "cal1").Return(newTestCalendar("2"), nil)
+					repo.EXPECT().FindRole(gomock.Any(), "cal1", "1").Return(core.CalendarRole(""), nil)
+					return repo
+				},
+			},
+			wantErrIs: core.ErrCalendarNotFound,
+		},
+		{
+			name: "Not OK - a delegate can't delete the calendar",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					repo := mock.NewMockCalendarRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "cal1").Return(newTestCalendar("2"), nil)
+					repo.EXPECT().FindRole(gomock.Any(), "cal1", "1").Return(core.CalendarRole_Manage, nil)
+					return repo
+				},
+			},
+			wantErrIs: core.ErrPermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			err := calendar.NewService(tt.fields.repoMock(ctrl), nil, nil, changefeed.NewBroker(0)).DeleteCalendar(context.Background(), &core.DeleteCalendarRequest{
+				ActorID:    "1",
+				CalendarID: "cal1",
+			})
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_MoveEvent(t *testing.T) {
+	type fields struct {
+		calendarMock func(ctrl *gomock.Controller) core.CalendarRepository
+		eventMock    func(ctrl *gomock.Controller) core.EventRepository
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantErrIs error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				calendarMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					repo := mock.NewMockCalendarRepository(ctrl)
+					c := newTestCalendar("1")
+					c.ID = "cal2"
+					repo.EXPECT().FindByID(gomock.Any(), "cal2").Return(c, nil)
+					repo.EXPECT().MoveEvent(gomock.Any(), gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, event *core.Event, journal core.Journal) error {
+							assert.Equal(t, "123", event.ID)
+							assert.Equal(t, "cal2", event.CalendarID)
+							assert.NotNil(t, event.UpdatedAt)
+							assert.Equal(t, core.FieldChange{Before: "cal1", After: "cal2"}, journal.History.Changes["calendar_id"])
+							assert.Len(t, journal.Outbox, 1)
+							return nil
+						})
+					return repo
+				},
+				eventMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "123").Return(&core.Event{ID: "123", CreatedBy: "1", CalendarID: "cal1"}, nil)
+					return repo
+				},
+			},
+		},
+		{
+			name: "OK - already in the calendar",
+			fields: fields{
+				calendarMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					repo := mock.NewMockCalendarRepository(ctrl)
+					c := newTestCalendar("1")
+					c.ID = "cal2"
+					repo.EXPECT().FindByID(gomock.Any(), "cal2").Return(c, nil)
+					return repo
+				},
+				eventMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "123").Return(&core.Event{ID: "123", CreatedBy: "1", CalendarID: "cal2"}, nil)
+					return repo
+				},
+			},
+		},
+		{
+			name: "Not OK - event of another user",
+			fields: fields{
+				calendarMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					repo := mock.NewMockCalendarRepository(ctrl)
+					repo.EXPECT().FindRole(gomock.Any(), "cal1", "1").Return(core.CalendarRole(""), nil)
+					return repo
+				},
+				eventMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "123").Return(&core.Event{ID: "123", CreatedBy: "2", CalendarID: "cal1"}, nil)
+					return repo
+				},
+			},
+			wantErrIs: core.ErrEventNotFound,
+		},
+		{
+			name: "Not OK - calendar of another user",
+			fields: fields{
+				calendarMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					repo := mock.NewMockCalendarRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "cal2").Return(newTestCalendar("2"), nil)
+					repo.EXPECT().FindRole(gomock.Any(), "cal2", "1").Return(core.CalendarRole(""), nil)
+					return repo
+				},
+				eventMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "123").Return(&core.Event{ID: "123", CreatedBy: "1", CalendarID: "cal1"}, nil)
+					return repo
+				},
+			},
+			wantErrIs: core.ErrCalendarNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := calendar.NewService(tt.fields.calendarMock(ctrl), tt.fields.eventMock(ctrl), nil, changefeed.NewBroker(0))
+			err := svc.MoveEvent(context.Background(), &core.MoveEventRequest{
+				ActorID:    "1",
+				EventID:    "123",
+				CalendarID: "cal2",
+			})
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_ShareCalendar(t *testing.T) {
+	type fields struct {
+		repoMock      func(ctrl *gomock.Controller) core.CalendarRepository
+		groupRepoMock func(ctrl *gomock.Controller) core.GroupRepository
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		granteeType core.GranteeType
+		granteeID   string
+		wantErrIs   error
+	}{
+		{
+			name: "OK - the owner shares the calendar",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					repo := mock.NewMockCalendarRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "cal1").Return(newTestCalendar("1"), nil)
+					repo.EXPECT().Grant(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, g *core.CalendarGrant) error {
+							assert.Equal(t, "3", g.GranteeID)
+							assert.Equal(t, core.CalendarRole_Write, g.Role)
+							assert.Equal(t, "1", g.GrantedBy)
+							assert.False(t, g.CreatedAt.IsZero())
+							return nil
+						})
+					return repo
+				},
+			},
+			granteeID: "3",
+		},
+		{
+			name: "OK - a delegate managing the sharing",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					repo := mock.NewMockCalendarRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "cal1").Return(newTestCalendar("2"), nil)
+					repo.EXPECT().FindRole(gomock.Any(), "cal1", "1").Return(core.CalendarRole_Manage, nil)
+					repo.EXPECT().Grant(gomock.Any(), gomock.Any()).Return(nil)
+					return repo
+				},
+			},
+			granteeID: "3",
+		},
+		{
+			name: "Not OK - a delegate without manage access",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					repo := mock.NewMockCalendarRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "cal1").Return(newTestCalendar("2"), nil)
+					repo.EXPECT().FindRole(gomock.Any(), "cal1", "1").Return(core.CalendarRole_Read, nil)
+					return repo
+				},
+			},
+			granteeID: "3",
+			wantErrIs: core.ErrPermissionDenied,
+		},
+		{
+			name: "Not OK - sharing with the owner",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					repo := mock.NewMockCalendarRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "cal1").Return(newTestCalendar("1"), nil)
+					return repo
+				},
+			},
+			granteeID: "1",
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "OK - sharing with a group of the actor",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					repo := mock.NewMockCalendarRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "cal1").Return(newTestCalendar("1"), nil)
+					repo.EXPECT().Grant(gomock.Any(), gomock.Any()).Return(nil)
+					return repo
+				},
+				groupRepoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					repo := mock.NewMockGroupRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "group1").Return(&core.Group{ID: "group1", OwnerID: "1"}, nil)
+					return repo
+				},
+			},
+			granteeType: core.GranteeType_Group,
+			granteeID:   "group1",
+		},
+		{
+			name: "Not OK - sharing with a group the actor can't see",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					repo := mock.NewMockCalendarRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "cal1").Return(newTestCalendar("1"), nil)
+					return repo
+				},
+				groupRepoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					repo := mock.NewMockGroupRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "group1").Return(&core.Group{ID: "group1", OwnerID: "2", Members: []int32{3}}, nil)
+					return repo
+				},
+			},
+			granteeType: core.GranteeType_Group,
+			granteeID:   "group1",
+			wantErrIs:   core.ErrGroupNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			granteeType := core.GranteeType_User
+			if tt.granteeType != "" {
+				granteeType = tt.granteeType
+			}
+			var groups core.GroupRepository
+			if tt.fields.groupRepoMock != nil {
+				groups = tt.fields.groupRepoMock(ctrl)
+			}
+
+			err := calendar.NewService(tt.fields.repoMock(ctrl), nil, groups, nil).ShareCalendar(context.Background(), &core.ShareCalendarRequest{
+				ActorID: "1",
+				Grant: &core.CalendarGrant{
+					CalendarID:  "cal1",
+					GranteeType: granteeType,
+					GranteeID:   tt.granteeID,
+					Role:        core.CalendarRole_Write,
+				},
+			})
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
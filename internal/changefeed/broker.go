@@ -0,0 +1,148 @@
+package changefeed
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+const (
+	defaultBacklogSize = 1024
+	// subscriberBuffer is how many changes a subscriber may lag behind before it is dropped
+	subscriberBuffer = 64
+)
+
+// Broker is an in-process core.EventChangeBroker. It retains the latest changes, so that
+// subscribers can resume after a reconnect, as long as they reconnect to the same process.
+type Broker struct {
+	epoch       string
+	backlogSize int
+
+	mu          sync.Mutex
+	sequence    uint64
+	backlog     []core.EventChange
+	subscribers map[*subscriber]struct{}
+	closed      bool
+}
+
+type subscriber struct {
+	ch     chan core.EventChange
+	filter func(core.EventChange) bool
+}
+
+func NewBroker(backlogSize int) *Broker {
+	if backlogSize <= 0 {
+		backlogSize = defaultBacklogSize
+	}
+
+	return &Broker{
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		backlogSize: backlogSize,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+func (b *Broker) Publish(change core.EventChange) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	b.sequence++
+	change.Cursor = core.ChangeCursor{Epoch: b.epoch, Sequence: b.sequence}
+
+	b.backlog = append(b.backlog, change)
+	if len(b.backlog) > b.backlogSize {
+		b.backlog = append(b.backlog[:0:0], b.backlog[len(b.backlog)-b.backlogSize:]...)
+	}
+
+	for s := range b.subscribers {
+		if !s.filter(change) {
+			continue
+		}
+
+		select {
+		case s.ch <- change:
+		default:
+			// the subscriber can't keep up, drop it rather than blocking the publisher
+			b.unsubscribe(s)
+		}
+	}
+}
+
+func (b *Broker) Subscribe(ctx context.Context, after *core.ChangeCursor, filter func(core.EventChange) bool) (<-chan core.EventChange, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var replay []core.EventChange
+	if after != nil {
+		if !b.retains(*after) {
+			return nil, core.ErrChangeCursorExpired
+		}
+
+		for _, change := range b.backlog {
+			if change.Cursor.Sequence > after.Sequence && filter(change) {
+				replay = append(replay, change)
+			}
+		}
+	}
+
+	s := &subscriber{
+		ch:     make(chan core.EventChange, subscriberBuffer+len(replay)),
+		filter: filter,
+	}
+	for _, change := range replay {
+		s.ch <- change
+	}
+
+	if b.closed {
+		close(s.ch)
+		return s.ch, nil
+	}
+	b.subscribers[s] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.unsubscribe(s)
+	}()
+
+	return s.ch, nil
+}
+
+// Close ends all the subscriptions, i.e: when the server shuts down.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for s := range b.subscribers {
+		b.unsubscribe(s)
+	}
+}
+
+// retains reports whether none of the changes after the cursor were dropped from the backlog.
+func (b *Broker) retains(cursor core.ChangeCursor) bool {
+	if cursor.Epoch != b.epoch || cursor.Sequence > b.sequence {
+		return false
+	}
+
+	oldest := b.sequence - uint64(len(b.backlog)) + 1
+	return cursor.Sequence+1 >= oldest
+}
+
+// unsubscribe must be called with the lock held.
+func (b *Broker) unsubscribe(s *subscriber) {
+	if _, ok := b.subscribers[s]; !ok {
+		return
+	}
+	delete(b.subscribers, s)
+	close(s.ch)
+}
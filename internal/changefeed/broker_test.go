@@ -0,0 +1,168 @@
+package changefeed_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/changefeed"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func involving(actorID string) func(core.EventChange) bool {
+	return func(change core.EventChange) bool {
+		return change.Involves(actorID)
+	}
+}
+
+func changeFor(eventID string, audience ...string) core.EventChange {
+	return core.EventChange{
+		EventID:   eventID,
+		Operation: core.HistoryOperation_Update,
+		Audience:  audience,
+	}
+}
+
+func receive(t *testing.T, ch <-chan core.EventChange) core.EventChange {
+	t.Helper()
+	select {
+	case change, ok := <-ch:
+		require.True(t, ok, "the subscription was closed")
+		return change
+	case <-time.After(time.Second):
+		t.Fatal("no change received")
+		return core.EventChange{}
+	}
+}
+
+func TestBroker_Subscribe(t *testing.T) {
+	b := changefeed.NewBroker(10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes, err := b.Subscribe(ctx, nil, involving("1"))
+	require.NoError(t, err)
+
+	b.Publish(changeFor("a", "1", "2"))
+	b.Publish(changeFor("b", "2"))
+	b.Publish(changeFor("c", "1"))
+
+	first := receive(t, changes)
+	assert.Equal(t, "a", first.EventID)
+	assert.Equal(t, uint64(1), first.Cursor.Sequence)
+	assert.NotEmpty(t, first.Cursor.Epoch)
+
+	third := receive(t, changes)
+	assert.Equal(t, "c", third.EventID)
+	assert.Equal(t, uint64(3), third.Cursor.Sequence)
+
+	cancel()
+	require.Eventually(t, func() bool {
+		_, ok := <-changes
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestBroker_Subscribe_Resume(t *testing.T) {
+	b := changefeed.NewBroker(3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes, err := b.Subscribe(ctx, nil, involving("1"))
+	require.NoError(t, err)
+
+	b.Publish(changeFor("a", "1"))
+	last := receive(t, changes)
+	cancel()
+
+	// changes published while the subscriber was away
+	b.Publish(changeFor("b", "1"))
+	b.Publish(changeFor("c", "2"))
+	b.Publish(changeFor("d", "1"))
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	changes, err = b.Subscribe(ctx, &last.Cursor, involving("1"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "b", receive(t, changes).EventID)
+	assert.Equal(t, "d", receive(t, changes).EventID)
+
+	b.Publish(changeFor("e", "1"))
+	assert.Equal(t, "e", receive(t, changes).EventID)
+}
+
+func TestBroker_Subscribe_ExpiredCursor(t *testing.T) {
+	b := changefeed.NewBroker(2)
+	for _, id := range []string{"a", "b", "c"} {
+		b.Publish(changeFor(id, "1"))
+	}
+
+	tests := []struct {
+		name   string
+		cursor core.ChangeCursor
+	}{
+		{
+			name:   "dropped from the backlog",
+			cursor: core.ChangeCursor{Epoch: epochOf(t, b), Sequence: 0},
+		},
+		{
+			name:   "issued by another broker",
+			cursor: core.ChangeCursor{Epoch: "other", Sequence: 3},
+		},
+		{
+			name:   "not issued yet",
+			cursor: core.ChangeCursor{Epoch: epochOf(t, b), Sequence: 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := b.Subscribe(context.Background(), &tt.cursor, involving("1"))
+			assert.ErrorIs(t, err, core.ErrChangeCursorExpired)
+		})
+	}
+}
+
+func TestBroker_SlowSubscriber(t *testing.T) {
+	b := changefeed.NewBroker(0)
+	changes, err := b.Subscribe(context.Background(), nil, involving("1"))
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		b.Publish(changeFor("a", "1"))
+	}
+
+	received := 0
+	for range changes {
+		received++
+	}
+	assert.Less(t, received, 100, "the subscriber is dropped once it falls behind")
+}
+
+func TestBroker_Close(t *testing.T) {
+	b := changefeed.NewBroker(0)
+	changes, err := b.Subscribe(context.Background(), nil, involving("1"))
+	require.NoError(t, err)
+
+	b.Close()
+	_, ok := <-changes
+	assert.False(t, ok)
+
+	changes, err = b.Subscribe(context.Background(), nil, involving("1"))
+	require.NoError(t, err)
+	_, ok = <-changes
+	assert.False(t, ok)
+}
+
+// epochOf returns the epoch of the broker, which is only exposed through the cursors it issues.
+func epochOf(t *testing.T, b *changefeed.Broker) string {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes, err := b.Subscribe(ctx, nil, involving("epoch"))
+	require.NoError(t, err)
+	b.Publish(changeFor("epoch", "epoch"))
+	return receive(t, changes).Cursor.Epoch
+}
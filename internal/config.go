@@ -1,12 +1,60 @@
 package internal
 
-import "github.com/spf13/viper"
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 type Config struct {
 	DbSource           string `mapstructure:"db_source"`
 	GRPCAddress        string `mapstructure:"grpc_address"`
 	GRPCGatewayAddress string `mapstructure:"grpc_gateway_address"`
 	OTLPEndpoint       string `mapstructure:"otel_exporter_otlp_endpoint"`
+	AutoMigrate        bool   `mapstructure:"auto_migrate"`
+
+	// DeletedEventRetention is how long deleted events stay in the trash, purging is disabled when it is zero
+	DeletedEventRetention time.Duration `mapstructure:"deleted_event_retention"`
+	PurgeInterval         time.Duration `mapstructure:"purge_interval"`
+
+	// IdempotencyKeyTTL is how long an idempotency key of CreateEvent is remembered
+	IdempotencyKeyTTL time.Duration `mapstructure:"idempotency_key_ttl"`
+
+	// HealthCheckInterval is how often the dependencies of the grpc server are probed
+	HealthCheckInterval time.Duration `mapstructure:"health_check_interval"`
+
+	// ChangeFeedBacklog is how many event changes are retained for watchers resuming after a reconnect
+	ChangeFeedBacklog int `mapstructure:"change_feed_backlog"`
+
+	// WebhookDispatchInterval is how often the due webhook deliveries are sent. A failed delivery is
+	// attempted up to WebhookMaxAttempts times, waiting WebhookRetryBackoff doubled on every attempt
+	WebhookDispatchInterval time.Duration `mapstructure:"webhook_dispatch_interval"`
+	WebhookMaxAttempts      int           `mapstructure:"webhook_max_attempts"`
+	WebhookRetryBackoff     time.Duration `mapstructure:"webhook_retry_backoff"`
+
+	// OutboxRelayInterval is how often the domain events written to the outbox are published, the
+	// published ones are kept for OutboxRetention, or forever when it is zero
+	OutboxRelayInterval time.Duration `mapstructure:"outbox_relay_interval"`
+	OutboxRetention     time.Duration `mapstructure:"outbox_retention"`
+
+	// ReminderInterval is how often the reminder worker fires the due reminders and sends them
+	ReminderInterval time.Duration `mapstructure:"reminder_interval"`
+
+	// AgendaTime is the time of the day, e.g: "07:00", the users are sent their agenda at in their
+	// timezone. AgendaInterval is how often the users whose morning it is are looked for
+	AgendaTime     string        `mapstructure:"agenda_time"`
+	AgendaInterval time.Duration `mapstructure:"agenda_interval"`
+
+	// SMTPAddress is the host:port of the mail server the notifications are sent through, emails are
+	// disabled when it is empty. A user receives them at EmailAddressFormat formatted with their id
+	SMTPAddress        string `mapstructure:"smtp_address"`
+	SMTPUsername       string `mapstructure:"smtp_username"`
+	SMTPPassword       string `mapstructure:"smtp_password"`
+	EmailFrom          string `mapstructure:"email_from"`
+	EmailAddressFormat string `mapstructure:"email_address_format"`
+	// EmailDigestInterval is how often the emails held back by the notification preferences of
+	// their recipients are checked for being due
+	EmailDigestInterval time.Duration `mapstructure:"email_digest_interval"`
 }
 
 func LoadConfig(path string) (Config, error) {
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+)
+
+// DateLayout is the layout of the dates of the agendas, e.g: "2026-01-05".
+const DateLayout = "2006-01-02"
+
+// AgendaItem is an occurrence of an event on the day of an agenda.
+type AgendaItem struct {
+	EventID     string
+	Title       string
+	Description string
+	CreatedBy   string
+	// Start and End are in the timezone of the agenda
+	Start     time.Time
+	End       time.Time
+	IsFullDay bool
+	// Status is the response of the user to their invitation, it is unknown for their own events
+	Status InvitationStatus
+}
+
+// Agenda is what a user has on a day: the occurrences of the events they created, and of the ones
+// they are invited to and didn't decline, earliest first.
+type Agenda struct {
+	UserID string
+	// Date is the start of the day, in the timezone of the user
+	Date  time.Time
+	Items []AgendaItem
+}
+
+// NewAgenda expands the occurrences of the events that overlap the day of the given time, in its
+// location. An event listed twice, i.e: as created by the user and as one they are invited to, is
+// only expanded once.
+func NewAgenda(userID string, day time.Time, events []Event) *Agenda {
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	to := from.AddDate(0, 0, 1)
+	agenda := &Agenda{
+		UserID: userID,
+		Date:   from,
+		Items:  []AgendaItem{},
+	}
+
+	seen := make(map[string]bool, len(events))
+	for index := range events {
+		event := &events[index]
+		if seen[event.ID] {
+			continue
+		}
+		seen[event.ID] = true
+
+		status := InvitationStatus_Unknown
+		if event.CreatedBy != userID {
+			id, err := ParseUserID(userID)
+			if err != nil {
+				continue
+			}
+			invitation := event.FindInvitation(id)
+			if invitation == nil || invitation.Status == InvitationStatus_Declined {
+				continue
+			}
+			status = invitation.Status
+		}
+
+		for _, schedule := range event.Schedules {
+			for _, start := range schedule.Occurrences(from, to) {
+				agenda.Items = append(agenda.Items, AgendaItem{
+					EventID:     event.ID,
+					Title:       event.Title,
+					Description: event.Description,
+					CreatedBy:   event.CreatedBy,
+					Start:       start.In(day.Location()),
+					End:         schedule.EndTimeFrom(start).In(day.Location()),
+					IsFullDay:   schedule.IsFullDay,
+					Status:      status,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(agenda.Items, func(i, j int) bool {
+		return agenda.Items[i].Start.Before(agenda.Items[j].Start)
+	})
+	return agenda
+}
+
+// Occurrences returns the starts of the occurrences of the schedule that overlap [from, to).
+func (s *Schedule) Occurrences(from time.Time, to time.Time) []time.Time {
+	duration := time.Duration(s.DurationInMinutes) * time.Minute
+
+	var starts []time.Time
+	// an occurrence that starts before from still overlaps when it ends after it
+	next := from.Add(-duration + time.Second)
+	for {
+		start, ok := s.NextOccurrence(next)
+		if !ok || !start.Before(to) {
+			return starts
+		}
+		starts = append(starts, start)
+		next = start.Add(time.Second)
+	}
+}
+
+type AgendaDeliveryStatus string
+
+const (
+	AgendaDeliveryStatus_Pending AgendaDeliveryStatus = "PENDING"
+	AgendaDeliveryStatus_Sent    AgendaDeliveryStatus = "SENT"
+	// AgendaDeliveryStatus_Skipped is an agenda that had nothing on it, it isn't sent
+	AgendaDeliveryStatus_Skipped AgendaDeliveryStatus = "SKIPPED"
+	// AgendaDeliveryStatus_Failed is a delivery that was given up on after the last attempt
+	AgendaDeliveryStatus_Failed AgendaDeliveryStatus = "FAILED"
+)
+
+// AgendaDelivery is the agenda of a user for a day, sent on a channel. There is at most one delivery
+// per user, day and channel, which is what makes the agenda sent once a day.
+type AgendaDelivery struct {
+	ID     int64
+	UserID string
+	// Date is the day of the agenda, at midnight UTC
+	Date          time.Time
+	Channel       ReminderChannel
+	Status        AgendaDeliveryStatus
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
+
+//go:generate mockgen -destination=../mock/mock_agenda_repository.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core AgendaRepository
+type AgendaRepository interface {
+	// ScheduleDue logs a delivery on every channel the user enabled for each user whose local time
+	// at now is between morning and noon, and who has an event that isn't deleted, created or
+	// invited to. The deliveries already logged for the local day of the user are skipped, so it
+	// returns how many were logged.
+	ScheduleDue(ctx context.Context, now time.Time, morning string) (int64, error)
+	// ClaimDueDeliveries returns at most limit pending deliveries that are due at now, and postpones
+	// them until leaseUntil so that they aren't claimed again while they are being sent.
+	ClaimDueDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]AgendaDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *AgendaDelivery) error
+}
+
+type GetAgendaRequest struct {
+	ActorID string
+	// Date is a date in the timezone of the actor's notification preference, e.g: "2026-01-05". It is
+	// today when it is empty.
+	Date string
+}
+
+func (g *GetAgendaRequest) Validate() error {
+	if g.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if g.Date == "" {
+		return nil
+	}
+
+	if _, err := time.Parse(DateLayout, g.Date); err != nil {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Date", "date must be a date, e.g: 2026-01-05")
+	}
+	return nil
+}
+
+//go:generate mockgen -destination=../mock/mock_agenda_service.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core AgendaService
+type AgendaService interface {
+	GetAgenda(ctx context.Context, req *GetAgendaRequest) (*Agenda, error)
+}
@@ -0,0 +1,376 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/satori/uuid"
+)
+
+// MaxSlotWindow is the longest window the open slots of a booking page are listed for.
+const MaxSlotWindow = 31 * 24 * time.Hour
+
+// AvailabilityRule is when the owner of a booking page takes bookings on a day of the week.
+type AvailabilityRule struct {
+	Weekday time.Weekday `json:"weekday" validate:"gte=0,lte=6"`
+	// Start and End are times of the day in the timezone of the page, e.g: "09:00" and "17:00"
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// BookingPage lets people without an account book a slot with its owner. The slots follow each
+// other from the start of the availability rules, each one as long as the duration.
+type BookingPage struct {
+	ID          string
+	OwnerID     string
+	Title       string `validate:"required,max=100"`
+	Description string `validate:"max=1000"`
+	// Timezone is the timezone of the availability rules and of the days MaxPerDay counts on
+	Timezone        string `validate:"required"`
+	DurationMinutes int32  `validate:"gte=5,lte=480"`
+	// BufferBeforeMinutes and BufferAfterMinutes are kept free around a slot, the slots that would
+	// otherwise be too close to an event of the owner aren't open
+	BufferBeforeMinutes int32 `validate:"gte=0,lte=240"`
+	BufferAfterMinutes  int32 `validate:"gte=0,lte=240"`
+	// MinNoticeMinutes is how long before a slot it can be booked at the latest
+	MinNoticeMinutes int32 `validate:"gte=0"`
+	// MaxPerDay is how many bookings the page takes on a day, 0 when it isn't limited
+	MaxPerDay int32              `validate:"gte=0"`
+	Rules     []AvailabilityRule `validate:"required,max=50,dive"`
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+func NewBookingPage(ownerID string) *BookingPage {
+	return &BookingPage{
+		ID:        uuid.NewV4().String(),
+		OwnerID:   ownerID,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (p *BookingPage) Validate() error {
+	_, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return internal.WrapFieldErr(internal.ErrInvalidTimezone, "BookingPage.Timezone", p.Timezone)
+	}
+
+	err = validate.Struct(p)
+	if err != nil {
+		return internal.WrapCause(internal.ErrValidationFailed, err)
+	}
+
+	for index, rule := range p.Rules {
+		field := fmt.Sprintf("BookingPage.Rules[%d]", index)
+		start, err := time.Parse(clockLayout, rule.Start)
+		if err != nil {
+			return internal.WrapFieldErr(internal.ErrValidationFailed, field+".Start", "start must be a time of the day, e.g: 09:00")
+		}
+
+		end, err := time.Parse(clockLayout, rule.End)
+		if err != nil {
+			return internal.WrapFieldErr(internal.ErrValidationFailed, field+".End", "end must be a time of the day, e.g: 17:00")
+		}
+
+		if !end.After(start) {
+			return internal.WrapFieldErr(internal.ErrValidationFailed, field+".End", "end must be after start")
+		}
+	}
+	return nil
+}
+
+func (p *BookingPage) location() *time.Location {
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Slots returns the starts of the open slots in [from, to), earliest first. A slot is open when it
+// starts at least the minimum notice after now, none of the busy schedules of the owner overlaps it
+// once stretched by the buffers, and fewer bookings than the maximum were made on its day. booked
+// are the starts of the bookings made through the page.
+func (p *BookingPage) Slots(from time.Time, to time.Time, now time.Time, busy []Schedule, booked []time.Time) []time.Time {
+	loc := p.location()
+	duration := time.Duration(p.DurationMinutes) * time.Minute
+	earliest := now.Add(time.Duration(p.MinNoticeMinutes) * time.Minute)
+
+	bookings := make(map[string]int32, len(booked))
+	for _, start := range booked {
+		bookings[start.In(loc).Format(DateLayout)]++
+	}
+
+	seen := make(map[time.Time]bool)
+	slots := []time.Time{}
+	local := from.In(loc)
+	for day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc); day.Before(to); day = day.AddDate(0, 0, 1) {
+		if p.MaxPerDay > 0 && bookings[day.Format(DateLayout)] >= p.MaxPerDay {
+			continue
+		}
+
+		for _, rule := range p.Rules {
+			if rule.Weekday != day.Weekday() {
+				continue
+			}
+
+			start, end := clockMinutes(rule.Start), clockMinutes(rule.End)
+			// the clock is set on the day rather than added to midnight, which keeps it right on the
+			// days the clocks change
+			limit := time.Date(day.Year(), day.Month(), day.Day(), end/60, end%60, 0, 0, loc)
+			slot := time.Date(day.Year(), day.Month(), day.Day(), start/60, start%60, 0, 0, loc)
+			for ; !slot.Add(duration).After(limit); slot = slot.Add(duration) {
+				if slot.Before(from) || !slot.Before(to) || slot.Before(earliest) || seen[slot] {
+					continue
+				}
+				if p.overlapsBusy(slot, busy) {
+					continue
+				}
+				seen[slot] = true
+				slots = append(slots, slot)
+			}
+		}
+	}
+
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].Before(slots[j])
+	})
+	return slots
+}
+
+// IsOpen reports whether the slot starting at start is one of the open slots.
+func (p *BookingPage) IsOpen(start time.Time, now time.Time, busy []Schedule, booked []time.Time) bool {
+	for _, slot := range p.Slots(start, start.Add(time.Minute), now, busy, booked) {
+		if slot.Equal(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// BusyWindow returns the window the busy schedules are needed in to find the slots starting in
+// [from, to), which is stretched by the buffers and the duration of the last slot.
+func (p *BookingPage) BusyWindow(from time.Time, to time.Time) (time.Time, time.Time) {
+	return from.Add(-time.Duration(p.BufferBeforeMinutes) * time.Minute),
+		to.Add(time.Duration(p.DurationMinutes+p.BufferAfterMinutes) * time.Minute)
+}
+
+func (p *BookingPage) overlapsBusy(slot time.Time, busy []Schedule) bool {
+	from := slot.Add(-time.Duration(p.BufferBeforeMinutes) * time.Minute)
+	to := slot.Add(time.Duration(p.DurationMinutes+p.BufferAfterMinutes) * time.Minute)
+	for index := range busy {
+		if len(busy[index].Occurrences(from, to)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Slot is an open slot of a booking page, in the timezone of the page.
+type Slot struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Guest is someone without an account who is invited to an event by email, e.g: who booked a slot
+// of a booking page.
+type Guest struct {
+	EventID string `db:"event_id"`
+	Name    string `db:"name" validate:"required,max=100"`
+	Email   string `db:"email" validate:"required,email,max=254"`
+}
+
+// Booking is a slot booked through a booking page, which made an event of the owner the guest is
+// invited to.
+type Booking struct {
+	ID        string
+	PageID    string
+	EventID   string
+	Start     time.Time
+	Guest     Guest
+	CreatedAt time.Time
+}
+
+// NewBookingEvent returns the event of the owner of the page the guest is invited to when they book
+// the slot starting at start.
+func NewBookingEvent(page *BookingPage, start time.Time, guest Guest, notes string) *Event {
+	event := NewEvent(page.OwnerID)
+	event.Title = fmt.Sprintf("%s with %s", page.Title, guest.Name)
+	event.Description = fmt.Sprintf("Booked by %s <%s>", guest.Name, guest.Email)
+	if notes != "" {
+		event.Description += "\n\n" + notes
+	}
+	event.Timezone = page.Timezone
+	event.Schedules = []Schedule{
+		{
+			ID:                uuid.NewV4().String(),
+			EventID:           event.ID,
+			StartTime:         start.Unix(),
+			DurationInMinutes: int64(page.DurationMinutes),
+			RecurringType:     RecurringType_None,
+		},
+	}
+	guest.EventID = event.ID
+	event.Guests = []Guest{guest}
+	return event
+}
+
+//go:generate mockgen -destination=../mock/mock_booking_page_repository.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core BookingPageRepository
+type BookingPageRepository interface {
+	Store(ctx context.Context, page *BookingPage) error
+	Update(ctx context.Context, page *BookingPage) error
+	// DeleteByID deletes the page, the events booked through it are kept.
+	DeleteByID(ctx context.Context, id string) error
+	FindByID(ctx context.Context, id string) (*BookingPage, error)
+	FindByOwner(ctx context.Context, ownerID string) ([]BookingPage, error)
+	// FindBusySchedules returns the schedules of the events that are not deleted and that the user
+	// created or is invited to and didn't decline, which may have an occurrence in [from, to).
+	FindBusySchedules(ctx context.Context, userID string, from time.Time, to time.Time) ([]Schedule, error)
+	// FindBookedStarts returns the starts of the bookings of the page in [from, to) whose event
+	// isn't deleted.
+	FindBookedStarts(ctx context.Context, pageID string, from time.Time, to time.Time) ([]time.Time, error)
+	// Book stores the booking along with its event if the slot is still open, and ErrSlotUnavailable
+	// otherwise. The bookings of an owner are made one at a time, so that a slot can't be booked
+	// twice.
+	Book(ctx context.Context, page *BookingPage, booking *Booking, event *Event, journal Journal) error
+}
+
+type CreateBookingPageRequest struct {
+	ActorID string
+	Page    *BookingPage
+}
+
+func (c *CreateBookingPageRequest) Validate() error {
+	if c.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if c.Page == nil {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "BookingPage", "invalid booking page")
+	}
+
+	return c.Page.Validate()
+}
+
+type UpdateBookingPageRequest struct {
+	ActorID string
+	Page    *BookingPage
+}
+
+func (u *UpdateBookingPageRequest) Validate() error {
+	if u.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if u.Page == nil || u.Page.ID == "" {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "BookingPage", "invalid booking page")
+	}
+
+	return u.Page.Validate()
+}
+
+type DeleteBookingPageRequest struct {
+	ActorID string
+	PageID  string
+}
+
+func (d *DeleteBookingPageRequest) Validate() error {
+	if d.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if d.PageID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid booking page id")
+	}
+
+	return nil
+}
+
+type ListBookingPagesRequest struct {
+	ActorID string
+}
+
+func (l *ListBookingPagesRequest) Validate() error {
+	if l.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	return nil
+}
+
+// FindBookingPageByIDRequest is public, the page is shown to whoever books a slot.
+type FindBookingPageByIDRequest struct {
+	PageID string
+}
+
+func (f *FindBookingPageByIDRequest) Validate() error {
+	if f.PageID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid booking page id")
+	}
+
+	return nil
+}
+
+// ListSlotsRequest is public.
+type ListSlotsRequest struct {
+	PageID string
+	From   time.Time
+	To     time.Time
+}
+
+func (l *ListSlotsRequest) Validate() error {
+	if l.PageID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid booking page id")
+	}
+
+	if !l.To.After(l.From) {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "To", "the end of the window must be after its start")
+	}
+
+	if l.To.Sub(l.From) > MaxSlotWindow {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "To", fmt.Sprintf("the window can't be longer than %d days", int(MaxSlotWindow.Hours()/24)))
+	}
+	return nil
+}
+
+// BookSlotRequest is public, the guest doesn't need an account.
+type BookSlotRequest struct {
+	PageID string
+	Start  time.Time
+	Guest  Guest
+	// Notes are what the guest wants the owner to know, they are added to the description
+	Notes string `validate:"max=1000"`
+}
+
+func (b *BookSlotRequest) Validate() error {
+	if b.PageID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid booking page id")
+	}
+
+	if b.Start.IsZero() {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "StartTime", "start time is required")
+	}
+
+	err := validate.Struct(b)
+	if err != nil {
+		return internal.WrapCause(internal.ErrValidationFailed, err)
+	}
+	return nil
+}
+
+//go:generate mockgen -destination=../mock/mock_booking_service.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core BookingService
+type BookingService interface {
+	CreateBookingPage(ctx context.Context, req *CreateBookingPageRequest) error
+	UpdateBookingPage(ctx context.Context, req *UpdateBookingPageRequest) error
+	DeleteBookingPage(ctx context.Context, req *DeleteBookingPageRequest) error
+	ListBookingPages(ctx context.Context, req *ListBookingPagesRequest) ([]BookingPage, error)
+	FindBookingPageByID(ctx context.Context, req *FindBookingPageByIDRequest) (*BookingPage, error)
+	// ListSlots returns the open slots of the page in the window, earliest first.
+	ListSlots(ctx context.Context, req *ListSlotsRequest) ([]Slot, error)
+	// BookSlot books the slot for the guest and returns the booking, whose event is created for the
+	// owner of the page with the guest invited.
+	BookSlot(ctx context.Context, req *BookSlotRequest) (*Booking, error)
+}
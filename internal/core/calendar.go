@@ -0,0 +1,325 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/satori/uuid"
+)
+
+// DefaultCalendarName is the name of the calendar a user gets along with their first event.
+const DefaultCalendarName = "Default"
+
+// CalendarRole is the access a user has to a calendar, each role includes the ones before it.
+type CalendarRole string
+
+const (
+	// CalendarRole_FreeBusy only sees when the events of the calendar take place
+	CalendarRole_FreeBusy CalendarRole = "FREE_BUSY"
+	// CalendarRole_Read sees the events of the calendar
+	CalendarRole_Read CalendarRole = "READ"
+	// CalendarRole_Write creates, updates and deletes the events of the calendar on behalf of its owner
+	CalendarRole_Write CalendarRole = "WRITE"
+	// CalendarRole_Manage also updates the calendar and shares it, the owner manages their calendars
+	CalendarRole_Manage CalendarRole = "MANAGE"
+)
+
+var calendarRoleRanks = map[CalendarRole]int{
+	CalendarRole_FreeBusy: 1,
+	CalendarRole_Read:     2,
+	CalendarRole_Write:    3,
+	CalendarRole_Manage:   4,
+}
+
+// Includes reports whether the role grants at least the other role, no role includes nothing.
+func (r CalendarRole) Includes(other CalendarRole) bool {
+	rank, ok := calendarRoleRanks[r]
+	return ok && rank >= calendarRoleRanks[other]
+}
+
+// HighestCalendarRole returns the role that includes the others, empty when there is none.
+func HighestCalendarRole(roles ...CalendarRole) CalendarRole {
+	var highest CalendarRole
+	for _, role := range roles {
+		if calendarRoleRanks[role] > calendarRoleRanks[highest] {
+			highest = role
+		}
+	}
+	return highest
+}
+
+type GranteeType string
+
+const (
+	GranteeType_User GranteeType = "USER"
+	// GranteeType_Group grants the role to each member of the group
+	GranteeType_Group GranteeType = "GROUP"
+)
+
+// Calendar groups the events of its owner. Every user has a default calendar, the events created
+// without a calendar go into it.
+type Calendar struct {
+	ID      string
+	OwnerID string
+	Name    string `validate:"required,max=100"`
+	// Color is a hex color, e.g: "#1A73E8", or empty to let the client pick one
+	Color       string `validate:"omitempty,hexcolor,len=7"`
+	Timezone    string `validate:"required"`
+	Description string `validate:"max=1000"`
+	// IsDefault is set on the calendar of the user that can't be deleted
+	IsDefault bool
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	// AccessRole is the role of the user the calendar was looked up for, it isn't stored
+	AccessRole CalendarRole
+}
+
+func NewCalendar(ownerID string) *Calendar {
+	return &Calendar{
+		ID:        uuid.NewV4().String(),
+		OwnerID:   ownerID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// NewDefaultCalendar returns the default calendar of the owner, in the timezone of their first
+// event.
+func NewDefaultCalendar(ownerID string, timezone string) *Calendar {
+	calendar := NewCalendar(ownerID)
+	calendar.Name = DefaultCalendarName
+	calendar.Timezone = timezone
+	calendar.IsDefault = true
+	return calendar
+}
+
+func (c *Calendar) Validate() error {
+	_, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return internal.WrapFieldErr(internal.ErrInvalidTimezone, "Calendar.Timezone", c.Timezone)
+	}
+
+	err = validate.Struct(c)
+	if err != nil {
+		return internal.WrapCause(internal.ErrValidationFailed, err)
+	}
+	return nil
+}
+
+// CalendarGrant gives a role on a calendar to a grantee, a grantee has one role per calendar.
+type CalendarGrant struct {
+	CalendarID  string
+	GranteeType GranteeType  `validate:"oneof=USER GROUP"`
+	GranteeID   string       `validate:"required,max=50"`
+	Role        CalendarRole `validate:"oneof=FREE_BUSY READ WRITE MANAGE"`
+	GrantedBy   string
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+}
+
+func (c *CalendarGrant) Validate() error {
+	err := validate.Struct(c)
+	if err != nil {
+		return internal.WrapCause(internal.ErrValidationFailed, err)
+	}
+	return nil
+}
+
+//go:generate mockgen -destination=../mock/mock_calendar_repository.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core CalendarRepository
+type CalendarRepository interface {
+	Store(ctx context.Context, calendar *Calendar) error
+	Update(ctx context.Context, calendar *Calendar) error
+	// DeleteByID deletes a calendar that isn't a default one, its events are moved to the default
+	// calendar of its owner. The journal of each move is given by journal, and recorded in the same
+	// transaction.
+	DeleteByID(ctx context.Context, id string, journal func(before, after *Event) Journal) error
+	FindByID(ctx context.Context, id string) (*Calendar, error)
+	// FindByOwner returns the calendars of the owner, the default one first.
+	FindByOwner(ctx context.Context, ownerID string) ([]Calendar, error)
+	// MoveEvent puts an event that isn't deleted into its CalendarID, along with the journal of the move.
+	MoveEvent(ctx context.Context, event *Event, journal Journal) error
+	// Grant gives the role of the grant to its grantee, replacing the role they had.
+	Grant(ctx context.Context, grant *CalendarGrant) error
+	Revoke(ctx context.Context, calendarID string, granteeType GranteeType, granteeID string) error
+	FindGrants(ctx context.Context, calendarID string) ([]CalendarGrant, error)
+	// FindRole returns the highest role of the user on the calendar, given to them or to a group
+	// they are a member of. It is MANAGE for its owner, empty when they have no access to it.
+	FindRole(ctx context.Context, calendarID string, userID string) (CalendarRole, error)
+	// FindSharedWith returns the calendars shared with the user or their groups along with their
+	// highest role on them.
+	FindSharedWith(ctx context.Context, userID string) ([]Calendar, error)
+}
+
+type CreateCalendarRequest struct {
+	ActorID  string
+	Calendar *Calendar
+}
+
+func (c *CreateCalendarRequest) Validate() error {
+	if c.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if c.Calendar == nil {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Calendar", "invalid calendar")
+	}
+
+	return c.Calendar.Validate()
+}
+
+type UpdateCalendarRequest struct {
+	ActorID string
+	// Calendar replaces the name, the color, the timezone and the description
+	Calendar *Calendar
+}
+
+func (u *UpdateCalendarRequest) Validate() error {
+	if u.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if u.Calendar == nil || u.Calendar.ID == "" {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Calendar", "invalid calendar")
+	}
+
+	return nil
+}
+
+type DeleteCalendarRequest struct {
+	ActorID    string
+	CalendarID string
+}
+
+func (d *DeleteCalendarRequest) Validate() error {
+	if d.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if d.CalendarID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid calendar id")
+	}
+
+	return nil
+}
+
+type FindCalendarByIDRequest struct {
+	ActorID    string
+	CalendarID string
+}
+
+func (f *FindCalendarByIDRequest) Validate() error {
+	if f.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if f.CalendarID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid calendar id")
+	}
+
+	return nil
+}
+
+type ShareCalendarRequest struct {
+	ActorID string
+	Grant   *CalendarGrant
+}
+
+func (s *ShareCalendarRequest) Validate() error {
+	if s.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if s.Grant == nil || s.Grant.CalendarID == "" {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Grant", "invalid grant")
+	}
+
+	return s.Grant.Validate()
+}
+
+type UnshareCalendarRequest struct {
+	ActorID     string
+	CalendarID  string
+	GranteeType GranteeType
+	GranteeID   string
+}
+
+func (u *UnshareCalendarRequest) Validate() error {
+	if u.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if u.CalendarID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid calendar id")
+	}
+
+	if (u.GranteeType != GranteeType_User && u.GranteeType != GranteeType_Group) || u.GranteeID == "" {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "GranteeID", "invalid grantee")
+	}
+
+	return nil
+}
+
+type ListCalendarGrantsRequest struct {
+	ActorID    string
+	CalendarID string
+}
+
+func (l *ListCalendarGrantsRequest) Validate() error {
+	if l.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if l.CalendarID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid calendar id")
+	}
+
+	return nil
+}
+
+type ListCalendarsRequest struct {
+	ActorID string
+}
+
+func (l *ListCalendarsRequest) Validate() error {
+	if l.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	return nil
+}
+
+type MoveEventRequest struct {
+	ActorID string
+	EventID string
+	// CalendarID is the calendar the event is moved to, a calendar of the creator of the event
+	CalendarID string
+}
+
+func (m *MoveEventRequest) Validate() error {
+	if m.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if m.EventID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid event id")
+	}
+
+	if m.CalendarID == "" {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "CalendarID", "invalid calendar id")
+	}
+
+	return nil
+}
+
+//go:generate mockgen -destination=../mock/mock_calendar_service.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core CalendarService
+type CalendarService interface {
+	CreateCalendar(ctx context.Context, req *CreateCalendarRequest) error
+	UpdateCalendar(ctx context.Context, req *UpdateCalendarRequest) error
+	DeleteCalendar(ctx context.Context, req *DeleteCalendarRequest) error
+	FindCalendarByID(ctx context.Context, req *FindCalendarByIDRequest) (*Calendar, error)
+	ListCalendars(ctx context.Context, req *ListCalendarsRequest) ([]Calendar, error)
+	MoveEvent(ctx context.Context, req *MoveEventRequest) error
+	ShareCalendar(ctx context.Context, req *ShareCalendarRequest) error
+	UnshareCalendar(ctx context.Context, req *UnshareCalendarRequest) error
+	ListCalendarGrants(ctx context.Context, req *ListCalendarGrantsRequest) ([]CalendarGrant, error)
+}
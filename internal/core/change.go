@@ -0,0 +1,89 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// ChangeCursor is the position of a change in the feed. The epoch identifies the feed,
+// a cursor is only meaningful to the feed that issued it.
+type ChangeCursor struct {
+	Epoch    string
+	Sequence uint64
+}
+
+// EventChange notifies the users involved in an event that it was changed.
+type EventChange struct {
+	// Cursor is assigned when the change is published
+	Cursor  ChangeCursor
+	EventID string
+	ActorID string
+	// OnBehalfOf is the owner of the calendar of the event when the actor is a delegate of theirs
+	OnBehalfOf string
+	Operation  HistoryOperation
+	// Event is the event after the change, or before it when it was deleted
+	Event *Event
+	// Audience is the creator and the attendees of the event, before and after the change,
+	// so that removed attendees also learn about it
+	Audience  []string
+	CreatedAt time.Time
+}
+
+func NewEventChange(eventID string, actorID string, op HistoryOperation, before, after *Event) EventChange {
+	event := after
+	if event == nil {
+		event = before
+	}
+
+	return EventChange{
+		EventID:   eventID,
+		ActorID:   actorID,
+		Operation: op,
+		Event:     event,
+		Audience:  audience(before, after),
+		CreatedAt: time.Now(),
+	}
+}
+
+// Involves reports whether the user is the creator or one of the attendees of the changed event.
+func (c *EventChange) Involves(actorID string) bool {
+	for _, a := range c.Audience {
+		if a == actorID {
+			return true
+		}
+	}
+	return false
+}
+
+func audience(events ...*Event) []string {
+	seen := make(map[string]struct{})
+	var users []string
+	add := func(user string) {
+		if _, ok := seen[user]; ok || user == "" {
+			return
+		}
+		seen[user] = struct{}{}
+		users = append(users, user)
+	}
+
+	for _, e := range events {
+		if e == nil {
+			continue
+		}
+		add(e.CreatedBy)
+		for _, i := range e.Invitations {
+			add(FormatUserID(i.UserID))
+		}
+	}
+	return users
+}
+
+//go:generate mockgen -destination=../mock/mock_event_change_broker.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core EventChangeBroker
+type EventChangeBroker interface {
+	// Publish assigns the change its cursor and delivers it to the matching subscribers.
+	Publish(change EventChange)
+	// Subscribe delivers the changes matching the filter, starting with the ones published after the
+	// given cursor when it is set. The channel is closed once ctx is done, or earlier when the
+	// subscriber falls behind, in which case it should subscribe again from its last cursor.
+	Subscribe(ctx context.Context, after *ChangeCursor, filter func(EventChange) bool) (<-chan EventChange, error)
+}
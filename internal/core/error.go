@@ -0,0 +1,31 @@
+package core
+
+import "errors"
+
+var (
+	ErrEventNotFound      = errors.New("event not found")
+	ErrEventAlreadyExists = errors.New("event already exists")
+	ErrInvitationNotFound = errors.New("invitation not found")
+	ErrBatchAborted       = errors.New("aborted because another mutation of the batch failed")
+
+	ErrChangeCursorExpired = errors.New("change cursor has expired, the changes after it are no longer available")
+
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+	ErrIdempotencyKeyReused   = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyPending  = errors.New("a request with the same idempotency key is still in progress")
+
+	ErrWebhookNotFound = errors.New("webhook not found")
+
+	ErrCalendarNotFound      = errors.New("calendar not found")
+	ErrDefaultCalendar       = errors.New("the default calendar can't be deleted")
+	ErrCalendarGrantNotFound = errors.New("calendar grant not found")
+
+	ErrGroupNotFound = errors.New("group not found")
+
+	ErrResourceNotFound = errors.New("resource not found")
+
+	ErrBookingPageNotFound = errors.New("booking page not found")
+	ErrSlotUnavailable     = errors.New("the slot is no longer available")
+
+	ErrPermissionDenied = errors.New("permission denied")
+)
@@ -2,6 +2,8 @@ package core
 
 import (
 	"context"
+	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/dzakaammar/event-scheduling-example/internal"
@@ -11,6 +13,12 @@ import (
 
 var validate *validator.Validate
 
+// MaxEventIDLength matches the size of the id column of the event table.
+const MaxEventIDLength = 50
+
+// eventIDPattern only allows URL-safe characters, so that an ID can be used as is in the REST paths.
+var eventIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._~-]*$`)
+
 func init() {
 	validate = validator.New()
 }
@@ -23,17 +31,64 @@ type Event struct {
 	CreatedBy   string     `db:"created_by"`
 	CreatedAt   time.Time  `db:"created_at"`
 	UpdatedAt   *time.Time `db:"updated_at"`
+	DeletedAt   *time.Time `db:"deleted_at"`
+	// CalendarID is the calendar of the creator the event belongs to, their default calendar when it
+	// is empty on creation
+	CalendarID string `db:"calendar_id"`
+	// Capacity is how many attendees can accept the invitation, 0 when it isn't limited. The ones
+	// who accept once it is full are waitlisted, see Respond.
+	Capacity int32 `validate:"gte=0" db:"capacity"`
 
 	Schedules   []Schedule   `validate:"required,dive,required"`
 	Invitations []Invitation `validate:"dive"`
+	Reminders   []Reminder   `validate:"max=10,dive"`
+	// Groups are invited along with the event, they are expanded into the invitations of their
+	// members when the event is written. They aren't stored, see InvitedGroups.
+	Groups []string `validate:"max=20,unique,dive,required"`
+	// Resources are the rooms and the equipment booked for the event, their status is decided
+	// when the event is written
+	Resources []ResourceBooking `validate:"max=20,unique=ResourceID,dive"`
+	// Guests are invited by email when they book a slot of a booking page, the event keeps them
+	// when it is updated
+	Guests []Guest `validate:"max=20,dive"`
 }
 
 func (e *Event) Validate() error {
 	_, err := time.LoadLocation(e.Timezone)
 	if err != nil {
-		return internal.WrapErr(internal.ErrInvalidTimezone, e.Timezone)
+		return internal.WrapFieldErr(internal.ErrInvalidTimezone, "Event.Timezone", e.Timezone)
+	}
+
+	err = validate.Struct(e)
+	if err != nil {
+		return internal.WrapCause(internal.ErrValidationFailed, err)
+	}
+	return nil
+}
+
+// ValidateEventID checks an ID supplied by the client, generated IDs are always valid.
+func ValidateEventID(id string) error {
+	if len(id) > MaxEventIDLength {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Event.ID", fmt.Sprintf("event id must not be longer than %d characters", MaxEventIDLength))
+	}
+
+	if !eventIDPattern.MatchString(id) {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Event.ID", "event id may only contain letters, digits, '.', '_', '~' and '-', and must start with a letter or digit")
+	}
+	return nil
+}
+
+// FreeBusy returns the event as seen by a user who may only know when its calendar is busy, only
+// its time is kept.
+func (e *Event) FreeBusy() Event {
+	return Event{
+		ID:         e.ID,
+		Timezone:   e.Timezone,
+		CreatedBy:  e.CreatedBy,
+		CreatedAt:  e.CreatedAt,
+		CalendarID: e.CalendarID,
+		Schedules:  e.Schedules,
 	}
-	return validate.Struct(e)
 }
 
 func (e *Event) GetUpdatedAt() string {
@@ -52,10 +107,95 @@ func NewEvent(createdBy string) *Event {
 	}
 }
 
+// EventCursor is the position of an event in the listing order, which is the newest created first.
+type EventCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+func (e *Event) Cursor() EventCursor {
+	return EventCursor{
+		CreatedAt: e.CreatedAt,
+		ID:        e.ID,
+	}
+}
+
+// EventFilter narrows down listed events, zero-valued fields are not filtered on.
+type EventFilter struct {
+	CreatedBy     string
+	Attendee      int32
+	Title         string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Timezone      string
+	CalendarID    string
+	// Viewer limits the events to those the user created, is invited to, or can see in a calendar
+	// shared with them. The events of a calendar only shared free/busy don't match on Title and
+	// Attendee, their content isn't the viewer's to search.
+	Viewer string
+	// After lists the events that come after the cursor
+	After *EventCursor
+	Limit int
+}
+
+type MutationType string
+
+const (
+	MutationType_Create MutationType = "CREATE"
+	MutationType_Update MutationType = "UPDATE"
+	MutationType_Delete MutationType = "DELETE"
+)
+
+// EventMutation is a write of a batch. A delete only needs the ID of the event.
+type EventMutation struct {
+	Type  MutationType
+	Event *Event
+	// Journal is written along with the mutation
+	Journal Journal
+}
+
+// BatchError is the failure of the mutation at Index, which made the whole batch fail.
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (b *BatchError) Error() string {
+	return fmt.Sprintf("mutation %d: %s", b.Index, b.Err.Error())
+}
+
+func (b *BatchError) Unwrap() error {
+	return b.Err
+}
+
 //go:generate mockgen -destination=../mock/mock_event_repository.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core EventRepository
 type EventRepository interface {
-	Store(ctx context.Context, e *Event) error
-	DeleteByID(ctx context.Context, id string) error
-	Update(ctx context.Context, e *Event) error
+	// The writes record the journal in the same transaction, so that either both or neither of
+	// them are committed.
+	Store(ctx context.Context, e *Event, journal Journal) error
+	DeleteByID(ctx context.Context, id string, journal Journal) error
+	Update(ctx context.Context, e *Event, journal Journal) error
 	FindByID(ctx context.Context, id string) (*Event, error)
+	// FindDeletedByID returns an event in the trash.
+	FindDeletedByID(ctx context.Context, id string) (*Event, error)
+	// RestoreByID takes the event out of the trash. It returns ErrSlotUnavailable when the slot it
+	// was booked for through a booking page was taken meanwhile, and declines the resources that
+	// were.
+	RestoreByID(ctx context.Context, id string, journal Journal) error
+	FindDeletedByCreator(ctx context.Context, createdBy string) ([]Event, error)
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
+	// RespondInvitation locks the event and hands it to respond, which returns the invitations it
+	// changed and the journal of the response. The RSVPs of the event are decided one at a time, so
+	// that two attendees can't take its last seat.
+	RespondInvitation(ctx context.Context, eventID string, respond func(event *Event) ([]Invitation, Journal, error)) error
+	// ReplaceReminders replaces the reminders the attendee set on the event.
+	ReplaceReminders(ctx context.Context, eventID string, userID int32, reminders []Reminder) error
+	List(ctx context.Context, filter EventFilter) ([]Event, error)
+	// ListInvolved returns the events the user created or is invited to and didn't decline, which
+	// may have an occurrence in [from, to).
+	ListInvolved(ctx context.Context, userID string, from time.Time, to time.Time) ([]Event, error)
+	// Batch applies all of the mutations or none of them. A failed mutation is reported as a *BatchError.
+	Batch(ctx context.Context, mutations []EventMutation) error
+	// Search returns the events visible to the actor that match the full-text query, most relevant first.
+	Search(ctx context.Context, actorID string, query string, offset int, limit int) ([]Event, error)
 }
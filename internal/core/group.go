@@ -0,0 +1,233 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/satori/uuid"
+)
+
+// MaxGroupMembers is how many users a group may have, all of them are invited along with the group.
+const MaxGroupMembers = 500
+
+// Group is a distribution list of users. Inviting a group to an event invites each of its members,
+// the invitations are linked back to the group.
+type Group struct {
+	ID          string
+	OwnerID     string
+	Name        string `validate:"required,max=100"`
+	Description string `validate:"max=1000"`
+	// Members are the ids of the users in the group
+	Members   []int32 `validate:"max=500,unique,dive,gt=0"`
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+func NewGroup(ownerID string) *Group {
+	return &Group{
+		ID:        uuid.NewV4().String(),
+		OwnerID:   ownerID,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (g *Group) Validate() error {
+	err := validate.Struct(g)
+	if err != nil {
+		return internal.WrapCause(internal.ErrValidationFailed, err)
+	}
+	return nil
+}
+
+// HasMember reports whether the user is a member of the group.
+func (g *Group) HasMember(userID int32) bool {
+	for _, member := range g.Members {
+		if member == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// VisibleTo reports whether the actor may see the group and invite it, which is its owner and
+// its members.
+func (g *Group) VisibleTo(actorID string) bool {
+	if g.OwnerID == actorID {
+		return true
+	}
+
+	userID, err := ParseUserID(actorID)
+	return err == nil && g.HasMember(userID)
+}
+
+// InviteGroup adds an invitation linked to the group for each of its members who isn't invited
+// yet, the members who are invited on their own keep their invitation.
+func (e *Event) InviteGroup(group *Group) {
+	for _, member := range group.Members {
+		if e.FindInvitation(member) != nil {
+			continue
+		}
+
+		invitation := NewInvitation(e.ID, member)
+		invitation.GroupID = group.ID
+		e.Invitations = append(e.Invitations, invitation)
+	}
+}
+
+// InvitedGroups returns the groups the attendees of the event were invited through.
+func (e *Event) InvitedGroups() []string {
+	var groups []string
+	seen := make(map[string]bool)
+	for _, invitation := range e.Invitations {
+		if invitation.GroupID == "" || seen[invitation.GroupID] {
+			continue
+		}
+		seen[invitation.GroupID] = true
+		groups = append(groups, invitation.GroupID)
+	}
+	return groups
+}
+
+//go:generate mockgen -destination=../mock/mock_group_repository.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core GroupRepository
+type GroupRepository interface {
+	// Store writes the group along with its members.
+	Store(ctx context.Context, group *Group) error
+	// Update replaces the name and the description of the group.
+	Update(ctx context.Context, group *Group) error
+	// DeleteByID deletes the group and the calendar grants given to it, the invitations of its
+	// members are kept but no longer linked to it.
+	DeleteByID(ctx context.Context, id string) error
+	// FindByID returns the group along with its members.
+	FindByID(ctx context.Context, id string) (*Group, error)
+	// FindByOwner returns the groups of the owner along with their members.
+	FindByOwner(ctx context.Context, ownerID string) ([]Group, error)
+	// AddMembers adds the users who aren't members yet, and applies the updates of the events that
+	// invite them along with their journals.
+	AddMembers(ctx context.Context, groupID string, userIDs []int32, updates []EventMutation) error
+	// RemoveMembers removes the users from the group, and applies the updates of the events that
+	// withdraw the invitations they got through the group along with their journals. The
+	// reminders they set on these events are deleted as well.
+	RemoveMembers(ctx context.Context, groupID string, userIDs []int32, updates []EventMutation) error
+	// FindUpcomingEventIDs returns the events that are not deleted, that the group was invited to
+	// and that still have an occurrence after the given time.
+	FindUpcomingEventIDs(ctx context.Context, groupID string, after time.Time) ([]string, error)
+}
+
+type CreateGroupRequest struct {
+	ActorID string
+	Group   *Group
+}
+
+func (c *CreateGroupRequest) Validate() error {
+	if c.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if c.Group == nil {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Group", "invalid group")
+	}
+
+	return c.Group.Validate()
+}
+
+type UpdateGroupRequest struct {
+	ActorID string
+	// Group replaces the name and the description, the members are changed on their own
+	Group *Group
+}
+
+func (u *UpdateGroupRequest) Validate() error {
+	if u.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if u.Group == nil || u.Group.ID == "" {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Group", "invalid group")
+	}
+
+	return nil
+}
+
+type DeleteGroupRequest struct {
+	ActorID string
+	GroupID string
+}
+
+func (d *DeleteGroupRequest) Validate() error {
+	if d.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if d.GroupID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid group id")
+	}
+
+	return nil
+}
+
+type FindGroupByIDRequest struct {
+	ActorID string
+	GroupID string
+}
+
+func (f *FindGroupByIDRequest) Validate() error {
+	if f.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if f.GroupID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid group id")
+	}
+
+	return nil
+}
+
+type ListGroupsRequest struct {
+	ActorID string
+}
+
+func (l *ListGroupsRequest) Validate() error {
+	if l.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	return nil
+}
+
+type ChangeGroupMembersRequest struct {
+	ActorID string
+	GroupID string
+	UserIDs []int32 `validate:"required,max=500,unique,dive,gt=0"`
+	// Propagate also invites the added members to, or uninvites the removed members from, the
+	// upcoming events the group was invited to
+	Propagate bool
+}
+
+func (c *ChangeGroupMembersRequest) Validate() error {
+	if c.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if c.GroupID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid group id")
+	}
+
+	err := validate.Struct(c)
+	if err != nil {
+		return internal.WrapCause(internal.ErrValidationFailed, err)
+	}
+	return nil
+}
+
+//go:generate mockgen -destination=../mock/mock_group_service.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core GroupService
+type GroupService interface {
+	CreateGroup(ctx context.Context, req *CreateGroupRequest) error
+	UpdateGroup(ctx context.Context, req *UpdateGroupRequest) error
+	DeleteGroup(ctx context.Context, req *DeleteGroupRequest) error
+	FindGroupByID(ctx context.Context, req *FindGroupByIDRequest) (*Group, error)
+	// ListGroups returns the groups of the actor.
+	ListGroups(ctx context.Context, req *ListGroupsRequest) ([]Group, error)
+	AddGroupMembers(ctx context.Context, req *ChangeGroupMembersRequest) error
+	RemoveGroupMembers(ctx context.Context, req *ChangeGroupMembersRequest) error
+}
@@ -0,0 +1,152 @@
+package core
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+type HistoryOperation string
+
+const (
+	HistoryOperation_Create  HistoryOperation = "CREATE"
+	HistoryOperation_Update  HistoryOperation = "UPDATE"
+	HistoryOperation_Delete  HistoryOperation = "DELETE"
+	HistoryOperation_Restore HistoryOperation = "RESTORE"
+	HistoryOperation_RSVP    HistoryOperation = "RSVP"
+)
+
+// FieldChange is the value of an event field before and after an operation.
+// Before is nil for created fields and After is nil for removed ones.
+type FieldChange struct {
+	Before any `json:"before,omitempty"`
+	After  any `json:"after,omitempty"`
+}
+
+// EventHistory is an entry of the append-only audit trail of an event.
+type EventHistory struct {
+	ID      int64
+	EventID string
+	ActorID string
+	// OnBehalfOf is the owner of the calendar of the event when the actor is a delegate of theirs
+	OnBehalfOf string
+	Operation  HistoryOperation
+	Changes    map[string]FieldChange
+	CreatedAt  time.Time
+}
+
+func NewEventHistory(eventID string, actorID string, op HistoryOperation, before, after *Event) EventHistory {
+	return EventHistory{
+		EventID:   eventID,
+		ActorID:   actorID,
+		Operation: op,
+		Changes:   DiffEvent(before, after),
+		CreatedAt: time.Now(),
+	}
+}
+
+// Journal is what a write of an event records in the same transaction as the write itself: its
+// entry of the audit trail, its domain events and the idempotency key it completes, if any.
+type Journal struct {
+	History        *EventHistory
+	Outbox         []DomainEvent
+	IdempotencyKey *IdempotencyKey
+}
+
+// NewJournal returns the journal of the change, whose history is the difference between before and
+// after.
+func NewJournal(change EventChange, before, after *Event) Journal {
+	history := NewEventHistory(change.EventID, change.ActorID, change.Operation, before, after)
+	history.OnBehalfOf = change.OnBehalfOf
+	return Journal{
+		History: &history,
+		Outbox:  []DomainEvent{NewDomainEvent(change)},
+	}
+}
+
+// NewPromotedJournal returns the journal of the change, which also tells the attendees who were
+// promoted off the waitlist that they were given a seat.
+func NewPromotedJournal(change EventChange, before, after *Event, promoted []Invitation) Journal {
+	journal := NewJournal(change, before, after)
+	for _, invitation := range promoted {
+		journal.Outbox = append(journal.Outbox, NewWaitlistPromotedDomainEvent(change, invitation.UserID))
+	}
+	return journal
+}
+
+type scheduleSnapshot struct {
+	StartTime         int64         `json:"start_time"`
+	DurationInMinutes int64         `json:"duration_in_minutes"`
+	IsFullDay         bool          `json:"is_full_day"`
+	RecurringType     RecurringType `json:"recurring_type"`
+}
+
+type attendeeSnapshot struct {
+	UserID int32            `json:"user_id"`
+	Status InvitationStatus `json:"status"`
+}
+
+// snapshot returns the fields of the event that are tracked by the audit trail, keyed by their
+// API name. Generated identifiers are left out so that re-created schedules don't show up as changes.
+func (e *Event) snapshot() map[string]any {
+	if e == nil {
+		return map[string]any{}
+	}
+
+	schedules := make([]scheduleSnapshot, len(e.Schedules))
+	for index, s := range e.Schedules {
+		schedules[index] = scheduleSnapshot{
+			StartTime:         s.StartTime,
+			DurationInMinutes: s.DurationInMinutes,
+			IsFullDay:         s.IsFullDay,
+			RecurringType:     s.RecurringType,
+		}
+	}
+
+	attendees := make([]attendeeSnapshot, len(e.Invitations))
+	for index, i := range e.Invitations {
+		attendees[index] = attendeeSnapshot{
+			UserID: i.UserID,
+			Status: i.Status,
+		}
+	}
+
+	return map[string]any{
+		"title":       e.Title,
+		"description": e.Description,
+		"timezone":    e.Timezone,
+		"schedule":    schedules,
+		"attendees":   attendees,
+		"capacity":    e.Capacity,
+	}
+}
+
+// DiffEvent returns the tracked fields that differ between before and after.
+// Either of them can be nil, i.e: for a newly created or a deleted event.
+func DiffEvent(before, after *Event) map[string]FieldChange {
+	beforeFields, afterFields := before.snapshot(), after.snapshot()
+
+	changes := make(map[string]FieldChange)
+	for field, afterValue := range afterFields {
+		beforeValue, ok := beforeFields[field]
+		if ok && reflect.DeepEqual(beforeValue, afterValue) {
+			continue
+		}
+		changes[field] = FieldChange{Before: beforeValue, After: afterValue}
+	}
+
+	for field, beforeValue := range beforeFields {
+		if _, ok := afterFields[field]; !ok {
+			changes[field] = FieldChange{Before: beforeValue}
+		}
+	}
+
+	return changes
+}
+
+//go:generate mockgen -destination=../mock/mock_event_history_repository.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core EventHistoryRepository
+type EventHistoryRepository interface {
+	// FindByEventID returns at most limit entries of the event older than beforeID, newest first.
+	// A zero beforeID starts from the newest entry.
+	FindByEventID(ctx context.Context, eventID string, beforeID int64, limit int) ([]EventHistory, error)
+}
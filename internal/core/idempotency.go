@@ -0,0 +1,133 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+const MaxIdempotencyKeyLength = 100
+
+type IdempotencyKeyState string
+
+const (
+	// IdempotencyKeyState_Pending is the state while the request that reserved the key runs
+	IdempotencyKeyState_Pending IdempotencyKeyState = "PENDING"
+	// IdempotencyKeyState_Completed is the state once the event of the request is created
+	IdempotencyKeyState_Completed IdempotencyKeyState = "COMPLETED"
+)
+
+// IdempotencyKey remembers the event created by the first request carrying the key,
+// so that retries of the same request get the same event.
+type IdempotencyKey struct {
+	ActorID string
+	Key     string
+	// RequestHash is the fingerprint of the request payload, see CreateEventRequest.Fingerprint
+	RequestHash string
+	EventID     string
+	State       IdempotencyKeyState
+	// Response is the event created by the request, it is only set once the key is completed
+	Response  *Event
+	CreatedAt time.Time
+	// ExpiresAt is the end of the lease of the request while the key is pending
+	ExpiresAt time.Time
+}
+
+type scheduleFingerprint struct {
+	StartTime         int64         `json:"start_time"`
+	DurationInMinutes int64         `json:"duration_in_minutes"`
+	IsFullDay         bool          `json:"is_full_day"`
+	RecurringType     RecurringType `json:"recurring_type"`
+	RecurringInterval int64         `json:"recurring_interval"`
+}
+
+type reminderFingerprint struct {
+	OffsetMinutes int64           `json:"offset_minutes"`
+	Channel       ReminderChannel `json:"channel"`
+}
+
+type guestFingerprint struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// requestFingerprint holds every field of the create request that is set by the client.
+type requestFingerprint struct {
+	EventID     string                `json:"event_id"`
+	Title       string                `json:"title"`
+	Description string                `json:"description"`
+	Timezone    string                `json:"timezone"`
+	CalendarID  string                `json:"calendar_id"`
+	Capacity    int32                 `json:"capacity"`
+	Schedules   []scheduleFingerprint `json:"schedules"`
+	Attendees   []int32               `json:"attendees"`
+	Groups      []string              `json:"groups"`
+	Resources   []string              `json:"resources"`
+	Reminders   []reminderFingerprint `json:"reminders"`
+	Guests      []guestFingerprint    `json:"guests"`
+}
+
+// Fingerprint returns a hash of the fields of the request that are set by the client. Generated
+// identifiers are left out, so that retries of the same payload have the same fingerprint, but the
+// id of the event is kept when the client chose it.
+func (c *CreateEventRequest) Fingerprint() string {
+	fingerprint := requestFingerprint{
+		Title:       c.Event.Title,
+		Description: c.Event.Description,
+		Timezone:    c.Event.Timezone,
+		CalendarID:  c.Event.CalendarID,
+		Capacity:    c.Event.Capacity,
+		Schedules:   make([]scheduleFingerprint, len(c.Event.Schedules)),
+		Attendees:   make([]int32, len(c.Event.Invitations)),
+		Groups:      c.Event.Groups,
+		Resources:   make([]string, len(c.Event.Resources)),
+		Reminders:   make([]reminderFingerprint, len(c.Event.Reminders)),
+		Guests:      make([]guestFingerprint, len(c.Event.Guests)),
+	}
+	if c.EventIDChosen {
+		fingerprint.EventID = c.Event.ID
+	}
+	for index, s := range c.Event.Schedules {
+		fingerprint.Schedules[index] = scheduleFingerprint{
+			StartTime:         s.StartTime,
+			DurationInMinutes: s.DurationInMinutes,
+			IsFullDay:         s.IsFullDay,
+			RecurringType:     s.RecurringType,
+			RecurringInterval: s.RecurringInterval,
+		}
+	}
+	for index, i := range c.Event.Invitations {
+		fingerprint.Attendees[index] = i.UserID
+	}
+	for index, r := range c.Event.Resources {
+		fingerprint.Resources[index] = r.ResourceID
+	}
+	for index, r := range c.Event.Reminders {
+		fingerprint.Reminders[index] = reminderFingerprint{
+			OffsetMinutes: r.OffsetMinutes,
+			Channel:       r.Channel,
+		}
+	}
+	for index, g := range c.Event.Guests {
+		fingerprint.Guests[index] = guestFingerprint{
+			Name:  g.Name,
+			Email: g.Email,
+		}
+	}
+
+	b, _ := json.Marshal(fingerprint) //nolint:errchkjson // the fingerprint only holds plain values
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+//go:generate mockgen -destination=../mock/mock_idempotency_key_repository.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core IdempotencyKeyRepository
+type IdempotencyKeyRepository interface {
+	// Reserve stores the key unless the actor already holds it unexpired, in which case it returns false.
+	Reserve(ctx context.Context, key *IdempotencyKey) (bool, error)
+	Find(ctx context.Context, actorID string, key string) (*IdempotencyKey, error)
+	// Release deletes the key if it is still pending under the reservation of key.
+	Release(ctx context.Context, key *IdempotencyKey) error
+	PurgeExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -2,6 +2,8 @@ package core
 
 import (
 	"encoding/base64"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/satori/uuid"
@@ -13,6 +15,9 @@ const (
 	InvitationStatus_Unknown InvitationStatus = iota
 	InvitationStatus_Confirmed
 	InvitationStatus_Declined
+	// InvitationStatus_Waitlisted is an invitation accepted once the event was full, it is confirmed
+	// when a seat frees up
+	InvitationStatus_Waitlisted
 )
 
 type Invitation struct {
@@ -22,6 +27,13 @@ type Invitation struct {
 	Status    InvitationStatus `db:"status"`
 	Token     string           `validate:"required" db:"token"`
 	UpdatedAt *time.Time       `db:"updated_at"`
+	// GroupID is the group the attendee was invited through, empty when they were invited on their own
+	GroupID string `db:"group_id"`
+	// Reminders replace the reminders of the event for the attendee
+	Reminders []Reminder `validate:"max=10,dive"`
+	// WaitlistedAt is when the attendee accepted the invitation of the full event, the waitlist is
+	// in its order
+	WaitlistedAt *time.Time `db:"waitlisted_at"`
 }
 
 func NewInvitation(eventID string, userID int32) Invitation {
@@ -34,3 +46,137 @@ func NewInvitation(eventID string, userID int32) Invitation {
 		Token:   base64.StdEncoding.EncodeToString([]byte(id)), // use base64-encoded id for simplicity sake
 	}
 }
+
+// ParseUserID converts an actor id into the id of the user it represents.
+func ParseUserID(actorID string) (int32, error) {
+	userID, err := strconv.ParseInt(actorID, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return int32(userID), nil
+}
+
+// FormatUserID converts the id of a user into the actor id representing it.
+func FormatUserID(userID int32) string {
+	return strconv.FormatInt(int64(userID), 10)
+}
+
+// FindInvitation returns the invitation of the given user, or nil if the user isn't invited.
+func (e *Event) FindInvitation(userID int32) *Invitation {
+	for index := range e.Invitations {
+		if e.Invitations[index].UserID == userID {
+			return &e.Invitations[index]
+		}
+	}
+	return nil
+}
+
+// Involves reports whether the user is the creator of the event or is invited to it.
+func (e *Event) Involves(actorID string) bool {
+	if e.CreatedBy == actorID {
+		return true
+	}
+
+	userID, err := ParseUserID(actorID)
+	return err == nil && e.FindInvitation(userID) != nil
+}
+
+// Attends reports whether the attendee may attend the event, they didn't decline the invitation
+// and aren't waitlisted.
+func (i *Invitation) Attends() bool {
+	return i.Status != InvitationStatus_Declined && i.Status != InvitationStatus_Waitlisted
+}
+
+// Attending returns how many attendees accepted the invitation and have a seat.
+func (e *Event) Attending() int {
+	count := 0
+	for _, i := range e.Invitations {
+		if i.Status == InvitationStatus_Confirmed {
+			count++
+		}
+	}
+	return count
+}
+
+// IsFull reports whether the event has a capacity and no seat left.
+func (e *Event) IsFull() bool {
+	return e.Capacity > 0 && e.Attending() >= int(e.Capacity)
+}
+
+// Waitlist returns the waitlisted invitations, the earliest to accept first.
+func (e *Event) Waitlist() []Invitation {
+	waitlist := []Invitation{}
+	for _, i := range e.Invitations {
+		if i.Status == InvitationStatus_Waitlisted {
+			waitlist = append(waitlist, i)
+		}
+	}
+
+	sort.SliceStable(waitlist, func(a, b int) bool {
+		at, bt := waitlist[a].WaitlistedAt, waitlist[b].WaitlistedAt
+		if at == nil || bt == nil {
+			return at != nil
+		}
+		return at.Before(*bt)
+	})
+	return waitlist
+}
+
+// Respond records the response of the attendee to their invitation. Accepting the invitation of a
+// full event puts the attendee at the end of the waitlist, or keeps them where they are on it. An
+// attendee who declines frees their seat, which is given to the first one on the waitlist.
+//
+// It returns the invitations that changed, the one of the attendee first, followed by the ones
+// promoted off the waitlist.
+func (e *Event) Respond(userID int32, status InvitationStatus, now time.Time) ([]Invitation, error) {
+	invitation := e.FindInvitation(userID)
+	if invitation == nil {
+		return nil, ErrInvitationNotFound
+	}
+
+	switch {
+	case status != InvitationStatus_Confirmed:
+		invitation.WaitlistedAt = nil
+	case invitation.Status == InvitationStatus_Confirmed, invitation.Status == InvitationStatus_Waitlisted:
+		status = invitation.Status
+	case e.IsFull():
+		status = InvitationStatus_Waitlisted
+		invitation.WaitlistedAt = &now
+	}
+	invitation.Status = status
+	invitation.UpdatedAt = &now
+
+	changed := []Invitation{*invitation}
+	return append(changed, e.promote(now)...), nil
+}
+
+// KeepInvitations carries the invitations of the event before the update over to the attendees
+// who are still invited, so that their responses and their place on the waitlist are kept. The
+// seats that freed up, e.g: because the capacity went up, are given to the first ones on the
+// waitlist, whose invitations are returned.
+func (e *Event) KeepInvitations(before *Event, now time.Time) []Invitation {
+	for index := range e.Invitations {
+		if existing := before.FindInvitation(e.Invitations[index].UserID); existing != nil {
+			e.Invitations[index] = *existing
+		}
+	}
+	return e.promote(now)
+}
+
+// promote confirms the first ones on the waitlist while the event has seats left, and returns
+// their invitations.
+func (e *Event) promote(now time.Time) []Invitation {
+	var promoted []Invitation
+	for _, waitlisted := range e.Waitlist() {
+		if e.IsFull() {
+			break
+		}
+
+		invitation := e.FindInvitation(waitlisted.UserID)
+		invitation.Status = InvitationStatus_Confirmed
+		invitation.WaitlistedAt = nil
+		invitation.UpdatedAt = &now
+		promoted = append(promoted, *invitation)
+	}
+	return promoted
+}
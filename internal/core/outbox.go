@@ -0,0 +1,110 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/satori/uuid"
+)
+
+type DomainEventType string
+
+const (
+	DomainEventType_EventCreated        DomainEventType = "EventCreated"
+	DomainEventType_EventUpdated        DomainEventType = "EventUpdated"
+	DomainEventType_EventDeleted        DomainEventType = "EventDeleted"
+	DomainEventType_EventRestored       DomainEventType = "EventRestored"
+	DomainEventType_InvitationResponded DomainEventType = "InvitationResponded"
+	DomainEventType_WaitlistPromoted    DomainEventType = "WaitlistPromoted"
+)
+
+// DomainEvent is a committed change of an event, written to the outbox in the same transaction
+// as the change itself so that it is published even when the process dies right after the commit.
+type DomainEvent struct {
+	// ID stays the same every time the domain event is published, consumers drop the duplicates by it
+	ID      string
+	Type    DomainEventType
+	EventID string
+	ActorID string
+	// Event is the event after the change, or before it when it was deleted
+	Event      *Event
+	Audience   []string
+	OccurredAt time.Time
+	// Attempts is how many times publishing the domain event failed so far
+	Attempts int
+}
+
+func NewDomainEvent(change EventChange) DomainEvent {
+	return DomainEvent{
+		ID:         uuid.NewV4().String(),
+		Type:       domainEventType(change.Operation),
+		EventID:    change.EventID,
+		ActorID:    change.ActorID,
+		Event:      change.Event,
+		Audience:   change.Audience,
+		OccurredAt: change.CreatedAt,
+	}
+}
+
+// NewWaitlistPromotedDomainEvent tells the attendee of the change that they were given a seat when
+// someone else declined.
+func NewWaitlistPromotedDomainEvent(change EventChange, userID int32) DomainEvent {
+	event := NewDomainEvent(change)
+	event.Type = DomainEventType_WaitlistPromoted
+	event.Audience = []string{FormatUserID(userID)}
+	return event
+}
+
+func domainEventType(op HistoryOperation) DomainEventType {
+	switch op {
+	case HistoryOperation_Create:
+		return DomainEventType_EventCreated
+	case HistoryOperation_Update:
+		return DomainEventType_EventUpdated
+	case HistoryOperation_Delete:
+		return DomainEventType_EventDeleted
+	case HistoryOperation_Restore:
+		return DomainEventType_EventRestored
+	case HistoryOperation_RSVP:
+		return DomainEventType_InvitationResponded
+	default:
+		return ""
+	}
+}
+
+// Operation returns the operation of the change the domain event was made of. It is empty for a
+// domain event that isn't a change of its own, e.g: DomainEventType_WaitlistPromoted.
+func (t DomainEventType) Operation() HistoryOperation {
+	switch t {
+	case DomainEventType_EventCreated:
+		return HistoryOperation_Create
+	case DomainEventType_EventUpdated:
+		return HistoryOperation_Update
+	case DomainEventType_EventDeleted:
+		return HistoryOperation_Delete
+	case DomainEventType_EventRestored:
+		return HistoryOperation_Restore
+	case DomainEventType_InvitationResponded:
+		return HistoryOperation_RSVP
+	default:
+		return ""
+	}
+}
+
+//go:generate mockgen -destination=../mock/mock_event_publisher.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core EventPublisher
+type EventPublisher interface {
+	// Publish hands the domain event over to its consumers. The same domain event may be published
+	// more than once, e.g: when the relay stopped before recording that it was published.
+	Publish(ctx context.Context, event DomainEvent) error
+}
+
+//go:generate mockgen -destination=../mock/mock_outbox_repository.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core OutboxRepository
+type OutboxRepository interface {
+	// ClaimPending returns the unpublished domain events that are due, oldest first, and hides them
+	// from other relays until leaseUntil.
+	ClaimPending(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]DomainEvent, error)
+	MarkPublished(ctx context.Context, id string, publishedAt time.Time) error
+	// Retry records a failed attempt, the domain event is claimed again at nextAttemptAt.
+	Retry(ctx context.Context, id string, lastError string, nextAttemptAt time.Time) error
+	PurgePublished(ctx context.Context, publishedBefore time.Time) (int64, error)
+}
@@ -0,0 +1,256 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+)
+
+// clockLayout is the layout of the times of the day, e.g: the quiet hours.
+const clockLayout = "15:04"
+
+type NotificationDelivery string
+
+const (
+	NotificationDelivery_Immediate NotificationDelivery = "IMMEDIATE"
+	// NotificationDelivery_Digest holds the notifications back and sends them together once a day
+	NotificationDelivery_Digest NotificationDelivery = "DIGEST"
+)
+
+// NotificationPreference is how a user wants to be notified about the changes of the events they
+// are involved in. The channels are the ones reminders are sent on.
+type NotificationPreference struct {
+	UserID   string
+	Channels []ReminderChannel `validate:"unique,dive,oneof=EMAIL PUSH"`
+	// Timezone is the timezone of the quiet hours and of the digest time
+	Timezone string `validate:"required"`
+	// QuietHoursStart and QuietHoursEnd are times of the day, e.g: "22:00" and "07:00". The
+	// notifications due in between are held back until the quiet hours end. Both are empty when
+	// the user has no quiet hours.
+	QuietHoursStart string
+	QuietHoursEnd   string
+	Delivery        NotificationDelivery `validate:"oneof=IMMEDIATE DIGEST"`
+	// DigestTime is the time of the day the digest is sent at
+	DigestTime string
+	// MutedEventIDs are the events the user isn't notified about, they are muted one by one
+	MutedEventIDs []string
+	UpdatedAt     *time.Time
+}
+
+// DefaultNotificationPreference is the preference of a user who hasn't set one, it notifies them
+// right away on every channel.
+func DefaultNotificationPreference(userID string) *NotificationPreference {
+	return &NotificationPreference{
+		UserID:     userID,
+		Channels:   []ReminderChannel{ReminderChannel_Email, ReminderChannel_Push},
+		Timezone:   "UTC",
+		Delivery:   NotificationDelivery_Immediate,
+		DigestTime: "08:00",
+	}
+}
+
+func (p *NotificationPreference) Validate() error {
+	_, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return internal.WrapFieldErr(internal.ErrInvalidTimezone, "NotificationPreference.Timezone", p.Timezone)
+	}
+
+	err = validate.Struct(p)
+	if err != nil {
+		return internal.WrapCause(internal.ErrValidationFailed, err)
+	}
+
+	if _, err := time.Parse(clockLayout, p.DigestTime); err != nil {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "NotificationPreference.DigestTime", "digest time must be a time of the day, e.g: 08:00")
+	}
+
+	if p.QuietHoursStart == "" && p.QuietHoursEnd == "" {
+		return nil
+	}
+
+	if _, err := time.Parse(clockLayout, p.QuietHoursStart); err != nil {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "NotificationPreference.QuietHoursStart", "quiet hours must be times of the day, e.g: 22:00")
+	}
+
+	if _, err := time.Parse(clockLayout, p.QuietHoursEnd); err != nil {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "NotificationPreference.QuietHoursEnd", "quiet hours must be times of the day, e.g: 07:00")
+	}
+
+	if p.QuietHoursStart == p.QuietHoursEnd {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "NotificationPreference.QuietHoursEnd", "quiet hours must not start and end at the same time")
+	}
+	return nil
+}
+
+// Notifies reports whether the user wants to be notified on the channel about the event.
+func (p *NotificationPreference) Notifies(channel ReminderChannel, eventID string) bool {
+	for _, id := range p.MutedEventIDs {
+		if id == eventID {
+			return false
+		}
+	}
+
+	for _, c := range p.Channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// DeliverAt returns when a notification due at now is delivered: now unless it is held back for
+// the digest, or until the quiet hours end. A digest due in the quiet hours is held back until
+// they end as well.
+func (p *NotificationPreference) DeliverAt(now time.Time) time.Time {
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	at := now.In(loc)
+	if p.Delivery == NotificationDelivery_Digest {
+		at = nextClock(at, p.DigestTime)
+	}
+	return p.QuietUntil(at).In(now.Location())
+}
+
+// QuietUntil returns when the quiet hours t falls in end, or t when it isn't in the quiet hours.
+func (p *NotificationPreference) QuietUntil(t time.Time) time.Time {
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	at := t.In(loc)
+	if !p.inQuietHours(at) {
+		return t
+	}
+	return nextClock(at, p.QuietHoursEnd).In(t.Location())
+}
+
+func (p *NotificationPreference) inQuietHours(t time.Time) bool {
+	if p.QuietHoursStart == "" || p.QuietHoursEnd == "" {
+		return false
+	}
+
+	start, end := clockMinutes(p.QuietHoursStart), clockMinutes(p.QuietHoursEnd)
+	minutes := t.Hour()*60 + t.Minute()
+	if start < end {
+		return minutes >= start && minutes < end
+	}
+	// the quiet hours span midnight
+	return minutes >= start || minutes < end
+}
+
+// nextClock returns the first time at or after t that is the given time of the day.
+func nextClock(t time.Time, clock string) time.Time {
+	minutes := clockMinutes(clock)
+	at := time.Date(t.Year(), t.Month(), t.Day(), minutes/60, minutes%60, 0, 0, t.Location())
+	if at.Before(t) {
+		at = time.Date(t.Year(), t.Month(), t.Day()+1, minutes/60, minutes%60, 0, 0, t.Location())
+	}
+	return at
+}
+
+func clockMinutes(clock string) int {
+	t, err := time.Parse(clockLayout, clock)
+	if err != nil {
+		return 0
+	}
+	return t.Hour()*60 + t.Minute()
+}
+
+// DigestItem is a notification held back until SendAfter, it is sent along with the other items
+// of the user that are due by then.
+type DigestItem struct {
+	ID     int64
+	UserID string
+	// Key identifies the notification, an item held back before with the same key is kept instead
+	Key     string
+	Subject string
+	Body    string
+	// CalendarMethod and Calendar are the .ics attachment of the notification, if any
+	CalendarMethod string
+	Calendar       []byte
+	SendAfter      time.Time
+	CreatedAt      time.Time
+}
+
+//go:generate mockgen -destination=../mock/mock_notification_preference_repository.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core NotificationPreferenceRepository
+type NotificationPreferenceRepository interface {
+	// FindByUserID returns the preference of the user along with the events they muted, or the
+	// default preference when they haven't set one.
+	FindByUserID(ctx context.Context, userID string) (*NotificationPreference, error)
+	// Upsert stores the preference, the muted events are left as they are.
+	Upsert(ctx context.Context, preference *NotificationPreference) error
+	MuteEvent(ctx context.Context, userID string, eventID string) error
+	UnmuteEvent(ctx context.Context, userID string, eventID string) error
+}
+
+//go:generate mockgen -destination=../mock/mock_notification_digest_repository.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core NotificationDigestRepository
+type NotificationDigestRepository interface {
+	// Hold stores the item unless the user already has one with the same key.
+	Hold(ctx context.Context, item *DigestItem) error
+	// ClaimDue returns at most limit items that are due at now, grouped by user, and postpones them
+	// until leaseUntil so that they aren't claimed again while they are being sent.
+	ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]DigestItem, error)
+	// Delete removes the items once they were sent.
+	Delete(ctx context.Context, ids []int64) error
+}
+
+type GetNotificationPreferenceRequest struct {
+	ActorID string
+}
+
+func (g *GetNotificationPreferenceRequest) Validate() error {
+	if g.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	return nil
+}
+
+type UpdateNotificationPreferenceRequest struct {
+	ActorID string
+	// Preference replaces the preference of the actor, except for the events they muted
+	Preference *NotificationPreference
+}
+
+func (u *UpdateNotificationPreferenceRequest) Validate() error {
+	if u.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if u.Preference == nil {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Preference", "invalid notification preference")
+	}
+
+	return u.Preference.Validate()
+}
+
+type MuteEventRequest struct {
+	ActorID string
+	EventID string
+	// Muted is false to unmute the event
+	Muted bool
+}
+
+func (m *MuteEventRequest) Validate() error {
+	if m.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if m.EventID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid event id")
+	}
+
+	return nil
+}
+
+//go:generate mockgen -destination=../mock/mock_notification_preference_service.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core NotificationPreferenceService
+type NotificationPreferenceService interface {
+	GetNotificationPreference(ctx context.Context, req *GetNotificationPreferenceRequest) (*NotificationPreference, error)
+	UpdateNotificationPreference(ctx context.Context, req *UpdateNotificationPreferenceRequest) error
+	MuteEvent(ctx context.Context, req *MuteEventRequest) error
+}
@@ -0,0 +1,180 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/satori/uuid"
+)
+
+type ReminderChannel string
+
+const (
+	ReminderChannel_Email ReminderChannel = "EMAIL"
+	ReminderChannel_Push  ReminderChannel = "PUSH"
+)
+
+// Reminder is sent OffsetMinutes before every occurrence of an event, through its channel.
+type Reminder struct {
+	ID      string `db:"id"`
+	EventID string `db:"event_id"`
+	// UserID is the attendee who set the reminder. It is zero for the reminders of the event,
+	// which are sent to its creator and to the attendees who didn't set reminders of their own.
+	UserID int32 `db:"user_id"`
+	// OffsetMinutes is at most four weeks
+	OffsetMinutes int64           `db:"offset_minutes" validate:"min=0,max=40320"`
+	Channel       ReminderChannel `db:"channel" validate:"oneof=EMAIL PUSH"`
+}
+
+func NewReminder(eventID string, userID int32, offsetMinutes int64, channel ReminderChannel) Reminder {
+	return Reminder{
+		ID:            uuid.NewV4().String(),
+		EventID:       eventID,
+		UserID:        userID,
+		OffsetMinutes: offsetMinutes,
+		Channel:       channel,
+	}
+}
+
+func (r *Reminder) Offset() time.Duration {
+	return time.Duration(r.OffsetMinutes) * time.Minute
+}
+
+// NextOccurrence returns the start of the first occurrence of the schedule at or after the given
+// time, ok is false when there's none.
+func (s *Schedule) NextOccurrence(from time.Time) (time.Time, bool) {
+	start := time.Unix(s.StartTime, 0).UTC()
+	if !from.After(start) {
+		return start, true
+	}
+
+	if s.RecurringInterval <= 0 {
+		return time.Time{}, false
+	}
+
+	interval := time.Duration(s.RecurringInterval) * time.Second
+	occurrences := (from.Sub(start) + interval - 1) / interval
+	return start.Add(occurrences * interval), true
+}
+
+// NextOccurrence returns the start of the first occurrence of any of the schedules of the event
+// at or after the given time, ok is false when there's none.
+func (e *Event) NextOccurrence(from time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	for index := range e.Schedules {
+		occurrence, ok := e.Schedules[index].NextOccurrence(from)
+		if ok && (!found || occurrence.Before(next)) {
+			next, found = occurrence, true
+		}
+	}
+	return next, found
+}
+
+// ReminderRecipients returns the actor ids the reminder is sent to: the attendee who set it, or
+// for a reminder of the event its creator and the attendees without reminders of their own.
+// Attendees who declined the invitation or are on the waitlist don't get reminders.
+func (e *Event) ReminderRecipients(r *Reminder) []string {
+	if r.UserID != 0 {
+		invitation := e.FindInvitation(r.UserID)
+		if invitation == nil || !invitation.Attends() {
+			return nil
+		}
+		return []string{FormatUserID(r.UserID)}
+	}
+
+	recipients := []string{e.CreatedBy}
+	for _, invitation := range e.Invitations {
+		if !invitation.Attends() || len(invitation.Reminders) > 0 {
+			continue
+		}
+		recipients = append(recipients, FormatUserID(invitation.UserID))
+	}
+	return recipients
+}
+
+type ReminderDeliveryStatus string
+
+const (
+	ReminderDeliveryStatus_Pending ReminderDeliveryStatus = "PENDING"
+	ReminderDeliveryStatus_Sent    ReminderDeliveryStatus = "SENT"
+	// ReminderDeliveryStatus_Failed is a delivery that was given up on after the last attempt
+	ReminderDeliveryStatus_Failed ReminderDeliveryStatus = "FAILED"
+	// ReminderDeliveryStatus_Skipped is a delivery the recipient didn't want, they turned the
+	// channel off or muted the event
+	ReminderDeliveryStatus_Skipped ReminderDeliveryStatus = "SKIPPED"
+)
+
+// ReminderDelivery is a reminder of one occurrence to one recipient. There is at most one delivery
+// per recipient, event, channel, offset and occurrence, which is what makes a reminder fire once.
+type ReminderDelivery struct {
+	ID            int64
+	EventID       string
+	RecipientID   string
+	Channel       ReminderChannel
+	OffsetMinutes int64
+	OccurrenceAt  time.Time
+	Status        ReminderDeliveryStatus
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
+
+// Notification is a message to a user about an event, or their agenda of the day. The notifier of
+// its channel sends it.
+type Notification struct {
+	// ID is the same on every attempt, so that the notifier can drop duplicates
+	ID          string
+	Channel     ReminderChannel
+	RecipientID string
+	Event       *Event
+	// OccurrenceAt is the start of the occurrence the notification is about
+	OccurrenceAt time.Time
+	// Agenda is set instead of Event and OccurrenceAt when the notification is the daily agenda
+	Agenda *Agenda
+}
+
+//go:generate mockgen -destination=../mock/mock_notifier.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core Notifier
+type Notifier interface {
+	Notify(ctx context.Context, notification *Notification) error
+}
+
+//go:generate mockgen -destination=../mock/mock_reminder_repository.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core ReminderRepository
+type ReminderRepository interface {
+	// ClaimDue returns at most limit reminders of the events that aren't deleted which are due at
+	// now, and postpones them until leaseUntil so that they aren't claimed again meanwhile.
+	ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]Reminder, error)
+	// Fire logs the deliveries, skipping the ones that were already logged, and reschedules the
+	// reminder to nextFireAt in the same transaction. A nil nextFireAt is a reminder that has no
+	// occurrence left.
+	Fire(ctx context.Context, reminderID string, deliveries []ReminderDelivery, nextFireAt *time.Time) error
+	// ClaimDueDeliveries returns at most limit pending deliveries that are due at now, and postpones
+	// them until leaseUntil so that they aren't claimed again while they are being sent.
+	ClaimDueDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]ReminderDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *ReminderDelivery) error
+}
+
+type SetRemindersRequest struct {
+	ActorID   string
+	EventID   string
+	Reminders []Reminder `validate:"max=10,dive"`
+}
+
+func (s *SetRemindersRequest) Validate() error {
+	if _, err := ParseUserID(s.ActorID); err != nil {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if s.EventID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid event id")
+	}
+
+	err := validate.Struct(s)
+	if err != nil {
+		return internal.WrapCause(internal.ErrValidationFailed, err)
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/satori/uuid"
+)
+
+// MaxResourceWindow is the longest window the free/busy and the availability of resources are
+// looked up for.
+const MaxResourceWindow = 31 * 24 * time.Hour
+
+type ResourceType string
+
+const (
+	ResourceType_Room      ResourceType = "ROOM"
+	ResourceType_Equipment ResourceType = "EQUIPMENT"
+)
+
+// Resource is a meeting room or a piece of equipment that is booked by attaching it to events.
+// Resources are shared by every user, only their creator changes them.
+type Resource struct {
+	ID   string
+	Name string       `validate:"required,max=100"`
+	Type ResourceType `validate:"oneof=ROOM EQUIPMENT"`
+	// Capacity is how many people the resource fits, 0 when it doesn't apply
+	Capacity  int32  `validate:"gte=0"`
+	Location  string `validate:"max=200"`
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+func NewResource(createdBy string) *Resource {
+	return &Resource{
+		ID:        uuid.NewV4().String(),
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (r *Resource) Validate() error {
+	err := validate.Struct(r)
+	if err != nil {
+		return internal.WrapCause(internal.ErrValidationFailed, err)
+	}
+	return nil
+}
+
+// ResourceBooking is a resource attached to an event. The booking is confirmed when the resource
+// is free for every occurrence of the event and declined when it is already booked for an
+// overlapping one, which is decided when the event is written.
+type ResourceBooking struct {
+	EventID    string           `db:"event_id"`
+	ResourceID string           `validate:"required" db:"resource_id"`
+	Status     InvitationStatus `db:"status"`
+}
+
+// BookingStatus returns the status of a booking of a resource for the schedules, given the
+// schedules the resource is already booked for.
+func BookingStatus(schedules []Schedule, booked []Schedule) InvitationStatus {
+	for index := range schedules {
+		for other := range booked {
+			if schedules[index].Overlaps(&booked[other]) {
+				return InvitationStatus_Declined
+			}
+		}
+	}
+	return InvitationStatus_Confirmed
+}
+
+// Overlaps reports whether an occurrence of the schedule overlaps an occurrence of the other one.
+func (s *Schedule) Overlaps(other *Schedule) bool {
+	if s.RecurringInterval > 0 && other.RecurringInterval <= 0 {
+		return other.Overlaps(s)
+	}
+
+	start := time.Unix(s.StartTime, 0).UTC()
+	duration := time.Duration(s.DurationInMinutes) * time.Minute
+	if s.RecurringInterval <= 0 {
+		return len(other.Occurrences(start, start.Add(duration))) > 0
+	}
+
+	// once both have started, the occurrences repeat every longest interval since the intervals
+	// divide each other, so the first such period, stretched by the durations for the occurrences
+	// that span it, tells whether they ever overlap
+	from := time.Unix(max(s.StartTime, other.StartTime), 0).UTC()
+	period := time.Duration(max(s.RecurringInterval, other.RecurringInterval)) * time.Second
+	to := from.Add(period + duration + time.Duration(other.DurationInMinutes)*time.Minute)
+	for _, occurrence := range s.Occurrences(from, to) {
+		if len(other.Occurrences(occurrence, occurrence.Add(duration))) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// BookedSchedule is a schedule of an event a resource is booked for.
+type BookedSchedule struct {
+	ResourceID string `db:"resource_id"`
+	Schedule
+}
+
+// BusyPeriod is a time a resource is booked.
+type BusyPeriod struct {
+	Start time.Time
+	End   time.Time
+}
+
+// NewBusyPeriods expands the occurrences of the schedules that overlap [from, to), overlapping
+// occurrences are merged into one period. The periods are sorted and clipped to the window.
+func NewBusyPeriods(schedules []Schedule, from time.Time, to time.Time) []BusyPeriod {
+	var periods []BusyPeriod
+	for index := range schedules {
+		for _, start := range schedules[index].Occurrences(from, to) {
+			periods = append(periods, BusyPeriod{
+				Start: maxTime(start, from),
+				End:   minTime(schedules[index].EndTimeFrom(start), to),
+			})
+		}
+	}
+
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].Start.Before(periods[j].Start)
+	})
+
+	merged := []BusyPeriod{}
+	for _, period := range periods {
+		last := len(merged) - 1
+		if last >= 0 && !period.Start.After(merged[last].End) {
+			merged[last].End = maxTime(merged[last].End, period.End)
+			continue
+		}
+		merged = append(merged, period)
+	}
+	return merged
+}
+
+func maxTime(a time.Time, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a time.Time, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// ResourceFilter narrows down listed resources, zero-valued fields are not filtered on.
+type ResourceFilter struct {
+	Type        ResourceType
+	MinCapacity int32
+}
+
+//go:generate mockgen -destination=../mock/mock_resource_repository.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core ResourceRepository
+type ResourceRepository interface {
+	Store(ctx context.Context, resource *Resource) error
+	Update(ctx context.Context, resource *Resource) error
+	// DeleteByID deletes the resource along with its bookings.
+	DeleteByID(ctx context.Context, id string) error
+	FindByID(ctx context.Context, id string) (*Resource, error)
+	// List returns the resources matching the filter, sorted by name.
+	List(ctx context.Context, filter ResourceFilter) ([]Resource, error)
+	// FindBookedSchedules returns the schedules of the events that are not deleted and that the
+	// resources are confirmed for.
+	FindBookedSchedules(ctx context.Context, resourceIDs []string) ([]BookedSchedule, error)
+}
+
+type CreateResourceRequest struct {
+	ActorID  string
+	Resource *Resource
+}
+
+func (c *CreateResourceRequest) Validate() error {
+	if c.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if c.Resource == nil {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Resource", "invalid resource")
+	}
+
+	return c.Resource.Validate()
+}
+
+type UpdateResourceRequest struct {
+	ActorID  string
+	Resource *Resource
+}
+
+func (u *UpdateResourceRequest) Validate() error {
+	if u.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if u.Resource == nil || u.Resource.ID == "" {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Resource", "invalid resource")
+	}
+
+	return u.Resource.Validate()
+}
+
+type DeleteResourceRequest struct {
+	ActorID    string
+	ResourceID string
+}
+
+func (d *DeleteResourceRequest) Validate() error {
+	if d.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if d.ResourceID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid resource id")
+	}
+
+	return nil
+}
+
+type FindResourceByIDRequest struct {
+	ActorID    string
+	ResourceID string
+}
+
+func (f *FindResourceByIDRequest) Validate() error {
+	if f.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if f.ResourceID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid resource id")
+	}
+
+	return nil
+}
+
+type SearchResourcesRequest struct {
+	ActorID     string
+	Type        ResourceType `validate:"omitempty,oneof=ROOM EQUIPMENT"`
+	MinCapacity int32        `validate:"gte=0"`
+	// AvailableFrom and AvailableTo, when set, only keep the resources that aren't booked between them
+	AvailableFrom *time.Time
+	AvailableTo   *time.Time
+}
+
+func (s *SearchResourcesRequest) Validate() error {
+	if s.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	err := validate.Struct(s)
+	if err != nil {
+		return internal.WrapCause(internal.ErrValidationFailed, err)
+	}
+
+	if (s.AvailableFrom == nil) != (s.AvailableTo == nil) {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "AvailableTo", "the availability window needs both a start and an end")
+	}
+	if s.AvailableFrom != nil {
+		return validateResourceWindow(*s.AvailableFrom, *s.AvailableTo, "AvailableTo")
+	}
+	return nil
+}
+
+type GetResourceFreeBusyRequest struct {
+	ActorID    string
+	ResourceID string
+	From       time.Time
+	To         time.Time
+}
+
+func (g *GetResourceFreeBusyRequest) Validate() error {
+	if g.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if g.ResourceID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid resource id")
+	}
+
+	return validateResourceWindow(g.From, g.To, "To")
+}
+
+func validateResourceWindow(from time.Time, to time.Time, field string) error {
+	if !to.After(from) {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, field, "the end of the window must be after its start")
+	}
+
+	if to.Sub(from) > MaxResourceWindow {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, field, fmt.Sprintf("the window can't be longer than %d days", int(MaxResourceWindow.Hours()/24)))
+	}
+	return nil
+}
+
+//go:generate mockgen -destination=../mock/mock_resource_service.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core ResourceService
+type ResourceService interface {
+	CreateResource(ctx context.Context, req *CreateResourceRequest) error
+	UpdateResource(ctx context.Context, req *UpdateResourceRequest) error
+	DeleteResource(ctx context.Context, req *DeleteResourceRequest) error
+	FindResourceByID(ctx context.Context, req *FindResourceByIDRequest) (*Resource, error)
+	// SearchResources returns the resources matching the request, sorted by name.
+	SearchResources(ctx context.Context, req *SearchResourcesRequest) ([]Resource, error)
+	// GetResourceFreeBusy returns when the resource is booked in the window, earliest first.
+	GetResourceFreeBusy(ctx context.Context, req *GetResourceFreeBusyRequest) ([]BusyPeriod, error)
+}
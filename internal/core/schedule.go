@@ -4,10 +4,15 @@ import (
 	"errors"
 	"time"
 
+	"github.com/dzakaammar/event-scheduling-example/internal"
 	"github.com/satori/uuid"
 )
 
-var ErrInvalidTimezone = errors.New("invalid timezone")
+var (
+	ErrInvalidTimezone  = errors.New("invalid timezone")
+	ErrInvalidStartTime = errors.New("invalid start time")
+	ErrInvalidEndTime   = errors.New("invalid end time")
+)
 
 type RecurringType string
 
@@ -49,12 +54,12 @@ func (s *Schedule) EndTimeFrom(st time.Time) time.Time {
 func NewSchedule(eventID string, start, end string, isFullDay bool, rt RecurringType) (Schedule, error) {
 	startTime, err := time.Parse(time.RFC3339, start)
 	if err != nil {
-		return Schedule{}, err
+		return Schedule{}, internal.WrapCause(ErrInvalidStartTime, err)
 	}
 
 	endTime, err := time.Parse(time.RFC3339, end)
 	if err != nil {
-		return Schedule{}, err
+		return Schedule{}, internal.WrapCause(ErrInvalidEndTime, err)
 	}
 
 	s := Schedule{
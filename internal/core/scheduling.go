@@ -2,6 +2,8 @@ package core
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/dzakaammar/event-scheduling-example/internal"
 )
@@ -9,6 +11,11 @@ import (
 type CreateEventRequest struct {
 	ActorID string
 	Event   *Event
+	// EventIDChosen is set when the client chose the id of the event rather than having it
+	// generated, the retries of the request then have to choose the same id
+	EventIDChosen bool
+	// IdempotencyKey is optional, retries carrying the same key get the event created by the first request
+	IdempotencyKey string
 }
 
 func (c *CreateEventRequest) Validate() error {
@@ -16,12 +23,20 @@ func (c *CreateEventRequest) Validate() error {
 		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
 	}
 
+	if len(c.IdempotencyKey) > MaxIdempotencyKeyLength {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "IdempotencyKey", "idempotency key is too long")
+	}
+
 	if c.Event == nil {
-		return internal.WrapErr(internal.ErrValidationFailed, "invalid event")
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Event", "invalid event")
+	}
+
+	if err := ValidateEventID(c.Event.ID); err != nil {
+		return err
 	}
 
 	if len(c.Event.Schedules) <= 0 {
-		return internal.WrapErr(internal.ErrValidationFailed, "no schedules provided for the event")
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Event.Schedules", "no schedules provided for the event")
 	}
 
 	return c.Event.Validate()
@@ -56,17 +71,22 @@ func (u *UpdateEventRequest) Validate() error {
 	}
 
 	if u.Event == nil {
-		return internal.WrapErr(internal.ErrValidationFailed, "invalid event")
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Event", "invalid event")
 	}
 
 	return u.Event.Validate()
 }
 
 type FindEventByIDRequest struct {
+	ActorID string
 	EventID string
 }
 
 func (f *FindEventByIDRequest) Validate() error {
+	if f.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
 	if f.EventID == "" {
 		return internal.WrapErr(internal.ErrValidationFailed, "invalid event id")
 	}
@@ -74,10 +94,291 @@ func (f *FindEventByIDRequest) Validate() error {
 	return nil
 }
 
+type RestoreEventRequest struct {
+	ActorID string
+	EventID string
+}
+
+func (r *RestoreEventRequest) Validate() error {
+	if r.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if r.EventID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid event id")
+	}
+
+	return nil
+}
+
+type ListDeletedEventsRequest struct {
+	ActorID string
+}
+
+func (l *ListDeletedEventsRequest) Validate() error {
+	if l.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	return nil
+}
+
+type RespondInvitationRequest struct {
+	ActorID string
+	EventID string
+	Status  InvitationStatus
+}
+
+func (r *RespondInvitationRequest) Validate() error {
+	if _, err := ParseUserID(r.ActorID); err != nil {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if r.EventID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid event id")
+	}
+
+	if r.Status != InvitationStatus_Confirmed && r.Status != InvitationStatus_Declined {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Status", "invalid invitation status")
+	}
+
+	return nil
+}
+
+type ListWaitlistRequest struct {
+	ActorID string
+	EventID string
+}
+
+func (l *ListWaitlistRequest) Validate() error {
+	if l.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if l.EventID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid event id")
+	}
+
+	return nil
+}
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 100
+)
+
+type WatchEventsRequest struct {
+	ActorID string
+	// After is the cursor of the last change received before a reconnect, nil to only get new changes
+	After *ChangeCursor
+}
+
+func (w *WatchEventsRequest) Validate() error {
+	if w.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+	return nil
+}
+
+type ListEventHistoryRequest struct {
+	ActorID  string
+	EventID  string
+	PageSize int
+	// BeforeID is the cursor of the page, the id of the last entry of the previous page
+	BeforeID int64
+}
+
+func (l *ListEventHistoryRequest) Validate() error {
+	if l.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if l.EventID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid event id")
+	}
+
+	if l.PageSize < 0 || l.PageSize > MaxPageSize {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "PageSize", "invalid page size")
+	}
+
+	if l.PageSize == 0 {
+		l.PageSize = DefaultPageSize
+	}
+
+	return nil
+}
+
+type EventHistoryPage struct {
+	Entries []EventHistory
+	// NextBeforeID is the cursor of the next page, zero when there are no more entries
+	NextBeforeID int64
+}
+
+type ListEventsRequest struct {
+	// ActorID only sees the events they created or are invited to, and those of the calendars
+	// shared with them
+	ActorID   string
+	CreatedBy string
+	Attendee  int32
+	// Title is matched as a case-insensitive substring
+	Title string
+	// CreatedAfter is inclusive, CreatedBefore is exclusive
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Timezone      string
+	CalendarID    string
+	PageSize      int
+	// After is the cursor of the page, the last event of the previous page
+	After *EventCursor
+}
+
+func (l *ListEventsRequest) Validate() error {
+	if l.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if l.PageSize < 0 || l.PageSize > MaxPageSize {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "PageSize", "invalid page size")
+	}
+
+	if l.Timezone != "" {
+		if _, err := time.LoadLocation(l.Timezone); err != nil {
+			return internal.WrapFieldErr(internal.ErrInvalidTimezone, "Timezone", l.Timezone)
+		}
+	}
+
+	if l.CreatedAfter != nil && l.CreatedBefore != nil && !l.CreatedAfter.Before(*l.CreatedBefore) {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "CreatedBefore", "created before must be later than created after")
+	}
+
+	if l.PageSize == 0 {
+		l.PageSize = DefaultPageSize
+	}
+
+	return nil
+}
+
+type EventsPage struct {
+	Events []Event
+	// Next is the cursor of the next page, nil when there are no more events
+	Next *EventCursor
+}
+
+type SearchEventsRequest struct {
+	ActorID  string
+	Query    string
+	PageSize int
+	// Offset is the number of results of the previous pages
+	Offset int
+}
+
+func (s *SearchEventsRequest) Validate() error {
+	if s.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	s.Query = strings.TrimSpace(s.Query)
+	if s.Query == "" {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Query", "empty search query")
+	}
+
+	if s.PageSize < 0 || s.PageSize > MaxPageSize {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "PageSize", "invalid page size")
+	}
+
+	if s.Offset < 0 {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "PageToken", "invalid offset")
+	}
+
+	if s.PageSize == 0 {
+		s.PageSize = DefaultPageSize
+	}
+
+	return nil
+}
+
+type SearchEventsPage struct {
+	Events []Event
+	// NextOffset is the offset of the next page, zero when there are no more results
+	NextOffset int
+}
+
+const MaxBatchSize = 500
+
+// BatchMutation is one write of a batch, exactly one of the requests must be set.
+type BatchMutation struct {
+	Create *CreateEventRequest
+	Update *UpdateEventRequest
+	Delete *DeleteEventByIDRequest
+}
+
+func (b *BatchMutation) Validate() error {
+	switch {
+	case b.Create != nil && b.Update == nil && b.Delete == nil:
+		return b.Create.Validate()
+	case b.Create == nil && b.Update != nil && b.Delete == nil:
+		return b.Update.Validate()
+	case b.Create == nil && b.Update == nil && b.Delete != nil:
+		return b.Delete.Validate()
+	default:
+		return internal.WrapErr(internal.ErrValidationFailed, "exactly one operation must be set")
+	}
+}
+
+// EventID returns the ID of the event the mutation writes to.
+func (b *BatchMutation) EventID() string {
+	switch {
+	case b.Create != nil && b.Create.Event != nil:
+		return b.Create.Event.ID
+	case b.Update != nil && b.Update.Event != nil:
+		return b.Update.Event.ID
+	case b.Delete != nil:
+		return b.Delete.EventID
+	default:
+		return ""
+	}
+}
+
+type BatchMutateEventsRequest struct {
+	ActorID string
+	// Atomic applies all of the mutations or none of them, otherwise each one is applied on its own
+	Atomic    bool
+	Mutations []BatchMutation
+}
+
+func (b *BatchMutateEventsRequest) Validate() error {
+	if b.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if len(b.Mutations) == 0 || len(b.Mutations) > MaxBatchSize {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Mutations", "invalid number of mutations")
+	}
+
+	return nil
+}
+
+// BatchMutationResult is the outcome of the mutation at the same position in the batch.
+type BatchMutationResult struct {
+	EventID string
+	Err     error
+}
+
 //go:generate mockgen -destination=../mock/mock_scheduling_service.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core SchedulingService
 type SchedulingService interface {
 	CreateEvent(ctx context.Context, req *CreateEventRequest) error
 	DeleteEventByID(ctx context.Context, req *DeleteEventByIDRequest) error
 	UpdateEvent(ctx context.Context, req *UpdateEventRequest) error
 	FindEventByID(ctx context.Context, req *FindEventByIDRequest) (*Event, error)
+	RestoreEvent(ctx context.Context, req *RestoreEventRequest) error
+	ListDeletedEvents(ctx context.Context, req *ListDeletedEventsRequest) ([]Event, error)
+	RespondInvitation(ctx context.Context, req *RespondInvitationRequest) error
+	// ListWaitlist returns the waitlisted invitations of the event in the order they are promoted.
+	ListWaitlist(ctx context.Context, req *ListWaitlistRequest) ([]Invitation, error)
+	SetReminders(ctx context.Context, req *SetRemindersRequest) error
+	ListEventHistory(ctx context.Context, req *ListEventHistoryRequest) (*EventHistoryPage, error)
+	ListEvents(ctx context.Context, req *ListEventsRequest) (*EventsPage, error)
+	SearchEvents(ctx context.Context, req *SearchEventsRequest) (*SearchEventsPage, error)
+	BatchMutateEvents(ctx context.Context, req *BatchMutateEventsRequest) ([]BatchMutationResult, error)
+	WatchEvents(ctx context.Context, req *WatchEventsRequest) (<-chan EventChange, error)
 }
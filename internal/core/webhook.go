@@ -0,0 +1,251 @@
+package core
+
+import (
+	"context"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/satori/uuid"
+)
+
+// Webhook subscribes a URL to the changes of the events its owner created or is invited to.
+type Webhook struct {
+	ID      string
+	OwnerID string
+	URL     string `validate:"required,url,max=2048"`
+	// Secret is the key the deliveries are signed with, it is never sent back to the client
+	Secret     string             `validate:"required,min=16,max=256"`
+	EventTypes []HistoryOperation `validate:"required,min=1,unique,dive,oneof=CREATE UPDATE DELETE RESTORE RSVP"`
+	CreatedAt  time.Time
+	UpdatedAt  *time.Time
+}
+
+func NewWebhook(ownerID string) *Webhook {
+	return &Webhook{
+		ID:        uuid.NewV4().String(),
+		OwnerID:   ownerID,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (w *Webhook) Validate() error {
+	err := validate.Struct(w)
+	if err != nil {
+		return internal.WrapCause(internal.ErrValidationFailed, err)
+	}
+
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Webhook.URL", "url must be an absolute http or https url")
+	}
+
+	// names are resolved again on every delivery, where the dispatcher refuses internal addresses
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	addr, err := netip.ParseAddr(host)
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || (err == nil && IsInternalAddr(addr)) {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Webhook.URL", "url must not point to a loopback, link-local or private address")
+	}
+	return nil
+}
+
+// IsInternalAddr reports whether addr belongs to the host itself or to a private network, which
+// the deliveries of webhooks must never reach.
+func IsInternalAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified()
+}
+
+// Subscribes reports whether the changes of the operation are delivered to the webhook.
+func (w *Webhook) Subscribes(op HistoryOperation) bool {
+	for _, t := range w.EventTypes {
+		if t == op {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatus_Pending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatus_Succeeded WebhookDeliveryStatus = "SUCCEEDED"
+	// WebhookDeliveryStatus_Failed is a delivery that was given up on after the last attempt
+	WebhookDeliveryStatus_Failed WebhookDeliveryStatus = "FAILED"
+)
+
+// WebhookDelivery is an entry of the delivery log of a webhook, it tracks the attempts
+// to deliver one event change.
+type WebhookDelivery struct {
+	ID        int64
+	WebhookID string
+	EventID   string
+	EventType HistoryOperation
+	// Payload is the request body, the same one is sent on every attempt
+	Payload  []byte
+	Status   WebhookDeliveryStatus
+	Attempts int
+	// ResponseStatus and LastError are the outcome of the last attempt, the status is zero
+	// when no response was received
+	ResponseStatus int
+	LastError      string
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+	// OutboxID is the domain event the delivery was logged for, see DomainEvent.ID
+	OutboxID string
+}
+
+//go:generate mockgen -destination=../mock/mock_webhook_repository.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core WebhookRepository
+type WebhookRepository interface {
+	Store(ctx context.Context, webhook *Webhook) error
+	Update(ctx context.Context, webhook *Webhook) error
+	DeleteByID(ctx context.Context, id string) error
+	FindByID(ctx context.Context, id string) (*Webhook, error)
+	FindByOwner(ctx context.Context, ownerID string) ([]Webhook, error)
+	// EnqueueDeliveries logs a pending delivery of the change for each webhook of the owners that
+	// subscribes to its event type, and returns how many were logged. A webhook already holding a
+	// delivery of the same OutboxID is skipped.
+	EnqueueDeliveries(ctx context.Context, delivery *WebhookDelivery, ownerIDs []string) (int64, error)
+	// ClaimDueDeliveries returns at most limit pending deliveries that are due at now, and postpones
+	// them until leaseUntil so that they aren't claimed again while they are being delivered.
+	ClaimDueDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *WebhookDelivery) error
+	// FindDeliveries returns at most limit deliveries of the webhook older than beforeID, newest first.
+	// A zero beforeID starts from the newest delivery.
+	FindDeliveries(ctx context.Context, webhookID string, beforeID int64, limit int) ([]WebhookDelivery, error)
+}
+
+type CreateWebhookRequest struct {
+	ActorID string
+	Webhook *Webhook
+}
+
+func (c *CreateWebhookRequest) Validate() error {
+	if c.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if c.Webhook == nil {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Webhook", "invalid webhook")
+	}
+
+	return c.Webhook.Validate()
+}
+
+type UpdateWebhookRequest struct {
+	ActorID string
+	// Webhook replaces the url and the event types, and the secret unless it is empty
+	Webhook *Webhook
+}
+
+func (u *UpdateWebhookRequest) Validate() error {
+	if u.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if u.Webhook == nil || u.Webhook.ID == "" {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "Webhook", "invalid webhook")
+	}
+
+	return nil
+}
+
+type DeleteWebhookRequest struct {
+	ActorID   string
+	WebhookID string
+}
+
+func (d *DeleteWebhookRequest) Validate() error {
+	if d.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if d.WebhookID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid webhook id")
+	}
+
+	return nil
+}
+
+type FindWebhookByIDRequest struct {
+	ActorID   string
+	WebhookID string
+}
+
+func (f *FindWebhookByIDRequest) Validate() error {
+	if f.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if f.WebhookID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid webhook id")
+	}
+
+	return nil
+}
+
+type ListWebhooksRequest struct {
+	ActorID string
+}
+
+func (l *ListWebhooksRequest) Validate() error {
+	if l.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	return nil
+}
+
+type ListWebhookDeliveriesRequest struct {
+	ActorID   string
+	WebhookID string
+	PageSize  int
+	// BeforeID is the cursor of the page, the id of the last delivery of the previous page
+	BeforeID int64
+}
+
+func (l *ListWebhookDeliveriesRequest) Validate() error {
+	if l.ActorID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid actor id")
+	}
+
+	if l.WebhookID == "" {
+		return internal.WrapErr(internal.ErrValidationFailed, "invalid webhook id")
+	}
+
+	if l.PageSize < 0 || l.PageSize > MaxPageSize {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "PageSize", "invalid page size")
+	}
+
+	if l.PageSize == 0 {
+		l.PageSize = DefaultPageSize
+	}
+
+	return nil
+}
+
+type WebhookDeliveryPage struct {
+	Deliveries []WebhookDelivery
+	// NextBeforeID is the cursor of the next page, zero when there are no more deliveries
+	NextBeforeID int64
+}
+
+//go:generate mockgen -destination=../mock/mock_webhook_service.go -package=mock github.com/dzakaammar/event-scheduling-example/internal/core WebhookService
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, req *CreateWebhookRequest) error
+	UpdateWebhook(ctx context.Context, req *UpdateWebhookRequest) error
+	DeleteWebhook(ctx context.Context, req *DeleteWebhookRequest) error
+	FindWebhookByID(ctx context.Context, req *FindWebhookByIDRequest) (*Webhook, error)
+	ListWebhooks(ctx context.Context, req *ListWebhooksRequest) ([]Webhook, error)
+	ListWebhookDeliveries(ctx context.Context, req *ListWebhookDeliveriesRequest) (*WebhookDeliveryPage, error)
+}
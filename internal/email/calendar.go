@@ -0,0 +1,145 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+const (
+	methodRequest = "REQUEST"
+	methodCancel  = "CANCEL"
+	methodReply   = "REPLY"
+
+	// maxLineLength is the octets a content line of iCalendar may take before it is folded
+	maxLineLength = 75
+)
+
+// attendee is an attendee as listed in the .ics attachment.
+type attendee struct {
+	Address string
+	Status  core.InvitationStatus
+}
+
+// calendar is the iCalendar (RFC 5545) object attached to an email, holding a VEVENT per schedule
+// of the event. The UID of a VEVENT is derived from the event and the position of the schedule, so
+// that calendar clients update the entry they already have instead of adding another one.
+type calendar struct {
+	Method string
+	// Sequence orders the revisions of the event, it grows with every change
+	Sequence  int64
+	Stamp     time.Time
+	Domain    string
+	Event     *core.Event
+	Organizer string
+	Attendees []attendee
+}
+
+func (c *calendar) Bytes() []byte {
+	loc := eventLocation(c.Event)
+
+	var buf bytes.Buffer
+	writeLine(&buf, "BEGIN:VCALENDAR")
+	writeLine(&buf, "VERSION:2.0")
+	writeLine(&buf, "PRODID:-//event-scheduling-example//EN")
+	writeLine(&buf, "METHOD:"+c.Method)
+	for index, s := range c.Event.Schedules {
+		start := time.Unix(s.StartTime, 0).In(loc)
+		end := s.EndTimeFrom(start)
+
+		writeLine(&buf, "BEGIN:VEVENT")
+		writeLine(&buf, fmt.Sprintf("UID:%s-%d@%s", c.Event.ID, index, c.Domain))
+		writeLine(&buf, "SEQUENCE:"+fmt.Sprint(c.Sequence))
+		writeLine(&buf, "DTSTAMP:"+formatDateTime(c.Stamp))
+		if s.IsFullDay {
+			writeLine(&buf, "DTSTART;VALUE=DATE:"+start.Format("20060102"))
+			writeLine(&buf, "DTEND;VALUE=DATE:"+end.Format("20060102"))
+		} else {
+			writeLine(&buf, "DTSTART:"+formatDateTime(start))
+			writeLine(&buf, "DTEND:"+formatDateTime(end))
+		}
+		if rule := recurrenceRule(s.RecurringType); rule != "" {
+			writeLine(&buf, "RRULE:"+rule)
+		}
+		writeLine(&buf, "SUMMARY:"+escapeText(c.Event.Title))
+		writeLine(&buf, "DESCRIPTION:"+escapeText(c.Event.Description))
+		writeLine(&buf, "ORGANIZER:mailto:"+c.Organizer)
+		for _, a := range c.Attendees {
+			writeLine(&buf, fmt.Sprintf("ATTENDEE;PARTSTAT=%s;RSVP=TRUE:mailto:%s", partStat(a.Status), a.Address))
+		}
+		if c.Method == methodCancel {
+			writeLine(&buf, "STATUS:CANCELLED")
+		} else {
+			writeLine(&buf, "STATUS:CONFIRMED")
+		}
+		writeLine(&buf, "END:VEVENT")
+	}
+	writeLine(&buf, "END:VCALENDAR")
+
+	return buf.Bytes()
+}
+
+func formatDateTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+func recurrenceRule(rt core.RecurringType) string {
+	switch rt {
+	case core.RecurringType_Daily:
+		return "FREQ=DAILY"
+	case core.RecurringType_Every_Week:
+		return "FREQ=WEEKLY"
+	default:
+		return ""
+	}
+}
+
+func partStat(status core.InvitationStatus) string {
+	switch status {
+	case core.InvitationStatus_Confirmed:
+		return "ACCEPTED"
+	case core.InvitationStatus_Declined:
+		return "DECLINED"
+	case core.InvitationStatus_Waitlisted:
+		return "TENTATIVE"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// writeLine folds the line into lines of at most maxLineLength octets, without splitting a
+// character, and ends each of them with CRLF.
+func writeLine(buf *bytes.Buffer, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// the leading space of a continuation line counts towards its length
+		limit = maxLineLength - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+func eventLocation(event *core.Event) *time.Location {
+	loc, err := time.LoadLocation(event.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
@@ -0,0 +1,110 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+const defaultTimeout = 30 * time.Second
+
+var errAuthNotSupported = errors.New("the smtp server doesn't support authentication")
+
+// Sender hands a message over to a mail server.
+type Sender interface {
+	Send(ctx context.Context, from string, to []string, msg []byte) error
+}
+
+// SMTPClient sends every message over its own connection. The connection is upgraded with
+// STARTTLS whenever the server offers it, and authenticated when a username is set.
+type SMTPClient struct {
+	address string
+	auth    smtp.Auth
+	timeout time.Duration
+}
+
+func NewSMTPClient(address string, username string, password string) *SMTPClient {
+	c := &SMTPClient{
+		address: address,
+		timeout: defaultTimeout,
+	}
+
+	if username != "" {
+		host, _, _ := net.SplitHostPort(address)
+		c.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return c
+}
+
+func (c *SMTPClient) Send(ctx context.Context, from string, to []string, msg []byte) error {
+	host, _, err := net.SplitHostPort(c.address)
+	if err != nil {
+		return err
+	}
+
+	dialer := net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.address)
+	if err != nil {
+		return err
+	}
+
+	// net/smtp doesn't take a context, the deadline bounds the whole conversation instead
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(c.timeout)
+	}
+	_ = conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		err = client.StartTLS(&tls.Config{ServerName: host, MinVersion: tls.VersionTLS12})
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.auth != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return errAuthNotSupported
+		}
+		err = client.Auth(c.auth)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = client.Mail(from)
+	if err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		err = client.Rcpt(rcpt)
+		if err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(msg)
+	if err != nil {
+		return err
+	}
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
@@ -0,0 +1,90 @@
+package email_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/email"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSMTPClient_Send(t *testing.T) {
+	msg := []byte("Subject: hello\r\n\r\nhello\r\n")
+	tests := []struct {
+		name     string
+		server   func(t *testing.T) *smtpServer
+		username string
+		password string
+		wantErr  bool
+	}{
+		{
+			name: "OK",
+			server: func(t *testing.T) *smtpServer {
+				return newSMTPServer(t)
+			},
+		},
+		{
+			name: "OK - authenticated",
+			server: func(t *testing.T) *smtpServer {
+				return newSMTPServer(t, withAuth("scheduler", "secret"))
+			},
+			username: "scheduler",
+			password: "secret",
+		},
+		{
+			name: "Not OK - wrong password",
+			server: func(t *testing.T) *smtpServer {
+				return newSMTPServer(t, withAuth("scheduler", "secret"))
+			},
+			username: "scheduler",
+			password: "guess",
+			wantErr:  true,
+		},
+		{
+			name: "Not OK - authentication isn't offered",
+			server: func(t *testing.T) *smtpServer {
+				return newSMTPServer(t)
+			},
+			username: "scheduler",
+			password: "secret",
+			wantErr:  true,
+		},
+		{
+			name: "Not OK - recipient rejected",
+			server: func(t *testing.T) *smtpServer {
+				return newSMTPServer(t, rejectingRecipients)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not OK - server unreachable",
+			server: func(t *testing.T) *smtpServer {
+				s := newSMTPServer(t)
+				_ = s.listener.Close()
+				return s
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := tt.server(t)
+			client := email.NewSMTPClient(server.Addr(), tt.username, tt.password)
+
+			err := client.Send(context.Background(), "noreply@example.com", []string{"user-2@example.com"}, msg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, server.Mails())
+				return
+			}
+			assert.NoError(t, err)
+
+			mails := server.Mails()
+			if assert.Len(t, mails, 1) {
+				assert.Equal(t, "noreply@example.com", mails[0].From)
+				assert.Equal(t, []string{"user-2@example.com"}, mails[0].To)
+				assert.Equal(t, "Subject: hello\n\nhello\n", string(mails[0].Data))
+			}
+		})
+	}
+}
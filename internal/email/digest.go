@@ -0,0 +1,133 @@
+package email
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+const (
+	defaultDigestInterval = time.Minute
+
+	// digestLease must outlast a round: the items claimed by a digester that went away are claimed
+	// again once their lease expires
+	digestLease          = time.Minute
+	digestClaimBatchSize = 100
+)
+
+// Digester sends the notifications the Notifier held back once they are due. The items of a user
+// that are due together are sent in a single email, with the .ics attachments of all of them.
+type Digester struct {
+	digests  core.NotificationDigestRepository
+	notifier *Notifier
+	interval time.Duration
+}
+
+func NewDigester(digests core.NotificationDigestRepository, notifier *Notifier, interval time.Duration) *Digester {
+	if interval <= 0 {
+		interval = defaultDigestInterval
+	}
+
+	return &Digester{
+		digests:  digests,
+		notifier: notifier,
+		interval: interval,
+	}
+}
+
+// Run sends the due digests on every interval, until ctx is done.
+func (d *Digester) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		sent, err := d.SendDue(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Error(err.Error())
+		} else if sent > 0 {
+			slog.Info("sent notification digests", "count", sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDue sends the items that are due until none is left, and returns how many emails were sent.
+func (d *Digester) SendDue(ctx context.Context) (int, error) {
+	sent := 0
+	for {
+		now := time.Now().UTC()
+		items, err := d.digests.ClaimDue(ctx, now, now.Add(digestLease), digestClaimBatchSize)
+		if err != nil {
+			return sent, err
+		}
+
+		for start := 0; start < len(items); {
+			end := start + 1
+			for end < len(items) && items[end].UserID == items[start].UserID {
+				end++
+			}
+
+			err = d.Send(ctx, items[start:end])
+			if ctx.Err() != nil {
+				return sent, ctx.Err()
+			}
+			if err != nil {
+				// the items are claimed again once their lease expires
+				slog.Error("failed to send notification digest", "user_id", items[start].UserID, "error", err.Error())
+			} else {
+				sent++
+			}
+			start = end
+		}
+
+		if len(items) < digestClaimBatchSize {
+			return sent, nil
+		}
+	}
+}
+
+// Send emails the items of a user and deletes them. A single item is sent as the email it was held
+// back as, so it is still recognized as a duplicate when it was sent before.
+func (d *Digester) Send(ctx context.Context, items []core.DigestItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(items))
+	var attachments []attachment
+	for index, item := range items {
+		ids[index] = item.ID
+		if len(item.Calendar) > 0 {
+			attachments = append(attachments, attachment{Method: item.CalendarMethod, Content: item.Calendar})
+		}
+	}
+
+	key, subject, body := items[0].Key, items[0].Subject, items[0].Body
+	if len(items) > 1 {
+		var err error
+		subject, body, err = render("digest", items)
+		if err != nil {
+			return err
+		}
+		key = "digest." + strconv.FormatInt(items[0].ID, 10)
+	}
+
+	err := d.notifier.send(ctx, items[0].UserID, &message{
+		ID:          key,
+		Subject:     subject,
+		Body:        body,
+		Attachments: attachments,
+	})
+	if err != nil {
+		return err
+	}
+	return d.digests.Delete(ctx, ids)
+}
@@ -0,0 +1,95 @@
+package email_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/email"
+	"github.com/dzakaammar/event-scheduling-example/internal/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigester_SendDue(t *testing.T) {
+	items := []core.DigestItem{
+		{ID: 1, UserID: "2", Key: "d1.2", Subject: "Invitation: Weekly sync", Body: "User 1 invited you.\n", CalendarMethod: "REQUEST", Calendar: []byte("BEGIN:VCALENDAR\r\nMETHOD:REQUEST\r\nEND:VCALENDAR\r\n")},
+		{ID: 2, UserID: "2", Key: "d2.2", Subject: "Cancelled: Weekly sync", Body: "User 1 cancelled it.\n", CalendarMethod: "CANCEL", Calendar: []byte("BEGIN:VCALENDAR\r\nMETHOD:CANCEL\r\nEND:VCALENDAR\r\n")},
+		{ID: 3, UserID: "3", Key: "d1.3", Subject: "Invitation: Weekly sync", Body: "User 1 invited you.\n", CalendarMethod: "REQUEST", Calendar: []byte("BEGIN:VCALENDAR\r\nMETHOD:REQUEST\r\nEND:VCALENDAR\r\n")},
+	}
+	tests := []struct {
+		name        string
+		server      func(t *testing.T) *smtpServer
+		digestsMock func(digests *mock.MockNotificationDigestRepository)
+		want        int
+		wantMails   []parsedMail
+		wantErr     bool
+	}{
+		{
+			name: "OK - the items of a user are sent together",
+			digestsMock: func(digests *mock.MockNotificationDigestRepository) {
+				digests.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(items, nil)
+				digests.EXPECT().Delete(gomock.Any(), []int64{1, 2}).Return(nil)
+				digests.EXPECT().Delete(gomock.Any(), []int64{3}).Return(nil)
+			},
+			want: 2,
+			wantMails: []parsedMail{
+				{To: "user-2@example.com", Subject: "2 updates about your events", MessageID: "<digest.1@example.com>", Body: "--- Cancelled: Weekly sync"},
+				// a single item is sent as the email it was held back as
+				{To: "user-3@example.com", Subject: "Invitation: Weekly sync", MessageID: "<d1.3@example.com>", Body: "User 1 invited you."},
+			},
+		},
+		{
+			name: "OK - the items that failed to be sent are kept",
+			server: func(t *testing.T) *smtpServer {
+				return newSMTPServer(t, rejectingRecipients)
+			},
+			digestsMock: func(digests *mock.MockNotificationDigestRepository) {
+				digests.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(items, nil)
+			},
+			want: 0,
+		},
+		{
+			name: "Not OK - error claiming",
+			digestsMock: func(digests *mock.MockNotificationDigestRepository) {
+				digests.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("error")) //nolint:goerr113
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := newSMTPServer(t)
+			if tt.server != nil {
+				server = tt.server(t)
+			}
+			digests := mock.NewMockNotificationDigestRepository(ctrl)
+			tt.digestsMock(digests)
+
+			n := email.NewNotifier(email.NewSMTPClient(server.Addr(), "", ""), addresses, mock.NewMockNotificationPreferenceRepository(ctrl), digests, from)
+			got, err := email.NewDigester(digests, n, 0).SendDue(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			mails := server.Mails()
+			require.Len(t, mails, len(tt.wantMails))
+			for index, want := range tt.wantMails {
+				got := parseMail(t, mails[index].Data)
+				assert.Equal(t, want.To, got.To)
+				assert.Equal(t, want.Subject, got.Subject)
+				assert.Equal(t, want.MessageID, got.MessageID)
+				assert.Contains(t, got.Body, want.Body)
+				assert.NotEmpty(t, got.Calendar)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package email
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// message is an email holding a plain text body, along with its HTML alternative if any, and the
+// .ics attachments of the invitations it is about.
+type message struct {
+	ID          string
+	From        string
+	To          string
+	Subject     string
+	Body        string
+	HTML        string
+	Attachments []attachment
+}
+
+// attachment is an iCalendar object sent with the given method.
+type attachment struct {
+	Method  string
+	Content []byte
+}
+
+func (m *message) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	header := textproto.MIMEHeader{}
+	header.Set("From", m.From)
+	header.Set("To", m.To)
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", singleLine(m.Subject)))
+	header.Set("Date", time.Now().Format(time.RFC1123Z))
+	header.Set("Message-ID", "<"+m.ID+">")
+	header.Set("MIME-Version", "1.0")
+
+	if len(m.Attachments) == 0 && m.HTML == "" {
+		header.Set("Content-Type", "text/plain; charset=utf-8")
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+		writeHeader(&buf, header)
+		err := writeQuotedPrintable(&buf, m.Body)
+		if err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	if len(m.Attachments) == 0 {
+		alternatives := multipart.NewWriter(&buf)
+		header.Set("Content-Type", "multipart/alternative; boundary="+alternatives.Boundary())
+		writeHeader(&buf, header)
+		err := writeAlternatives(alternatives, m.Body, m.HTML)
+		if err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	parts := multipart.NewWriter(&buf)
+	header.Set("Content-Type", "multipart/mixed; boundary="+parts.Boundary())
+	writeHeader(&buf, header)
+
+	err := m.writeBody(parts)
+	if err != nil {
+		return nil, err
+	}
+
+	for index, a := range m.Attachments {
+		filename := "invite.ics"
+		if index > 0 {
+			filename = fmt.Sprintf("invite-%d.ics", index+1)
+		}
+
+		part, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {fmt.Sprintf("text/calendar; charset=utf-8; method=%s", a.Method)},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {fmt.Sprintf(`attachment; filename="%s"`, filename)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		writeBase64(part, a.Content)
+	}
+
+	err = parts.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeBody writes the plain text body as a part, or the alternatives when there's an HTML body.
+func (m *message) writeBody(parts *multipart.Writer) error {
+	if m.HTML == "" {
+		return writeTextPart(parts, "text/plain", m.Body)
+	}
+
+	var buf bytes.Buffer
+	alternatives := multipart.NewWriter(&buf)
+	err := writeAlternatives(alternatives, m.Body, m.HTML)
+	if err != nil {
+		return err
+	}
+
+	part, err := parts.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {"multipart/alternative; boundary=" + alternatives.Boundary()},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = part.Write(buf.Bytes())
+	return err
+}
+
+// writeAlternatives writes the plain text and the HTML body, the preferred one last as MIME requires.
+func writeAlternatives(alternatives *multipart.Writer, text string, html string) error {
+	err := writeTextPart(alternatives, "text/plain", text)
+	if err != nil {
+		return err
+	}
+	err = writeTextPart(alternatives, "text/html", html)
+	if err != nil {
+		return err
+	}
+	return alternatives.Close()
+}
+
+func writeTextPart(parts *multipart.Writer, mediaType string, text string) error {
+	part, err := parts.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {mediaType + "; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return err
+	}
+	return writeQuotedPrintable(part, text)
+}
+
+func writeHeader(buf *bytes.Buffer, header textproto.MIMEHeader) {
+	for _, key := range []string{"From", "To", "Subject", "Date", "Message-ID", "MIME-Version", "Content-Type", "Content-Transfer-Encoding"} {
+		if value := header.Get(key); value != "" {
+			fmt.Fprintf(buf, "%s: %s\r\n", key, value)
+		}
+	}
+	buf.WriteString("\r\n")
+}
+
+func writeQuotedPrintable(w io.Writer, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	_, err := qp.Write([]byte(strings.ReplaceAll(body, "\n", "\r\n")))
+	if err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// writeBase64 wraps the encoded content at 76 characters, as MIME requires.
+func writeBase64(w io.Writer, content []byte) {
+	encoded := base64.StdEncoding.EncodeToString(content)
+	for len(encoded) > 76 {
+		_, _ = w.Write([]byte(encoded[:76] + "\r\n"))
+		encoded = encoded[76:]
+	}
+	_, _ = w.Write([]byte(encoded + "\r\n"))
+}
+
+// singleLine keeps a user supplied value, e.g: the title of an event, from adding headers.
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
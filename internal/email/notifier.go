@@ -0,0 +1,478 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"net/mail"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+//go:embed templates/*.tmpl templates/*.html
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+// htmlTemplates escape what the users wrote, e.g: the titles of the events
+var htmlTemplates = htmltemplate.Must(htmltemplate.ParseFS(templateFS, "templates/*.html"))
+
+// AddressBook looks up the mailbox of a user.
+type AddressBook interface {
+	Address(ctx context.Context, userID string) (string, error)
+}
+
+// AddressFormat is the address book of a deployment whose mailboxes are named after the ids of
+// the users, e.g: "user-%s@example.com".
+type AddressFormat string
+
+func (f AddressFormat) Address(_ context.Context, userID string) (string, error) {
+	return fmt.Sprintf(string(f), userID), nil
+}
+
+// Notifier emails the attendees when they are invited to an event, when it changes and when it
+// is cancelled, and emails the organizer when an attendee responds to the invitation. The user
+// who made the change isn't emailed about it, the attendees promoted off the waitlist by it are
+// emailed on their own. It also sends the reminders of the email channel.
+//
+// The guests of an event have no account, they are emailed at their address about the invitation,
+// the changes and the cancellation, without a preference to follow.
+//
+// The emails about the changes follow the notification preference of the recipient: they are
+// left out when the recipient disabled emails or muted the event, and held back for the Digester
+// when the recipient wants a digest or is in their quiet hours.
+//
+// The Message-ID of an email is derived from the domain event and the recipient, so the emails
+// sent again when a domain event is published again are recognized as duplicates by mail clients.
+type Notifier struct {
+	sender      Sender
+	addresses   AddressBook
+	preferences core.NotificationPreferenceRepository
+	digests     core.NotificationDigestRepository
+	from        string
+	// envelopeFrom is the bare address of from, and domain is its domain. The domain also names the
+	// messages and the calendar entries.
+	envelopeFrom string
+	domain       string
+}
+
+func NewNotifier(sender Sender, addresses AddressBook, preferences core.NotificationPreferenceRepository, digests core.NotificationDigestRepository, from string) *Notifier {
+	n := &Notifier{
+		sender:       sender,
+		addresses:    addresses,
+		preferences:  preferences,
+		digests:      digests,
+		from:         from,
+		envelopeFrom: from,
+		domain:       "event-scheduling-example",
+	}
+
+	if address, err := mail.ParseAddress(from); err == nil {
+		n.envelopeFrom = address.Address
+	}
+	if at := strings.LastIndex(n.envelopeFrom, "@"); at >= 0 {
+		n.domain = n.envelopeFrom[at+1:]
+	}
+	return n
+}
+
+// envelope is an email to be sent about a domain event.
+type envelope struct {
+	recipientID string
+	// address is set when the recipient is a guest, who is emailed at it right away
+	address  string
+	template string
+	// method is the iCalendar method of the attachment, listing the given attendees and guests
+	method    string
+	attendees []core.Invitation
+	guests    []core.Guest
+	removed   bool
+}
+
+func (n *Notifier) Publish(ctx context.Context, event core.DomainEvent) error {
+	if event.Event == nil {
+		return nil
+	}
+
+	var envelopes []envelope
+	invitees := event.Event.Invitations
+	guests := event.Event.Guests
+	forInvitees := func(name string, method string) {
+		for _, i := range invitees {
+			envelopes = append(envelopes, envelope{
+				recipientID: core.FormatUserID(i.UserID),
+				template:    name,
+				method:      method,
+				attendees:   invitees,
+				guests:      guests,
+			})
+		}
+		for _, g := range guests {
+			envelopes = append(envelopes, envelope{
+				address:   g.Email,
+				template:  name,
+				method:    method,
+				attendees: invitees,
+				guests:    guests,
+			})
+		}
+	}
+
+	switch event.Type {
+	case core.DomainEventType_EventCreated, core.DomainEventType_EventRestored:
+		forInvitees("invitation", methodRequest)
+	case core.DomainEventType_EventUpdated:
+		forInvitees("update", methodRequest)
+		for _, userID := range removedAttendees(event) {
+			id, _ := core.ParseUserID(userID)
+			envelopes = append(envelopes, envelope{
+				recipientID: userID,
+				template:    "cancellation",
+				method:      methodCancel,
+				attendees:   []core.Invitation{{UserID: id, Status: core.InvitationStatus_Declined}},
+				removed:     true,
+			})
+		}
+	case core.DomainEventType_EventDeleted:
+		forInvitees("cancellation", methodCancel)
+	case core.DomainEventType_InvitationResponded:
+		userID, _ := core.ParseUserID(event.ActorID)
+		invitation := event.Event.FindInvitation(userID)
+		if invitation == nil {
+			return nil
+		}
+		envelopes = append(envelopes, envelope{
+			recipientID: event.Event.CreatedBy,
+			template:    "rsvp",
+			method:      methodReply,
+			attendees:   []core.Invitation{*invitation},
+		})
+	case core.DomainEventType_WaitlistPromoted:
+		for _, userID := range event.Audience {
+			envelopes = append(envelopes, envelope{
+				recipientID: userID,
+				template:    "promotion",
+				method:      methodRequest,
+				attendees:   invitees,
+				guests:      guests,
+			})
+		}
+	}
+
+	var errs []error
+	for _, e := range envelopes {
+		if e.address == "" && e.recipientID == event.ActorID {
+			continue
+		}
+
+		err := n.publish(ctx, event, e)
+		if err != nil && e.address != "" {
+			errs = append(errs, fmt.Errorf("failed to email guest %s: %w", e.address, err))
+		} else if err != nil {
+			errs = append(errs, fmt.Errorf("failed to email user %s: %w", e.recipientID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (n *Notifier) publish(ctx context.Context, event core.DomainEvent, e envelope) error {
+	var preference *core.NotificationPreference
+	if e.address == "" {
+		var err error
+		preference, err = n.preferences.FindByUserID(ctx, e.recipientID)
+		if err != nil {
+			return err
+		}
+		if !preference.Notifies(core.ReminderChannel_Email, event.EventID) {
+			return nil
+		}
+	}
+
+	organizer, err := n.addresses.Address(ctx, event.Event.CreatedBy)
+	if err != nil {
+		return err
+	}
+
+	attendees := make([]attendee, len(e.attendees))
+	for index, i := range e.attendees {
+		address, err := n.addresses.Address(ctx, core.FormatUserID(i.UserID))
+		if err != nil {
+			return err
+		}
+		attendees[index] = attendee{Address: address, Status: i.Status}
+	}
+	for _, g := range e.guests {
+		attendees = append(attendees, attendee{Address: g.Email, Status: core.InvitationStatus_Confirmed})
+	}
+
+	data := newTemplateData(event.Event, event.ActorID)
+	data.Removed = e.removed
+	if e.template == "rsvp" {
+		data.Response, data.ResponseVerb = response(e.attendees[0].Status)
+		data.Waitlisted = e.attendees[0].Status == core.InvitationStatus_Waitlisted
+	}
+
+	subject, body, err := render(e.template, data)
+	if err != nil {
+		return err
+	}
+
+	cal := &calendar{
+		Method:    e.method,
+		Sequence:  event.OccurredAt.Unix(),
+		Stamp:     event.OccurredAt,
+		Domain:    n.domain,
+		Event:     event.Event,
+		Organizer: organizer,
+		Attendees: attendees,
+	}
+	if e.address != "" {
+		return n.sendTo(ctx, e.address, &message{
+			ID:          event.ID + "." + strings.ReplaceAll(e.address, "@", "="),
+			Subject:     subject,
+			Body:        body,
+			Attachments: []attachment{{Method: cal.Method, Content: cal.Bytes()}},
+		})
+	}
+	key := event.ID + "." + e.recipientID
+
+	now := time.Now()
+	if deliverAt := preference.DeliverAt(now); deliverAt.After(now) {
+		return n.digests.Hold(ctx, &core.DigestItem{
+			UserID:         e.recipientID,
+			Key:            key,
+			Subject:        subject,
+			Body:           body,
+			CalendarMethod: cal.Method,
+			Calendar:       cal.Bytes(),
+			SendAfter:      deliverAt,
+		})
+	}
+
+	return n.send(ctx, e.recipientID, &message{
+		ID:          key,
+		Subject:     subject,
+		Body:        body,
+		Attachments: []attachment{{Method: cal.Method, Content: cal.Bytes()}},
+	})
+}
+
+// Notify sends a reminder or an agenda of the email channel right away. The reminder worker
+// already followed the preference of the recipient, and they chose the channels of the agenda in
+// their preference.
+func (n *Notifier) Notify(ctx context.Context, notification *core.Notification) error {
+	if notification.Agenda != nil {
+		return n.notifyAgenda(ctx, notification)
+	}
+
+	data := newTemplateData(notification.Event, "")
+	data.OccurrenceAt = notification.OccurrenceAt.In(eventLocation(notification.Event)).Format(dateTimeLayout)
+
+	subject, body, err := render("reminder", data)
+	if err != nil {
+		return err
+	}
+	return n.send(ctx, notification.RecipientID, &message{
+		ID:      "reminder." + notification.ID,
+		Subject: subject,
+		Body:    body,
+	})
+}
+
+func (n *Notifier) notifyAgenda(ctx context.Context, notification *core.Notification) error {
+	data := newAgendaData(notification.Agenda)
+	subject, body, err := render("agenda", data)
+	if err != nil {
+		return err
+	}
+
+	var html bytes.Buffer
+	err = htmlTemplates.ExecuteTemplate(&html, "agenda.html", data)
+	if err != nil {
+		return err
+	}
+
+	return n.send(ctx, notification.RecipientID, &message{
+		ID:      "agenda." + notification.ID,
+		Subject: subject,
+		Body:    body,
+		HTML:    html.String(),
+	})
+}
+
+// send addresses the message to the recipient, its ID is the key of the message in the domain.
+func (n *Notifier) send(ctx context.Context, recipientID string, msg *message) error {
+	to, err := n.addresses.Address(ctx, recipientID)
+	if err != nil {
+		return err
+	}
+	return n.sendTo(ctx, to, msg)
+}
+
+// sendTo sends the message to the address.
+func (n *Notifier) sendTo(ctx context.Context, to string, msg *message) error {
+	msg.ID += "@" + n.domain
+	msg.From = n.from
+	msg.To = to
+	data, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	return n.sender.Send(ctx, n.envelopeFrom, []string{to}, data)
+}
+
+func render(name string, data any) (string, string, error) {
+	var subject, body bytes.Buffer
+	err := templates.ExecuteTemplate(&subject, name+".subject", data)
+	if err != nil {
+		return "", "", err
+	}
+	err = templates.ExecuteTemplate(&body, name+".body", data)
+	if err != nil {
+		return "", "", err
+	}
+	return subject.String(), body.String(), nil
+}
+
+// removedAttendees are the users who were invited before the change but no longer are.
+func removedAttendees(event core.DomainEvent) []string {
+	var removed []string
+	for _, userID := range event.Audience {
+		if userID == event.Event.CreatedBy {
+			continue
+		}
+		id, err := core.ParseUserID(userID)
+		if err != nil || event.Event.FindInvitation(id) != nil {
+			continue
+		}
+		removed = append(removed, userID)
+	}
+	return removed
+}
+
+func response(status core.InvitationStatus) (string, string) {
+	switch status {
+	case core.InvitationStatus_Confirmed:
+		return "Accepted", "accepted"
+	case core.InvitationStatus_Declined:
+		return "Declined", "declined"
+	case core.InvitationStatus_Waitlisted:
+		return "Waitlisted", "accepted"
+	default:
+		return "Responded", "responded to"
+	}
+}
+
+const (
+	dateLayout     = "Mon, 02 Jan 2006"
+	dateTimeLayout = "Mon, 02 Jan 2006 15:04"
+)
+
+type templateData struct {
+	Event    *core.Event
+	ActorID  string
+	Timezone string
+	// When describes each schedule of the event in its timezone
+	When []string
+	// Removed is set on the cancellation of an attendee who was removed from the event
+	Removed bool
+	// Response and ResponseVerb describe the response of the attendee to the organizer
+	Response     string
+	ResponseVerb string
+	// Waitlisted is set on the response of an attendee who accepted once the event was full
+	Waitlisted bool
+	// OccurrenceAt is the occurrence a reminder is about
+	OccurrenceAt string
+}
+
+func newTemplateData(event *core.Event, actorID string) *templateData {
+	loc := eventLocation(event)
+	data := &templateData{
+		Event:    event,
+		ActorID:  actorID,
+		Timezone: loc.String(),
+	}
+
+	for _, s := range event.Schedules {
+		start := time.Unix(s.StartTime, 0).In(loc)
+		end := s.EndTimeFrom(start)
+
+		var when string
+		switch {
+		case s.IsFullDay:
+			when = start.Format(dateLayout) + ", all day"
+		case start.YearDay() == end.YearDay() && start.Year() == end.Year():
+			when = start.Format(dateTimeLayout) + " - " + end.Format("15:04")
+		default:
+			when = start.Format(dateTimeLayout) + " - " + end.Format(dateTimeLayout)
+		}
+
+		switch s.RecurringType {
+		case core.RecurringType_Daily:
+			when += ", every day"
+		case core.RecurringType_Every_Week:
+			when += ", every week"
+		}
+		data.When = append(data.When, when)
+	}
+	return data
+}
+
+type agendaData struct {
+	Date     string
+	Timezone string
+	Items    []agendaItem
+}
+
+type agendaItem struct {
+	When        string
+	Title       string
+	Description string
+	// Note is how the recipient is involved in an event they didn't create
+	Note string
+}
+
+func newAgendaData(agenda *core.Agenda) *agendaData {
+	data := &agendaData{
+		Date:     agenda.Date.Format(dateLayout),
+		Timezone: agenda.Date.Location().String(),
+	}
+
+	for _, item := range agenda.Items {
+		var when string
+		switch {
+		case item.IsFullDay:
+			when = "All day"
+		case item.End.YearDay() == item.Start.YearDay() && item.End.Year() == item.Start.Year():
+			when = item.Start.Format("15:04") + " - " + item.End.Format("15:04")
+		default:
+			when = item.Start.Format(dateTimeLayout) + " - " + item.End.Format(dateTimeLayout)
+		}
+
+		var note string
+		switch {
+		case item.CreatedBy == agenda.UserID:
+		case item.Status == core.InvitationStatus_Confirmed:
+			note = fmt.Sprintf("You accepted the invitation of user %s.", item.CreatedBy)
+		case item.Status == core.InvitationStatus_Waitlisted:
+			note = fmt.Sprintf("You are on the waitlist of the event of user %s.", item.CreatedBy)
+		default:
+			note = fmt.Sprintf("User %s invited you, you haven't responded yet.", item.CreatedBy)
+		}
+
+		data.Items = append(data.Items, agendaItem{
+			When:        when,
+			Title:       item.Title,
+			Description: item.Description,
+			Note:        note,
+		})
+	}
+	return data
+}
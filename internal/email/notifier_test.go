@@ -0,0 +1,543 @@
+package email_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/email"
+	"github.com/dzakaammar/event-scheduling-example/internal/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const from = "Event Scheduling <noreply@example.com>"
+
+var addresses = email.AddressFormat("user-%s@example.com")
+
+type parsedMail struct {
+	To        string
+	Subject   string
+	MessageID string
+	Body      string
+	HTML      string
+	// Calendar is the .ics attachment with its lines unfolded
+	Calendar      []string
+	CalendarLines []string
+}
+
+func parseMail(t *testing.T, data []byte) parsedMail {
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	parsed := parsedMail{
+		To:        msg.Header.Get("To"),
+		Subject:   subject,
+		MessageID: msg.Header.Get("Message-ID"),
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	if mediaType == "text/plain" {
+		body, err := io.ReadAll(quotedprintable.NewReader(msg.Body))
+		require.NoError(t, err)
+		parsed.Body = string(body)
+		return parsed
+	}
+
+	if mediaType == "multipart/alternative" {
+		alternatives := multipart.NewReader(msg.Body, params["boundary"])
+		for _, field := range []*string{&parsed.Body, &parsed.HTML} {
+			part, err := alternatives.NextPart()
+			require.NoError(t, err)
+			content, err := io.ReadAll(part)
+			require.NoError(t, err)
+			*field = string(content)
+		}
+		return parsed
+	}
+
+	require.Equal(t, "multipart/mixed", mediaType)
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+
+	body, err := parts.NextPart()
+	require.NoError(t, err)
+	content, err := io.ReadAll(body)
+	require.NoError(t, err)
+	parsed.Body = string(content)
+
+	attachment, err := parts.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "invite.ics", attachment.FileName())
+	content, err = io.ReadAll(base64.NewDecoder(base64.StdEncoding, attachment))
+	require.NoError(t, err)
+
+	raw := string(content)
+	parsed.CalendarLines = strings.Split(strings.TrimSuffix(raw, "\r\n"), "\r\n")
+	parsed.Calendar = strings.Split(strings.TrimSuffix(strings.ReplaceAll(raw, "\r\n ", ""), "\r\n"), "\r\n")
+	return parsed
+}
+
+// newPreferences returns the given preferences, and the default preference of the other users.
+func newPreferences(ctrl *gomock.Controller, preferences map[string]*core.NotificationPreference) *mock.MockNotificationPreferenceRepository {
+	repo := mock.NewMockNotificationPreferenceRepository(ctrl)
+	repo.EXPECT().FindByUserID(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, userID string) (*core.NotificationPreference, error) {
+			if preference, ok := preferences[userID]; ok {
+				return preference, nil
+			}
+			return core.DefaultNotificationPreference(userID), nil
+		}).AnyTimes()
+	return repo
+}
+
+func newEvent() *core.Event {
+	return &core.Event{
+		ID:          "123",
+		Title:       "Weekly sync; planning, retro",
+		Description: "Bring your notes.\n" + strings.Repeat("Going through the roadmap of the quarter. ", 3),
+		Timezone:    "Asia/Jakarta",
+		CreatedBy:   "1",
+		Schedules: []core.Schedule{
+			{
+				ID:                "s1",
+				EventID:           "123",
+				StartTime:         time.Date(2026, 1, 5, 3, 0, 0, 0, time.UTC).Unix(),
+				DurationInMinutes: 60,
+				RecurringType:     core.RecurringType_Every_Week,
+				RecurringInterval: int64(7 * 24 * time.Hour.Seconds()),
+			},
+		},
+		Invitations: []core.Invitation{
+			{ID: "i2", EventID: "123", UserID: 2, Status: core.InvitationStatus_Confirmed},
+			{ID: "i3", EventID: "123", UserID: 3, Status: core.InvitationStatus_Unknown},
+		},
+	}
+}
+
+func TestNotifier_Publish(t *testing.T) {
+	type wantMail struct {
+		to           string
+		subject      string
+		bodyContains string
+		// calendar holds the lines the attachment must contain
+		calendar []string
+		// messageID is the local part of the Message-ID, when it isn't named after the user
+		messageID string
+	}
+	occurredAt := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	// quiet hours that last the whole day but a minute, so that they are on while the test runs
+	quietHoursEnd := time.Now().UTC().Add(2 * time.Minute).Format("15:04")
+	quietHoursStart := time.Now().UTC().Add(3 * time.Minute).Format("15:04")
+
+	tests := []struct {
+		name        string
+		event       core.DomainEvent
+		server      func(t *testing.T) *smtpServer
+		preferences map[string]*core.NotificationPreference
+		digestsMock func(digests *mock.MockNotificationDigestRepository)
+		wantMails   []wantMail
+		wantErr     bool
+	}{
+		{
+			name: "OK - the attendees are invited",
+			event: core.DomainEvent{
+				ID: "d1", Type: core.DomainEventType_EventCreated, EventID: "123", ActorID: "1",
+				Event: newEvent(), Audience: []string{"1", "2", "3"}, OccurredAt: occurredAt,
+			},
+			wantMails: []wantMail{
+				{
+					to:           "user-2@example.com",
+					subject:      "Invitation: Weekly sync; planning, retro",
+					bodyContains: "Mon, 05 Jan 2026 10:00 - 11:00, every week",
+					calendar: []string{
+						"METHOD:REQUEST",
+						"UID:123-0@example.com",
+						"SEQUENCE:1767254400",
+						"DTSTART:20260105T030000Z",
+						"DTEND:20260105T040000Z",
+						"RRULE:FREQ=WEEKLY",
+						`SUMMARY:Weekly sync\; planning\, retro`,
+						`DESCRIPTION:Bring your notes.\n` + strings.Repeat("Going through the roadmap of the quarter. ", 3),
+						"ORGANIZER:mailto:user-1@example.com",
+						"ATTENDEE;PARTSTAT=ACCEPTED;RSVP=TRUE:mailto:user-2@example.com",
+						"ATTENDEE;PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:user-3@example.com",
+						"STATUS:CONFIRMED",
+					},
+				},
+				{
+					to:           "user-3@example.com",
+					subject:      "Invitation: Weekly sync; planning, retro",
+					bodyContains: `User 1 invited you to "Weekly sync; planning, retro".`,
+					calendar:     []string{"METHOD:REQUEST"},
+				},
+			},
+		},
+		{
+			name: "OK - the guests are emailed at their address",
+			event: core.DomainEvent{
+				ID: "d1", Type: core.DomainEventType_EventCreated, EventID: "123", ActorID: "1",
+				Event: func() *core.Event {
+					event := newEvent()
+					event.Invitations = event.Invitations[:1]
+					event.Guests = []core.Guest{{EventID: "123", Name: "Ana", Email: "ana@example.org"}}
+					return event
+				}(),
+				Audience: []string{"1", "2"}, OccurredAt: occurredAt,
+			},
+			preferences: map[string]*core.NotificationPreference{
+				// a guest has no preference, the one of a user can't keep them from being emailed
+				"": {Channels: []core.ReminderChannel{core.ReminderChannel_Push}, Timezone: "UTC", Delivery: core.NotificationDelivery_Immediate, DigestTime: "08:00"},
+			},
+			wantMails: []wantMail{
+				{
+					to:       "user-2@example.com",
+					subject:  "Invitation: Weekly sync; planning, retro",
+					calendar: []string{"ATTENDEE;PARTSTAT=ACCEPTED;RSVP=TRUE:mailto:ana@example.org"},
+				},
+				{
+					to:           "ana@example.org",
+					subject:      "Invitation: Weekly sync; planning, retro",
+					bodyContains: `User 1 invited you to "Weekly sync; planning, retro".`,
+					calendar: []string{
+						"METHOD:REQUEST",
+						"ATTENDEE;PARTSTAT=ACCEPTED;RSVP=TRUE:mailto:user-2@example.com",
+						"ATTENDEE;PARTSTAT=ACCEPTED;RSVP=TRUE:mailto:ana@example.org",
+					},
+					messageID: "d1.ana=example.org",
+				},
+			},
+		},
+		{
+			name: "OK - the removed attendees learn they are no longer invited",
+			event: core.DomainEvent{
+				ID: "d1", Type: core.DomainEventType_EventUpdated, EventID: "123", ActorID: "1",
+				Event: newEvent(), Audience: []string{"1", "2", "3", "4"}, OccurredAt: occurredAt,
+			},
+			wantMails: []wantMail{
+				{to: "user-2@example.com", subject: "Updated: Weekly sync; planning, retro", calendar: []string{"METHOD:REQUEST"}},
+				{to: "user-3@example.com", subject: "Updated: Weekly sync; planning, retro", calendar: []string{"METHOD:REQUEST"}},
+				{
+					to:           "user-4@example.com",
+					subject:      "Cancelled: Weekly sync; planning, retro",
+					bodyContains: "removed you from",
+					calendar: []string{
+						"METHOD:CANCEL",
+						"ATTENDEE;PARTSTAT=DECLINED;RSVP=TRUE:mailto:user-4@example.com",
+						"STATUS:CANCELLED",
+					},
+				},
+			},
+		},
+		{
+			name: "OK - the actor isn't emailed about their own change",
+			event: core.DomainEvent{
+				ID: "d1", Type: core.DomainEventType_EventUpdated, EventID: "123", ActorID: "2",
+				Event: newEvent(), Audience: []string{"1", "2", "3"}, OccurredAt: occurredAt,
+			},
+			wantMails: []wantMail{
+				{to: "user-3@example.com", subject: "Updated: Weekly sync; planning, retro", bodyContains: "User 2 updated"},
+			},
+		},
+		{
+			name: "OK - cancelled",
+			event: core.DomainEvent{
+				ID: "d1", Type: core.DomainEventType_EventDeleted, EventID: "123", ActorID: "1",
+				Event: newEvent(), Audience: []string{"1", "2", "3"}, OccurredAt: occurredAt,
+			},
+			wantMails: []wantMail{
+				{to: "user-2@example.com", subject: "Cancelled: Weekly sync; planning, retro", bodyContains: `User 1 cancelled "Weekly sync; planning, retro".`, calendar: []string{"METHOD:CANCEL", "STATUS:CANCELLED"}},
+				{to: "user-3@example.com", subject: "Cancelled: Weekly sync; planning, retro", calendar: []string{"METHOD:CANCEL", "STATUS:CANCELLED"}},
+			},
+		},
+		{
+			name: "OK - the organizer learns about the response",
+			event: core.DomainEvent{
+				ID: "d1", Type: core.DomainEventType_InvitationResponded, EventID: "123", ActorID: "2",
+				Event: newEvent(), Audience: []string{"1", "2", "3"}, OccurredAt: occurredAt,
+			},
+			wantMails: []wantMail{
+				{
+					to:           "user-1@example.com",
+					subject:      "Accepted: Weekly sync; planning, retro",
+					bodyContains: "User 2 accepted your invitation",
+					calendar: []string{
+						"METHOD:REPLY",
+						"ATTENDEE;PARTSTAT=ACCEPTED;RSVP=TRUE:mailto:user-2@example.com",
+					},
+				},
+			},
+		},
+		{
+			name: "OK - the organizer learns that the attendee is waitlisted",
+			event: core.DomainEvent{
+				ID: "d1", Type: core.DomainEventType_InvitationResponded, EventID: "123", ActorID: "2",
+				Event: func() *core.Event {
+					event := newEvent()
+					event.Invitations[0].Status = core.InvitationStatus_Waitlisted
+					return event
+				}(),
+				Audience: []string{"1", "2", "3"}, OccurredAt: occurredAt,
+			},
+			wantMails: []wantMail{
+				{
+					to:           "user-1@example.com",
+					subject:      "Waitlisted: Weekly sync; planning, retro",
+					bodyContains: "User 2 accepted your invitation to \"Weekly sync; planning, retro\". The event is full, they are on its waitlist.",
+					calendar: []string{
+						"METHOD:REPLY",
+						"ATTENDEE;PARTSTAT=TENTATIVE;RSVP=TRUE:mailto:user-2@example.com",
+					},
+				},
+			},
+		},
+		{
+			name: "OK - only the attendee promoted off the waitlist is told",
+			event: core.DomainEvent{
+				ID: "d1", Type: core.DomainEventType_WaitlistPromoted, EventID: "123", ActorID: "2",
+				Event: func() *core.Event {
+					event := newEvent()
+					event.Invitations[0].Status = core.InvitationStatus_Declined
+					event.Invitations[1].Status = core.InvitationStatus_Confirmed
+					return event
+				}(),
+				Audience: []string{"3"}, OccurredAt: occurredAt,
+			},
+			wantMails: []wantMail{
+				{
+					to:           "user-3@example.com",
+					subject:      "Confirmed: Weekly sync; planning, retro",
+					bodyContains: "you are off its waitlist and attend it",
+					calendar: []string{
+						"METHOD:REQUEST",
+						"ATTENDEE;PARTSTAT=ACCEPTED;RSVP=TRUE:mailto:user-3@example.com",
+					},
+				},
+			},
+		},
+		{
+			name: "OK - the attendees who muted the event or disabled emails aren't emailed",
+			event: core.DomainEvent{
+				ID: "d1", Type: core.DomainEventType_EventUpdated, EventID: "123", ActorID: "1",
+				Event: newEvent(), Audience: []string{"1", "2", "3", "4"}, OccurredAt: occurredAt,
+			},
+			preferences: map[string]*core.NotificationPreference{
+				"2": {UserID: "2", Channels: []core.ReminderChannel{core.ReminderChannel_Email}, Timezone: "UTC", Delivery: core.NotificationDelivery_Immediate, DigestTime: "08:00", MutedEventIDs: []string{"123"}},
+				"4": {UserID: "4", Channels: []core.ReminderChannel{core.ReminderChannel_Push}, Timezone: "UTC", Delivery: core.NotificationDelivery_Immediate, DigestTime: "08:00"},
+			},
+			wantMails: []wantMail{
+				{to: "user-3@example.com", subject: "Updated: Weekly sync; planning, retro"},
+			},
+		},
+		{
+			name: "OK - held back for the attendee who wants a digest",
+			event: core.DomainEvent{
+				ID: "d1", Type: core.DomainEventType_EventCreated, EventID: "123", ActorID: "1",
+				Event: newEvent(), Audience: []string{"1", "2", "3"}, OccurredAt: occurredAt,
+			},
+			preferences: map[string]*core.NotificationPreference{
+				"2": {UserID: "2", Channels: []core.ReminderChannel{core.ReminderChannel_Email}, Timezone: "Asia/Jakarta", Delivery: core.NotificationDelivery_Digest, DigestTime: "08:00"},
+			},
+			digestsMock: func(digests *mock.MockNotificationDigestRepository) {
+				digests.EXPECT().Hold(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, item *core.DigestItem) error {
+					assert.Equal(t, "2", item.UserID)
+					assert.Equal(t, "d1.2", item.Key)
+					assert.Equal(t, "Invitation: Weekly sync; planning, retro", item.Subject)
+					assert.Contains(t, item.Body, "invited you to")
+					assert.Equal(t, "REQUEST", item.CalendarMethod)
+					assert.Contains(t, string(item.Calendar), "UID:123-0@example.com")
+
+					loc, _ := time.LoadLocation("Asia/Jakarta")
+					at := item.SendAfter.In(loc)
+					assert.True(t, item.SendAfter.After(time.Now()))
+					assert.Equal(t, []int{8, 0}, []int{at.Hour(), at.Minute()})
+					return nil
+				})
+			},
+			wantMails: []wantMail{
+				{to: "user-3@example.com", subject: "Invitation: Weekly sync; planning, retro"},
+			},
+		},
+		{
+			name: "OK - held back until the quiet hours of the attendee end",
+			event: core.DomainEvent{
+				ID: "d1", Type: core.DomainEventType_EventDeleted, EventID: "123", ActorID: "1",
+				Event: newEvent(), Audience: []string{"1", "2", "3"}, OccurredAt: occurredAt,
+			},
+			preferences: map[string]*core.NotificationPreference{
+				"3": {UserID: "3", Channels: []core.ReminderChannel{core.ReminderChannel_Email}, Timezone: "UTC", QuietHoursStart: quietHoursStart, QuietHoursEnd: quietHoursEnd, Delivery: core.NotificationDelivery_Immediate, DigestTime: "08:00"},
+			},
+			digestsMock: func(digests *mock.MockNotificationDigestRepository) {
+				digests.EXPECT().Hold(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, item *core.DigestItem) error {
+					assert.Equal(t, "3", item.UserID)
+					assert.Equal(t, "CANCEL", item.CalendarMethod)
+					assert.Equal(t, quietHoursEnd, item.SendAfter.UTC().Format("15:04"))
+					return nil
+				})
+			},
+			wantMails: []wantMail{
+				{to: "user-2@example.com", subject: "Cancelled: Weekly sync; planning, retro"},
+			},
+		},
+		{
+			name: "Not OK - error holding back",
+			event: core.DomainEvent{
+				ID: "d1", Type: core.DomainEventType_EventDeleted, EventID: "123", ActorID: "1",
+				Event: newEvent(), Audience: []string{"1", "2"}, OccurredAt: occurredAt,
+			},
+			preferences: map[string]*core.NotificationPreference{
+				"2": {UserID: "2", Channels: []core.ReminderChannel{core.ReminderChannel_Email}, Timezone: "UTC", Delivery: core.NotificationDelivery_Digest, DigestTime: "08:00"},
+			},
+			digestsMock: func(digests *mock.MockNotificationDigestRepository) {
+				digests.EXPECT().Hold(gomock.Any(), gomock.Any()).Return(errors.New("error")) //nolint:goerr113
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not OK - the mail server is unavailable",
+			event: core.DomainEvent{
+				ID: "d1", Type: core.DomainEventType_EventDeleted, EventID: "123", ActorID: "1",
+				Event: newEvent(), Audience: []string{"1", "2", "3"}, OccurredAt: occurredAt,
+			},
+			server: func(t *testing.T) *smtpServer {
+				return newSMTPServer(t, rejectingRecipients)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newSMTPServer(t)
+			if tt.server != nil {
+				server = tt.server(t)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			digests := mock.NewMockNotificationDigestRepository(ctrl)
+			if tt.digestsMock != nil {
+				tt.digestsMock(digests)
+			}
+
+			n := email.NewNotifier(email.NewSMTPClient(server.Addr(), "", ""), addresses, newPreferences(ctrl, tt.preferences), digests, from)
+			err := n.Publish(context.Background(), tt.event)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			mails := server.Mails()
+			require.Len(t, mails, len(tt.wantMails))
+			for index, want := range tt.wantMails {
+				assert.Equal(t, "noreply@example.com", mails[index].From)
+				assert.Equal(t, []string{want.to}, mails[index].To)
+
+				got := parseMail(t, mails[index].Data)
+				assert.Equal(t, want.to, got.To)
+				assert.Equal(t, want.subject, got.Subject)
+				assert.Contains(t, got.Body, want.bodyContains)
+				messageID := want.messageID
+				if messageID == "" {
+					messageID = "d1." + strings.TrimSuffix(strings.TrimPrefix(want.to, "user-"), "@example.com")
+				}
+				assert.Equal(t, "<"+messageID+"@example.com>", got.MessageID)
+				for _, line := range want.calendar {
+					assert.Contains(t, got.Calendar, line)
+				}
+				for _, line := range got.CalendarLines {
+					assert.LessOrEqual(t, len(line), 75, "lines of the attachment must be folded")
+				}
+			}
+		})
+	}
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newSMTPServer(t)
+	// reminders are sent on the channel they were set for, so the preferences aren't consulted
+	n := email.NewNotifier(email.NewSMTPClient(server.Addr(), "", ""), addresses, mock.NewMockNotificationPreferenceRepository(ctrl), mock.NewMockNotificationDigestRepository(ctrl), from)
+
+	err := n.Notify(context.Background(), &core.Notification{
+		ID:           "42",
+		Channel:      core.ReminderChannel_Email,
+		RecipientID:  "2",
+		Event:        newEvent(),
+		OccurrenceAt: time.Date(2026, 1, 12, 3, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+
+	mails := server.Mails()
+	require.Len(t, mails, 1)
+	assert.Equal(t, []string{"user-2@example.com"}, mails[0].To)
+
+	got := parseMail(t, mails[0].Data)
+	assert.Equal(t, "Reminder: Weekly sync; planning, retro at Mon, 12 Jan 2026 10:00", got.Subject)
+	assert.Equal(t, "<reminder.42@example.com>", got.MessageID)
+	assert.Contains(t, got.Body, "starts at Mon, 12 Jan 2026 10:00 (Asia/Jakarta)")
+	assert.Empty(t, got.Calendar)
+}
+
+func TestNotifier_Notify_Agenda(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newSMTPServer(t)
+	n := email.NewNotifier(email.NewSMTPClient(server.Addr(), "", ""), addresses, mock.NewMockNotificationPreferenceRepository(ctrl), mock.NewMockNotificationDigestRepository(ctrl), from)
+
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+	err = n.Notify(context.Background(), &core.Notification{
+		ID:          "7",
+		Channel:     core.ReminderChannel_Email,
+		RecipientID: "2",
+		Agenda: &core.Agenda{
+			UserID: "2",
+			Date:   time.Date(2026, 1, 12, 0, 0, 0, 0, loc),
+			Items: []core.AgendaItem{
+				{
+					EventID: "a", Title: "Holiday", CreatedBy: "2", IsFullDay: true,
+					Start: time.Date(2026, 1, 12, 0, 0, 0, 0, loc), End: time.Date(2026, 1, 13, 0, 0, 0, 0, loc),
+				},
+				{
+					EventID: "b", Title: "Weekly sync <planning>", Description: "Agenda & notes", CreatedBy: "1",
+					Start: time.Date(2026, 1, 12, 10, 0, 0, 0, loc), End: time.Date(2026, 1, 12, 11, 0, 0, 0, loc),
+					Status: core.InvitationStatus_Confirmed,
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	mails := server.Mails()
+	require.Len(t, mails, 1)
+	assert.Equal(t, []string{"user-2@example.com"}, mails[0].To)
+
+	got := parseMail(t, mails[0].Data)
+	assert.Equal(t, "Your agenda for Mon, 12 Jan 2026", got.Subject)
+	assert.Equal(t, "<agenda.7@example.com>", got.MessageID)
+	assert.Contains(t, got.Body, "Here is your day (Asia/Jakarta):")
+	assert.Contains(t, got.Body, "All day  Holiday")
+	assert.Contains(t, got.Body, "10:00 - 11:00  Weekly sync <planning>")
+	assert.Contains(t, got.Body, "You accepted the invitation of user 1.")
+	// the values are escaped in the HTML alternative
+	assert.Contains(t, got.HTML, "<strong>Weekly sync &lt;planning&gt;</strong>")
+	assert.Contains(t, got.HTML, "Agenda &amp; notes")
+}
@@ -0,0 +1,160 @@
+package email_test
+
+import (
+	"encoding/base64"
+	"io"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type receivedMail struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+// smtpServer is an in-process stand-in of a mail server. It requires AUTH PLAIN when a username is
+// set, and accepts every message unless it is told to reject the recipients.
+type smtpServer struct {
+	listener   net.Listener
+	username   string
+	password   string
+	rejectRcpt bool
+
+	mu    sync.Mutex
+	mails []receivedMail
+}
+
+func newSMTPServer(t *testing.T, opts ...func(s *smtpServer)) *smtpServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &smtpServer{listener: listener}
+	for _, opt := range opts {
+		opt(s)
+	}
+	go s.serve()
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+	return s
+}
+
+func withAuth(username string, password string) func(s *smtpServer) {
+	return func(s *smtpServer) {
+		s.username = username
+		s.password = password
+	}
+}
+
+func rejectingRecipients(s *smtpServer) {
+	s.rejectRcpt = true
+}
+
+func (s *smtpServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *smtpServer) Mails() []receivedMail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]receivedMail(nil), s.mails...)
+}
+
+func (s *smtpServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *smtpServer) handle(conn net.Conn) {
+	c := textproto.NewConn(conn)
+	defer c.Close()
+
+	authenticated := s.username == ""
+	var mail receivedMail
+	reply := func(format string, args ...any) {
+		_ = c.PrintfLine(format, args...)
+	}
+
+	reply("220 localhost ESMTP stand-in")
+	for {
+		line, err := c.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			if s.username != "" {
+				reply("250-localhost")
+				reply("250 AUTH PLAIN")
+			} else {
+				reply("250 localhost")
+			}
+		case "HELO", "NOOP":
+			reply("250 OK")
+		case "AUTH":
+			_, credentials, _ := strings.Cut(arg, " ")
+			decoded, _ := base64.StdEncoding.DecodeString(credentials)
+			if string(decoded) != "\x00"+s.username+"\x00"+s.password {
+				reply("535 5.7.8 Authentication credentials invalid")
+				continue
+			}
+			authenticated = true
+			reply("235 2.7.0 Authentication successful")
+		case "MAIL":
+			if !authenticated {
+				reply("530 5.7.0 Authentication required")
+				continue
+			}
+			mail = receivedMail{From: address(arg)}
+			reply("250 OK")
+		case "RCPT":
+			if s.rejectRcpt {
+				reply("550 5.1.1 Mailbox unavailable")
+				continue
+			}
+			mail.To = append(mail.To, address(arg))
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			mail.Data, err = io.ReadAll(c.DotReader())
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.mails = append(s.mails, mail)
+			s.mu.Unlock()
+			reply("250 OK")
+		case "RSET":
+			mail = receivedMail{}
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+// address takes the address out of e.g: "FROM:<noreply@example.com>".
+func address(arg string) string {
+	start := strings.Index(arg, "<")
+	end := strings.Index(arg, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return arg[start+1 : end]
+}
@@ -0,0 +1,41 @@
+package endpoint
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	v1 "github.com/dzakaammar/event-scheduling-example/gen/go/proto/v1"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+func (g *GRPCEndpoint) GetAgenda(ctx context.Context, req *v1.GetAgendaRequest) (*v1.GetAgendaResponse, error) {
+	agenda, err := g.agendas.GetAgenda(ctx, &core.GetAgendaRequest{
+		ActorID: extractAuthorization(ctx),
+		Date:    req.GetDate(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	items := make([]*v1.AgendaItem, len(agenda.Items))
+	for index, item := range agenda.Items {
+		items[index] = &v1.AgendaItem{
+			EventId:     item.EventID,
+			Title:       item.Title,
+			Description: item.Description,
+			CreatedBy:   item.CreatedBy,
+			StartTime:   item.Start.Format(time.RFC3339),
+			EndTime:     item.End.Format(time.RFC3339),
+			IsFullDay:   item.IsFullDay,
+			Status:      mapInvitationStatusToPB(item.Status),
+		}
+	}
+
+	return &v1.GetAgendaResponse{
+		Date:     agenda.Date.Format(core.DateLayout),
+		Timezone: agenda.Date.Location().String(),
+		Items:    items,
+	}, nil
+}
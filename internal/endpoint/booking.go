@@ -0,0 +1,215 @@
+package endpoint
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	v1 "github.com/dzakaammar/event-scheduling-example/gen/go/proto/v1"
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (g *GRPCEndpoint) CreateBookingPage(ctx context.Context, req *v1.CreateBookingPageRequest) (*v1.CreateBookingPageResponse, error) {
+	if req.GetBookingPage() == nil {
+		return nil, invalidArgument("booking_page", internal.ErrInvalidRequest)
+	}
+
+	actorID := extractAuthorization(ctx)
+
+	page := core.NewBookingPage(actorID)
+	parseBookingPage(req.GetBookingPage(), page)
+
+	err := g.bookings.CreateBookingPage(ctx, &core.CreateBookingPageRequest{
+		ActorID: actorID,
+		Page:    page,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.CreateBookingPageResponse{
+		Id: page.ID,
+	}, nil
+}
+
+func (g *GRPCEndpoint) UpdateBookingPage(ctx context.Context, req *v1.UpdateBookingPageRequest) (*emptypb.Empty, error) {
+	if req.GetBookingPage() == nil {
+		return nil, invalidArgument("booking_page", internal.ErrInvalidRequest)
+	}
+
+	page := &core.BookingPage{ID: req.GetId()}
+	parseBookingPage(req.GetBookingPage(), page)
+
+	err := g.bookings.UpdateBookingPage(ctx, &core.UpdateBookingPageRequest{
+		ActorID: extractAuthorization(ctx),
+		Page:    page,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) DeleteBookingPage(ctx context.Context, req *v1.DeleteBookingPageRequest) (*emptypb.Empty, error) {
+	err := g.bookings.DeleteBookingPage(ctx, &core.DeleteBookingPageRequest{
+		ActorID: extractAuthorization(ctx),
+		PageID:  req.GetId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) ListBookingPages(ctx context.Context, _ *v1.ListBookingPagesRequest) (*v1.ListBookingPagesResponse, error) {
+	pages, err := g.bookings.ListBookingPages(ctx, &core.ListBookingPagesRequest{
+		ActorID: extractAuthorization(ctx),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	res := make([]*v1.BookingPage, len(pages))
+	for index := range pages {
+		res[index] = parseBookingPageToPB(&pages[index])
+	}
+
+	return &v1.ListBookingPagesResponse{
+		BookingPages: res,
+	}, nil
+}
+
+func (g *GRPCEndpoint) FindBookingPageByID(ctx context.Context, req *v1.FindBookingPageByIDRequest) (*v1.FindBookingPageByIDResponse, error) {
+	page, err := g.bookings.FindBookingPageByID(ctx, &core.FindBookingPageByIDRequest{
+		PageID: req.GetId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.FindBookingPageByIDResponse{
+		BookingPage: parseBookingPageToPB(page),
+	}, nil
+}
+
+func (g *GRPCEndpoint) ListSlots(ctx context.Context, req *v1.ListSlotsRequest) (*v1.ListSlotsResponse, error) {
+	from, err := time.Parse(time.RFC3339, req.GetFrom())
+	if err != nil {
+		return nil, invalidArgument("from", internal.ErrInvalidRequest)
+	}
+
+	to, err := time.Parse(time.RFC3339, req.GetTo())
+	if err != nil {
+		return nil, invalidArgument("to", internal.ErrInvalidRequest)
+	}
+
+	slots, err := g.bookings.ListSlots(ctx, &core.ListSlotsRequest{
+		PageID: req.GetId(),
+		From:   from,
+		To:     to,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	res := make([]*v1.Slot, len(slots))
+	for index, slot := range slots {
+		res[index] = &v1.Slot{
+			StartTime: slot.Start.Format(time.RFC3339),
+			EndTime:   slot.End.Format(time.RFC3339),
+		}
+	}
+
+	return &v1.ListSlotsResponse{
+		Slots: res,
+	}, nil
+}
+
+func (g *GRPCEndpoint) BookSlot(ctx context.Context, req *v1.BookSlotRequest) (*v1.BookSlotResponse, error) {
+	if req.GetGuest() == nil {
+		return nil, invalidArgument("guest", internal.ErrInvalidRequest)
+	}
+
+	start, err := time.Parse(time.RFC3339, req.GetStartTime())
+	if err != nil {
+		return nil, invalidArgument("start_time", internal.ErrInvalidRequest)
+	}
+
+	booking, err := g.bookings.BookSlot(ctx, &core.BookSlotRequest{
+		PageID: req.GetId(),
+		Start:  start,
+		Guest: core.Guest{
+			Name:  req.GetGuest().GetName(),
+			Email: req.GetGuest().GetEmail(),
+		},
+		Notes: req.GetNotes(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.BookSlotResponse{
+		Id:      booking.ID,
+		EventId: booking.EventID,
+	}, nil
+}
+
+// parseBookingPage sets the fields of page that the caller can write from pb.
+func parseBookingPage(pb *v1.BookingPage, page *core.BookingPage) {
+	page.Title = pb.GetTitle()
+	page.Description = pb.GetDescription()
+	page.Timezone = pb.GetTimezone()
+	page.DurationMinutes = pb.GetDurationMinutes()
+	page.BufferBeforeMinutes = pb.GetBufferBeforeMinutes()
+	page.BufferAfterMinutes = pb.GetBufferAfterMinutes()
+	page.MinNoticeMinutes = pb.GetMinNoticeMinutes()
+	page.MaxPerDay = pb.GetMaxPerDay()
+
+	page.Rules = make([]core.AvailabilityRule, len(pb.GetRules()))
+	for index, rule := range pb.GetRules() {
+		page.Rules[index] = core.AvailabilityRule{
+			Weekday: time.Weekday(rule.GetWeekday()),
+			Start:   rule.GetStart(),
+			End:     rule.GetEnd(),
+		}
+	}
+}
+
+func parseBookingPageToPB(page *core.BookingPage) *v1.BookingPage {
+	rules := make([]*v1.AvailabilityRule, len(page.Rules))
+	for index, rule := range page.Rules {
+		rules[index] = &v1.AvailabilityRule{
+			Weekday: int32(rule.Weekday),
+			Start:   rule.Start,
+			End:     rule.End,
+		}
+	}
+
+	res := &v1.BookingPage{
+		Id:                  page.ID,
+		OwnerId:             page.OwnerID,
+		Title:               page.Title,
+		Description:         page.Description,
+		Timezone:            page.Timezone,
+		DurationMinutes:     page.DurationMinutes,
+		BufferBeforeMinutes: page.BufferBeforeMinutes,
+		BufferAfterMinutes:  page.BufferAfterMinutes,
+		MinNoticeMinutes:    page.MinNoticeMinutes,
+		MaxPerDay:           page.MaxPerDay,
+		Rules:               rules,
+		CreatedAt:           page.CreatedAt.Format(time.RFC3339),
+	}
+	if page.UpdatedAt != nil {
+		res.LastUpdatedAt = page.UpdatedAt.Format(time.RFC3339)
+	}
+	return res
+}
@@ -0,0 +1,259 @@
+package endpoint
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	v1 "github.com/dzakaammar/event-scheduling-example/gen/go/proto/v1"
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (g *GRPCEndpoint) CreateCalendar(ctx context.Context, req *v1.CreateCalendarRequest) (*v1.CreateCalendarResponse, error) {
+	if req.GetCalendar() == nil {
+		return nil, invalidArgument("calendar", internal.ErrInvalidRequest)
+	}
+
+	actorID := extractAuthorization(ctx)
+
+	calendar := core.NewCalendar(actorID)
+	calendar.Name = req.GetCalendar().GetName()
+	calendar.Color = req.GetCalendar().GetColor()
+	calendar.Timezone = req.GetCalendar().GetTimezone()
+	calendar.Description = req.GetCalendar().GetDescription()
+
+	err := g.calendars.CreateCalendar(ctx, &core.CreateCalendarRequest{
+		ActorID:  actorID,
+		Calendar: calendar,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.CreateCalendarResponse{
+		Id: calendar.ID,
+	}, nil
+}
+
+func (g *GRPCEndpoint) UpdateCalendar(ctx context.Context, req *v1.UpdateCalendarRequest) (*emptypb.Empty, error) {
+	if req.GetCalendar() == nil {
+		return nil, invalidArgument("calendar", internal.ErrInvalidRequest)
+	}
+
+	err := g.calendars.UpdateCalendar(ctx, &core.UpdateCalendarRequest{
+		ActorID: extractAuthorization(ctx),
+		Calendar: &core.Calendar{
+			ID:          req.GetId(),
+			Name:        req.GetCalendar().GetName(),
+			Color:       req.GetCalendar().GetColor(),
+			Timezone:    req.GetCalendar().GetTimezone(),
+			Description: req.GetCalendar().GetDescription(),
+		},
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) DeleteCalendar(ctx context.Context, req *v1.DeleteCalendarRequest) (*emptypb.Empty, error) {
+	err := g.calendars.DeleteCalendar(ctx, &core.DeleteCalendarRequest{
+		ActorID:    extractAuthorization(ctx),
+		CalendarID: req.GetId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) FindCalendarByID(ctx context.Context, req *v1.FindCalendarByIDRequest) (*v1.FindCalendarByIDResponse, error) {
+	calendar, err := g.calendars.FindCalendarByID(ctx, &core.FindCalendarByIDRequest{
+		ActorID:    extractAuthorization(ctx),
+		CalendarID: req.GetId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.FindCalendarByIDResponse{
+		Calendar: parseCalendarToPB(calendar),
+	}, nil
+}
+
+func (g *GRPCEndpoint) ListCalendars(ctx context.Context, _ *v1.ListCalendarsRequest) (*v1.ListCalendarsResponse, error) {
+	calendars, err := g.calendars.ListCalendars(ctx, &core.ListCalendarsRequest{
+		ActorID: extractAuthorization(ctx),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	res := make([]*v1.Calendar, len(calendars))
+	for index := range calendars {
+		res[index] = parseCalendarToPB(&calendars[index])
+	}
+
+	return &v1.ListCalendarsResponse{
+		Calendars: res,
+	}, nil
+}
+
+func (g *GRPCEndpoint) MoveEvent(ctx context.Context, req *v1.MoveEventRequest) (*emptypb.Empty, error) {
+	err := g.calendars.MoveEvent(ctx, &core.MoveEventRequest{
+		ActorID:    extractAuthorization(ctx),
+		EventID:    req.GetId(),
+		CalendarID: req.GetCalendarId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) ShareCalendar(ctx context.Context, req *v1.ShareCalendarRequest) (*emptypb.Empty, error) {
+	if req.GetGrant() == nil {
+		return nil, invalidArgument("grant", internal.ErrInvalidRequest)
+	}
+
+	err := g.calendars.ShareCalendar(ctx, &core.ShareCalendarRequest{
+		ActorID: extractAuthorization(ctx),
+		Grant: &core.CalendarGrant{
+			CalendarID:  req.GetId(),
+			GranteeType: mapGranteeType(req.GetGrant().GetGranteeType()),
+			GranteeID:   req.GetGrant().GetGranteeId(),
+			Role:        mapCalendarRole(req.GetGrant().GetRole()),
+		},
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) UnshareCalendar(ctx context.Context, req *v1.UnshareCalendarRequest) (*emptypb.Empty, error) {
+	err := g.calendars.UnshareCalendar(ctx, &core.UnshareCalendarRequest{
+		ActorID:     extractAuthorization(ctx),
+		CalendarID:  req.GetId(),
+		GranteeType: mapGranteeType(req.GetGranteeType()),
+		GranteeID:   req.GetGranteeId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) ListCalendarGrants(ctx context.Context, req *v1.ListCalendarGrantsRequest) (*v1.ListCalendarGrantsResponse, error) {
+	grants, err := g.calendars.ListCalendarGrants(ctx, &core.ListCalendarGrantsRequest{
+		ActorID:    extractAuthorization(ctx),
+		CalendarID: req.GetId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	res := make([]*v1.CalendarGrant, len(grants))
+	for index := range grants {
+		res[index] = parseCalendarGrantToPB(&grants[index])
+	}
+
+	return &v1.ListCalendarGrantsResponse{
+		Grants: res,
+	}, nil
+}
+
+func parseCalendarToPB(c *core.Calendar) *v1.Calendar {
+	res := &v1.Calendar{
+		Id:          c.ID,
+		OwnerId:     c.OwnerID,
+		Name:        c.Name,
+		Color:       c.Color,
+		Timezone:    c.Timezone,
+		Description: c.Description,
+		IsDefault:   c.IsDefault,
+		CreatedAt:   c.CreatedAt.Format(time.RFC3339),
+		AccessRole:  mapCalendarRoleToPB(c.AccessRole),
+	}
+	if c.UpdatedAt != nil {
+		res.LastUpdatedAt = c.UpdatedAt.Format(time.RFC3339)
+	}
+	return res
+}
+
+func parseCalendarGrantToPB(g *core.CalendarGrant) *v1.CalendarGrant {
+	res := &v1.CalendarGrant{
+		GranteeType: mapGranteeTypeToPB(g.GranteeType),
+		GranteeId:   g.GranteeID,
+		Role:        mapCalendarRoleToPB(g.Role),
+		GrantedBy:   g.GrantedBy,
+		CreatedAt:   g.CreatedAt.Format(time.RFC3339),
+	}
+	if g.UpdatedAt != nil {
+		res.LastUpdatedAt = g.UpdatedAt.Format(time.RFC3339)
+	}
+	return res
+}
+
+func mapCalendarRole(r v1.CalendarRole) core.CalendarRole {
+	switch r {
+	case v1.CalendarRole_FREE_BUSY:
+		return core.CalendarRole_FreeBusy
+	case v1.CalendarRole_READ:
+		return core.CalendarRole_Read
+	case v1.CalendarRole_WRITE:
+		return core.CalendarRole_Write
+	case v1.CalendarRole_MANAGE:
+		return core.CalendarRole_Manage
+	default:
+		return ""
+	}
+}
+
+func mapCalendarRoleToPB(r core.CalendarRole) v1.CalendarRole {
+	switch r {
+	case core.CalendarRole_FreeBusy:
+		return v1.CalendarRole_FREE_BUSY
+	case core.CalendarRole_Read:
+		return v1.CalendarRole_READ
+	case core.CalendarRole_Write:
+		return v1.CalendarRole_WRITE
+	case core.CalendarRole_Manage:
+		return v1.CalendarRole_MANAGE
+	default:
+		return v1.CalendarRole_UNKNOWN_ROLE
+	}
+}
+
+func mapGranteeType(t v1.GranteeType) core.GranteeType {
+	switch t {
+	case v1.GranteeType_USER:
+		return core.GranteeType_User
+	case v1.GranteeType_GROUP:
+		return core.GranteeType_Group
+	default:
+		return ""
+	}
+}
+
+func mapGranteeTypeToPB(t core.GranteeType) v1.GranteeType {
+	switch t {
+	case core.GranteeType_User:
+		return v1.GranteeType_USER
+	case core.GranteeType_Group:
+		return v1.GranteeType_GROUP
+	default:
+		return v1.GranteeType_UNKNOWN_GRANTEE
+	}
+}
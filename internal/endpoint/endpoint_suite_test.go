@@ -5,9 +5,10 @@ import (
 	"log"
 	"testing"
 
+	"github.com/dzakaammar/event-scheduling-example/sql/migrations"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
 	. "github.com/onsi/ginkgo/v2"
@@ -91,9 +92,12 @@ func runDockerPostgres(pool *dockertest.Pool) string {
 }
 
 func migrateDB(dbUrl string) error {
-	m, err := migrate.New(
-		"file://../../sql/migrations",
-		dbUrl)
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return err
+	}
+
+	m, err := migrate.NewWithSourceInstance("iofs", src, dbUrl)
 	if err != nil {
 		return err
 	}
@@ -2,22 +2,197 @@ package endpoint
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"unicode"
 
 	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// protoFieldNames maps the fields of the core structs to their name in the proto messages.
+// An empty name means the field has no counterpart of its own and is reported on its parent,
+// i.e: an invitation's user id is the attendee itself.
+var protoFieldNames = map[string]string{
+	"Event":             "event",
+	"ID":                "id",
+	"Title":             "title",
+	"Description":       "description",
+	"Timezone":          "timezone",
+	"CreatedBy":         "created_by",
+	"CreatedAt":         "created_at",
+	"UpdatedAt":         "last_updated_at",
+	"Schedules":         "schedule",
+	"Invitations":       "attendees",
+	"StartTime":         "start_time",
+	"DurationInMinutes": "end_time",
+	"IsFullDay":         "is_full_day",
+	"RecurringType":     "recurring_type",
+	"RecurringInterval": "recurring_type",
+	"EventID":           "",
+	"UserID":            "",
+	"Token":             "",
+	"IdempotencyKey":    "request_id",
+	"Reminders":         "reminders",
+	"OffsetMinutes":     "offset_minutes",
+	"Channel":           "channel",
+	// the preference is the request's own field when updating it
+	"NotificationPreference": "preference",
+	// the fields of a booking are the request's own fields
+	"BookSlotRequest": "",
+}
+
 func mapErrToStatusCode(err error) error {
 	if err == nil {
 		return nil
 	}
 
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	if errors.Is(err, core.ErrEventNotFound) ||
+		errors.Is(err, core.ErrInvitationNotFound) ||
+		errors.Is(err, core.ErrWebhookNotFound) ||
+		errors.Is(err, core.ErrCalendarNotFound) ||
+		errors.Is(err, core.ErrCalendarGrantNotFound) ||
+		errors.Is(err, core.ErrGroupNotFound) ||
+		errors.Is(err, core.ErrResourceNotFound) ||
+		errors.Is(err, core.ErrBookingPageNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	if errors.Is(err, core.ErrPermissionDenied) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	if errors.Is(err, core.ErrDefaultCalendar) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	if errors.Is(err, core.ErrEventAlreadyExists) {
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+
+	if errors.Is(err, core.ErrChangeCursorExpired) {
+		return status.Error(codes.OutOfRange, err.Error())
+	}
+
+	if errors.Is(err, core.ErrIdempotencyKeyReused) {
+		return invalidArgument("request_id", err)
+	}
+
+	if errors.Is(err, core.ErrBatchAborted) ||
+		errors.Is(err, core.ErrSlotUnavailable) ||
+		errors.Is(err, core.ErrIdempotencyKeyPending) {
+		return status.Error(codes.Aborted, err.Error())
+	}
+
 	if errors.Is(err, internal.ErrInvalidRequest) ||
 		errors.Is(err, internal.ErrInvalidTimezone) ||
 		errors.Is(err, internal.ErrValidationFailed) {
-		return status.Error(codes.InvalidArgument, err.Error())
+		return withFieldViolations(status.New(codes.InvalidArgument, err.Error()), fieldViolations(err)...)
 	}
 
 	return status.Error(codes.Internal, err.Error())
 }
+
+// invalidArgument reports err as an InvalidArgument status with a single violation on field,
+// given as a proto path.
+func invalidArgument(field string, err error) error {
+	return withFieldViolations(status.New(codes.InvalidArgument, err.Error()), &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: err.Error(),
+	})
+}
+
+// withFieldViolations attaches violations to st as a google.rpc.BadRequest detail.
+func withFieldViolations(st *status.Status, violations ...*errdetails.BadRequest_FieldViolation) error {
+	if len(violations) == 0 {
+		return st.Err()
+	}
+
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func fieldViolations(err error) []*errdetails.BadRequest_FieldViolation {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(validationErrs))
+		for index, fieldErr := range validationErrs {
+			violations[index] = &errdetails.BadRequest_FieldViolation{
+				Field:       protoFieldPath(fieldErr.Namespace()),
+				Description: violationDescription(fieldErr),
+			}
+		}
+		return violations
+	}
+
+	var wrappedErr *internal.Error
+	if errors.As(err, &wrappedErr) && wrappedErr.Field() != "" {
+		return []*errdetails.BadRequest_FieldViolation{
+			{
+				Field:       protoFieldPath(wrappedErr.Field()),
+				Description: wrappedErr.Error(),
+			},
+		}
+	}
+
+	return nil
+}
+
+func violationDescription(fieldErr validator.FieldError) string {
+	if fieldErr.Tag() == "required" {
+		return "is required"
+	}
+	return fmt.Sprintf("failed on the '%s' rule", fieldErr.Tag())
+}
+
+// protoFieldPath converts a validator namespace such as "Event.Schedules[0].StartTime"
+// into the matching proto path, "event.schedule[0].start_time".
+func protoFieldPath(namespace string) string {
+	segments := strings.Split(namespace, ".")
+	path := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		name, index := segment, ""
+		if i := strings.IndexByte(segment, '['); i >= 0 {
+			name, index = segment[:i], segment[i:]
+		}
+
+		protoName, ok := protoFieldNames[name]
+		if !ok {
+			protoName = toSnakeCase(name)
+		}
+
+		switch {
+		case protoName != "":
+			path = append(path, protoName+index)
+		case len(path) > 0:
+			path[len(path)-1] += index
+		}
+	}
+	return strings.Join(path, ".")
+}
+
+func toSnakeCase(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,146 @@
+package endpoint
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	v1 "github.com/dzakaammar/event-scheduling-example/gen/go/proto/v1"
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (g *GRPCEndpoint) CreateGroup(ctx context.Context, req *v1.CreateGroupRequest) (*v1.CreateGroupResponse, error) {
+	if req.GetGroup() == nil {
+		return nil, invalidArgument("group", internal.ErrInvalidRequest)
+	}
+
+	actorID := extractAuthorization(ctx)
+
+	group := core.NewGroup(actorID)
+	group.Name = req.GetGroup().GetName()
+	group.Description = req.GetGroup().GetDescription()
+	group.Members = req.GetGroup().GetMembers()
+
+	err := g.groups.CreateGroup(ctx, &core.CreateGroupRequest{
+		ActorID: actorID,
+		Group:   group,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.CreateGroupResponse{
+		Id: group.ID,
+	}, nil
+}
+
+func (g *GRPCEndpoint) UpdateGroup(ctx context.Context, req *v1.UpdateGroupRequest) (*emptypb.Empty, error) {
+	if req.GetGroup() == nil {
+		return nil, invalidArgument("group", internal.ErrInvalidRequest)
+	}
+
+	err := g.groups.UpdateGroup(ctx, &core.UpdateGroupRequest{
+		ActorID: extractAuthorization(ctx),
+		Group: &core.Group{
+			ID:          req.GetId(),
+			Name:        req.GetGroup().GetName(),
+			Description: req.GetGroup().GetDescription(),
+		},
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) DeleteGroup(ctx context.Context, req *v1.DeleteGroupRequest) (*emptypb.Empty, error) {
+	err := g.groups.DeleteGroup(ctx, &core.DeleteGroupRequest{
+		ActorID: extractAuthorization(ctx),
+		GroupID: req.GetId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) FindGroupByID(ctx context.Context, req *v1.FindGroupByIDRequest) (*v1.FindGroupByIDResponse, error) {
+	group, err := g.groups.FindGroupByID(ctx, &core.FindGroupByIDRequest{
+		ActorID: extractAuthorization(ctx),
+		GroupID: req.GetId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.FindGroupByIDResponse{
+		Group: parseGroupToPB(group),
+	}, nil
+}
+
+func (g *GRPCEndpoint) ListGroups(ctx context.Context, _ *v1.ListGroupsRequest) (*v1.ListGroupsResponse, error) {
+	groups, err := g.groups.ListGroups(ctx, &core.ListGroupsRequest{
+		ActorID: extractAuthorization(ctx),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	res := make([]*v1.Group, len(groups))
+	for index := range groups {
+		res[index] = parseGroupToPB(&groups[index])
+	}
+
+	return &v1.ListGroupsResponse{
+		Groups: res,
+	}, nil
+}
+
+func (g *GRPCEndpoint) AddGroupMembers(ctx context.Context, req *v1.ChangeGroupMembersRequest) (*emptypb.Empty, error) {
+	err := g.groups.AddGroupMembers(ctx, parseChangeGroupMembersRequest(ctx, req))
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) RemoveGroupMembers(ctx context.Context, req *v1.ChangeGroupMembersRequest) (*emptypb.Empty, error) {
+	err := g.groups.RemoveGroupMembers(ctx, parseChangeGroupMembersRequest(ctx, req))
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func parseChangeGroupMembersRequest(ctx context.Context, req *v1.ChangeGroupMembersRequest) *core.ChangeGroupMembersRequest {
+	return &core.ChangeGroupMembersRequest{
+		ActorID:   extractAuthorization(ctx),
+		GroupID:   req.GetId(),
+		UserIDs:   req.GetUserIds(),
+		Propagate: req.GetPropagate(),
+	}
+}
+
+func parseGroupToPB(g *core.Group) *v1.Group {
+	res := &v1.Group{
+		Id:          g.ID,
+		OwnerId:     g.OwnerID,
+		Name:        g.Name,
+		Description: g.Description,
+		Members:     g.Members,
+		CreatedAt:   g.CreatedAt.Format(time.RFC3339),
+	}
+	if g.UpdatedAt != nil {
+		res.LastUpdatedAt = g.UpdatedAt.Format(time.RFC3339)
+	}
+	return res
+}
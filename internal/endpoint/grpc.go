@@ -2,25 +2,49 @@ package endpoint
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"sort"
+	"strconv"
 	"time"
 
 	v1 "github.com/dzakaammar/event-scheduling-example/gen/go/proto/v1"
+	"github.com/dzakaammar/event-scheduling-example/internal"
 	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/health"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type GRPCEndpoint struct {
 	v1.UnimplementedAPIServer
-	svc core.SchedulingService
+	svc         core.SchedulingService
+	webhooks    core.WebhookService
+	preferences core.NotificationPreferenceService
+	agendas     core.AgendaService
+	calendars   core.CalendarService
+	groups      core.GroupService
+	resources   core.ResourceService
+	bookings    core.BookingService
+	health      *health.Monitor
 }
 
-func NewGRPCEndpoint(svc core.SchedulingService) *GRPCEndpoint {
+func NewGRPCEndpoint(svc core.SchedulingService, webhookSvc core.WebhookService, preferenceSvc core.NotificationPreferenceService, agendaSvc core.AgendaService, calendarSvc core.CalendarService, groupSvc core.GroupService, resourceSvc core.ResourceService, bookingSvc core.BookingService, healthMonitor *health.Monitor) *GRPCEndpoint {
 	return &GRPCEndpoint{
-		svc: svc,
+		svc:         svc,
+		webhooks:    webhookSvc,
+		preferences: preferenceSvc,
+		agendas:     agendaSvc,
+		calendars:   calendarSvc,
+		groups:      groupSvc,
+		resources:   resourceSvc,
+		bookings:    bookingSvc,
+		health:      healthMonitor,
 	}
 }
 
@@ -28,7 +52,7 @@ func (g *GRPCEndpoint) CreateEvent(ctx context.Context, req *v1.CreateEventReque
 	createReq, err := parseCreateEventRequest(ctx, req)
 	if err != nil {
 		slog.Error(err.Error())
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, err
 	}
 
 	err = g.svc.CreateEvent(ctx, createReq)
@@ -45,7 +69,7 @@ func (g *GRPCEndpoint) DeleteEventByID(ctx context.Context, req *v1.DeleteEventB
 	delReq, err := parseDeleteEventByIDtRequest(ctx, req)
 	if err != nil {
 		slog.Error(err.Error())
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, err
 	}
 
 	err = g.svc.DeleteEventByID(ctx, delReq)
@@ -60,7 +84,7 @@ func (g *GRPCEndpoint) UpdateEvent(ctx context.Context, req *v1.UpdateEventReque
 	updateReq, err := parseUpdateEventByIDRequest(ctx, req)
 	if err != nil {
 		slog.Error(err.Error())
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, err
 	}
 
 	err = g.svc.UpdateEvent(ctx, updateReq)
@@ -72,7 +96,10 @@ func (g *GRPCEndpoint) UpdateEvent(ctx context.Context, req *v1.UpdateEventReque
 }
 
 func (g *GRPCEndpoint) FindEventByID(ctx context.Context, req *v1.FindEventByIDRequest) (*v1.FindEventByIDResponse, error) {
-	event, err := g.svc.FindEventByID(ctx, &core.FindEventByIDRequest{EventID: req.GetId()})
+	event, err := g.svc.FindEventByID(ctx, &core.FindEventByIDRequest{
+		ActorID: extractAuthorization(ctx),
+		EventID: req.GetId(),
+	})
 	if err != nil {
 		slog.Error(err.Error())
 		return nil, mapErrToStatusCode(err)
@@ -88,12 +115,304 @@ func (g *GRPCEndpoint) FindEventByID(ctx context.Context, req *v1.FindEventByIDR
 	}, nil
 }
 
-func (g *GRPCEndpoint) Check(ctx context.Context, _ *v1.HealthCheckRequest) (*v1.HealthCheckResponse, error) {
-	return &v1.HealthCheckResponse{Status: v1.HealthCheckResponse_SERVING}, nil
+func (g *GRPCEndpoint) ListEvents(ctx context.Context, req *v1.ListEventsRequest) (*v1.ListEventsResponse, error) {
+	listReq, err := parseListEventsRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := g.svc.ListEvents(ctx, listReq)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	events := make([]*v1.Event, len(page.Events))
+	for index := range page.Events {
+		e, err := parseEventToPB(&page.Events[index])
+		if err != nil {
+			return nil, mapErrToStatusCode(err)
+		}
+		events[index] = e
+	}
+
+	return &v1.ListEventsResponse{
+		Events:        events,
+		NextPageToken: encodeEventPageToken(page.Next),
+	}, nil
+}
+
+func (g *GRPCEndpoint) SearchEvents(ctx context.Context, req *v1.SearchEventsRequest) (*v1.SearchEventsResponse, error) {
+	offset, err := decodeOffsetPageToken(req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := g.svc.SearchEvents(ctx, &core.SearchEventsRequest{
+		ActorID:  extractAuthorization(ctx),
+		Query:    req.GetQuery(),
+		PageSize: int(req.GetPageSize()),
+		Offset:   offset,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	events := make([]*v1.Event, len(page.Events))
+	for index := range page.Events {
+		e, err := parseEventToPB(&page.Events[index])
+		if err != nil {
+			return nil, mapErrToStatusCode(err)
+		}
+		events[index] = e
+	}
+
+	res := &v1.SearchEventsResponse{
+		Events: events,
+	}
+	if page.NextOffset > 0 {
+		res.NextPageToken = encodePageToken(strconv.Itoa(page.NextOffset))
+	}
+	return res, nil
+}
+
+func (g *GRPCEndpoint) BatchMutateEvents(ctx context.Context, req *v1.BatchMutateEventsRequest) (*v1.BatchMutateEventsResponse, error) {
+	if len(req.GetMutations()) == 0 || len(req.GetMutations()) > core.MaxBatchSize {
+		return nil, invalidArgument("mutations", internal.ErrInvalidRequest)
+	}
+
+	// mutations that can't be parsed fail on their own, the others are sent to the service
+	results := make([]core.BatchMutationResult, len(req.GetMutations()))
+	mutations := make([]core.BatchMutation, 0, len(req.GetMutations()))
+	positions := make([]int, 0, len(req.GetMutations()))
+	for index, m := range req.GetMutations() {
+		mutation, err := parseEventMutation(ctx, m)
+		if err != nil {
+			results[index].Err = err
+			continue
+		}
+		mutations = append(mutations, *mutation)
+		positions = append(positions, index)
+	}
+
+	switch {
+	case len(mutations) < len(results) && req.GetAtomic():
+		for index := range results {
+			if results[index].Err == nil {
+				results[index].Err = core.ErrBatchAborted
+			}
+		}
+	case len(mutations) > 0:
+		res, err := g.svc.BatchMutateEvents(ctx, &core.BatchMutateEventsRequest{
+			ActorID:   extractAuthorization(ctx),
+			Atomic:    req.GetAtomic(),
+			Mutations: mutations,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return nil, mapErrToStatusCode(err)
+		}
+		for index, result := range res {
+			results[positions[index]] = result
+		}
+	}
+
+	pbResults := make([]*v1.EventMutationResult, len(results))
+	for index, result := range results {
+		pbResults[index] = &v1.EventMutationResult{
+			Id:     result.EventID,
+			Status: status.Convert(mapErrToStatusCode(result.Err)).Proto(),
+		}
+	}
+
+	return &v1.BatchMutateEventsResponse{
+		Results: pbResults,
+	}, nil
+}
+
+func (g *GRPCEndpoint) RestoreEvent(ctx context.Context, req *v1.RestoreEventRequest) (*emptypb.Empty, error) {
+	err := g.svc.RestoreEvent(ctx, &core.RestoreEventRequest{
+		ActorID: extractAuthorization(ctx),
+		EventID: req.GetId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) ListDeletedEvents(ctx context.Context, _ *v1.ListDeletedEventsRequest) (*v1.ListDeletedEventsResponse, error) {
+	events, err := g.svc.ListDeletedEvents(ctx, &core.ListDeletedEventsRequest{
+		ActorID: extractAuthorization(ctx),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	res := make([]*v1.Event, len(events))
+	for index := range events {
+		e, err := parseEventToPB(&events[index])
+		if err != nil {
+			return nil, mapErrToStatusCode(err)
+		}
+		res[index] = e
+	}
+
+	return &v1.ListDeletedEventsResponse{
+		Events: res,
+	}, nil
+}
+
+func (g *GRPCEndpoint) RespondInvitation(ctx context.Context, req *v1.RespondInvitationRequest) (*emptypb.Empty, error) {
+	err := g.svc.RespondInvitation(ctx, &core.RespondInvitationRequest{
+		ActorID: extractAuthorization(ctx),
+		EventID: req.GetId(),
+		Status:  mapInvitationStatus(req.GetStatus()),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) ListWaitlist(ctx context.Context, req *v1.ListWaitlistRequest) (*v1.ListWaitlistResponse, error) {
+	waitlist, err := g.svc.ListWaitlist(ctx, &core.ListWaitlistRequest{
+		ActorID: extractAuthorization(ctx),
+		EventID: req.GetId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	entries := make([]*v1.WaitlistEntry, len(waitlist))
+	for index, invitation := range waitlist {
+		entries[index] = &v1.WaitlistEntry{
+			UserId:   invitation.UserID,
+			Position: int32(index + 1),
+		}
+		if invitation.WaitlistedAt != nil {
+			entries[index].WaitlistedAt = invitation.WaitlistedAt.Format(time.RFC3339)
+		}
+	}
+
+	return &v1.ListWaitlistResponse{
+		Entries: entries,
+	}, nil
+}
+
+func (g *GRPCEndpoint) SetReminders(ctx context.Context, req *v1.SetRemindersRequest) (*emptypb.Empty, error) {
+	err := g.svc.SetReminders(ctx, &core.SetRemindersRequest{
+		ActorID:   extractAuthorization(ctx),
+		EventID:   req.GetId(),
+		Reminders: parseReminders(req.GetReminders(), req.GetId()),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) ListEventHistory(ctx context.Context, req *v1.ListEventHistoryRequest) (*v1.ListEventHistoryResponse, error) {
+	beforeID, err := decodeIDPageToken(req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := g.svc.ListEventHistory(ctx, &core.ListEventHistoryRequest{
+		ActorID:  extractAuthorization(ctx),
+		EventID:  req.GetId(),
+		PageSize: int(req.GetPageSize()),
+		BeforeID: beforeID,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	entries := make([]*v1.EventHistoryEntry, len(page.Entries))
+	for index := range page.Entries {
+		e, err := parseEventHistoryToPB(&page.Entries[index])
+		if err != nil {
+			return nil, mapErrToStatusCode(err)
+		}
+		entries[index] = e
+	}
+
+	res := &v1.ListEventHistoryResponse{
+		Entries: entries,
+	}
+	if page.NextBeforeID > 0 {
+		res.NextPageToken = encodePageToken(strconv.FormatInt(page.NextBeforeID, 10))
+	}
+	return res, nil
+}
+
+// WatchEvents streams the changes until the client hangs up. When the stream is cut short, i.e: the client
+// fell behind or the server is shutting down, the client should watch again from the last cursor it got.
+func (g *GRPCEndpoint) WatchEvents(req *v1.WatchEventsRequest, stream v1.API_WatchEventsServer) error {
+	ctx := stream.Context()
+
+	after, err := decodeChangeCursor(req.GetCursor())
+	if err != nil {
+		return err
+	}
+
+	changes, err := g.svc.WatchEvents(ctx, &core.WatchEventsRequest{
+		ActorID: extractAuthorization(ctx),
+		After:   after,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return mapErrToStatusCode(err)
+	}
+
+	// let the client know that the changes made from now on are delivered
+	err = stream.SendHeader(metadata.MD{})
+	if err != nil {
+		return err
+	}
+
+	for change := range changes {
+		c, err := parseEventChangeToPB(&change)
+		if err != nil {
+			return mapErrToStatusCode(err)
+		}
+
+		err = stream.Send(c)
+		if err != nil {
+			return err
+		}
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	return status.Error(codes.Unavailable, "the watch was interrupted, watch again from the last cursor")
 }
 
-func (g *GRPCEndpoint) Watch(_ *v1.HealthCheckRequest, _ v1.API_WatchServer) error {
-	return status.Error(codes.Unimplemented, "unimplemented")
+// extractIdempotencyKey returns the request_id, or the Idempotency-Key metadata when it is empty.
+func extractIdempotencyKey(ctx context.Context, requestID string) (string, error) {
+	var key string
+	if m, ok := metadata.FromIncomingContext(ctx); ok {
+		if k := m.Get("Idempotency-Key"); len(k) > 0 {
+			key = k[0]
+		}
+	}
+
+	switch {
+	case key == "":
+		return requestID, nil
+	case requestID == "" || requestID == key:
+		return key, nil
+	default:
+		return "", invalidArgument("request_id", internal.WrapErr(internal.ErrInvalidRequest, "request_id does not match the Idempotency-Key header"))
+	}
 }
 
 func extractAuthorization(ctx context.Context) string {
@@ -110,15 +429,23 @@ func extractAuthorization(ctx context.Context) string {
 
 func parseCreateEventRequest(ctx context.Context, req *v1.CreateEventRequest) (*core.CreateEventRequest, error) {
 	if req == nil || req.GetEvent() == nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid request")
+		return nil, invalidArgument("event", internal.ErrInvalidRequest)
 	}
 
 	actorID := extractAuthorization(ctx)
 
 	event := core.NewEvent(actorID)
+	if req.GetEventId() != "" {
+		if err := core.ValidateEventID(req.GetEventId()); err != nil {
+			return nil, invalidArgument("event_id", err)
+		}
+		event.ID = req.GetEventId()
+	}
 	event.Title = req.GetEvent().GetTitle()
 	event.Description = req.GetEvent().GetDescription()
 	event.Timezone = req.GetEvent().GetTimezone()
+	event.CalendarID = req.GetEvent().GetCalendarId()
+	event.Capacity = req.GetEvent().GetCapacity()
 	event.CreatedAt = time.Now()
 
 	sch, err := parseSchedules(req.GetEvent().GetSchedule(), event.ID)
@@ -128,10 +455,20 @@ func parseCreateEventRequest(ctx context.Context, req *v1.CreateEventRequest) (*
 	event.Schedules = sch
 
 	event.Invitations = parseInvitations(req.GetEvent().GetAttendees(), event.ID)
+	event.Groups = req.GetEvent().GetAttendeeGroups()
+	event.Resources = parseResourceBookings(req.GetEvent().GetResources(), event.ID)
+	event.Reminders = parseReminders(req.GetEvent().GetReminders(), event.ID)
+
+	idempotencyKey, err := extractIdempotencyKey(ctx, req.GetRequestId())
+	if err != nil {
+		return nil, err
+	}
 
 	return &core.CreateEventRequest{
-		ActorID: actorID,
-		Event:   event,
+		ActorID:        actorID,
+		Event:          event,
+		EventIDChosen:  req.GetEventId() != "",
+		IdempotencyKey: idempotencyKey,
 	}, nil
 }
 
@@ -148,7 +485,7 @@ func parseDeleteEventByIDtRequest(ctx context.Context, req *v1.DeleteEventByIDRe
 
 func parseUpdateEventByIDRequest(ctx context.Context, req *v1.UpdateEventRequest) (*core.UpdateEventRequest, error) {
 	if req == nil || req.GetEvent() == nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid request")
+		return nil, invalidArgument("event", internal.ErrInvalidRequest)
 	}
 
 	now := time.Now()
@@ -157,6 +494,7 @@ func parseUpdateEventByIDRequest(ctx context.Context, req *v1.UpdateEventRequest
 		Title:       req.GetEvent().GetTitle(),
 		Description: req.GetEvent().GetDescription(),
 		Timezone:    req.GetEvent().GetTimezone(),
+		Capacity:    req.GetEvent().GetCapacity(),
 		UpdatedAt:   &now,
 	}
 
@@ -166,6 +504,9 @@ func parseUpdateEventByIDRequest(ctx context.Context, req *v1.UpdateEventRequest
 	}
 	event.Schedules = sch
 	event.Invitations = parseInvitations(req.GetEvent().GetAttendees(), event.ID)
+	event.Groups = req.GetEvent().GetAttendeeGroups()
+	event.Resources = parseResourceBookings(req.GetEvent().GetResources(), event.ID)
+	event.Reminders = parseReminders(req.GetEvent().GetReminders(), event.ID)
 
 	return &core.UpdateEventRequest{
 		ID:      req.GetId(),
@@ -174,6 +515,74 @@ func parseUpdateEventByIDRequest(ctx context.Context, req *v1.UpdateEventRequest
 	}, nil
 }
 
+func parseEventMutation(ctx context.Context, m *v1.EventMutation) (*core.BatchMutation, error) {
+	switch op := m.GetOperation().(type) {
+	case *v1.EventMutation_Create:
+		createReq, err := parseCreateEventRequest(ctx, &v1.CreateEventRequest{Event: op.Create})
+		if err != nil {
+			return nil, err
+		}
+		return &core.BatchMutation{Create: createReq}, nil
+	case *v1.EventMutation_Update:
+		updateReq, err := parseUpdateEventByIDRequest(ctx, op.Update)
+		if err != nil {
+			return nil, err
+		}
+		return &core.BatchMutation{Update: updateReq}, nil
+	case *v1.EventMutation_Delete:
+		delReq, err := parseDeleteEventByIDtRequest(ctx, op.Delete)
+		if err != nil {
+			return nil, err
+		}
+		return &core.BatchMutation{Delete: delReq}, nil
+	default:
+		return nil, invalidArgument("operation", internal.ErrInvalidRequest)
+	}
+}
+
+func parseListEventsRequest(ctx context.Context, req *v1.ListEventsRequest) (*core.ListEventsRequest, error) {
+	after, err := decodeEventPageToken(req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	createdAfter, err := parseOptionalTime(req.GetCreatedAfter(), "created_after")
+	if err != nil {
+		return nil, err
+	}
+
+	createdBefore, err := parseOptionalTime(req.GetCreatedBefore(), "created_before")
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.ListEventsRequest{
+		ActorID:       extractAuthorization(ctx),
+		CreatedBy:     req.GetCreatedBy(),
+		Attendee:      req.GetAttendee(),
+		Title:         req.GetTitle(),
+		CreatedAfter:  createdAfter,
+		CreatedBefore: createdBefore,
+		Timezone:      req.GetTimezone(),
+		CalendarID:    req.GetCalendarId(),
+		PageSize:      int(req.GetPageSize()),
+		After:         after,
+	}, nil
+}
+
+// parseOptionalTime parses an RFC3339 time of the given request field, an empty value is nil.
+func parseOptionalTime(value string, field string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, invalidArgument(field, internal.ErrInvalidRequest)
+	}
+	return &t, nil
+}
+
 func parseSchedules(sch []*v1.Schedule, eventID string) ([]core.Schedule, error) {
 	schedules := make([]core.Schedule, len(sch))
 	for index, sch := range sch {
@@ -185,7 +594,11 @@ func parseSchedules(sch []*v1.Schedule, eventID string) ([]core.Schedule, error)
 			mapRecurringType(sch.GetRecurringType()),
 		)
 		if err != nil {
-			return nil, status.Error(codes.InvalidArgument, err.Error())
+			field := fmt.Sprintf("event.schedule[%d].start_time", index)
+			if errors.Is(err, core.ErrInvalidEndTime) {
+				field = fmt.Sprintf("event.schedule[%d].end_time", index)
+			}
+			return nil, invalidArgument(field, err)
 		}
 		schedules[index] = s
 	}
@@ -201,6 +614,16 @@ func parseInvitations(attendees []int32, eventID string) []core.Invitation {
 	return invitations
 }
 
+// parseReminders returns the reminders of the event, the service assigns them to the attendee
+// who sets them.
+func parseReminders(reminders []*v1.Reminder, eventID string) []core.Reminder {
+	res := make([]core.Reminder, len(reminders))
+	for index, r := range reminders {
+		res[index] = core.NewReminder(eventID, 0, r.GetOffsetMinutes(), mapReminderChannel(r.GetChannel()))
+	}
+	return res
+}
+
 func parseEventToPB(event *core.Event) (*v1.Event, error) {
 	e := &v1.Event{
 		Id:            event.ID,
@@ -210,6 +633,11 @@ func parseEventToPB(event *core.Event) (*v1.Event, error) {
 		CreatedAt:     event.CreatedAt.Format(time.RFC3339),
 		CreatedBy:     event.CreatedBy,
 		LastUpdatedAt: event.GetUpdatedAt(),
+		CalendarId:    event.CalendarID,
+		Capacity:      event.Capacity,
+	}
+	if event.DeletedAt != nil {
+		e.DeletedAt = event.DeletedAt.Format(time.RFC3339)
 	}
 
 	schedules := make([]*v1.Schedule, len(event.Schedules))
@@ -235,10 +663,156 @@ func parseEventToPB(event *core.Event) (*v1.Event, error) {
 		attendees[index] = inv.UserID
 	}
 	e.Attendees = attendees
+	e.AttendeeGroups = event.InvitedGroups()
+
+	resources := make([]*v1.ResourceBooking, len(event.Resources))
+	for index, r := range event.Resources {
+		resources[index] = &v1.ResourceBooking{
+			ResourceId: r.ResourceID,
+			Status:     mapInvitationStatusToPB(r.Status),
+		}
+	}
+	e.Resources = resources
+
+	guests := make([]*v1.Guest, len(event.Guests))
+	for index, guest := range event.Guests {
+		guests[index] = &v1.Guest{
+			Name:  guest.Name,
+			Email: guest.Email,
+		}
+	}
+	e.Guests = guests
+
+	reminders := make([]*v1.Reminder, len(event.Reminders))
+	for index, r := range event.Reminders {
+		reminders[index] = &v1.Reminder{
+			OffsetMinutes: r.OffsetMinutes,
+			Channel:       mapReminderChannelToPB(r.Channel),
+		}
+	}
+	e.Reminders = reminders
 
 	return e, nil
 }
 
+func parseEventChangeToPB(c *core.EventChange) (*v1.EventChange, error) {
+	res := &v1.EventChange{
+		Cursor:    encodeChangeCursor(c.Cursor),
+		EventId:   c.EventID,
+		Operation: mapHistoryOperationToPB(c.Operation),
+		ActorId:   c.ActorID,
+		CreatedAt: c.CreatedAt.Format(time.RFC3339),
+	}
+
+	if c.Event != nil {
+		event, err := parseEventToPB(c.Event)
+		if err != nil {
+			return nil, err
+		}
+		res.Event = event
+	}
+	return res, nil
+}
+
+func parseEventHistoryToPB(h *core.EventHistory) (*v1.EventHistoryEntry, error) {
+	fields := make([]string, 0, len(h.Changes))
+	for field := range h.Changes {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	changes := make([]*v1.FieldChange, len(fields))
+	for index, field := range fields {
+		before, err := toStructValue(h.Changes[field].Before)
+		if err != nil {
+			return nil, err
+		}
+		after, err := toStructValue(h.Changes[field].After)
+		if err != nil {
+			return nil, err
+		}
+
+		changes[index] = &v1.FieldChange{
+			Field:  field,
+			Before: before,
+			After:  after,
+		}
+	}
+
+	return &v1.EventHistoryEntry{
+		Id:         h.ID,
+		EventId:    h.EventID,
+		ActorId:    h.ActorID,
+		Operation:  mapHistoryOperationToPB(h.Operation),
+		Changes:    changes,
+		CreatedAt:  h.CreatedAt.Format(time.RFC3339),
+		OnBehalfOf: h.OnBehalfOf,
+	}, nil
+}
+
+// toStructValue converts a value of a field change into a protobuf value through its JSON form,
+// so that nested snapshots (i.e: schedules) keep the same shape as the stored history.
+func toStructValue(v any) (*structpb.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	value := &structpb.Value{}
+	err = value.UnmarshalJSON(b)
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func mapInvitationStatus(s v1.InvitationStatus) core.InvitationStatus {
+	switch s {
+	case v1.InvitationStatus_CONFIRMED:
+		return core.InvitationStatus_Confirmed
+	case v1.InvitationStatus_DECLINED:
+		return core.InvitationStatus_Declined
+	case v1.InvitationStatus_WAITLISTED:
+		return core.InvitationStatus_Waitlisted
+	default:
+		return core.InvitationStatus_Unknown
+	}
+}
+
+func mapInvitationStatusToPB(s core.InvitationStatus) v1.InvitationStatus {
+	switch s {
+	case core.InvitationStatus_Confirmed:
+		return v1.InvitationStatus_CONFIRMED
+	case core.InvitationStatus_Declined:
+		return v1.InvitationStatus_DECLINED
+	case core.InvitationStatus_Waitlisted:
+		return v1.InvitationStatus_WAITLISTED
+	default:
+		return v1.InvitationStatus_PENDING
+	}
+}
+
+func mapHistoryOperationToPB(op core.HistoryOperation) v1.HistoryOperation {
+	switch op {
+	case core.HistoryOperation_Create:
+		return v1.HistoryOperation_CREATE
+	case core.HistoryOperation_Update:
+		return v1.HistoryOperation_UPDATE
+	case core.HistoryOperation_Delete:
+		return v1.HistoryOperation_DELETE
+	case core.HistoryOperation_Restore:
+		return v1.HistoryOperation_RESTORE
+	case core.HistoryOperation_RSVP:
+		return v1.HistoryOperation_RSVP
+	default:
+		return v1.HistoryOperation_UNKNOWN_OPERATION
+	}
+}
+
 func mapRecurringType(rt v1.RecurringType) core.RecurringType {
 	switch rt {
 	case v1.RecurringType_DAILY:
@@ -260,3 +834,25 @@ func mapRecurringTypeToPB(rt core.RecurringType) v1.RecurringType {
 		return v1.RecurringType_NONE
 	}
 }
+
+func mapReminderChannel(c v1.ReminderChannel) core.ReminderChannel {
+	switch c {
+	case v1.ReminderChannel_EMAIL:
+		return core.ReminderChannel_Email
+	case v1.ReminderChannel_PUSH:
+		return core.ReminderChannel_Push
+	default:
+		return ""
+	}
+}
+
+func mapReminderChannelToPB(c core.ReminderChannel) v1.ReminderChannel {
+	switch c {
+	case core.ReminderChannel_Email:
+		return v1.ReminderChannel_EMAIL
+	case core.ReminderChannel_Push:
+		return v1.ReminderChannel_PUSH
+	default:
+		return v1.ReminderChannel_UNKNOWN_CHANNEL
+	}
+}
@@ -2,15 +2,22 @@ package endpoint_test
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	v1 "github.com/dzakaammar/event-scheduling-example/gen/go/proto/v1"
+	"github.com/dzakaammar/event-scheduling-example/internal/changefeed"
 	"github.com/dzakaammar/event-scheduling-example/internal/core"
 	grpcEndpoint "github.com/dzakaammar/event-scheduling-example/internal/endpoint"
+	"github.com/dzakaammar/event-scheduling-example/internal/health"
 	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
 	"github.com/dzakaammar/event-scheduling-example/internal/scheduling"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 var _ = Describe("Creating an Event", func() {
@@ -21,8 +28,8 @@ var _ = Describe("Creating an Event", func() {
 	)
 	BeforeEach(func() {
 		eventRepo = postgresql.NewEventRepository(db)
-		schedulingSvc = scheduling.NewService(eventRepo)
-		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc)
+		schedulingSvc = scheduling.NewService(eventRepo, postgresql.NewEventHistoryRepository(db), postgresql.NewCalendarRepository(db), postgresql.NewGroupRepository(db), changefeed.NewBroker(0))
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, nil, nil, nil, nil, nil, nil, nil, health.NewMonitor(nil, 0))
 	})
 
 	// AfterAll(func() {})
@@ -79,6 +86,12 @@ var _ = Describe("Creating an Event", func() {
 					res, err := endpoint.CreateEvent(ctx, basedReq)
 					Expect(err).ShouldNot(BeNil())
 					Expect(res).Should(BeNil())
+
+					st, _ := status.FromError(err)
+					Expect(st.Code()).To(Equal(codes.InvalidArgument))
+					Expect(st.Details()).To(ContainElement(BeAssignableToTypeOf(&errdetails.BadRequest{})))
+					badRequest := st.Details()[0].(*errdetails.BadRequest)
+					Expect(badRequest.GetFieldViolations()[0].GetField()).To(Equal("event.schedule[0].start_time"))
 				})
 			})
 
@@ -156,15 +169,15 @@ var _ = Describe("Deleting an Event", func() {
 	)
 	BeforeEach(func() {
 		eventRepo = postgresql.NewEventRepository(db)
-		schedulingSvc = scheduling.NewService(eventRepo)
-		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc)
+		schedulingSvc = scheduling.NewService(eventRepo, postgresql.NewEventHistoryRepository(db), postgresql.NewCalendarRepository(db), postgresql.NewGroupRepository(db), changefeed.NewBroker(0))
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, nil, nil, nil, nil, nil, nil, nil, health.NewMonitor(nil, 0))
 	})
 
 	Context("Run", func() {
 		var event *core.Event
 		BeforeEach(func() {
 			event = core.NewEvent("test_actor")
-			err := eventRepo.Store(context.Background(), event)
+			err := eventRepo.Store(context.Background(), event, core.Journal{})
 			Expect(err).Should(BeNil())
 		})
 
@@ -204,11 +217,12 @@ var _ = Describe("Deleting an Event", func() {
 			})
 
 			When("the event is not exists", func() {
-				It("returns an error", func() {
+				It("returns a not found error", func() {
 					empty, err := endpoint.DeleteEventByID(ctx, &v1.DeleteEventByIDRequest{
 						Id: "invalid id",
 					})
 					Expect(err).ShouldNot(BeNil())
+					Expect(status.Code(err)).To(Equal(codes.NotFound))
 					Expect(empty).Should(BeNil())
 				})
 			})
@@ -216,6 +230,91 @@ var _ = Describe("Deleting an Event", func() {
 	})
 })
 
+var _ = Describe("Restoring an Event", func() {
+	var (
+		eventRepo     *postgresql.EventRepository
+		schedulingSvc *scheduling.Service
+		endpoint      *grpcEndpoint.GRPCEndpoint
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		schedulingSvc = scheduling.NewService(eventRepo, postgresql.NewEventHistoryRepository(db), postgresql.NewCalendarRepository(db), postgresql.NewGroupRepository(db), changefeed.NewBroker(0))
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, nil, nil, nil, nil, nil, nil, nil, health.NewMonitor(nil, 0))
+	})
+
+	Context("Run", func() {
+		var (
+			event *core.Event
+			ctx   context.Context
+		)
+		BeforeEach(func() {
+			event = core.NewEvent("test_actor")
+			err := eventRepo.Store(context.Background(), event, core.Journal{})
+			Expect(err).Should(BeNil())
+
+			ctx = metadata.NewIncomingContext(context.Background(), metadata.MD{
+				"Authorization": []string{"test_actor"},
+			})
+		})
+
+		When("the event is in the trash", func() {
+			It("lists and restores the event", func() {
+				_, err := endpoint.DeleteEventByID(ctx, &v1.DeleteEventByIDRequest{Id: event.ID})
+				Expect(err).Should(BeNil())
+
+				deleted, err := endpoint.ListDeletedEvents(ctx, &v1.ListDeletedEventsRequest{})
+				Expect(err).Should(BeNil())
+				Expect(deleted.GetEvents()).To(ContainElement(HaveField("Id", event.ID)))
+
+				empty, err := endpoint.RestoreEvent(ctx, &v1.RestoreEventRequest{Id: event.ID})
+				Expect(err).Should(BeNil())
+				Expect(empty).ShouldNot(BeNil())
+
+				e, err := eventRepo.FindByID(context.Background(), event.ID)
+				Expect(err).Should(BeNil())
+				Expect(e.DeletedAt).Should(BeNil())
+			})
+		})
+
+		When("the event is not deleted", func() {
+			It("returns a not found error", func() {
+				empty, err := endpoint.RestoreEvent(ctx, &v1.RestoreEventRequest{Id: event.ID})
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+				Expect(empty).Should(BeNil())
+			})
+		})
+	})
+})
+
+var _ = Describe("Finding an Event", func() {
+	var (
+		eventRepo     *postgresql.EventRepository
+		schedulingSvc *scheduling.Service
+		endpoint      *grpcEndpoint.GRPCEndpoint
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		schedulingSvc = scheduling.NewService(eventRepo, postgresql.NewEventHistoryRepository(db), postgresql.NewCalendarRepository(db), postgresql.NewGroupRepository(db), changefeed.NewBroker(0))
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, nil, nil, nil, nil, nil, nil, nil, health.NewMonitor(nil, 0))
+	})
+
+	Context("Run", func() {
+		When("the event is not exists", func() {
+			It("returns a not found error", func() {
+				ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+					"Authorization": []string{"1"},
+				})
+				res, err := endpoint.FindEventByID(ctx, &v1.FindEventByIDRequest{
+					Id: "invalid id",
+				})
+				Expect(err).ShouldNot(BeNil())
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+				Expect(res).Should(BeNil())
+			})
+		})
+	})
+})
+
 // func TestGRPCEndpoint_UpdateEvent(t *testing.T) {
 // 	type fields struct {
 // 		svcMock func(ctrl *gomock.Controller) core.SchedulingService
@@ -451,3 +550,687 @@ var _ = Describe("Deleting an Event", func() {
 // 		})
 // 	}
 // }
+
+var _ = Describe("Listing Event History", func() {
+	var (
+		eventRepo     *postgresql.EventRepository
+		schedulingSvc *scheduling.Service
+		endpoint      *grpcEndpoint.GRPCEndpoint
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		schedulingSvc = scheduling.NewService(eventRepo, postgresql.NewEventHistoryRepository(db), postgresql.NewCalendarRepository(db), postgresql.NewGroupRepository(db), changefeed.NewBroker(0))
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, nil, nil, nil, nil, nil, nil, nil, health.NewMonitor(nil, 0))
+	})
+
+	Context("Run", func() {
+		var eventID string
+		BeforeEach(func() {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+				"Authorization": []string{"1"},
+			})
+			res, err := endpoint.CreateEvent(ctx, &v1.CreateEventRequest{
+				Event: &v1.Event{
+					Title:     "test",
+					Timezone:  "Asia/Jakarta",
+					Attendees: []int32{2},
+					Schedule: []*v1.Schedule{
+						{
+							StartTime: "2022-01-01T00:00:00+07:00",
+							EndTime:   "2022-01-01T01:00:00+07:00",
+						},
+					},
+				},
+			})
+			Expect(err).Should(BeNil())
+			eventID = res.GetId()
+		})
+
+		When("an attendee responds to the invitation", func() {
+			It("records who did what, newest first", func() {
+				ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+					"Authorization": []string{"2"},
+				})
+				_, err := endpoint.RespondInvitation(ctx, &v1.RespondInvitationRequest{
+					Id:     eventID,
+					Status: v1.InvitationStatus_CONFIRMED,
+				})
+				Expect(err).Should(BeNil())
+
+				ctx = metadata.NewIncomingContext(context.Background(), metadata.MD{
+					"Authorization": []string{"1"},
+				})
+				first, err := endpoint.ListEventHistory(ctx, &v1.ListEventHistoryRequest{
+					Id:       eventID,
+					PageSize: 1,
+				})
+				Expect(err).Should(BeNil())
+				Expect(first.GetEntries()).To(HaveLen(1))
+				Expect(first.GetEntries()[0].GetOperation()).To(Equal(v1.HistoryOperation_RSVP))
+				Expect(first.GetEntries()[0].GetActorId()).To(Equal("2"))
+				Expect(first.GetEntries()[0].GetChanges()).To(ContainElement(HaveField("Field", "attendees")))
+				Expect(first.GetNextPageToken()).ShouldNot(BeEmpty())
+
+				second, err := endpoint.ListEventHistory(ctx, &v1.ListEventHistoryRequest{
+					Id:        eventID,
+					PageSize:  1,
+					PageToken: first.GetNextPageToken(),
+				})
+				Expect(err).Should(BeNil())
+				Expect(second.GetEntries()).To(HaveLen(1))
+				Expect(second.GetEntries()[0].GetOperation()).To(Equal(v1.HistoryOperation_CREATE))
+				Expect(second.GetEntries()[0].GetActorId()).To(Equal("1"))
+				Expect(second.GetNextPageToken()).Should(BeEmpty())
+			})
+		})
+
+		When("the caller is not invited", func() {
+			It("returns a not found error", func() {
+				ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+					"Authorization": []string{"3"},
+				})
+				_, err := endpoint.RespondInvitation(ctx, &v1.RespondInvitationRequest{
+					Id:     eventID,
+					Status: v1.InvitationStatus_DECLINED,
+				})
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+			})
+		})
+
+		When("the page token is malformed", func() {
+			It("returns an invalid argument error", func() {
+				ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+					"Authorization": []string{"1"},
+				})
+				_, err := endpoint.ListEventHistory(ctx, &v1.ListEventHistoryRequest{
+					Id:        eventID,
+					PageToken: "%%%",
+				})
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+	})
+})
+
+var _ = Describe("Waitlisting Attendees", func() {
+	var (
+		eventRepo     *postgresql.EventRepository
+		schedulingSvc *scheduling.Service
+		endpoint      *grpcEndpoint.GRPCEndpoint
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		schedulingSvc = scheduling.NewService(eventRepo, postgresql.NewEventHistoryRepository(db), postgresql.NewCalendarRepository(db), postgresql.NewGroupRepository(db), changefeed.NewBroker(0))
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, nil, nil, nil, nil, nil, nil, nil, health.NewMonitor(nil, 0))
+	})
+
+	Context("Run", func() {
+		var eventID string
+		ownerCtx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+			"Authorization": []string{"1"},
+		})
+		respond := func(userID string, s v1.InvitationStatus) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+				"Authorization": []string{userID},
+			})
+			_, err := endpoint.RespondInvitation(ctx, &v1.RespondInvitationRequest{
+				Id:     eventID,
+				Status: s,
+			})
+			Expect(err).Should(BeNil())
+		}
+		BeforeEach(func() {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+				"Authorization": []string{"1"},
+			})
+			res, err := endpoint.CreateEvent(ctx, &v1.CreateEventRequest{
+				Event: &v1.Event{
+					Title:     "training",
+					Timezone:  "Asia/Jakarta",
+					Attendees: []int32{2, 3, 4},
+					Capacity:  1,
+					Schedule: []*v1.Schedule{
+						{
+							StartTime: "2022-01-01T00:00:00+07:00",
+							EndTime:   "2022-01-01T01:00:00+07:00",
+						},
+					},
+				},
+			})
+			Expect(err).Should(BeNil())
+			eventID = res.GetId()
+		})
+
+		When("the event is full", func() {
+			It("waitlists the attendees who accept in the order they accept", func() {
+				respond("2", v1.InvitationStatus_CONFIRMED)
+				respond("4", v1.InvitationStatus_CONFIRMED)
+				respond("3", v1.InvitationStatus_CONFIRMED)
+
+				res, err := endpoint.ListWaitlist(ownerCtx, &v1.ListWaitlistRequest{Id: eventID})
+				Expect(err).Should(BeNil())
+				Expect(res.GetEntries()).To(HaveLen(2))
+				Expect(res.GetEntries()[0].GetUserId()).To(Equal(int32(4)))
+				Expect(res.GetEntries()[0].GetPosition()).To(Equal(int32(1)))
+				Expect(res.GetEntries()[1].GetUserId()).To(Equal(int32(3)))
+			})
+		})
+
+		When("a confirmed attendee declines", func() {
+			It("confirms the first one on the waitlist", func() {
+				respond("2", v1.InvitationStatus_CONFIRMED)
+				respond("4", v1.InvitationStatus_CONFIRMED)
+				respond("2", v1.InvitationStatus_DECLINED)
+
+				res, err := endpoint.ListWaitlist(ownerCtx, &v1.ListWaitlistRequest{Id: eventID})
+				Expect(err).Should(BeNil())
+				Expect(res.GetEntries()).To(BeEmpty())
+
+				event, err := eventRepo.FindByID(context.Background(), eventID)
+				Expect(err).Should(BeNil())
+				Expect(event.FindInvitation(4).Status).To(Equal(core.InvitationStatus_Confirmed))
+			})
+		})
+
+		When("the event is updated with a larger capacity", func() {
+			It("keeps the invitations and confirms the first one on the waitlist", func() {
+				respond("2", v1.InvitationStatus_CONFIRMED)
+				respond("4", v1.InvitationStatus_CONFIRMED)
+				respond("3", v1.InvitationStatus_CONFIRMED)
+
+				_, err := endpoint.UpdateEvent(ownerCtx, &v1.UpdateEventRequest{
+					Id: eventID,
+					Event: &v1.Event{
+						Title:       "training",
+						Description: "training",
+						Timezone:    "Asia/Jakarta",
+						Attendees:   []int32{2, 3, 4},
+						Capacity:    2,
+						Schedule: []*v1.Schedule{
+							{
+								StartTime: "2022-01-01T00:00:00+07:00",
+								EndTime:   "2022-01-01T01:00:00+07:00",
+							},
+						},
+					},
+				})
+				Expect(err).Should(BeNil())
+
+				res, err := endpoint.ListWaitlist(ownerCtx, &v1.ListWaitlistRequest{Id: eventID})
+				Expect(err).Should(BeNil())
+				Expect(res.GetEntries()).To(HaveLen(1))
+				Expect(res.GetEntries()[0].GetUserId()).To(Equal(int32(3)))
+
+				event, err := eventRepo.FindByID(context.Background(), eventID)
+				Expect(err).Should(BeNil())
+				Expect(event.Invitations).To(HaveLen(3))
+				Expect(event.FindInvitation(4).Status).To(Equal(core.InvitationStatus_Confirmed))
+			})
+		})
+	})
+})
+
+var _ = Describe("Listing Events", func() {
+	var (
+		eventRepo     *postgresql.EventRepository
+		schedulingSvc *scheduling.Service
+		endpoint      *grpcEndpoint.GRPCEndpoint
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		schedulingSvc = scheduling.NewService(eventRepo, postgresql.NewEventHistoryRepository(db), postgresql.NewCalendarRepository(db), postgresql.NewGroupRepository(db), changefeed.NewBroker(0))
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, nil, nil, nil, nil, nil, nil, nil, health.NewMonitor(nil, 0))
+	})
+
+	Context("Run", func() {
+		var ids []string
+		actorCtx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+			"Authorization": []string{"list_actor"},
+		})
+		BeforeEach(func() {
+			ids = nil
+			for _, title := range []string{"Weekly Sync", "weekly review", "Retro"} {
+				event := core.NewEvent("list_actor")
+				event.Title = title
+				event.Timezone = "Asia/Jakarta"
+				event.Schedules = []core.Schedule{
+					{ID: event.ID + "-schedule", EventID: event.ID, StartTime: 1, DurationInMinutes: 60, RecurringType: core.RecurringType_None},
+				}
+				event.Invitations = []core.Invitation{core.NewInvitation(event.ID, 3)}
+				err := eventRepo.Store(context.Background(), event, core.Journal{})
+				Expect(err).Should(BeNil())
+				ids = append([]string{event.ID}, ids...)
+			}
+		})
+
+		AfterEach(func() {
+			for _, id := range ids {
+				_, err := db.Exec(`DELETE FROM event WHERE id = $1`, id)
+				Expect(err).Should(BeNil())
+			}
+		})
+
+		When("the events span multiple pages", func() {
+			It("pages through them newest first with their schedules and attendees", func() {
+				first, err := endpoint.ListEvents(actorCtx, &v1.ListEventsRequest{
+					CreatedBy: "list_actor",
+					PageSize:  2,
+				})
+				Expect(err).Should(BeNil())
+				Expect(first.GetEvents()).To(HaveLen(2))
+				Expect(first.GetEvents()[0].GetId()).To(Equal(ids[0]))
+				Expect(first.GetEvents()[1].GetId()).To(Equal(ids[1]))
+				Expect(first.GetEvents()[0].GetSchedule()).To(HaveLen(1))
+				Expect(first.GetEvents()[0].GetAttendees()).To(Equal([]int32{3}))
+				Expect(first.GetNextPageToken()).ShouldNot(BeEmpty())
+
+				second, err := endpoint.ListEvents(actorCtx, &v1.ListEventsRequest{
+					CreatedBy: "list_actor",
+					PageSize:  2,
+					PageToken: first.GetNextPageToken(),
+				})
+				Expect(err).Should(BeNil())
+				Expect(second.GetEvents()).To(HaveLen(1))
+				Expect(second.GetEvents()[0].GetId()).To(Equal(ids[2]))
+				Expect(second.GetNextPageToken()).Should(BeEmpty())
+			})
+		})
+
+		When("the calendar is only shared free/busy", func() {
+			var grantee context.Context
+			BeforeEach(func() {
+				event, err := eventRepo.FindByID(context.Background(), ids[0])
+				Expect(err).Should(BeNil())
+				err = postgresql.NewCalendarRepository(db).Grant(context.Background(), &core.CalendarGrant{
+					CalendarID:  event.CalendarID,
+					GranteeType: core.GranteeType_User,
+					GranteeID:   "list_grantee",
+					Role:        core.CalendarRole_FreeBusy,
+					GrantedBy:   "list_actor",
+					CreatedAt:   time.Now(),
+				})
+				Expect(err).Should(BeNil())
+				grantee = metadata.NewIncomingContext(context.Background(), metadata.MD{
+					"Authorization": []string{"list_grantee"},
+				})
+			})
+
+			AfterEach(func() {
+				_, err := db.Exec(`DELETE FROM calendar_acl WHERE grantee_id = $1`, "list_grantee")
+				Expect(err).Should(BeNil())
+			})
+
+			It("lists when the events are busy, but doesn't match them on their content", func() {
+				res, err := endpoint.ListEvents(grantee, &v1.ListEventsRequest{CreatedBy: "list_actor"})
+				Expect(err).Should(BeNil())
+				Expect(res.GetEvents()).To(HaveLen(3))
+				Expect(res.GetEvents()[0].GetTitle()).To(BeEmpty())
+
+				res, err = endpoint.ListEvents(grantee, &v1.ListEventsRequest{
+					CreatedBy: "list_actor",
+					Title:     "weekly",
+				})
+				Expect(err).Should(BeNil())
+				Expect(res.GetEvents()).To(BeEmpty())
+
+				res, err = endpoint.ListEvents(grantee, &v1.ListEventsRequest{
+					CreatedBy: "list_actor",
+					Attendee:  3,
+				})
+				Expect(err).Should(BeNil())
+				Expect(res.GetEvents()).To(BeEmpty())
+			})
+		})
+
+		When("filtering by title and attendee", func() {
+			It("returns the matching events only", func() {
+				res, err := endpoint.ListEvents(actorCtx, &v1.ListEventsRequest{
+					CreatedBy: "list_actor",
+					Title:     "WEEKLY",
+					Attendee:  3,
+				})
+				Expect(err).Should(BeNil())
+				Expect(res.GetEvents()).To(HaveLen(2))
+
+				res, err = endpoint.ListEvents(actorCtx, &v1.ListEventsRequest{
+					CreatedBy: "list_actor",
+					Attendee:  2,
+				})
+				Expect(err).Should(BeNil())
+				Expect(res.GetEvents()).To(BeEmpty())
+			})
+		})
+	})
+})
+
+var _ = Describe("Searching Events", func() {
+	var (
+		eventRepo     *postgresql.EventRepository
+		schedulingSvc *scheduling.Service
+		endpoint      *grpcEndpoint.GRPCEndpoint
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		schedulingSvc = scheduling.NewService(eventRepo, postgresql.NewEventHistoryRepository(db), postgresql.NewCalendarRepository(db), postgresql.NewGroupRepository(db), changefeed.NewBroker(0))
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, nil, nil, nil, nil, nil, nil, nil, health.NewMonitor(nil, 0))
+	})
+
+	Context("Run", func() {
+		var ids []string
+		BeforeEach(func() {
+			ids = nil
+			for _, e := range []struct{ title, description, createdBy string }{
+				{"Quarterly planning", "plan the next quarter", "10"},
+				{"Team lunch", "quarterly planning wrap-up", "10"},
+				{"Quarterly planning", "someone else's planning", "11"},
+			} {
+				event := core.NewEvent(e.createdBy)
+				event.Title = e.title
+				event.Description = e.description
+				event.Timezone = "Asia/Jakarta"
+				err := eventRepo.Store(context.Background(), event, core.Journal{})
+				Expect(err).Should(BeNil())
+				ids = append(ids, event.ID)
+			}
+		})
+
+		AfterEach(func() {
+			for _, id := range ids {
+				_, err := db.Exec(`DELETE FROM event WHERE id = $1`, id)
+				Expect(err).Should(BeNil())
+			}
+		})
+
+		When("the caller searches for a phrase", func() {
+			It("returns the visible matches with title matches ranked first", func() {
+				ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+					"Authorization": []string{"10"},
+				})
+				res, err := endpoint.SearchEvents(ctx, &v1.SearchEventsRequest{
+					Query: "quarterly planning",
+				})
+				Expect(err).Should(BeNil())
+				Expect(res.GetEvents()).To(HaveLen(2))
+				Expect(res.GetEvents()[0].GetId()).To(Equal(ids[0]))
+				Expect(res.GetEvents()[1].GetId()).To(Equal(ids[1]))
+			})
+		})
+
+		When("the calendar is shared with the caller", func() {
+			var grantee context.Context
+			grant := func(role core.CalendarRole) {
+				event, err := eventRepo.FindByID(context.Background(), ids[2])
+				Expect(err).Should(BeNil())
+				err = postgresql.NewCalendarRepository(db).Grant(context.Background(), &core.CalendarGrant{
+					CalendarID:  event.CalendarID,
+					GranteeType: core.GranteeType_User,
+					GranteeID:   "search_grantee",
+					Role:        role,
+					GrantedBy:   "11",
+					CreatedAt:   time.Now(),
+				})
+				Expect(err).Should(BeNil())
+			}
+			BeforeEach(func() {
+				grantee = metadata.NewIncomingContext(context.Background(), metadata.MD{
+					"Authorization": []string{"search_grantee"},
+				})
+			})
+
+			AfterEach(func() {
+				_, err := db.Exec(`DELETE FROM calendar_acl WHERE grantee_id = $1`, "search_grantee")
+				Expect(err).Should(BeNil())
+			})
+
+			It("returns the events of the calendar like listing does", func() {
+				grant(core.CalendarRole_Read)
+				res, err := endpoint.SearchEvents(grantee, &v1.SearchEventsRequest{
+					Query: "quarterly planning",
+				})
+				Expect(err).Should(BeNil())
+				Expect(res.GetEvents()).To(HaveLen(1))
+				Expect(res.GetEvents()[0].GetId()).To(Equal(ids[2]))
+				Expect(res.GetEvents()[0].GetTitle()).To(Equal("Quarterly planning"))
+			})
+
+			It("doesn't match the events of a calendar shared free/busy on their content", func() {
+				grant(core.CalendarRole_FreeBusy)
+				res, err := endpoint.SearchEvents(grantee, &v1.SearchEventsRequest{
+					Query: "quarterly planning",
+				})
+				Expect(err).Should(BeNil())
+				Expect(res.GetEvents()).To(BeEmpty())
+			})
+		})
+
+		When("the caller is unauthenticated", func() {
+			It("returns an invalid argument error", func() {
+				_, err := endpoint.SearchEvents(context.Background(), &v1.SearchEventsRequest{
+					Query: "quarterly planning",
+				})
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+	})
+})
+
+var _ = Describe("Batch Mutating Events", func() {
+	var (
+		eventRepo     *postgresql.EventRepository
+		schedulingSvc *scheduling.Service
+		endpoint      *grpcEndpoint.GRPCEndpoint
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		schedulingSvc = scheduling.NewService(eventRepo, postgresql.NewEventHistoryRepository(db), postgresql.NewCalendarRepository(db), postgresql.NewGroupRepository(db), changefeed.NewBroker(0))
+		endpoint = grpcEndpoint.NewGRPCEndpoint(schedulingSvc, nil, nil, nil, nil, nil, nil, nil, health.NewMonitor(nil, 0))
+	})
+
+	Context("Run", func() {
+		var (
+			ctx       context.Context
+			mutations []*v1.EventMutation
+		)
+		BeforeEach(func() {
+			ctx = metadata.NewIncomingContext(context.Background(), metadata.MD{
+				"Authorization": []string{"1"},
+			})
+			mutations = []*v1.EventMutation{
+				{
+					Operation: &v1.EventMutation_Create{
+						Create: &v1.Event{
+							Title:       "batch",
+							Description: "batch description",
+							Timezone:    "Asia/Jakarta",
+							Schedule: []*v1.Schedule{
+								{
+									StartTime: "2022-01-01T00:00:00+07:00",
+									EndTime:   "2022-01-01T01:00:00+07:00",
+								},
+							},
+						},
+					},
+				},
+				{
+					Operation: &v1.EventMutation_Delete{
+						Delete: &v1.DeleteEventByIDRequest{Id: "not exists"},
+					},
+				},
+			}
+		})
+
+		When("a mutation fails in atomic mode", func() {
+			It("applies none of them", func() {
+				res, err := endpoint.BatchMutateEvents(ctx, &v1.BatchMutateEventsRequest{
+					Mutations: mutations,
+					Atomic:    true,
+				})
+				Expect(err).Should(BeNil())
+				Expect(res.GetResults()).To(HaveLen(2))
+				Expect(codes.Code(res.GetResults()[0].GetStatus().GetCode())).To(Equal(codes.Aborted))
+				Expect(codes.Code(res.GetResults()[1].GetStatus().GetCode())).To(Equal(codes.NotFound))
+
+				_, err = eventRepo.FindByID(context.Background(), res.GetResults()[0].GetId())
+				Expect(err).To(MatchError(core.ErrEventNotFound))
+			})
+		})
+
+		When("a mutation fails in best-effort mode", func() {
+			It("applies the others", func() {
+				res, err := endpoint.BatchMutateEvents(ctx, &v1.BatchMutateEventsRequest{
+					Mutations: mutations,
+				})
+				Expect(err).Should(BeNil())
+				Expect(res.GetResults()).To(HaveLen(2))
+				Expect(codes.Code(res.GetResults()[0].GetStatus().GetCode())).To(Equal(codes.OK))
+				Expect(codes.Code(res.GetResults()[1].GetStatus().GetCode())).To(Equal(codes.NotFound))
+
+				e, err := eventRepo.FindByID(context.Background(), res.GetResults()[0].GetId())
+				Expect(err).Should(BeNil())
+				Expect(e.Title).To(Equal("batch"))
+			})
+		})
+	})
+})
+
+var _ = Describe("Creating an Event with an Idempotency Key", func() {
+	var (
+		eventRepo *postgresql.EventRepository
+		endpoint  *grpcEndpoint.GRPCEndpoint
+		ctx       context.Context
+		req       *v1.CreateEventRequest
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		schedulingSvc := scheduling.NewService(eventRepo, postgresql.NewEventHistoryRepository(db), postgresql.NewCalendarRepository(db), postgresql.NewGroupRepository(db), changefeed.NewBroker(0))
+		endpoint = grpcEndpoint.NewGRPCEndpoint(scheduling.NewIdempotency(schedulingSvc, postgresql.NewIdempotencyKeyRepository(db), time.Hour), nil, nil, nil, nil, nil, nil, nil, health.NewMonitor(nil, 0))
+
+		ctx = metadata.NewIncomingContext(context.Background(), metadata.MD{
+			"Authorization": []string{"1"},
+		})
+		req = &v1.CreateEventRequest{
+			RequestId: fmt.Sprintf("idempotency-%d", time.Now().UnixNano()),
+			Event: &v1.Event{
+				Title:       "idempotent",
+				Description: "idempotent description",
+				Timezone:    "Asia/Jakarta",
+				Schedule: []*v1.Schedule{
+					{
+						StartTime: "2022-01-01T00:00:00+07:00",
+						EndTime:   "2022-01-01T01:00:00+07:00",
+					},
+				},
+			},
+		}
+	})
+
+	When("the request is retried", func() {
+		It("returns the event created by the first request", func() {
+			first, err := endpoint.CreateEvent(ctx, req)
+			Expect(err).Should(BeNil())
+
+			retried, err := endpoint.CreateEvent(ctx, req)
+			Expect(err).Should(BeNil())
+			Expect(retried.GetId()).To(Equal(first.GetId()))
+		})
+	})
+
+	When("the key is reused with a different payload", func() {
+		It("returns error", func() {
+			_, err := endpoint.CreateEvent(ctx, req)
+			Expect(err).Should(BeNil())
+
+			req.Event.Title = "changed"
+			res, err := endpoint.CreateEvent(ctx, req)
+			Expect(err).ShouldNot(BeNil())
+			Expect(res).Should(BeNil())
+
+			st, _ := status.FromError(err)
+			Expect(st.Code()).To(Equal(codes.InvalidArgument))
+		})
+	})
+
+	When("the key is sent as metadata as well", func() {
+		It("returns error when they do not match", func() {
+			ctx = metadata.NewIncomingContext(context.Background(), metadata.MD{
+				"Authorization":   []string{"1"},
+				"Idempotency-Key": []string{"other"},
+			})
+			res, err := endpoint.CreateEvent(ctx, req)
+			Expect(err).ShouldNot(BeNil())
+			Expect(res).Should(BeNil())
+
+			st, _ := status.FromError(err)
+			Expect(st.Code()).To(Equal(codes.InvalidArgument))
+		})
+	})
+})
+
+var _ = Describe("Creating an Event with a Client Supplied ID", func() {
+	var (
+		eventRepo *postgresql.EventRepository
+		endpoint  *grpcEndpoint.GRPCEndpoint
+		ctx       context.Context
+		req       *v1.CreateEventRequest
+	)
+	BeforeEach(func() {
+		eventRepo = postgresql.NewEventRepository(db)
+		endpoint = grpcEndpoint.NewGRPCEndpoint(scheduling.NewService(eventRepo, postgresql.NewEventHistoryRepository(db), postgresql.NewCalendarRepository(db), postgresql.NewGroupRepository(db), changefeed.NewBroker(0)), nil, nil, nil, nil, nil, nil, nil, health.NewMonitor(nil, 0))
+
+		ctx = metadata.NewIncomingContext(context.Background(), metadata.MD{
+			"Authorization": []string{"1"},
+		})
+		req = &v1.CreateEventRequest{
+			EventId: fmt.Sprintf("integration-%d", time.Now().UnixNano()),
+			Event: &v1.Event{
+				Title:       "client id",
+				Description: "client id description",
+				Timezone:    "Asia/Jakarta",
+				Schedule: []*v1.Schedule{
+					{
+						StartTime: "2022-01-01T00:00:00+07:00",
+						EndTime:   "2022-01-01T01:00:00+07:00",
+					},
+				},
+			},
+		}
+	})
+
+	When("the id is free", func() {
+		It("creates the event with that id", func() {
+			res, err := endpoint.CreateEvent(ctx, req)
+			Expect(err).Should(BeNil())
+			Expect(res.GetId()).To(Equal(req.GetEventId()))
+
+			e, err := eventRepo.FindByID(context.Background(), req.GetEventId())
+			Expect(err).Should(BeNil())
+			Expect(e.Schedules).To(HaveLen(1))
+		})
+	})
+
+	When("the id is already taken", func() {
+		It("returns already exists", func() {
+			_, err := endpoint.CreateEvent(ctx, req)
+			Expect(err).Should(BeNil())
+
+			res, err := endpoint.CreateEvent(ctx, req)
+			Expect(err).ShouldNot(BeNil())
+			Expect(res).Should(BeNil())
+
+			st, _ := status.FromError(err)
+			Expect(st.Code()).To(Equal(codes.AlreadyExists))
+		})
+	})
+
+	When("the id is malformed", func() {
+		It("returns error", func() {
+			req.EventId = "has spaces"
+			res, err := endpoint.CreateEvent(ctx, req)
+			Expect(err).ShouldNot(BeNil())
+			Expect(res).Should(BeNil())
+
+			st, _ := status.FromError(err)
+			Expect(st.Code()).To(Equal(codes.InvalidArgument))
+		})
+	})
+})
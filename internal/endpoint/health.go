@@ -0,0 +1,96 @@
+package endpoint
+
+import (
+	"context"
+
+	v1 "github.com/dzakaammar/event-scheduling-example/gen/go/proto/v1"
+	"github.com/dzakaammar/event-scheduling-example/internal/health"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// HealthEndpoint implements the standard grpc.health.v1.Health service. The overall health,
+// with an empty service name, and the health of the API are the same.
+type HealthEndpoint struct {
+	healthpb.UnimplementedHealthServer
+	monitor *health.Monitor
+}
+
+func NewHealthEndpoint(monitor *health.Monitor) *HealthEndpoint {
+	return &HealthEndpoint{
+		monitor: monitor,
+	}
+}
+
+func (h *HealthEndpoint) Check(_ context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if !isKnownService(req.GetService()) {
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+
+	return &healthpb.HealthCheckResponse{
+		Status: mapHealthStatus(h.monitor.Status()),
+	}, nil
+}
+
+func (h *HealthEndpoint) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	if !isKnownService(req.GetService()) {
+		return stream.Send(&healthpb.HealthCheckResponse{
+			Status: healthpb.HealthCheckResponse_SERVICE_UNKNOWN,
+		})
+	}
+
+	for s := range h.monitor.Watch(stream.Context()) {
+		err := stream.Send(&healthpb.HealthCheckResponse{
+			Status: mapHealthStatus(s),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	// the watch ends when the client goes away or the server shuts down
+	return nil
+}
+
+func (g *GRPCEndpoint) Check(_ context.Context, req *v1.HealthCheckRequest) (*v1.HealthCheckResponse, error) {
+	if !isKnownService(req.GetService()) {
+		return &v1.HealthCheckResponse{Status: v1.HealthCheckResponse_SERVICE_UNKNOWN}, nil
+	}
+
+	return &v1.HealthCheckResponse{
+		Status: v1.HealthCheckResponse_ServingStatus(mapHealthStatus(g.health.Status())),
+	}, nil
+}
+
+func (g *GRPCEndpoint) Watch(req *v1.HealthCheckRequest, stream v1.API_WatchServer) error {
+	if !isKnownService(req.GetService()) {
+		return stream.Send(&v1.HealthCheckResponse{Status: v1.HealthCheckResponse_SERVICE_UNKNOWN})
+	}
+
+	for s := range g.health.Watch(stream.Context()) {
+		err := stream.Send(&v1.HealthCheckResponse{
+			Status: v1.HealthCheckResponse_ServingStatus(mapHealthStatus(s)),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	// the watch ends when the client goes away or the server shuts down
+	return nil
+}
+
+func isKnownService(service string) bool {
+	return service == "" || service == v1.API_ServiceDesc.ServiceName
+}
+
+// mapHealthStatus maps to the standard status, the API's own enum has the same values.
+func mapHealthStatus(s health.Status) healthpb.HealthCheckResponse_ServingStatus {
+	switch s {
+	case health.Status_Serving:
+		return healthpb.HealthCheckResponse_SERVING
+	case health.Status_NotServing:
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	default:
+		return healthpb.HealthCheckResponse_UNKNOWN
+	}
+}
@@ -0,0 +1,123 @@
+package endpoint
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	v1 "github.com/dzakaammar/event-scheduling-example/gen/go/proto/v1"
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (g *GRPCEndpoint) GetNotificationPreference(ctx context.Context, _ *v1.GetNotificationPreferenceRequest) (*v1.GetNotificationPreferenceResponse, error) {
+	preference, err := g.preferences.GetNotificationPreference(ctx, &core.GetNotificationPreferenceRequest{
+		ActorID: extractAuthorization(ctx),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.GetNotificationPreferenceResponse{
+		Preference: parseNotificationPreferenceToPB(preference),
+	}, nil
+}
+
+func (g *GRPCEndpoint) UpdateNotificationPreference(ctx context.Context, req *v1.UpdateNotificationPreferenceRequest) (*emptypb.Empty, error) {
+	if req.GetPreference() == nil {
+		return nil, invalidArgument("preference", internal.ErrInvalidRequest)
+	}
+
+	channels := make([]core.ReminderChannel, len(req.GetPreference().GetChannels()))
+	for index, c := range req.GetPreference().GetChannels() {
+		channels[index] = mapReminderChannel(c)
+	}
+
+	err := g.preferences.UpdateNotificationPreference(ctx, &core.UpdateNotificationPreferenceRequest{
+		ActorID: extractAuthorization(ctx),
+		Preference: &core.NotificationPreference{
+			Channels:        channels,
+			Timezone:        req.GetPreference().GetTimezone(),
+			QuietHoursStart: req.GetPreference().GetQuietHoursStart(),
+			QuietHoursEnd:   req.GetPreference().GetQuietHoursEnd(),
+			Delivery:        mapNotificationDelivery(req.GetPreference().GetDelivery()),
+			DigestTime:      req.GetPreference().GetDigestTime(),
+		},
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) MuteEvent(ctx context.Context, req *v1.MuteEventRequest) (*emptypb.Empty, error) {
+	err := g.preferences.MuteEvent(ctx, &core.MuteEventRequest{
+		ActorID: extractAuthorization(ctx),
+		EventID: req.GetId(),
+		Muted:   true,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) UnmuteEvent(ctx context.Context, req *v1.UnmuteEventRequest) (*emptypb.Empty, error) {
+	err := g.preferences.MuteEvent(ctx, &core.MuteEventRequest{
+		ActorID: extractAuthorization(ctx),
+		EventID: req.GetId(),
+		Muted:   false,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func parseNotificationPreferenceToPB(p *core.NotificationPreference) *v1.NotificationPreference {
+	channels := make([]v1.ReminderChannel, len(p.Channels))
+	for index, c := range p.Channels {
+		channels[index] = mapReminderChannelToPB(c)
+	}
+
+	res := &v1.NotificationPreference{
+		Channels:        channels,
+		Timezone:        p.Timezone,
+		QuietHoursStart: p.QuietHoursStart,
+		QuietHoursEnd:   p.QuietHoursEnd,
+		Delivery:        mapNotificationDeliveryToPB(p.Delivery),
+		DigestTime:      p.DigestTime,
+		MutedEventIds:   p.MutedEventIDs,
+	}
+	if p.UpdatedAt != nil {
+		res.LastUpdatedAt = p.UpdatedAt.Format(time.RFC3339)
+	}
+	return res
+}
+
+func mapNotificationDelivery(d v1.NotificationDelivery) core.NotificationDelivery {
+	switch d {
+	case v1.NotificationDelivery_IMMEDIATE:
+		return core.NotificationDelivery_Immediate
+	case v1.NotificationDelivery_DIGEST:
+		return core.NotificationDelivery_Digest
+	default:
+		return ""
+	}
+}
+
+func mapNotificationDeliveryToPB(d core.NotificationDelivery) v1.NotificationDelivery {
+	switch d {
+	case core.NotificationDelivery_Immediate:
+		return v1.NotificationDelivery_IMMEDIATE
+	case core.NotificationDelivery_Digest:
+		return v1.NotificationDelivery_DIGEST
+	default:
+		return v1.NotificationDelivery_UNKNOWN_DELIVERY
+	}
+}
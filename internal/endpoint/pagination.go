@@ -0,0 +1,116 @@
+package endpoint
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+// encodePageToken turns a cursor into an opaque page token, an empty token means there is no next page.
+func encodePageToken(cursor string) string {
+	if cursor == "" {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(cursor))
+}
+
+func decodePageToken(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+
+	cursor, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", invalidArgument("page_token", internal.ErrInvalidRequest)
+	}
+	return string(cursor), nil
+}
+
+func decodeIDPageToken(token string) (int64, error) {
+	cursor, err := decodePageToken(token)
+	if err != nil || cursor == "" {
+		return 0, err
+	}
+
+	id, err := strconv.ParseInt(cursor, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, invalidArgument("page_token", internal.ErrInvalidRequest)
+	}
+	return id, nil
+}
+
+func encodeEventPageToken(cursor *core.EventCursor) string {
+	if cursor == nil {
+		return ""
+	}
+	return encodePageToken(strconv.FormatInt(cursor.CreatedAt.UnixNano(), 10) + "," + cursor.ID)
+}
+
+func decodeEventPageToken(token string) (*core.EventCursor, error) {
+	cursor, err := decodePageToken(token)
+	if err != nil || cursor == "" {
+		return nil, err
+	}
+
+	createdAt, id, ok := strings.Cut(cursor, ",")
+	if !ok || id == "" {
+		return nil, invalidArgument("page_token", internal.ErrInvalidRequest)
+	}
+
+	nsec, err := strconv.ParseInt(createdAt, 10, 64)
+	if err != nil {
+		return nil, invalidArgument("page_token", internal.ErrInvalidRequest)
+	}
+
+	return &core.EventCursor{
+		CreatedAt: time.Unix(0, nsec).UTC(),
+		ID:        id,
+	}, nil
+}
+
+func decodeOffsetPageToken(token string) (int, error) {
+	cursor, err := decodePageToken(token)
+	if err != nil || cursor == "" {
+		return 0, err
+	}
+
+	offset, err := strconv.Atoi(cursor)
+	if err != nil || offset <= 0 {
+		return 0, invalidArgument("page_token", internal.ErrInvalidRequest)
+	}
+	return offset, nil
+}
+
+func encodeChangeCursor(cursor core.ChangeCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursor.Epoch + "," + strconv.FormatUint(cursor.Sequence, 10)))
+}
+
+func decodeChangeCursor(token string) (*core.ChangeCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	cursor, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, invalidArgument("cursor", internal.ErrInvalidRequest)
+	}
+
+	epoch, sequence, ok := strings.Cut(string(cursor), ",")
+	if !ok || epoch == "" {
+		return nil, invalidArgument("cursor", internal.ErrInvalidRequest)
+	}
+
+	seq, err := strconv.ParseUint(sequence, 10, 64)
+	if err != nil {
+		return nil, invalidArgument("cursor", internal.ErrInvalidRequest)
+	}
+
+	return &core.ChangeCursor{
+		Epoch:    epoch,
+		Sequence: seq,
+	}, nil
+}
@@ -0,0 +1,207 @@
+package endpoint
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	v1 "github.com/dzakaammar/event-scheduling-example/gen/go/proto/v1"
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (g *GRPCEndpoint) CreateResource(ctx context.Context, req *v1.CreateResourceRequest) (*v1.CreateResourceResponse, error) {
+	if req.GetResource() == nil {
+		return nil, invalidArgument("resource", internal.ErrInvalidRequest)
+	}
+
+	actorID := extractAuthorization(ctx)
+
+	resource := core.NewResource(actorID)
+	resource.Name = req.GetResource().GetName()
+	resource.Type = mapResourceType(req.GetResource().GetType())
+	resource.Capacity = req.GetResource().GetCapacity()
+	resource.Location = req.GetResource().GetLocation()
+
+	err := g.resources.CreateResource(ctx, &core.CreateResourceRequest{
+		ActorID:  actorID,
+		Resource: resource,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.CreateResourceResponse{
+		Id: resource.ID,
+	}, nil
+}
+
+func (g *GRPCEndpoint) UpdateResource(ctx context.Context, req *v1.UpdateResourceRequest) (*emptypb.Empty, error) {
+	if req.GetResource() == nil {
+		return nil, invalidArgument("resource", internal.ErrInvalidRequest)
+	}
+
+	err := g.resources.UpdateResource(ctx, &core.UpdateResourceRequest{
+		ActorID: extractAuthorization(ctx),
+		Resource: &core.Resource{
+			ID:       req.GetId(),
+			Name:     req.GetResource().GetName(),
+			Type:     mapResourceType(req.GetResource().GetType()),
+			Capacity: req.GetResource().GetCapacity(),
+			Location: req.GetResource().GetLocation(),
+		},
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) DeleteResource(ctx context.Context, req *v1.DeleteResourceRequest) (*emptypb.Empty, error) {
+	err := g.resources.DeleteResource(ctx, &core.DeleteResourceRequest{
+		ActorID:    extractAuthorization(ctx),
+		ResourceID: req.GetId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) FindResourceByID(ctx context.Context, req *v1.FindResourceByIDRequest) (*v1.FindResourceByIDResponse, error) {
+	resource, err := g.resources.FindResourceByID(ctx, &core.FindResourceByIDRequest{
+		ActorID:    extractAuthorization(ctx),
+		ResourceID: req.GetId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.FindResourceByIDResponse{
+		Resource: parseResourceToPB(resource),
+	}, nil
+}
+
+func (g *GRPCEndpoint) SearchResources(ctx context.Context, req *v1.SearchResourcesRequest) (*v1.SearchResourcesResponse, error) {
+	availableFrom, err := parseOptionalTime(req.GetAvailableFrom(), "available_from")
+	if err != nil {
+		return nil, err
+	}
+
+	availableTo, err := parseOptionalTime(req.GetAvailableTo(), "available_to")
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := g.resources.SearchResources(ctx, &core.SearchResourcesRequest{
+		ActorID:       extractAuthorization(ctx),
+		Type:          mapResourceType(req.GetType()),
+		MinCapacity:   req.GetMinCapacity(),
+		AvailableFrom: availableFrom,
+		AvailableTo:   availableTo,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	res := make([]*v1.Resource, len(resources))
+	for index := range resources {
+		res[index] = parseResourceToPB(&resources[index])
+	}
+
+	return &v1.SearchResourcesResponse{
+		Resources: res,
+	}, nil
+}
+
+func (g *GRPCEndpoint) GetResourceFreeBusy(ctx context.Context, req *v1.GetResourceFreeBusyRequest) (*v1.GetResourceFreeBusyResponse, error) {
+	from, err := time.Parse(time.RFC3339, req.GetFrom())
+	if err != nil {
+		return nil, invalidArgument("from", internal.ErrInvalidRequest)
+	}
+
+	to, err := time.Parse(time.RFC3339, req.GetTo())
+	if err != nil {
+		return nil, invalidArgument("to", internal.ErrInvalidRequest)
+	}
+
+	periods, err := g.resources.GetResourceFreeBusy(ctx, &core.GetResourceFreeBusyRequest{
+		ActorID:    extractAuthorization(ctx),
+		ResourceID: req.GetId(),
+		From:       from,
+		To:         to,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	busy := make([]*v1.BusyPeriod, len(periods))
+	for index, period := range periods {
+		busy[index] = &v1.BusyPeriod{
+			StartTime: period.Start.In(from.Location()).Format(time.RFC3339),
+			EndTime:   period.End.In(from.Location()).Format(time.RFC3339),
+		}
+	}
+
+	return &v1.GetResourceFreeBusyResponse{
+		Busy: busy,
+	}, nil
+}
+
+// parseResourceBookings returns the resources to book for the event, their status is decided when
+// the event is written.
+func parseResourceBookings(bookings []*v1.ResourceBooking, eventID string) []core.ResourceBooking {
+	res := make([]core.ResourceBooking, len(bookings))
+	for index, b := range bookings {
+		res[index] = core.ResourceBooking{
+			EventID:    eventID,
+			ResourceID: b.GetResourceId(),
+		}
+	}
+	return res
+}
+
+func parseResourceToPB(r *core.Resource) *v1.Resource {
+	res := &v1.Resource{
+		Id:        r.ID,
+		Name:      r.Name,
+		Type:      mapResourceTypeToPB(r.Type),
+		Capacity:  r.Capacity,
+		Location:  r.Location,
+		CreatedBy: r.CreatedBy,
+		CreatedAt: r.CreatedAt.Format(time.RFC3339),
+	}
+	if r.UpdatedAt != nil {
+		res.LastUpdatedAt = r.UpdatedAt.Format(time.RFC3339)
+	}
+	return res
+}
+
+func mapResourceType(t v1.ResourceType) core.ResourceType {
+	switch t {
+	case v1.ResourceType_ROOM:
+		return core.ResourceType_Room
+	case v1.ResourceType_EQUIPMENT:
+		return core.ResourceType_Equipment
+	default:
+		return ""
+	}
+}
+
+func mapResourceTypeToPB(t core.ResourceType) v1.ResourceType {
+	switch t {
+	case core.ResourceType_Room:
+		return v1.ResourceType_ROOM
+	case core.ResourceType_Equipment:
+		return v1.ResourceType_EQUIPMENT
+	default:
+		return v1.ResourceType_UNKNOWN_RESOURCE_TYPE
+	}
+}
@@ -0,0 +1,215 @@
+package endpoint
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	v1 "github.com/dzakaammar/event-scheduling-example/gen/go/proto/v1"
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (g *GRPCEndpoint) CreateWebhook(ctx context.Context, req *v1.CreateWebhookRequest) (*v1.CreateWebhookResponse, error) {
+	if req.GetWebhook() == nil {
+		return nil, invalidArgument("webhook", internal.ErrInvalidRequest)
+	}
+
+	actorID := extractAuthorization(ctx)
+
+	webhook := core.NewWebhook(actorID)
+	webhook.URL = req.GetWebhook().GetUrl()
+	webhook.Secret = req.GetWebhook().GetSecret()
+	webhook.EventTypes = parseHistoryOperations(req.GetWebhook().GetEventTypes())
+
+	err := g.webhooks.CreateWebhook(ctx, &core.CreateWebhookRequest{
+		ActorID: actorID,
+		Webhook: webhook,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.CreateWebhookResponse{
+		Id: webhook.ID,
+	}, nil
+}
+
+func (g *GRPCEndpoint) UpdateWebhook(ctx context.Context, req *v1.UpdateWebhookRequest) (*emptypb.Empty, error) {
+	if req.GetWebhook() == nil {
+		return nil, invalidArgument("webhook", internal.ErrInvalidRequest)
+	}
+
+	err := g.webhooks.UpdateWebhook(ctx, &core.UpdateWebhookRequest{
+		ActorID: extractAuthorization(ctx),
+		Webhook: &core.Webhook{
+			ID:         req.GetId(),
+			URL:        req.GetWebhook().GetUrl(),
+			Secret:     req.GetWebhook().GetSecret(),
+			EventTypes: parseHistoryOperations(req.GetWebhook().GetEventTypes()),
+		},
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) DeleteWebhook(ctx context.Context, req *v1.DeleteWebhookRequest) (*emptypb.Empty, error) {
+	err := g.webhooks.DeleteWebhook(ctx, &core.DeleteWebhookRequest{
+		ActorID:   extractAuthorization(ctx),
+		WebhookID: req.GetId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPCEndpoint) FindWebhookByID(ctx context.Context, req *v1.FindWebhookByIDRequest) (*v1.FindWebhookByIDResponse, error) {
+	webhook, err := g.webhooks.FindWebhookByID(ctx, &core.FindWebhookByIDRequest{
+		ActorID:   extractAuthorization(ctx),
+		WebhookID: req.GetId(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	return &v1.FindWebhookByIDResponse{
+		Webhook: parseWebhookToPB(webhook),
+	}, nil
+}
+
+func (g *GRPCEndpoint) ListWebhooks(ctx context.Context, _ *v1.ListWebhooksRequest) (*v1.ListWebhooksResponse, error) {
+	webhooks, err := g.webhooks.ListWebhooks(ctx, &core.ListWebhooksRequest{
+		ActorID: extractAuthorization(ctx),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	res := make([]*v1.Webhook, len(webhooks))
+	for index := range webhooks {
+		res[index] = parseWebhookToPB(&webhooks[index])
+	}
+
+	return &v1.ListWebhooksResponse{
+		Webhooks: res,
+	}, nil
+}
+
+func (g *GRPCEndpoint) ListWebhookDeliveries(ctx context.Context, req *v1.ListWebhookDeliveriesRequest) (*v1.ListWebhookDeliveriesResponse, error) {
+	beforeID, err := decodeIDPageToken(req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := g.webhooks.ListWebhookDeliveries(ctx, &core.ListWebhookDeliveriesRequest{
+		ActorID:   extractAuthorization(ctx),
+		WebhookID: req.GetId(),
+		PageSize:  int(req.GetPageSize()),
+		BeforeID:  beforeID,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, mapErrToStatusCode(err)
+	}
+
+	deliveries := make([]*v1.WebhookDelivery, len(page.Deliveries))
+	for index := range page.Deliveries {
+		deliveries[index] = parseWebhookDeliveryToPB(&page.Deliveries[index])
+	}
+
+	res := &v1.ListWebhookDeliveriesResponse{
+		Deliveries: deliveries,
+	}
+	if page.NextBeforeID > 0 {
+		res.NextPageToken = encodePageToken(strconv.FormatInt(page.NextBeforeID, 10))
+	}
+	return res, nil
+}
+
+func parseHistoryOperations(ops []v1.HistoryOperation) []core.HistoryOperation {
+	res := make([]core.HistoryOperation, len(ops))
+	for index, op := range ops {
+		res[index] = mapHistoryOperation(op)
+	}
+	return res
+}
+
+// parseWebhookToPB leaves the secret out, it is never sent back to the client.
+func parseWebhookToPB(w *core.Webhook) *v1.Webhook {
+	eventTypes := make([]v1.HistoryOperation, len(w.EventTypes))
+	for index, op := range w.EventTypes {
+		eventTypes[index] = mapHistoryOperationToPB(op)
+	}
+
+	res := &v1.Webhook{
+		Id:         w.ID,
+		Url:        w.URL,
+		EventTypes: eventTypes,
+		CreatedAt:  w.CreatedAt.Format(time.RFC3339),
+	}
+	if w.UpdatedAt != nil {
+		res.LastUpdatedAt = w.UpdatedAt.Format(time.RFC3339)
+	}
+	return res
+}
+
+func parseWebhookDeliveryToPB(d *core.WebhookDelivery) *v1.WebhookDelivery {
+	res := &v1.WebhookDelivery{
+		Id:             d.ID,
+		WebhookId:      d.WebhookID,
+		EventId:        d.EventID,
+		EventType:      mapHistoryOperationToPB(d.EventType),
+		Status:         mapWebhookDeliveryStatusToPB(d.Status),
+		Attempts:       int32(d.Attempts),
+		ResponseStatus: int32(d.ResponseStatus),
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt.Format(time.RFC3339),
+	}
+	if d.Status == core.WebhookDeliveryStatus_Pending {
+		res.NextAttemptAt = d.NextAttemptAt.Format(time.RFC3339)
+	}
+	if d.DeliveredAt != nil {
+		res.DeliveredAt = d.DeliveredAt.Format(time.RFC3339)
+	}
+	return res
+}
+
+func mapHistoryOperation(op v1.HistoryOperation) core.HistoryOperation {
+	switch op {
+	case v1.HistoryOperation_CREATE:
+		return core.HistoryOperation_Create
+	case v1.HistoryOperation_UPDATE:
+		return core.HistoryOperation_Update
+	case v1.HistoryOperation_DELETE:
+		return core.HistoryOperation_Delete
+	case v1.HistoryOperation_RESTORE:
+		return core.HistoryOperation_Restore
+	case v1.HistoryOperation_RSVP:
+		return core.HistoryOperation_RSVP
+	default:
+		return ""
+	}
+}
+
+func mapWebhookDeliveryStatusToPB(s core.WebhookDeliveryStatus) v1.WebhookDeliveryStatus {
+	switch s {
+	case core.WebhookDeliveryStatus_Pending:
+		return v1.WebhookDeliveryStatus_DELIVERY_PENDING
+	case core.WebhookDeliveryStatus_Succeeded:
+		return v1.WebhookDeliveryStatus_DELIVERY_SUCCEEDED
+	case core.WebhookDeliveryStatus_Failed:
+		return v1.WebhookDeliveryStatus_DELIVERY_FAILED
+	default:
+		return v1.WebhookDeliveryStatus_UNKNOWN_DELIVERY_STATUS
+	}
+}
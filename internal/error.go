@@ -12,8 +12,10 @@ var (
 )
 
 type Error struct {
-	err error
-	msg string
+	err   error
+	msg   string
+	cause error
+	field string
 }
 
 func (e *Error) Error() string {
@@ -23,9 +25,45 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("%s: %s", e.err.Error(), e.msg)
 }
 
+// Field returns the namespace of the struct field that failed, in the same form the validator
+// reports it (i.e: "Event.Timezone"). It is empty when the error isn't tied to a field.
+func (e *Error) Field() string {
+	return e.field
+}
+
+// Unwrap exposes both the sentinel error and the underlying cause to errors.Is and errors.As.
+func (e *Error) Unwrap() []error {
+	errs := make([]error, 0, 2)
+	if e.err != nil {
+		errs = append(errs, e.err)
+	}
+	if e.cause != nil {
+		errs = append(errs, e.cause)
+	}
+	return errs
+}
+
 func WrapErr(err error, msg string) *Error {
 	return &Error{
 		err: err,
 		msg: msg,
 	}
 }
+
+// WrapCause annotates cause with the sentinel err, keeping cause reachable through errors.As.
+func WrapCause(err error, cause error) *Error {
+	return &Error{
+		err:   err,
+		msg:   cause.Error(),
+		cause: cause,
+	}
+}
+
+// WrapFieldErr is like WrapErr, but also records which field of the request is invalid.
+func WrapFieldErr(err error, field string, msg string) *Error {
+	return &Error{
+		err:   err,
+		msg:   msg,
+		field: field,
+	}
+}
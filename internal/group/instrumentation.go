@@ -0,0 +1,105 @@
+package group
+
+import (
+	"context"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Instrumentation struct {
+	next   core.GroupService
+	tracer trace.Tracer
+}
+
+func NewInstrumentation(next core.GroupService) *Instrumentation {
+	return &Instrumentation{
+		next:   next,
+		tracer: otel.Tracer("group-service"),
+	}
+}
+
+func (i *Instrumentation) CreateGroup(ctx context.Context, req *core.CreateGroupRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "create-group")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.CreateGroup(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) UpdateGroup(ctx context.Context, req *core.UpdateGroupRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "update-group")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.UpdateGroup(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) DeleteGroup(ctx context.Context, req *core.DeleteGroupRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "delete-group")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.DeleteGroup(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) FindGroupByID(ctx context.Context, req *core.FindGroupByIDRequest) (*core.Group, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-group-by-id")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	group, err := i.next.FindGroupByID(ctx, req)
+	return group, err
+}
+
+func (i *Instrumentation) ListGroups(ctx context.Context, req *core.ListGroupsRequest) ([]core.Group, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "list-groups")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	groups, err := i.next.ListGroups(ctx, req)
+	return groups, err
+}
+
+func (i *Instrumentation) AddGroupMembers(ctx context.Context, req *core.ChangeGroupMembersRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "add-group-members")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.AddGroupMembers(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) RemoveGroupMembers(ctx context.Context, req *core.ChangeGroupMembersRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "remove-group-members")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.RemoveGroupMembers(ctx, req)
+	return err
+}
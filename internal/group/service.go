@@ -0,0 +1,259 @@
+package group
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+type Service struct {
+	groups  core.GroupRepository
+	events  core.EventRepository
+	changes core.EventChangeBroker
+}
+
+func NewService(groups core.GroupRepository, events core.EventRepository, changes core.EventChangeBroker) *Service {
+	return &Service{
+		groups:  groups,
+		events:  events,
+		changes: changes,
+	}
+}
+
+func (s *Service) CreateGroup(ctx context.Context, req *core.CreateGroupRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	req.Group.OwnerID = req.ActorID
+	return s.groups.Store(ctx, req.Group)
+}
+
+func (s *Service) UpdateGroup(ctx context.Context, req *core.UpdateGroupRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	group, err := s.findOwned(ctx, req.ActorID, req.Group.ID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	group.Name = req.Group.Name
+	group.Description = req.Group.Description
+	group.UpdatedAt = &now
+
+	err = group.Validate()
+	if err != nil {
+		return err
+	}
+
+	return s.groups.Update(ctx, group)
+}
+
+// DeleteGroup deletes the group along with the calendar grants given to it. The members keep the
+// invitations they got through it.
+func (s *Service) DeleteGroup(ctx context.Context, req *core.DeleteGroupRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.findOwned(ctx, req.ActorID, req.GroupID)
+	if err != nil {
+		return err
+	}
+
+	return s.groups.DeleteByID(ctx, req.GroupID)
+}
+
+// FindGroupByID returns the group to its owner and to its members.
+func (s *Service) FindGroupByID(ctx context.Context, req *core.FindGroupByIDRequest) (*core.Group, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := s.groups.FindByID(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !group.VisibleTo(req.ActorID) {
+		return nil, core.ErrGroupNotFound
+	}
+	return group, nil
+}
+
+func (s *Service) ListGroups(ctx context.Context, req *core.ListGroupsRequest) ([]core.Group, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.groups.FindByOwner(ctx, req.ActorID)
+}
+
+// AddGroupMembers adds users to the group. When the change is propagated, the new members are
+// also invited to the upcoming events the group was invited to, unless they are already invited,
+// as an update of these events.
+func (s *Service) AddGroupMembers(ctx context.Context, req *core.ChangeGroupMembersRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	group, err := s.findOwned(ctx, req.ActorID, req.GroupID)
+	if err != nil {
+		return err
+	}
+
+	var added []int32
+	for _, userID := range req.UserIDs {
+		if !group.HasMember(userID) {
+			added = append(added, userID)
+		}
+	}
+	if len(group.Members)+len(added) > core.MaxGroupMembers {
+		return internal.WrapFieldErr(internal.ErrValidationFailed, "UserIDs", fmt.Sprintf("a group can't have more than %d members", core.MaxGroupMembers))
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	var updates []core.EventMutation
+	var changes []core.EventChange
+	if req.Propagate {
+		updates, changes, err = s.propagate(ctx, req.ActorID, group.ID, func(event *core.Event) {
+			for _, userID := range added {
+				if event.FindInvitation(userID) != nil {
+					continue
+				}
+
+				invitation := core.NewInvitation(event.ID, userID)
+				invitation.GroupID = group.ID
+				event.Invitations = append(event.Invitations, invitation)
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.groups.AddMembers(ctx, group.ID, added, updates)
+	if err != nil {
+		return err
+	}
+
+	s.committed(changes)
+	return nil
+}
+
+// RemoveGroupMembers removes users from the group. When the change is propagated, the invitations
+// they got through the group to its upcoming events are withdrawn as well, as an update of these
+// events.
+func (s *Service) RemoveGroupMembers(ctx context.Context, req *core.ChangeGroupMembersRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	group, err := s.findOwned(ctx, req.ActorID, req.GroupID)
+	if err != nil {
+		return err
+	}
+
+	var updates []core.EventMutation
+	var changes []core.EventChange
+	if req.Propagate {
+		updates, changes, err = s.propagate(ctx, req.ActorID, group.ID, func(event *core.Event) {
+			var invitations []core.Invitation
+			for _, invitation := range event.Invitations {
+				if invitation.GroupID == group.ID && slices.Contains(req.UserIDs, invitation.UserID) {
+					continue
+				}
+				invitations = append(invitations, invitation)
+			}
+			event.Invitations = invitations
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.groups.RemoveMembers(ctx, group.ID, req.UserIDs, updates)
+	if err != nil {
+		return err
+	}
+
+	s.committed(changes)
+	return nil
+}
+
+// propagate applies the change of the members to the invitations of the upcoming events the group
+// was invited to, and returns the updates of the events whose invitations changed along with their
+// journals. The seats freed up by the withdrawn invitations are given to the first ones on the
+// waitlist.
+func (s *Service) propagate(ctx context.Context, actorID string, groupID string, apply func(event *core.Event)) ([]core.EventMutation, []core.EventChange, error) {
+	eventIDs, err := s.groups.FindUpcomingEventIDs(ctx, groupID, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	var updates []core.EventMutation
+	var changes []core.EventChange
+	for _, eventID := range eventIDs {
+		before, err := s.events.FindByID(ctx, eventID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		after := *before
+		after.Invitations = slices.Clone(before.Invitations)
+		apply(&after)
+		if len(after.Invitations) == len(before.Invitations) {
+			continue
+		}
+
+		after.UpdatedAt = &now
+		promoted := after.KeepInvitations(before, now)
+
+		change := core.NewEventChange(eventID, actorID, core.HistoryOperation_Update, before, &after)
+		updates = append(updates, core.EventMutation{
+			Type:    core.MutationType_Update,
+			Event:   &after,
+			Journal: core.NewPromotedJournal(change, before, &after, promoted),
+		})
+		changes = append(changes, change)
+	}
+	return updates, changes, nil
+}
+
+// committed publishes the committed changes of the events to the change feed.
+func (s *Service) committed(changes []core.EventChange) {
+	for _, change := range changes {
+		s.changes.Publish(change)
+	}
+}
+
+// findOwned returns the group if it belongs to the actor, the groups of other users are reported
+// as not found so that their IDs can't be probed.
+func (s *Service) findOwned(ctx context.Context, actorID string, id string) (*core.Group, error) {
+	group, err := s.groups.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if group.OwnerID != actorID {
+		return nil, core.ErrGroupNotFound
+	}
+	return group, nil
+}
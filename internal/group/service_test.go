@@ -0,0 +1,531 @@
+package group_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/group"
+	"github.com/dzakaammar/event-scheduling-example/internal/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestGroup(ownerID string, members ...int32) *core.Group {
+	g := core.NewGroup(ownerID)
+	g.Name = "Engineering"
+	g.Members = members
+	return g
+}
+
+func TestService_CreateGroup(t *testing.T) {
+	type fields struct {
+		repoMock func(ctrl *gomock.Controller) core.GroupRepository
+	}
+	type args struct {
+		req *core.CreateGroupRequest
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "OK - owned by the actor",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					repo := mock.NewMockGroupRepository(ctrl)
+					repo.EXPECT().Store(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, g *core.Group) error {
+							assert.Equal(t, "1", g.OwnerID)
+							assert.Equal(t, []int32{2, 3}, g.Members)
+							return nil
+						})
+					return repo
+				},
+			},
+			args: args{
+				req: &core.CreateGroupRequest{ActorID: "1", Group: newTestGroup("", 2, 3)},
+			},
+		},
+		{
+			name: "Not OK - duplicated members",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					return mock.NewMockGroupRepository(ctrl)
+				},
+			},
+			args: args{
+				req: &core.CreateGroupRequest{ActorID: "1", Group: newTestGroup("1", 2, 2)},
+			},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - error from repo",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					repo := mock.NewMockGroupRepository(ctrl)
+					repo.EXPECT().Store(gomock.Any(), gomock.Any()).Return(errors.New("error")) //nolint:goerr113
+					return repo
+				},
+			},
+			args: args{
+				req: &core.CreateGroupRequest{ActorID: "1", Group: newTestGroup("")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			err := group.NewService(tt.fields.repoMock(ctrl), mock.NewMockEventRepository(ctrl), mock.NewMockEventChangeBroker(ctrl)).CreateGroup(context.Background(), tt.args.req)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_UpdateGroup(t *testing.T) {
+	type fields struct {
+		repoMock func(ctrl *gomock.Controller) core.GroupRepository
+	}
+	type args struct {
+		req *core.UpdateGroupRequest
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		wantErrIs error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					repo := mock.NewMockGroupRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "group1").Return(newTestGroup("1", 2), nil)
+					repo.EXPECT().Update(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, g *core.Group) error {
+							assert.Equal(t, "Platform", g.Name)
+							assert.NotNil(t, g.UpdatedAt)
+							return nil
+						})
+					return repo
+				},
+			},
+			args: args{
+				req: &core.UpdateGroupRequest{ActorID: "1", Group: &core.Group{ID: "group1", Name: "Platform"}},
+			},
+		},
+		{
+			name: "Not OK - a member can't update the group",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					repo := mock.NewMockGroupRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "group1").Return(newTestGroup("1", 2), nil)
+					return repo
+				},
+			},
+			args: args{
+				req: &core.UpdateGroupRequest{ActorID: "2", Group: &core.Group{ID: "group1", Name: "Platform"}},
+			},
+			wantErrIs: core.ErrGroupNotFound,
+		},
+		{
+			name: "Not OK - empty name",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					repo := mock.NewMockGroupRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "group1").Return(newTestGroup("1", 2), nil)
+					return repo
+				},
+			},
+			args: args{
+				req: &core.UpdateGroupRequest{ActorID: "1", Group: &core.Group{ID: "group1"}},
+			},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			err := group.NewService(tt.fields.repoMock(ctrl), mock.NewMockEventRepository(ctrl), mock.NewMockEventChangeBroker(ctrl)).UpdateGroup(context.Background(), tt.args.req)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_FindGroupByID(t *testing.T) {
+	type fields struct {
+		repoMock func(ctrl *gomock.Controller) core.GroupRepository
+	}
+	type args struct {
+		req *core.FindGroupByIDRequest
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		wantErrIs error
+	}{
+		{
+			name: "OK - a member can see the group",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					repo := mock.NewMockGroupRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "group1").Return(newTestGroup("1", 2), nil)
+					return repo
+				},
+			},
+			args: args{
+				req: &core.FindGroupByIDRequest{ActorID: "2", GroupID: "group1"},
+			},
+		},
+		{
+			name: "Not OK - not a member",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					repo := mock.NewMockGroupRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "group1").Return(newTestGroup("1", 2), nil)
+					return repo
+				},
+			},
+			args: args{
+				req: &core.FindGroupByIDRequest{ActorID: "3", GroupID: "group1"},
+			},
+			wantErrIs: core.ErrGroupNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			got, err := group.NewService(tt.fields.repoMock(ctrl), mock.NewMockEventRepository(ctrl), mock.NewMockEventChangeBroker(ctrl)).FindGroupByID(context.Background(), tt.args.req)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, got)
+		})
+	}
+}
+
+func TestService_AddGroupMembers(t *testing.T) {
+	type fields struct {
+		repoMock    func(ctrl *gomock.Controller) core.GroupRepository
+		eventsMock  func(ctrl *gomock.Controller) core.EventRepository
+		changesMock func(ctrl *gomock.Controller) core.EventChangeBroker
+	}
+	type args struct {
+		req *core.ChangeGroupMembersRequest
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "OK - only the new members are added",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					repo := mock.NewMockGroupRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "group1").Return(newTestGroup("1", 2), nil)
+					repo.EXPECT().AddMembers(gomock.Any(), gomock.Any(), []int32{3}, nil).Return(nil)
+					return repo
+				},
+			},
+			args: args{
+				req: &core.ChangeGroupMembersRequest{ActorID: "1", GroupID: "group1", UserIDs: []int32{2, 3}},
+			},
+		},
+		{
+			name: "OK - propagated to the upcoming events",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					repo := mock.NewMockGroupRepository(ctrl)
+					g := newTestGroup("1", 2)
+					g.ID = "group1"
+					repo.EXPECT().FindByID(gomock.Any(), "group1").Return(g, nil)
+					repo.EXPECT().FindUpcomingEventIDs(gomock.Any(), "group1", gomock.Any()).Return([]string{"event1", "event2"}, nil)
+					repo.EXPECT().AddMembers(gomock.Any(), "group1", []int32{3}, gomock.Any()).
+						DoAndReturn(func(_ context.Context, _ string, _ []int32, updates []core.EventMutation) error {
+							// the new member is already invited to the second event
+							assert.Len(t, updates, 1)
+							event := updates[0].Event
+							assert.Equal(t, "event1", event.ID)
+							assert.NotNil(t, event.UpdatedAt)
+							assert.Len(t, event.Invitations, 2)
+							assert.Equal(t, int32(3), event.Invitations[1].UserID)
+							assert.Equal(t, "group1", event.Invitations[1].GroupID)
+							assert.Equal(t, core.HistoryOperation_Update, updates[0].Journal.History.Operation)
+							assert.Equal(t, "1", updates[0].Journal.History.ActorID)
+							assert.Len(t, updates[0].Journal.Outbox, 1)
+							return nil
+						})
+					return repo
+				},
+				eventsMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "event1").Return(&core.Event{
+						ID:          "event1",
+						CreatedBy:   "5",
+						Invitations: []core.Invitation{{ID: "inv1", EventID: "event1", UserID: 2, GroupID: "group1"}},
+					}, nil)
+					repo.EXPECT().FindByID(gomock.Any(), "event2").Return(&core.Event{
+						ID:          "event2",
+						CreatedBy:   "5",
+						Invitations: []core.Invitation{{ID: "inv2", EventID: "event2", UserID: 3}},
+					}, nil)
+					return repo
+				},
+				changesMock: func(ctrl *gomock.Controller) core.EventChangeBroker {
+					changes := mock.NewMockEventChangeBroker(ctrl)
+					changes.EXPECT().Publish(gomock.Any()).Times(1).Do(func(change core.EventChange) {
+						assert.Equal(t, "event1", change.EventID)
+					})
+					return changes
+				},
+			},
+			args: args{
+				req: &core.ChangeGroupMembersRequest{ActorID: "1", GroupID: "group1", UserIDs: []int32{3}, Propagate: true},
+			},
+		},
+		{
+			name: "Not OK - error from repo, nothing is published",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					repo := mock.NewMockGroupRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "group1").Return(newTestGroup("1", 2), nil)
+					repo.EXPECT().FindUpcomingEventIDs(gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{"event1"}, nil)
+					repo.EXPECT().AddMembers(gomock.Any(), gomock.Any(), []int32{3}, gomock.Any()).Return(errors.New("error")) //nolint:goerr113
+					return repo
+				},
+				eventsMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "event1").Return(&core.Event{ID: "event1"}, nil)
+					return repo
+				},
+			},
+			args: args{
+				req: &core.ChangeGroupMembersRequest{ActorID: "1", GroupID: "group1", UserIDs: []int32{3}, Propagate: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "OK - nothing to add",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					repo := mock.NewMockGroupRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "group1").Return(newTestGroup("1", 2), nil)
+					return repo
+				},
+			},
+			args: args{
+				req: &core.ChangeGroupMembersRequest{ActorID: "1", GroupID: "group1", UserIDs: []int32{2}, Propagate: true},
+			},
+		},
+		{
+			name: "Not OK - too many members",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					repo := mock.NewMockGroupRepository(ctrl)
+					members := make([]int32, core.MaxGroupMembers)
+					for index := range members {
+						members[index] = int32(index + 10)
+					}
+					repo.EXPECT().FindByID(gomock.Any(), "group1").Return(newTestGroup("1", members...), nil)
+					return repo
+				},
+			},
+			args: args{
+				req: &core.ChangeGroupMembersRequest{ActorID: "1", GroupID: "group1", UserIDs: []int32{2}},
+			},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - not the owner",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					repo := mock.NewMockGroupRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "group1").Return(newTestGroup("1", 2), nil)
+					return repo
+				},
+			},
+			args: args{
+				req: &core.ChangeGroupMembersRequest{ActorID: "2", GroupID: "group1", UserIDs: []int32{3}},
+			},
+			wantErrIs: core.ErrGroupNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			err := group.NewService(tt.fields.repoMock(ctrl), eventsMock(ctrl, tt.fields.eventsMock), changesMock(ctrl, tt.fields.changesMock)).AddGroupMembers(context.Background(), tt.args.req)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_RemoveGroupMembers(t *testing.T) {
+	type fields struct {
+		repoMock    func(ctrl *gomock.Controller) core.GroupRepository
+		eventsMock  func(ctrl *gomock.Controller) core.EventRepository
+		changesMock func(ctrl *gomock.Controller) core.EventChangeBroker
+	}
+	type args struct {
+		req *core.ChangeGroupMembersRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "OK - the invitations are kept",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					repo := mock.NewMockGroupRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "group1").Return(newTestGroup("1", 2), nil)
+					repo.EXPECT().RemoveMembers(gomock.Any(), gomock.Any(), []int32{2}, nil).Return(nil)
+					return repo
+				},
+			},
+			args: args{
+				req: &core.ChangeGroupMembersRequest{ActorID: "1", GroupID: "group1", UserIDs: []int32{2}},
+			},
+		},
+		{
+			name: "OK - propagated to the upcoming events, the waitlist is promoted",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					repo := mock.NewMockGroupRepository(ctrl)
+					g := newTestGroup("1", 2)
+					g.ID = "group1"
+					repo.EXPECT().FindByID(gomock.Any(), "group1").Return(g, nil)
+					repo.EXPECT().FindUpcomingEventIDs(gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{"event1", "event2"}, nil)
+					repo.EXPECT().RemoveMembers(gomock.Any(), gomock.Any(), []int32{2}, gomock.Any()).
+						DoAndReturn(func(_ context.Context, _ string, _ []int32, updates []core.EventMutation) error {
+							// the member was invited to the second event on their own
+							assert.Len(t, updates, 1)
+							event := updates[0].Event
+							assert.Equal(t, "event1", event.ID)
+							assert.Len(t, event.Invitations, 1)
+							assert.Equal(t, int32(3), event.Invitations[0].UserID)
+							assert.Equal(t, core.InvitationStatus_Confirmed, event.Invitations[0].Status)
+							assert.Nil(t, event.Invitations[0].WaitlistedAt)
+
+							outbox := updates[0].Journal.Outbox
+							assert.Len(t, outbox, 2)
+							assert.Equal(t, core.DomainEventType_WaitlistPromoted, outbox[1].Type)
+							return nil
+						})
+					return repo
+				},
+				eventsMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					waitlistedAt := time.Now().Add(-time.Hour)
+					repo.EXPECT().FindByID(gomock.Any(), "event1").Return(&core.Event{
+						ID:        "event1",
+						CreatedBy: "5",
+						Capacity:  1,
+						Invitations: []core.Invitation{
+							{ID: "inv1", EventID: "event1", UserID: 2, GroupID: "group1", Status: core.InvitationStatus_Confirmed},
+							{ID: "inv2", EventID: "event1", UserID: 3, Status: core.InvitationStatus_Waitlisted, WaitlistedAt: &waitlistedAt},
+						},
+					}, nil)
+					repo.EXPECT().FindByID(gomock.Any(), "event2").Return(&core.Event{
+						ID:          "event2",
+						CreatedBy:   "5",
+						Invitations: []core.Invitation{{ID: "inv3", EventID: "event2", UserID: 2}},
+					}, nil)
+					return repo
+				},
+				changesMock: func(ctrl *gomock.Controller) core.EventChangeBroker {
+					changes := mock.NewMockEventChangeBroker(ctrl)
+					changes.EXPECT().Publish(gomock.Any()).Times(1)
+					return changes
+				},
+			},
+			args: args{
+				req: &core.ChangeGroupMembersRequest{ActorID: "1", GroupID: "group1", UserIDs: []int32{2}, Propagate: true},
+			},
+		},
+		{
+			name: "Not OK - error from repo",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					repo := mock.NewMockGroupRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "group1").Return(newTestGroup("1", 2), nil)
+					repo.EXPECT().FindUpcomingEventIDs(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("error")) //nolint:goerr113
+					return repo
+				},
+			},
+			args: args{
+				req: &core.ChangeGroupMembersRequest{ActorID: "1", GroupID: "group1", UserIDs: []int32{2}, Propagate: true},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			err := group.NewService(tt.fields.repoMock(ctrl), eventsMock(ctrl, tt.fields.eventsMock), changesMock(ctrl, tt.fields.changesMock)).RemoveGroupMembers(context.Background(), tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func eventsMock(ctrl *gomock.Controller, fn func(ctrl *gomock.Controller) core.EventRepository) core.EventRepository {
+	if fn == nil {
+		return mock.NewMockEventRepository(ctrl)
+	}
+	return fn(ctrl)
+}
+
+func changesMock(ctrl *gomock.Controller, fn func(ctrl *gomock.Controller) core.EventChangeBroker) core.EventChangeBroker {
+	if fn == nil {
+		return mock.NewMockEventChangeBroker(ctrl)
+	}
+	return fn(ctrl)
+}
@@ -0,0 +1,165 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type Status string
+
+const (
+	Status_Unknown    Status = "UNKNOWN"
+	Status_Serving    Status = "SERVING"
+	Status_NotServing Status = "NOT_SERVING"
+)
+
+// Probe checks one dependency of the server, i.e: the database.
+type Probe interface {
+	Probe(ctx context.Context) error
+}
+
+type ProbeFunc func(ctx context.Context) error
+
+func (f ProbeFunc) Probe(ctx context.Context) error {
+	return f(ctx)
+}
+
+const (
+	defaultCheckInterval = 10 * time.Second
+	probeTimeout         = 3 * time.Second
+)
+
+// Monitor runs the probes periodically. The server is serving only when all of them pass.
+type Monitor struct {
+	probes   map[string]Probe
+	interval time.Duration
+
+	mu       sync.RWMutex
+	status   Status
+	shutdown bool
+	watchers map[chan Status]struct{}
+}
+
+// NewMonitor creates a monitor of the given named probes. A monitor without probes is always serving,
+// otherwise the status is unknown until the first check.
+func NewMonitor(probes map[string]Probe, interval time.Duration) *Monitor {
+	if interval <= 0 {
+		interval = defaultCheckInterval
+	}
+
+	status := Status_Unknown
+	if len(probes) == 0 {
+		status = Status_Serving
+	}
+
+	return &Monitor{
+		probes:   probes,
+		interval: interval,
+		status:   status,
+		watchers: make(map[chan Status]struct{}),
+	}
+}
+
+// Run checks right away and then on every interval, until ctx is done.
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check runs all the probes and updates the status accordingly.
+func (m *Monitor) Check(ctx context.Context) Status {
+	status := Status_Serving
+	for name, probe := range m.probes {
+		probeCtx, cancel := context.WithTimeout(ctx, probeTimeout)
+		err := probe.Probe(probeCtx)
+		cancel()
+
+		if err != nil {
+			slog.Warn("health probe failed", "probe", name, "error", err)
+			status = Status_NotServing
+		}
+	}
+
+	m.set(status)
+	return m.Status()
+}
+
+func (m *Monitor) Status() Status {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.status
+}
+
+// Shutdown reports the server as not serving from now on, so that clients stop sending requests
+// before it stops. The watches end after they get the last status.
+func (m *Monitor) Shutdown() {
+	m.set(Status_NotServing)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.shutdown = true
+	for ch := range m.watchers {
+		delete(m.watchers, ch)
+		close(ch)
+	}
+}
+
+// Watch sends the current status, and then every change of it until ctx is done or the monitor
+// is shut down. A slow receiver only gets the latest status.
+func (m *Monitor) Watch(ctx context.Context) <-chan Status {
+	ch := make(chan Status, 1)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ch <- m.status
+	if m.shutdown {
+		close(ch)
+		return ch
+	}
+	m.watchers[ch] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if _, ok := m.watchers[ch]; ok {
+			delete(m.watchers, ch)
+			close(ch)
+		}
+	}()
+
+	return ch
+}
+
+func (m *Monitor) set(status Status) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.shutdown || m.status == status {
+		return
+	}
+	m.status = status
+
+	for ch := range m.watchers {
+		// drop the status the receiver hasn't picked up yet, it is outdated now
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- status
+	}
+}
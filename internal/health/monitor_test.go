@@ -0,0 +1,92 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errProbe = errors.New("unreachable") //nolint:goerr113
+
+func TestMonitor_Check(t *testing.T) {
+	tests := []struct {
+		name   string
+		probes map[string]health.Probe
+		want   health.Status
+	}{
+		{
+			name: "all probes pass",
+			probes: map[string]health.Probe{
+				"db":     health.ProbeFunc(func(context.Context) error { return nil }),
+				"schema": health.ProbeFunc(func(context.Context) error { return nil }),
+			},
+			want: health.Status_Serving,
+		},
+		{
+			name: "one probe fails",
+			probes: map[string]health.Probe{
+				"db":     health.ProbeFunc(func(context.Context) error { return errProbe }),
+				"schema": health.ProbeFunc(func(context.Context) error { return nil }),
+			},
+			want: health.Status_NotServing,
+		},
+		{
+			name: "no probes",
+			want: health.Status_Serving,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := health.NewMonitor(tt.probes, time.Second)
+			assert.Equal(t, tt.want, m.Check(context.Background()))
+			assert.Equal(t, tt.want, m.Status())
+		})
+	}
+}
+
+func TestMonitor_Watch(t *testing.T) {
+	var probeErr error
+	m := health.NewMonitor(map[string]health.Probe{
+		"db": health.ProbeFunc(func(context.Context) error { return probeErr }),
+	}, time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watch := m.Watch(ctx)
+	assert.Equal(t, health.Status_Unknown, <-watch)
+
+	m.Check(context.Background())
+	assert.Equal(t, health.Status_Serving, <-watch)
+
+	// an unchanged status is not sent again
+	m.Check(context.Background())
+	probeErr = errProbe
+	m.Check(context.Background())
+	assert.Equal(t, health.Status_NotServing, <-watch)
+
+	cancel()
+	require.Eventually(t, func() bool {
+		_, ok := <-watch
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestMonitor_Shutdown(t *testing.T) {
+	m := health.NewMonitor(nil, time.Second)
+	watch := m.Watch(context.Background())
+	assert.Equal(t, health.Status_Serving, <-watch)
+
+	m.Shutdown()
+	assert.Equal(t, health.Status_NotServing, <-watch)
+	_, ok := <-watch
+	assert.False(t, ok, "the watch must end on shutdown")
+
+	// probes don't bring a server that is shutting down back
+	m.Check(context.Background())
+	assert.Equal(t, health.Status_NotServing, m.Status())
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dzakaammar/event-scheduling-example/internal/core (interfaces: AgendaRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	core "github.com/dzakaammar/event-scheduling-example/internal/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAgendaRepository is a mock of AgendaRepository interface.
+type MockAgendaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgendaRepositoryMockRecorder
+}
+
+// MockAgendaRepositoryMockRecorder is the mock recorder for MockAgendaRepository.
+type MockAgendaRepositoryMockRecorder struct {
+	mock *MockAgendaRepository
+}
+
+// NewMockAgendaRepository creates a new mock instance.
+func NewMockAgendaRepository(ctrl *gomock.Controller) *MockAgendaRepository {
+	mock := &MockAgendaRepository{ctrl: ctrl}
+	mock.recorder = &MockAgendaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgendaRepository) EXPECT() *MockAgendaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDueDeliveries mocks base method.
+func (m *MockAgendaRepository) ClaimDueDeliveries(arg0 context.Context, arg1, arg2 time.Time, arg3 int) ([]core.AgendaDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueDeliveries", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]core.AgendaDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueDeliveries indicates an expected call of ClaimDueDeliveries.
+func (mr *MockAgendaRepositoryMockRecorder) ClaimDueDeliveries(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueDeliveries", reflect.TypeOf((*MockAgendaRepository)(nil).ClaimDueDeliveries), arg0, arg1, arg2, arg3)
+}
+
+// ScheduleDue mocks base method.
+func (m *MockAgendaRepository) ScheduleDue(arg0 context.Context, arg1 time.Time, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleDue", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleDue indicates an expected call of ScheduleDue.
+func (mr *MockAgendaRepositoryMockRecorder) ScheduleDue(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleDue", reflect.TypeOf((*MockAgendaRepository)(nil).ScheduleDue), arg0, arg1, arg2)
+}
+
+// UpdateDelivery mocks base method.
+func (m *MockAgendaRepository) UpdateDelivery(arg0 context.Context, arg1 *core.AgendaDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelivery", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDelivery indicates an expected call of UpdateDelivery.
+func (mr *MockAgendaRepositoryMockRecorder) UpdateDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelivery", reflect.TypeOf((*MockAgendaRepository)(nil).UpdateDelivery), arg0, arg1)
+}
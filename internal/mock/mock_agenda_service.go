@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dzakaammar/event-scheduling-example/internal/core (interfaces: AgendaService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/dzakaammar/event-scheduling-example/internal/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAgendaService is a mock of AgendaService interface.
+type MockAgendaService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgendaServiceMockRecorder
+}
+
+// MockAgendaServiceMockRecorder is the mock recorder for MockAgendaService.
+type MockAgendaServiceMockRecorder struct {
+	mock *MockAgendaService
+}
+
+// NewMockAgendaService creates a new mock instance.
+func NewMockAgendaService(ctrl *gomock.Controller) *MockAgendaService {
+	mock := &MockAgendaService{ctrl: ctrl}
+	mock.recorder = &MockAgendaServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgendaService) EXPECT() *MockAgendaServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAgenda mocks base method.
+func (m *MockAgendaService) GetAgenda(arg0 context.Context, arg1 *core.GetAgendaRequest) (*core.Agenda, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAgenda", arg0, arg1)
+	ret0, _ := ret[0].(*core.Agenda)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAgenda indicates an expected call of GetAgenda.
+func (mr *MockAgendaServiceMockRecorder) GetAgenda(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAgenda", reflect.TypeOf((*MockAgendaService)(nil).GetAgenda), arg0, arg1)
+}
@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dzakaammar/event-scheduling-example/internal/core (interfaces: BookingPageRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	core "github.com/dzakaammar/event-scheduling-example/internal/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBookingPageRepository is a mock of BookingPageRepository interface.
+type MockBookingPageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookingPageRepositoryMockRecorder
+}
+
+// MockBookingPageRepositoryMockRecorder is the mock recorder for MockBookingPageRepository.
+type MockBookingPageRepositoryMockRecorder struct {
+	mock *MockBookingPageRepository
+}
+
+// NewMockBookingPageRepository creates a new mock instance.
+func NewMockBookingPageRepository(ctrl *gomock.Controller) *MockBookingPageRepository {
+	mock := &MockBookingPageRepository{ctrl: ctrl}
+	mock.recorder = &MockBookingPageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookingPageRepository) EXPECT() *MockBookingPageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Book mocks base method.
+func (m *MockBookingPageRepository) Book(arg0 context.Context, arg1 *core.BookingPage, arg2 *core.Booking, arg3 *core.Event, arg4 core.Journal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Book", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Book indicates an expected call of Book.
+func (mr *MockBookingPageRepositoryMockRecorder) Book(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Book", reflect.TypeOf((*MockBookingPageRepository)(nil).Book), arg0, arg1, arg2, arg3, arg4)
+}
+
+// DeleteByID mocks base method.
+func (m *MockBookingPageRepository) DeleteByID(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockBookingPageRepositoryMockRecorder) DeleteByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockBookingPageRepository)(nil).DeleteByID), arg0, arg1)
+}
+
+// FindBookedStarts mocks base method.
+func (m *MockBookingPageRepository) FindBookedStarts(arg0 context.Context, arg1 string, arg2, arg3 time.Time) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBookedStarts", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBookedStarts indicates an expected call of FindBookedStarts.
+func (mr *MockBookingPageRepositoryMockRecorder) FindBookedStarts(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBookedStarts", reflect.TypeOf((*MockBookingPageRepository)(nil).FindBookedStarts), arg0, arg1, arg2, arg3)
+}
+
+// FindBusySchedules mocks base method.
+func (m *MockBookingPageRepository) FindBusySchedules(arg0 context.Context, arg1 string, arg2, arg3 time.Time) ([]core.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBusySchedules", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]core.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBusySchedules indicates an expected call of FindBusySchedules.
+func (mr *MockBookingPageRepositoryMockRecorder) FindBusySchedules(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBusySchedules", reflect.TypeOf((*MockBookingPageRepository)(nil).FindBusySchedules), arg0, arg1, arg2, arg3)
+}
+
+// FindByID mocks base method.
+func (m *MockBookingPageRepository) FindByID(arg0 context.Context, arg1 string) (*core.BookingPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*core.BookingPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockBookingPageRepositoryMockRecorder) FindByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockBookingPageRepository)(nil).FindByID), arg0, arg1)
+}
+
+// FindByOwner mocks base method.
+func (m *MockBookingPageRepository) FindByOwner(arg0 context.Context, arg1 string) ([]core.BookingPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByOwner", arg0, arg1)
+	ret0, _ := ret[0].([]core.BookingPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByOwner indicates an expected call of FindByOwner.
+func (mr *MockBookingPageRepositoryMockRecorder) FindByOwner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOwner", reflect.TypeOf((*MockBookingPageRepository)(nil).FindByOwner), arg0, arg1)
+}
+
+// Store mocks base method.
+func (m *MockBookingPageRepository) Store(arg0 context.Context, arg1 *core.BookingPage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Store indicates an expected call of Store.
+func (mr *MockBookingPageRepositoryMockRecorder) Store(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockBookingPageRepository)(nil).Store), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockBookingPageRepository) Update(arg0 context.Context, arg1 *core.BookingPage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBookingPageRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookingPageRepository)(nil).Update), arg0, arg1)
+}
@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dzakaammar/event-scheduling-example/internal/core (interfaces: BookingService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/dzakaammar/event-scheduling-example/internal/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBookingService is a mock of BookingService interface.
+type MockBookingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookingServiceMockRecorder
+}
+
+// MockBookingServiceMockRecorder is the mock recorder for MockBookingService.
+type MockBookingServiceMockRecorder struct {
+	mock *MockBookingService
+}
+
+// NewMockBookingService creates a new mock instance.
+func NewMockBookingService(ctrl *gomock.Controller) *MockBookingService {
+	mock := &MockBookingService{ctrl: ctrl}
+	mock.recorder = &MockBookingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookingService) EXPECT() *MockBookingServiceMockRecorder {
+	return m.recorder
+}
+
+// BookSlot mocks base method.
+func (m *MockBookingService) BookSlot(arg0 context.Context, arg1 *core.BookSlotRequest) (*core.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BookSlot", arg0, arg1)
+	ret0, _ := ret[0].(*core.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BookSlot indicates an expected call of BookSlot.
+func (mr *MockBookingServiceMockRecorder) BookSlot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BookSlot", reflect.TypeOf((*MockBookingService)(nil).BookSlot), arg0, arg1)
+}
+
+// CreateBookingPage mocks base method.
+func (m *MockBookingService) CreateBookingPage(arg0 context.Context, arg1 *core.CreateBookingPageRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBookingPage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBookingPage indicates an expected call of CreateBookingPage.
+func (mr *MockBookingServiceMockRecorder) CreateBookingPage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBookingPage", reflect.TypeOf((*MockBookingService)(nil).CreateBookingPage), arg0, arg1)
+}
+
+// DeleteBookingPage mocks base method.
+func (m *MockBookingService) DeleteBookingPage(arg0 context.Context, arg1 *core.DeleteBookingPageRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBookingPage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBookingPage indicates an expected call of DeleteBookingPage.
+func (mr *MockBookingServiceMockRecorder) DeleteBookingPage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBookingPage", reflect.TypeOf((*MockBookingService)(nil).DeleteBookingPage), arg0, arg1)
+}
+
+// FindBookingPageByID mocks base method.
+func (m *MockBookingService) FindBookingPageByID(arg0 context.Context, arg1 *core.FindBookingPageByIDRequest) (*core.BookingPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBookingPageByID", arg0, arg1)
+	ret0, _ := ret[0].(*core.BookingPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBookingPageByID indicates an expected call of FindBookingPageByID.
+func (mr *MockBookingServiceMockRecorder) FindBookingPageByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBookingPageByID", reflect.TypeOf((*MockBookingService)(nil).FindBookingPageByID), arg0, arg1)
+}
+
+// ListBookingPages mocks base method.
+func (m *MockBookingService) ListBookingPages(arg0 context.Context, arg1 *core.ListBookingPagesRequest) ([]core.BookingPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBookingPages", arg0, arg1)
+	ret0, _ := ret[0].([]core.BookingPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBookingPages indicates an expected call of ListBookingPages.
+func (mr *MockBookingServiceMockRecorder) ListBookingPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBookingPages", reflect.TypeOf((*MockBookingService)(nil).ListBookingPages), arg0, arg1)
+}
+
+// ListSlots mocks base method.
+func (m *MockBookingService) ListSlots(arg0 context.Context, arg1 *core.ListSlotsRequest) ([]core.Slot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSlots", arg0, arg1)
+	ret0, _ := ret[0].([]core.Slot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSlots indicates an expected call of ListSlots.
+func (mr *MockBookingServiceMockRecorder) ListSlots(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSlots", reflect.TypeOf((*MockBookingService)(nil).ListSlots), arg0, arg1)
+}
+
+// UpdateBookingPage mocks base method.
+func (m *MockBookingService) UpdateBookingPage(arg0 context.Context, arg1 *core.UpdateBookingPageRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBookingPage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBookingPage indicates an expected call of UpdateBookingPage.
+func (mr *MockBookingServiceMockRecorder) UpdateBookingPage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBookingPage", reflect.TypeOf((*MockBookingService)(nil).UpdateBookingPage), arg0, arg1)
+}
@@ -0,0 +1,195 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dzakaammar/event-scheduling-example/internal/core (interfaces: CalendarRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/dzakaammar/event-scheduling-example/internal/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCalendarRepository is a mock of CalendarRepository interface.
+type MockCalendarRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarRepositoryMockRecorder
+}
+
+// MockCalendarRepositoryMockRecorder is the mock recorder for MockCalendarRepository.
+type MockCalendarRepositoryMockRecorder struct {
+	mock *MockCalendarRepository
+}
+
+// NewMockCalendarRepository creates a new mock instance.
+func NewMockCalendarRepository(ctrl *gomock.Controller) *MockCalendarRepository {
+	mock := &MockCalendarRepository{ctrl: ctrl}
+	mock.recorder = &MockCalendarRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarRepository) EXPECT() *MockCalendarRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteByID mocks base method.
+func (m *MockCalendarRepository) DeleteByID(arg0 context.Context, arg1 string, arg2 func(*core.Event, *core.Event) core.Journal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockCalendarRepositoryMockRecorder) DeleteByID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockCalendarRepository)(nil).DeleteByID), arg0, arg1, arg2)
+}
+
+// FindByID mocks base method.
+func (m *MockCalendarRepository) FindByID(arg0 context.Context, arg1 string) (*core.Calendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*core.Calendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockCalendarRepositoryMockRecorder) FindByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockCalendarRepository)(nil).FindByID), arg0, arg1)
+}
+
+// FindByOwner mocks base method.
+func (m *MockCalendarRepository) FindByOwner(arg0 context.Context, arg1 string) ([]core.Calendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByOwner", arg0, arg1)
+	ret0, _ := ret[0].([]core.Calendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByOwner indicates an expected call of FindByOwner.
+func (mr *MockCalendarRepositoryMockRecorder) FindByOwner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOwner", reflect.TypeOf((*MockCalendarRepository)(nil).FindByOwner), arg0, arg1)
+}
+
+// FindGrants mocks base method.
+func (m *MockCalendarRepository) FindGrants(arg0 context.Context, arg1 string) ([]core.CalendarGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindGrants", arg0, arg1)
+	ret0, _ := ret[0].([]core.CalendarGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindGrants indicates an expected call of FindGrants.
+func (mr *MockCalendarRepositoryMockRecorder) FindGrants(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindGrants", reflect.TypeOf((*MockCalendarRepository)(nil).FindGrants), arg0, arg1)
+}
+
+// FindRole mocks base method.
+func (m *MockCalendarRepository) FindRole(arg0 context.Context, arg1, arg2 string) (core.CalendarRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRole", arg0, arg1, arg2)
+	ret0, _ := ret[0].(core.CalendarRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRole indicates an expected call of FindRole.
+func (mr *MockCalendarRepositoryMockRecorder) FindRole(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRole", reflect.TypeOf((*MockCalendarRepository)(nil).FindRole), arg0, arg1, arg2)
+}
+
+// FindSharedWith mocks base method.
+func (m *MockCalendarRepository) FindSharedWith(arg0 context.Context, arg1 string) ([]core.Calendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSharedWith", arg0, arg1)
+	ret0, _ := ret[0].([]core.Calendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSharedWith indicates an expected call of FindSharedWith.
+func (mr *MockCalendarRepositoryMockRecorder) FindSharedWith(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSharedWith", reflect.TypeOf((*MockCalendarRepository)(nil).FindSharedWith), arg0, arg1)
+}
+
+// Grant mocks base method.
+func (m *MockCalendarRepository) Grant(arg0 context.Context, arg1 *core.CalendarGrant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Grant", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Grant indicates an expected call of Grant.
+func (mr *MockCalendarRepositoryMockRecorder) Grant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Grant", reflect.TypeOf((*MockCalendarRepository)(nil).Grant), arg0, arg1)
+}
+
+// MoveEvent mocks base method.
+func (m *MockCalendarRepository) MoveEvent(arg0 context.Context, arg1 *core.Event, arg2 core.Journal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveEvent indicates an expected call of MoveEvent.
+func (mr *MockCalendarRepositoryMockRecorder) MoveEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveEvent", reflect.TypeOf((*MockCalendarRepository)(nil).MoveEvent), arg0, arg1, arg2)
+}
+
+// Revoke mocks base method.
+func (m *MockCalendarRepository) Revoke(arg0 context.Context, arg1 string, arg2 core.GranteeType, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockCalendarRepositoryMockRecorder) Revoke(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockCalendarRepository)(nil).Revoke), arg0, arg1, arg2, arg3)
+}
+
+// Store mocks base method.
+func (m *MockCalendarRepository) Store(arg0 context.Context, arg1 *core.Calendar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Store indicates an expected call of Store.
+func (mr *MockCalendarRepositoryMockRecorder) Store(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockCalendarRepository)(nil).Store), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockCalendarRepository) Update(arg0 context.Context, arg1 *core.Calendar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCalendarRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCalendarRepository)(nil).Update), arg0, arg1)
+}
@@ -0,0 +1,165 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dzakaammar/event-scheduling-example/internal/core (interfaces: CalendarService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/dzakaammar/event-scheduling-example/internal/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCalendarService is a mock of CalendarService interface.
+type MockCalendarService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarServiceMockRecorder
+}
+
+// MockCalendarServiceMockRecorder is the mock recorder for MockCalendarService.
+type MockCalendarServiceMockRecorder struct {
+	mock *MockCalendarService
+}
+
+// NewMockCalendarService creates a new mock instance.
+func NewMockCalendarService(ctrl *gomock.Controller) *MockCalendarService {
+	mock := &MockCalendarService{ctrl: ctrl}
+	mock.recorder = &MockCalendarServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarService) EXPECT() *MockCalendarServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateCalendar mocks base method.
+func (m *MockCalendarService) CreateCalendar(arg0 context.Context, arg1 *core.CreateCalendarRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCalendar", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCalendar indicates an expected call of CreateCalendar.
+func (mr *MockCalendarServiceMockRecorder) CreateCalendar(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCalendar", reflect.TypeOf((*MockCalendarService)(nil).CreateCalendar), arg0, arg1)
+}
+
+// DeleteCalendar mocks base method.
+func (m *MockCalendarService) DeleteCalendar(arg0 context.Context, arg1 *core.DeleteCalendarRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCalendar", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCalendar indicates an expected call of DeleteCalendar.
+func (mr *MockCalendarServiceMockRecorder) DeleteCalendar(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCalendar", reflect.TypeOf((*MockCalendarService)(nil).DeleteCalendar), arg0, arg1)
+}
+
+// FindCalendarByID mocks base method.
+func (m *MockCalendarService) FindCalendarByID(arg0 context.Context, arg1 *core.FindCalendarByIDRequest) (*core.Calendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCalendarByID", arg0, arg1)
+	ret0, _ := ret[0].(*core.Calendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCalendarByID indicates an expected call of FindCalendarByID.
+func (mr *MockCalendarServiceMockRecorder) FindCalendarByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCalendarByID", reflect.TypeOf((*MockCalendarService)(nil).FindCalendarByID), arg0, arg1)
+}
+
+// ListCalendarGrants mocks base method.
+func (m *MockCalendarService) ListCalendarGrants(arg0 context.Context, arg1 *core.ListCalendarGrantsRequest) ([]core.CalendarGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCalendarGrants", arg0, arg1)
+	ret0, _ := ret[0].([]core.CalendarGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCalendarGrants indicates an expected call of ListCalendarGrants.
+func (mr *MockCalendarServiceMockRecorder) ListCalendarGrants(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCalendarGrants", reflect.TypeOf((*MockCalendarService)(nil).ListCalendarGrants), arg0, arg1)
+}
+
+// ListCalendars mocks base method.
+func (m *MockCalendarService) ListCalendars(arg0 context.Context, arg1 *core.ListCalendarsRequest) ([]core.Calendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCalendars", arg0, arg1)
+	ret0, _ := ret[0].([]core.Calendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCalendars indicates an expected call of ListCalendars.
+func (mr *MockCalendarServiceMockRecorder) ListCalendars(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCalendars", reflect.TypeOf((*MockCalendarService)(nil).ListCalendars), arg0, arg1)
+}
+
+// MoveEvent mocks base method.
+func (m *MockCalendarService) MoveEvent(arg0 context.Context, arg1 *core.MoveEventRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveEvent indicates an expected call of MoveEvent.
+func (mr *MockCalendarServiceMockRecorder) MoveEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveEvent", reflect.TypeOf((*MockCalendarService)(nil).MoveEvent), arg0, arg1)
+}
+
+// ShareCalendar mocks base method.
+func (m *MockCalendarService) ShareCalendar(arg0 context.Context, arg1 *core.ShareCalendarRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareCalendar", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShareCalendar indicates an expected call of ShareCalendar.
+func (mr *MockCalendarServiceMockRecorder) ShareCalendar(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareCalendar", reflect.TypeOf((*MockCalendarService)(nil).ShareCalendar), arg0, arg1)
+}
+
+// UnshareCalendar mocks base method.
+func (m *MockCalendarService) UnshareCalendar(arg0 context.Context, arg1 *core.UnshareCalendarRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnshareCalendar", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnshareCalendar indicates an expected call of UnshareCalendar.
+func (mr *MockCalendarServiceMockRecorder) UnshareCalendar(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnshareCalendar", reflect.TypeOf((*MockCalendarService)(nil).UnshareCalendar), arg0, arg1)
+}
+
+// UpdateCalendar mocks base method.
+func (m *MockCalendarService) UpdateCalendar(arg0 context.Context, arg1 *core.UpdateCalendarRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCalendar", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCalendar indicates an expected call of UpdateCalendar.
+func (mr *MockCalendarServiceMockRecorder) UpdateCalendar(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCalendar", reflect.TypeOf((*MockCalendarService)(nil).UpdateCalendar), arg0, arg1)
+}
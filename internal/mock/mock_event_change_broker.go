@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dzakaammar/event-scheduling-example/internal/core (interfaces: EventChangeBroker)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/dzakaammar/event-scheduling-example/internal/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEventChangeBroker is a mock of EventChangeBroker interface.
+type MockEventChangeBroker struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventChangeBrokerMockRecorder
+}
+
+// MockEventChangeBrokerMockRecorder is the mock recorder for MockEventChangeBroker.
+type MockEventChangeBrokerMockRecorder struct {
+	mock *MockEventChangeBroker
+}
+
+// NewMockEventChangeBroker creates a new mock instance.
+func NewMockEventChangeBroker(ctrl *gomock.Controller) *MockEventChangeBroker {
+	mock := &MockEventChangeBroker{ctrl: ctrl}
+	mock.recorder = &MockEventChangeBrokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventChangeBroker) EXPECT() *MockEventChangeBrokerMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockEventChangeBroker) Publish(arg0 core.EventChange) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", arg0)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventChangeBrokerMockRecorder) Publish(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventChangeBroker)(nil).Publish), arg0)
+}
+
+// Subscribe mocks base method.
+func (m *MockEventChangeBroker) Subscribe(arg0 context.Context, arg1 *core.ChangeCursor, arg2 func(core.EventChange) bool) (<-chan core.EventChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", arg0, arg1, arg2)
+	ret0, _ := ret[0].(<-chan core.EventChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockEventChangeBrokerMockRecorder) Subscribe(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockEventChangeBroker)(nil).Subscribe), arg0, arg1, arg2)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dzakaammar/event-scheduling-example/internal/core (interfaces: EventHistoryRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/dzakaammar/event-scheduling-example/internal/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEventHistoryRepository is a mock of EventHistoryRepository interface.
+type MockEventHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventHistoryRepositoryMockRecorder
+}
+
+// MockEventHistoryRepositoryMockRecorder is the mock recorder for MockEventHistoryRepository.
+type MockEventHistoryRepositoryMockRecorder struct {
+	mock *MockEventHistoryRepository
+}
+
+// NewMockEventHistoryRepository creates a new mock instance.
+func NewMockEventHistoryRepository(ctrl *gomock.Controller) *MockEventHistoryRepository {
+	mock := &MockEventHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockEventHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventHistoryRepository) EXPECT() *MockEventHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByEventID mocks base method.
+func (m *MockEventHistoryRepository) FindByEventID(arg0 context.Context, arg1 string, arg2 int64, arg3 int) ([]core.EventHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEventID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]core.EventHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByEventID indicates an expected call of FindByEventID.
+func (mr *MockEventHistoryRepositoryMockRecorder) FindByEventID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEventID", reflect.TypeOf((*MockEventHistoryRepository)(nil).FindByEventID), arg0, arg1, arg2, arg3)
+}
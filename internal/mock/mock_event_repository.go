@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	core "github.com/dzakaammar/event-scheduling-example/internal/core"
 	gomock "github.com/golang/mock/gomock"
@@ -35,18 +36,32 @@ func (m *MockEventRepository) EXPECT() *MockEventRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Batch mocks base method.
+func (m *MockEventRepository) Batch(arg0 context.Context, arg1 []core.EventMutation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Batch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Batch indicates an expected call of Batch.
+func (mr *MockEventRepositoryMockRecorder) Batch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Batch", reflect.TypeOf((*MockEventRepository)(nil).Batch), arg0, arg1)
+}
+
 // DeleteByID mocks base method.
-func (m *MockEventRepository) DeleteByID(arg0 context.Context, arg1 string) error {
+func (m *MockEventRepository) DeleteByID(arg0 context.Context, arg1 string, arg2 core.Journal) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteByID", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteByID", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteByID indicates an expected call of DeleteByID.
-func (mr *MockEventRepositoryMockRecorder) DeleteByID(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockEventRepositoryMockRecorder) DeleteByID(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockEventRepository)(nil).DeleteByID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockEventRepository)(nil).DeleteByID), arg0, arg1, arg2)
 }
 
 // FindByID mocks base method.
@@ -64,30 +79,162 @@ func (mr *MockEventRepositoryMockRecorder) FindByID(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockEventRepository)(nil).FindByID), arg0, arg1)
 }
 
+// FindDeletedByCreator mocks base method.
+func (m *MockEventRepository) FindDeletedByCreator(arg0 context.Context, arg1 string) ([]core.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeletedByCreator", arg0, arg1)
+	ret0, _ := ret[0].([]core.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletedByCreator indicates an expected call of FindDeletedByCreator.
+func (mr *MockEventRepositoryMockRecorder) FindDeletedByCreator(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletedByCreator", reflect.TypeOf((*MockEventRepository)(nil).FindDeletedByCreator), arg0, arg1)
+}
+
+// FindDeletedByID mocks base method.
+func (m *MockEventRepository) FindDeletedByID(arg0 context.Context, arg1 string) (*core.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeletedByID", arg0, arg1)
+	ret0, _ := ret[0].(*core.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletedByID indicates an expected call of FindDeletedByID.
+func (mr *MockEventRepositoryMockRecorder) FindDeletedByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletedByID", reflect.TypeOf((*MockEventRepository)(nil).FindDeletedByID), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockEventRepository) List(arg0 context.Context, arg1 core.EventFilter) ([]core.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]core.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockEventRepositoryMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockEventRepository)(nil).List), arg0, arg1)
+}
+
+// ListInvolved mocks base method.
+func (m *MockEventRepository) ListInvolved(arg0 context.Context, arg1 string, arg2, arg3 time.Time) ([]core.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvolved", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]core.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvolved indicates an expected call of ListInvolved.
+func (mr *MockEventRepositoryMockRecorder) ListInvolved(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvolved", reflect.TypeOf((*MockEventRepository)(nil).ListInvolved), arg0, arg1, arg2, arg3)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockEventRepository) PurgeDeleted(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockEventRepositoryMockRecorder) PurgeDeleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockEventRepository)(nil).PurgeDeleted), arg0, arg1)
+}
+
+// ReplaceReminders mocks base method.
+func (m *MockEventRepository) ReplaceReminders(arg0 context.Context, arg1 string, arg2 int32, arg3 []core.Reminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceReminders", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceReminders indicates an expected call of ReplaceReminders.
+func (mr *MockEventRepositoryMockRecorder) ReplaceReminders(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceReminders", reflect.TypeOf((*MockEventRepository)(nil).ReplaceReminders), arg0, arg1, arg2, arg3)
+}
+
+// RespondInvitation mocks base method.
+func (m *MockEventRepository) RespondInvitation(arg0 context.Context, arg1 string, arg2 func(*core.Event) ([]core.Invitation, core.Journal, error)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RespondInvitation", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RespondInvitation indicates an expected call of RespondInvitation.
+func (mr *MockEventRepositoryMockRecorder) RespondInvitation(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondInvitation", reflect.TypeOf((*MockEventRepository)(nil).RespondInvitation), arg0, arg1, arg2)
+}
+
+// RestoreByID mocks base method.
+func (m *MockEventRepository) RestoreByID(arg0 context.Context, arg1 string, arg2 core.Journal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreByID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreByID indicates an expected call of RestoreByID.
+func (mr *MockEventRepositoryMockRecorder) RestoreByID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreByID", reflect.TypeOf((*MockEventRepository)(nil).RestoreByID), arg0, arg1, arg2)
+}
+
+// Search mocks base method.
+func (m *MockEventRepository) Search(arg0 context.Context, arg1, arg2 string, arg3, arg4 int) ([]core.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]core.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockEventRepositoryMockRecorder) Search(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockEventRepository)(nil).Search), arg0, arg1, arg2, arg3, arg4)
+}
+
 // Store mocks base method.
-func (m *MockEventRepository) Store(arg0 context.Context, arg1 *core.Event) error {
+func (m *MockEventRepository) Store(arg0 context.Context, arg1 *core.Event, arg2 core.Journal) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Store", arg0, arg1)
+	ret := m.ctrl.Call(m, "Store", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Store indicates an expected call of Store.
-func (mr *MockEventRepositoryMockRecorder) Store(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockEventRepositoryMockRecorder) Store(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockEventRepository)(nil).Store), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockEventRepository)(nil).Store), arg0, arg1, arg2)
 }
 
 // Update mocks base method.
-func (m *MockEventRepository) Update(arg0 context.Context, arg1 *core.Event) error {
+func (m *MockEventRepository) Update(arg0 context.Context, arg1 *core.Event, arg2 core.Journal) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockEventRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockEventRepositoryMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEventRepository)(nil).Update), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEventRepository)(nil).Update), arg0, arg1, arg2)
 }
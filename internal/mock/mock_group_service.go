@@ -0,0 +1,136 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dzakaammar/event-scheduling-example/internal/core (interfaces: GroupService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/dzakaammar/event-scheduling-example/internal/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGroupService is a mock of GroupService interface.
+type MockGroupService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupServiceMockRecorder
+}
+
+// MockGroupServiceMockRecorder is the mock recorder for MockGroupService.
+type MockGroupServiceMockRecorder struct {
+	mock *MockGroupService
+}
+
+// NewMockGroupService creates a new mock instance.
+func NewMockGroupService(ctrl *gomock.Controller) *MockGroupService {
+	mock := &MockGroupService{ctrl: ctrl}
+	mock.recorder = &MockGroupServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupService) EXPECT() *MockGroupServiceMockRecorder {
+	return m.recorder
+}
+
+// AddGroupMembers mocks base method.
+func (m *MockGroupService) AddGroupMembers(arg0 context.Context, arg1 *core.ChangeGroupMembersRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGroupMembers", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddGroupMembers indicates an expected call of AddGroupMembers.
+func (mr *MockGroupServiceMockRecorder) AddGroupMembers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGroupMembers", reflect.TypeOf((*MockGroupService)(nil).AddGroupMembers), arg0, arg1)
+}
+
+// CreateGroup mocks base method.
+func (m *MockGroupService) CreateGroup(arg0 context.Context, arg1 *core.CreateGroupRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateGroup indicates an expected call of CreateGroup.
+func (mr *MockGroupServiceMockRecorder) CreateGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockGroupService)(nil).CreateGroup), arg0, arg1)
+}
+
+// DeleteGroup mocks base method.
+func (m *MockGroupService) DeleteGroup(arg0 context.Context, arg1 *core.DeleteGroupRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGroup indicates an expected call of DeleteGroup.
+func (mr *MockGroupServiceMockRecorder) DeleteGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockGroupService)(nil).DeleteGroup), arg0, arg1)
+}
+
+// FindGroupByID mocks base method.
+func (m *MockGroupService) FindGroupByID(arg0 context.Context, arg1 *core.FindGroupByIDRequest) (*core.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindGroupByID", arg0, arg1)
+	ret0, _ := ret[0].(*core.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindGroupByID indicates an expected call of FindGroupByID.
+func (mr *MockGroupServiceMockRecorder) FindGroupByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindGroupByID", reflect.TypeOf((*MockGroupService)(nil).FindGroupByID), arg0, arg1)
+}
+
+// ListGroups mocks base method.
+func (m *MockGroupService) ListGroups(arg0 context.Context, arg1 *core.ListGroupsRequest) ([]core.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroups", arg0, arg1)
+	ret0, _ := ret[0].([]core.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroups indicates an expected call of ListGroups.
+func (mr *MockGroupServiceMockRecorder) ListGroups(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroups", reflect.TypeOf((*MockGroupService)(nil).ListGroups), arg0, arg1)
+}
+
+// RemoveGroupMembers mocks base method.
+func (m *MockGroupService) RemoveGroupMembers(arg0 context.Context, arg1 *core.ChangeGroupMembersRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveGroupMembers", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveGroupMembers indicates an expected call of RemoveGroupMembers.
+func (mr *MockGroupServiceMockRecorder) RemoveGroupMembers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveGroupMembers", reflect.TypeOf((*MockGroupService)(nil).RemoveGroupMembers), arg0, arg1)
+}
+
+// UpdateGroup mocks base method.
+func (m *MockGroupService) UpdateGroup(arg0 context.Context, arg1 *core.UpdateGroupRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGroup indicates an expected call of UpdateGroup.
+func (mr *MockGroupServiceMockRecorder) UpdateGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroup", reflect.TypeOf((*MockGroupService)(nil).UpdateGroup), arg0, arg1)
+}
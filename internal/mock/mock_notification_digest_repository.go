@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dzakaammar/event-scheduling-example/internal/core (interfaces: NotificationDigestRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	core "github.com/dzakaammar/event-scheduling-example/internal/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNotificationDigestRepository is a mock of NotificationDigestRepository interface.
+type MockNotificationDigestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationDigestRepositoryMockRecorder
+}
+
+// MockNotificationDigestRepositoryMockRecorder is the mock recorder for MockNotificationDigestRepository.
+type MockNotificationDigestRepositoryMockRecorder struct {
+	mock *MockNotificationDigestRepository
+}
+
+// NewMockNotificationDigestRepository creates a new mock instance.
+func NewMockNotificationDigestRepository(ctrl *gomock.Controller) *MockNotificationDigestRepository {
+	mock := &MockNotificationDigestRepository{ctrl: ctrl}
+	mock.recorder = &MockNotificationDigestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationDigestRepository) EXPECT() *MockNotificationDigestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDue mocks base method.
+func (m *MockNotificationDigestRepository) ClaimDue(arg0 context.Context, arg1, arg2 time.Time, arg3 int) ([]core.DigestItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDue", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]core.DigestItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDue indicates an expected call of ClaimDue.
+func (mr *MockNotificationDigestRepositoryMockRecorder) ClaimDue(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDue", reflect.TypeOf((*MockNotificationDigestRepository)(nil).ClaimDue), arg0, arg1, arg2, arg3)
+}
+
+// Delete mocks base method.
+func (m *MockNotificationDigestRepository) Delete(arg0 context.Context, arg1 []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockNotificationDigestRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockNotificationDigestRepository)(nil).Delete), arg0, arg1)
+}
+
+// Hold mocks base method.
+func (m *MockNotificationDigestRepository) Hold(arg0 context.Context, arg1 *core.DigestItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hold", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Hold indicates an expected call of Hold.
+func (mr *MockNotificationDigestRepositoryMockRecorder) Hold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hold", reflect.TypeOf((*MockNotificationDigestRepository)(nil).Hold), arg0, arg1)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dzakaammar/event-scheduling-example/internal/core (interfaces: NotificationPreferenceService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/dzakaammar/event-scheduling-example/internal/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNotificationPreferenceService is a mock of NotificationPreferenceService interface.
+type MockNotificationPreferenceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationPreferenceServiceMockRecorder
+}
+
+// MockNotificationPreferenceServiceMockRecorder is the mock recorder for MockNotificationPreferenceService.
+type MockNotificationPreferenceServiceMockRecorder struct {
+	mock *MockNotificationPreferenceService
+}
+
+// NewMockNotificationPreferenceService creates a new mock instance.
+func NewMockNotificationPreferenceService(ctrl *gomock.Controller) *MockNotificationPreferenceService {
+	mock := &MockNotificationPreferenceService{ctrl: ctrl}
+	mock.recorder = &MockNotificationPreferenceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationPreferenceService) EXPECT() *MockNotificationPreferenceServiceMockRecorder {
+	return m.recorder
+}
+
+// GetNotificationPreference mocks base method.
+func (m *MockNotificationPreferenceService) GetNotificationPreference(arg0 context.Context, arg1 *core.GetNotificationPreferenceRequest) (*core.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationPreference", arg0, arg1)
+	ret0, _ := ret[0].(*core.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationPreference indicates an expected call of GetNotificationPreference.
+func (mr *MockNotificationPreferenceServiceMockRecorder) GetNotificationPreference(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationPreference", reflect.TypeOf((*MockNotificationPreferenceService)(nil).GetNotificationPreference), arg0, arg1)
+}
+
+// MuteEvent mocks base method.
+func (m *MockNotificationPreferenceService) MuteEvent(arg0 context.Context, arg1 *core.MuteEventRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MuteEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MuteEvent indicates an expected call of MuteEvent.
+func (mr *MockNotificationPreferenceServiceMockRecorder) MuteEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteEvent", reflect.TypeOf((*MockNotificationPreferenceService)(nil).MuteEvent), arg0, arg1)
+}
+
+// UpdateNotificationPreference mocks base method.
+func (m *MockNotificationPreferenceService) UpdateNotificationPreference(arg0 context.Context, arg1 *core.UpdateNotificationPreferenceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationPreference", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNotificationPreference indicates an expected call of UpdateNotificationPreference.
+func (mr *MockNotificationPreferenceServiceMockRecorder) UpdateNotificationPreference(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationPreference", reflect.TypeOf((*MockNotificationPreferenceService)(nil).UpdateNotificationPreference), arg0, arg1)
+}
@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dzakaammar/event-scheduling-example/internal/core (interfaces: ResourceRepository)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/dzakaammar/event-scheduling-example/internal/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockResourceRepository is a mock of ResourceRepository interface.
+type MockResourceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockResourceRepositoryMockRecorder
+}
+
+// MockResourceRepositoryMockRecorder is the mock recorder for MockResourceRepository.
+type MockResourceRepositoryMockRecorder struct {
+	mock *MockResourceRepository
+}
+
+// NewMockResourceRepository creates a new mock instance.
+func NewMockResourceRepository(ctrl *gomock.Controller) *MockResourceRepository {
+	mock := &MockResourceRepository{ctrl: ctrl}
+	mock.recorder = &MockResourceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResourceRepository) EXPECT() *MockResourceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteByID mocks base method.
+func (m *MockResourceRepository) DeleteByID(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockResourceRepositoryMockRecorder) DeleteByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockResourceRepository)(nil).DeleteByID), arg0, arg1)
+}
+
+// FindBookedSchedules mocks base method.
+func (m *MockResourceRepository) FindBookedSchedules(arg0 context.Context, arg1 []string) ([]core.BookedSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBookedSchedules", arg0, arg1)
+	ret0, _ := ret[0].([]core.BookedSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBookedSchedules indicates an expected call of FindBookedSchedules.
+func (mr *MockResourceRepositoryMockRecorder) FindBookedSchedules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBookedSchedules", reflect.TypeOf((*MockResourceRepository)(nil).FindBookedSchedules), arg0, arg1)
+}
+
+// FindByID mocks base method.
+func (m *MockResourceRepository) FindByID(arg0 context.Context, arg1 string) (*core.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*core.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockResourceRepositoryMockRecorder) FindByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockResourceRepository)(nil).FindByID), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockResourceRepository) List(arg0 context.Context, arg1 core.ResourceFilter) ([]core.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]core.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockResourceRepositoryMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockResourceRepository)(nil).List), arg0, arg1)
+}
+
+// Store mocks base method.
+func (m *MockResourceRepository) Store(arg0 context.Context, arg1 *core.Resource) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Store indicates an expected call of Store.
+func (mr *MockResourceRepositoryMockRecorder) Store(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockResourceRepository)(nil).Store), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockResourceRepository) Update(arg0 context.Context, arg1 *core.Resource) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockResourceRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockResourceRepository)(nil).Update), arg0, arg1)
+}
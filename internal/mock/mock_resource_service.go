@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dzakaammar/event-scheduling-example/internal/core (interfaces: ResourceService)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	core "github.com/dzakaammar/event-scheduling-example/internal/core"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockResourceService is a mock of ResourceService interface.
+type MockResourceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockResourceServiceMockRecorder
+}
+
+// MockResourceServiceMockRecorder is the mock recorder for MockResourceService.
+type MockResourceServiceMockRecorder struct {
+	mock *MockResourceService
+}
+
+// NewMockResourceService creates a new mock instance.
+func NewMockResourceService(ctrl *gomock.Controller) *MockResourceService {
+	mock := &MockResourceService{ctrl: ctrl}
+	mock.recorder = &MockResourceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResourceService) EXPECT() *MockResourceServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateResource mocks base method.
+func (m *MockResourceService) CreateResource(arg0 context.Context, arg1 *core.CreateResourceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateResource", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateResource indicates an expected call of CreateResource.
+func (mr *MockResourceServiceMockRecorder) CreateResource(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateResource", reflect.TypeOf((*MockResourceService)(nil).CreateResource), arg0, arg1)
+}
+
+// DeleteResource mocks base method.
+func (m *MockResourceService) DeleteResource(arg0 context.Context, arg1 *core.DeleteResourceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteResource", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteResource indicates an expected call of DeleteResource.
+func (mr *MockResourceServiceMockRecorder) DeleteResource(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResource", reflect.TypeOf((*MockResourceService)(nil).DeleteResource), arg0, arg1)
+}
+
+// FindResourceByID mocks base method.
+func (m *MockResourceService) FindResourceByID(arg0 context.Context, arg1 *core.FindResourceByIDRequest) (*core.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindResourceByID", arg0, arg1)
+	ret0, _ := ret[0].(*core.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindResourceByID indicates an expected call of FindResourceByID.
+func (mr *MockResourceServiceMockRecorder) FindResourceByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindResourceByID", reflect.TypeOf((*MockResourceService)(nil).FindResourceByID), arg0, arg1)
+}
+
+// GetResourceFreeBusy mocks base method.
+func (m *MockResourceService) GetResourceFreeBusy(arg0 context.Context, arg1 *core.GetResourceFreeBusyRequest) ([]core.BusyPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourceFreeBusy", arg0, arg1)
+	ret0, _ := ret[0].([]core.BusyPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourceFreeBusy indicates an expected call of GetResourceFreeBusy.
+func (mr *MockResourceServiceMockRecorder) GetResourceFreeBusy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourceFreeBusy", reflect.TypeOf((*MockResourceService)(nil).GetResourceFreeBusy), arg0, arg1)
+}
+
+// SearchResources mocks base method.
+func (m *MockResourceService) SearchResources(arg0 context.Context, arg1 *core.SearchResourcesRequest) ([]core.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchResources", arg0, arg1)
+	ret0, _ := ret[0].([]core.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchResources indicates an expected call of SearchResources.
+func (mr *MockResourceServiceMockRecorder) SearchResources(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchResources", reflect.TypeOf((*MockResourceService)(nil).SearchResources), arg0, arg1)
+}
+
+// UpdateResource mocks base method.
+func (m *MockResourceService) UpdateResource(arg0 context.Context, arg1 *core.UpdateResourceRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateResource", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateResource indicates an expected call of UpdateResource.
+func (mr *MockResourceServiceMockRecorder) UpdateResource(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateResource", reflect.TypeOf((*MockResourceService)(nil).UpdateResource), arg0, arg1)
+}
@@ -35,6 +35,21 @@ func (m *MockSchedulingService) EXPECT() *MockSchedulingServiceMockRecorder {
 	return m.recorder
 }
 
+// BatchMutateEvents mocks base method.
+func (m *MockSchedulingService) BatchMutateEvents(arg0 context.Context, arg1 *core.BatchMutateEventsRequest) ([]core.BatchMutationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchMutateEvents", arg0, arg1)
+	ret0, _ := ret[0].([]core.BatchMutationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchMutateEvents indicates an expected call of BatchMutateEvents.
+func (mr *MockSchedulingServiceMockRecorder) BatchMutateEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchMutateEvents", reflect.TypeOf((*MockSchedulingService)(nil).BatchMutateEvents), arg0, arg1)
+}
+
 // CreateEvent mocks base method.
 func (m *MockSchedulingService) CreateEvent(arg0 context.Context, arg1 *core.CreateEventRequest) error {
 	m.ctrl.T.Helper()
@@ -78,6 +93,123 @@ func (mr *MockSchedulingServiceMockRecorder) FindEventByID(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventByID", reflect.TypeOf((*MockSchedulingService)(nil).FindEventByID), arg0, arg1)
 }
 
+// ListDeletedEvents mocks base method.
+func (m *MockSchedulingService) ListDeletedEvents(arg0 context.Context, arg1 *core.ListDeletedEventsRequest) ([]core.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedEvents", arg0, arg1)
+	ret0, _ := ret[0].([]core.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedEvents indicates an expected call of ListDeletedEvents.
+func (mr *MockSchedulingServiceMockRecorder) ListDeletedEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedEvents", reflect.TypeOf((*MockSchedulingService)(nil).ListDeletedEvents), arg0, arg1)
+}
+
+// ListEventHistory mocks base method.
+func (m *MockSchedulingService) ListEventHistory(arg0 context.Context, arg1 *core.ListEventHistoryRequest) (*core.EventHistoryPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventHistory", arg0, arg1)
+	ret0, _ := ret[0].(*core.EventHistoryPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventHistory indicates an expected call of ListEventHistory.
+func (mr *MockSchedulingServiceMockRecorder) ListEventHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventHistory", reflect.TypeOf((*MockSchedulingService)(nil).ListEventHistory), arg0, arg1)
+}
+
+// ListEvents mocks base method.
+func (m *MockSchedulingService) ListEvents(arg0 context.Context, arg1 *core.ListEventsRequest) (*core.EventsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", arg0, arg1)
+	ret0, _ := ret[0].(*core.EventsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockSchedulingServiceMockRecorder) ListEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockSchedulingService)(nil).ListEvents), arg0, arg1)
+}
+
+// ListWaitlist mocks base method.
+func (m *MockSchedulingService) ListWaitlist(arg0 context.Context, arg1 *core.ListWaitlistRequest) ([]core.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWaitlist", arg0, arg1)
+	ret0, _ := ret[0].([]core.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWaitlist indicates an expected call of ListWaitlist.
+func (mr *MockSchedulingServiceMockRecorder) ListWaitlist(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWaitlist", reflect.TypeOf((*MockSchedulingService)(nil).ListWaitlist), arg0, arg1)
+}
+
+// RespondInvitation mocks base method.
+func (m *MockSchedulingService) RespondInvitation(arg0 context.Context, arg1 *core.RespondInvitationRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RespondInvitation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RespondInvitation indicates an expected call of RespondInvitation.
+func (mr *MockSchedulingServiceMockRecorder) RespondInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondInvitation", reflect.TypeOf((*MockSchedulingService)(nil).RespondInvitation), arg0, arg1)
+}
+
+// RestoreEvent mocks base method.
+func (m *MockSchedulingService) RestoreEvent(arg0 context.Context, arg1 *core.RestoreEventRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreEvent indicates an expected call of RestoreEvent.
+func (mr *MockSchedulingServiceMockRecorder) RestoreEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreEvent", reflect.TypeOf((*MockSchedulingService)(nil).RestoreEvent), arg0, arg1)
+}
+
+// SearchEvents mocks base method.
+func (m *MockSchedulingService) SearchEvents(arg0 context.Context, arg1 *core.SearchEventsRequest) (*core.SearchEventsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchEvents", arg0, arg1)
+	ret0, _ := ret[0].(*core.SearchEventsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchEvents indicates an expected call of SearchEvents.
+func (mr *MockSchedulingServiceMockRecorder) SearchEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchEvents", reflect.TypeOf((*MockSchedulingService)(nil).SearchEvents), arg0, arg1)
+}
+
+// SetReminders mocks base method.
+func (m *MockSchedulingService) SetReminders(arg0 context.Context, arg1 *core.SetRemindersRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReminders", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReminders indicates an expected call of SetReminders.
+func (mr *MockSchedulingServiceMockRecorder) SetReminders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReminders", reflect.TypeOf((*MockSchedulingService)(nil).SetReminders), arg0, arg1)
+}
+
 // UpdateEvent mocks base method.
 func (m *MockSchedulingService) UpdateEvent(arg0 context.Context, arg1 *core.UpdateEventRequest) error {
 	m.ctrl.T.Helper()
@@ -91,3 +223,18 @@ func (mr *MockSchedulingServiceMockRecorder) UpdateEvent(arg0, arg1 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEvent", reflect.TypeOf((*MockSchedulingService)(nil).UpdateEvent), arg0, arg1)
 }
+
+// WatchEvents mocks base method.
+func (m *MockSchedulingService) WatchEvents(arg0 context.Context, arg1 *core.WatchEventsRequest) (<-chan core.EventChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchEvents", arg0, arg1)
+	ret0, _ := ret[0].(<-chan core.EventChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchEvents indicates an expected call of WatchEvents.
+func (mr *MockSchedulingServiceMockRecorder) WatchEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchEvents", reflect.TypeOf((*MockSchedulingService)(nil).WatchEvents), arg0, arg1)
+}
@@ -0,0 +1,57 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Instrumentation struct {
+	next   core.NotificationPreferenceService
+	tracer trace.Tracer
+}
+
+func NewInstrumentation(next core.NotificationPreferenceService) *Instrumentation {
+	return &Instrumentation{
+		next:   next,
+		tracer: otel.Tracer("notification-preference-service"),
+	}
+}
+
+func (i *Instrumentation) GetNotificationPreference(ctx context.Context, req *core.GetNotificationPreferenceRequest) (*core.NotificationPreference, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "get-notification-preference")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	preference, err := i.next.GetNotificationPreference(ctx, req)
+	return preference, err
+}
+
+func (i *Instrumentation) UpdateNotificationPreference(ctx context.Context, req *core.UpdateNotificationPreferenceRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "update-notification-preference")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.UpdateNotificationPreference(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) MuteEvent(ctx context.Context, req *core.MuteEventRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "mute-event")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.MuteEvent(ctx, req)
+	return err
+}
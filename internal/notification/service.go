@@ -0,0 +1,69 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+type Service struct {
+	repo      core.NotificationPreferenceRepository
+	eventRepo core.EventRepository
+}
+
+func NewService(repo core.NotificationPreferenceRepository, eventRepo core.EventRepository) *Service {
+	return &Service{
+		repo:      repo,
+		eventRepo: eventRepo,
+	}
+}
+
+func (s *Service) GetNotificationPreference(ctx context.Context, req *core.GetNotificationPreferenceRequest) (*core.NotificationPreference, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByUserID(ctx, req.ActorID)
+}
+
+func (s *Service) UpdateNotificationPreference(ctx context.Context, req *core.UpdateNotificationPreferenceRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	req.Preference.UserID = req.ActorID
+	req.Preference.UpdatedAt = &now
+
+	return s.repo.Upsert(ctx, req.Preference)
+}
+
+// MuteEvent mutes an event the actor created or is invited to, other events are reported as not
+// found. Unmuting is always allowed, so that an attendee who was removed can still unmute.
+func (s *Service) MuteEvent(ctx context.Context, req *core.MuteEventRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	if !req.Muted {
+		return s.repo.UnmuteEvent(ctx, req.ActorID, req.EventID)
+	}
+
+	event, err := s.eventRepo.FindByID(ctx, req.EventID)
+	if err != nil {
+		return err
+	}
+
+	if event.CreatedBy != req.ActorID {
+		userID, err := core.ParseUserID(req.ActorID)
+		if err != nil || event.FindInvitation(userID) == nil {
+			return core.ErrEventNotFound
+		}
+	}
+
+	return s.repo.MuteEvent(ctx, req.ActorID, req.EventID)
+}
@@ -0,0 +1,217 @@
+package notification_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/mock"
+	"github.com/dzakaammar/event-scheduling-example/internal/notification"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_UpdateNotificationPreference(t *testing.T) {
+	newPreference := func(modify func(p *core.NotificationPreference)) *core.NotificationPreference {
+		p := core.DefaultNotificationPreference("")
+		p.Timezone = "Asia/Jakarta"
+		p.QuietHoursStart = "22:00"
+		p.QuietHoursEnd = "07:00"
+		if modify != nil {
+			modify(p)
+		}
+		return p
+	}
+	tests := []struct {
+		name      string
+		repoMock  func(repo *mock.MockNotificationPreferenceRepository)
+		req       *core.UpdateNotificationPreferenceRequest
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "OK",
+			repoMock: func(repo *mock.MockNotificationPreferenceRepository) {
+				repo.EXPECT().Upsert(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, p *core.NotificationPreference) error {
+						assert.Equal(t, "1", p.UserID)
+						assert.NotNil(t, p.UpdatedAt)
+						return nil
+					})
+			},
+			req: &core.UpdateNotificationPreferenceRequest{ActorID: "1", Preference: newPreference(nil)},
+		},
+		{
+			name: "OK - every channel disabled",
+			repoMock: func(repo *mock.MockNotificationPreferenceRepository) {
+				repo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			req: &core.UpdateNotificationPreferenceRequest{ActorID: "1", Preference: newPreference(func(p *core.NotificationPreference) {
+				p.Channels = nil
+			})},
+		},
+		{
+			name: "Not OK - invalid timezone",
+			req: &core.UpdateNotificationPreferenceRequest{ActorID: "1", Preference: newPreference(func(p *core.NotificationPreference) {
+				p.Timezone = "Mars/Olympus"
+			})},
+			wantErrIs: internal.ErrInvalidTimezone,
+		},
+		{
+			name: "Not OK - unknown channel",
+			req: &core.UpdateNotificationPreferenceRequest{ActorID: "1", Preference: newPreference(func(p *core.NotificationPreference) {
+				p.Channels = []core.ReminderChannel{"SMS"}
+			})},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - quiet hours without an end",
+			req: &core.UpdateNotificationPreferenceRequest{ActorID: "1", Preference: newPreference(func(p *core.NotificationPreference) {
+				p.QuietHoursEnd = ""
+			})},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - quiet hours that end when they start",
+			req: &core.UpdateNotificationPreferenceRequest{ActorID: "1", Preference: newPreference(func(p *core.NotificationPreference) {
+				p.QuietHoursEnd = "22:00"
+			})},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - invalid digest time",
+			req: &core.UpdateNotificationPreferenceRequest{ActorID: "1", Preference: newPreference(func(p *core.NotificationPreference) {
+				p.Delivery = core.NotificationDelivery_Digest
+				p.DigestTime = "8am"
+			})},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name:      "Not OK - invalid actor",
+			req:       &core.UpdateNotificationPreferenceRequest{Preference: newPreference(nil)},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - error from repo",
+			repoMock: func(repo *mock.MockNotificationPreferenceRepository) {
+				repo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(errors.New("error")) //nolint:goerr113
+			},
+			req:     &core.UpdateNotificationPreferenceRequest{ActorID: "1", Preference: newPreference(nil)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockNotificationPreferenceRepository(ctrl)
+			if tt.repoMock != nil {
+				tt.repoMock(repo)
+			}
+
+			s := notification.NewService(repo, mock.NewMockEventRepository(ctrl))
+			err := s.UpdateNotificationPreference(context.Background(), tt.req)
+			switch {
+			case tt.wantErrIs != nil:
+				assert.ErrorIs(t, err, tt.wantErrIs)
+			case tt.wantErr:
+				assert.Error(t, err)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_MuteEvent(t *testing.T) {
+	event := &core.Event{
+		ID:          "123",
+		CreatedBy:   "1",
+		Invitations: []core.Invitation{{ID: "i2", EventID: "123", UserID: 2}},
+	}
+	tests := []struct {
+		name          string
+		repoMock      func(repo *mock.MockNotificationPreferenceRepository)
+		eventRepoMock func(repo *mock.MockEventRepository)
+		req           *core.MuteEventRequest
+		wantErr       bool
+		wantErrIs     error
+	}{
+		{
+			name: "OK - muted by an attendee",
+			repoMock: func(repo *mock.MockNotificationPreferenceRepository) {
+				repo.EXPECT().MuteEvent(gomock.Any(), "2", "123").Return(nil)
+			},
+			eventRepoMock: func(repo *mock.MockEventRepository) {
+				repo.EXPECT().FindByID(gomock.Any(), "123").Return(event, nil)
+			},
+			req: &core.MuteEventRequest{ActorID: "2", EventID: "123", Muted: true},
+		},
+		{
+			name: "OK - muted by the creator",
+			repoMock: func(repo *mock.MockNotificationPreferenceRepository) {
+				repo.EXPECT().MuteEvent(gomock.Any(), "1", "123").Return(nil)
+			},
+			eventRepoMock: func(repo *mock.MockEventRepository) {
+				repo.EXPECT().FindByID(gomock.Any(), "123").Return(event, nil)
+			},
+			req: &core.MuteEventRequest{ActorID: "1", EventID: "123", Muted: true},
+		},
+		{
+			name: "OK - unmuted without looking the event up",
+			repoMock: func(repo *mock.MockNotificationPreferenceRepository) {
+				repo.EXPECT().UnmuteEvent(gomock.Any(), "3", "123").Return(nil)
+			},
+			req: &core.MuteEventRequest{ActorID: "3", EventID: "123"},
+		},
+		{
+			name: "Not OK - the event of someone else is reported as not found",
+			eventRepoMock: func(repo *mock.MockEventRepository) {
+				repo.EXPECT().FindByID(gomock.Any(), "123").Return(event, nil)
+			},
+			req:       &core.MuteEventRequest{ActorID: "3", EventID: "123", Muted: true},
+			wantErrIs: core.ErrEventNotFound,
+		},
+		{
+			name: "Not OK - event not found",
+			eventRepoMock: func(repo *mock.MockEventRepository) {
+				repo.EXPECT().FindByID(gomock.Any(), "123").Return(nil, core.ErrEventNotFound)
+			},
+			req:       &core.MuteEventRequest{ActorID: "2", EventID: "123", Muted: true},
+			wantErrIs: core.ErrEventNotFound,
+		},
+		{
+			name:      "Not OK - invalid event id",
+			req:       &core.MuteEventRequest{ActorID: "2", Muted: true},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockNotificationPreferenceRepository(ctrl)
+			if tt.repoMock != nil {
+				tt.repoMock(repo)
+			}
+			eventRepo := mock.NewMockEventRepository(ctrl)
+			if tt.eventRepoMock != nil {
+				tt.eventRepoMock(eventRepo)
+			}
+
+			err := notification.NewService(repo, eventRepo).MuteEvent(context.Background(), tt.req)
+			switch {
+			case tt.wantErrIs != nil:
+				assert.ErrorIs(t, err, tt.wantErrIs)
+			case tt.wantErr:
+				assert.Error(t, err)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
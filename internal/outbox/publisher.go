@@ -0,0 +1,43 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+// Publishers hands a domain event over to all of its publishers. When one of them fails the
+// domain event is published again to all of them, so each one has to drop the duplicates.
+type Publishers []core.EventPublisher
+
+func (p Publishers) Publish(ctx context.Context, event core.DomainEvent) error {
+	var errs []error
+	for _, publisher := range p {
+		err := publisher.Publish(ctx, event)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LogPublisher writes the domain events to the log, it is the publisher of a deployment that
+// doesn't have a message broker.
+type LogPublisher struct{}
+
+func NewLogPublisher() *LogPublisher {
+	return &LogPublisher{}
+}
+
+func (l *LogPublisher) Publish(_ context.Context, event core.DomainEvent) error {
+	slog.Info("domain event",
+		"id", event.ID,
+		"type", event.Type,
+		"event_id", event.EventID,
+		"actor_id", event.ActorID,
+		"occurred_at", event.OccurredAt,
+	)
+	return nil
+}
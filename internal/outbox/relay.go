@@ -0,0 +1,138 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+const (
+	defaultRelayInterval = time.Second
+	defaultRetryBackoff  = 5 * time.Second
+	maxRetryBackoff      = 10 * time.Minute
+	purgeInterval        = time.Hour
+
+	// relayLease must outlast the publishing of a batch: a domain event claimed by a relay that
+	// went away is claimed again once its lease expires
+	relayLease     = time.Minute
+	claimBatchSize = 100
+)
+
+// Relay publishes the domain events written to the outbox. A domain event is only marked as
+// published once the publisher accepted it, so it is published at least once: when the relay
+// stops in between, or the publisher fails, it is published again later with the same ID.
+type Relay struct {
+	repo      core.OutboxRepository
+	publisher core.EventPublisher
+	interval  time.Duration
+	// retention is zero when published domain events are kept forever
+	retention time.Duration
+}
+
+func NewRelay(repo core.OutboxRepository, publisher core.EventPublisher, interval time.Duration, retention time.Duration) *Relay {
+	if interval <= 0 {
+		interval = defaultRelayInterval
+	}
+
+	return &Relay{
+		repo:      repo,
+		publisher: publisher,
+		interval:  interval,
+		retention: retention,
+	}
+}
+
+// Run relays the pending domain events on every interval and purges the published ones every
+// hour, until ctx is done.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	var lastPurge time.Time
+	for {
+		relayed, err := r.RelayPending(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Error(err.Error())
+		} else if relayed > 0 {
+			slog.Debug("relayed domain events", "count", relayed)
+		}
+
+		if time.Since(lastPurge) >= purgeInterval {
+			lastPurge = time.Now()
+			purged, err := r.Purge(ctx)
+			if err != nil && ctx.Err() == nil {
+				slog.Error(err.Error())
+			} else if purged > 0 {
+				slog.Info("purged published domain events", "count", purged)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayPending publishes the pending domain events, oldest first, until none is left. It returns
+// how many were published.
+func (r *Relay) RelayPending(ctx context.Context) (int, error) {
+	relayed := 0
+	for {
+		now := time.Now()
+		events, err := r.repo.ClaimPending(ctx, now, now.Add(relayLease), claimBatchSize)
+		if err != nil {
+			return relayed, err
+		}
+
+		for index := range events {
+			ok, err := r.relay(ctx, &events[index])
+			if err != nil {
+				return relayed, err
+			}
+			if ok {
+				relayed++
+			}
+		}
+
+		if len(events) < claimBatchSize {
+			return relayed, nil
+		}
+	}
+}
+
+// relay publishes the domain event and records the outcome. A failed attempt is retried after a
+// backoff that doubles on every attempt, it is reported as not ok rather than as an error so that
+// the other domain events are still relayed.
+func (r *Relay) relay(ctx context.Context, event *core.DomainEvent) (bool, error) {
+	err := r.publisher.Publish(ctx, *event)
+	if ctx.Err() != nil {
+		// the relay is stopping, the domain event is claimed again once its lease expires
+		return false, ctx.Err()
+	}
+
+	if err != nil {
+		slog.Error("failed to publish domain event", "id", event.ID, "type", event.Type, "attempts", event.Attempts+1, "error", err.Error())
+		return false, r.repo.Retry(ctx, event.ID, err.Error(), time.Now().Add(retryBackoff(event.Attempts+1)))
+	}
+
+	return true, r.repo.MarkPublished(ctx, event.ID, time.Now())
+}
+
+func (r *Relay) Purge(ctx context.Context) (int64, error) {
+	if r.retention <= 0 {
+		return 0, nil
+	}
+	return r.repo.PurgePublished(ctx, time.Now().Add(-r.retention))
+}
+
+func retryBackoff(attempts int) time.Duration {
+	backoff := defaultRetryBackoff
+	for i := 1; i < attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxRetryBackoff)
+}
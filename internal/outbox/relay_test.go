@@ -0,0 +1,150 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/mock"
+	"github.com/dzakaammar/event-scheduling-example/internal/outbox"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelay_RelayPending(t *testing.T) {
+	events := []core.DomainEvent{
+		{ID: "a", Type: core.DomainEventType_EventCreated, EventID: "123"},
+		{ID: "b", Type: core.DomainEventType_EventUpdated, EventID: "123", Attempts: 2},
+	}
+	tests := []struct {
+		name          string
+		repoMock      func(repo *mock.MockOutboxRepository)
+		publisherMock func(publisher *mock.MockEventPublisher)
+		want          int
+		wantErr       bool
+	}{
+		{
+			name: "OK - published in order",
+			repoMock: func(repo *mock.MockOutboxRepository) {
+				repo.EXPECT().ClaimPending(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(events, nil)
+				gomock.InOrder(
+					repo.EXPECT().MarkPublished(gomock.Any(), "a", gomock.Any()).Return(nil),
+					repo.EXPECT().MarkPublished(gomock.Any(), "b", gomock.Any()).Return(nil),
+				)
+			},
+			publisherMock: func(publisher *mock.MockEventPublisher) {
+				gomock.InOrder(
+					publisher.EXPECT().Publish(gomock.Any(), events[0]).Return(nil),
+					publisher.EXPECT().Publish(gomock.Any(), events[1]).Return(nil),
+				)
+			},
+			want: 2,
+		},
+		{
+			name: "OK - failed domain event is retried later with a backoff",
+			repoMock: func(repo *mock.MockOutboxRepository) {
+				repo.EXPECT().ClaimPending(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(events, nil)
+				repo.EXPECT().MarkPublished(gomock.Any(), "a", gomock.Any()).Return(nil)
+				repo.EXPECT().Retry(gomock.Any(), "b", "broker is down", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, _ string, nextAttemptAt time.Time) error {
+						// the third failure waits twice as long as the second one
+						assert.WithinDuration(t, time.Now().Add(20*time.Second), nextAttemptAt, time.Second)
+						return nil
+					})
+			},
+			publisherMock: func(publisher *mock.MockEventPublisher) {
+				publisher.EXPECT().Publish(gomock.Any(), events[0]).Return(nil)
+				publisher.EXPECT().Publish(gomock.Any(), events[1]).Return(errors.New("broker is down")) //nolint:goerr113
+			},
+			want: 1,
+		},
+		{
+			name: "Not OK - error claiming",
+			repoMock: func(repo *mock.MockOutboxRepository) {
+				repo.EXPECT().ClaimPending(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("error")) //nolint:goerr113
+			},
+			publisherMock: func(publisher *mock.MockEventPublisher) {},
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockOutboxRepository(ctrl)
+			tt.repoMock(repo)
+			publisher := mock.NewMockEventPublisher(ctrl)
+			tt.publisherMock(publisher)
+
+			got, err := outbox.NewRelay(repo, publisher, time.Second, 0).RelayPending(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRelay_RelayPending_Stopping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := mock.NewMockOutboxRepository(ctrl)
+	repo.EXPECT().ClaimPending(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]core.DomainEvent{{ID: "a"}}, nil)
+	// neither published nor retried, the domain event is claimed again once its lease expires
+	repo.EXPECT().MarkPublished(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	repo.EXPECT().Retry(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	publisher := mock.NewMockEventPublisher(ctrl)
+	publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ core.DomainEvent) error {
+			cancel()
+			return ctx.Err()
+		})
+
+	_, err := outbox.NewRelay(repo, publisher, time.Second, 0).RelayPending(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRelay_Purge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockOutboxRepository(ctrl)
+	repo.EXPECT().PurgePublished(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, publishedBefore time.Time) (int64, error) {
+			assert.WithinDuration(t, time.Now().Add(-time.Hour), publishedBefore, time.Second)
+			return 3, nil
+		})
+
+	got, err := outbox.NewRelay(repo, mock.NewMockEventPublisher(ctrl), time.Second, time.Hour).Purge(context.Background())
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, got)
+
+	got, err = outbox.NewRelay(repo, mock.NewMockEventPublisher(ctrl), time.Second, 0).Purge(context.Background())
+	assert.NoError(t, err)
+	assert.Zero(t, got)
+}
+
+func TestPublishers_Publish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	event := core.DomainEvent{ID: "a"}
+	failing := mock.NewMockEventPublisher(ctrl)
+	failing.EXPECT().Publish(gomock.Any(), event).Return(errors.New("error")) //nolint:goerr113
+	// the other publishers still get the domain event
+	working := mock.NewMockEventPublisher(ctrl)
+	working.EXPECT().Publish(gomock.Any(), event).Return(nil)
+
+	err := outbox.Publishers{failing, working}.Publish(context.Background(), event)
+	assert.Error(t, err)
+}
@@ -0,0 +1,112 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
+	"github.com/jmoiron/sqlx"
+)
+
+// agendaCutoff is the time of the day the agendas are no longer sent at, i.e: when the worker was
+// down all morning
+const agendaCutoff = "12:00"
+
+// AgendaRepository keeps track of the daily agendas sent to the users. The timestamps are written
+// in UTC.
+type AgendaRepository struct {
+	queries *gen.Queries
+}
+
+func NewAgendaRepository(dbConn *sqlx.DB) *AgendaRepository {
+	return &AgendaRepository{
+		queries: gen.New(dbConn),
+	}
+}
+
+// ScheduleDue relies on the unique key of the deliveries, the users whose agenda of the day was
+// already logged are skipped. The users who haven't set a notification preference get the agenda
+// on the channels of the default one, in UTC.
+func (a *AgendaRepository) ScheduleDue(ctx context.Context, now time.Time, morning string) (int64, error) {
+	channels, err := json.Marshal(core.DefaultNotificationPreference("").Channels)
+	if err != nil {
+		slog.Error(err.Error())
+		return 0, err
+	}
+
+	logged, err := a.queries.ScheduleDueAgendaDeliveries(ctx, gen.ScheduleDueAgendaDeliveriesParams{
+		Status:          string(core.AgendaDeliveryStatus_Pending),
+		Now:             now.UTC(),
+		DefaultChannels: channels,
+		Declined:        int16(core.InvitationStatus_Declined),
+		Morning:         morning,
+		Noon:            agendaCutoff,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return 0, translateErr(err)
+	}
+	return logged, nil
+}
+
+func (a *AgendaRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]core.AgendaDelivery, error) {
+	rows, err := a.queries.ClaimDueAgendaDeliveries(ctx, gen.ClaimDueAgendaDeliveriesParams{
+		LeaseUntil: leaseUntil.UTC(),
+		Status:     string(core.AgendaDeliveryStatus_Pending),
+		Now:        now.UTC(),
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	deliveries := make([]core.AgendaDelivery, len(rows))
+	for index, row := range rows {
+		deliveries[index] = toCoreAgendaDelivery(row)
+	}
+	return deliveries, nil
+}
+
+func (a *AgendaRepository) UpdateDelivery(ctx context.Context, delivery *core.AgendaDelivery) error {
+	var sentAt sql.NullTime
+	if delivery.SentAt != nil {
+		sentAt = sql.NullTime{Time: delivery.SentAt.UTC(), Valid: true}
+	}
+
+	_, err := a.queries.UpdateAgendaDelivery(ctx, gen.UpdateAgendaDeliveryParams{
+		Status:        string(delivery.Status),
+		Attempts:      int32(delivery.Attempts),
+		LastError:     delivery.LastError,
+		NextAttemptAt: delivery.NextAttemptAt.UTC(),
+		SentAt:        sentAt,
+		ID:            delivery.ID,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	return nil
+}
+
+func toCoreAgendaDelivery(row gen.AgendaDelivery) core.AgendaDelivery {
+	delivery := core.AgendaDelivery{
+		ID:            row.ID,
+		UserID:        row.UserID,
+		Date:          row.AgendaDate,
+		Channel:       core.ReminderChannel(row.Channel),
+		Status:        core.AgendaDeliveryStatus(row.Status),
+		Attempts:      int(row.Attempts),
+		LastError:     row.LastError,
+		NextAttemptAt: row.NextAttemptAt,
+		CreatedAt:     row.CreatedAt,
+	}
+	if row.SentAt.Valid {
+		delivery.SentAt = &row.SentAt.Time
+	}
+	return delivery
+}
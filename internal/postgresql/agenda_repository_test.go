@@ -0,0 +1,102 @@
+package postgresql_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAgendaRepository_ScheduleDue(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	now := time.Date(2026, 1, 5, 0, 30, 0, 0, time.UTC)
+
+	mock.ExpectExec(`INSERT INTO agenda_delivery`).
+		WithArgs("PENDING", now, []byte(`["EMAIL","PUSH"]`), int16(core.InvitationStatus_Declined), "07:00", "12:00").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	got, err := postgresql.NewAgendaRepository(sqlx.NewDb(db, "pgx")).ScheduleDue(context.Background(), now.In(loc), "07:00")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAgendaRepository_ClaimDueDeliveries(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	now := time.Now().UTC()
+	leaseUntil := now.Add(time.Minute)
+	date := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	columns := []string{"id", "user_id", "agenda_date", "channel", "status", "attempts", "last_error", "next_attempt_at", "created_at", "sent_at"}
+	mock.ExpectQuery(`UPDATE agenda_delivery SET next_attempt_at`).
+		WithArgs(leaseUntil, "PENDING", now, 10).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, "2", date, "EMAIL", "PENDING", 0, "", leaseUntil, now, nil).
+			AddRow(2, "2", date, "PUSH", "PENDING", 1, "timeout", leaseUntil, now, nil))
+
+	got, err := postgresql.NewAgendaRepository(sqlx.NewDb(db, "pgx")).ClaimDueDeliveries(context.Background(), now, leaseUntil, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []core.AgendaDelivery{
+		{ID: 1, UserID: "2", Date: date, Channel: core.ReminderChannel_Email, Status: core.AgendaDeliveryStatus_Pending, NextAttemptAt: leaseUntil, CreatedAt: now},
+		{ID: 2, UserID: "2", Date: date, Channel: core.ReminderChannel_Push, Status: core.AgendaDeliveryStatus_Pending, Attempts: 1, LastError: "timeout", NextAttemptAt: leaseUntil, CreatedAt: now},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAgendaRepository_UpdateDelivery(t *testing.T) {
+	sentAt := time.Date(2026, 1, 5, 0, 1, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		delivery *core.AgendaDelivery
+		dbMock   func(mock sqlmock.Sqlmock)
+		wantErr  bool
+	}{
+		{
+			name:     "OK - sent",
+			delivery: &core.AgendaDelivery{ID: 1, Status: core.AgendaDeliveryStatus_Sent, Attempts: 1, NextAttemptAt: sentAt, SentAt: &sentAt},
+			dbMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE agenda_delivery`).
+					WithArgs("SENT", 1, "", sentAt, sentAt, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:     "OK - skipped",
+			delivery: &core.AgendaDelivery{ID: 1, Status: core.AgendaDeliveryStatus_Skipped, NextAttemptAt: sentAt},
+			dbMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE agenda_delivery`).
+					WithArgs("SKIPPED", 0, "", sentAt, nil, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:     "Not OK - error updating",
+			delivery: &core.AgendaDelivery{ID: 1, Status: core.AgendaDeliveryStatus_Sent, SentAt: &sentAt},
+			dbMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE agenda_delivery`).WillReturnError(errors.New("error")) //nolint:goerr113
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			tt.dbMock(mock)
+
+			err := postgresql.NewAgendaRepository(sqlx.NewDb(db, "pgx")).UpdateDelivery(context.Background(), tt.delivery)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
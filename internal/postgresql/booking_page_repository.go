@@ -0,0 +1,287 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
+	"github.com/jmoiron/sqlx"
+)
+
+type BookingPageRepository struct {
+	dbConn  *sqlx.DB
+	queries *gen.Queries
+}
+
+func NewBookingPageRepository(dbConn *sqlx.DB) *BookingPageRepository {
+	return &BookingPageRepository{
+		dbConn:  dbConn,
+		queries: gen.New(dbConn),
+	}
+}
+
+func (b *BookingPageRepository) Store(ctx context.Context, page *core.BookingPage) error {
+	rules, err := json.Marshal(page.Rules)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	err = b.queries.CreateBookingPage(ctx, gen.CreateBookingPageParams{
+		ID:           page.ID,
+		OwnerID:      page.OwnerID,
+		Title:        page.Title,
+		Description:  page.Description,
+		Timezone:     page.Timezone,
+		Duration:     page.DurationMinutes,
+		BufferBefore: page.BufferBeforeMinutes,
+		BufferAfter:  page.BufferAfterMinutes,
+		MinNotice:    page.MinNoticeMinutes,
+		MaxPerDay:    page.MaxPerDay,
+		Rules:        rules,
+		CreatedAt:    page.CreatedAt,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	return nil
+}
+
+func (b *BookingPageRepository) Update(ctx context.Context, page *core.BookingPage) error {
+	rules, err := json.Marshal(page.Rules)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	var updatedAt sql.NullTime
+	if page.UpdatedAt != nil {
+		updatedAt = sql.NullTime{Time: *page.UpdatedAt, Valid: true}
+	}
+
+	affected, err := b.queries.UpdateBookingPage(ctx, gen.UpdateBookingPageParams{
+		Title:        page.Title,
+		Description:  page.Description,
+		Timezone:     page.Timezone,
+		Duration:     page.DurationMinutes,
+		BufferBefore: page.BufferBeforeMinutes,
+		BufferAfter:  page.BufferAfterMinutes,
+		MinNotice:    page.MinNoticeMinutes,
+		MaxPerDay:    page.MaxPerDay,
+		Rules:        rules,
+		UpdatedAt:    updatedAt,
+		ID:           page.ID,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	if affected == 0 {
+		return core.ErrBookingPageNotFound
+	}
+	return nil
+}
+
+func (b *BookingPageRepository) DeleteByID(ctx context.Context, id string) error {
+	affected, err := b.queries.DeleteBookingPage(ctx, id)
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	if affected == 0 {
+		return core.ErrBookingPageNotFound
+	}
+	return nil
+}
+
+func (b *BookingPageRepository) FindByID(ctx context.Context, id string) (*core.BookingPage, error) {
+	row, err := b.queries.FindBookingPageByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, core.ErrBookingPageNotFound
+	}
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	return toCoreBookingPage(row)
+}
+
+func (b *BookingPageRepository) FindByOwner(ctx context.Context, ownerID string) ([]core.BookingPage, error) {
+	rows, err := b.queries.FindBookingPagesByOwner(ctx, ownerID)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	pages := make([]core.BookingPage, len(rows))
+	for index, row := range rows {
+		page, err := toCoreBookingPage(row)
+		if err != nil {
+			return nil, err
+		}
+		pages[index] = *page
+	}
+	return pages, nil
+}
+
+func (b *BookingPageRepository) FindBusySchedules(ctx context.Context, userID string, from time.Time, to time.Time) ([]core.Schedule, error) {
+	return findBusySchedules(ctx, b.queries, userID, from, to)
+}
+
+func (b *BookingPageRepository) FindBookedStarts(ctx context.Context, pageID string, from time.Time, to time.Time) ([]time.Time, error) {
+	starts, err := b.queries.FindBookedStarts(ctx, gen.FindBookedStartsParams{
+		PageID:      pageID,
+		StartTime:   from.UTC(),
+		StartTime_2: to.UTC(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+	return starts, nil
+}
+
+// Book takes a transaction-scoped advisory lock on the owner of the page before checking the slot,
+// which makes the concurrent bookings of the owner wait for each other, whichever page they are
+// made through. The busy schedules and the bookings are read again once the lock is held.
+func (b *BookingPageRepository) Book(ctx context.Context, page *core.BookingPage, booking *core.Booking, event *core.Event, journal core.Journal) error {
+	return b.inTx(ctx, func(queries *gen.Queries) error {
+		err := lockOpenSlot(ctx, queries, page, booking.Start, booking.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		err = storeEvent(ctx, queries, event)
+		if err != nil {
+			return err
+		}
+
+		err = queries.CreateBooking(ctx, gen.CreateBookingParams{
+			ID:         booking.ID,
+			PageID:     booking.PageID,
+			EventID:    booking.EventID,
+			StartTime:  booking.Start.UTC(),
+			GuestName:  booking.Guest.Name,
+			GuestEmail: booking.Guest.Email,
+			CreatedAt:  booking.CreatedAt,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+
+		return recordJournal(ctx, queries, journal)
+	})
+}
+
+// lockOpenSlot locks the bookings of the owner of the page until the transaction ends, and returns
+// core.ErrSlotUnavailable unless the slot starting at start is open.
+func lockOpenSlot(ctx context.Context, queries *gen.Queries, page *core.BookingPage, start time.Time, now time.Time) error {
+	err := queries.LockBookingOwner(ctx, page.OwnerID)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	busyFrom, busyTo := page.BusyWindow(start, start.Add(time.Minute))
+	busy, err := findBusySchedules(ctx, queries, page.OwnerID, busyFrom, busyTo)
+	if err != nil {
+		return err
+	}
+
+	// the bookings of the days around the slot are enough to count the ones of its day
+	booked, err := queries.FindBookedStarts(ctx, gen.FindBookedStartsParams{
+		PageID:      page.ID,
+		StartTime:   start.Add(-24 * time.Hour).UTC(),
+		StartTime_2: start.Add(24 * time.Hour).UTC(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	if !page.IsOpen(start, now, busy, booked) {
+		return core.ErrSlotUnavailable
+	}
+	return nil
+}
+
+// inTx runs fn with queries bound to a new transaction, which is committed if fn succeeds.
+func (b *BookingPageRepository) inTx(ctx context.Context, fn func(queries *gen.Queries) error) error {
+	tx, err := b.dbConn.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+	defer func() {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			slog.Error(rollbackErr.Error())
+		}
+	}()
+
+	err = fn(b.queries.WithTx(tx))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// findBusySchedules returns the schedules of the events the user created or is invited to and
+// didn't decline, which may have an occurrence in [from, to): the single ones overlapping it, and
+// the recurring ones starting before its end. A user whose id isn't a number can't be invited,
+// only their own events count.
+func findBusySchedules(ctx context.Context, queries *gen.Queries, userID string, from time.Time, to time.Time) ([]core.Schedule, error) {
+	id, _ := core.ParseUserID(userID)
+	rows, err := queries.FindUserBusySchedules(ctx, gen.FindUserBusySchedulesParams{
+		CreatedBy:   userID,
+		UserID:      id,
+		Declined:    int16(core.InvitationStatus_Declined),
+		WindowStart: from.Unix(),
+		WindowEnd:   to.Unix(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	schedules := make([]core.Schedule, len(rows))
+	for index, row := range rows {
+		schedules[index] = toCoreSchedule(row)
+	}
+	return schedules, nil
+}
+
+func toCoreBookingPage(row gen.BookingPage) (*core.BookingPage, error) {
+	var rules []core.AvailabilityRule
+	if err := json.Unmarshal(row.Rules, &rules); err != nil {
+		return nil, err
+	}
+
+	page := &core.BookingPage{
+		ID:                  row.ID,
+		OwnerID:             row.OwnerID,
+		Title:               row.Title,
+		Description:         row.Description,
+		Timezone:            row.Timezone,
+		DurationMinutes:     row.Duration,
+		BufferBeforeMinutes: row.BufferBefore,
+		BufferAfterMinutes:  row.BufferAfter,
+		MinNoticeMinutes:    row.MinNotice,
+		MaxPerDay:           row.MaxPerDay,
+		Rules:               rules,
+		CreatedAt:           row.CreatedAt,
+	}
+	if row.UpdatedAt.Valid {
+		page.UpdatedAt = &row.UpdatedAt.Time
+	}
+	return page, nil
+}
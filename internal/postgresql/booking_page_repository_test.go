@@ -0,0 +1,276 @@
+package postgresql_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var bookingPageColumns = []string{"id", "owner_id", "title", "description", "timezone", "duration", "buffer_before", "buffer_after", "min_notice", "max_per_day", "rules", "created_at", "updated_at"}
+
+func newTestBookingPage() *core.BookingPage {
+	return &core.BookingPage{
+		ID:              "page1",
+		OwnerID:         "1",
+		Title:           "Intro call",
+		Timezone:        "UTC",
+		DurationMinutes: 30,
+		Rules: []core.AvailabilityRule{
+			{Weekday: time.Monday, Start: "09:00", End: "17:00"},
+		},
+		CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestBookingPageRepository_Store(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	page := newTestBookingPage()
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectExec(`INSERT INTO booking_page`).
+						WithArgs("page1", "1", "Intro call", "", "UTC", int32(30), int32(0), int32(0), int32(0), int32(0),
+							[]byte(`[{"weekday":1,"start":"09:00","end":"17:00"}]`), page.CreatedAt).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+		},
+		{
+			name: "Not OK - error",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectExec(`INSERT INTO booking_page`).WillReturnError(errors.New("error")) //nolint:goerr113
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := postgresql.NewBookingPageRepository(tt.fields.dbMock(t)).Store(context.Background(), page)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestBookingPageRepository_FindByID(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	page := newTestBookingPage()
+	tests := []struct {
+		name      string
+		fields    fields
+		want      *core.BookingPage
+		wantErrIs error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectQuery(`SELECT .+ FROM booking_page WHERE id = \$1`).WithArgs("page1").
+						WillReturnRows(sqlmock.NewRows(bookingPageColumns).
+							AddRow("page1", "1", "Intro call", "", "UTC", 30, 0, 0, 0, 0, []byte(`[{"weekday":1,"start":"09:00","end":"17:00"}]`), page.CreatedAt, nil))
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			want: page,
+		},
+		{
+			name: "Not OK - not found",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectQuery(`SELECT .+ FROM booking_page WHERE id = \$1`).WillReturnError(sql.ErrNoRows)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErrIs: core.ErrBookingPageNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := postgresql.NewBookingPageRepository(tt.fields.dbMock(t)).FindByID(context.Background(), "page1")
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBookingPageRepository_FindBusySchedules(t *testing.T) {
+	from := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(`SELECT .+ FROM schedule JOIN event .+ schedule.start_time \+ schedule.duration \* 60 > \$4 \) AND schedule.start_time < \$5`).
+		WithArgs("1", int32(1), int16(core.InvitationStatus_Declined), from.Unix(), to.Unix()).
+		WillReturnRows(sqlmock.NewRows(scheduleColumns).
+			AddRow("s1", "event1", from.Add(10*time.Hour).Unix(), int64(30), false, int64(0), "NONE"))
+
+	got, err := postgresql.NewBookingPageRepository(sqlx.NewDb(db, "pgx")).FindBusySchedules(context.Background(), "1", from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, []core.Schedule{
+		{ID: "s1", EventID: "event1", StartTime: from.Add(10 * time.Hour).Unix(), DurationInMinutes: 30, RecurringType: core.RecurringType_None},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBookingPageRepository_FindBookedStarts(t *testing.T) {
+	from := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	db, mock, _ := sqlmock.New()
+	// the bookings whose event was deleted free their slot
+	mock.ExpectQuery(`SELECT booking.start_time FROM booking JOIN event ON event.id = booking.event_id WHERE .+ AND event.deleted_at IS NULL`).
+		WithArgs("page1", from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"start_time"}).AddRow(from.Add(10 * time.Hour)))
+
+	got, err := postgresql.NewBookingPageRepository(sqlx.NewDb(db, "pgx")).FindBookedStarts(context.Background(), "page1", from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Time{from.Add(10 * time.Hour)}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBookingPageRepository_Book(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	page := newTestBookingPage()
+	start := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	guest := core.Guest{Name: "Ana", Email: "ana@example.org"}
+	tests := []struct {
+		name      string
+		fields    fields
+		maxPerDay int32
+		wantErrIs error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectBegin()
+					mock.ExpectExec(`SELECT pg_advisory_xact_lock\(hashtext\(\$1\)\)`).WithArgs("1").
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectQuery(`SELECT .+ FROM schedule JOIN event`).WithArgs("1", int32(1), int16(core.InvitationStatus_Declined), start.Unix(), start.Add(31*time.Minute).Unix()).
+						WillReturnRows(sqlmock.NewRows(scheduleColumns))
+					mock.ExpectQuery(`SELECT booking.start_time FROM booking JOIN event .+ WHERE booking.page_id = \$1 .+ AND event.deleted_at IS NULL`).
+						WithArgs("page1", start.Add(-24*time.Hour), start.Add(24*time.Hour)).
+						WillReturnRows(sqlmock.NewRows([]string{"start_time"}).AddRow(start.Add(-time.Hour)))
+					mock.ExpectQuery(`SELECT .+ FROM calendar WHERE owner_id = \$1 AND is_default`).WithArgs("1").
+						WillReturnRows(sqlmock.NewRows(calendarColumns).
+							AddRow("cal1", "1", "Default", "", "UTC", "", true, time.Now(), nil))
+					mock.ExpectExec(`INSERT INTO event`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`INSERT INTO schedule`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`INSERT INTO guest`).WithArgs(sqlmock.AnyArg(), "ana@example.org", "Ana").
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`INSERT INTO booking`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`INSERT INTO event_history`).WithArgs(sqlmock.AnyArg(), "1", "CREATE", sqlmock.AnyArg(), sqlmock.AnyArg(), "").
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`INSERT INTO outbox`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectCommit()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			maxPerDay: 2,
+		},
+		{
+			name: "Not OK - the owner became busy in the meantime",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectBegin()
+					mock.ExpectExec(`SELECT pg_advisory_xact_lock`).WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectQuery(`SELECT .+ FROM schedule JOIN event`).
+						WillReturnRows(sqlmock.NewRows(scheduleColumns).
+							AddRow("s1", "event1", start.Add(15*time.Minute).Unix(), int64(30), false, int64(0), ""))
+					mock.ExpectQuery(`SELECT booking.start_time FROM booking`).WillReturnRows(sqlmock.NewRows([]string{"start_time"}))
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErrIs: core.ErrSlotUnavailable,
+		},
+		{
+			name: "Not OK - the page is full for the day",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectBegin()
+					mock.ExpectExec(`SELECT pg_advisory_xact_lock`).WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectQuery(`SELECT .+ FROM schedule JOIN event`).WillReturnRows(sqlmock.NewRows(scheduleColumns))
+					mock.ExpectQuery(`SELECT booking.start_time FROM booking`).
+						WillReturnRows(sqlmock.NewRows([]string{"start_time"}).AddRow(start.Add(-time.Hour)))
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			maxPerDay: 1,
+			wantErrIs: core.ErrSlotUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := *page
+			page.MaxPerDay = tt.maxPerDay
+
+			event := core.NewBookingEvent(&page, start, guest, "")
+			booking := &core.Booking{
+				ID:        "booking1",
+				PageID:    page.ID,
+				EventID:   event.ID,
+				Start:     start,
+				Guest:     event.Guests[0],
+				CreatedAt: page.CreatedAt,
+			}
+			change := core.NewEventChange(event.ID, page.OwnerID, core.HistoryOperation_Create, nil, event)
+
+			err := postgresql.NewBookingPageRepository(tt.fields.dbMock(t)).Book(context.Background(), &page, booking, event, core.NewJournal(change, nil, event))
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,341 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
+	"github.com/jmoiron/sqlx"
+)
+
+type CalendarRepository struct {
+	dbConn  *sqlx.DB
+	queries *gen.Queries
+}
+
+func NewCalendarRepository(dbConn *sqlx.DB) *CalendarRepository {
+	return &CalendarRepository{
+		dbConn:  dbConn,
+		queries: gen.New(dbConn),
+	}
+}
+
+func (c *CalendarRepository) Store(ctx context.Context, calendar *core.Calendar) error {
+	err := c.queries.CreateCalendar(ctx, gen.CreateCalendarParams{
+		ID:          calendar.ID,
+		OwnerID:     calendar.OwnerID,
+		Name:        calendar.Name,
+		Color:       calendar.Color,
+		Timezone:    calendar.Timezone,
+		Description: calendar.Description,
+		IsDefault:   calendar.IsDefault,
+		CreatedAt:   calendar.CreatedAt,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	return nil
+}
+
+func (c *CalendarRepository) Update(ctx context.Context, calendar *core.Calendar) error {
+	var updatedAt sql.NullTime
+	if calendar.UpdatedAt != nil {
+		updatedAt = sql.NullTime{Time: *calendar.UpdatedAt, Valid: true}
+	}
+
+	affected, err := c.queries.UpdateCalendar(ctx, gen.UpdateCalendarParams{
+		Name:        calendar.Name,
+		Color:       calendar.Color,
+		Timezone:    calendar.Timezone,
+		Description: calendar.Description,
+		UpdatedAt:   updatedAt,
+		ID:          calendar.ID,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	if affected == 0 {
+		return core.ErrCalendarNotFound
+	}
+	return nil
+}
+
+// DeleteByID moves the events of the calendar, the ones in the trash included, to the default
+// calendar of its owner before deleting it, all in one transaction.
+func (c *CalendarRepository) DeleteByID(ctx context.Context, id string, journal func(before, after *core.Event) core.Journal) error {
+	return c.inTxx(ctx, func(tx *sqlx.Tx, queries *gen.Queries) error {
+		calendar, err := queries.FindCalendarByID(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.ErrCalendarNotFound
+		}
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+		if calendar.IsDefault {
+			return core.ErrDefaultCalendar
+		}
+
+		defaultID, err := defaultCalendarID(ctx, queries, calendar.OwnerID, calendar.Timezone)
+		if err != nil {
+			return err
+		}
+
+		rows, err := queries.LockCalendarEvents(ctx, id)
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+
+		err = queries.MoveCalendarEvents(ctx, gen.MoveCalendarEventsParams{
+			ToCalendarID:   defaultID,
+			FromCalendarID: id,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+
+		for _, row := range rows {
+			before := toCoreEvent(row)
+			err = loadEvent(ctx, tx, &before)
+			if err != nil {
+				return err
+			}
+
+			after := before
+			after.CalendarID = defaultID
+			err = recordJournal(ctx, queries, journal(&before, &after))
+			if err != nil {
+				return err
+			}
+		}
+
+		affected, err := queries.DeleteCalendar(ctx, id)
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+		if affected == 0 {
+			return core.ErrCalendarNotFound
+		}
+		return nil
+	})
+}
+
+// inTxx runs fn with queries bound to a new transaction, which is committed if fn succeeds.
+func (c *CalendarRepository) inTxx(ctx context.Context, fn func(tx *sqlx.Tx, queries *gen.Queries) error) error {
+	tx, err := c.dbConn.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+	defer func() {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			slog.Error(rollbackErr.Error())
+		}
+	}()
+
+	err = fn(tx, c.queries.WithTx(tx.Tx))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (c *CalendarRepository) FindByID(ctx context.Context, id string) (*core.Calendar, error) {
+	row, err := c.queries.FindCalendarByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, core.ErrCalendarNotFound
+	}
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	calendar := toCoreCalendar(row)
+	return &calendar, nil
+}
+
+func (c *CalendarRepository) FindByOwner(ctx context.Context, ownerID string) ([]core.Calendar, error) {
+	rows, err := c.queries.FindCalendarsByOwner(ctx, ownerID)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	calendars := make([]core.Calendar, len(rows))
+	for index, row := range rows {
+		calendars[index] = toCoreCalendar(row)
+	}
+	return calendars, nil
+}
+
+func (c *CalendarRepository) MoveEvent(ctx context.Context, event *core.Event, journal core.Journal) error {
+	var movedAt sql.NullTime
+	if event.UpdatedAt != nil {
+		movedAt = sql.NullTime{Time: *event.UpdatedAt, Valid: true}
+	}
+
+	return c.inTxx(ctx, func(_ *sqlx.Tx, queries *gen.Queries) error {
+		affected, err := queries.MoveEvent(ctx, gen.MoveEventParams{
+			CalendarID: event.CalendarID,
+			UpdatedAt:  movedAt,
+			ID:         event.ID,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return translateErr(err)
+		}
+		if affected == 0 {
+			return core.ErrEventNotFound
+		}
+		return recordJournal(ctx, queries, journal)
+	})
+}
+
+func (c *CalendarRepository) Grant(ctx context.Context, grant *core.CalendarGrant) error {
+	err := c.queries.UpsertCalendarGrant(ctx, gen.UpsertCalendarGrantParams{
+		CalendarID:  grant.CalendarID,
+		GranteeType: string(grant.GranteeType),
+		GranteeID:   grant.GranteeID,
+		Role:        string(grant.Role),
+		GrantedBy:   grant.GrantedBy,
+		CreatedAt:   grant.CreatedAt,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	return nil
+}
+
+func (c *CalendarRepository) Revoke(ctx context.Context, calendarID string, granteeType core.GranteeType, granteeID string) error {
+	affected, err := c.queries.DeleteCalendarGrant(ctx, gen.DeleteCalendarGrantParams{
+		CalendarID:  calendarID,
+		GranteeType: string(granteeType),
+		GranteeID:   granteeID,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	if affected == 0 {
+		return core.ErrCalendarGrantNotFound
+	}
+	return nil
+}
+
+func (c *CalendarRepository) FindGrants(ctx context.Context, calendarID string) ([]core.CalendarGrant, error) {
+	rows, err := c.queries.FindCalendarGrants(ctx, calendarID)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	grants := make([]core.CalendarGrant, len(rows))
+	for index, row := range rows {
+		grants[index] = core.CalendarGrant{
+			CalendarID:  row.CalendarID,
+			GranteeType: core.GranteeType(row.GranteeType),
+			GranteeID:   row.GranteeID,
+			Role:        core.CalendarRole(row.Role),
+			GrantedBy:   row.GrantedBy,
+			CreatedAt:   row.CreatedAt,
+		}
+		if row.UpdatedAt.Valid {
+			grants[index].UpdatedAt = &row.UpdatedAt.Time
+		}
+	}
+	return grants, nil
+}
+
+func (c *CalendarRepository) FindRole(ctx context.Context, calendarID string, userID string) (core.CalendarRole, error) {
+	rows, err := c.queries.FindCalendarRoles(ctx, gen.FindCalendarRolesParams{
+		CalendarID: calendarID,
+		UserID:     userID,
+		MemberID:   groupMemberID(userID),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return "", translateErr(err)
+	}
+
+	roles := make([]core.CalendarRole, len(rows))
+	for index, row := range rows {
+		roles[index] = core.CalendarRole(row)
+	}
+	return core.HighestCalendarRole(roles...), nil
+}
+
+func (c *CalendarRepository) FindSharedWith(ctx context.Context, userID string) ([]core.Calendar, error) {
+	rows, err := c.queries.FindCalendarsSharedWith(ctx, gen.FindCalendarsSharedWithParams{
+		UserID:   userID,
+		MemberID: groupMemberID(userID),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	// a calendar shared with the user and with their groups comes once for each grant
+	calendars := make([]core.Calendar, 0, len(rows))
+	positions := make(map[string]int, len(rows))
+	for _, row := range rows {
+		role := core.CalendarRole(row.Role)
+		if index, ok := positions[row.ID]; ok {
+			calendars[index].AccessRole = core.HighestCalendarRole(calendars[index].AccessRole, role)
+			continue
+		}
+
+		calendar := toCoreCalendar(gen.Calendar{
+			ID:          row.ID,
+			OwnerID:     row.OwnerID,
+			Name:        row.Name,
+			Color:       row.Color,
+			Timezone:    row.Timezone,
+			Description: row.Description,
+			IsDefault:   row.IsDefault,
+			CreatedAt:   row.CreatedAt,
+			UpdatedAt:   row.UpdatedAt,
+		})
+		calendar.AccessRole = role
+		positions[row.ID] = len(calendars)
+		calendars = append(calendars, calendar)
+	}
+	return calendars, nil
+}
+
+// groupMemberID returns the id the user is a member of groups by, 0 for an actor that isn't a user,
+// who is in no group.
+func groupMemberID(userID string) int32 {
+	memberID, err := core.ParseUserID(userID)
+	if err != nil {
+		return 0
+	}
+	return memberID
+}
+
+func toCoreCalendar(row gen.Calendar) core.Calendar {
+	calendar := core.Calendar{
+		ID:          row.ID,
+		OwnerID:     row.OwnerID,
+		Name:        row.Name,
+		Color:       row.Color,
+		Timezone:    row.Timezone,
+		Description: row.Description,
+		IsDefault:   row.IsDefault,
+		CreatedAt:   row.CreatedAt,
+	}
+	if row.UpdatedAt.Valid {
+		calendar.UpdatedAt = &row.UpdatedAt.Time
+	}
+	return calendar
+}
@@ -0,0 +1,514 @@
+package postgresql_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var calendarColumns = []string{"id", "owner_id", "name", "color", "timezone", "description", "is_default", "created_at", "updated_at"}
+
+func TestCalendarRepository_Store(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	now := time.Now()
+	calendar := &core.Calendar{
+		ID:          "cal1",
+		OwnerID:     "1",
+		Name:        "Work",
+		Color:       "#1A73E8",
+		Timezone:    "Asia/Jakarta",
+		Description: "desc",
+		CreatedAt:   now,
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectExec(`INSERT INTO calendar`).
+						WithArgs("cal1", "1", "Work", "#1A73E8", "Asia/Jakarta", "desc", false, now).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+		},
+		{
+			name: "Not OK - error",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectExec(`INSERT INTO calendar`).WillReturnError(errors.New("error")) //nolint:goerr113
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := postgresql.NewCalendarRepository(tt.fields.dbMock(t)).Store(context.Background(), calendar)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCalendarRepository_Update(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	now := time.Now()
+	calendar := &core.Calendar{
+		ID:        "cal1",
+		Name:      "Work",
+		Timezone:  "Asia/Jakarta",
+		UpdatedAt: &now,
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectExec(`UPDATE calendar`).
+						WithArgs("Work", "", "Asia/Jakarta", "", sql.NullTime{Time: now, Valid: true}, "cal1").
+						WillReturnResult(sqlmock.NewResult(0, 1))
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+		},
+		{
+			name: "Not OK - not found",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectExec(`UPDATE calendar`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErr:   true,
+			wantErrIs: core.ErrCalendarNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := postgresql.NewCalendarRepository(tt.fields.dbMock(t)).Update(context.Background(), calendar)
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCalendarRepository_DeleteByID(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	now := time.Now()
+	eventColumns := []string{"id", "title", "description", "timezone", "created_by", "created_at", "updated_at", "deleted_at", "search_vector", "calendar_id", "capacity"}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "OK - the events are moved to the default calendar",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectQuery(`SELECT .+ FROM calendar WHERE id = \$1`).WithArgs("cal2").
+						WillReturnRows(sqlmock.NewRows(calendarColumns).AddRow("cal2", "1", "Work", "", "Asia/Jakarta", "", false, now, nil))
+					mock.ExpectQuery(`SELECT .+ FROM calendar WHERE owner_id = \$1 AND is_default`).WithArgs("1").
+						WillReturnRows(sqlmock.NewRows(calendarColumns).AddRow("cal1", "1", "Default", "", "Asia/Jakarta", "", true, now, nil))
+					mock.ExpectQuery(`SELECT .+ FROM event WHERE calendar_id = \$1 ORDER BY id FOR UPDATE`).WithArgs("cal2").
+						WillReturnRows(sqlmock.NewRows(eventColumns).AddRow("123", "test", "desc", "Asia/Jakarta", "1", now, now, nil, "", "cal2", 0))
+					mock.ExpectExec(`UPDATE event SET calendar_id`).WithArgs("cal1", "cal2").WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectQuery(`SELECT \* FROM schedule WHERE event_id = \$1`).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+					mock.ExpectQuery(`SELECT \* FROM invitation WHERE event_id = \$1`).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+					mock.ExpectQuery(`SELECT .+ FROM resource_booking WHERE event_id = \$1`).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"event_id"}))
+					mock.ExpectQuery(`SELECT .+ FROM guest WHERE event_id = \$1`).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"event_id"}))
+					mock.ExpectQuery(`SELECT .+ FROM reminder WHERE event_id = \$1`).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+					mock.ExpectExec(`INSERT INTO event_history`).
+						WithArgs("123", "1", "UPDATE", []byte(`{"calendar_id":{"before":"cal2","after":"cal1"}}`), now, "").
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`DELETE FROM calendar`).WithArgs("cal2").WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectCommit()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+		},
+		{
+			name: "Not OK - default calendar",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectQuery(`SELECT .+ FROM calendar WHERE id = \$1`).WithArgs("cal2").
+						WillReturnRows(sqlmock.NewRows(calendarColumns).AddRow("cal2", "1", "Default", "", "Asia/Jakarta", "", true, now, nil))
+					mock.ExpectRollback()
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErr:   true,
+			wantErrIs: core.ErrDefaultCalendar,
+		},
+		{
+			name: "Not OK - not found",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectQuery(`SELECT .+ FROM calendar WHERE id = \$1`).WithArgs("cal2").WillReturnError(sql.ErrNoRows)
+					mock.ExpectRollback()
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErr:   true,
+			wantErrIs: core.ErrCalendarNotFound,
+		},
+		{
+			name: "Not OK - the events are kept when moving them fails",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectQuery(`SELECT .+ FROM calendar WHERE id = \$1`).WithArgs("cal2").
+						WillReturnRows(sqlmock.NewRows(calendarColumns).AddRow("cal2", "1", "Work", "", "Asia/Jakarta", "", false, now, nil))
+					mock.ExpectQuery(`SELECT .+ FROM calendar WHERE owner_id = \$1 AND is_default`).WithArgs("1").
+						WillReturnRows(sqlmock.NewRows(calendarColumns).AddRow("cal1", "1", "Default", "", "Asia/Jakarta", "", true, now, nil))
+					mock.ExpectQuery(`SELECT .+ FROM event WHERE calendar_id`).WithArgs("cal2").WillReturnRows(sqlmock.NewRows(eventColumns))
+					mock.ExpectExec(`UPDATE event SET calendar_id`).WillReturnError(errors.New("error")) //nolint:goerr113
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := postgresql.NewCalendarRepository(tt.fields.dbMock(t)).DeleteByID(context.Background(), "cal2", func(before, after *core.Event) core.Journal {
+				return core.Journal{History: &core.EventHistory{
+					EventID:   before.ID,
+					ActorID:   "1",
+					Operation: core.HistoryOperation_Update,
+					Changes: map[string]core.FieldChange{
+						"calendar_id": {Before: before.CalendarID, After: after.CalendarID},
+					},
+					CreatedAt: now,
+				}}
+			})
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCalendarRepository_FindByID(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	now := time.Now()
+	tests := []struct {
+		name      string
+		fields    fields
+		want      *core.Calendar
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectQuery(`SELECT .+ FROM calendar WHERE id = \$1`).WithArgs("cal1").
+						WillReturnRows(sqlmock.NewRows(calendarColumns).AddRow("cal1", "1", "Work", "#1A73E8", "Asia/Jakarta", "desc", false, now, now))
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			want: &core.Calendar{
+				ID:          "cal1",
+				OwnerID:     "1",
+				Name:        "Work",
+				Color:       "#1A73E8",
+				Timezone:    "Asia/Jakarta",
+				Description: "desc",
+				CreatedAt:   now,
+				UpdatedAt:   &now,
+			},
+		},
+		{
+			name: "Not OK - not found",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectQuery(`SELECT .+ FROM calendar`).WillReturnError(sql.ErrNoRows)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErr:   true,
+			wantErrIs: core.ErrCalendarNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := postgresql.NewCalendarRepository(tt.fields.dbMock(t)).FindByID(context.Background(), "cal1")
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCalendarRepository_FindByOwner(t *testing.T) {
+	now := time.Now()
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(`SELECT .+ FROM calendar WHERE owner_id = \$1 ORDER BY is_default DESC`).WithArgs("1").
+		WillReturnRows(sqlmock.NewRows(calendarColumns).
+			AddRow("cal1", "1", "Default", "", "Asia/Jakarta", "", true, now, nil).
+			AddRow("cal2", "1", "Work", "", "Asia/Jakarta", "", false, now, nil))
+
+	got, err := postgresql.NewCalendarRepository(sqlx.NewDb(db, "pgx")).FindByOwner(context.Background(), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, []core.Calendar{
+		{ID: "cal1", OwnerID: "1", Name: "Default", Timezone: "Asia/Jakarta", IsDefault: true, CreatedAt: now},
+		{ID: "cal2", OwnerID: "1", Name: "Work", Timezone: "Asia/Jakarta", CreatedAt: now},
+	}, got)
+}
+
+func TestCalendarRepository_MoveEvent(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	now := time.Now()
+	tests := []struct {
+		name      string
+		fields    fields
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectExec(`UPDATE event SET calendar_id = \$1, updated_at = \$2 WHERE id = \$3 AND deleted_at IS NULL`).
+						WithArgs("cal2", sql.NullTime{Time: now, Valid: true}, "123").
+						WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectExec(`INSERT INTO event_history`).
+						WithArgs("123", "1", "UPDATE", []byte(`{"calendar_id":{"before":"cal1","after":"cal2"}}`), now, "").
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectCommit()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+		},
+		{
+			name: "Not OK - event not found",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectExec(`UPDATE event SET calendar_id`).WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectRollback()
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErr:   true,
+			wantErrIs: core.ErrEventNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := postgresql.NewCalendarRepository(tt.fields.dbMock(t)).MoveEvent(context.Background(), &core.Event{ID: "123", CalendarID: "cal2", UpdatedAt: &now}, core.Journal{
+				History: &core.EventHistory{
+					EventID:   "123",
+					ActorID:   "1",
+					Operation: core.HistoryOperation_Update,
+					Changes: map[string]core.FieldChange{
+						"calendar_id": {Before: "cal1", After: "cal2"},
+					},
+					CreatedAt: now,
+				},
+			})
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCalendarRepository_Grant(t *testing.T) {
+	now := time.Now()
+	db, mock, _ := sqlmock.New()
+	mock.ExpectExec(`INSERT INTO calendar_acl`).
+		WithArgs("cal1", "USER", "2", "WRITE", "1", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := postgresql.NewCalendarRepository(sqlx.NewDb(db, "pgx")).Grant(context.Background(), &core.CalendarGrant{
+		CalendarID:  "cal1",
+		GranteeType: core.GranteeType_User,
+		GranteeID:   "2",
+		Role:        core.CalendarRole_Write,
+		GrantedBy:   "1",
+		CreatedAt:   now,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCalendarRepository_Revoke(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantErrIs error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectExec(`DELETE FROM calendar_acl`).WithArgs("cal1", "USER", "2").
+						WillReturnResult(sqlmock.NewResult(0, 1))
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+		},
+		{
+			name: "Not OK - grant not found",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectExec(`DELETE FROM calendar_acl`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErrIs: core.ErrCalendarGrantNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := postgresql.NewCalendarRepository(tt.fields.dbMock(t)).Revoke(context.Background(), "cal1", core.GranteeType_User, "2")
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCalendarRepository_FindRole(t *testing.T) {
+	tests := []struct {
+		name  string
+		roles []string
+		want  core.CalendarRole
+	}{
+		{
+			name:  "OK - the highest role",
+			roles: []string{"READ", "MANAGE", "FREE_BUSY"},
+			want:  core.CalendarRole_Manage,
+		},
+		{
+			name: "OK - no access",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			rows := sqlmock.NewRows([]string{"role"})
+			for _, role := range tt.roles {
+				rows.AddRow(role)
+			}
+			mock.ExpectQuery(`SELECT role FROM calendar_acl`).WithArgs("cal1", "2", 2).WillReturnRows(rows)
+
+			got, err := postgresql.NewCalendarRepository(sqlx.NewDb(db, "pgx")).FindRole(context.Background(), "cal1", "2")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCalendarRepository_FindSharedWith(t *testing.T) {
+	now := time.Now()
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(`SELECT .+ FROM calendar JOIN calendar_acl`).WithArgs("2", 2).
+		WillReturnRows(sqlmock.NewRows(append(calendarColumns, "role")).
+			AddRow("cal1", "1", "Work", "", "Asia/Jakarta", "", false, now, nil, "READ").
+			AddRow("cal1", "1", "Work", "", "Asia/Jakarta", "", false, now, nil, "WRITE"))
+
+	got, err := postgresql.NewCalendarRepository(sqlx.NewDb(db, "pgx")).FindSharedWith(context.Background(), "2")
+	assert.NoError(t, err)
+	assert.Equal(t, []core.Calendar{
+		{ID: "cal1", OwnerID: "1", Name: "Work", Timezone: "Asia/Jakarta", CreatedAt: now, AccessRole: core.CalendarRole_Write},
+	}, got)
+}
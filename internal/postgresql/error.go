@@ -0,0 +1,47 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolation is the SQLSTATE of a unique constraint violation.
+const uniqueViolation = "23505"
+
+// translateErr converts driver errors into the domain errors defined in core, so callers
+// never have to know which database is behind the repository.
+func translateErr(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, sql.ErrNoRows):
+		return core.ErrEventNotFound
+	default:
+		return err
+	}
+}
+
+// isExpectedErr reports whether err is a domain outcome rather than a failure of the database.
+func isExpectedErr(err error) bool {
+	return errors.Is(err, core.ErrEventNotFound) ||
+		errors.Is(err, core.ErrEventAlreadyExists) ||
+		errors.Is(err, core.ErrInvitationNotFound) ||
+		errors.Is(err, core.ErrIdempotencyKeyNotFound) ||
+		errors.Is(err, core.ErrIdempotencyKeyPending) ||
+		errors.Is(err, core.ErrWebhookNotFound) ||
+		errors.Is(err, core.ErrCalendarNotFound) ||
+		errors.Is(err, core.ErrDefaultCalendar) ||
+		errors.Is(err, core.ErrCalendarGrantNotFound) ||
+		errors.Is(err, core.ErrGroupNotFound) ||
+		errors.Is(err, core.ErrResourceNotFound) ||
+		errors.Is(err, core.ErrBookingPageNotFound) ||
+		errors.Is(err, core.ErrSlotUnavailable)
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
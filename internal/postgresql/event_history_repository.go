@@ -0,0 +1,82 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
+	"github.com/jmoiron/sqlx"
+)
+
+type EventHistoryRepository struct {
+	queries *gen.Queries
+}
+
+func NewEventHistoryRepository(dbConn *sqlx.DB) *EventHistoryRepository {
+	return &EventHistoryRepository{
+		queries: gen.New(dbConn),
+	}
+}
+
+// storeHistory appends the entry to the audit trail with queries, which are bound to the transaction
+// of the write it records.
+func storeHistory(ctx context.Context, queries *gen.Queries, h *core.EventHistory) error {
+	changes, err := json.Marshal(h.Changes)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	err = queries.CreateEventHistory(ctx, gen.CreateEventHistoryParams{
+		EventID:    h.EventID,
+		ActorID:    h.ActorID,
+		Operation:  string(h.Operation),
+		Changes:    changes,
+		CreatedAt:  h.CreatedAt,
+		OnBehalfOf: h.OnBehalfOf,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	return nil
+}
+
+func (e *EventHistoryRepository) FindByEventID(ctx context.Context, eventID string, beforeID int64, limit int) ([]core.EventHistory, error) {
+	if beforeID <= 0 {
+		beforeID = math.MaxInt64
+	}
+
+	rows, err := e.queries.FindEventHistoryByEventID(ctx, gen.FindEventHistoryByEventIDParams{
+		EventID: eventID,
+		ID:      beforeID,
+		Limit:   int32(limit),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	entries := make([]core.EventHistory, len(rows))
+	for index, row := range rows {
+		var changes map[string]core.FieldChange
+		if err := json.Unmarshal(row.Changes, &changes); err != nil {
+			slog.Error(err.Error())
+			return nil, err
+		}
+
+		entries[index] = core.EventHistory{
+			ID:         row.ID,
+			EventID:    row.EventID,
+			ActorID:    row.ActorID,
+			OnBehalfOf: row.OnBehalfOf,
+			Operation:  core.HistoryOperation(row.Operation),
+			Changes:    changes,
+			CreatedAt:  row.CreatedAt,
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,203 @@
+package postgresql_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventRepository_DeleteByID_History(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	now := time.Now()
+	history := &core.EventHistory{
+		EventID:   "123",
+		ActorID:   "1",
+		Operation: core.HistoryOperation_Delete,
+		Changes: map[string]core.FieldChange{
+			"title": {Before: "old"},
+		},
+		CreatedAt:  now,
+		OnBehalfOf: "2",
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "OK - written in the transaction of the event",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectExec(`UPDATE event SET deleted_at`).WithArgs("123", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`INSERT INTO event_history`).
+						WithArgs("123", "1", "DELETE", []byte(`{"title":{"before":"old"}}`), now, "2").
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectCommit()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+		},
+		{
+			name: "Not OK - the event is rolled back when the history fails",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectExec(`UPDATE event SET deleted_at`).WithArgs("123", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`INSERT INTO event_history`).WillReturnError(errors.New("error")) //nolint:goerr113
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := postgresql.NewEventRepository(tt.fields.dbMock(t))
+			err := e.DeleteByID(context.Background(), "123", core.Journal{History: history})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEventHistoryRepository_FindByEventID(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	type args struct {
+		ctx      context.Context
+		eventID  string
+		beforeID int64
+		limit    int
+	}
+	now := time.Now()
+	columns := []string{"id", "event_id", "actor_id", "operation", "changes", "created_at", "on_behalf_of"}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []core.EventHistory
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectQuery(`SELECT (.+) FROM event_history`).WithArgs("123", int64(10), int32(2)).
+						WillReturnRows(sqlmock.NewRows(columns).
+							AddRow(9, "123", "1", "RSVP", []byte(`{"attendees":{"before":[{"user_id":1,"status":0}],"after":[{"user_id":1,"status":1}]}}`), now, "").
+							AddRow(3, "123", "1", "CREATE", []byte(`{"title":{"after":"test"}}`), now, "2"))
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:      context.Background(),
+				eventID:  "123",
+				beforeID: 10,
+				limit:    2,
+			},
+			want: []core.EventHistory{
+				{
+					ID:        9,
+					EventID:   "123",
+					ActorID:   "1",
+					Operation: core.HistoryOperation_RSVP,
+					Changes: map[string]core.FieldChange{
+						"attendees": {
+							Before: []any{map[string]any{"user_id": float64(1), "status": float64(0)}},
+							After:  []any{map[string]any{"user_id": float64(1), "status": float64(1)}},
+						},
+					},
+					CreatedAt: now,
+				},
+				{
+					ID:        3,
+					EventID:   "123",
+					ActorID:   "1",
+					Operation: core.HistoryOperation_Create,
+					Changes: map[string]core.FieldChange{
+						"title": {After: "test"},
+					},
+					CreatedAt:  now,
+					OnBehalfOf: "2",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "OK - first page",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectQuery(`SELECT (.+) FROM event_history`).WithArgs("123", int64(math.MaxInt64), int32(2)).
+						WillReturnRows(sqlmock.NewRows(columns))
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				eventID: "123",
+				limit:   2,
+			},
+			want:    []core.EventHistory{},
+			wantErr: false,
+		},
+		{
+			name: "Not OK - error",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectQuery(`SELECT (.+) FROM event_history`).WillReturnError(errors.New("error")) //nolint:goerr113
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				eventID: "123",
+				limit:   2,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := postgresql.NewEventHistoryRepository(tt.fields.dbMock(t))
+			got, err := e.FindByEventID(tt.args.ctx, tt.args.eventID, tt.args.beforeID, tt.args.limit)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
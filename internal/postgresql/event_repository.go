@@ -5,8 +5,11 @@ import (
 	"database/sql"
 	"errors"
 	"log/slog"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/dzakaammar/event-scheduling-example/internal"
 	"github.com/dzakaammar/event-scheduling-example/internal/core"
 	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
 	"github.com/jmoiron/sqlx"
@@ -24,8 +27,74 @@ func NewEventRepository(dbConn *sqlx.DB) *EventRepository {
 	}
 }
 
-func (e *EventRepository) Store(ctx context.Context, event *core.Event) error { //nolint:funlen,gocognit
-	tx, err := e.dbConn.BeginTx(ctx, &sql.TxOptions{})
+func (e *EventRepository) Store(ctx context.Context, event *core.Event, journal core.Journal) error {
+	return e.inTx(ctx, func(queries *gen.Queries) error {
+		err := storeEvent(ctx, queries, event)
+		if err != nil {
+			return err
+		}
+		return recordJournal(ctx, queries, journal)
+	})
+}
+
+// DeleteByID moves the event to the trash. It is hidden from reads until restored or purged.
+func (e *EventRepository) DeleteByID(ctx context.Context, id string, journal core.Journal) error {
+	return e.inTx(ctx, func(queries *gen.Queries) error {
+		err := deleteEvent(ctx, queries, id)
+		if err != nil {
+			return err
+		}
+		return recordJournal(ctx, queries, journal)
+	})
+}
+
+func (e *EventRepository) Update(ctx context.Context, event *core.Event, journal core.Journal) error {
+	return e.inTx(ctx, func(queries *gen.Queries) error {
+		err := updateEvent(ctx, queries, event)
+		if err != nil {
+			return err
+		}
+		return recordJournal(ctx, queries, journal)
+	})
+}
+
+// Batch applies the mutations in order within one transaction. When one of them fails nothing is
+// applied, and the returned *core.BatchError tells which one.
+func (e *EventRepository) Batch(ctx context.Context, mutations []core.EventMutation) error {
+	return e.inTx(ctx, func(queries *gen.Queries) error {
+		for index, mutation := range mutations {
+			var err error
+			switch mutation.Type {
+			case core.MutationType_Create:
+				err = storeEvent(ctx, queries, mutation.Event)
+			case core.MutationType_Update:
+				err = updateEvent(ctx, queries, mutation.Event)
+			case core.MutationType_Delete:
+				err = deleteEvent(ctx, queries, mutation.Event.ID)
+			default:
+				err = internal.WrapErr(internal.ErrInvalidRequest, "unknown mutation type")
+			}
+			if err == nil {
+				err = recordJournal(ctx, queries, mutation.Journal)
+			}
+			if err != nil {
+				return &core.BatchError{Index: index, Err: err}
+			}
+		}
+		return nil
+	})
+}
+
+// inTx runs fn with queries bound to a new transaction, which is committed if fn succeeds.
+func (e *EventRepository) inTx(ctx context.Context, fn func(queries *gen.Queries) error) error {
+	return e.inTxx(ctx, func(_ *sqlx.Tx, queries *gen.Queries) error {
+		return fn(queries)
+	})
+}
+
+// inTxx is inTx for the writes that also need to query the transaction without the generated queries.
+func (e *EventRepository) inTxx(ctx context.Context, fn func(tx *sqlx.Tx, queries *gen.Queries) error) error {
+	tx, err := e.dbConn.BeginTxx(ctx, &sql.TxOptions{})
 	if err != nil {
 		slog.Error(err.Error())
 		return err
@@ -37,7 +106,22 @@ func (e *EventRepository) Store(ctx context.Context, event *core.Event) error {
 		}
 	}()
 
-	err = e.queries.WithTx(tx).CreateEvent(ctx, gen.CreateEventParams{
+	err = fn(tx, e.queries.WithTx(tx.Tx))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func storeEvent(ctx context.Context, queries *gen.Queries, event *core.Event) error {
+	calendarID, err := eventCalendarID(ctx, queries, event)
+	if err != nil {
+		return err
+	}
+	event.CalendarID = calendarID
+
+	err = queries.CreateEvent(ctx, gen.CreateEventParams{
 		ID:          event.ID,
 		Title:       event.Title,
 		Description: event.Description,
@@ -45,14 +129,19 @@ func (e *EventRepository) Store(ctx context.Context, event *core.Event) error {
 		CreatedBy:   event.CreatedBy,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   sql.NullTime{Time: time.Now()},
+		CalendarID:  calendarID,
+		Capacity:    event.Capacity,
 	})
+	if isUniqueViolation(err) {
+		return core.ErrEventAlreadyExists
+	}
 	if err != nil {
 		slog.Error(err.Error())
 		return err
 	}
 
 	for _, schedule := range event.Schedules {
-		err = e.queries.WithTx(tx).CreateSchedule(ctx, gen.CreateScheduleParams{
+		err = queries.CreateSchedule(ctx, gen.CreateScheduleParams{
 			ID:                schedule.ID,
 			EventID:           event.ID,
 			StartTime:         schedule.StartTime,
@@ -68,51 +157,129 @@ func (e *EventRepository) Store(ctx context.Context, event *core.Event) error {
 	}
 
 	for _, invitation := range event.Invitations {
-		err = e.queries.WithTx(tx).CreateInvitation(ctx, gen.CreateInvitationParams{
+		err = queries.CreateInvitation(ctx, gen.CreateInvitationParams{
 			ID:      invitation.ID,
 			EventID: invitation.EventID,
 			UserID:  int32(invitation.UserID),
 			Token:   invitation.Token,
 			Status:  int16(invitation.Status),
+			GroupID: invitation.GroupID,
 		})
 		if err != nil {
 			slog.Error(err.Error())
 			return err
 		}
+
+		err = storeReminders(ctx, queries, event.ID, invitation.Reminders)
+		if err != nil {
+			return err
+		}
 	}
 
-	return tx.Commit()
+	for _, guest := range event.Guests {
+		err = queries.CreateGuest(ctx, gen.CreateGuestParams{
+			EventID: event.ID,
+			Email:   guest.Email,
+			Name:    guest.Name,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+	}
+
+	err = bookResources(ctx, queries, event)
+	if err != nil {
+		return err
+	}
+
+	return storeReminders(ctx, queries, event.ID, event.Reminders)
 }
 
-func (e *EventRepository) DeleteByID(ctx context.Context, id string) error {
-	return e.queries.DeleteEvent(ctx, id)
+// eventCalendarID returns the calendar a new event goes into, which must be one of its creator.
+// An event that doesn't name one goes into the default calendar of its creator, which is created
+// along with their first event.
+func eventCalendarID(ctx context.Context, queries *gen.Queries, event *core.Event) (string, error) {
+	if event.CalendarID == "" {
+		return defaultCalendarID(ctx, queries, event.CreatedBy, event.Timezone)
+	}
+
+	calendar, err := queries.FindCalendarByID(ctx, event.CalendarID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", core.ErrCalendarNotFound
+	}
+	if err != nil {
+		slog.Error(err.Error())
+		return "", err
+	}
+
+	if calendar.OwnerID != event.CreatedBy {
+		return "", core.ErrCalendarNotFound
+	}
+	return calendar.ID, nil
 }
 
-func (e *EventRepository) Update(ctx context.Context, event *core.Event) error { //nolint:gocognit
-	tx, err := e.dbConn.BeginTx(ctx, &sql.TxOptions{})
+// defaultCalendarID returns the default calendar of the owner, creating it in the given timezone
+// when they don't have one yet.
+func defaultCalendarID(ctx context.Context, queries *gen.Queries, ownerID string, timezone string) (string, error) {
+	row, err := queries.FindDefaultCalendar(ctx, ownerID)
+	if err == nil {
+		return row.ID, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		slog.Error(err.Error())
+		return "", err
+	}
+
+	calendar := core.NewDefaultCalendar(ownerID, timezone)
+	// a concurrent write may have created it meanwhile, which is the one found below
+	err = queries.CreateDefaultCalendar(ctx, gen.CreateDefaultCalendarParams{
+		ID:          calendar.ID,
+		OwnerID:     calendar.OwnerID,
+		Name:        calendar.Name,
+		Color:       calendar.Color,
+		Timezone:    calendar.Timezone,
+		Description: calendar.Description,
+		CreatedAt:   calendar.CreatedAt,
+	})
 	if err != nil {
 		slog.Error(err.Error())
-		return err
+		return "", err
 	}
-	defer func() {
-		rollbackErr := tx.Rollback()
-		if rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
-			slog.Error(rollbackErr.Error())
-		}
-	}()
 
-	err = e.queries.WithTx(tx).UpdateEvent(ctx, gen.UpdateEventParams{
+	row, err = queries.FindDefaultCalendar(ctx, ownerID)
+	if err != nil {
+		slog.Error(err.Error())
+		return "", err
+	}
+	return row.ID, nil
+}
+
+func updateEvent(ctx context.Context, queries *gen.Queries, event *core.Event) error {
+	var updatedAt sql.NullTime
+	if event.UpdatedAt != nil {
+		updatedAt = sql.NullTime{Time: *event.UpdatedAt, Valid: true}
+	}
+
+	affected, err := queries.UpdateEvent(ctx, gen.UpdateEventParams{
 		Title:       event.Title,
 		Description: event.Description,
 		Timezone:    event.Timezone,
+		UpdatedAt:   updatedAt,
+		Capacity:    event.Capacity,
+		ID:          event.ID,
 	})
 	if err != nil {
 		slog.Error(err.Error())
-		return err
+		return translateErr(err)
+	}
+
+	if affected == 0 {
+		return core.ErrEventNotFound
 	}
 
 	for _, schedule := range event.Schedules {
-		err = e.queries.WithTx(tx).UpsertSchedule(ctx, gen.UpsertScheduleParams{
+		err = queries.UpsertSchedule(ctx, gen.UpsertScheduleParams{
 			ID:                schedule.ID,
 			EventID:           event.ID,
 			StartTime:         schedule.StartTime,
@@ -128,12 +295,23 @@ func (e *EventRepository) Update(ctx context.Context, event *core.Event) error {
 	}
 
 	for _, invitation := range event.Invitations {
-		err = e.queries.WithTx(tx).UpsertInvitation(ctx, gen.UpsertInvitationParams{
-			ID:      invitation.ID,
-			EventID: event.ID,
-			UserID:  invitation.UserID,
-			Token:   invitation.Token,
-			Status:  int16(invitation.Status),
+		var updatedAt, waitlistedAt sql.NullTime
+		if invitation.UpdatedAt != nil {
+			updatedAt = sql.NullTime{Time: *invitation.UpdatedAt, Valid: true}
+		}
+		if invitation.WaitlistedAt != nil {
+			waitlistedAt = sql.NullTime{Time: *invitation.WaitlistedAt, Valid: true}
+		}
+
+		err = queries.UpsertInvitation(ctx, gen.UpsertInvitationParams{
+			ID:           invitation.ID,
+			EventID:      event.ID,
+			UserID:       invitation.UserID,
+			Token:        invitation.Token,
+			Status:       int16(invitation.Status),
+			GroupID:      invitation.GroupID,
+			UpdatedAt:    updatedAt,
+			WaitlistedAt: waitlistedAt,
 		})
 		if err != nil {
 			slog.Error(err.Error())
@@ -141,40 +319,624 @@ func (e *EventRepository) Update(ctx context.Context, event *core.Event) error {
 		}
 	}
 
-	return tx.Commit()
+	err = queries.DeleteResourceBookings(ctx, event.ID)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	err = bookResources(ctx, queries, event)
+	if err != nil {
+		return err
+	}
+
+	err = queries.DeleteReminders(ctx, gen.DeleteRemindersParams{EventID: event.ID})
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	err = storeReminders(ctx, queries, event.ID, event.Reminders)
+	if err != nil {
+		return err
+	}
+
+	// the schedules may have changed, so the reminders set by the attendees are due to be
+	// scheduled again as well
+	err = queries.RescheduleReminders(ctx, gen.RescheduleRemindersParams{
+		NextFireAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		EventID:    event.ID,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// bookResources books the resources of the event, each one is confirmed unless it is already
+// booked for an overlapping occurrence of another event. The resources are locked in the order of
+// their IDs until the transaction ends, so that concurrent writes can't both confirm them.
+func bookResources(ctx context.Context, queries *gen.Queries, event *core.Event) error {
+	sort.Slice(event.Resources, func(i, j int) bool {
+		return event.Resources[i].ResourceID < event.Resources[j].ResourceID
+	})
+
+	now := time.Now()
+	for index := range event.Resources {
+		booking := &event.Resources[index]
+		_, err := queries.LockResource(ctx, booking.ResourceID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.ErrResourceNotFound
+		}
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+
+		rows, err := queries.FindResourceBookedSchedules(ctx, gen.FindResourceBookedSchedulesParams{
+			ResourceID: booking.ResourceID,
+			EventID:    event.ID,
+			Status:     int16(core.InvitationStatus_Confirmed),
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+
+		booked := make([]core.Schedule, len(rows))
+		for index, row := range rows {
+			booked[index] = toCoreSchedule(row)
+		}
+
+		booking.EventID = event.ID
+		booking.Status = core.BookingStatus(event.Schedules, booked)
+		err = queries.CreateResourceBooking(ctx, gen.CreateResourceBookingParams{
+			EventID:    booking.EventID,
+			ResourceID: booking.ResourceID,
+			Status:     int16(booking.Status),
+			CreatedAt:  now,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+// storeReminders writes the reminders as due, the reminder worker schedules them on its next round.
+// The reminder timestamps are in UTC.
+func storeReminders(ctx context.Context, queries *gen.Queries, eventID string, reminders []core.Reminder) error {
+	now := time.Now().UTC()
+	for _, reminder := range reminders {
+		err := queries.CreateReminder(ctx, gen.CreateReminderParams{
+			ID:            reminder.ID,
+			EventID:       eventID,
+			UserID:        reminder.UserID,
+			OffsetMinutes: reminder.OffsetMinutes,
+			Channel:       string(reminder.Channel),
+			NextFireAt:    sql.NullTime{Time: now, Valid: true},
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteEvent(ctx context.Context, queries *gen.Queries, id string) error {
+	affected, err := queries.DeleteEvent(ctx, gen.DeleteEventParams{
+		ID:        id,
+		DeletedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+
+	if affected == 0 {
+		return core.ErrEventNotFound
+	}
+	return nil
 }
 
 func (e *EventRepository) FindByID(ctx context.Context, id string) (*core.Event, error) {
 	queryEvent, err := e.queries.FindEventByID(ctx, id)
 	if err != nil {
-		slog.Error(err.Error())
+		err = translateErr(err)
+		if !isExpectedErr(err) {
+			slog.Error(err.Error())
+		}
 		return nil, err
 	}
-	event := core.Event{
-		ID:          queryEvent.ID,
-		Title:       queryEvent.Title,
-		Description: queryEvent.Description,
-		Timezone:    queryEvent.Timezone,
-		CreatedBy:   queryEvent.CreatedBy,
-		CreatedAt:   queryEvent.CreatedAt,
-		UpdatedAt:   &queryEvent.UpdatedAt.Time,
+	event := toCoreEvent(queryEvent)
+
+	err = loadEvent(ctx, e.dbConn, &event)
+	if err != nil {
+		return nil, err
 	}
+	return &event, nil
+}
 
+// loadEvent loads the schedules, invitations, resource bookings, guests and reminders of the event
+// through db, which is the transaction when the event is locked.
+func loadEvent(ctx context.Context, db sqlx.QueryerContext, event *core.Event) error {
 	var schedules []core.Schedule
-	err = e.dbConn.SelectContext(ctx, &schedules, `SELECT * FROM schedule WHERE event_id = $1`, id)
+	err := sqlx.SelectContext(ctx, db, &schedules, `SELECT * FROM schedule WHERE event_id = $1`, event.ID)
 	if err != nil {
 		slog.Error(err.Error())
-		return nil, err
+		return err
 	}
 	event.Schedules = schedules
 
 	var invitations []core.Invitation
-	err = e.dbConn.SelectContext(ctx, &invitations, `SELECT * FROM invitation WHERE event_id = $1`, id)
+	err = sqlx.SelectContext(ctx, db, &invitations, `SELECT * FROM invitation WHERE event_id = $1`, event.ID)
 	if err != nil {
 		slog.Error(err.Error())
-		return nil, err
+		return err
 	}
 	event.Invitations = invitations
 
-	return &event, err
+	var resources []core.ResourceBooking
+	err = sqlx.SelectContext(ctx, db, &resources, `SELECT event_id, resource_id, status FROM resource_booking WHERE event_id = $1 ORDER BY resource_id`, event.ID)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+	event.Resources = resources
+
+	var guests []core.Guest
+	err = sqlx.SelectContext(ctx, db, &guests, `SELECT event_id, email, name FROM guest WHERE event_id = $1 ORDER BY email`, event.ID)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+	event.Guests = guests
+
+	var reminders []core.Reminder
+	err = sqlx.SelectContext(ctx, db, &reminders, `SELECT id, event_id, user_id, offset_minutes, channel FROM reminder WHERE event_id = $1`, event.ID)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+	attachReminders(event, reminders)
+
+	return nil
+}
+
+// attachReminders adds the reminders to the event, or to the invitation of the attendee who set them.
+func attachReminders(event *core.Event, reminders []core.Reminder) {
+	for _, reminder := range reminders {
+		if reminder.UserID == 0 {
+			event.Reminders = append(event.Reminders, reminder)
+			continue
+		}
+
+		if invitation := event.FindInvitation(reminder.UserID); invitation != nil {
+			invitation.Reminders = append(invitation.Reminders, reminder)
+		}
+	}
+}
+
+func (e *EventRepository) FindDeletedByID(ctx context.Context, id string) (*core.Event, error) {
+	queryEvent, err := e.queries.FindDeletedEventByID(ctx, id)
+	if err != nil {
+		err = translateErr(err)
+		if !isExpectedErr(err) {
+			slog.Error(err.Error())
+		}
+		return nil, err
+	}
+
+	events := []core.Event{toCoreEvent(queryEvent)}
+	err = e.loadDetails(ctx, events)
+	if err != nil {
+		return nil, err
+	}
+	return &events[0], nil
+}
+
+// RestoreByID restores the deleted event, which no longer held its slot or its resources while it
+// was deleted. The slot booked through a booking page has to be open again, otherwise
+// core.ErrSlotUnavailable is returned, and the resources booked meanwhile by other events are
+// declined.
+func (e *EventRepository) RestoreByID(ctx context.Context, id string, journal core.Journal) error {
+	return e.inTxx(ctx, func(tx *sqlx.Tx, queries *gen.Queries) error {
+		queryEvent, err := queries.FindDeletedEventByID(ctx, id)
+		if err != nil {
+			err = translateErr(err)
+			if !isExpectedErr(err) {
+				slog.Error(err.Error())
+			}
+			return err
+		}
+		event := toCoreEvent(queryEvent)
+
+		err = loadEvent(ctx, tx, &event)
+		if err != nil {
+			return err
+		}
+
+		// the slot is checked while the event is still deleted, so that it doesn't count as
+		// busy itself
+		err = lockBookedSlot(ctx, queries, id)
+		if err != nil {
+			return err
+		}
+
+		affected, err := queries.RestoreEvent(ctx, id)
+		if err != nil {
+			slog.Error(err.Error())
+			return translateErr(err)
+		}
+
+		if affected == 0 {
+			return core.ErrEventNotFound
+		}
+
+		err = queries.DeleteResourceBookings(ctx, id)
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+
+		err = bookResources(ctx, queries, &event)
+		if err != nil {
+			return err
+		}
+
+		return recordJournal(ctx, queries, journal)
+	})
+}
+
+// lockBookedSlot returns core.ErrSlotUnavailable if the event was booked through a booking page and
+// its slot isn't open anymore. The notice the page asks for was given when the slot was booked.
+func lockBookedSlot(ctx context.Context, queries *gen.Queries, eventID string) error {
+	booking, err := queries.FindBookingByEventID(ctx, eventID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	row, err := queries.FindBookingPageByID(ctx, booking.PageID)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	page, err := toCoreBookingPage(row)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	return lockOpenSlot(ctx, queries, page, booking.StartTime, booking.CreatedAt)
+}
+
+// FindDeletedByCreator returns the events in the trash of the given creator, most recently deleted first.
+// Schedules and invitations are not loaded.
+func (e *EventRepository) FindDeletedByCreator(ctx context.Context, createdBy string) ([]core.Event, error) {
+	queryEvents, err := e.queries.FindDeletedEventsByCreator(ctx, createdBy)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	events := make([]core.Event, len(queryEvents))
+	for index, queryEvent := range queryEvents {
+		events[index] = toCoreEvent(queryEvent)
+	}
+	return events, nil
+}
+
+// PurgeDeleted permanently removes the events deleted before the given time, along with their
+// schedules and invitations.
+func (e *EventRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	affected, err := e.queries.PurgeDeletedEvents(ctx, sql.NullTime{Time: deletedBefore, Valid: true})
+	if err != nil {
+		slog.Error(err.Error())
+		return 0, translateErr(err)
+	}
+	return affected, nil
+}
+
+// RespondInvitation locks the row of the event until the transaction ends, the event and its
+// invitations are read once the lock is held so that respond decides on the latest ones.
+func (e *EventRepository) RespondInvitation(ctx context.Context, eventID string, respond func(event *core.Event) ([]core.Invitation, core.Journal, error)) error {
+	return e.inTxx(ctx, func(tx *sqlx.Tx, queries *gen.Queries) error {
+		queryEvent, err := queries.LockEvent(ctx, eventID)
+		if err != nil {
+			err = translateErr(err)
+			if !isExpectedErr(err) {
+				slog.Error(err.Error())
+			}
+			return err
+		}
+		event := toCoreEvent(queryEvent)
+
+		err = loadEvent(ctx, tx, &event)
+		if err != nil {
+			return err
+		}
+
+		changed, journal, err := respond(&event)
+		if err != nil {
+			return err
+		}
+
+		for _, invitation := range changed {
+			err = updateInvitationStatus(ctx, queries, invitation)
+			if err != nil {
+				return err
+			}
+		}
+		return recordJournal(ctx, queries, journal)
+	})
+}
+
+func updateInvitationStatus(ctx context.Context, queries *gen.Queries, invitation core.Invitation) error {
+	var updatedAt, waitlistedAt sql.NullTime
+	if invitation.UpdatedAt != nil {
+		updatedAt = sql.NullTime{Time: *invitation.UpdatedAt, Valid: true}
+	}
+	if invitation.WaitlistedAt != nil {
+		waitlistedAt = sql.NullTime{Time: *invitation.WaitlistedAt, Valid: true}
+	}
+
+	affected, err := queries.UpdateInvitationStatus(ctx, gen.UpdateInvitationStatusParams{
+		ID:           invitation.ID,
+		Status:       int16(invitation.Status),
+		UpdatedAt:    updatedAt,
+		WaitlistedAt: waitlistedAt,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+
+	if affected == 0 {
+		return core.ErrInvitationNotFound
+	}
+	return nil
+}
+
+func (e *EventRepository) ReplaceReminders(ctx context.Context, eventID string, userID int32, reminders []core.Reminder) error {
+	return e.inTx(ctx, func(queries *gen.Queries) error {
+		err := queries.DeleteReminders(ctx, gen.DeleteRemindersParams{
+			EventID: eventID,
+			UserID:  userID,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return translateErr(err)
+		}
+		return storeReminders(ctx, queries, eventID, reminders)
+	})
+}
+
+// List returns the events matching the filter, newest created first.
+func (e *EventRepository) List(ctx context.Context, filter core.EventFilter) ([]core.Event, error) {
+	params := gen.ListEventsParams{
+		CreatedBy:      sql.NullString{String: filter.CreatedBy, Valid: filter.CreatedBy != ""},
+		Attendee:       sql.NullInt32{Int32: filter.Attendee, Valid: filter.Attendee != 0},
+		Title:          sql.NullString{String: likeEscaper.Replace(filter.Title), Valid: filter.Title != ""},
+		Timezone:       sql.NullString{String: filter.Timezone, Valid: filter.Timezone != ""},
+		CalendarID:     sql.NullString{String: filter.CalendarID, Valid: filter.CalendarID != ""},
+		Viewer:         sql.NullString{String: filter.Viewer, Valid: filter.Viewer != ""},
+		ViewerMemberID: groupMemberID(filter.Viewer),
+		PageSize:       int32(filter.Limit),
+	}
+	// created_at is a timestamp without time zone written from the server's local time
+	if filter.CreatedAfter != nil {
+		params.CreatedAfter = sql.NullTime{Time: filter.CreatedAfter.Local(), Valid: true}
+	}
+	if filter.CreatedBefore != nil {
+		params.CreatedBefore = sql.NullTime{Time: filter.CreatedBefore.Local(), Valid: true}
+	}
+	if filter.After != nil {
+		params.CursorCreatedAt = sql.NullTime{Time: filter.After.CreatedAt, Valid: true}
+		params.CursorID = sql.NullString{String: filter.After.ID, Valid: true}
+	}
+
+	queryEvents, err := e.queries.ListEvents(ctx, params)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	events := make([]core.Event, len(queryEvents))
+	for index, queryEvent := range queryEvents {
+		events[index] = toCoreEvent(queryEvent)
+	}
+
+	err = e.loadDetails(ctx, events)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// ListInvolved returns the events with a schedule windowed like the busy schedules of a booking
+// page: the single ones overlapping [from, to), and the recurring ones starting before its end.
+func (e *EventRepository) ListInvolved(ctx context.Context, userID string, from time.Time, to time.Time) ([]core.Event, error) {
+	id, _ := core.ParseUserID(userID)
+	queryEvents, err := e.queries.ListInvolvedEvents(ctx, gen.ListInvolvedEventsParams{
+		CreatedBy:   userID,
+		UserID:      id,
+		Declined:    int16(core.InvitationStatus_Declined),
+		WindowStart: from.Unix(),
+		WindowEnd:   to.Unix(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	events := make([]core.Event, len(queryEvents))
+	for index, queryEvent := range queryEvents {
+		events[index] = toCoreEvent(queryEvent)
+	}
+
+	err = e.loadDetails(ctx, events)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (e *EventRepository) Search(ctx context.Context, actorID string, query string, offset int, limit int) ([]core.Event, error) {
+	rows, err := e.queries.SearchEvents(ctx, gen.SearchEventsParams{
+		Query:         query,
+		ActorID:       actorID,
+		PageSize:      int32(limit),
+		PageOffset:    int32(offset),
+		ActorMemberID: groupMemberID(actorID),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	events := make([]core.Event, len(rows))
+	for index, row := range rows {
+		events[index] = toCoreEvent(gen.Event{
+			ID:          row.ID,
+			Title:       row.Title,
+			Description: row.Description,
+			Timezone:    row.Timezone,
+			CreatedBy:   row.CreatedBy,
+			CreatedAt:   row.CreatedAt,
+			UpdatedAt:   row.UpdatedAt,
+			DeletedAt:   row.DeletedAt,
+			CalendarID:  row.CalendarID,
+			Capacity:    row.Capacity,
+		})
+	}
+
+	err = e.loadDetails(ctx, events)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// loadDetails loads the schedules, invitations, resource bookings and reminders of the events with
+// one query each.
+func (e *EventRepository) loadDetails(ctx context.Context, events []core.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(events))
+	positions := make(map[string]int, len(events))
+	for index := range events {
+		ids[index] = events[index].ID
+		positions[events[index].ID] = index
+	}
+
+	var schedules []core.Schedule
+	err := e.selectByEventIDs(ctx, &schedules, `SELECT * FROM schedule WHERE event_id IN (?)`, ids)
+	if err != nil {
+		return err
+	}
+
+	var invitations []core.Invitation
+	err = e.selectByEventIDs(ctx, &invitations, `SELECT * FROM invitation WHERE event_id IN (?)`, ids)
+	if err != nil {
+		return err
+	}
+
+	var resources []core.ResourceBooking
+	err = e.selectByEventIDs(ctx, &resources, `SELECT event_id, resource_id, status FROM resource_booking WHERE event_id IN (?) ORDER BY resource_id`, ids)
+	if err != nil {
+		return err
+	}
+
+	var guests []core.Guest
+	err = e.selectByEventIDs(ctx, &guests, `SELECT event_id, email, name FROM guest WHERE event_id IN (?) ORDER BY email`, ids)
+	if err != nil {
+		return err
+	}
+
+	var reminders []core.Reminder
+	err = e.selectByEventIDs(ctx, &reminders, `SELECT id, event_id, user_id, offset_minutes, channel FROM reminder WHERE event_id IN (?)`, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range schedules {
+		event := &events[positions[schedule.EventID]]
+		event.Schedules = append(event.Schedules, schedule)
+	}
+	for _, invitation := range invitations {
+		event := &events[positions[invitation.EventID]]
+		event.Invitations = append(event.Invitations, invitation)
+	}
+	for _, resource := range resources {
+		event := &events[positions[resource.EventID]]
+		event.Resources = append(event.Resources, resource)
+	}
+	for _, guest := range guests {
+		event := &events[positions[guest.EventID]]
+		event.Guests = append(event.Guests, guest)
+	}
+	for _, reminder := range reminders {
+		attachReminders(&events[positions[reminder.EventID]], []core.Reminder{reminder})
+	}
+	return nil
+}
+
+func (e *EventRepository) selectByEventIDs(ctx context.Context, dest any, query string, ids []string) error {
+	query, args, err := sqlx.In(query, ids)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	err = e.dbConn.SelectContext(ctx, dest, e.dbConn.Rebind(query), args...)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern so that a title filter is matched literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func toCoreEvent(queryEvent gen.Event) core.Event {
+	event := core.Event{
+		ID:          queryEvent.ID,
+		Title:       queryEvent.Title,
+		Description: queryEvent.Description,
+		Timezone:    queryEvent.Timezone,
+		CreatedBy:   queryEvent.CreatedBy,
+		CreatedAt:   queryEvent.CreatedAt,
+		UpdatedAt:   &queryEvent.UpdatedAt.Time,
+		CalendarID:  queryEvent.CalendarID,
+		Capacity:    queryEvent.Capacity,
+	}
+
+	if queryEvent.DeletedAt.Valid {
+		event.DeletedAt = &queryEvent.DeletedAt.Time
+	}
+	return event
+}
+
+func toCoreSchedule(querySchedule gen.Schedule) core.Schedule {
+	return core.Schedule{
+		ID:                querySchedule.ID,
+		EventID:           querySchedule.EventID,
+		StartTime:         querySchedule.StartTime,
+		DurationInMinutes: querySchedule.Duration,
+		IsFullDay:         querySchedule.IsFullDay,
+		RecurringType:     core.RecurringType(querySchedule.RecurringType),
+		RecurringInterval: querySchedule.RecurringInterval,
+	}
 }
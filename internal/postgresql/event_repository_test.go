@@ -2,6 +2,7 @@ package postgresql_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/dzakaammar/event-scheduling-example/internal/core"
 	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 )
@@ -46,10 +48,11 @@ func TestEventRepository_Store(t *testing.T) {
 		event *core.Event
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
+		name      string
+		fields    fields
+		args      args
+		wantErr   bool
+		wantErrIs error
 	}{
 		{
 			name: "OK",
@@ -58,7 +61,88 @@ func TestEventRepository_Store(t *testing.T) {
 					db, mock, _ := sqlmock.New()
 
 					mock.ExpectBegin()
+					mock.ExpectQuery(`SELECT .+ FROM calendar WHERE owner_id = \$1 AND is_default`).WithArgs("1").
+						WillReturnRows(sqlmock.NewRows(calendarColumns).
+							AddRow("cal1", "1", "Default", "", "Asia/Jakarta", "", true, time.Now(), nil))
+					mock.ExpectExec(`INSERT INTO event`).
+						WithArgs("test", "test123", "test123", "Asia/Jakarta", "1", sqlmock.AnyArg(), sqlmock.AnyArg(), "cal1", int32(0)).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectCommit()
+					mock.MatchExpectationsInOrder(true)
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				event: &core.Event{
+					ID:          "test",
+					Title:       "test123",
+					Description: "test123",
+					Timezone:    "Asia/Jakarta",
+					CreatedBy:   "1",
+				},
+			},
+		},
+		{
+			name: "OK - the default calendar is created along with the first event",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectBegin()
+					mock.ExpectQuery(`SELECT .+ FROM calendar WHERE owner_id = \$1 AND is_default`).WithArgs("1").WillReturnError(sql.ErrNoRows)
+					mock.ExpectExec(`INSERT INTO calendar`).
+						WithArgs(sqlmock.AnyArg(), "1", "Default", "", "Asia/Jakarta", "", sqlmock.AnyArg()).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectQuery(`SELECT .+ FROM calendar WHERE owner_id = \$1 AND is_default`).WithArgs("1").
+						WillReturnRows(sqlmock.NewRows(calendarColumns).
+							AddRow("cal1", "1", "Default", "", "Asia/Jakarta", "", true, time.Now(), nil))
+					mock.ExpectExec(`INSERT INTO event`).
+						WithArgs("test", "test123", "test123", "Asia/Jakarta", "1", sqlmock.AnyArg(), sqlmock.AnyArg(), "cal1", int32(0)).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectCommit()
+					mock.MatchExpectationsInOrder(true)
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				event: &core.Event{
+					ID:          "test",
+					Title:       "test123",
+					Description: "test123",
+					Timezone:    "Asia/Jakarta",
+					CreatedBy:   "1",
+				},
+			},
+		},
+		{
+			name: "OK - along with the resources, declined when already booked",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectBegin()
+					mock.ExpectQuery(`SELECT .+ FROM calendar WHERE owner_id = \$1 AND is_default`).WithArgs("1").
+						WillReturnRows(sqlmock.NewRows(calendarColumns).
+							AddRow("cal1", "1", "Default", "", "Asia/Jakarta", "", true, time.Now(), nil))
 					mock.ExpectExec(`INSERT INTO event`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`INSERT INTO schedule`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectQuery(`SELECT id FROM resource WHERE id = \$1 FOR UPDATE`).WithArgs("room1").
+						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("room1"))
+					mock.ExpectQuery(`SELECT .+ FROM resource_booking`).WithArgs("room1", "test", int16(core.InvitationStatus_Confirmed)).
+						WillReturnRows(sqlmock.NewRows(scheduleColumns))
+					mock.ExpectExec(`INSERT INTO resource_booking`).
+						WithArgs("test", "room1", int16(core.InvitationStatus_Confirmed), sqlmock.AnyArg()).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectQuery(`SELECT id FROM resource WHERE id = \$1 FOR UPDATE`).WithArgs("room2").
+						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("room2"))
+					mock.ExpectQuery(`SELECT .+ FROM resource_booking`).WithArgs("room2", "test", int16(core.InvitationStatus_Confirmed)).
+						WillReturnRows(sqlmock.NewRows(scheduleColumns).
+							AddRow("s2", "other", int64(1704101400), int64(60), false, int64(0), ""))
+					mock.ExpectExec(`INSERT INTO resource_booking`).
+						WithArgs("test", "room2", int16(core.InvitationStatus_Declined), sqlmock.AnyArg()).
+						WillReturnResult(sqlmock.NewResult(1, 1))
 					mock.ExpectCommit()
 					mock.MatchExpectationsInOrder(true)
 					return sqlx.NewDb(db, "pgx")
@@ -71,8 +155,73 @@ func TestEventRepository_Store(t *testing.T) {
 					Title:       "test123",
 					Description: "test123",
 					Timezone:    "Asia/Jakarta",
+					CreatedBy:   "1",
+					Schedules: []core.Schedule{
+						{ID: "s1", EventID: "test", StartTime: 1704099600, DurationInMinutes: 60},
+					},
+					Resources: []core.ResourceBooking{{ResourceID: "room2"}, {ResourceID: "room1"}},
+				},
+			},
+		},
+		{
+			name: "Not OK - resource not found",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectBegin()
+					mock.ExpectQuery(`SELECT .+ FROM calendar WHERE owner_id = \$1 AND is_default`).WithArgs("1").
+						WillReturnRows(sqlmock.NewRows(calendarColumns).
+							AddRow("cal1", "1", "Default", "", "Asia/Jakarta", "", true, time.Now(), nil))
+					mock.ExpectExec(`INSERT INTO event`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectQuery(`SELECT id FROM resource WHERE id = \$1 FOR UPDATE`).WithArgs("room1").
+						WillReturnError(sql.ErrNoRows)
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				event: &core.Event{
+					ID:          "test",
+					Title:       "test123",
+					Description: "test123",
+					Timezone:    "Asia/Jakarta",
+					CreatedBy:   "1",
+					Resources:   []core.ResourceBooking{{ResourceID: "room1"}},
+				},
+			},
+			wantErr:   true,
+			wantErrIs: core.ErrResourceNotFound,
+		},
+		{
+			name: "Not OK - calendar of another user",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectQuery(`SELECT .+ FROM calendar WHERE id = \$1`).WithArgs("cal2").
+						WillReturnRows(sqlmock.NewRows(calendarColumns).
+							AddRow("cal2", "2", "Work", "", "Asia/Jakarta", "", false, time.Now(), nil))
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				event: &core.Event{
+					ID:          "test",
+					Title:       "test123",
+					Description: "test123",
+					Timezone:    "Asia/Jakarta",
+					CreatedBy:   "1",
+					CalendarID:  "cal2",
 				},
 			},
+			wantErr:   true,
+			wantErrIs: core.ErrCalendarNotFound,
 		},
 		{
 			name: "Not OK - error",
@@ -80,6 +229,9 @@ func TestEventRepository_Store(t *testing.T) {
 				dbMock: func(t *testing.T) *sqlx.DB {
 					db, mock, _ := sqlmock.New()
 					mock.ExpectBegin()
+					mock.ExpectQuery(`SELECT .+ FROM calendar WHERE owner_id = \$1 AND is_default`).WithArgs("1").
+						WillReturnRows(sqlmock.NewRows(calendarColumns).
+							AddRow("cal1", "1", "Default", "", "Asia/Jakarta", "", true, time.Now(), nil))
 					mock.ExpectExec(`INSERT INTO event`).WillReturnError(errors.New("error")) //nolint:goerr113
 					mock.ExpectRollback()
 					mock.MatchExpectationsInOrder(true)
@@ -93,17 +245,49 @@ func TestEventRepository_Store(t *testing.T) {
 					Title:       "test123",
 					Description: "test123",
 					Timezone:    "Asia/Jakarta",
+					CreatedBy:   "1",
 				},
 			},
 			wantErr: true,
 		},
+		{
+			name: "Not OK - id already taken",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectQuery(`SELECT .+ FROM calendar WHERE owner_id = \$1 AND is_default`).WithArgs("1").
+						WillReturnRows(sqlmock.NewRows(calendarColumns).
+							AddRow("cal1", "1", "Default", "", "Asia/Jakarta", "", true, time.Now(), nil))
+					mock.ExpectExec(`INSERT INTO event`).WillReturnError(&pgconn.PgError{Code: "23505"})
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				event: &core.Event{
+					ID:          "test",
+					Title:       "test123",
+					Description: "test123",
+					Timezone:    "Asia/Jakarta",
+					CreatedBy:   "1",
+				},
+			},
+			wantErr:   true,
+			wantErrIs: core.ErrEventAlreadyExists,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := postgresql.NewEventRepository(tt.fields.dbMock(t))
-			err := e.Store(tt.args.ctx, tt.args.event)
+			err := e.Store(tt.args.ctx, tt.args.event, core.Journal{})
 			if tt.wantErr {
 				assert.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
 				return
 			}
 			assert.NoError(t, err)
@@ -120,17 +304,20 @@ func TestEventRepository_DeleteByID(t *testing.T) {
 		id  string
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
+		name      string
+		fields    fields
+		args      args
+		wantErr   bool
+		wantErrIs error
 	}{
 		{
 			name: "OK",
 			fields: fields{
 				dbMock: func(t *testing.T) *sqlx.DB {
 					db, mock, _ := sqlmock.New()
-					mock.ExpectExec(`DELETE FROM event`).WithArgs("test123").WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectBegin()
+					mock.ExpectExec(`UPDATE event SET deleted_at`).WithArgs("test123", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectCommit()
 					mock.MatchExpectationsInOrder(true)
 
 					return sqlx.NewDb(db, "pgx")
@@ -147,7 +334,9 @@ func TestEventRepository_DeleteByID(t *testing.T) {
 				dbMock: func(t *testing.T) *sqlx.DB {
 					db, mock, _ := sqlmock.New()
 
-					mock.ExpectExec(`DELETE FROM event`).WithArgs("test123").WillReturnError(errors.New("error")) //nolint:goerr113
+					mock.ExpectBegin()
+					mock.ExpectExec(`UPDATE event SET deleted_at`).WithArgs("test123", sqlmock.AnyArg()).WillReturnError(errors.New("error")) //nolint:goerr113
+					mock.ExpectRollback()
 					mock.MatchExpectationsInOrder(true)
 
 					return sqlx.NewDb(db, "postgres")
@@ -159,13 +348,37 @@ func TestEventRepository_DeleteByID(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Not OK - not found",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectBegin()
+					mock.ExpectExec(`UPDATE event SET deleted_at`).WithArgs("test123", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  "test123",
+			},
+			wantErr:   true,
+			wantErrIs: core.ErrEventNotFound,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := postgresql.NewEventRepository(tt.fields.dbMock(t))
-			err := e.DeleteByID(tt.args.ctx, tt.args.id)
+			err := e.DeleteByID(tt.args.ctx, tt.args.id, core.Journal{})
 			if tt.wantErr {
 				assert.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
 				return
 			}
 			assert.NoError(t, err)
@@ -182,10 +395,11 @@ func TestEventRepository_Update(t *testing.T) {
 		event *core.Event
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
+		name      string
+		fields    fields
+		args      args
+		wantErr   bool
+		wantErrIs error
 	}{
 		{
 			name: "OK",
@@ -197,6 +411,11 @@ func TestEventRepository_Update(t *testing.T) {
 					mock.ExpectExec(`UPDATE event SET`).WillReturnResult(sqlmock.NewResult(1, 1))
 					mock.ExpectExec(`INSERT INTO schedule`).WillReturnResult(sqlmock.NewResult(1, 1))
 					mock.ExpectExec(`INSERT INTO invitation`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`DELETE FROM resource_booking`).WithArgs("123").WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectExec(`DELETE FROM reminder`).WithArgs("123", int32(0)).WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectExec(`INSERT INTO reminder`).WithArgs("r1", "123", int32(0), int64(10), "EMAIL", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+					// the reminders of the attendees are scheduled again along with the ones of the event
+					mock.ExpectExec(`UPDATE reminder SET next_fire_at`).WithArgs(sqlmock.AnyArg(), "123").WillReturnResult(sqlmock.NewResult(0, 2))
 					mock.ExpectCommit()
 					mock.MatchExpectationsInOrder(true)
 
@@ -207,6 +426,9 @@ func TestEventRepository_Update(t *testing.T) {
 				ctx: context.Background(),
 				event: &core.Event{
 					ID: "123",
+					Reminders: []core.Reminder{
+						{ID: "r1", EventID: "123", OffsetMinutes: 10, Channel: core.ReminderChannel_Email},
+					},
 					Schedules: []core.Schedule{
 						{
 							ID:                "123",
@@ -274,13 +496,39 @@ func TestEventRepository_Update(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Not OK - not found",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectBegin()
+					mock.ExpectExec(`UPDATE event SET`).WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				event: &core.Event{
+					ID: "123",
+				},
+			},
+			wantErr:   true,
+			wantErrIs: core.ErrEventNotFound,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := postgresql.NewEventRepository(tt.fields.dbMock(t))
-			err := e.Update(tt.args.ctx, tt.args.event)
+			err := e.Update(tt.args.ctx, tt.args.event, core.Journal{})
 			if tt.wantErr {
 				assert.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
 				return
 			}
 			assert.NoError(t, err)
@@ -299,11 +547,12 @@ func TestEventRepository_FindByID(t *testing.T) {
 
 	now := time.Now()
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    *core.Event
-		wantErr bool
+		name      string
+		fields    fields
+		args      args
+		want      *core.Event
+		wantErr   bool
+		wantErrIs error
 	}{
 		{
 			name: "OK",
@@ -312,11 +561,20 @@ func TestEventRepository_FindByID(t *testing.T) {
 					db, mock, _ := sqlmock.New()
 
 					mock.ExpectQuery(`SELECT .+ FROM event`).WithArgs("123").WillReturnRows(
-						sqlmock.NewRows([]string{"id", "title", "description", "timezone", "created_by", "created_at", "updated_at"}).
-							AddRow("123", "title", "desc", "Asia/Jakarta", "1", now, now),
+						sqlmock.NewRows([]string{"id", "title", "description", "timezone", "created_by", "created_at", "updated_at", "deleted_at", "search_vector", "calendar_id", "capacity"}).
+							AddRow("123", "title", "desc", "Asia/Jakarta", "1", now, now, nil, nil, "cal1", 0),
 					)
 					mock.ExpectQuery(`SELECT .+ FROM schedule`).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"id"}))
 					mock.ExpectQuery(`SELECT .+ FROM invitation`).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+					mock.ExpectQuery(`SELECT .+ FROM resource_booking`).WithArgs("123").
+						WillReturnRows(sqlmock.NewRows([]string{"event_id", "resource_id", "status"}).
+							AddRow("123", "room1", 1))
+					mock.ExpectQuery(`SELECT .+ FROM guest`).WithArgs("123").
+						WillReturnRows(sqlmock.NewRows([]string{"event_id", "email", "name"}).
+							AddRow("123", "ana@example.org", "Ana"))
+					mock.ExpectQuery(`SELECT .+ FROM reminder`).WithArgs("123").
+						WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "offset_minutes", "channel"}).
+							AddRow("r1", "123", 0, 10, "EMAIL"))
 					mock.MatchExpectationsInOrder(true)
 
 					return sqlx.NewDb(db, "pgx")
@@ -334,8 +592,18 @@ func TestEventRepository_FindByID(t *testing.T) {
 				CreatedBy:   "1",
 				CreatedAt:   now,
 				UpdatedAt:   &now,
+				CalendarID:  "cal1",
 				Invitations: []core.Invitation(nil),
 				Schedules:   []core.Schedule(nil),
+				Resources: []core.ResourceBooking{
+					{EventID: "123", ResourceID: "room1", Status: core.InvitationStatus_Confirmed},
+				},
+				Guests: []core.Guest{
+					{EventID: "123", Name: "Ana", Email: "ana@example.org"},
+				},
+				Reminders: []core.Reminder{
+					{ID: "r1", EventID: "123", OffsetMinutes: 10, Channel: core.ReminderChannel_Email},
+				},
 			},
 		},
 		{
@@ -357,6 +625,26 @@ func TestEventRepository_FindByID(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Not OK - not found",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectQuery(`SELECT .+ FROM event`).WithArgs("123").WillReturnError(sql.ErrNoRows)
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  "123",
+			},
+			want:      nil,
+			wantErr:   true,
+			wantErrIs: core.ErrEventNotFound,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -364,6 +652,9 @@ func TestEventRepository_FindByID(t *testing.T) {
 			got, err := e.FindByID(tt.args.ctx, tt.args.id)
 			if tt.wantErr {
 				assert.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
 				return
 			}
 			assert.NoError(t, err)
@@ -371,3 +662,955 @@ func TestEventRepository_FindByID(t *testing.T) {
 		})
 	}
 }
+
+func TestEventRepository_RestoreByID(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+	now := time.Now()
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	eventColumns := []string{"id", "title", "description", "timezone", "created_by", "created_at", "updated_at", "deleted_at", "search_vector", "calendar_id", "capacity"}
+	deletedEvent := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT .+ FROM event WHERE id = \$1 AND deleted_at IS NOT NULL`).WithArgs("test123").
+			WillReturnRows(sqlmock.NewRows(eventColumns).
+				AddRow("test123", "title", "desc", "UTC", "1", now, now, now, nil, "cal1", 0))
+		mock.ExpectQuery(`SELECT .+ FROM schedule`).WithArgs("test123").
+			WillReturnRows(sqlmock.NewRows(scheduleColumns).
+				AddRow("s1", "test123", start.Unix(), int64(30), false, int64(0), "NONE"))
+		mock.ExpectQuery(`SELECT .+ FROM invitation`).WithArgs("test123").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(`SELECT .+ FROM resource_booking`).WithArgs("test123").
+			WillReturnRows(sqlmock.NewRows([]string{"event_id", "resource_id", "status"}).
+				AddRow("test123", "room1", int16(core.InvitationStatus_Confirmed)))
+		mock.ExpectQuery(`SELECT .+ FROM guest`).WithArgs("test123").WillReturnRows(sqlmock.NewRows([]string{"event_id"}))
+		mock.ExpectQuery(`SELECT .+ FROM reminder`).WithArgs("test123").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "OK - the room booked meanwhile by another event is declined",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					deletedEvent(mock)
+					mock.ExpectQuery(`SELECT .+ FROM booking WHERE event_id = \$1`).WithArgs("test123").WillReturnError(sql.ErrNoRows)
+					mock.ExpectExec(`UPDATE event SET deleted_at = NULL`).WithArgs("test123").WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`DELETE FROM resource_booking`).WithArgs("test123").WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectQuery(`SELECT id FROM resource WHERE id = \$1 FOR UPDATE`).WithArgs("room1").
+						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("room1"))
+					mock.ExpectQuery(`SELECT .+ FROM resource_booking`).WithArgs("room1", "test123", int16(core.InvitationStatus_Confirmed)).
+						WillReturnRows(sqlmock.NewRows(scheduleColumns).
+							AddRow("s2", "other", start.Add(15*time.Minute).Unix(), int64(30), false, int64(0), "NONE"))
+					mock.ExpectExec(`INSERT INTO resource_booking`).
+						WithArgs("test123", "room1", int16(core.InvitationStatus_Declined), sqlmock.AnyArg()).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectCommit()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  "test123",
+			},
+		},
+		{
+			name: "Not OK - the booked slot was taken meanwhile",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					deletedEvent(mock)
+					mock.ExpectQuery(`SELECT .+ FROM booking WHERE event_id = \$1`).WithArgs("test123").
+						WillReturnRows(sqlmock.NewRows([]string{"id", "page_id", "event_id", "start_time", "guest_name", "guest_email", "created_at"}).
+							AddRow("booking1", "page1", "test123", start, "Ana", "ana@example.org", start.Add(-24*time.Hour)))
+					mock.ExpectQuery(`SELECT .+ FROM booking_page WHERE id = \$1`).WithArgs("page1").
+						WillReturnRows(sqlmock.NewRows(bookingPageColumns).
+							AddRow("page1", "1", "Intro call", "", "UTC", 30, 0, 0, 0, 0, []byte(`[{"weekday":1,"start":"09:00","end":"17:00"}]`), now, nil))
+					mock.ExpectExec(`SELECT pg_advisory_xact_lock`).WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectQuery(`SELECT .+ FROM schedule JOIN event`).
+						WillReturnRows(sqlmock.NewRows(scheduleColumns).
+							AddRow("s2", "other", start.Unix(), int64(30), false, int64(0), "NONE"))
+					mock.ExpectQuery(`SELECT booking.start_time FROM booking`).WillReturnRows(sqlmock.NewRows([]string{"start_time"}))
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  "test123",
+			},
+			wantErr:   true,
+			wantErrIs: core.ErrSlotUnavailable,
+		},
+		{
+			name: "Not OK - not found",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectQuery(`SELECT .+ FROM event WHERE id = \$1 AND deleted_at IS NOT NULL`).WithArgs("test123").WillReturnError(sql.ErrNoRows)
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  "test123",
+			},
+			wantErr:   true,
+			wantErrIs: core.ErrEventNotFound,
+		},
+		{
+			name: "Not OK - error",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					deletedEvent(mock)
+					mock.ExpectQuery(`SELECT .+ FROM booking WHERE event_id = \$1`).WithArgs("test123").WillReturnError(sql.ErrNoRows)
+					mock.ExpectExec(`UPDATE event SET deleted_at = NULL`).WithArgs("test123").WillReturnError(errors.New("error")) //nolint:goerr113
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  "test123",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := postgresql.NewEventRepository(tt.fields.dbMock(t))
+			err := e.RestoreByID(tt.args.ctx, tt.args.id, core.Journal{})
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEventRepository_FindDeletedByCreator(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	type args struct {
+		ctx       context.Context
+		createdBy string
+	}
+
+	now := time.Now()
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []core.Event
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectQuery(`SELECT .+ FROM event WHERE created_by = \$1 AND deleted_at IS NOT NULL`).WithArgs("1").WillReturnRows(
+						sqlmock.NewRows([]string{"id", "title", "description", "timezone", "created_by", "created_at", "updated_at", "deleted_at", "search_vector", "calendar_id", "capacity"}).
+							AddRow("123", "title", "desc", "Asia/Jakarta", "1", now, now, now, nil, "cal1", 0),
+					)
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:       context.Background(),
+				createdBy: "1",
+			},
+			want: []core.Event{
+				{
+					ID:          "123",
+					Title:       "title",
+					Description: "desc",
+					Timezone:    "Asia/Jakarta",
+					CreatedBy:   "1",
+					CreatedAt:   now,
+					UpdatedAt:   &now,
+					CalendarID:  "cal1",
+					DeletedAt:   &now,
+				},
+			},
+		},
+		{
+			name: "Not OK - error",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectQuery(`SELECT .+ FROM event`).WithArgs("1").WillReturnError(errors.New("error")) //nolint:goerr113
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:       context.Background(),
+				createdBy: "1",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := postgresql.NewEventRepository(tt.fields.dbMock(t))
+			got, err := e.FindDeletedByCreator(tt.args.ctx, tt.args.createdBy)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestEventRepository_PurgeDeleted(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	type args struct {
+		ctx           context.Context
+		deletedBefore time.Time
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectExec(`DELETE FROM event WHERE deleted_at IS NOT NULL`).WillReturnResult(sqlmock.NewResult(0, 2))
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:           context.Background(),
+				deletedBefore: time.Now(),
+			},
+			want: 2,
+		},
+		{
+			name: "Not OK - error",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectExec(`DELETE FROM event WHERE deleted_at IS NOT NULL`).WillReturnError(errors.New("error")) //nolint:goerr113
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:           context.Background(),
+				deletedBefore: time.Now(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := postgresql.NewEventRepository(tt.fields.dbMock(t))
+			got, err := e.PurgeDeleted(tt.args.ctx, tt.args.deletedBefore)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEventRepository_RespondInvitation(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	type args struct {
+		ctx    context.Context
+		userID int32
+	}
+	now := time.Now()
+	eventColumns := []string{"id", "title", "description", "timezone", "created_by", "created_at", "updated_at", "deleted_at", "search_vector", "calendar_id", "capacity"}
+	invitationColumns := []string{"id", "event_id", "user_id", "token", "status", "updated_at", "group_id", "waitlisted_at"}
+	lockedEvent := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT .+ FROM event WHERE id = \$1 AND deleted_at IS NULL FOR UPDATE`).WithArgs("123").
+			WillReturnRows(sqlmock.NewRows(eventColumns).
+				AddRow("123", "title", "desc", "Asia/Jakarta", "1", now, now, nil, nil, "cal1", 1))
+		mock.ExpectQuery(`SELECT .+ FROM schedule`).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(`SELECT .+ FROM invitation`).WithArgs("123").
+			WillReturnRows(sqlmock.NewRows(invitationColumns).
+				AddRow("i1", "123", 2, "token", int16(core.InvitationStatus_Confirmed), nil, "", nil).
+				AddRow("i2", "123", 3, "token", int16(core.InvitationStatus_Waitlisted), nil, "", now))
+		mock.ExpectQuery(`SELECT .+ FROM resource_booking`).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"event_id"}))
+		mock.ExpectQuery(`SELECT .+ FROM guest`).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"event_id"}))
+		mock.ExpectQuery(`SELECT .+ FROM reminder`).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "OK - the first one on the waitlist is confirmed when an attendee declines",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					lockedEvent(mock)
+					mock.ExpectExec(`UPDATE invitation SET status`).
+						WithArgs("i1", int16(core.InvitationStatus_Declined), sqlmock.AnyArg(), sql.NullTime{}).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`UPDATE invitation SET status`).
+						WithArgs("i2", int16(core.InvitationStatus_Confirmed), sqlmock.AnyArg(), sql.NullTime{}).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`INSERT INTO event_history`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`INSERT INTO outbox`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectCommit()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: 2,
+			},
+		},
+		{
+			name: "Not OK - event not found",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectQuery(`SELECT .+ FROM event WHERE id = \$1 AND deleted_at IS NULL FOR UPDATE`).WithArgs("123").
+						WillReturnError(sql.ErrNoRows)
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: 2,
+			},
+			wantErr:   true,
+			wantErrIs: core.ErrEventNotFound,
+		},
+		{
+			name: "Not OK - the actor isn't invited",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					lockedEvent(mock)
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: 4,
+			},
+			wantErr:   true,
+			wantErrIs: core.ErrInvitationNotFound,
+		},
+		{
+			name: "Not OK - invitation not found",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					lockedEvent(mock)
+					mock.ExpectExec(`UPDATE invitation SET status`).WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: 2,
+			},
+			wantErr:   true,
+			wantErrIs: core.ErrInvitationNotFound,
+		},
+		{
+			name: "Not OK - error",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					lockedEvent(mock)
+					mock.ExpectExec(`UPDATE invitation SET status`).WillReturnError(errors.New("error")) //nolint:goerr113
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: 2,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := postgresql.NewEventRepository(tt.fields.dbMock(t))
+			err := e.RespondInvitation(tt.args.ctx, "123", func(event *core.Event) ([]core.Invitation, core.Journal, error) {
+				before := *event
+				changed, err := event.Respond(tt.args.userID, core.InvitationStatus_Declined, now)
+				if err != nil {
+					return nil, core.Journal{}, err
+				}
+				change := core.NewEventChange(event.ID, core.FormatUserID(tt.args.userID), core.HistoryOperation_RSVP, &before, event)
+				return changed, core.NewJournal(change, &before, event), nil
+			})
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEventRepository_List(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	type args struct {
+		ctx    context.Context
+		filter core.EventFilter
+	}
+
+	now := time.Now()
+	eventColumns := []string{"id", "title", "description", "timezone", "created_by", "created_at", "updated_at", "deleted_at", "search_vector", "calendar_id", "capacity"}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []core.Event
+		wantErr bool
+	}{
+		{
+			name: "OK - schedules, invitations and reminders are loaded for the whole page",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectQuery(`SELECT .+ FROM event`).
+						WithArgs(
+							sql.NullString{String: "1", Valid: true},
+							sql.NullInt32{},
+							sql.NullString{String: `50\%`, Valid: true},
+							sql.NullTime{},
+							sql.NullTime{},
+							sql.NullString{},
+							sql.NullString{},
+							sql.NullString{String: "2", Valid: true},
+							int32(2),
+							sql.NullTime{Time: now, Valid: true},
+							sql.NullString{String: "789", Valid: true},
+							int32(3),
+						).
+						WillReturnRows(sqlmock.NewRows(eventColumns).
+							AddRow("456", "title 2", "desc", "Asia/Jakarta", "1", now, now, nil, nil, "cal1", 0).
+							AddRow("123", "title 1", "desc", "Asia/Jakarta", "1", now, now, nil, nil, "cal1", 0))
+					mock.ExpectQuery(`SELECT \* FROM schedule WHERE event_id IN \(\$1, \$2\)`).WithArgs("456", "123").
+						WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "start_time", "duration", "is_full_day", "recurring_interval", "recurring_type"}).
+							AddRow("s1", "123", 1, 60, false, 0, "NONE").
+							AddRow("s2", "456", 2, 30, false, 0, "NONE"))
+					mock.ExpectQuery(`SELECT \* FROM invitation WHERE event_id IN \(\$1, \$2\)`).WithArgs("456", "123").
+						WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "token", "status", "updated_at"}).
+							AddRow("i1", "456", 2, "token", 0, nil))
+					mock.ExpectQuery(`SELECT .+ FROM resource_booking WHERE event_id IN \(\$1, \$2\)`).WithArgs("456", "123").
+						WillReturnRows(sqlmock.NewRows([]string{"event_id", "resource_id", "status"}).
+							AddRow("456", "room1", 2))
+					mock.ExpectQuery(`SELECT .+ FROM guest WHERE event_id IN \(\$1, \$2\)`).WithArgs("456", "123").
+						WillReturnRows(sqlmock.NewRows([]string{"event_id", "email", "name"}))
+					mock.ExpectQuery(`SELECT .+ FROM reminder WHERE event_id IN \(\$1, \$2\)`).WithArgs("456", "123").
+						WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "offset_minutes", "channel"}).
+							AddRow("r1", "456", 2, 10, "PUSH").
+							AddRow("r2", "123", 0, 60, "EMAIL"))
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				filter: core.EventFilter{
+					CreatedBy: "1",
+					Title:     "50%",
+					Viewer:    "2",
+					After:     &core.EventCursor{CreatedAt: now, ID: "789"},
+					Limit:     3,
+				},
+			},
+			want: []core.Event{
+				{
+					ID:          "456",
+					Title:       "title 2",
+					Description: "desc",
+					Timezone:    "Asia/Jakarta",
+					CreatedBy:   "1",
+					CreatedAt:   now,
+					UpdatedAt:   &now,
+					CalendarID:  "cal1",
+					Schedules: []core.Schedule{
+						{ID: "s2", EventID: "456", StartTime: 2, DurationInMinutes: 30, RecurringType: core.RecurringType_None},
+					},
+					Invitations: []core.Invitation{
+						{
+							ID: "i1", EventID: "456", UserID: 2, Token: "token", Status: core.InvitationStatus_Unknown,
+							Reminders: []core.Reminder{
+								{ID: "r1", EventID: "456", UserID: 2, OffsetMinutes: 10, Channel: core.ReminderChannel_Push},
+							},
+						},
+					},
+					Resources: []core.ResourceBooking{
+						{EventID: "456", ResourceID: "room1", Status: core.InvitationStatus_Declined},
+					},
+				},
+				{
+					ID:          "123",
+					Title:       "title 1",
+					Description: "desc",
+					Timezone:    "Asia/Jakarta",
+					CreatedBy:   "1",
+					CreatedAt:   now,
+					UpdatedAt:   &now,
+					CalendarID:  "cal1",
+					Schedules: []core.Schedule{
+						{ID: "s1", EventID: "123", StartTime: 1, DurationInMinutes: 60, RecurringType: core.RecurringType_None},
+					},
+					Reminders: []core.Reminder{
+						{ID: "r2", EventID: "123", OffsetMinutes: 60, Channel: core.ReminderChannel_Email},
+					},
+				},
+			},
+		},
+		{
+			name: "OK - the events of a calendar shared free/busy don't match on their content",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectQuery(`FROM calendar_acl .+ AND \( calendar_acl.role <> 'FREE_BUSY' OR \( \$3::text IS NULL AND \$2::integer IS NULL \) \)`).
+						WithArgs(
+							sql.NullString{},
+							sql.NullInt32{},
+							sql.NullString{String: "weekly", Valid: true},
+							sql.NullTime{},
+							sql.NullTime{},
+							sql.NullString{},
+							sql.NullString{},
+							sql.NullString{String: "2", Valid: true},
+							int32(2),
+							sql.NullTime{},
+							sql.NullString{},
+							int32(3),
+						).
+						WillReturnRows(sqlmock.NewRows(eventColumns))
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:    context.Background(),
+				filter: core.EventFilter{Title: "weekly", Viewer: "2", Limit: 3},
+			},
+			want: []core.Event{},
+		},
+		{
+			name: "OK - empty page",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectQuery(`SELECT .+ FROM event`).WillReturnRows(sqlmock.NewRows(eventColumns))
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:    context.Background(),
+				filter: core.EventFilter{Limit: 3},
+			},
+			want: []core.Event{},
+		},
+		{
+			name: "Not OK - error",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectQuery(`SELECT .+ FROM event`).WillReturnError(errors.New("error")) //nolint:goerr113
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:    context.Background(),
+				filter: core.EventFilter{Limit: 3},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := postgresql.NewEventRepository(tt.fields.dbMock(t))
+			got, err := e.List(tt.args.ctx, tt.args.filter)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestEventRepository_Search(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	type args struct {
+		ctx     context.Context
+		actorID string
+		query   string
+		offset  int
+		limit   int
+	}
+
+	now := time.Now()
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []core.Event
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectQuery(`SELECT .+ FROM event, websearch_to_tsquery`).WithArgs("quarterly planning", "1", int32(11), int32(10), int32(1)).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "timezone", "created_by", "created_at", "updated_at", "deleted_at", "calendar_id", "capacity", "rank"}).
+							AddRow("123", "Quarterly Planning", "desc", "Asia/Jakarta", "2", now, now, nil, "cal1", 0, 0.6))
+					mock.ExpectQuery(`SELECT \* FROM schedule WHERE event_id IN \(\$1\)`).WithArgs("123").
+						WillReturnRows(sqlmock.NewRows([]string{"id"}))
+					mock.ExpectQuery(`SELECT \* FROM invitation WHERE event_id IN \(\$1\)`).WithArgs("123").
+						WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "token", "status", "updated_at"}).
+							AddRow("i1", "123", 1, "token", 0, nil))
+					mock.ExpectQuery(`SELECT .+ FROM resource_booking WHERE event_id IN \(\$1\)`).WithArgs("123").
+						WillReturnRows(sqlmock.NewRows([]string{"event_id", "resource_id", "status"}))
+					mock.ExpectQuery(`SELECT .+ FROM guest WHERE event_id IN \(\$1\)`).WithArgs("123").
+						WillReturnRows(sqlmock.NewRows([]string{"event_id", "email", "name"}))
+					mock.ExpectQuery(`SELECT .+ FROM reminder WHERE event_id IN \(\$1\)`).WithArgs("123").
+						WillReturnRows(sqlmock.NewRows([]string{"id"}))
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				actorID: "1",
+				query:   "quarterly planning",
+				offset:  10,
+				limit:   11,
+			},
+			want: []core.Event{
+				{
+					ID:          "123",
+					Title:       "Quarterly Planning",
+					Description: "desc",
+					Timezone:    "Asia/Jakarta",
+					CreatedBy:   "2",
+					CreatedAt:   now,
+					UpdatedAt:   &now,
+					CalendarID:  "cal1",
+					Invitations: []core.Invitation{
+						{ID: "i1", EventID: "123", UserID: 1, Token: "token", Status: core.InvitationStatus_Unknown},
+					},
+				},
+			},
+		},
+		{
+			name: "Not OK - error",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectQuery(`SELECT .+ FROM event, websearch_to_tsquery`).WillReturnError(errors.New("error")) //nolint:goerr113
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				actorID: "1",
+				query:   "quarterly planning",
+				limit:   11,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := postgresql.NewEventRepository(tt.fields.dbMock(t))
+			got, err := e.Search(tt.args.ctx, tt.args.actorID, tt.args.query, tt.args.offset, tt.args.limit)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestEventRepository_ListInvolved(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	type args struct {
+		ctx    context.Context
+		userID string
+		from   time.Time
+		to     time.Time
+	}
+
+	now := time.Now()
+	from := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []core.Event
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectQuery(`SELECT .+ FROM event WHERE deleted_at IS NULL AND \( created_by = \$1 .+ FROM schedule`).
+						WithArgs("2", int32(2), int16(core.InvitationStatus_Declined), from.Unix(), to.Unix()).
+						WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "timezone", "created_by", "created_at", "updated_at", "deleted_at", "search_vector", "calendar_id", "capacity"}).
+							AddRow("123", "Standup", "desc", "UTC", "1", now, now, nil, "", "cal1", 0))
+					mock.ExpectQuery(`SELECT \* FROM schedule WHERE event_id IN \(\$1\)`).WithArgs("123").
+						WillReturnRows(sqlmock.NewRows([]string{"id"}))
+					mock.ExpectQuery(`SELECT \* FROM invitation WHERE event_id IN \(\$1\)`).WithArgs("123").
+						WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "token", "status", "updated_at"}).
+							AddRow("i2", "123", 2, "token", 1, nil))
+					mock.ExpectQuery(`SELECT .+ FROM resource_booking WHERE event_id IN \(\$1\)`).WithArgs("123").
+						WillReturnRows(sqlmock.NewRows([]string{"event_id", "resource_id", "status"}))
+					mock.ExpectQuery(`SELECT .+ FROM guest WHERE event_id IN \(\$1\)`).WithArgs("123").
+						WillReturnRows(sqlmock.NewRows([]string{"event_id", "email", "name"}))
+					mock.ExpectQuery(`SELECT .+ FROM reminder WHERE event_id IN \(\$1\)`).WithArgs("123").
+						WillReturnRows(sqlmock.NewRows([]string{"id"}))
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: "2",
+				from:   from,
+				to:     to,
+			},
+			want: []core.Event{
+				{
+					ID:          "123",
+					Title:       "Standup",
+					Description: "desc",
+					Timezone:    "UTC",
+					CreatedBy:   "1",
+					CreatedAt:   now,
+					UpdatedAt:   &now,
+					CalendarID:  "cal1",
+					Invitations: []core.Invitation{
+						{ID: "i2", EventID: "123", UserID: 2, Token: "token", Status: core.InvitationStatus_Confirmed},
+					},
+				},
+			},
+		},
+		{
+			name: "Not OK - error",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectQuery(`SELECT .+ FROM event WHERE deleted_at IS NULL`).WillReturnError(errors.New("error")) //nolint:goerr113
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: "2",
+				from:   from,
+				to:     to,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := postgresql.NewEventRepository(tt.fields.dbMock(t))
+			got, err := e.ListInvolved(tt.args.ctx, tt.args.userID, tt.args.from, tt.args.to)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestEventRepository_Batch(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	type args struct {
+		ctx       context.Context
+		mutations []core.EventMutation
+	}
+	// the repository fills in the calendar of the created event, each case gets its own mutations
+	mutations := func() []core.EventMutation {
+		return []core.EventMutation{
+			{Type: core.MutationType_Create, Event: &core.Event{ID: "new", Title: "test", Timezone: "Asia/Jakarta"}},
+			{Type: core.MutationType_Delete, Event: &core.Event{ID: "old"}},
+		}
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantErr      bool
+		wantErrIndex int
+		wantErrIs    error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectBegin()
+					mock.ExpectQuery(`SELECT .+ FROM calendar WHERE owner_id = \$1 AND is_default`).WithArgs("").
+						WillReturnRows(sqlmock.NewRows(calendarColumns).AddRow("cal1", "", "Default", "", "Asia/Jakarta", "", true, time.Now(), nil))
+					mock.ExpectExec(`INSERT INTO event`).WithArgs("new", "test", "", "Asia/Jakarta", "", sqlmock.AnyArg(), sqlmock.AnyArg(), "cal1", int32(0)).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`UPDATE event SET deleted_at`).WithArgs("old", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectCommit()
+					mock.MatchExpectationsInOrder(true)
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:       context.Background(),
+				mutations: mutations(),
+			},
+		},
+		{
+			name: "Not OK - one mutation fails",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+
+					mock.ExpectBegin()
+					mock.ExpectQuery(`SELECT .+ FROM calendar`).WithArgs("").
+						WillReturnRows(sqlmock.NewRows(calendarColumns).AddRow("cal1", "", "Default", "", "Asia/Jakarta", "", true, time.Now(), nil))
+					mock.ExpectExec(`INSERT INTO event`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`UPDATE event SET deleted_at`).WithArgs("old", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			args: args{
+				ctx:       context.Background(),
+				mutations: mutations(),
+			},
+			wantErr:      true,
+			wantErrIndex: 1,
+			wantErrIs:    core.ErrEventNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := postgresql.NewEventRepository(tt.fields.dbMock(t))
+			err := e.Batch(tt.args.ctx, tt.args.mutations)
+			if tt.wantErr {
+				var batchErr *core.BatchError
+				assert.ErrorAs(t, err, &batchErr)
+				assert.Equal(t, tt.wantErrIndex, batchErr.Index)
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
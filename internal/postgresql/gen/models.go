@@ -6,28 +6,207 @@ package gen
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 )
 
-type Event struct {
+type AgendaDelivery struct {
+	ID            int64
+	UserID        string
+	AgendaDate    time.Time
+	Channel       string
+	Status        string
+	Attempts      int32
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	SentAt        sql.NullTime
+}
+
+type Booking struct {
+	ID         string
+	PageID     string
+	EventID    string
+	StartTime  time.Time
+	GuestName  string
+	GuestEmail string
+	CreatedAt  time.Time
+}
+
+type BookingPage struct {
+	ID           string
+	OwnerID      string
+	Title        string
+	Description  string
+	Timezone     string
+	Duration     int32
+	BufferBefore int32
+	BufferAfter  int32
+	MinNotice    int32
+	MaxPerDay    int32
+	Rules        json.RawMessage
+	CreatedAt    time.Time
+	UpdatedAt    sql.NullTime
+}
+
+type Calendar struct {
 	ID          string
-	Title       string
-	Description string
+	OwnerID     string
+	Name        string
+	Color       string
 	Timezone    string
-	CreatedBy   string
+	Description string
+	IsDefault   bool
 	CreatedAt   time.Time
 	UpdatedAt   sql.NullTime
 }
 
+type CalendarAcl struct {
+	CalendarID  string
+	GranteeType string
+	GranteeID   string
+	Role        string
+	GrantedBy   string
+	CreatedAt   time.Time
+	UpdatedAt   sql.NullTime
+}
+
+type Event struct {
+	ID           string
+	Title        string
+	Description  string
+	Timezone     string
+	CreatedBy    string
+	CreatedAt    time.Time
+	UpdatedAt    sql.NullTime
+	DeletedAt    sql.NullTime
+	SearchVector interface{}
+	CalendarID   string
+	Capacity     int32
+}
+
+type EventHistory struct {
+	ID         int64
+	EventID    string
+	ActorID    string
+	Operation  string
+	Changes    json.RawMessage
+	CreatedAt  time.Time
+	OnBehalfOf string
+}
+
+type EventMute struct {
+	UserID    string
+	EventID   string
+	CreatedAt time.Time
+}
+
+type Guest struct {
+	EventID string
+	Email   string
+	Name    string
+}
+
+type IdempotencyKey struct {
+	ActorID     string
+	Key         string
+	RequestHash string
+	EventID     string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	State       string
+	Response    json.RawMessage
+}
+
 type Invitation struct {
+	ID           string
+	EventID      string
+	UserID       int32
+	Token        string
+	Status       int16
+	UpdatedAt    sql.NullTime
+	GroupID      string
+	WaitlistedAt sql.NullTime
+}
+
+type NotificationDigestItem struct {
+	ID             int64
+	UserID         string
+	DedupKey       string
+	Subject        string
+	Body           string
+	CalendarMethod string
+	Calendar       string
+	SendAfter      time.Time
+	CreatedAt      time.Time
+}
+
+type NotificationPreference struct {
+	UserID          string
+	Channels        json.RawMessage
+	Timezone        string
+	QuietHoursStart string
+	QuietHoursEnd   string
+	Delivery        string
+	DigestTime      string
+	UpdatedAt       time.Time
+}
+
+type Outbox struct {
+	ID            string
+	Type          string
+	EventID       string
+	ActorID       string
+	Payload       json.RawMessage
+	OccurredAt    time.Time
+	Attempts      int32
+	LastError     string
+	NextAttemptAt time.Time
+	PublishedAt   sql.NullTime
+}
+
+type Reminder struct {
+	ID            string
+	EventID       string
+	UserID        int32
+	OffsetMinutes int64
+	Channel       string
+	NextFireAt    sql.NullTime
+}
+
+type ReminderDelivery struct {
+	ID            int64
+	EventID       string
+	RecipientID   string
+	Channel       string
+	OffsetMinutes int64
+	OccurrenceAt  time.Time
+	Status        string
+	Attempts      int32
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	SentAt        sql.NullTime
+}
+
+type Resource struct {
 	ID        string
-	EventID   string
-	UserID    int32
-	Token     string
-	Status    int16
+	Name      string
+	Type      string
+	Capacity  int32
+	Location  string
+	CreatedBy string
+	CreatedAt time.Time
 	UpdatedAt sql.NullTime
 }
 
+type ResourceBooking struct {
+	EventID    string
+	ResourceID string
+	Status     int16
+	CreatedAt  time.Time
+}
+
 type Schedule struct {
 	ID                string
 	EventID           string
@@ -42,3 +221,44 @@ type User struct {
 	ID   int32
 	Name string
 }
+
+type UserGroup struct {
+	ID          string
+	OwnerID     string
+	Name        string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   sql.NullTime
+}
+
+type UserGroupMember struct {
+	GroupID   string
+	UserID    int32
+	CreatedAt time.Time
+}
+
+type Webhook struct {
+	ID         string
+	OwnerID    string
+	Url        string
+	Secret     string
+	EventTypes json.RawMessage
+	CreatedAt  time.Time
+	UpdatedAt  sql.NullTime
+}
+
+type WebhookDelivery struct {
+	ID             int64
+	WebhookID      string
+	EventID        string
+	EventType      string
+	Payload        string
+	Status         string
+	Attempts       int32
+	ResponseStatus int32
+	LastError      string
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+	DeliveredAt    sql.NullTime
+	OutboxID       sql.NullString
+}
@@ -8,273 +8,3920 @@ package gen
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 )
 
-const createEvent = `-- name: CreateEvent :exec
+const claimDueAgendaDeliveries = `-- name: ClaimDueAgendaDeliveries :many
+UPDATE
+    agenda_delivery
+SET
+    next_attempt_at = $1
+WHERE
+    id IN (
+        SELECT
+            id
+        FROM
+            agenda_delivery AS due
+        WHERE
+            due.status = $2
+            AND due.next_attempt_at <= $3
+        ORDER BY
+            due.next_attempt_at
+        LIMIT
+            $4 FOR UPDATE SKIP LOCKED
+    ) RETURNING id, user_id, agenda_date, channel, status, attempts, last_error, next_attempt_at, created_at, sent_at
+`
+
+type ClaimDueAgendaDeliveriesParams struct {
+	LeaseUntil time.Time
+	Status     string
+	Now        time.Time
+	Limit      int32
+}
+
+func (q *Queries) ClaimDueAgendaDeliveries(ctx context.Context, arg ClaimDueAgendaDeliveriesParams) ([]AgendaDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, claimDueAgendaDeliveries,
+		arg.LeaseUntil,
+		arg.Status,
+		arg.Now,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AgendaDelivery
+	for rows.Next() {
+		var i AgendaDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.AgendaDate,
+			&i.Channel,
+			&i.Status,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.CreatedAt,
+			&i.SentAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const claimDueNotificationDigestItems = `-- name: ClaimDueNotificationDigestItems :many
+UPDATE
+    notification_digest_item
+SET
+    send_after = $1
+WHERE
+    id IN (
+        SELECT
+            id
+        FROM
+            notification_digest_item AS due
+        WHERE
+            due.send_after <= $2
+        ORDER BY
+            due.user_id,
+            due.id
+        LIMIT
+            $3 FOR UPDATE SKIP LOCKED
+    ) RETURNING id, user_id, dedup_key, subject, body, calendar_method, calendar, send_after, created_at
+`
+
+type ClaimDueNotificationDigestItemsParams struct {
+	LeaseUntil time.Time
+	Now        time.Time
+	Limit      int32
+}
+
+func (q *Queries) ClaimDueNotificationDigestItems(ctx context.Context, arg ClaimDueNotificationDigestItemsParams) ([]NotificationDigestItem, error) {
+	rows, err := q.db.QueryContext(ctx, claimDueNotificationDigestItems, arg.LeaseUntil, arg.Now, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NotificationDigestItem
+	for rows.Next() {
+		var i NotificationDigestItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.DedupKey,
+			&i.Subject,
+			&i.Body,
+			&i.CalendarMethod,
+			&i.Calendar,
+			&i.SendAfter,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const claimDueReminderDeliveries = `-- name: ClaimDueReminderDeliveries :many
+UPDATE
+    reminder_delivery
+SET
+    next_attempt_at = $1
+WHERE
+    id IN (
+        SELECT
+            id
+        FROM
+            reminder_delivery AS due
+        WHERE
+            due.status = $2
+            AND due.next_attempt_at <= $3
+        ORDER BY
+            due.next_attempt_at
+        LIMIT
+            $4 FOR UPDATE SKIP LOCKED
+    ) RETURNING id, event_id, recipient_id, channel, offset_minutes, occurrence_at, status, attempts, last_error, next_attempt_at, created_at, sent_at
+`
+
+type ClaimDueReminderDeliveriesParams struct {
+	LeaseUntil time.Time
+	Status     string
+	Now        time.Time
+	Limit      int32
+}
+
+func (q *Queries) ClaimDueReminderDeliveries(ctx context.Context, arg ClaimDueReminderDeliveriesParams) ([]ReminderDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, claimDueReminderDeliveries,
+		arg.LeaseUntil,
+		arg.Status,
+		arg.Now,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReminderDelivery
+	for rows.Next() {
+		var i ReminderDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.RecipientID,
+			&i.Channel,
+			&i.OffsetMinutes,
+			&i.OccurrenceAt,
+			&i.Status,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.CreatedAt,
+			&i.SentAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const claimDueReminders = `-- name: ClaimDueReminders :many
+UPDATE
+    reminder
+SET
+    next_fire_at = $1
+WHERE
+    id IN (
+        SELECT
+            due.id
+        FROM
+            reminder AS due
+            JOIN event ON event.id = due.event_id
+        WHERE
+            event.deleted_at IS NULL
+            AND due.next_fire_at <= $2
+        ORDER BY
+            due.next_fire_at
+        LIMIT
+            $3 FOR UPDATE OF due SKIP LOCKED
+    ) RETURNING id, event_id, user_id, offset_minutes, channel, next_fire_at
+`
+
+type ClaimDueRemindersParams struct {
+	LeaseUntil sql.NullTime
+	Now        sql.NullTime
+	Limit      int32
+}
+
+func (q *Queries) ClaimDueReminders(ctx context.Context, arg ClaimDueRemindersParams) ([]Reminder, error) {
+	rows, err := q.db.QueryContext(ctx, claimDueReminders, arg.LeaseUntil, arg.Now, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Reminder
+	for rows.Next() {
+		var i Reminder
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.UserID,
+			&i.OffsetMinutes,
+			&i.Channel,
+			&i.NextFireAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const claimDueWebhookDeliveries = `-- name: ClaimDueWebhookDeliveries :many
+UPDATE
+    webhook_delivery
+SET
+    next_attempt_at = $1
+WHERE
+    id IN (
+        SELECT
+            id
+        FROM
+            webhook_delivery AS due
+        WHERE
+            due.status = $2
+            AND due.next_attempt_at <= $3
+        ORDER BY
+            due.next_attempt_at
+        LIMIT
+            $4 FOR UPDATE SKIP LOCKED
+    ) RETURNING id, webhook_id, event_id, event_type, payload, status, attempts, response_status, last_error, next_attempt_at, created_at, delivered_at, outbox_id
+`
+
+type ClaimDueWebhookDeliveriesParams struct {
+	LeaseUntil time.Time
+	Status     string
+	Now        time.Time
+	Limit      int32
+}
+
+func (q *Queries) ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, claimDueWebhookDeliveries,
+		arg.LeaseUntil,
+		arg.Status,
+		arg.Now,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.ResponseStatus,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.CreatedAt,
+			&i.DeliveredAt,
+			&i.OutboxID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const claimPendingOutboxMessages = `-- name: ClaimPendingOutboxMessages :many
+UPDATE
+    outbox
+SET
+    next_attempt_at = $1
+WHERE
+    id IN (
+        SELECT
+            id
+        FROM
+            outbox AS pending
+        WHERE
+            pending.published_at IS NULL
+            AND pending.next_attempt_at <= $2
+        ORDER BY
+            pending.occurred_at
+        LIMIT
+            $3 FOR UPDATE SKIP LOCKED
+    ) RETURNING id, type, event_id, actor_id, payload, occurred_at, attempts, last_error, next_attempt_at, published_at
+`
+
+type ClaimPendingOutboxMessagesParams struct {
+	LeaseUntil time.Time
+	Now        time.Time
+	Limit      int32
+}
+
+func (q *Queries) ClaimPendingOutboxMessages(ctx context.Context, arg ClaimPendingOutboxMessagesParams) ([]Outbox, error) {
+	rows, err := q.db.QueryContext(ctx, claimPendingOutboxMessages, arg.LeaseUntil, arg.Now, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Outbox
+	for rows.Next() {
+		var i Outbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.EventID,
+			&i.ActorID,
+			&i.Payload,
+			&i.OccurredAt,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.PublishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const completeIdempotencyKey = `-- name: CompleteIdempotencyKey :execrows
+UPDATE
+    idempotency_key
+SET
+    state = 'COMPLETED',
+    event_id = $4,
+    response = $5,
+    expires_at = $6
+WHERE
+    actor_id = $1
+    AND key = $2
+    AND created_at = $3
+    AND state = 'PENDING'
+`
+
+type CompleteIdempotencyKeyParams struct {
+	ActorID   string
+	Key       string
+	CreatedAt time.Time
+	EventID   string
+	Response  json.RawMessage
+	ExpiresAt time.Time
+}
+
+func (q *Queries) CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, completeIdempotencyKey,
+		arg.ActorID,
+		arg.Key,
+		arg.CreatedAt,
+		arg.EventID,
+		arg.Response,
+		arg.ExpiresAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const createBooking = `-- name: CreateBooking :exec
 INSERT INTO
-    event (
+    booking (
+        id,
+        page_id,
+        event_id,
+        start_time,
+        guest_name,
+        guest_email,
+        created_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type CreateBookingParams struct {
+	ID         string
+	PageID     string
+	EventID    string
+	StartTime  time.Time
+	GuestName  string
+	GuestEmail string
+	CreatedAt  time.Time
+}
+
+func (q *Queries) CreateBooking(ctx context.Context, arg CreateBookingParams) error {
+	_, err := q.db.ExecContext(ctx, createBooking,
+		arg.ID,
+		arg.PageID,
+		arg.EventID,
+		arg.StartTime,
+		arg.GuestName,
+		arg.GuestEmail,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createBookingPage = `-- name: CreateBookingPage :exec
+INSERT INTO
+    booking_page (
         id,
+        owner_id,
         title,
         description,
         timezone,
-        created_by,
-        created_at,
-        updated_at
+        duration,
+        buffer_before,
+        buffer_after,
+        min_notice,
+        max_per_day,
+        rules,
+        created_at
     )
 VALUES
-    ($1, $2, $3, $4, $5, $6, $7)
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 `
 
-type CreateEventParams struct {
+type CreateBookingPageParams struct {
+	ID           string
+	OwnerID      string
+	Title        string
+	Description  string
+	Timezone     string
+	Duration     int32
+	BufferBefore int32
+	BufferAfter  int32
+	MinNotice    int32
+	MaxPerDay    int32
+	Rules        json.RawMessage
+	CreatedAt    time.Time
+}
+
+func (q *Queries) CreateBookingPage(ctx context.Context, arg CreateBookingPageParams) error {
+	_, err := q.db.ExecContext(ctx, createBookingPage,
+		arg.ID,
+		arg.OwnerID,
+		arg.Title,
+		arg.Description,
+		arg.Timezone,
+		arg.Duration,
+		arg.BufferBefore,
+		arg.BufferAfter,
+		arg.MinNotice,
+		arg.MaxPerDay,
+		arg.Rules,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createCalendar = `-- name: CreateCalendar :exec
+INSERT INTO
+    calendar (
+        id,
+        owner_id,
+        name,
+        color,
+        timezone,
+        description,
+        is_default,
+        created_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+
+type CreateCalendarParams struct {
 	ID          string
+	OwnerID     string
+	Name        string
+	Color       string
+	Timezone    string
+	Description string
+	IsDefault   bool
+	CreatedAt   time.Time
+}
+
+func (q *Queries) CreateCalendar(ctx context.Context, arg CreateCalendarParams) error {
+	_, err := q.db.ExecContext(ctx, createCalendar,
+		arg.ID,
+		arg.OwnerID,
+		arg.Name,
+		arg.Color,
+		arg.Timezone,
+		arg.Description,
+		arg.IsDefault,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createDefaultCalendar = `-- name: CreateDefaultCalendar :exec
+INSERT INTO
+    calendar (
+        id,
+        owner_id,
+        name,
+        color,
+        timezone,
+        description,
+        is_default,
+        created_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, TRUE, $7) ON CONFLICT (owner_id)
+WHERE
+    is_default DO NOTHING
+`
+
+type CreateDefaultCalendarParams struct {
+	ID          string
+	OwnerID     string
+	Name        string
+	Color       string
+	Timezone    string
+	Description string
+	CreatedAt   time.Time
+}
+
+func (q *Queries) CreateDefaultCalendar(ctx context.Context, arg CreateDefaultCalendarParams) error {
+	_, err := q.db.ExecContext(ctx, createDefaultCalendar,
+		arg.ID,
+		arg.OwnerID,
+		arg.Name,
+		arg.Color,
+		arg.Timezone,
+		arg.Description,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createEvent = `-- name: CreateEvent :exec
+INSERT INTO
+    event (
+        id,
+        title,
+        description,
+        timezone,
+        created_by,
+        created_at,
+        updated_at,
+        calendar_id,
+        capacity
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+`
+
+type CreateEventParams struct {
+	ID          string
+	Title       string
+	Description string
+	Timezone    string
+	CreatedBy   string
+	CreatedAt   time.Time
+	UpdatedAt   sql.NullTime
+	CalendarID  string
+	Capacity    int32
+}
+
+func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) error {
+	_, err := q.db.ExecContext(ctx, createEvent,
+		arg.ID,
+		arg.Title,
+		arg.Description,
+		arg.Timezone,
+		arg.CreatedBy,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		arg.CalendarID,
+		arg.Capacity,
+	)
+	return err
+}
+
+const createEventHistory = `-- name: CreateEventHistory :exec
+INSERT INTO
+    event_history (
+        event_id,
+        actor_id,
+        operation,
+        changes,
+        created_at,
+        on_behalf_of
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateEventHistoryParams struct {
+	EventID    string
+	ActorID    string
+	Operation  string
+	Changes    json.RawMessage
+	CreatedAt  time.Time
+	OnBehalfOf string
+}
+
+func (q *Queries) CreateEventHistory(ctx context.Context, arg CreateEventHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, createEventHistory,
+		arg.EventID,
+		arg.ActorID,
+		arg.Operation,
+		arg.Changes,
+		arg.CreatedAt,
+		arg.OnBehalfOf,
+	)
+	return err
+}
+
+const createEventMute = `-- name: CreateEventMute :exec
+INSERT INTO
+    event_mute (user_id, event_id)
+VALUES
+    ($1, $2) ON CONFLICT DO NOTHING
+`
+
+type CreateEventMuteParams struct {
+	UserID  string
+	EventID string
+}
+
+func (q *Queries) CreateEventMute(ctx context.Context, arg CreateEventMuteParams) error {
+	_, err := q.db.ExecContext(ctx, createEventMute, arg.UserID, arg.EventID)
+	return err
+}
+
+const createGroup = `-- name: CreateGroup :exec
+INSERT INTO
+    user_group (id, owner_id, name, description, created_at)
+VALUES
+    ($1, $2, $3, $4, $5)
+`
+
+type CreateGroupParams struct {
+	ID          string
+	OwnerID     string
+	Name        string
+	Description string
+	CreatedAt   time.Time
+}
+
+func (q *Queries) CreateGroup(ctx context.Context, arg CreateGroupParams) error {
+	_, err := q.db.ExecContext(ctx, createGroup,
+		arg.ID,
+		arg.OwnerID,
+		arg.Name,
+		arg.Description,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createGroupMember = `-- name: CreateGroupMember :exec
+INSERT INTO
+    user_group_member (group_id, user_id, created_at)
+VALUES
+    ($1, $2, $3) ON CONFLICT (group_id, user_id) DO NOTHING
+`
+
+type CreateGroupMemberParams struct {
+	GroupID   string
+	UserID    int32
+	CreatedAt time.Time
+}
+
+func (q *Queries) CreateGroupMember(ctx context.Context, arg CreateGroupMemberParams) error {
+	_, err := q.db.ExecContext(ctx, createGroupMember, arg.GroupID, arg.UserID, arg.CreatedAt)
+	return err
+}
+
+const createGuest = `-- name: CreateGuest :exec
+INSERT INTO
+    guest (event_id, email, name)
+VALUES
+    ($1, $2, $3)
+`
+
+type CreateGuestParams struct {
+	EventID string
+	Email   string
+	Name    string
+}
+
+func (q *Queries) CreateGuest(ctx context.Context, arg CreateGuestParams) error {
+	_, err := q.db.ExecContext(ctx, createGuest, arg.EventID, arg.Email, arg.Name)
+	return err
+}
+
+const createInvitation = `-- name: CreateInvitation :exec
+INSERT INTO
+    invitation (id, event_id, user_id, token, status, group_id)
+VALUES
+    ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateInvitationParams struct {
+	ID      string
+	EventID string
+	UserID  int32
+	Token   string
+	Status  int16
+	GroupID string
+}
+
+func (q *Queries) CreateInvitation(ctx context.Context, arg CreateInvitationParams) error {
+	_, err := q.db.ExecContext(ctx, createInvitation,
+		arg.ID,
+		arg.EventID,
+		arg.UserID,
+		arg.Token,
+		arg.Status,
+		arg.GroupID,
+	)
+	return err
+}
+
+const createNotificationDigestItem = `-- name: CreateNotificationDigestItem :exec
+INSERT INTO
+    notification_digest_item (
+        user_id,
+        dedup_key,
+        subject,
+        body,
+        calendar_method,
+        calendar,
+        send_after
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT ON CONSTRAINT uq_notification_digest_item DO NOTHING
+`
+
+type CreateNotificationDigestItemParams struct {
+	UserID         string
+	DedupKey       string
+	Subject        string
+	Body           string
+	CalendarMethod string
+	Calendar       string
+	SendAfter      time.Time
+}
+
+func (q *Queries) CreateNotificationDigestItem(ctx context.Context, arg CreateNotificationDigestItemParams) error {
+	_, err := q.db.ExecContext(ctx, createNotificationDigestItem,
+		arg.UserID,
+		arg.DedupKey,
+		arg.Subject,
+		arg.Body,
+		arg.CalendarMethod,
+		arg.Calendar,
+		arg.SendAfter,
+	)
+	return err
+}
+
+const createOutboxMessage = `-- name: CreateOutboxMessage :exec
+INSERT INTO
+    outbox (
+        id,
+        type,
+        event_id,
+        actor_id,
+        payload,
+        occurred_at,
+        next_attempt_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $6)
+`
+
+type CreateOutboxMessageParams struct {
+	ID         string
+	Type       string
+	EventID    string
+	ActorID    string
+	Payload    json.RawMessage
+	OccurredAt time.Time
+}
+
+func (q *Queries) CreateOutboxMessage(ctx context.Context, arg CreateOutboxMessageParams) error {
+	_, err := q.db.ExecContext(ctx, createOutboxMessage,
+		arg.ID,
+		arg.Type,
+		arg.EventID,
+		arg.ActorID,
+		arg.Payload,
+		arg.OccurredAt,
+	)
+	return err
+}
+
+const createReminder = `-- name: CreateReminder :exec
+INSERT INTO
+    reminder (
+        id,
+        event_id,
+        user_id,
+        offset_minutes,
+        channel,
+        next_fire_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateReminderParams struct {
+	ID            string
+	EventID       string
+	UserID        int32
+	OffsetMinutes int64
+	Channel       string
+	NextFireAt    sql.NullTime
+}
+
+func (q *Queries) CreateReminder(ctx context.Context, arg CreateReminderParams) error {
+	_, err := q.db.ExecContext(ctx, createReminder,
+		arg.ID,
+		arg.EventID,
+		arg.UserID,
+		arg.OffsetMinutes,
+		arg.Channel,
+		arg.NextFireAt,
+	)
+	return err
+}
+
+const createReminderDelivery = `-- name: CreateReminderDelivery :execrows
+INSERT INTO
+    reminder_delivery (
+        event_id,
+        recipient_id,
+        channel,
+        offset_minutes,
+        occurrence_at,
+        status,
+        next_attempt_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT ON CONSTRAINT uq_reminder_delivery DO NOTHING
+`
+
+type CreateReminderDeliveryParams struct {
+	EventID       string
+	RecipientID   string
+	Channel       string
+	OffsetMinutes int64
+	OccurrenceAt  time.Time
+	Status        string
+	NextAttemptAt time.Time
+}
+
+func (q *Queries) CreateReminderDelivery(ctx context.Context, arg CreateReminderDeliveryParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createReminderDelivery,
+		arg.EventID,
+		arg.RecipientID,
+		arg.Channel,
+		arg.OffsetMinutes,
+		arg.OccurrenceAt,
+		arg.Status,
+		arg.NextAttemptAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const createResource = `-- name: CreateResource :exec
+INSERT INTO
+    resource (
+        id,
+        name,
+        type,
+        capacity,
+        location,
+        created_by,
+        created_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type CreateResourceParams struct {
+	ID        string
+	Name      string
+	Type      string
+	Capacity  int32
+	Location  string
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+func (q *Queries) CreateResource(ctx context.Context, arg CreateResourceParams) error {
+	_, err := q.db.ExecContext(ctx, createResource,
+		arg.ID,
+		arg.Name,
+		arg.Type,
+		arg.Capacity,
+		arg.Location,
+		arg.CreatedBy,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createResourceBooking = `-- name: CreateResourceBooking :exec
+INSERT INTO
+    resource_booking (event_id, resource_id, status, created_at)
+VALUES
+    ($1, $2, $3, $4)
+`
+
+type CreateResourceBookingParams struct {
+	EventID    string
+	ResourceID string
+	Status     int16
+	CreatedAt  time.Time
+}
+
+func (q *Queries) CreateResourceBooking(ctx context.Context, arg CreateResourceBookingParams) error {
+	_, err := q.db.ExecContext(ctx, createResourceBooking,
+		arg.EventID,
+		arg.ResourceID,
+		arg.Status,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createSchedule = `-- name: CreateSchedule :exec
+INSERT INTO
+    schedule (
+        id,
+        event_id,
+        start_time,
+        "duration",
+        is_full_day,
+        recurring_interval,
+        recurring_type
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type CreateScheduleParams struct {
+	ID                string
+	EventID           string
+	StartTime         int64
+	Duration          int64
+	IsFullDay         bool
+	RecurringInterval int64
+	RecurringType     string
+}
+
+func (q *Queries) CreateSchedule(ctx context.Context, arg CreateScheduleParams) error {
+	_, err := q.db.ExecContext(ctx, createSchedule,
+		arg.ID,
+		arg.EventID,
+		arg.StartTime,
+		arg.Duration,
+		arg.IsFullDay,
+		arg.RecurringInterval,
+		arg.RecurringType,
+	)
+	return err
+}
+
+const createWebhook = `-- name: CreateWebhook :exec
+INSERT INTO
+    webhook (
+        id,
+        owner_id,
+        url,
+        secret,
+        event_types,
+        created_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateWebhookParams struct {
+	ID         string
+	OwnerID    string
+	Url        string
+	Secret     string
+	EventTypes json.RawMessage
+	CreatedAt  time.Time
+}
+
+func (q *Queries) CreateWebhook(ctx context.Context, arg CreateWebhookParams) error {
+	_, err := q.db.ExecContext(ctx, createWebhook,
+		arg.ID,
+		arg.OwnerID,
+		arg.Url,
+		arg.Secret,
+		arg.EventTypes,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const deleteBookingPage = `-- name: DeleteBookingPage :execrows
+DELETE FROM
+    booking_page
+WHERE
+    id = $1
+`
+
+func (q *Queries) DeleteBookingPage(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteBookingPage, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteCalendar = `-- name: DeleteCalendar :execrows
+DELETE FROM
+    calendar
+WHERE
+    id = $1
+    AND NOT is_default
+`
+
+func (q *Queries) DeleteCalendar(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteCalendar, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteCalendarGrant = `-- name: DeleteCalendarGrant :execrows
+DELETE FROM
+    calendar_acl
+WHERE
+    calendar_id = $1
+    AND grantee_type = $2
+    AND grantee_id = $3
+`
+
+type DeleteCalendarGrantParams struct {
+	CalendarID  string
+	GranteeType string
+	GranteeID   string
+}
+
+func (q *Queries) DeleteCalendarGrant(ctx context.Context, arg DeleteCalendarGrantParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteCalendarGrant, arg.CalendarID, arg.GranteeType, arg.GranteeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteEvent = `-- name: DeleteEvent :execrows
+UPDATE
+    event
+SET
+    deleted_at = $2
+WHERE
+    id = $1
+    AND deleted_at IS NULL
+`
+
+type DeleteEventParams struct {
+	ID        string
+	DeletedAt sql.NullTime
+}
+
+func (q *Queries) DeleteEvent(ctx context.Context, arg DeleteEventParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteEvent, arg.ID, arg.DeletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteEventMute = `-- name: DeleteEventMute :exec
+DELETE FROM
+    event_mute
+WHERE
+    user_id = $1
+    AND event_id = $2
+`
+
+type DeleteEventMuteParams struct {
+	UserID  string
+	EventID string
+}
+
+func (q *Queries) DeleteEventMute(ctx context.Context, arg DeleteEventMuteParams) error {
+	_, err := q.db.ExecContext(ctx, deleteEventMute, arg.UserID, arg.EventID)
+	return err
+}
+
+const deleteGroup = `-- name: DeleteGroup :execrows
+DELETE FROM
+    user_group
+WHERE
+    id = $1
+`
+
+func (q *Queries) DeleteGroup(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteGroup, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteGroupCalendarGrants = `-- name: DeleteGroupCalendarGrants :exec
+DELETE FROM
+    calendar_acl
+WHERE
+    grantee_type = 'GROUP'
+    AND grantee_id = $1
+`
+
+func (q *Queries) DeleteGroupCalendarGrants(ctx context.Context, granteeID string) error {
+	_, err := q.db.ExecContext(ctx, deleteGroupCalendarGrants, granteeID)
+	return err
+}
+
+const deleteGroupInvitation = `-- name: DeleteGroupInvitation :execrows
+DELETE FROM
+    invitation
+WHERE
+    event_id = $1
+    AND user_id = $2
+    AND group_id = $3
+`
+
+type DeleteGroupInvitationParams struct {
+	EventID string
+	UserID  int32
+	GroupID string
+}
+
+func (q *Queries) DeleteGroupInvitation(ctx context.Context, arg DeleteGroupInvitationParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteGroupInvitation, arg.EventID, arg.UserID, arg.GroupID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteGroupMember = `-- name: DeleteGroupMember :exec
+DELETE FROM
+    user_group_member
+WHERE
+    group_id = $1
+    AND user_id = $2
+`
+
+type DeleteGroupMemberParams struct {
+	GroupID string
+	UserID  int32
+}
+
+func (q *Queries) DeleteGroupMember(ctx context.Context, arg DeleteGroupMemberParams) error {
+	_, err := q.db.ExecContext(ctx, deleteGroupMember, arg.GroupID, arg.UserID)
+	return err
+}
+
+const deleteNotificationDigestItem = `-- name: DeleteNotificationDigestItem :exec
+DELETE FROM
+    notification_digest_item
+WHERE
+    id = $1
+`
+
+func (q *Queries) DeleteNotificationDigestItem(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deleteNotificationDigestItem, id)
+	return err
+}
+
+const deleteReminders = `-- name: DeleteReminders :exec
+DELETE FROM
+    reminder
+WHERE
+    event_id = $1
+    AND user_id = $2
+`
+
+type DeleteRemindersParams struct {
+	EventID string
+	UserID  int32
+}
+
+func (q *Queries) DeleteReminders(ctx context.Context, arg DeleteRemindersParams) error {
+	_, err := q.db.ExecContext(ctx, deleteReminders, arg.EventID, arg.UserID)
+	return err
+}
+
+const deleteResource = `-- name: DeleteResource :execrows
+DELETE FROM
+    resource
+WHERE
+    id = $1
+`
+
+func (q *Queries) DeleteResource(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteResource, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteResourceBookings = `-- name: DeleteResourceBookings :exec
+DELETE FROM
+    resource_booking
+WHERE
+    event_id = $1
+`
+
+func (q *Queries) DeleteResourceBookings(ctx context.Context, eventID string) error {
+	_, err := q.db.ExecContext(ctx, deleteResourceBookings, eventID)
+	return err
+}
+
+const deleteWebhook = `-- name: DeleteWebhook :execrows
+DELETE FROM
+    webhook
+WHERE
+    id = $1
+`
+
+func (q *Queries) DeleteWebhook(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteWebhook, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const enqueueWebhookDeliveries = `-- name: EnqueueWebhookDeliveries :execrows
+INSERT INTO
+    webhook_delivery (
+        webhook_id,
+        event_id,
+        event_type,
+        payload,
+        status,
+        next_attempt_at,
+        created_at,
+        outbox_id
+    )
+SELECT
+    webhook.id,
+    $1::varchar,
+    $2::varchar,
+    $3::text,
+    $4::varchar,
+    $5::timestamp,
+    $5::timestamp,
+    $6::varchar
+FROM
+    webhook
+WHERE
+    webhook.event_types @> jsonb_build_array($2::varchar)
+    AND webhook.owner_id IN (
+        SELECT
+            jsonb_array_elements_text($7::jsonb)
+    ) ON CONFLICT (outbox_id, webhook_id) DO NOTHING
+`
+
+type EnqueueWebhookDeliveriesParams struct {
+	EventID   string
+	EventType string
+	Payload   string
+	Status    string
+	CreatedAt time.Time
+	OutboxID  sql.NullString
+	OwnerIds  json.RawMessage
+}
+
+func (q *Queries) EnqueueWebhookDeliveries(ctx context.Context, arg EnqueueWebhookDeliveriesParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, enqueueWebhookDeliveries,
+		arg.EventID,
+		arg.EventType,
+		arg.Payload,
+		arg.Status,
+		arg.CreatedAt,
+		arg.OutboxID,
+		arg.OwnerIds,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const findBookedStarts = `-- name: FindBookedStarts :many
+SELECT
+    booking.start_time
+FROM
+    booking
+    JOIN event ON event.id = booking.event_id
+WHERE
+    booking.page_id = $1
+    AND booking.start_time >= $2
+    AND booking.start_time < $3
+    AND event.deleted_at IS NULL
+`
+
+type FindBookedStartsParams struct {
+	PageID      string
+	StartTime   time.Time
+	StartTime_2 time.Time
+}
+
+func (q *Queries) FindBookedStarts(ctx context.Context, arg FindBookedStartsParams) ([]time.Time, error) {
+	rows, err := q.db.QueryContext(ctx, findBookedStarts, arg.PageID, arg.StartTime, arg.StartTime_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []time.Time
+	for rows.Next() {
+		var start_time time.Time
+		if err := rows.Scan(&start_time); err != nil {
+			return nil, err
+		}
+		items = append(items, start_time)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findBookingByEventID = `-- name: FindBookingByEventID :one
+SELECT
+    id, page_id, event_id, start_time, guest_name, guest_email, created_at
+FROM
+    booking
+WHERE
+    event_id = $1
+LIMIT
+    1
+`
+
+func (q *Queries) FindBookingByEventID(ctx context.Context, eventID string) (Booking, error) {
+	row := q.db.QueryRowContext(ctx, findBookingByEventID, eventID)
+	var i Booking
+	err := row.Scan(
+		&i.ID,
+		&i.PageID,
+		&i.EventID,
+		&i.StartTime,
+		&i.GuestName,
+		&i.GuestEmail,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const findBookingPageByID = `-- name: FindBookingPageByID :one
+SELECT
+    id, owner_id, title, description, timezone, duration, buffer_before, buffer_after, min_notice, max_per_day, rules, created_at, updated_at
+FROM
+    booking_page
+WHERE
+    id = $1
+LIMIT
+    1
+`
+
+func (q *Queries) FindBookingPageByID(ctx context.Context, id string) (BookingPage, error) {
+	row := q.db.QueryRowContext(ctx, findBookingPageByID, id)
+	var i BookingPage
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Title,
+		&i.Description,
+		&i.Timezone,
+		&i.Duration,
+		&i.BufferBefore,
+		&i.BufferAfter,
+		&i.MinNotice,
+		&i.MaxPerDay,
+		&i.Rules,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const findBookingPagesByOwner = `-- name: FindBookingPagesByOwner :many
+SELECT
+    id, owner_id, title, description, timezone, duration, buffer_before, buffer_after, min_notice, max_per_day, rules, created_at, updated_at
+FROM
+    booking_page
+WHERE
+    owner_id = $1
+ORDER BY
+    created_at,
+    id
+`
+
+func (q *Queries) FindBookingPagesByOwner(ctx context.Context, ownerID string) ([]BookingPage, error) {
+	rows, err := q.db.QueryContext(ctx, findBookingPagesByOwner, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BookingPage
+	for rows.Next() {
+		var i BookingPage
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.Title,
+			&i.Description,
+			&i.Timezone,
+			&i.Duration,
+			&i.BufferBefore,
+			&i.BufferAfter,
+			&i.MinNotice,
+			&i.MaxPerDay,
+			&i.Rules,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findCalendarByID = `-- name: FindCalendarByID :one
+SELECT
+    id, owner_id, name, color, timezone, description, is_default, created_at, updated_at
+FROM
+    calendar
+WHERE
+    id = $1
+LIMIT
+    1
+`
+
+func (q *Queries) FindCalendarByID(ctx context.Context, id string) (Calendar, error) {
+	row := q.db.QueryRowContext(ctx, findCalendarByID, id)
+	var i Calendar
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Color,
+		&i.Timezone,
+		&i.Description,
+		&i.IsDefault,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const findCalendarGrants = `-- name: FindCalendarGrants :many
+SELECT
+    calendar_id, grantee_type, grantee_id, role, granted_by, created_at, updated_at
+FROM
+    calendar_acl
+WHERE
+    calendar_id = $1
+ORDER BY
+    created_at,
+    grantee_type,
+    grantee_id
+`
+
+func (q *Queries) FindCalendarGrants(ctx context.Context, calendarID string) ([]CalendarAcl, error) {
+	rows, err := q.db.QueryContext(ctx, findCalendarGrants, calendarID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CalendarAcl
+	for rows.Next() {
+		var i CalendarAcl
+		if err := rows.Scan(
+			&i.CalendarID,
+			&i.GranteeType,
+			&i.GranteeID,
+			&i.Role,
+			&i.GrantedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findCalendarRoles = `-- name: FindCalendarRoles :many
+SELECT
+    role
+FROM
+    calendar_acl
+WHERE
+    calendar_id = $1
+    AND grantee_type = 'USER'
+    AND grantee_id = $2
+UNION ALL
+SELECT
+    calendar_acl.role
+FROM
+    calendar_acl
+    JOIN user_group_member ON user_group_member.group_id = calendar_acl.grantee_id
+WHERE
+    calendar_acl.calendar_id = $1
+    AND calendar_acl.grantee_type = 'GROUP'
+    AND user_group_member.user_id = $3
+UNION ALL
+SELECT
+    'MANAGE'
+FROM
+    calendar
+WHERE
+    id = $1
+    AND owner_id = $2
+`
+
+type FindCalendarRolesParams struct {
+	CalendarID string
+	UserID     string
+	MemberID   int32
+}
+
+func (q *Queries) FindCalendarRoles(ctx context.Context, arg FindCalendarRolesParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, findCalendarRoles, arg.CalendarID, arg.UserID, arg.MemberID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		items = append(items, role)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findCalendarsByOwner = `-- name: FindCalendarsByOwner :many
+SELECT
+    id, owner_id, name, color, timezone, description, is_default, created_at, updated_at
+FROM
+    calendar
+WHERE
+    owner_id = $1
+ORDER BY
+    is_default DESC,
+    created_at,
+    id
+`
+
+func (q *Queries) FindCalendarsByOwner(ctx context.Context, ownerID string) ([]Calendar, error) {
+	rows, err := q.db.QueryContext(ctx, findCalendarsByOwner, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Calendar
+	for rows.Next() {
+		var i Calendar
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.Name,
+			&i.Color,
+			&i.Timezone,
+			&i.Description,
+			&i.IsDefault,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findCalendarsSharedWith = `-- name: FindCalendarsSharedWith :many
+SELECT
+    calendar.id, calendar.owner_id, calendar.name, calendar.color, calendar.timezone, calendar.description, calendar.is_default, calendar.created_at, calendar.updated_at,
+    calendar_acl.role
+FROM
+    calendar
+    JOIN calendar_acl ON calendar_acl.calendar_id = calendar.id
+WHERE
+    calendar.owner_id <> $1
+    AND (
+        (
+            calendar_acl.grantee_type = 'USER'
+            AND calendar_acl.grantee_id = $1
+        )
+        OR (
+            calendar_acl.grantee_type = 'GROUP'
+            AND calendar_acl.grantee_id IN (
+                SELECT
+                    group_id
+                FROM
+                    user_group_member
+                WHERE
+                    user_id = $2
+            )
+        )
+    )
+ORDER BY
+    calendar.created_at,
+    calendar.id
+`
+
+type FindCalendarsSharedWithParams struct {
+	UserID   string
+	MemberID int32
+}
+
+type FindCalendarsSharedWithRow struct {
+	ID          string
+	OwnerID     string
+	Name        string
+	Color       string
+	Timezone    string
+	Description string
+	IsDefault   bool
+	CreatedAt   time.Time
+	UpdatedAt   sql.NullTime
+	Role        string
+}
+
+func (q *Queries) FindCalendarsSharedWith(ctx context.Context, arg FindCalendarsSharedWithParams) ([]FindCalendarsSharedWithRow, error) {
+	rows, err := q.db.QueryContext(ctx, findCalendarsSharedWith, arg.UserID, arg.MemberID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindCalendarsSharedWithRow
+	for rows.Next() {
+		var i FindCalendarsSharedWithRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.Name,
+			&i.Color,
+			&i.Timezone,
+			&i.Description,
+			&i.IsDefault,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findDefaultCalendar = `-- name: FindDefaultCalendar :one
+SELECT
+    id, owner_id, name, color, timezone, description, is_default, created_at, updated_at
+FROM
+    calendar
+WHERE
+    owner_id = $1
+    AND is_default
+LIMIT
+    1
+`
+
+func (q *Queries) FindDefaultCalendar(ctx context.Context, ownerID string) (Calendar, error) {
+	row := q.db.QueryRowContext(ctx, findDefaultCalendar, ownerID)
+	var i Calendar
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Color,
+		&i.Timezone,
+		&i.Description,
+		&i.IsDefault,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const findDeletedEventByID = `-- name: FindDeletedEventByID :one
+SELECT
+    id, title, description, timezone, created_by, created_at, updated_at, deleted_at, search_vector, calendar_id, capacity
+FROM
+    event
+WHERE
+    id = $1
+    AND deleted_at IS NOT NULL
+LIMIT
+    1
+`
+
+func (q *Queries) FindDeletedEventByID(ctx context.Context, id string) (Event, error) {
+	row := q.db.QueryRowContext(ctx, findDeletedEventByID, id)
+	var i Event
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Timezone,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.SearchVector,
+		&i.CalendarID,
+		&i.Capacity,
+	)
+	return i, err
+}
+
+const findDeletedEventsByCreator = `-- name: FindDeletedEventsByCreator :many
+SELECT
+    id, title, description, timezone, created_by, created_at, updated_at, deleted_at, search_vector, calendar_id, capacity
+FROM
+    event
+WHERE
+    created_by = $1
+    AND deleted_at IS NOT NULL
+ORDER BY
+    deleted_at DESC
+`
+
+func (q *Queries) FindDeletedEventsByCreator(ctx context.Context, createdBy string) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, findDeletedEventsByCreator, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Timezone,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SearchVector,
+			&i.CalendarID,
+			&i.Capacity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findEventByID = `-- name: FindEventByID :one
+SELECT
+    id, title, description, timezone, created_by, created_at, updated_at, deleted_at, search_vector, calendar_id, capacity
+FROM
+    event
+WHERE
+    id = $1
+    AND deleted_at IS NULL
+LIMIT
+    1
+`
+
+func (q *Queries) FindEventByID(ctx context.Context, id string) (Event, error) {
+	row := q.db.QueryRowContext(ctx, findEventByID, id)
+	var i Event
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Timezone,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.SearchVector,
+		&i.CalendarID,
+		&i.Capacity,
+	)
+	return i, err
+}
+
+const findEventHistoryByEventID = `-- name: FindEventHistoryByEventID :many
+SELECT
+    id, event_id, actor_id, operation, changes, created_at, on_behalf_of
+FROM
+    event_history
+WHERE
+    event_id = $1
+    AND id < $2
+ORDER BY
+    id DESC
+LIMIT
+    $3
+`
+
+type FindEventHistoryByEventIDParams struct {
+	EventID string
+	ID      int64
+	Limit   int32
+}
+
+func (q *Queries) FindEventHistoryByEventID(ctx context.Context, arg FindEventHistoryByEventIDParams) ([]EventHistory, error) {
+	rows, err := q.db.QueryContext(ctx, findEventHistoryByEventID, arg.EventID, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EventHistory
+	for rows.Next() {
+		var i EventHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.ActorID,
+			&i.Operation,
+			&i.Changes,
+			&i.CreatedAt,
+			&i.OnBehalfOf,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findGroupByID = `-- name: FindGroupByID :one
+SELECT
+    id, owner_id, name, description, created_at, updated_at
+FROM
+    user_group
+WHERE
+    id = $1
+LIMIT
+    1
+`
+
+func (q *Queries) FindGroupByID(ctx context.Context, id string) (UserGroup, error) {
+	row := q.db.QueryRowContext(ctx, findGroupByID, id)
+	var i UserGroup
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const findGroupMembers = `-- name: FindGroupMembers :many
+SELECT
+    user_id
+FROM
+    user_group_member
+WHERE
+    group_id = $1
+ORDER BY
+    created_at,
+    user_id
+`
+
+func (q *Queries) FindGroupMembers(ctx context.Context, groupID string) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, findGroupMembers, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var user_id int32
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findGroupsByOwner = `-- name: FindGroupsByOwner :many
+SELECT
+    id, owner_id, name, description, created_at, updated_at
+FROM
+    user_group
+WHERE
+    owner_id = $1
+ORDER BY
+    created_at,
+    id
+`
+
+func (q *Queries) FindGroupsByOwner(ctx context.Context, ownerID string) ([]UserGroup, error) {
+	rows, err := q.db.QueryContext(ctx, findGroupsByOwner, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UserGroup
+	for rows.Next() {
+		var i UserGroup
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findIdempotencyKey = `-- name: FindIdempotencyKey :one
+SELECT
+    actor_id, key, request_hash, event_id, created_at, expires_at, state, response
+FROM
+    idempotency_key
+WHERE
+    actor_id = $1
+    AND key = $2
+LIMIT
+    1
+`
+
+type FindIdempotencyKeyParams struct {
+	ActorID string
+	Key     string
+}
+
+func (q *Queries) FindIdempotencyKey(ctx context.Context, arg FindIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.db.QueryRowContext(ctx, findIdempotencyKey, arg.ActorID, arg.Key)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.ActorID,
+		&i.Key,
+		&i.RequestHash,
+		&i.EventID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.State,
+		&i.Response,
+	)
+	return i, err
+}
+
+const findInvitationsByEventID = `-- name: FindInvitationsByEventID :many
+SELECT
+    id, event_id, user_id, token, status, updated_at
+FROM
+    invitation
+WHERE
+    event_id = $1
+`
+
+func (q *Queries) FindInvitationsByEventID(ctx context.Context, eventID string) ([]Invitation, error) {
+	rows, err := q.db.QueryContext(ctx, findInvitationsByEventID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Invitation
+	for rows.Next() {
+		var i Invitation
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.UserID,
+			&i.Token,
+			&i.Status,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findMutedEventIDs = `-- name: FindMutedEventIDs :many
+SELECT
+    event_id
+FROM
+    event_mute
+WHERE
+    user_id = $1
+ORDER BY
+    created_at
+`
+
+func (q *Queries) FindMutedEventIDs(ctx context.Context, userID string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, findMutedEventIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var event_id string
+		if err := rows.Scan(&event_id); err != nil {
+			return nil, err
+		}
+		items = append(items, event_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findNotificationPreference = `-- name: FindNotificationPreference :one
+SELECT
+    user_id, channels, timezone, quiet_hours_start, quiet_hours_end, delivery, digest_time, updated_at
+FROM
+    notification_preference
+WHERE
+    user_id = $1
+LIMIT
+    1
+`
+
+func (q *Queries) FindNotificationPreference(ctx context.Context, userID string) (NotificationPreference, error) {
+	row := q.db.QueryRowContext(ctx, findNotificationPreference, userID)
+	var i NotificationPreference
+	err := row.Scan(
+		&i.UserID,
+		&i.Channels,
+		&i.Timezone,
+		&i.QuietHoursStart,
+		&i.QuietHoursEnd,
+		&i.Delivery,
+		&i.DigestTime,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const findResourceBookedSchedules = `-- name: FindResourceBookedSchedules :many
+SELECT
+    schedule.id, schedule.event_id, schedule.start_time, schedule.duration, schedule.is_full_day, schedule.recurring_interval, schedule.recurring_type
+FROM
+    resource_booking
+    JOIN event ON event.id = resource_booking.event_id
+    JOIN schedule ON schedule.event_id = resource_booking.event_id
+WHERE
+    resource_booking.resource_id = $1
+    AND resource_booking.event_id <> $2
+    AND resource_booking.status = $3
+    AND event.deleted_at IS NULL
+`
+
+type FindResourceBookedSchedulesParams struct {
+	ResourceID string
+	EventID    string
+	Status     int16
+}
+
+func (q *Queries) FindResourceBookedSchedules(ctx context.Context, arg FindResourceBookedSchedulesParams) ([]Schedule, error) {
+	rows, err := q.db.QueryContext(ctx, findResourceBookedSchedules, arg.ResourceID, arg.EventID, arg.Status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Schedule
+	for rows.Next() {
+		var i Schedule
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.StartTime,
+			&i.Duration,
+			&i.IsFullDay,
+			&i.RecurringInterval,
+			&i.RecurringType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findResourceByID = `-- name: FindResourceByID :one
+SELECT
+    id, name, type, capacity, location, created_by, created_at, updated_at
+FROM
+    resource
+WHERE
+    id = $1
+LIMIT
+    1
+`
+
+func (q *Queries) FindResourceByID(ctx context.Context, id string) (Resource, error) {
+	row := q.db.QueryRowContext(ctx, findResourceByID, id)
+	var i Resource
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Type,
+		&i.Capacity,
+		&i.Location,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const findSchedulesByEventID = `-- name: FindSchedulesByEventID :many
+SELECT
+    id, event_id, start_time, duration, is_full_day, recurring_interval, recurring_type
+FROM
+    schedule
+WHERE
+    event_id = $1
+`
+
+func (q *Queries) FindSchedulesByEventID(ctx context.Context, eventID string) ([]Schedule, error) {
+	rows, err := q.db.QueryContext(ctx, findSchedulesByEventID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Schedule
+	for rows.Next() {
+		var i Schedule
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.StartTime,
+			&i.Duration,
+			&i.IsFullDay,
+			&i.RecurringInterval,
+			&i.RecurringType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findUpcomingGroupEventIDs = `-- name: FindUpcomingGroupEventIDs :many
+SELECT
+    DISTINCT event.id
+FROM
+    event
+    JOIN invitation ON invitation.event_id = event.id
+WHERE
+    invitation.group_id = $1
+    AND event.deleted_at IS NULL
+    AND EXISTS (
+        SELECT
+            1
+        FROM
+            schedule
+        WHERE
+            schedule.event_id = event.id
+            AND (
+                schedule.start_time > $2
+                OR schedule.recurring_type <> 'NONE'
+            )
+    )
+ORDER BY
+    event.id
+`
+
+type FindUpcomingGroupEventIDsParams struct {
+	GroupID string
+	After   int64
+}
+
+func (q *Queries) FindUpcomingGroupEventIDs(ctx context.Context, arg FindUpcomingGroupEventIDsParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, findUpcomingGroupEventIDs, arg.GroupID, arg.After)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findUserBusySchedules = `-- name: FindUserBusySchedules :many
+SELECT
+    schedule.id, schedule.event_id, schedule.start_time, schedule.duration, schedule.is_full_day, schedule.recurring_interval, schedule.recurring_type
+FROM
+    schedule
+    JOIN event ON event.id = schedule.event_id
+WHERE
+    event.deleted_at IS NULL
+    AND (
+        event.created_by = $1
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                invitation
+            WHERE
+                invitation.event_id = event.id
+                AND invitation.user_id = $2
+                AND invitation.status <> $3
+        )
+    )
+    AND (
+        schedule.recurring_type <> 'NONE'
+        OR schedule.start_time + schedule.duration * 60 > $4
+    )
+    AND schedule.start_time < $5
+`
+
+type FindUserBusySchedulesParams struct {
+	CreatedBy   string
+	UserID      int32
+	Declined    int16
+	WindowStart int64
+	WindowEnd   int64
+}
+
+func (q *Queries) FindUserBusySchedules(ctx context.Context, arg FindUserBusySchedulesParams) ([]Schedule, error) {
+	rows, err := q.db.QueryContext(ctx, findUserBusySchedules,
+		arg.CreatedBy,
+		arg.UserID,
+		arg.Declined,
+		arg.WindowStart,
+		arg.WindowEnd,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Schedule
+	for rows.Next() {
+		var i Schedule
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.StartTime,
+			&i.Duration,
+			&i.IsFullDay,
+			&i.RecurringInterval,
+			&i.RecurringType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findWebhookByID = `-- name: FindWebhookByID :one
+SELECT
+    id, owner_id, url, secret, event_types, created_at, updated_at
+FROM
+    webhook
+WHERE
+    id = $1
+LIMIT
+    1
+`
+
+func (q *Queries) FindWebhookByID(ctx context.Context, id string) (Webhook, error) {
+	row := q.db.QueryRowContext(ctx, findWebhookByID, id)
+	var i Webhook
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Url,
+		&i.Secret,
+		&i.EventTypes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const findWebhookDeliveries = `-- name: FindWebhookDeliveries :many
+SELECT
+    id, webhook_id, event_id, event_type, payload, status, attempts, response_status, last_error, next_attempt_at, created_at, delivered_at, outbox_id
+FROM
+    webhook_delivery
+WHERE
+    webhook_id = $1
+    AND id < $2
+ORDER BY
+    id DESC
+LIMIT
+    $3
+`
+
+type FindWebhookDeliveriesParams struct {
+	WebhookID string
+	ID        int64
+	Limit     int32
+}
+
+func (q *Queries) FindWebhookDeliveries(ctx context.Context, arg FindWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, findWebhookDeliveries, arg.WebhookID, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.ResponseStatus,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.CreatedAt,
+			&i.DeliveredAt,
+			&i.OutboxID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findWebhooksByOwner = `-- name: FindWebhooksByOwner :many
+SELECT
+    id, owner_id, url, secret, event_types, created_at, updated_at
+FROM
+    webhook
+WHERE
+    owner_id = $1
+ORDER BY
+    created_at,
+    id
+`
+
+func (q *Queries) FindWebhooksByOwner(ctx context.Context, ownerID string) ([]Webhook, error) {
+	rows, err := q.db.QueryContext(ctx, findWebhooksByOwner, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Webhook
+	for rows.Next() {
+		var i Webhook
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.Url,
+			&i.Secret,
+			&i.EventTypes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEvents = `-- name: ListEvents :many
+SELECT
+    id, title, description, timezone, created_by, created_at, updated_at, deleted_at, search_vector, calendar_id, capacity
+FROM
+    event
+WHERE
+    deleted_at IS NULL
+    AND (
+        $1::varchar IS NULL
+        OR created_by = $1
+    )
+    AND (
+        $2::integer IS NULL
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                invitation
+            WHERE
+                invitation.event_id = event.id
+                AND invitation.user_id = $2
+        )
+    )
+    AND (
+        $3::text IS NULL
+        OR title ILIKE '%' || $3 || '%'
+    )
+    AND (
+        $4::timestamp IS NULL
+        OR created_at >= $4
+    )
+    AND (
+        $5::timestamp IS NULL
+        OR created_at < $5
+    )
+    AND (
+        $6::varchar IS NULL
+        OR timezone = $6
+    )
+    AND (
+        $7::varchar IS NULL
+        OR calendar_id = $7
+    )
+    AND (
+        $8::varchar IS NULL
+        OR created_by = $8
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                invitation
+            WHERE
+                invitation.event_id = event.id
+                AND invitation.user_id = $9
+        )
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                calendar
+            WHERE
+                calendar.id = event.calendar_id
+                AND calendar.owner_id = $8
+        )
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                calendar_acl
+            WHERE
+                calendar_acl.calendar_id = event.calendar_id
+                AND (
+                    (
+                        calendar_acl.grantee_type = 'USER'
+                        AND calendar_acl.grantee_id = $8
+                    )
+                    OR (
+                        calendar_acl.grantee_type = 'GROUP'
+                        AND calendar_acl.grantee_id IN (
+                            SELECT
+                                group_id
+                            FROM
+                                user_group_member
+                            WHERE
+                                user_id = $9
+                        )
+                    )
+                )
+                AND (
+                    calendar_acl.role <> 'FREE_BUSY'
+                    OR (
+                        $3::text IS NULL
+                        AND $2::integer IS NULL
+                    )
+                )
+        )
+    )
+    AND (
+        $10::timestamp IS NULL
+        OR (created_at, id) < (
+            $10,
+            $11::varchar
+        )
+    )
+ORDER BY
+    created_at DESC,
+    id DESC
+LIMIT
+    $12
+`
+
+type ListEventsParams struct {
+	CreatedBy       sql.NullString
+	Attendee        sql.NullInt32
+	Title           sql.NullString
+	CreatedAfter    sql.NullTime
+	CreatedBefore   sql.NullTime
+	Timezone        sql.NullString
+	CalendarID      sql.NullString
+	Viewer          sql.NullString
+	ViewerMemberID  int32
+	CursorCreatedAt sql.NullTime
+	CursorID        sql.NullString
+	PageSize        int32
+}
+
+func (q *Queries) ListEvents(ctx context.Context, arg ListEventsParams) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, listEvents,
+		arg.CreatedBy,
+		arg.Attendee,
+		arg.Title,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.Timezone,
+		arg.CalendarID,
+		arg.Viewer,
+		arg.ViewerMemberID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Timezone,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SearchVector,
+			&i.CalendarID,
+			&i.Capacity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInvolvedEvents = `-- name: ListInvolvedEvents :many
+SELECT
+    id, title, description, timezone, created_by, created_at, updated_at, deleted_at, search_vector, calendar_id, capacity
+FROM
+    event
+WHERE
+    deleted_at IS NULL
+    AND (
+        created_by = $1
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                invitation
+            WHERE
+                invitation.event_id = event.id
+                AND invitation.user_id = $2
+                AND invitation.status <> $3
+        )
+    )
+    AND EXISTS (
+        SELECT
+            1
+        FROM
+            schedule
+        WHERE
+            schedule.event_id = event.id
+            AND (
+                schedule.recurring_type <> 'NONE'
+                OR schedule.start_time + schedule.duration * 60 > $4
+            )
+            AND schedule.start_time < $5
+    )
+ORDER BY
+    created_at,
+    id
+`
+
+type ListInvolvedEventsParams struct {
+	CreatedBy   string
+	UserID      int32
+	Declined    int16
+	WindowStart int64
+	WindowEnd   int64
+}
+
+func (q *Queries) ListInvolvedEvents(ctx context.Context, arg ListInvolvedEventsParams) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, listInvolvedEvents,
+		arg.CreatedBy,
+		arg.UserID,
+		arg.Declined,
+		arg.WindowStart,
+		arg.WindowEnd,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Timezone,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SearchVector,
+			&i.CalendarID,
+			&i.Capacity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResources = `-- name: ListResources :many
+SELECT
+    id, name, type, capacity, location, created_by, created_at, updated_at
+FROM
+    resource
+WHERE
+    (
+        $1::varchar IS NULL
+        OR type = $1
+    )
+    AND capacity >= $2
+ORDER BY
+    name,
+    id
+`
+
+type ListResourcesParams struct {
+	Type        sql.NullString
+	MinCapacity int32
+}
+
+func (q *Queries) ListResources(ctx context.Context, arg ListResourcesParams) ([]Resource, error) {
+	rows, err := q.db.QueryContext(ctx, listResources, arg.Type, arg.MinCapacity)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Resource
+	for rows.Next() {
+		var i Resource
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Type,
+			&i.Capacity,
+			&i.Location,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockBookingOwner = `-- name: LockBookingOwner :exec
+SELECT
+    pg_advisory_xact_lock(hashtext($1))
+`
+
+func (q *Queries) LockBookingOwner(ctx context.Context, hashtext string) error {
+	_, err := q.db.ExecContext(ctx, lockBookingOwner, hashtext)
+	return err
+}
+
+const lockCalendarEvents = `-- name: LockCalendarEvents :many
+SELECT
+    id, title, description, timezone, created_by, created_at, updated_at, deleted_at, search_vector, calendar_id, capacity
+FROM
+    event
+WHERE
+    calendar_id = $1
+ORDER BY
+    id FOR
+UPDATE
+`
+
+func (q *Queries) LockCalendarEvents(ctx context.Context, calendarID string) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, lockCalendarEvents, calendarID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Timezone,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SearchVector,
+			&i.CalendarID,
+			&i.Capacity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockEvent = `-- name: LockEvent :one
+SELECT
+    id, title, description, timezone, created_by, created_at, updated_at, deleted_at, search_vector, calendar_id, capacity
+FROM
+    event
+WHERE
+    id = $1
+    AND deleted_at IS NULL FOR
+UPDATE
+`
+
+func (q *Queries) LockEvent(ctx context.Context, id string) (Event, error) {
+	row := q.db.QueryRowContext(ctx, lockEvent, id)
+	var i Event
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Timezone,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.SearchVector,
+		&i.CalendarID,
+		&i.Capacity,
+	)
+	return i, err
+}
+
+const lockResource = `-- name: LockResource :one
+SELECT
+    id
+FROM
+    resource
+WHERE
+    id = $1 FOR UPDATE
+`
+
+func (q *Queries) LockResource(ctx context.Context, id string) (string, error) {
+	row := q.db.QueryRowContext(ctx, lockResource, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const markOutboxMessagePublished = `-- name: MarkOutboxMessagePublished :execrows
+UPDATE
+    outbox
+SET
+    published_at = $1
+WHERE
+    id = $2
+`
+
+type MarkOutboxMessagePublishedParams struct {
+	PublishedAt sql.NullTime
+	ID          string
+}
+
+func (q *Queries) MarkOutboxMessagePublished(ctx context.Context, arg MarkOutboxMessagePublishedParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markOutboxMessagePublished, arg.PublishedAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const moveCalendarEvents = `-- name: MoveCalendarEvents :exec
+UPDATE
+    event
+SET
+    calendar_id = $1
+WHERE
+    calendar_id = $2
+`
+
+type MoveCalendarEventsParams struct {
+	ToCalendarID   string
+	FromCalendarID string
+}
+
+func (q *Queries) MoveCalendarEvents(ctx context.Context, arg MoveCalendarEventsParams) error {
+	_, err := q.db.ExecContext(ctx, moveCalendarEvents, arg.ToCalendarID, arg.FromCalendarID)
+	return err
+}
+
+const moveEvent = `-- name: MoveEvent :execrows
+UPDATE
+    event
+SET
+    calendar_id = $1,
+    updated_at = $2
+WHERE
+    id = $3
+    AND deleted_at IS NULL
+`
+
+type MoveEventParams struct {
+	CalendarID string
+	UpdatedAt  sql.NullTime
+	ID         string
+}
+
+func (q *Queries) MoveEvent(ctx context.Context, arg MoveEventParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, moveEvent, arg.CalendarID, arg.UpdatedAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeDeletedEvents = `-- name: PurgeDeletedEvents :execrows
+DELETE FROM
+    event
+WHERE
+    deleted_at IS NOT NULL
+    AND deleted_at < $1
+`
+
+func (q *Queries) PurgeDeletedEvents(ctx context.Context, deletedAt sql.NullTime) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeDeletedEvents, deletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeExpiredIdempotencyKeys = `-- name: PurgeExpiredIdempotencyKeys :execrows
+DELETE FROM
+    idempotency_key
+WHERE
+    expires_at <= $1
+`
+
+func (q *Queries) PurgeExpiredIdempotencyKeys(ctx context.Context, expiresAt time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeExpiredIdempotencyKeys, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgePublishedOutboxMessages = `-- name: PurgePublishedOutboxMessages :execrows
+DELETE FROM
+    outbox
+WHERE
+    published_at < $1
+`
+
+func (q *Queries) PurgePublishedOutboxMessages(ctx context.Context, publishedAt sql.NullTime) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgePublishedOutboxMessages, publishedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const releaseIdempotencyKey = `-- name: ReleaseIdempotencyKey :exec
+DELETE FROM
+    idempotency_key
+WHERE
+    actor_id = $1
+    AND key = $2
+    AND created_at = $3
+    AND state = 'PENDING'
+`
+
+type ReleaseIdempotencyKeyParams struct {
+	ActorID   string
+	Key       string
+	CreatedAt time.Time
+}
+
+func (q *Queries) ReleaseIdempotencyKey(ctx context.Context, arg ReleaseIdempotencyKeyParams) error {
+	_, err := q.db.ExecContext(ctx, releaseIdempotencyKey, arg.ActorID, arg.Key, arg.CreatedAt)
+	return err
+}
+
+const rescheduleReminders = `-- name: RescheduleReminders :exec
+UPDATE
+    reminder
+SET
+    next_fire_at = $1
+WHERE
+    event_id = $2
+`
+
+type RescheduleRemindersParams struct {
+	NextFireAt sql.NullTime
+	EventID    string
+}
+
+func (q *Queries) RescheduleReminders(ctx context.Context, arg RescheduleRemindersParams) error {
+	_, err := q.db.ExecContext(ctx, rescheduleReminders, arg.NextFireAt, arg.EventID)
+	return err
+}
+
+const reserveIdempotencyKey = `-- name: ReserveIdempotencyKey :execrows
+INSERT INTO
+    idempotency_key (
+        actor_id,
+        key,
+        request_hash,
+        event_id,
+        state,
+        created_at,
+        expires_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (actor_id, key) DO
+UPDATE
+SET
+    request_hash = EXCLUDED.request_hash,
+    event_id = EXCLUDED.event_id,
+    state = EXCLUDED.state,
+    response = 'null',
+    created_at = EXCLUDED.created_at,
+    expires_at = EXCLUDED.expires_at
+WHERE
+    idempotency_key.expires_at <= EXCLUDED.created_at
+`
+
+type ReserveIdempotencyKeyParams struct {
+	ActorID     string
+	Key         string
+	RequestHash string
+	EventID     string
+	State       string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+func (q *Queries) ReserveIdempotencyKey(ctx context.Context, arg ReserveIdempotencyKeyParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, reserveIdempotencyKey,
+		arg.ActorID,
+		arg.Key,
+		arg.RequestHash,
+		arg.EventID,
+		arg.State,
+		arg.CreatedAt,
+		arg.ExpiresAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreEvent = `-- name: RestoreEvent :execrows
+UPDATE
+    event
+SET
+    deleted_at = NULL
+WHERE
+    id = $1
+    AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) RestoreEvent(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreEvent, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const retryOutboxMessage = `-- name: RetryOutboxMessage :execrows
+UPDATE
+    outbox
+SET
+    attempts = attempts + 1,
+    last_error = $1,
+    next_attempt_at = $2
+WHERE
+    id = $3
+`
+
+type RetryOutboxMessageParams struct {
+	LastError     string
+	NextAttemptAt time.Time
+	ID            string
+}
+
+func (q *Queries) RetryOutboxMessage(ctx context.Context, arg RetryOutboxMessageParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, retryOutboxMessage, arg.LastError, arg.NextAttemptAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const scheduleDueAgendaDeliveries = `-- name: ScheduleDueAgendaDeliveries :execrows
+INSERT INTO
+    agenda_delivery (user_id, agenda_date, channel, status, next_attempt_at)
+SELECT
+    recipient.user_id,
+    recipient.local_now::date,
+    channel.value,
+    $1,
+    $2
+FROM
+    (
+        SELECT
+            users.user_id,
+            ($2::timestamp AT TIME ZONE 'UTC') AT TIME ZONE COALESCE(preference.timezone, 'UTC') AS local_now,
+            COALESCE(preference.channels, $3::jsonb) AS channels
+        FROM
+            (
+                SELECT
+                    created_by AS user_id
+                FROM
+                    event
+                WHERE
+                    deleted_at IS NULL
+                UNION
+                SELECT
+                    invitation.user_id::varchar AS user_id
+                FROM
+                    invitation
+                    JOIN event ON event.id = invitation.event_id
+                WHERE
+                    event.deleted_at IS NULL
+                    AND invitation.status <> $4
+            ) AS users
+            LEFT JOIN notification_preference AS preference ON preference.user_id = users.user_id
+    ) AS recipient
+    CROSS JOIN jsonb_array_elements_text(recipient.channels) AS channel
+WHERE
+    recipient.local_now::time >= $5::time
+    AND recipient.local_now::time < $6::time ON CONFLICT ON CONSTRAINT uq_agenda_delivery DO NOTHING
+`
+
+type ScheduleDueAgendaDeliveriesParams struct {
+	Status          string
+	Now             time.Time
+	DefaultChannels json.RawMessage
+	Declined        int16
+	Morning         string
+	Noon            string
+}
+
+func (q *Queries) ScheduleDueAgendaDeliveries(ctx context.Context, arg ScheduleDueAgendaDeliveriesParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, scheduleDueAgendaDeliveries,
+		arg.Status,
+		arg.Now,
+		arg.DefaultChannels,
+		arg.Declined,
+		arg.Morning,
+		arg.Noon,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const searchEvents = `-- name: SearchEvents :many
+SELECT
+    id,
+    title,
+    description,
+    timezone,
+    created_by,
+    created_at,
+    updated_at,
+    deleted_at,
+    calendar_id,
+    capacity,
+    ts_rank(search_vector, query) AS rank
+FROM
+    event,
+    websearch_to_tsquery('english', $1) query
+WHERE
+    deleted_at IS NULL
+    AND search_vector @@ query
+    AND (
+        created_by = $2
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                invitation
+            WHERE
+                invitation.event_id = event.id
+                AND invitation.user_id::varchar = $2
+        )
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                calendar
+            WHERE
+                calendar.id = event.calendar_id
+                AND calendar.owner_id = $2
+        )
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                calendar_acl
+            WHERE
+                calendar_acl.calendar_id = event.calendar_id
+                AND calendar_acl.role <> 'FREE_BUSY'
+                AND (
+                    (
+                        calendar_acl.grantee_type = 'USER'
+                        AND calendar_acl.grantee_id = $2
+                    )
+                    OR (
+                        calendar_acl.grantee_type = 'GROUP'
+                        AND calendar_acl.grantee_id IN (
+                            SELECT
+                                group_id
+                            FROM
+                                user_group_member
+                            WHERE
+                                user_id = $5
+                        )
+                    )
+                )
+        )
+    )
+ORDER BY
+    rank DESC,
+    created_at DESC,
+    id DESC
+LIMIT
+    $3 OFFSET $4
+`
+
+type SearchEventsParams struct {
+	Query         string
+	ActorID       string
+	PageSize      int32
+	PageOffset    int32
+	ActorMemberID int32
+}
+
+type SearchEventsRow struct {
+	ID          string
+	Title       string
+	Description string
+	Timezone    string
+	CreatedBy   string
+	CreatedAt   time.Time
+	UpdatedAt   sql.NullTime
+	DeletedAt   sql.NullTime
+	CalendarID  string
+	Capacity    int32
+	Rank        float32
+}
+
+func (q *Queries) SearchEvents(ctx context.Context, arg SearchEventsParams) ([]SearchEventsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchEvents,
+		arg.Query,
+		arg.ActorID,
+		arg.PageSize,
+		arg.PageOffset,
+		arg.ActorMemberID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchEventsRow
+	for rows.Next() {
+		var i SearchEventsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Timezone,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.CalendarID,
+			&i.Capacity,
+			&i.Rank,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const unlinkGroupInvitations = `-- name: UnlinkGroupInvitations :exec
+UPDATE
+    invitation
+SET
+    group_id = ''
+WHERE
+    group_id = $1
+`
+
+func (q *Queries) UnlinkGroupInvitations(ctx context.Context, groupID string) error {
+	_, err := q.db.ExecContext(ctx, unlinkGroupInvitations, groupID)
+	return err
+}
+
+const updateAgendaDelivery = `-- name: UpdateAgendaDelivery :execrows
+UPDATE
+    agenda_delivery
+SET
+    status = $1,
+    attempts = $2,
+    last_error = $3,
+    next_attempt_at = $4,
+    sent_at = $5
+WHERE
+    id = $6
+`
+
+type UpdateAgendaDeliveryParams struct {
+	Status        string
+	Attempts      int32
+	LastError     string
+	NextAttemptAt time.Time
+	SentAt        sql.NullTime
+	ID            int64
+}
+
+func (q *Queries) UpdateAgendaDelivery(ctx context.Context, arg UpdateAgendaDeliveryParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateAgendaDelivery,
+		arg.Status,
+		arg.Attempts,
+		arg.LastError,
+		arg.NextAttemptAt,
+		arg.SentAt,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateBookingPage = `-- name: UpdateBookingPage :execrows
+UPDATE
+    booking_page
+SET
+    title = $1,
+    description = $2,
+    timezone = $3,
+    duration = $4,
+    buffer_before = $5,
+    buffer_after = $6,
+    min_notice = $7,
+    max_per_day = $8,
+    rules = $9,
+    updated_at = $10
+WHERE
+    id = $11
+`
+
+type UpdateBookingPageParams struct {
+	Title        string
+	Description  string
+	Timezone     string
+	Duration     int32
+	BufferBefore int32
+	BufferAfter  int32
+	MinNotice    int32
+	MaxPerDay    int32
+	Rules        json.RawMessage
+	UpdatedAt    sql.NullTime
+	ID           string
+}
+
+func (q *Queries) UpdateBookingPage(ctx context.Context, arg UpdateBookingPageParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateBookingPage,
+		arg.Title,
+		arg.Description,
+		arg.Timezone,
+		arg.Duration,
+		arg.BufferBefore,
+		arg.BufferAfter,
+		arg.MinNotice,
+		arg.MaxPerDay,
+		arg.Rules,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateCalendar = `-- name: UpdateCalendar :execrows
+UPDATE
+    calendar
+SET
+    name = $1,
+    color = $2,
+    timezone = $3,
+    description = $4,
+    updated_at = $5
+WHERE
+    id = $6
+`
+
+type UpdateCalendarParams struct {
+	Name        string
+	Color       string
+	Timezone    string
+	Description string
+	UpdatedAt   sql.NullTime
+	ID          string
+}
+
+func (q *Queries) UpdateCalendar(ctx context.Context, arg UpdateCalendarParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateCalendar,
+		arg.Name,
+		arg.Color,
+		arg.Timezone,
+		arg.Description,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateEvent = `-- name: UpdateEvent :execrows
+UPDATE
+    event
+SET
+    title = $1,
+    description = $2,
+    timezone = $3,
+    updated_at = $4,
+    capacity = $5
+WHERE
+    id = $6
+    AND deleted_at IS NULL
+`
+
+type UpdateEventParams struct {
 	Title       string
 	Description string
 	Timezone    string
-	CreatedBy   string
-	CreatedAt   time.Time
 	UpdatedAt   sql.NullTime
+	Capacity    int32
+	ID          string
 }
 
-func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) error {
-	_, err := q.db.ExecContext(ctx, createEvent,
-		arg.ID,
+func (q *Queries) UpdateEvent(ctx context.Context, arg UpdateEventParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateEvent,
 		arg.Title,
 		arg.Description,
 		arg.Timezone,
-		arg.CreatedBy,
-		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.Capacity,
+		arg.ID,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const createInvitation = `-- name: CreateInvitation :exec
-INSERT INTO
-    invitation (id, event_id, user_id, token, status)
-VALUES
-    ($1, $2, $3, $4, $5)
+const updateGroup = `-- name: UpdateGroup :execrows
+UPDATE
+    user_group
+SET
+    name = $1,
+    description = $2,
+    updated_at = $3
+WHERE
+    id = $4
 `
 
-type CreateInvitationParams struct {
-	ID      string
-	EventID string
-	UserID  int32
-	Token   string
-	Status  int16
+type UpdateGroupParams struct {
+	Name        string
+	Description string
+	UpdatedAt   sql.NullTime
+	ID          string
 }
 
-func (q *Queries) CreateInvitation(ctx context.Context, arg CreateInvitationParams) error {
-	_, err := q.db.ExecContext(ctx, createInvitation,
+func (q *Queries) UpdateGroup(ctx context.Context, arg UpdateGroupParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateGroup,
+		arg.Name,
+		arg.Description,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateInvitationStatus = `-- name: UpdateInvitationStatus :execrows
+UPDATE
+    invitation
+SET
+    status = $2,
+    updated_at = $3,
+    waitlisted_at = $4
+WHERE
+    id = $1
+`
+
+type UpdateInvitationStatusParams struct {
+	ID           string
+	Status       int16
+	UpdatedAt    sql.NullTime
+	WaitlistedAt sql.NullTime
+}
+
+func (q *Queries) UpdateInvitationStatus(ctx context.Context, arg UpdateInvitationStatusParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateInvitationStatus,
 		arg.ID,
-		arg.EventID,
-		arg.UserID,
-		arg.Token,
 		arg.Status,
+		arg.UpdatedAt,
+		arg.WaitlistedAt,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const createSchedule = `-- name: CreateSchedule :exec
-INSERT INTO
-    schedule (
-        id,
-        event_id,
-        start_time,
-        "duration",
-        is_full_day,
-        recurring_interval,
-        recurring_type
-    )
-VALUES
-    ($1, $2, $3, $4, $5, $6, $7)
+const updateReminderDelivery = `-- name: UpdateReminderDelivery :execrows
+UPDATE
+    reminder_delivery
+SET
+    status = $1,
+    attempts = $2,
+    last_error = $3,
+    next_attempt_at = $4,
+    sent_at = $5
+WHERE
+    id = $6
 `
 
-type CreateScheduleParams struct {
-	ID                string
-	EventID           string
-	StartTime         int64
-	Duration          int64
-	IsFullDay         bool
-	RecurringInterval int64
-	RecurringType     string
+type UpdateReminderDeliveryParams struct {
+	Status        string
+	Attempts      int32
+	LastError     string
+	NextAttemptAt time.Time
+	SentAt        sql.NullTime
+	ID            int64
 }
 
-func (q *Queries) CreateSchedule(ctx context.Context, arg CreateScheduleParams) error {
-	_, err := q.db.ExecContext(ctx, createSchedule,
+func (q *Queries) UpdateReminderDelivery(ctx context.Context, arg UpdateReminderDeliveryParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateReminderDelivery,
+		arg.Status,
+		arg.Attempts,
+		arg.LastError,
+		arg.NextAttemptAt,
+		arg.SentAt,
 		arg.ID,
-		arg.EventID,
-		arg.StartTime,
-		arg.Duration,
-		arg.IsFullDay,
-		arg.RecurringInterval,
-		arg.RecurringType,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const deleteEvent = `-- name: DeleteEvent :exec
-DELETE FROM
-    event
+const updateReminderNextFireAt = `-- name: UpdateReminderNextFireAt :execrows
+UPDATE
+    reminder
+SET
+    next_fire_at = $1
 WHERE
-    id = $1
+    id = $2
 `
 
-func (q *Queries) DeleteEvent(ctx context.Context, id string) error {
-	_, err := q.db.ExecContext(ctx, deleteEvent, id)
-	return err
+type UpdateReminderNextFireAtParams struct {
+	NextFireAt sql.NullTime
+	ID         string
 }
 
-const findEventByID = `-- name: FindEventByID :one
-SELECT
-    id, title, description, timezone, created_by, created_at, updated_at
-FROM
-    event
+func (q *Queries) UpdateReminderNextFireAt(ctx context.Context, arg UpdateReminderNextFireAtParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateReminderNextFireAt, arg.NextFireAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateResource = `-- name: UpdateResource :execrows
+UPDATE
+    resource
+SET
+    name = $1,
+    type = $2,
+    capacity = $3,
+    location = $4,
+    updated_at = $5
 WHERE
-    id = $1
-LIMIT
-    1
+    id = $6
 `
 
-func (q *Queries) FindEventByID(ctx context.Context, id string) (Event, error) {
-	row := q.db.QueryRowContext(ctx, findEventByID, id)
-	var i Event
-	err := row.Scan(
-		&i.ID,
-		&i.Title,
-		&i.Description,
-		&i.Timezone,
-		&i.CreatedBy,
-		&i.CreatedAt,
-		&i.UpdatedAt,
+type UpdateResourceParams struct {
+	Name      string
+	Type      string
+	Capacity  int32
+	Location  string
+	UpdatedAt sql.NullTime
+	ID        string
+}
+
+func (q *Queries) UpdateResource(ctx context.Context, arg UpdateResourceParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateResource,
+		arg.Name,
+		arg.Type,
+		arg.Capacity,
+		arg.Location,
+		arg.UpdatedAt,
+		arg.ID,
 	)
-	return i, err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const findInvitationsByEventID = `-- name: FindInvitationsByEventID :many
-SELECT
-    id, event_id, user_id, token, status, updated_at
-FROM
-    invitation
+const updateWebhook = `-- name: UpdateWebhook :execrows
+UPDATE
+    webhook
+SET
+    url = $1,
+    secret = $2,
+    event_types = $3,
+    updated_at = $4
 WHERE
-    event_id = $1
+    id = $5
 `
 
-func (q *Queries) FindInvitationsByEventID(ctx context.Context, eventID string) ([]Invitation, error) {
-	rows, err := q.db.QueryContext(ctx, findInvitationsByEventID, eventID)
+type UpdateWebhookParams struct {
+	Url        string
+	Secret     string
+	EventTypes json.RawMessage
+	UpdatedAt  sql.NullTime
+	ID         string
+}
+
+func (q *Queries) UpdateWebhook(ctx context.Context, arg UpdateWebhookParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateWebhook,
+		arg.Url,
+		arg.Secret,
+		arg.EventTypes,
+		arg.UpdatedAt,
+		arg.ID,
+	)
 	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []Invitation
-	for rows.Next() {
-		var i Invitation
-		if err := rows.Scan(
-			&i.ID,
-			&i.EventID,
-			&i.UserID,
-			&i.Token,
-			&i.Status,
-			&i.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+		return 0, err
 	}
-	return items, nil
+	return result.RowsAffected()
 }
 
-const findSchedulesByEventID = `-- name: FindSchedulesByEventID :many
-SELECT
-    id, event_id, start_time, duration, is_full_day, recurring_interval, recurring_type
-FROM
-    schedule
+const updateWebhookDelivery = `-- name: UpdateWebhookDelivery :execrows
+UPDATE
+    webhook_delivery
+SET
+    status = $1,
+    attempts = $2,
+    response_status = $3,
+    last_error = $4,
+    next_attempt_at = $5,
+    delivered_at = $6
 WHERE
-    event_id = $1
+    id = $7
 `
 
-func (q *Queries) FindSchedulesByEventID(ctx context.Context, eventID string) ([]Schedule, error) {
-	rows, err := q.db.QueryContext(ctx, findSchedulesByEventID, eventID)
+type UpdateWebhookDeliveryParams struct {
+	Status         string
+	Attempts       int32
+	ResponseStatus int32
+	LastError      string
+	NextAttemptAt  time.Time
+	DeliveredAt    sql.NullTime
+	ID             int64
+}
+
+func (q *Queries) UpdateWebhookDelivery(ctx context.Context, arg UpdateWebhookDeliveryParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateWebhookDelivery,
+		arg.Status,
+		arg.Attempts,
+		arg.ResponseStatus,
+		arg.LastError,
+		arg.NextAttemptAt,
+		arg.DeliveredAt,
+		arg.ID,
+	)
 	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []Schedule
-	for rows.Next() {
-		var i Schedule
-		if err := rows.Scan(
-			&i.ID,
-			&i.EventID,
-			&i.StartTime,
-			&i.Duration,
-			&i.IsFullDay,
-			&i.RecurringInterval,
-			&i.RecurringType,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+		return 0, err
 	}
-	return items, nil
+	return result.RowsAffected()
 }
 
-const updateEvent = `-- name: UpdateEvent :exec
+const upsertCalendarGrant = `-- name: UpsertCalendarGrant :exec
+INSERT INTO
+    calendar_acl (
+        calendar_id,
+        grantee_type,
+        grantee_id,
+        role,
+        granted_by,
+        created_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6) ON CONFLICT (calendar_id, grantee_type, grantee_id) DO
 UPDATE
-    event
 SET
-    title = $1,
-    description = $2,
-    timezone = $3,
-    updated_at = $4
+    role = EXCLUDED.role,
+    granted_by = EXCLUDED.granted_by,
+    updated_at = EXCLUDED.created_at
 `
 
-type UpdateEventParams struct {
-	Title       string
-	Description string
-	Timezone    string
-	UpdatedAt   sql.NullTime
+type UpsertCalendarGrantParams struct {
+	CalendarID  string
+	GranteeType string
+	GranteeID   string
+	Role        string
+	GrantedBy   string
+	CreatedAt   time.Time
 }
 
-func (q *Queries) UpdateEvent(ctx context.Context, arg UpdateEventParams) error {
-	_, err := q.db.ExecContext(ctx, updateEvent,
-		arg.Title,
-		arg.Description,
-		arg.Timezone,
-		arg.UpdatedAt,
+func (q *Queries) UpsertCalendarGrant(ctx context.Context, arg UpsertCalendarGrantParams) error {
+	_, err := q.db.ExecContext(ctx, upsertCalendarGrant,
+		arg.CalendarID,
+		arg.GranteeType,
+		arg.GranteeID,
+		arg.Role,
+		arg.GrantedBy,
+		arg.CreatedAt,
 	)
 	return err
 }
 
 const upsertInvitation = `-- name: UpsertInvitation :exec
 INSERT INTO
-    invitation (id, event_id, user_id, token, status)
+    invitation (
+        id,
+        event_id,
+        user_id,
+        token,
+        status,
+        group_id,
+        updated_at,
+        waitlisted_at
+    )
 VALUES
-    ($1, $2, $3, $4, $5) ON CONFLICT (id, event_id) DO
+    ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (id, event_id) DO
 UPDATE
 SET
     user_id = $3,
     token = $4,
-    status = $5
+    status = $5,
+    group_id = $6,
+    updated_at = $7,
+    waitlisted_at = $8
 `
 
 type UpsertInvitationParams struct {
-	ID      string
-	EventID string
-	UserID  int32
-	Token   string
-	Status  int16
+	ID           string
+	EventID      string
+	UserID       int32
+	Token        string
+	Status       int16
+	GroupID      string
+	UpdatedAt    sql.NullTime
+	WaitlistedAt sql.NullTime
 }
 
 func (q *Queries) UpsertInvitation(ctx context.Context, arg UpsertInvitationParams) error {
@@ -284,6 +3931,59 @@ func (q *Queries) UpsertInvitation(ctx context.Context, arg UpsertInvitationPara
 		arg.UserID,
 		arg.Token,
 		arg.Status,
+		arg.GroupID,
+		arg.UpdatedAt,
+		arg.WaitlistedAt,
+	)
+	return err
+}
+
+const upsertNotificationPreference = `-- name: UpsertNotificationPreference :exec
+INSERT INTO
+    notification_preference (
+        user_id,
+        channels,
+        timezone,
+        quiet_hours_start,
+        quiet_hours_end,
+        delivery,
+        digest_time,
+        updated_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (user_id) DO
+UPDATE
+SET
+    channels = EXCLUDED.channels,
+    timezone = EXCLUDED.timezone,
+    quiet_hours_start = EXCLUDED.quiet_hours_start,
+    quiet_hours_end = EXCLUDED.quiet_hours_end,
+    delivery = EXCLUDED.delivery,
+    digest_time = EXCLUDED.digest_time,
+    updated_at = EXCLUDED.updated_at
+`
+
+type UpsertNotificationPreferenceParams struct {
+	UserID          string
+	Channels        json.RawMessage
+	Timezone        string
+	QuietHoursStart string
+	QuietHoursEnd   string
+	Delivery        string
+	DigestTime      string
+	UpdatedAt       time.Time
+}
+
+func (q *Queries) UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) error {
+	_, err := q.db.ExecContext(ctx, upsertNotificationPreference,
+		arg.UserID,
+		arg.Channels,
+		arg.Timezone,
+		arg.QuietHoursStart,
+		arg.QuietHoursEnd,
+		arg.Delivery,
+		arg.DigestTime,
+		arg.UpdatedAt,
 	)
 	return err
 }
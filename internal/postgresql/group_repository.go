@@ -0,0 +1,293 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
+	"github.com/jmoiron/sqlx"
+)
+
+type GroupRepository struct {
+	dbConn  *sqlx.DB
+	queries *gen.Queries
+}
+
+func NewGroupRepository(dbConn *sqlx.DB) *GroupRepository {
+	return &GroupRepository{
+		dbConn:  dbConn,
+		queries: gen.New(dbConn),
+	}
+}
+
+func (g *GroupRepository) Store(ctx context.Context, group *core.Group) error {
+	return g.inTx(ctx, func(queries *gen.Queries) error {
+		err := queries.CreateGroup(ctx, gen.CreateGroupParams{
+			ID:          group.ID,
+			OwnerID:     group.OwnerID,
+			Name:        group.Name,
+			Description: group.Description,
+			CreatedAt:   group.CreatedAt,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return translateErr(err)
+		}
+
+		return addMembers(ctx, queries, group.ID, group.Members, group.CreatedAt)
+	})
+}
+
+func (g *GroupRepository) Update(ctx context.Context, group *core.Group) error {
+	var updatedAt sql.NullTime
+	if group.UpdatedAt != nil {
+		updatedAt = sql.NullTime{Time: *group.UpdatedAt, Valid: true}
+	}
+
+	affected, err := g.queries.UpdateGroup(ctx, gen.UpdateGroupParams{
+		Name:        group.Name,
+		Description: group.Description,
+		UpdatedAt:   updatedAt,
+		ID:          group.ID,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	if affected == 0 {
+		return core.ErrGroupNotFound
+	}
+	return nil
+}
+
+func (g *GroupRepository) DeleteByID(ctx context.Context, id string) error {
+	return g.inTx(ctx, func(queries *gen.Queries) error {
+		err := queries.UnlinkGroupInvitations(ctx, id)
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+
+		err = queries.DeleteGroupCalendarGrants(ctx, id)
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+
+		affected, err := queries.DeleteGroup(ctx, id)
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+		if affected == 0 {
+			return core.ErrGroupNotFound
+		}
+		return nil
+	})
+}
+
+func (g *GroupRepository) FindByID(ctx context.Context, id string) (*core.Group, error) {
+	row, err := g.queries.FindGroupByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, core.ErrGroupNotFound
+	}
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	group := toCoreGroup(row)
+	group.Members, err = g.queries.FindGroupMembers(ctx, id)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (g *GroupRepository) FindByOwner(ctx context.Context, ownerID string) ([]core.Group, error) {
+	rows, err := g.queries.FindGroupsByOwner(ctx, ownerID)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	groups := make([]core.Group, len(rows))
+	for index, row := range rows {
+		groups[index] = toCoreGroup(row)
+	}
+	return groups, g.loadMembers(ctx, groups)
+}
+
+// loadMembers loads the members of the groups with one query.
+func (g *GroupRepository) loadMembers(ctx context.Context, groups []core.Group) error {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(groups))
+	positions := make(map[string]int, len(groups))
+	for index := range groups {
+		ids[index] = groups[index].ID
+		positions[groups[index].ID] = index
+	}
+
+	query, args, err := sqlx.In(`SELECT group_id, user_id FROM user_group_member WHERE group_id IN (?) ORDER BY created_at, user_id`, ids)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	var members []struct {
+		GroupID string `db:"group_id"`
+		UserID  int32  `db:"user_id"`
+	}
+	err = g.dbConn.SelectContext(ctx, &members, g.dbConn.Rebind(query), args...)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	for _, member := range members {
+		group := &groups[positions[member.GroupID]]
+		group.Members = append(group.Members, member.UserID)
+	}
+	return nil
+}
+
+func (g *GroupRepository) AddMembers(ctx context.Context, groupID string, userIDs []int32, updates []core.EventMutation) error {
+	return g.inTx(ctx, func(queries *gen.Queries) error {
+		err := addMembers(ctx, queries, groupID, userIDs, time.Now())
+		if err != nil {
+			return err
+		}
+
+		return applyUpdates(ctx, queries, updates)
+	})
+}
+
+func (g *GroupRepository) RemoveMembers(ctx context.Context, groupID string, userIDs []int32, updates []core.EventMutation) error {
+	return g.inTx(ctx, func(queries *gen.Queries) error {
+		for _, userID := range userIDs {
+			err := queries.DeleteGroupMember(ctx, gen.DeleteGroupMemberParams{
+				GroupID: groupID,
+				UserID:  userID,
+			})
+			if err != nil {
+				slog.Error(err.Error())
+				return err
+			}
+
+			for _, update := range updates {
+				affected, err := queries.DeleteGroupInvitation(ctx, gen.DeleteGroupInvitationParams{
+					EventID: update.Event.ID,
+					UserID:  userID,
+					GroupID: groupID,
+				})
+				if err != nil {
+					slog.Error(err.Error())
+					return err
+				}
+				if affected == 0 {
+					continue
+				}
+
+				// the reminders the attendee set would otherwise still fire
+				err = queries.DeleteReminders(ctx, gen.DeleteRemindersParams{
+					EventID: update.Event.ID,
+					UserID:  userID,
+				})
+				if err != nil {
+					slog.Error(err.Error())
+					return err
+				}
+			}
+		}
+
+		return applyUpdates(ctx, queries, updates)
+	})
+}
+
+// applyUpdates writes the events the way the event repository does, so that the history, the
+// outbox and the invitations of the attendees are kept in step with them.
+func applyUpdates(ctx context.Context, queries *gen.Queries, updates []core.EventMutation) error {
+	for _, update := range updates {
+		err := updateEvent(ctx, queries, update.Event)
+		if err != nil {
+			return err
+		}
+
+		err = recordJournal(ctx, queries, update.Journal)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *GroupRepository) FindUpcomingEventIDs(ctx context.Context, groupID string, after time.Time) ([]string, error) {
+	ids, err := g.queries.FindUpcomingGroupEventIDs(ctx, gen.FindUpcomingGroupEventIDsParams{
+		GroupID: groupID,
+		After:   after.Unix(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+	return ids, nil
+}
+
+// inTx runs fn with queries bound to a new transaction, which is committed if fn succeeds.
+func (g *GroupRepository) inTx(ctx context.Context, fn func(queries *gen.Queries) error) error {
+	tx, err := g.dbConn.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+	defer func() {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			slog.Error(rollbackErr.Error())
+		}
+	}()
+
+	err = fn(g.queries.WithTx(tx))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func addMembers(ctx context.Context, queries *gen.Queries, groupID string, userIDs []int32, addedAt time.Time) error {
+	for _, userID := range userIDs {
+		err := queries.CreateGroupMember(ctx, gen.CreateGroupMemberParams{
+			GroupID:   groupID,
+			UserID:    userID,
+			CreatedAt: addedAt,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+func toCoreGroup(row gen.UserGroup) core.Group {
+	group := core.Group{
+		ID:          row.ID,
+		OwnerID:     row.OwnerID,
+		Name:        row.Name,
+		Description: row.Description,
+		CreatedAt:   row.CreatedAt,
+	}
+	if row.UpdatedAt.Valid {
+		group.UpdatedAt = &row.UpdatedAt.Time
+	}
+	return group
+}
@@ -0,0 +1,254 @@
+package postgresql_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var groupColumns = []string{"id", "owner_id", "name", "description", "created_at", "updated_at"}
+
+func TestGroupRepository_Store(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	now := time.Now()
+	group := &core.Group{
+		ID:        "group1",
+		OwnerID:   "1",
+		Name:      "Engineering",
+		Members:   []int32{2, 3},
+		CreatedAt: now,
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectExec(`INSERT INTO user_group`).
+						WithArgs("group1", "1", "Engineering", "", now).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectExec(`INSERT INTO user_group_member`).WithArgs("group1", 2, now).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectExec(`INSERT INTO user_group_member`).WithArgs("group1", 3, now).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectCommit()
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+		},
+		{
+			name: "Not OK - error",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectExec(`INSERT INTO user_group`).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectExec(`INSERT INTO user_group_member`).WillReturnError(errors.New("error")) //nolint:goerr113
+					mock.ExpectRollback()
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := postgresql.NewGroupRepository(tt.fields.dbMock(t)).Store(context.Background(), group)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGroupRepository_FindByID(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	now := time.Now()
+	tests := []struct {
+		name      string
+		fields    fields
+		want      *core.Group
+		wantErrIs error
+	}{
+		{
+			name: "OK - along with the members",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectQuery(`SELECT .+ FROM user_group WHERE id = \$1`).WithArgs("group1").
+						WillReturnRows(sqlmock.NewRows(groupColumns).AddRow("group1", "1", "Engineering", "", now, nil))
+					mock.ExpectQuery(`SELECT user_id FROM user_group_member WHERE group_id = \$1`).WithArgs("group1").
+						WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(2).AddRow(3))
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			want: &core.Group{ID: "group1", OwnerID: "1", Name: "Engineering", Members: []int32{2, 3}, CreatedAt: now},
+		},
+		{
+			name: "Not OK - not found",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectQuery(`SELECT .+ FROM user_group WHERE id = \$1`).WillReturnError(sql.ErrNoRows)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErrIs: core.ErrGroupNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := postgresql.NewGroupRepository(tt.fields.dbMock(t)).FindByID(context.Background(), "group1")
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGroupRepository_DeleteByID(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantErrIs error
+	}{
+		{
+			name: "OK - the invitations are unlinked and the grants deleted",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectExec(`UPDATE invitation SET group_id = '' WHERE group_id = \$1`).WithArgs("group1").
+						WillReturnResult(sqlmock.NewResult(0, 2))
+					mock.ExpectExec(`DELETE FROM calendar_acl WHERE grantee_type = 'GROUP'`).WithArgs("group1").
+						WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectExec(`DELETE FROM user_group WHERE id = \$1`).WithArgs("group1").
+						WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectCommit()
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+		},
+		{
+			name: "Not OK - not found",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectExec(`UPDATE invitation`).WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectExec(`DELETE FROM calendar_acl`).WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectExec(`DELETE FROM user_group`).WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectRollback()
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErrIs: core.ErrGroupNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := postgresql.NewGroupRepository(tt.fields.dbMock(t)).DeleteByID(context.Background(), "group1")
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// groupUpdate is the update of the event that changes the invitations of the members of the group.
+func groupUpdate(eventID string, invitations ...core.Invitation) core.EventMutation {
+	before := &core.Event{ID: eventID}
+	after := &core.Event{ID: eventID, Invitations: invitations}
+	change := core.NewEventChange(eventID, "1", core.HistoryOperation_Update, before, after)
+	return core.EventMutation{
+		Type:    core.MutationType_Update,
+		Event:   after,
+		Journal: core.NewJournal(change, before, after),
+	}
+}
+
+func TestGroupRepository_AddMembers(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO user_group_member`).WithArgs("group1", 3, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// the event is updated along with its journal
+	mock.ExpectExec(`UPDATE event SET`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO invitation`).
+		WithArgs("inv1", "event1", 3, "token", int16(core.InvitationStatus_Unknown), "group1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM resource_booking`).WithArgs("event1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM reminder`).WithArgs("event1", int32(0)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE reminder SET next_fire_at`).WithArgs(sqlmock.AnyArg(), "event1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO event_history`).WithArgs("event1", "1", "UPDATE", sqlmock.AnyArg(), sqlmock.AnyArg(), "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO outbox`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := postgresql.NewGroupRepository(sqlx.NewDb(db, "pgx")).AddMembers(context.Background(), "group1", []int32{3}, []core.EventMutation{
+		groupUpdate("event1", core.Invitation{ID: "inv1", EventID: "event1", UserID: 3, Token: "token", GroupID: "group1"}),
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGroupRepository_RemoveMembers(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM user_group_member`).WithArgs("group1", 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM invitation`).WithArgs("event1", 2, "group1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM reminder`).WithArgs("event1", 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// the event is updated along with its journal, without the withdrawn invitation
+	mock.ExpectExec(`UPDATE event SET`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM resource_booking`).WithArgs("event1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM reminder`).WithArgs("event1", int32(0)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE reminder SET next_fire_at`).WithArgs(sqlmock.AnyArg(), "event1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO event_history`).WithArgs("event1", "1", "UPDATE", sqlmock.AnyArg(), sqlmock.AnyArg(), "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO outbox`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.MatchExpectationsInOrder(true)
+
+	err := postgresql.NewGroupRepository(sqlx.NewDb(db, "pgx")).RemoveMembers(context.Background(), "group1", []int32{2}, []core.EventMutation{
+		groupUpdate("event1"),
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,62 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/health"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jmoiron/sqlx"
+)
+
+// PingProbe fails when the database can't be reached.
+func PingProbe(dbConn *sqlx.DB) health.ProbeFunc {
+	return func(ctx context.Context) error {
+		return dbConn.PingContext(ctx)
+	}
+}
+
+// SchemaProbe fails when the database schema isn't at the latest of the given migrations,
+// since the queries of this binary may rely on any of them.
+func SchemaProbe(dbConn *sqlx.DB, migrations fs.FS) (health.ProbeFunc, error) {
+	src, err := iofs.New(migrations, ".")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+
+	latest, err := latestVersion(src)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`SELECT version, dirty FROM %s LIMIT 1`, postgres.DefaultMigrationsTable)
+	return func(ctx context.Context) error {
+		status := MigrationStatus{Latest: latest}
+
+		var version int64
+		err := dbConn.QueryRowContext(ctx, query).Scan(&version, &status.Dirty)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if version > 0 {
+			status.Version = uint(version)
+		}
+
+		err = status.compatible()
+		if err != nil {
+			return err
+		}
+
+		if status.Pending() {
+			return fmt.Errorf("%w: database is at version %d, application expects %d", ErrSchemaPending, status.Version, status.Latest)
+		}
+		return nil
+	}, nil
+}
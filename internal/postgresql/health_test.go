@@ -0,0 +1,85 @@
+package postgresql_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaProbe(t *testing.T) {
+	migrations := fstest.MapFS{
+		"000001_create_a.up.sql":   {Data: []byte("SELECT 1;")},
+		"000001_create_a.down.sql": {Data: []byte("SELECT 1;")},
+		"000002_create_b.up.sql":   {Data: []byte("SELECT 1;")},
+		"000002_create_b.down.sql": {Data: []byte("SELECT 1;")},
+	}
+	columns := []string{"version", "dirty"}
+	tests := []struct {
+		name      string
+		rows      *sqlmock.Rows
+		queryErr  error
+		wantErrIs error
+		wantErr   bool
+	}{
+		{
+			name: "OK - at the latest version",
+			rows: sqlmock.NewRows(columns).AddRow(2, false),
+		},
+		{
+			name:      "Not OK - pending migrations",
+			rows:      sqlmock.NewRows(columns).AddRow(1, false),
+			wantErrIs: postgresql.ErrSchemaPending,
+		},
+		{
+			name:      "Not OK - never migrated",
+			rows:      sqlmock.NewRows(columns),
+			wantErrIs: postgresql.ErrSchemaPending,
+		},
+		{
+			name:      "Not OK - dirty",
+			rows:      sqlmock.NewRows(columns).AddRow(2, true),
+			wantErrIs: postgresql.ErrSchemaDirty,
+		},
+		{
+			name:      "Not OK - ahead",
+			rows:      sqlmock.NewRows(columns).AddRow(3, false),
+			wantErrIs: postgresql.ErrSchemaVersionAhead,
+		},
+		{
+			name:     "Not OK - query error",
+			queryErr: errors.New("connection refused"), //nolint:goerr113
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			query := mock.ExpectQuery(`SELECT version, dirty FROM schema_migrations`)
+			if tt.queryErr != nil {
+				query.WillReturnError(tt.queryErr)
+			} else {
+				query.WillReturnRows(tt.rows)
+			}
+
+			probe, err := postgresql.SchemaProbe(sqlx.NewDb(db, "pgx"), migrations)
+			require.NoError(t, err)
+
+			err = probe(context.Background())
+			switch {
+			case tt.wantErrIs != nil:
+				assert.ErrorIs(t, err, tt.wantErrIs)
+			case tt.wantErr:
+				assert.Error(t, err)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
+	"github.com/jmoiron/sqlx"
+)
+
+// completeIdempotencyKey stores the response of the key with queries, which are bound to the
+// transaction that creates the event. It fails with core.ErrIdempotencyKeyPending when the key is no
+// longer pending under the reservation of key, i.e: its lease has been taken over by a retry.
+func completeIdempotencyKey(ctx context.Context, queries *gen.Queries, key *core.IdempotencyKey) error {
+	response, err := json.Marshal(key.Response)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	affected, err := queries.CompleteIdempotencyKey(ctx, gen.CompleteIdempotencyKeyParams{
+		ActorID:   key.ActorID,
+		Key:       key.Key,
+		CreatedAt: key.CreatedAt,
+		EventID:   key.EventID,
+		Response:  response,
+		ExpiresAt: key.ExpiresAt,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+	if affected == 0 {
+		return core.ErrIdempotencyKeyPending
+	}
+	return nil
+}
+
+type IdempotencyKeyRepository struct {
+	queries *gen.Queries
+}
+
+func NewIdempotencyKeyRepository(dbConn *sqlx.DB) *IdempotencyKeyRepository {
+	return &IdempotencyKeyRepository{
+		queries: gen.New(dbConn),
+	}
+}
+
+// Reserve inserts the key, or takes it over once it has expired.
+func (i *IdempotencyKeyRepository) Reserve(ctx context.Context, key *core.IdempotencyKey) (bool, error) {
+	affected, err := i.queries.ReserveIdempotencyKey(ctx, gen.ReserveIdempotencyKeyParams{
+		ActorID:     key.ActorID,
+		Key:         key.Key,
+		RequestHash: key.RequestHash,
+		EventID:     key.EventID,
+		State:       string(key.State),
+		CreatedAt:   key.CreatedAt,
+		ExpiresAt:   key.ExpiresAt,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return false, translateErr(err)
+	}
+	return affected > 0, nil
+}
+
+func (i *IdempotencyKeyRepository) Find(ctx context.Context, actorID string, key string) (*core.IdempotencyKey, error) {
+	row, err := i.queries.FindIdempotencyKey(ctx, gen.FindIdempotencyKeyParams{
+		ActorID: actorID,
+		Key:     key,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, core.ErrIdempotencyKeyNotFound
+	}
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	var response *core.Event
+	if err := json.Unmarshal(row.Response, &response); err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	return &core.IdempotencyKey{
+		ActorID:     row.ActorID,
+		Key:         row.Key,
+		RequestHash: row.RequestHash,
+		EventID:     row.EventID,
+		State:       core.IdempotencyKeyState(row.State),
+		Response:    response,
+		CreatedAt:   row.CreatedAt,
+		ExpiresAt:   row.ExpiresAt,
+	}, nil
+}
+
+func (i *IdempotencyKeyRepository) Release(ctx context.Context, key *core.IdempotencyKey) error {
+	err := i.queries.ReleaseIdempotencyKey(ctx, gen.ReleaseIdempotencyKeyParams{
+		ActorID:   key.ActorID,
+		Key:       key.Key,
+		CreatedAt: key.CreatedAt,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	return nil
+}
+
+func (i *IdempotencyKeyRepository) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	affected, err := i.queries.PurgeExpiredIdempotencyKeys(ctx, now)
+	if err != nil {
+		slog.Error(err.Error())
+		return 0, translateErr(err)
+	}
+	return affected, nil
+}
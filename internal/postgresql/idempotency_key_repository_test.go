@@ -0,0 +1,271 @@
+package postgresql_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyKeyRepository_Reserve(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	now := time.Now()
+	key := &core.IdempotencyKey{
+		ActorID:     "1",
+		Key:         "retry-123",
+		RequestHash: "hash",
+		EventID:     "123",
+		State:       core.IdempotencyKeyState_Pending,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "OK - reserved",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectExec(`INSERT INTO idempotency_key`).
+						WithArgs("1", "retry-123", "hash", "123", "PENDING", now, now.Add(time.Hour)).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			want: true,
+		},
+		{
+			name: "OK - already held",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectExec(`INSERT INTO idempotency_key`).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Not OK - error",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectExec(`INSERT INTO idempotency_key`).WillReturnError(errors.New("error")) //nolint:goerr113
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := postgresql.NewIdempotencyKeyRepository(tt.fields.dbMock(t)).Reserve(context.Background(), key)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIdempotencyKeyRepository_Find(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	now := time.Now()
+	columns := []string{"actor_id", "key", "request_hash", "event_id", "created_at", "expires_at", "state", "response"}
+	tests := []struct {
+		name      string
+		fields    fields
+		want      *core.IdempotencyKey
+		wantErrIs error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectQuery(`SELECT (.+) FROM idempotency_key`).WithArgs("1", "retry-123").
+						WillReturnRows(sqlmock.NewRows(columns).
+							AddRow("1", "retry-123", "hash", "123", now, now.Add(time.Hour), "COMPLETED", []byte(`{"ID":"123","Title":"test"}`)))
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			want: &core.IdempotencyKey{
+				ActorID:     "1",
+				Key:         "retry-123",
+				RequestHash: "hash",
+				EventID:     "123",
+				State:       core.IdempotencyKeyState_Completed,
+				Response:    &core.Event{ID: "123", Title: "test"},
+				CreatedAt:   now,
+				ExpiresAt:   now.Add(time.Hour),
+			},
+		},
+		{
+			name: "OK - pending",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectQuery(`SELECT (.+) FROM idempotency_key`).WithArgs("1", "retry-123").
+						WillReturnRows(sqlmock.NewRows(columns).
+							AddRow("1", "retry-123", "hash", "123", now, now.Add(time.Minute), "PENDING", []byte(`null`)))
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			want: &core.IdempotencyKey{
+				ActorID:     "1",
+				Key:         "retry-123",
+				RequestHash: "hash",
+				EventID:     "123",
+				State:       core.IdempotencyKeyState_Pending,
+				CreatedAt:   now,
+				ExpiresAt:   now.Add(time.Minute),
+			},
+		},
+		{
+			name: "Not OK - not found",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectQuery(`SELECT (.+) FROM idempotency_key`).WillReturnError(sql.ErrNoRows)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErrIs: core.ErrIdempotencyKeyNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := postgresql.NewIdempotencyKeyRepository(tt.fields.dbMock(t)).Find(context.Background(), "1", "retry-123")
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIdempotencyKeyRepository_Release(t *testing.T) {
+	now := time.Now()
+	db, mock, _ := sqlmock.New()
+	mock.ExpectExec(`DELETE FROM idempotency_key (.+) AND state = 'PENDING'`).WithArgs("1", "retry-123", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := postgresql.NewIdempotencyKeyRepository(sqlx.NewDb(db, "pgx")).Release(context.Background(), &core.IdempotencyKey{
+		ActorID:   "1",
+		Key:       "retry-123",
+		CreatedAt: now,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEventRepository_Store_IdempotencyKey(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	now := time.Now()
+	event := &core.Event{ID: "123", Title: "test", CreatedBy: "1", CalendarID: "cal1"}
+	key := &core.IdempotencyKey{
+		ActorID:   "1",
+		Key:       "retry-123",
+		EventID:   "123",
+		State:     core.IdempotencyKeyState_Completed,
+		Response:  event,
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantErrIs error
+	}{
+		{
+			name: "OK - completed in the transaction of the event",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectQuery(`SELECT .+ FROM calendar WHERE id = \$1`).WithArgs("cal1").
+						WillReturnRows(sqlmock.NewRows(calendarColumns).
+							AddRow("cal1", "1", "Default", "", "Asia/Jakarta", "", true, now, nil))
+					mock.ExpectExec(`INSERT INTO event`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`UPDATE idempotency_key`).
+						WithArgs("1", "retry-123", now, "123", sqlmock.AnyArg(), now.Add(time.Hour)).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectCommit()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+		},
+		{
+			name: "Not OK - the event is rolled back when the key was taken over",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectQuery(`SELECT .+ FROM calendar WHERE id = \$1`).WithArgs("cal1").
+						WillReturnRows(sqlmock.NewRows(calendarColumns).
+							AddRow("cal1", "1", "Default", "", "Asia/Jakarta", "", true, now, nil))
+					mock.ExpectExec(`INSERT INTO event`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`UPDATE idempotency_key`).WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErrIs: core.ErrIdempotencyKeyPending,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := postgresql.NewEventRepository(tt.fields.dbMock(t))
+			err := e.Store(context.Background(), event, core.Journal{IdempotencyKey: key})
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestIdempotencyKeyRepository_PurgeExpired(t *testing.T) {
+	now := time.Now()
+	db, mock, _ := sqlmock.New()
+	mock.ExpectExec(`DELETE FROM idempotency_key`).WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+
+	got, err := postgresql.NewIdempotencyKeyRepository(sqlx.NewDb(db, "pgx")).PurgeExpired(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
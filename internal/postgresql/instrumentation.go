@@ -2,9 +2,12 @@ package postgresql
 
 import (
 	"context"
+	"time"
 
 	"github.com/dzakaammar/event-scheduling-example/internal/core"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -20,39 +23,39 @@ func NewInstrumentation(next core.EventRepository) *Instrumentation {
 	}
 }
 
-func (i *Instrumentation) Store(ctx context.Context, event *core.Event) error {
+func (i *Instrumentation) Store(ctx context.Context, event *core.Event, journal core.Journal) error {
 	var err error
 	ctx, span := i.tracer.Start(ctx, "store")
 	defer func() {
-		span.RecordError(err)
+		recordError(span, err)
 		span.End()
 	}()
 
-	err = i.next.Store(ctx, event)
+	err = translateErr(i.next.Store(ctx, event, journal))
 	return err
 }
 
-func (i *Instrumentation) DeleteByID(ctx context.Context, id string) error {
+func (i *Instrumentation) DeleteByID(ctx context.Context, id string, journal core.Journal) error {
 	var err error
 	ctx, span := i.tracer.Start(ctx, "delete-by-id")
 	defer func() {
-		span.RecordError(err)
+		recordError(span, err)
 		span.End()
 	}()
 
-	err = i.next.DeleteByID(ctx, id)
+	err = translateErr(i.next.DeleteByID(ctx, id, journal))
 	return err
 }
 
-func (i *Instrumentation) Update(ctx context.Context, event *core.Event) error {
+func (i *Instrumentation) Update(ctx context.Context, event *core.Event, journal core.Journal) error {
 	var err error
 	ctx, span := i.tracer.Start(ctx, "update")
 	defer func() {
-		span.RecordError(err)
+		recordError(span, err)
 		span.End()
 	}()
 
-	err = i.next.Update(ctx, event)
+	err = translateErr(i.next.Update(ctx, event, journal))
 	return err
 }
 
@@ -60,10 +63,1078 @@ func (i *Instrumentation) FindByID(ctx context.Context, id string) (*core.Event,
 	var err error
 	ctx, span := i.tracer.Start(ctx, "find-by-id")
 	defer func() {
-		span.RecordError(err)
+		recordError(span, err)
 		span.End()
 	}()
 
 	event, err := i.next.FindByID(ctx, id)
+	err = translateErr(err)
+	return event, err
+}
+
+func (i *Instrumentation) FindDeletedByID(ctx context.Context, id string) (*core.Event, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-deleted-by-id")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	event, err := i.next.FindDeletedByID(ctx, id)
+	err = translateErr(err)
 	return event, err
 }
+
+func (i *Instrumentation) RestoreByID(ctx context.Context, id string, journal core.Journal) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "restore-by-id")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = translateErr(i.next.RestoreByID(ctx, id, journal))
+	return err
+}
+
+func (i *Instrumentation) FindDeletedByCreator(ctx context.Context, createdBy string) ([]core.Event, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-deleted-by-creator")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	events, err := i.next.FindDeletedByCreator(ctx, createdBy)
+	err = translateErr(err)
+	return events, err
+}
+
+func (i *Instrumentation) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "purge-deleted")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	affected, err := i.next.PurgeDeleted(ctx, deletedBefore)
+	err = translateErr(err)
+	return affected, err
+}
+
+func (i *Instrumentation) RespondInvitation(ctx context.Context, eventID string, respond func(event *core.Event) ([]core.Invitation, core.Journal, error)) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "respond-invitation")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = translateErr(i.next.RespondInvitation(ctx, eventID, respond))
+	return err
+}
+
+func (i *Instrumentation) ReplaceReminders(ctx context.Context, eventID string, userID int32, reminders []core.Reminder) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "replace-reminders")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.ReplaceReminders(ctx, eventID, userID, reminders)
+	return err
+}
+
+func (i *Instrumentation) List(ctx context.Context, filter core.EventFilter) ([]core.Event, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "list")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	events, err := i.next.List(ctx, filter)
+	err = translateErr(err)
+	return events, err
+}
+
+func (i *Instrumentation) Batch(ctx context.Context, mutations []core.EventMutation) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "batch")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.Batch(ctx, mutations)
+	return err
+}
+
+func (i *Instrumentation) ListInvolved(ctx context.Context, userID string, from time.Time, to time.Time) ([]core.Event, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "list-involved")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	events, err := i.next.ListInvolved(ctx, userID, from, to)
+	err = translateErr(err)
+	return events, err
+}
+
+func (i *Instrumentation) Search(ctx context.Context, actorID string, query string, offset int, limit int) ([]core.Event, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "search")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	events, err := i.next.Search(ctx, actorID, query, offset, limit)
+	err = translateErr(err)
+	return events, err
+}
+
+// recordError marks the span as failed for unexpected errors only. Domain outcomes such as
+// a missing event are recorded as an attribute so they don't show up as database failures.
+func recordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	if isExpectedErr(err) {
+		span.SetAttributes(attribute.String("db.result", err.Error()))
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+type EventHistoryInstrumentation struct {
+	next   core.EventHistoryRepository
+	tracer trace.Tracer
+}
+
+func NewEventHistoryInstrumentation(next core.EventHistoryRepository) *EventHistoryInstrumentation {
+	return &EventHistoryInstrumentation{
+		next:   next,
+		tracer: otel.Tracer("event-history-repository"),
+	}
+}
+
+func (i *EventHistoryInstrumentation) FindByEventID(ctx context.Context, eventID string, beforeID int64, limit int) ([]core.EventHistory, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-event-id")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	entries, err := i.next.FindByEventID(ctx, eventID, beforeID, limit)
+	err = translateErr(err)
+	return entries, err
+}
+
+type IdempotencyKeyInstrumentation struct {
+	next   core.IdempotencyKeyRepository
+	tracer trace.Tracer
+}
+
+func NewIdempotencyKeyInstrumentation(next core.IdempotencyKeyRepository) *IdempotencyKeyInstrumentation {
+	return &IdempotencyKeyInstrumentation{
+		next:   next,
+		tracer: otel.Tracer("idempotency-key-repository"),
+	}
+}
+
+func (i *IdempotencyKeyInstrumentation) Reserve(ctx context.Context, key *core.IdempotencyKey) (bool, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "reserve")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	reserved, err := i.next.Reserve(ctx, key)
+	return reserved, err
+}
+
+func (i *IdempotencyKeyInstrumentation) Find(ctx context.Context, actorID string, key string) (*core.IdempotencyKey, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	found, err := i.next.Find(ctx, actorID, key)
+	return found, err
+}
+
+func (i *IdempotencyKeyInstrumentation) Release(ctx context.Context, key *core.IdempotencyKey) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "release")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.Release(ctx, key)
+	return err
+}
+
+func (i *IdempotencyKeyInstrumentation) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "purge-expired")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	affected, err := i.next.PurgeExpired(ctx, now)
+	return affected, err
+}
+
+type WebhookInstrumentation struct {
+	next   core.WebhookRepository
+	tracer trace.Tracer
+}
+
+func NewWebhookInstrumentation(next core.WebhookRepository) *WebhookInstrumentation {
+	return &WebhookInstrumentation{
+		next:   next,
+		tracer: otel.Tracer("webhook-repository"),
+	}
+}
+
+func (i *WebhookInstrumentation) Store(ctx context.Context, webhook *core.Webhook) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "store")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.Store(ctx, webhook)
+	return err
+}
+
+func (i *WebhookInstrumentation) Update(ctx context.Context, webhook *core.Webhook) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "update")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.Update(ctx, webhook)
+	return err
+}
+
+func (i *WebhookInstrumentation) DeleteByID(ctx context.Context, id string) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "delete-by-id")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.DeleteByID(ctx, id)
+	return err
+}
+
+func (i *WebhookInstrumentation) FindByID(ctx context.Context, id string) (*core.Webhook, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-id")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	webhook, err := i.next.FindByID(ctx, id)
+	return webhook, err
+}
+
+func (i *WebhookInstrumentation) FindByOwner(ctx context.Context, ownerID string) ([]core.Webhook, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-owner")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	webhooks, err := i.next.FindByOwner(ctx, ownerID)
+	return webhooks, err
+}
+
+func (i *WebhookInstrumentation) EnqueueDeliveries(ctx context.Context, delivery *core.WebhookDelivery, ownerIDs []string) (int64, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "enqueue-deliveries")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	affected, err := i.next.EnqueueDeliveries(ctx, delivery, ownerIDs)
+	return affected, err
+}
+
+func (i *WebhookInstrumentation) ClaimDueDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]core.WebhookDelivery, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "claim-due-deliveries")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	deliveries, err := i.next.ClaimDueDeliveries(ctx, now, leaseUntil, limit)
+	return deliveries, err
+}
+
+func (i *WebhookInstrumentation) UpdateDelivery(ctx context.Context, delivery *core.WebhookDelivery) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "update-delivery")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.UpdateDelivery(ctx, delivery)
+	return err
+}
+
+func (i *WebhookInstrumentation) FindDeliveries(ctx context.Context, webhookID string, beforeID int64, limit int) ([]core.WebhookDelivery, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-deliveries")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	deliveries, err := i.next.FindDeliveries(ctx, webhookID, beforeID, limit)
+	return deliveries, err
+}
+
+type OutboxInstrumentation struct {
+	next   core.OutboxRepository
+	tracer trace.Tracer
+}
+
+func NewOutboxInstrumentation(next core.OutboxRepository) *OutboxInstrumentation {
+	return &OutboxInstrumentation{
+		next:   next,
+		tracer: otel.Tracer("outbox-repository"),
+	}
+}
+
+func (i *OutboxInstrumentation) ClaimPending(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]core.DomainEvent, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "claim-pending")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	events, err := i.next.ClaimPending(ctx, now, leaseUntil, limit)
+	return events, err
+}
+
+func (i *OutboxInstrumentation) MarkPublished(ctx context.Context, id string, publishedAt time.Time) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "mark-published")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.MarkPublished(ctx, id, publishedAt)
+	return err
+}
+
+func (i *OutboxInstrumentation) Retry(ctx context.Context, id string, lastError string, nextAttemptAt time.Time) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "retry")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.Retry(ctx, id, lastError, nextAttemptAt)
+	return err
+}
+
+func (i *OutboxInstrumentation) PurgePublished(ctx context.Context, publishedBefore time.Time) (int64, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "purge-published")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	affected, err := i.next.PurgePublished(ctx, publishedBefore)
+	return affected, err
+}
+
+type ReminderInstrumentation struct {
+	next   core.ReminderRepository
+	tracer trace.Tracer
+}
+
+func NewReminderInstrumentation(next core.ReminderRepository) *ReminderInstrumentation {
+	return &ReminderInstrumentation{
+		next:   next,
+		tracer: otel.Tracer("reminder-repository"),
+	}
+}
+
+func (i *ReminderInstrumentation) ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]core.Reminder, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "claim-due")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	reminders, err := i.next.ClaimDue(ctx, now, leaseUntil, limit)
+	return reminders, err
+}
+
+func (i *ReminderInstrumentation) Fire(ctx context.Context, reminderID string, deliveries []core.ReminderDelivery, nextFireAt *time.Time) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "fire")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.Fire(ctx, reminderID, deliveries, nextFireAt)
+	return err
+}
+
+func (i *ReminderInstrumentation) ClaimDueDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]core.ReminderDelivery, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "claim-due-deliveries")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	deliveries, err := i.next.ClaimDueDeliveries(ctx, now, leaseUntil, limit)
+	return deliveries, err
+}
+
+func (i *ReminderInstrumentation) UpdateDelivery(ctx context.Context, delivery *core.ReminderDelivery) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "update-delivery")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.UpdateDelivery(ctx, delivery)
+	return err
+}
+
+type NotificationPreferenceInstrumentation struct {
+	next   core.NotificationPreferenceRepository
+	tracer trace.Tracer
+}
+
+func NewNotificationPreferenceInstrumentation(next core.NotificationPreferenceRepository) *NotificationPreferenceInstrumentation {
+	return &NotificationPreferenceInstrumentation{
+		next:   next,
+		tracer: otel.Tracer("notification-preference-repository"),
+	}
+}
+
+func (i *NotificationPreferenceInstrumentation) FindByUserID(ctx context.Context, userID string) (*core.NotificationPreference, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-user-id")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	preference, err := i.next.FindByUserID(ctx, userID)
+	return preference, err
+}
+
+func (i *NotificationPreferenceInstrumentation) Upsert(ctx context.Context, preference *core.NotificationPreference) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "upsert")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.Upsert(ctx, preference)
+	return err
+}
+
+func (i *NotificationPreferenceInstrumentation) MuteEvent(ctx context.Context, userID string, eventID string) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "mute-event")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.MuteEvent(ctx, userID, eventID)
+	return err
+}
+
+func (i *NotificationPreferenceInstrumentation) UnmuteEvent(ctx context.Context, userID string, eventID string) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "unmute-event")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.UnmuteEvent(ctx, userID, eventID)
+	return err
+}
+
+type NotificationDigestInstrumentation struct {
+	next   core.NotificationDigestRepository
+	tracer trace.Tracer
+}
+
+func NewNotificationDigestInstrumentation(next core.NotificationDigestRepository) *NotificationDigestInstrumentation {
+	return &NotificationDigestInstrumentation{
+		next:   next,
+		tracer: otel.Tracer("notification-digest-repository"),
+	}
+}
+
+func (i *NotificationDigestInstrumentation) Hold(ctx context.Context, item *core.DigestItem) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "hold")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.Hold(ctx, item)
+	return err
+}
+
+func (i *NotificationDigestInstrumentation) ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]core.DigestItem, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "claim-due")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	items, err := i.next.ClaimDue(ctx, now, leaseUntil, limit)
+	return items, err
+}
+
+func (i *NotificationDigestInstrumentation) Delete(ctx context.Context, ids []int64) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "delete")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.Delete(ctx, ids)
+	return err
+}
+
+type AgendaInstrumentation struct {
+	next   core.AgendaRepository
+	tracer trace.Tracer
+}
+
+func NewAgendaInstrumentation(next core.AgendaRepository) *AgendaInstrumentation {
+	return &AgendaInstrumentation{
+		next:   next,
+		tracer: otel.Tracer("agenda-repository"),
+	}
+}
+
+func (i *AgendaInstrumentation) ScheduleDue(ctx context.Context, now time.Time, morning string) (int64, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "schedule-due")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	logged, err := i.next.ScheduleDue(ctx, now, morning)
+	return logged, err
+}
+
+func (i *AgendaInstrumentation) ClaimDueDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]core.AgendaDelivery, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "claim-due-deliveries")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	deliveries, err := i.next.ClaimDueDeliveries(ctx, now, leaseUntil, limit)
+	return deliveries, err
+}
+
+func (i *AgendaInstrumentation) UpdateDelivery(ctx context.Context, delivery *core.AgendaDelivery) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "update-delivery")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.UpdateDelivery(ctx, delivery)
+	return err
+}
+
+type CalendarInstrumentation struct {
+	next   core.CalendarRepository
+	tracer trace.Tracer
+}
+
+func NewCalendarInstrumentation(next core.CalendarRepository) *CalendarInstrumentation {
+	return &CalendarInstrumentation{
+		next:   next,
+		tracer: otel.Tracer("calendar-repository"),
+	}
+}
+
+func (i *CalendarInstrumentation) Store(ctx context.Context, calendar *core.Calendar) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "store")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.Store(ctx, calendar)
+	return err
+}
+
+func (i *CalendarInstrumentation) Update(ctx context.Context, calendar *core.Calendar) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "update")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.Update(ctx, calendar)
+	return err
+}
+
+func (i *CalendarInstrumentation) DeleteByID(ctx context.Context, id string, journal func(before, after *core.Event) core.Journal) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "delete-by-id")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.DeleteByID(ctx, id, journal)
+	return err
+}
+
+func (i *CalendarInstrumentation) FindByID(ctx context.Context, id string) (*core.Calendar, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-id")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	calendar, err := i.next.FindByID(ctx, id)
+	return calendar, err
+}
+
+func (i *CalendarInstrumentation) FindByOwner(ctx context.Context, ownerID string) ([]core.Calendar, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-owner")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	calendars, err := i.next.FindByOwner(ctx, ownerID)
+	return calendars, err
+}
+
+func (i *CalendarInstrumentation) MoveEvent(ctx context.Context, event *core.Event, journal core.Journal) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "move-event")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.MoveEvent(ctx, event, journal)
+	return err
+}
+
+func (i *CalendarInstrumentation) Grant(ctx context.Context, grant *core.CalendarGrant) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "grant")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.Grant(ctx, grant)
+	return err
+}
+
+func (i *CalendarInstrumentation) Revoke(ctx context.Context, calendarID string, granteeType core.GranteeType, granteeID string) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "revoke")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.Revoke(ctx, calendarID, granteeType, granteeID)
+	return err
+}
+
+func (i *CalendarInstrumentation) FindGrants(ctx context.Context, calendarID string) ([]core.CalendarGrant, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-grants")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	grants, err := i.next.FindGrants(ctx, calendarID)
+	return grants, err
+}
+
+func (i *CalendarInstrumentation) FindRole(ctx context.Context, calendarID string, userID string) (core.CalendarRole, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-role")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	role, err := i.next.FindRole(ctx, calendarID, userID)
+	return role, err
+}
+
+func (i *CalendarInstrumentation) FindSharedWith(ctx context.Context, userID string) ([]core.Calendar, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-shared-with")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	calendars, err := i.next.FindSharedWith(ctx, userID)
+	return calendars, err
+}
+
+type GroupInstrumentation struct {
+	next   core.GroupRepository
+	tracer trace.Tracer
+}
+
+func NewGroupInstrumentation(next core.GroupRepository) *GroupInstrumentation {
+	return &GroupInstrumentation{
+		next:   next,
+		tracer: otel.Tracer("group-repository"),
+	}
+}
+
+func (i *GroupInstrumentation) Store(ctx context.Context, group *core.Group) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "store")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.Store(ctx, group)
+	return err
+}
+
+func (i *GroupInstrumentation) Update(ctx context.Context, group *core.Group) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "update")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.Update(ctx, group)
+	return err
+}
+
+func (i *GroupInstrumentation) DeleteByID(ctx context.Context, id string) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "delete-by-id")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.DeleteByID(ctx, id)
+	return err
+}
+
+func (i *GroupInstrumentation) FindByID(ctx context.Context, id string) (*core.Group, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-id")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	group, err := i.next.FindByID(ctx, id)
+	return group, err
+}
+
+func (i *GroupInstrumentation) FindByOwner(ctx context.Context, ownerID string) ([]core.Group, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-owner")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	groups, err := i.next.FindByOwner(ctx, ownerID)
+	return groups, err
+}
+
+func (i *GroupInstrumentation) AddMembers(ctx context.Context, groupID string, userIDs []int32, updates []core.EventMutation) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "add-members")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.AddMembers(ctx, groupID, userIDs, updates)
+	return err
+}
+
+func (i *GroupInstrumentation) RemoveMembers(ctx context.Context, groupID string, userIDs []int32, updates []core.EventMutation) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "remove-members")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.RemoveMembers(ctx, groupID, userIDs, updates)
+	return err
+}
+
+func (i *GroupInstrumentation) FindUpcomingEventIDs(ctx context.Context, groupID string, after time.Time) ([]string, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-upcoming-event-ids")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	ids, err := i.next.FindUpcomingEventIDs(ctx, groupID, after)
+	return ids, err
+}
+
+type ResourceInstrumentation struct {
+	next   core.ResourceRepository
+	tracer trace.Tracer
+}
+
+func NewResourceInstrumentation(next core.ResourceRepository) *ResourceInstrumentation {
+	return &ResourceInstrumentation{
+		next:   next,
+		tracer: otel.Tracer("resource-repository"),
+	}
+}
+
+func (i *ResourceInstrumentation) Store(ctx context.Context, resource *core.Resource) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "store")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.Store(ctx, resource)
+	return err
+}
+
+func (i *ResourceInstrumentation) Update(ctx context.Context, resource *core.Resource) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "update")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.Update(ctx, resource)
+	return err
+}
+
+func (i *ResourceInstrumentation) DeleteByID(ctx context.Context, id string) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "delete-by-id")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.DeleteByID(ctx, id)
+	return err
+}
+
+func (i *ResourceInstrumentation) FindByID(ctx context.Context, id string) (*core.Resource, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-id")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	resource, err := i.next.FindByID(ctx, id)
+	return resource, err
+}
+
+func (i *ResourceInstrumentation) List(ctx context.Context, filter core.ResourceFilter) ([]core.Resource, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "list")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	resources, err := i.next.List(ctx, filter)
+	return resources, err
+}
+
+func (i *ResourceInstrumentation) FindBookedSchedules(ctx context.Context, resourceIDs []string) ([]core.BookedSchedule, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-booked-schedules")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	schedules, err := i.next.FindBookedSchedules(ctx, resourceIDs)
+	return schedules, err
+}
+
+type BookingPageInstrumentation struct {
+	next   core.BookingPageRepository
+	tracer trace.Tracer
+}
+
+func NewBookingPageInstrumentation(next core.BookingPageRepository) *BookingPageInstrumentation {
+	return &BookingPageInstrumentation{
+		next:   next,
+		tracer: otel.Tracer("booking-page-repository"),
+	}
+}
+
+func (i *BookingPageInstrumentation) Store(ctx context.Context, page *core.BookingPage) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "store")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.Store(ctx, page)
+	return err
+}
+
+func (i *BookingPageInstrumentation) Update(ctx context.Context, page *core.BookingPage) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "update")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.Update(ctx, page)
+	return err
+}
+
+func (i *BookingPageInstrumentation) DeleteByID(ctx context.Context, id string) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "delete-by-id")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.DeleteByID(ctx, id)
+	return err
+}
+
+func (i *BookingPageInstrumentation) FindByID(ctx context.Context, id string) (*core.BookingPage, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-id")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	page, err := i.next.FindByID(ctx, id)
+	return page, err
+}
+
+func (i *BookingPageInstrumentation) FindByOwner(ctx context.Context, ownerID string) ([]core.BookingPage, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-by-owner")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	pages, err := i.next.FindByOwner(ctx, ownerID)
+	return pages, err
+}
+
+func (i *BookingPageInstrumentation) FindBusySchedules(ctx context.Context, userID string, from time.Time, to time.Time) ([]core.Schedule, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-busy-schedules")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	schedules, err := i.next.FindBusySchedules(ctx, userID, from, to)
+	return schedules, err
+}
+
+func (i *BookingPageInstrumentation) FindBookedStarts(ctx context.Context, pageID string, from time.Time, to time.Time) ([]time.Time, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-booked-starts")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	starts, err := i.next.FindBookedStarts(ctx, pageID, from, to)
+	return starts, err
+}
+
+func (i *BookingPageInstrumentation) Book(ctx context.Context, page *core.BookingPage, booking *core.Booking, event *core.Event, journal core.Journal) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "book")
+	defer func() {
+		recordError(span, err)
+		span.End()
+	}()
+
+	err = i.next.Book(ctx, page, booking, event, journal)
+	return err
+}
@@ -0,0 +1,168 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrSchemaVersionAhead = errors.New("database schema version is ahead of the application")
+	ErrSchemaDirty        = errors.New("database schema is dirty")
+	ErrSchemaPending      = errors.New("database schema has pending migrations")
+)
+
+type MigrationStatus struct {
+	Version uint
+	Latest  uint
+	Dirty   bool
+}
+
+func (m MigrationStatus) Pending() bool {
+	return m.Version < m.Latest
+}
+
+// compatible returns an error when the schema is dirty or was migrated by a newer binary.
+func (m MigrationStatus) compatible() error {
+	if m.Dirty {
+		return fmt.Errorf("%w: version %d", ErrSchemaDirty, m.Version)
+	}
+
+	if m.Version > m.Latest {
+		return fmt.Errorf("%w: database is at version %d, application knows up to %d", ErrSchemaVersionAhead, m.Version, m.Latest)
+	}
+
+	return nil
+}
+
+type Migrator struct {
+	m      *migrate.Migrate
+	latest uint
+}
+
+func NewMigrator(ctx context.Context, dbConn *sqlx.DB, migrations fs.FS) (*Migrator, error) {
+	src, err := iofs.New(migrations, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := latestVersion(src)
+	if err != nil {
+		return nil, err
+	}
+
+	// use a dedicated connection, closing the migrator must not close the shared pool
+	conn, err := dbConn.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{})
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, "postgres", driver)
+	if err != nil {
+		_ = driver.Close()
+		return nil, err
+	}
+
+	return &Migrator{
+		m:      m,
+		latest: latest,
+	}, nil
+}
+
+// Up applies the given number of pending migrations, or all of them when steps is zero.
+func (m *Migrator) Up(steps int) error {
+	if err := m.EnsureCompatible(); err != nil {
+		return err
+	}
+
+	var err error
+	if steps > 0 {
+		err = m.m.Steps(steps)
+	} else {
+		err = m.m.Up()
+	}
+	return ignoreNoChange(err)
+}
+
+// Down rolls back the given number of applied migrations.
+func (m *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("invalid number of steps: %d", steps)
+	}
+	return ignoreNoChange(m.m.Steps(-steps))
+}
+
+// Force sets the schema version without running any migration and clears the dirty flag.
+func (m *Migrator) Force(version int) error {
+	return m.m.Force(version)
+}
+
+func (m *Migrator) Status() (MigrationStatus, error) {
+	version, dirty, err := m.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return MigrationStatus{}, err
+	}
+
+	return MigrationStatus{
+		Version: version,
+		Latest:  m.latest,
+		Dirty:   dirty,
+	}, nil
+}
+
+// EnsureCompatible returns an error when the database schema is dirty or was migrated by a newer binary.
+func (m *Migrator) EnsureCompatible() error {
+	status, err := m.Status()
+	if err != nil {
+		return err
+	}
+
+	return status.compatible()
+}
+
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+func latestVersion(src source.Driver) (uint, error) {
+	version, err := src.First()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,108 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
+	"github.com/jmoiron/sqlx"
+)
+
+// NotificationDigestRepository holds back the notifications until they are due. The timestamps are
+// written in UTC.
+type NotificationDigestRepository struct {
+	dbConn  *sqlx.DB
+	queries *gen.Queries
+}
+
+func NewNotificationDigestRepository(dbConn *sqlx.DB) *NotificationDigestRepository {
+	return &NotificationDigestRepository{
+		dbConn:  dbConn,
+		queries: gen.New(dbConn),
+	}
+}
+
+// Hold relies on the unique key of the items, a notification held back again when its domain event
+// is published again is skipped.
+func (n *NotificationDigestRepository) Hold(ctx context.Context, item *core.DigestItem) error {
+	err := n.queries.CreateNotificationDigestItem(ctx, gen.CreateNotificationDigestItemParams{
+		UserID:         item.UserID,
+		DedupKey:       item.Key,
+		Subject:        item.Subject,
+		Body:           item.Body,
+		CalendarMethod: item.CalendarMethod,
+		Calendar:       string(item.Calendar),
+		SendAfter:      item.SendAfter.UTC(),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	return nil
+}
+
+func (n *NotificationDigestRepository) ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]core.DigestItem, error) {
+	rows, err := n.queries.ClaimDueNotificationDigestItems(ctx, gen.ClaimDueNotificationDigestItemsParams{
+		LeaseUntil: leaseUntil.UTC(),
+		Now:        now.UTC(),
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	items := make([]core.DigestItem, len(rows))
+	for index, row := range rows {
+		items[index] = core.DigestItem{
+			ID:             row.ID,
+			UserID:         row.UserID,
+			Key:            row.DedupKey,
+			Subject:        row.Subject,
+			Body:           row.Body,
+			CalendarMethod: row.CalendarMethod,
+			Calendar:       []byte(row.Calendar),
+			SendAfter:      row.SendAfter,
+			CreatedAt:      row.CreatedAt,
+		}
+	}
+
+	// RETURNING doesn't keep the order of the subquery
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].UserID != items[j].UserID {
+			return items[i].UserID < items[j].UserID
+		}
+		return items[i].ID < items[j].ID
+	})
+	return items, nil
+}
+
+func (n *NotificationDigestRepository) Delete(ctx context.Context, ids []int64) error {
+	tx, err := n.dbConn.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+	defer func() {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			slog.Error(rollbackErr.Error())
+		}
+	}()
+
+	queries := n.queries.WithTx(tx)
+	for _, id := range ids {
+		err = queries.DeleteNotificationDigestItem(ctx, id)
+		if err != nil {
+			slog.Error(err.Error())
+			return translateErr(err)
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,107 @@
+package postgresql_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationDigestRepository_Hold(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	sendAfter := time.Date(2026, 1, 2, 8, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(`INSERT INTO notification_digest_item`).
+		WithArgs("2", "d1.2", "Invitation: Weekly sync", "body", "REQUEST", "BEGIN:VCALENDAR", sendAfter).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	err := postgresql.NewNotificationDigestRepository(sqlx.NewDb(db, "pgx")).Hold(context.Background(), &core.DigestItem{
+		UserID:         "2",
+		Key:            "d1.2",
+		Subject:        "Invitation: Weekly sync",
+		Body:           "body",
+		CalendarMethod: "REQUEST",
+		Calendar:       []byte("BEGIN:VCALENDAR"),
+		SendAfter:      sendAfter.In(loc),
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationDigestRepository_ClaimDue(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	now := time.Now().UTC()
+	leaseUntil := now.Add(time.Minute)
+
+	columns := []string{"id", "user_id", "dedup_key", "subject", "body", "calendar_method", "calendar", "send_after", "created_at"}
+	mock.ExpectQuery(`UPDATE notification_digest_item SET send_after`).
+		WithArgs(leaseUntil, now, 10).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(3, "3", "d1.3", "s", "b", "", "", leaseUntil, now).
+			AddRow(2, "2", "d2.2", "s", "b", "CANCEL", "c", leaseUntil, now).
+			AddRow(1, "2", "d1.2", "s", "b", "REQUEST", "r", leaseUntil, now))
+
+	got, err := postgresql.NewNotificationDigestRepository(sqlx.NewDb(db, "pgx")).ClaimDue(context.Background(), now, leaseUntil, 10)
+	assert.NoError(t, err)
+
+	ids := make([]int64, len(got))
+	for index := range got {
+		ids[index] = got[index].ID
+	}
+	assert.Equal(t, []int64{1, 2, 3}, ids, "the items must be grouped by user")
+	assert.Equal(t, []byte("r"), got[0].Calendar)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationDigestRepository_Delete(t *testing.T) {
+	tests := []struct {
+		name    string
+		dbMock  func(t *testing.T) *sqlx.DB
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			dbMock: func(t *testing.T) *sqlx.DB {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM notification_digest_item`).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM notification_digest_item`).WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.MatchExpectationsInOrder(true)
+
+				return sqlx.NewDb(db, "pgx")
+			},
+		},
+		{
+			name: "Not OK - error",
+			dbMock: func(t *testing.T) *sqlx.DB {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM notification_digest_item`).WithArgs(int64(1)).WillReturnError(errors.New("error")) //nolint:goerr113
+				mock.ExpectRollback()
+				mock.MatchExpectationsInOrder(true)
+
+				return sqlx.NewDb(db, "pgx")
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := postgresql.NewNotificationDigestRepository(tt.dbMock(t))
+			err := n.Delete(context.Background(), []int64{1, 2})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
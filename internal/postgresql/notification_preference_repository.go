@@ -0,0 +1,120 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
+	"github.com/jmoiron/sqlx"
+)
+
+type NotificationPreferenceRepository struct {
+	queries *gen.Queries
+}
+
+func NewNotificationPreferenceRepository(dbConn *sqlx.DB) *NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{
+		queries: gen.New(dbConn),
+	}
+}
+
+func (n *NotificationPreferenceRepository) FindByUserID(ctx context.Context, userID string) (*core.NotificationPreference, error) {
+	preference := core.DefaultNotificationPreference(userID)
+
+	row, err := n.queries.FindNotificationPreference(ctx, userID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	default:
+		preference, err = toCoreNotificationPreference(row)
+		if err != nil {
+			slog.Error(err.Error())
+			return nil, err
+		}
+	}
+
+	preference.MutedEventIDs, err = n.queries.FindMutedEventIDs(ctx, userID)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+	return preference, nil
+}
+
+func (n *NotificationPreferenceRepository) Upsert(ctx context.Context, preference *core.NotificationPreference) error {
+	channels, err := json.Marshal(preference.Channels)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	updatedAt := time.Now()
+	if preference.UpdatedAt != nil {
+		updatedAt = *preference.UpdatedAt
+	}
+
+	err = n.queries.UpsertNotificationPreference(ctx, gen.UpsertNotificationPreferenceParams{
+		UserID:          preference.UserID,
+		Channels:        channels,
+		Timezone:        preference.Timezone,
+		QuietHoursStart: preference.QuietHoursStart,
+		QuietHoursEnd:   preference.QuietHoursEnd,
+		Delivery:        string(preference.Delivery),
+		DigestTime:      preference.DigestTime,
+		UpdatedAt:       updatedAt,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	return nil
+}
+
+func (n *NotificationPreferenceRepository) MuteEvent(ctx context.Context, userID string, eventID string) error {
+	err := n.queries.CreateEventMute(ctx, gen.CreateEventMuteParams{
+		UserID:  userID,
+		EventID: eventID,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	return nil
+}
+
+func (n *NotificationPreferenceRepository) UnmuteEvent(ctx context.Context, userID string, eventID string) error {
+	err := n.queries.DeleteEventMute(ctx, gen.DeleteEventMuteParams{
+		UserID:  userID,
+		EventID: eventID,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	return nil
+}
+
+func toCoreNotificationPreference(row gen.NotificationPreference) (*core.NotificationPreference, error) {
+	var channels []core.ReminderChannel
+	if err := json.Unmarshal(row.Channels, &channels); err != nil {
+		return nil, err
+	}
+
+	return &core.NotificationPreference{
+		UserID:          row.UserID,
+		Channels:        channels,
+		Timezone:        row.Timezone,
+		QuietHoursStart: row.QuietHoursStart,
+		QuietHoursEnd:   row.QuietHoursEnd,
+		Delivery:        core.NotificationDelivery(row.Delivery),
+		DigestTime:      row.DigestTime,
+		UpdatedAt:       &row.UpdatedAt,
+	}, nil
+}
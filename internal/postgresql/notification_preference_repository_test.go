@@ -0,0 +1,118 @@
+package postgresql_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var notificationPreferenceColumns = []string{
+	"user_id", "channels", "timezone", "quiet_hours_start", "quiet_hours_end", "delivery", "digest_time", "updated_at",
+}
+
+func TestNotificationPreferenceRepository_FindByUserID(t *testing.T) {
+	updatedAt := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		dbMock  func(t *testing.T) *sqlx.DB
+		want    *core.NotificationPreference
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			dbMock: func(t *testing.T) *sqlx.DB {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery(`FROM notification_preference`).WithArgs("2").
+					WillReturnRows(sqlmock.NewRows(notificationPreferenceColumns).
+						AddRow("2", []byte(`["EMAIL"]`), "Asia/Jakarta", "22:00", "07:00", "DIGEST", "08:00", updatedAt))
+				mock.ExpectQuery(`FROM event_mute`).WithArgs("2").
+					WillReturnRows(sqlmock.NewRows([]string{"event_id"}).AddRow("123"))
+
+				return sqlx.NewDb(db, "pgx")
+			},
+			want: &core.NotificationPreference{
+				UserID:          "2",
+				Channels:        []core.ReminderChannel{core.ReminderChannel_Email},
+				Timezone:        "Asia/Jakarta",
+				QuietHoursStart: "22:00",
+				QuietHoursEnd:   "07:00",
+				Delivery:        core.NotificationDelivery_Digest,
+				DigestTime:      "08:00",
+				MutedEventIDs:   []string{"123"},
+				UpdatedAt:       &updatedAt,
+			},
+		},
+		{
+			name: "OK - the default preference of a user who hasn't set one",
+			dbMock: func(t *testing.T) *sqlx.DB {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery(`FROM notification_preference`).WithArgs("2").WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(`FROM event_mute`).WithArgs("2").
+					WillReturnRows(sqlmock.NewRows([]string{"event_id"}).AddRow("123"))
+
+				return sqlx.NewDb(db, "pgx")
+			},
+			want: func() *core.NotificationPreference {
+				preference := core.DefaultNotificationPreference("2")
+				preference.MutedEventIDs = []string{"123"}
+				return preference
+			}(),
+		},
+		{
+			name: "Not OK - error",
+			dbMock: func(t *testing.T) *sqlx.DB {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery(`FROM notification_preference`).WithArgs("2").WillReturnError(errors.New("error")) //nolint:goerr113
+
+				return sqlx.NewDb(db, "pgx")
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := postgresql.NewNotificationPreferenceRepository(tt.dbMock(t))
+			got, err := n.FindByUserID(context.Background(), "2")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNotificationPreferenceRepository_Upsert(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	updatedAt := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(`INSERT INTO notification_preference`).
+		WithArgs("2", []byte(`["EMAIL","PUSH"]`), "UTC", "", "", "IMMEDIATE", "08:00", updatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	preference := core.DefaultNotificationPreference("2")
+	preference.UpdatedAt = &updatedAt
+	err := postgresql.NewNotificationPreferenceRepository(sqlx.NewDb(db, "pgx")).Upsert(context.Background(), preference)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationPreferenceRepository_MuteEvent(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectExec(`INSERT INTO event_mute`).WithArgs("2", "123").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM event_mute`).WithArgs("2", "123").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	n := postgresql.NewNotificationPreferenceRepository(sqlx.NewDb(db, "pgx"))
+	assert.NoError(t, n.MuteEvent(context.Background(), "2", "123"))
+	assert.NoError(t, n.UnmuteEvent(context.Background(), "2", "123"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,147 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
+	"github.com/jmoiron/sqlx"
+)
+
+// outboxPayload is the part of a domain event that has no column of its own.
+type outboxPayload struct {
+	Event    *core.Event `json:"event,omitempty"`
+	Audience []string    `json:"audience"`
+}
+
+// recordJournal writes the history entry, the domain events and the idempotency key of the journal
+// with queries, which are bound to the transaction of the write that produced them.
+func recordJournal(ctx context.Context, queries *gen.Queries, journal core.Journal) error {
+	if journal.History != nil {
+		err := storeHistory(ctx, queries, journal.History)
+		if err != nil {
+			return err
+		}
+	}
+	if journal.IdempotencyKey != nil {
+		err := completeIdempotencyKey(ctx, queries, journal.IdempotencyKey)
+		if err != nil {
+			return err
+		}
+	}
+	return appendOutbox(ctx, queries, journal.Outbox)
+}
+
+// appendOutbox writes the domain events with queries, which are bound to the transaction of the
+// write that produced them.
+func appendOutbox(ctx context.Context, queries *gen.Queries, events []core.DomainEvent) error {
+	for _, event := range events {
+		payload, err := json.Marshal(outboxPayload{
+			Event:    event.Event,
+			Audience: event.Audience,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+
+		err = queries.CreateOutboxMessage(ctx, gen.CreateOutboxMessageParams{
+			ID:         event.ID,
+			Type:       string(event.Type),
+			EventID:    event.EventID,
+			ActorID:    event.ActorID,
+			Payload:    payload,
+			OccurredAt: event.OccurredAt,
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+type OutboxRepository struct {
+	queries *gen.Queries
+}
+
+func NewOutboxRepository(dbConn *sqlx.DB) *OutboxRepository {
+	return &OutboxRepository{
+		queries: gen.New(dbConn),
+	}
+}
+
+// ClaimPending locks the pending domain events with SKIP LOCKED, so that concurrent relays claim
+// different domain events instead of waiting on each other.
+func (o *OutboxRepository) ClaimPending(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]core.DomainEvent, error) {
+	rows, err := o.queries.ClaimPendingOutboxMessages(ctx, gen.ClaimPendingOutboxMessagesParams{
+		LeaseUntil: leaseUntil,
+		Now:        now,
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	events := make([]core.DomainEvent, len(rows))
+	for index, row := range rows {
+		var payload outboxPayload
+		err = json.Unmarshal(row.Payload, &payload)
+		if err != nil {
+			slog.Error(err.Error())
+			return nil, err
+		}
+
+		events[index] = core.DomainEvent{
+			ID:         row.ID,
+			Type:       core.DomainEventType(row.Type),
+			EventID:    row.EventID,
+			ActorID:    row.ActorID,
+			Event:      payload.Event,
+			Audience:   payload.Audience,
+			OccurredAt: row.OccurredAt,
+			Attempts:   int(row.Attempts),
+		}
+	}
+	return events, nil
+}
+
+func (o *OutboxRepository) MarkPublished(ctx context.Context, id string, publishedAt time.Time) error {
+	_, err := o.queries.MarkOutboxMessagePublished(ctx, gen.MarkOutboxMessagePublishedParams{
+		PublishedAt: sql.NullTime{Time: publishedAt, Valid: true},
+		ID:          id,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	return nil
+}
+
+func (o *OutboxRepository) Retry(ctx context.Context, id string, lastError string, nextAttemptAt time.Time) error {
+	_, err := o.queries.RetryOutboxMessage(ctx, gen.RetryOutboxMessageParams{
+		LastError:     lastError,
+		NextAttemptAt: nextAttemptAt,
+		ID:            id,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	return nil
+}
+
+// PurgePublished removes the domain events published before the given time.
+func (o *OutboxRepository) PurgePublished(ctx context.Context, publishedBefore time.Time) (int64, error) {
+	affected, err := o.queries.PurgePublishedOutboxMessages(ctx, sql.NullTime{Time: publishedBefore, Valid: true})
+	if err != nil {
+		slog.Error(err.Error())
+		return 0, translateErr(err)
+	}
+	return affected, nil
+}
@@ -0,0 +1,143 @@
+package postgresql_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var outboxColumns = []string{
+	"id", "type", "event_id", "actor_id", "payload", "occurred_at",
+	"attempts", "last_error", "next_attempt_at", "published_at",
+}
+
+func TestEventRepository_Store_Outbox(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	now := time.Now()
+	event := &core.Event{
+		ID:        "123",
+		Title:     "test",
+		Timezone:  "Asia/Jakarta",
+		CreatedBy: "1",
+	}
+	domainEvent := core.DomainEvent{
+		ID:         "abc",
+		Type:       core.DomainEventType_EventCreated,
+		EventID:    "123",
+		ActorID:    "1",
+		Event:      event,
+		Audience:   []string{"1"},
+		OccurredAt: now,
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "OK - written in the transaction of the event",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectQuery(`SELECT .+ FROM calendar`).WithArgs("1").
+						WillReturnRows(sqlmock.NewRows(calendarColumns).AddRow("cal1", "1", "Default", "", "Asia/Jakarta", "", true, now, nil))
+					mock.ExpectExec(`INSERT INTO event`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`INSERT INTO outbox`).
+						WithArgs("abc", "EventCreated", "123", "1", sqlmock.AnyArg(), now).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectCommit()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+		},
+		{
+			name: "Not OK - the event is rolled back when the outbox fails",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectBegin()
+					mock.ExpectQuery(`SELECT .+ FROM calendar`).WithArgs("1").
+						WillReturnRows(sqlmock.NewRows(calendarColumns).AddRow("cal1", "1", "Default", "", "Asia/Jakarta", "", true, now, nil))
+					mock.ExpectExec(`INSERT INTO event`).WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(`INSERT INTO outbox`).WillReturnError(errors.New("error")) //nolint:goerr113
+					mock.ExpectRollback()
+					mock.MatchExpectationsInOrder(true)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := postgresql.NewEventRepository(tt.fields.dbMock(t)).Store(context.Background(), event, core.Journal{Outbox: []core.DomainEvent{domainEvent}})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestOutboxRepository_ClaimPending(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	now := time.Now()
+	leaseUntil := now.Add(time.Minute)
+
+	mock.ExpectQuery(`UPDATE outbox`).
+		WithArgs(leaseUntil, now, 10).
+		WillReturnRows(sqlmock.NewRows(outboxColumns).
+			AddRow("abc", "EventDeleted", "123", "1", []byte(`{"event":{"ID":"123","CreatedBy":"1"},"audience":["1","2"]}`), now, 2, "error", leaseUntil, nil))
+
+	got, err := postgresql.NewOutboxRepository(sqlx.NewDb(db, "pgx")).ClaimPending(context.Background(), now, leaseUntil, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []core.DomainEvent{
+		{
+			ID:         "abc",
+			Type:       core.DomainEventType_EventDeleted,
+			EventID:    "123",
+			ActorID:    "1",
+			Event:      &core.Event{ID: "123", CreatedBy: "1"},
+			Audience:   []string{"1", "2"},
+			OccurredAt: now,
+			Attempts:   2,
+		},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepository_MarkPublished(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	now := time.Now()
+
+	mock.ExpectExec(`UPDATE outbox SET published_at`).WithArgs(now, "abc").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := postgresql.NewOutboxRepository(sqlx.NewDb(db, "pgx")).MarkPublished(context.Background(), "abc", now)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepository_Retry(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	next := time.Now().Add(time.Minute)
+
+	mock.ExpectExec(`UPDATE outbox SET attempts = attempts \+ 1`).WithArgs("broker is down", next, "abc").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := postgresql.NewOutboxRepository(sqlx.NewDb(db, "pgx")).Retry(context.Background(), "abc", "broker is down", next)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
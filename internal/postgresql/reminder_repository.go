@@ -0,0 +1,164 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
+	"github.com/jmoiron/sqlx"
+)
+
+// ReminderRepository keeps track of when the reminders fire, and of their deliveries. The
+// timestamps are written in UTC.
+type ReminderRepository struct {
+	dbConn  *sqlx.DB
+	queries *gen.Queries
+}
+
+func NewReminderRepository(dbConn *sqlx.DB) *ReminderRepository {
+	return &ReminderRepository{
+		dbConn:  dbConn,
+		queries: gen.New(dbConn),
+	}
+}
+
+// ClaimDue locks the due reminders with SKIP LOCKED, so that concurrent workers claim different
+// reminders instead of waiting on each other.
+func (r *ReminderRepository) ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]core.Reminder, error) {
+	rows, err := r.queries.ClaimDueReminders(ctx, gen.ClaimDueRemindersParams{
+		LeaseUntil: sql.NullTime{Time: leaseUntil.UTC(), Valid: true},
+		Now:        sql.NullTime{Time: now.UTC(), Valid: true},
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	reminders := make([]core.Reminder, len(rows))
+	for index, row := range rows {
+		reminders[index] = core.Reminder{
+			ID:            row.ID,
+			EventID:       row.EventID,
+			UserID:        row.UserID,
+			OffsetMinutes: row.OffsetMinutes,
+			Channel:       core.ReminderChannel(row.Channel),
+		}
+	}
+	return reminders, nil
+}
+
+// Fire relies on the unique constraint of the deliveries, a delivery logged by a worker that
+// went away before rescheduling the reminder is skipped when the reminder is fired again.
+func (r *ReminderRepository) Fire(ctx context.Context, reminderID string, deliveries []core.ReminderDelivery, nextFireAt *time.Time) error {
+	tx, err := r.dbConn.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+	defer func() {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			slog.Error(rollbackErr.Error())
+		}
+	}()
+
+	queries := r.queries.WithTx(tx)
+	for _, delivery := range deliveries {
+		_, err = queries.CreateReminderDelivery(ctx, gen.CreateReminderDeliveryParams{
+			EventID:       delivery.EventID,
+			RecipientID:   delivery.RecipientID,
+			Channel:       string(delivery.Channel),
+			OffsetMinutes: delivery.OffsetMinutes,
+			OccurrenceAt:  delivery.OccurrenceAt.UTC(),
+			Status:        string(core.ReminderDeliveryStatus_Pending),
+			NextAttemptAt: delivery.NextAttemptAt.UTC(),
+		})
+		if err != nil {
+			slog.Error(err.Error())
+			return translateErr(err)
+		}
+	}
+
+	var next sql.NullTime
+	if nextFireAt != nil {
+		next = sql.NullTime{Time: nextFireAt.UTC(), Valid: true}
+	}
+
+	// the reminder is gone when it was replaced meanwhile, the new one is scheduled on its own
+	_, err = queries.UpdateReminderNextFireAt(ctx, gen.UpdateReminderNextFireAtParams{
+		NextFireAt: next,
+		ID:         reminderID,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+
+	return tx.Commit()
+}
+
+func (r *ReminderRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]core.ReminderDelivery, error) {
+	rows, err := r.queries.ClaimDueReminderDeliveries(ctx, gen.ClaimDueReminderDeliveriesParams{
+		LeaseUntil: leaseUntil.UTC(),
+		Status:     string(core.ReminderDeliveryStatus_Pending),
+		Now:        now.UTC(),
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	deliveries := make([]core.ReminderDelivery, len(rows))
+	for index, row := range rows {
+		deliveries[index] = toCoreReminderDelivery(row)
+	}
+	return deliveries, nil
+}
+
+func (r *ReminderRepository) UpdateDelivery(ctx context.Context, delivery *core.ReminderDelivery) error {
+	var sentAt sql.NullTime
+	if delivery.SentAt != nil {
+		sentAt = sql.NullTime{Time: delivery.SentAt.UTC(), Valid: true}
+	}
+
+	// a delivery is gone when its event was purged meanwhile, there is nothing left to update then
+	_, err := r.queries.UpdateReminderDelivery(ctx, gen.UpdateReminderDeliveryParams{
+		Status:        string(delivery.Status),
+		Attempts:      int32(delivery.Attempts),
+		LastError:     delivery.LastError,
+		NextAttemptAt: delivery.NextAttemptAt.UTC(),
+		SentAt:        sentAt,
+		ID:            delivery.ID,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	return nil
+}
+
+func toCoreReminderDelivery(row gen.ReminderDelivery) core.ReminderDelivery {
+	delivery := core.ReminderDelivery{
+		ID:            row.ID,
+		EventID:       row.EventID,
+		RecipientID:   row.RecipientID,
+		Channel:       core.ReminderChannel(row.Channel),
+		OffsetMinutes: row.OffsetMinutes,
+		OccurrenceAt:  row.OccurrenceAt,
+		Status:        core.ReminderDeliveryStatus(row.Status),
+		Attempts:      int(row.Attempts),
+		LastError:     row.LastError,
+		NextAttemptAt: row.NextAttemptAt,
+		CreatedAt:     row.CreatedAt,
+	}
+	if row.SentAt.Valid {
+		delivery.SentAt = &row.SentAt.Time
+	}
+	return delivery
+}
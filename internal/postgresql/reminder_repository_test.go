@@ -0,0 +1,213 @@
+package postgresql_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var reminderDeliveryColumns = []string{
+	"id", "event_id", "recipient_id", "channel", "offset_minutes", "occurrence_at",
+	"status", "attempts", "last_error", "next_attempt_at", "created_at", "sent_at",
+}
+
+func TestEventRepository_ReplaceReminders(t *testing.T) {
+	reminders := []core.Reminder{
+		{ID: "r1", EventID: "123", UserID: 2, OffsetMinutes: 10, Channel: core.ReminderChannel_Push},
+	}
+	tests := []struct {
+		name    string
+		dbMock  func(t *testing.T) *sqlx.DB
+		wantErr bool
+	}{
+		{
+			name: "OK - the new reminders are due to be scheduled",
+			dbMock: func(t *testing.T) *sqlx.DB {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM reminder`).WithArgs("123", int32(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`INSERT INTO reminder`).WithArgs("r1", "123", int32(2), int64(10), "PUSH", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				mock.MatchExpectationsInOrder(true)
+
+				return sqlx.NewDb(db, "pgx")
+			},
+		},
+		{
+			name: "Not OK - the old reminders are kept when the new ones fail",
+			dbMock: func(t *testing.T) *sqlx.DB {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM reminder`).WithArgs("123", int32(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`INSERT INTO reminder`).WillReturnError(errors.New("error")) //nolint:goerr113
+				mock.ExpectRollback()
+				mock.MatchExpectationsInOrder(true)
+
+				return sqlx.NewDb(db, "pgx")
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := postgresql.NewEventRepository(tt.dbMock(t))
+			err := e.ReplaceReminders(context.Background(), "123", 2, reminders)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestReminderRepository_ClaimDue(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	now := time.Now().UTC()
+	leaseUntil := now.Add(time.Minute)
+
+	mock.ExpectQuery(`UPDATE reminder SET next_fire_at`).
+		WithArgs(leaseUntil, now, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "offset_minutes", "channel", "next_fire_at"}).
+			AddRow("r1", "123", 0, 10, "EMAIL", leaseUntil))
+
+	got, err := postgresql.NewReminderRepository(sqlx.NewDb(db, "pgx")).ClaimDue(context.Background(), now, leaseUntil, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []core.Reminder{
+		{ID: "r1", EventID: "123", OffsetMinutes: 10, Channel: core.ReminderChannel_Email},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReminderRepository_Fire(t *testing.T) {
+	now := time.Now().UTC()
+	occurrence := now.Add(10 * time.Minute)
+	next := occurrence.Add(24 * time.Hour)
+	deliveries := []core.ReminderDelivery{
+		{EventID: "123", RecipientID: "1", Channel: core.ReminderChannel_Email, OffsetMinutes: 10, OccurrenceAt: occurrence, NextAttemptAt: now},
+		{EventID: "123", RecipientID: "2", Channel: core.ReminderChannel_Email, OffsetMinutes: 10, OccurrenceAt: occurrence, NextAttemptAt: now},
+	}
+	tests := []struct {
+		name       string
+		dbMock     func(t *testing.T) *sqlx.DB
+		deliveries []core.ReminderDelivery
+		nextFireAt *time.Time
+		wantErr    bool
+	}{
+		{
+			name: "OK - deliveries logged before are skipped",
+			dbMock: func(t *testing.T) *sqlx.DB {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO reminder_delivery`).
+					WithArgs("123", "1", "EMAIL", int64(10), occurrence, "PENDING", now).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`INSERT INTO reminder_delivery`).
+					WithArgs("123", "2", "EMAIL", int64(10), occurrence, "PENDING", now).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`UPDATE reminder SET next_fire_at`).WithArgs(next, "r1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.MatchExpectationsInOrder(true)
+
+				return sqlx.NewDb(db, "pgx")
+			},
+			deliveries: deliveries,
+			nextFireAt: &next,
+		},
+		{
+			name: "OK - no occurrence left",
+			dbMock: func(t *testing.T) *sqlx.DB {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE reminder SET next_fire_at`).WithArgs(nil, "r1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.MatchExpectationsInOrder(true)
+
+				return sqlx.NewDb(db, "pgx")
+			},
+		},
+		{
+			name: "Not OK - the reminder isn't rescheduled when a delivery fails",
+			dbMock: func(t *testing.T) *sqlx.DB {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO reminder_delivery`).WillReturnError(errors.New("error")) //nolint:goerr113
+				mock.ExpectRollback()
+				mock.MatchExpectationsInOrder(true)
+
+				return sqlx.NewDb(db, "pgx")
+			},
+			deliveries: deliveries,
+			nextFireAt: &next,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := postgresql.NewReminderRepository(tt.dbMock(t))
+			err := r.Fire(context.Background(), "r1", tt.deliveries, tt.nextFireAt)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestReminderRepository_ClaimDueDeliveries(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	now := time.Now().UTC()
+	leaseUntil := now.Add(time.Minute)
+	occurrence := now.Add(10 * time.Minute)
+
+	mock.ExpectQuery(`UPDATE reminder_delivery SET next_attempt_at`).
+		WithArgs(leaseUntil, "PENDING", now, 10).
+		WillReturnRows(sqlmock.NewRows(reminderDeliveryColumns).
+			AddRow(1, "123", "2", "PUSH", 10, occurrence, "PENDING", 1, "error", leaseUntil, now, nil))
+
+	got, err := postgresql.NewReminderRepository(sqlx.NewDb(db, "pgx")).ClaimDueDeliveries(context.Background(), now, leaseUntil, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []core.ReminderDelivery{
+		{
+			ID:            1,
+			EventID:       "123",
+			RecipientID:   "2",
+			Channel:       core.ReminderChannel_Push,
+			OffsetMinutes: 10,
+			OccurrenceAt:  occurrence,
+			Status:        core.ReminderDeliveryStatus_Pending,
+			Attempts:      1,
+			LastError:     "error",
+			NextAttemptAt: leaseUntil,
+			CreatedAt:     now,
+		},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReminderRepository_UpdateDelivery(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	now := time.Now().UTC()
+
+	mock.ExpectExec(`UPDATE reminder_delivery SET status`).
+		WithArgs("SENT", int32(1), "", now, now, int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := postgresql.NewReminderRepository(sqlx.NewDb(db, "pgx")).UpdateDelivery(context.Background(), &core.ReminderDelivery{
+		ID:            1,
+		Status:        core.ReminderDeliveryStatus_Sent,
+		Attempts:      1,
+		NextAttemptAt: now,
+		SentAt:        &now,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,148 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
+	"github.com/jmoiron/sqlx"
+)
+
+type ResourceRepository struct {
+	dbConn  *sqlx.DB
+	queries *gen.Queries
+}
+
+func NewResourceRepository(dbConn *sqlx.DB) *ResourceRepository {
+	return &ResourceRepository{
+		dbConn:  dbConn,
+		queries: gen.New(dbConn),
+	}
+}
+
+func (r *ResourceRepository) Store(ctx context.Context, resource *core.Resource) error {
+	err := r.queries.CreateResource(ctx, gen.CreateResourceParams{
+		ID:        resource.ID,
+		Name:      resource.Name,
+		Type:      string(resource.Type),
+		Capacity:  resource.Capacity,
+		Location:  resource.Location,
+		CreatedBy: resource.CreatedBy,
+		CreatedAt: resource.CreatedAt,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	return nil
+}
+
+func (r *ResourceRepository) Update(ctx context.Context, resource *core.Resource) error {
+	var updatedAt sql.NullTime
+	if resource.UpdatedAt != nil {
+		updatedAt = sql.NullTime{Time: *resource.UpdatedAt, Valid: true}
+	}
+
+	affected, err := r.queries.UpdateResource(ctx, gen.UpdateResourceParams{
+		Name:      resource.Name,
+		Type:      string(resource.Type),
+		Capacity:  resource.Capacity,
+		Location:  resource.Location,
+		UpdatedAt: updatedAt,
+		ID:        resource.ID,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	if affected == 0 {
+		return core.ErrResourceNotFound
+	}
+	return nil
+}
+
+func (r *ResourceRepository) DeleteByID(ctx context.Context, id string) error {
+	affected, err := r.queries.DeleteResource(ctx, id)
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	if affected == 0 {
+		return core.ErrResourceNotFound
+	}
+	return nil
+}
+
+func (r *ResourceRepository) FindByID(ctx context.Context, id string) (*core.Resource, error) {
+	row, err := r.queries.FindResourceByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, core.ErrResourceNotFound
+	}
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	resource := toCoreResource(row)
+	return &resource, nil
+}
+
+func (r *ResourceRepository) List(ctx context.Context, filter core.ResourceFilter) ([]core.Resource, error) {
+	rows, err := r.queries.ListResources(ctx, gen.ListResourcesParams{
+		Type:        sql.NullString{String: string(filter.Type), Valid: filter.Type != ""},
+		MinCapacity: filter.MinCapacity,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	resources := make([]core.Resource, len(rows))
+	for index, row := range rows {
+		resources[index] = toCoreResource(row)
+	}
+	return resources, nil
+}
+
+func (r *ResourceRepository) FindBookedSchedules(ctx context.Context, resourceIDs []string) ([]core.BookedSchedule, error) {
+	if len(resourceIDs) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sqlx.In(`SELECT resource_booking.resource_id, schedule.* FROM resource_booking
+		JOIN event ON event.id = resource_booking.event_id
+		JOIN schedule ON schedule.event_id = resource_booking.event_id
+		WHERE resource_booking.resource_id IN (?) AND resource_booking.status = ? AND event.deleted_at IS NULL`,
+		resourceIDs, int16(core.InvitationStatus_Confirmed))
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+
+	var schedules []core.BookedSchedule
+	err = r.dbConn.SelectContext(ctx, &schedules, r.dbConn.Rebind(query), args...)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func toCoreResource(row gen.Resource) core.Resource {
+	resource := core.Resource{
+		ID:        row.ID,
+		Name:      row.Name,
+		Type:      core.ResourceType(row.Type),
+		Capacity:  row.Capacity,
+		Location:  row.Location,
+		CreatedBy: row.CreatedBy,
+		CreatedAt: row.CreatedAt,
+	}
+	if row.UpdatedAt.Valid {
+		resource.UpdatedAt = &row.UpdatedAt.Time
+	}
+	return resource
+}
@@ -0,0 +1,189 @@
+package postgresql_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var resourceColumns = []string{"id", "name", "type", "capacity", "location", "created_by", "created_at", "updated_at"}
+
+var scheduleColumns = []string{"id", "event_id", "start_time", "duration", "is_full_day", "recurring_interval", "recurring_type"}
+
+func TestResourceRepository_Store(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	now := time.Now()
+	resource := &core.Resource{
+		ID:        "room1",
+		Name:      "Room A",
+		Type:      core.ResourceType_Room,
+		Capacity:  8,
+		Location:  "2nd floor",
+		CreatedBy: "1",
+		CreatedAt: now,
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectExec(`INSERT INTO resource`).
+						WithArgs("room1", "Room A", "ROOM", int32(8), "2nd floor", "1", now).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+		},
+		{
+			name: "Not OK - error",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectExec(`INSERT INTO resource`).WillReturnError(errors.New("error")) //nolint:goerr113
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := postgresql.NewResourceRepository(tt.fields.dbMock(t)).Store(context.Background(), resource)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestResourceRepository_FindByID(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	now := time.Now()
+	tests := []struct {
+		name      string
+		fields    fields
+		want      *core.Resource
+		wantErrIs error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectQuery(`SELECT .+ FROM resource WHERE id = \$1`).WithArgs("room1").
+						WillReturnRows(sqlmock.NewRows(resourceColumns).AddRow("room1", "Room A", "ROOM", 8, "", "1", now, nil))
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			want: &core.Resource{ID: "room1", Name: "Room A", Type: core.ResourceType_Room, Capacity: 8, CreatedBy: "1", CreatedAt: now},
+		},
+		{
+			name: "Not OK - not found",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectQuery(`SELECT .+ FROM resource WHERE id = \$1`).WillReturnError(sql.ErrNoRows)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErrIs: core.ErrResourceNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := postgresql.NewResourceRepository(tt.fields.dbMock(t)).FindByID(context.Background(), "room1")
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResourceRepository_DeleteByID(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectExec(`DELETE FROM resource WHERE id = \$1`).WithArgs("room1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := postgresql.NewResourceRepository(sqlx.NewDb(db, "pgx")).DeleteByID(context.Background(), "room1")
+	assert.ErrorIs(t, err, core.ErrResourceNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResourceRepository_List(t *testing.T) {
+	type args struct {
+		filter core.ResourceFilter
+	}
+	now := time.Now()
+	tests := []struct {
+		name     string
+		args     args
+		wantArgs []driver.Value
+	}{
+		{
+			name:     "OK - without a type",
+			args:     args{filter: core.ResourceFilter{MinCapacity: 4}},
+			wantArgs: []driver.Value{nil, int32(4)},
+		},
+		{
+			name:     "OK - of a type",
+			args:     args{filter: core.ResourceFilter{Type: core.ResourceType_Room}},
+			wantArgs: []driver.Value{"ROOM", int32(0)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			mock.ExpectQuery(`SELECT .+ FROM resource WHERE`).WithArgs(tt.wantArgs...).
+				WillReturnRows(sqlmock.NewRows(resourceColumns).AddRow("room1", "Room A", "ROOM", 8, "", "1", now, nil))
+
+			got, err := postgresql.NewResourceRepository(sqlx.NewDb(db, "pgx")).List(context.Background(), tt.args.filter)
+			assert.NoError(t, err)
+			assert.Equal(t, []core.Resource{
+				{ID: "room1", Name: "Room A", Type: core.ResourceType_Room, Capacity: 8, CreatedBy: "1", CreatedAt: now},
+			}, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestResourceRepository_FindBookedSchedules(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(`SELECT resource_booking.resource_id, schedule.\* FROM resource_booking .+ IN \(\$1, \$2\) AND resource_booking.status = \$3 AND event.deleted_at IS NULL`).
+		WithArgs("room1", "room2", int16(core.InvitationStatus_Confirmed)).
+		WillReturnRows(sqlmock.NewRows(append([]string{"resource_id"}, scheduleColumns...)).
+			AddRow("room1", "s1", "event1", int64(1704099600), int64(60), false, int64(0), ""))
+
+	got, err := postgresql.NewResourceRepository(sqlx.NewDb(db, "pgx")).FindBookedSchedules(context.Background(), []string{"room1", "room2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []core.BookedSchedule{
+		{ResourceID: "room1", Schedule: core.Schedule{ID: "s1", EventID: "event1", StartTime: 1704099600, DurationInMinutes: 60}},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
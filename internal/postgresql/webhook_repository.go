@@ -0,0 +1,261 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql/gen"
+	"github.com/jmoiron/sqlx"
+)
+
+type WebhookRepository struct {
+	queries *gen.Queries
+}
+
+func NewWebhookRepository(dbConn *sqlx.DB) *WebhookRepository {
+	return &WebhookRepository{
+		queries: gen.New(dbConn),
+	}
+}
+
+func (w *WebhookRepository) Store(ctx context.Context, webhook *core.Webhook) error {
+	eventTypes, err := json.Marshal(webhook.EventTypes)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	err = w.queries.CreateWebhook(ctx, gen.CreateWebhookParams{
+		ID:         webhook.ID,
+		OwnerID:    webhook.OwnerID,
+		Url:        webhook.URL,
+		Secret:     webhook.Secret,
+		EventTypes: eventTypes,
+		CreatedAt:  webhook.CreatedAt,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	return nil
+}
+
+func (w *WebhookRepository) Update(ctx context.Context, webhook *core.Webhook) error {
+	eventTypes, err := json.Marshal(webhook.EventTypes)
+	if err != nil {
+		slog.Error(err.Error())
+		return err
+	}
+
+	var updatedAt sql.NullTime
+	if webhook.UpdatedAt != nil {
+		updatedAt = sql.NullTime{Time: *webhook.UpdatedAt, Valid: true}
+	}
+
+	affected, err := w.queries.UpdateWebhook(ctx, gen.UpdateWebhookParams{
+		Url:        webhook.URL,
+		Secret:     webhook.Secret,
+		EventTypes: eventTypes,
+		UpdatedAt:  updatedAt,
+		ID:         webhook.ID,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	if affected == 0 {
+		return core.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (w *WebhookRepository) DeleteByID(ctx context.Context, id string) error {
+	affected, err := w.queries.DeleteWebhook(ctx, id)
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	if affected == 0 {
+		return core.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (w *WebhookRepository) FindByID(ctx context.Context, id string) (*core.Webhook, error) {
+	row, err := w.queries.FindWebhookByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, core.ErrWebhookNotFound
+	}
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	webhook, err := toCoreWebhook(row)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (w *WebhookRepository) FindByOwner(ctx context.Context, ownerID string) ([]core.Webhook, error) {
+	rows, err := w.queries.FindWebhooksByOwner(ctx, ownerID)
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	webhooks := make([]core.Webhook, len(rows))
+	for index, row := range rows {
+		webhook, err := toCoreWebhook(row)
+		if err != nil {
+			slog.Error(err.Error())
+			return nil, err
+		}
+		webhooks[index] = *webhook
+	}
+	return webhooks, nil
+}
+
+// EnqueueDeliveries logs the deliveries of all the matching webhooks with a single statement,
+// so that either all of them or none of them are logged.
+func (w *WebhookRepository) EnqueueDeliveries(ctx context.Context, delivery *core.WebhookDelivery, ownerIDs []string) (int64, error) {
+	if len(ownerIDs) == 0 {
+		return 0, nil
+	}
+
+	owners, err := json.Marshal(ownerIDs)
+	if err != nil {
+		slog.Error(err.Error())
+		return 0, err
+	}
+
+	affected, err := w.queries.EnqueueWebhookDeliveries(ctx, gen.EnqueueWebhookDeliveriesParams{
+		EventID:   delivery.EventID,
+		EventType: string(delivery.EventType),
+		Payload:   string(delivery.Payload),
+		Status:    string(core.WebhookDeliveryStatus_Pending),
+		CreatedAt: delivery.CreatedAt,
+		OutboxID:  sql.NullString{String: delivery.OutboxID, Valid: delivery.OutboxID != ""},
+		OwnerIds:  owners,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return 0, translateErr(err)
+	}
+	return affected, nil
+}
+
+// ClaimDueDeliveries locks the due deliveries with SKIP LOCKED, so that concurrent dispatchers
+// claim different deliveries instead of waiting on each other.
+func (w *WebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]core.WebhookDelivery, error) {
+	rows, err := w.queries.ClaimDueWebhookDeliveries(ctx, gen.ClaimDueWebhookDeliveriesParams{
+		LeaseUntil: leaseUntil,
+		Status:     string(core.WebhookDeliveryStatus_Pending),
+		Now:        now,
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	deliveries := make([]core.WebhookDelivery, len(rows))
+	for index, row := range rows {
+		deliveries[index] = toCoreWebhookDelivery(row)
+	}
+	return deliveries, nil
+}
+
+func (w *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *core.WebhookDelivery) error {
+	var deliveredAt sql.NullTime
+	if delivery.DeliveredAt != nil {
+		deliveredAt = sql.NullTime{Time: *delivery.DeliveredAt, Valid: true}
+	}
+
+	// a delivery is gone when its webhook was deleted meanwhile, there is nothing left to update then
+	_, err := w.queries.UpdateWebhookDelivery(ctx, gen.UpdateWebhookDeliveryParams{
+		Status:         string(delivery.Status),
+		Attempts:       int32(delivery.Attempts),
+		ResponseStatus: int32(delivery.ResponseStatus),
+		LastError:      delivery.LastError,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		DeliveredAt:    deliveredAt,
+		ID:             delivery.ID,
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return translateErr(err)
+	}
+	return nil
+}
+
+func (w *WebhookRepository) FindDeliveries(ctx context.Context, webhookID string, beforeID int64, limit int) ([]core.WebhookDelivery, error) {
+	if beforeID <= 0 {
+		beforeID = math.MaxInt64
+	}
+
+	rows, err := w.queries.FindWebhookDeliveries(ctx, gen.FindWebhookDeliveriesParams{
+		WebhookID: webhookID,
+		ID:        beforeID,
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		slog.Error(err.Error())
+		return nil, translateErr(err)
+	}
+
+	deliveries := make([]core.WebhookDelivery, len(rows))
+	for index, row := range rows {
+		deliveries[index] = toCoreWebhookDelivery(row)
+	}
+	return deliveries, nil
+}
+
+func toCoreWebhook(row gen.Webhook) (*core.Webhook, error) {
+	var eventTypes []core.HistoryOperation
+	if err := json.Unmarshal(row.EventTypes, &eventTypes); err != nil {
+		return nil, err
+	}
+
+	webhook := &core.Webhook{
+		ID:         row.ID,
+		OwnerID:    row.OwnerID,
+		URL:        row.Url,
+		Secret:     row.Secret,
+		EventTypes: eventTypes,
+		CreatedAt:  row.CreatedAt,
+	}
+	if row.UpdatedAt.Valid {
+		webhook.UpdatedAt = &row.UpdatedAt.Time
+	}
+	return webhook, nil
+}
+
+func toCoreWebhookDelivery(row gen.WebhookDelivery) core.WebhookDelivery {
+	delivery := core.WebhookDelivery{
+		ID:             row.ID,
+		WebhookID:      row.WebhookID,
+		EventID:        row.EventID,
+		EventType:      core.HistoryOperation(row.EventType),
+		Payload:        []byte(row.Payload),
+		Status:         core.WebhookDeliveryStatus(row.Status),
+		Attempts:       int(row.Attempts),
+		ResponseStatus: int(row.ResponseStatus),
+		LastError:      row.LastError,
+		NextAttemptAt:  row.NextAttemptAt,
+		CreatedAt:      row.CreatedAt,
+		OutboxID:       row.OutboxID.String,
+	}
+	if row.DeliveredAt.Valid {
+		delivery.DeliveredAt = &row.DeliveredAt.Time
+	}
+	return delivery
+}
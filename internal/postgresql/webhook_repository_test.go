@@ -0,0 +1,276 @@
+package postgresql_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/postgresql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var webhookColumns = []string{"id", "owner_id", "url", "secret", "event_types", "created_at", "updated_at"}
+
+var webhookDeliveryColumns = []string{
+	"id", "webhook_id", "event_id", "event_type", "payload", "status", "attempts",
+	"response_status", "last_error", "next_attempt_at", "created_at", "delivered_at", "outbox_id",
+}
+
+func TestWebhookRepository_Store(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	now := time.Now()
+	webhook := &core.Webhook{
+		ID:         "abc",
+		OwnerID:    "1",
+		URL:        "https://example.com/hooks",
+		Secret:     "0123456789abcdef",
+		EventTypes: []core.HistoryOperation{core.HistoryOperation_Create, core.HistoryOperation_Delete},
+		CreatedAt:  now,
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectExec(`INSERT INTO webhook`).
+						WithArgs("abc", "1", "https://example.com/hooks", "0123456789abcdef", []byte(`["CREATE","DELETE"]`), now).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+		},
+		{
+			name: "Not OK - error",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectExec(`INSERT INTO webhook`).WillReturnError(errors.New("error")) //nolint:goerr113
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := postgresql.NewWebhookRepository(tt.fields.dbMock(t)).Store(context.Background(), webhook)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWebhookRepository_Update(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	webhook := &core.Webhook{
+		ID:         "abc",
+		URL:        "https://example.com/hooks",
+		Secret:     "0123456789abcdef",
+		EventTypes: []core.HistoryOperation{core.HistoryOperation_Update},
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectExec(`UPDATE webhook`).WillReturnResult(sqlmock.NewResult(0, 1))
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+		},
+		{
+			name: "Not OK - not found",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectExec(`UPDATE webhook`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErr:   true,
+			wantErrIs: core.ErrWebhookNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := postgresql.NewWebhookRepository(tt.fields.dbMock(t)).Update(context.Background(), webhook)
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWebhookRepository_FindByID(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	now := time.Now()
+	tests := []struct {
+		name      string
+		fields    fields
+		want      *core.Webhook
+		wantErrIs error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectQuery(`SELECT (.+) FROM webhook`).WithArgs("abc").
+						WillReturnRows(sqlmock.NewRows(webhookColumns).
+							AddRow("abc", "1", "https://example.com/hooks", "0123456789abcdef", []byte(`["RSVP"]`), now, nil))
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			want: &core.Webhook{
+				ID:         "abc",
+				OwnerID:    "1",
+				URL:        "https://example.com/hooks",
+				Secret:     "0123456789abcdef",
+				EventTypes: []core.HistoryOperation{core.HistoryOperation_RSVP},
+				CreatedAt:  now,
+			},
+		},
+		{
+			name: "Not OK - not found",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectQuery(`SELECT (.+) FROM webhook`).WillReturnError(sql.ErrNoRows)
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			wantErrIs: core.ErrWebhookNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := postgresql.NewWebhookRepository(tt.fields.dbMock(t)).FindByID(context.Background(), "abc")
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWebhookRepository_EnqueueDeliveries(t *testing.T) {
+	type fields struct {
+		dbMock func(t *testing.T) *sqlx.DB
+	}
+	now := time.Now()
+	delivery := &core.WebhookDelivery{
+		EventID:   "123",
+		EventType: core.HistoryOperation_Create,
+		Payload:   []byte(`{"event_id":"123"}`),
+		CreatedAt: now,
+		OutboxID:  "outbox-1",
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		ownerIDs []string
+		want     int64
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, mock, _ := sqlmock.New()
+					mock.ExpectExec(`INSERT INTO webhook_delivery`).
+						WithArgs("123", "CREATE", `{"event_id":"123"}`, "PENDING", now, "outbox-1", []byte(`["1","2"]`)).
+						WillReturnResult(sqlmock.NewResult(0, 3))
+
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			ownerIDs: []string{"1", "2"},
+			want:     3,
+		},
+		{
+			name: "OK - nobody involved",
+			fields: fields{
+				dbMock: func(t *testing.T) *sqlx.DB {
+					db, _, _ := sqlmock.New()
+					return sqlx.NewDb(db, "pgx")
+				},
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := postgresql.NewWebhookRepository(tt.fields.dbMock(t)).EnqueueDeliveries(context.Background(), delivery, tt.ownerIDs)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWebhookRepository_ClaimDueDeliveries(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	now := time.Now()
+	leaseUntil := now.Add(time.Minute)
+
+	mock.ExpectQuery(`UPDATE webhook_delivery`).
+		WithArgs(leaseUntil, "PENDING", now, 10).
+		WillReturnRows(sqlmock.NewRows(webhookDeliveryColumns).
+			AddRow(7, "abc", "123", "DELETE", `{"event_id":"123"}`, "PENDING", 2, 503, "unexpected response status 503", leaseUntil, now, nil, "outbox-1"))
+
+	got, err := postgresql.NewWebhookRepository(sqlx.NewDb(db, "pgx")).ClaimDueDeliveries(context.Background(), now, leaseUntil, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []core.WebhookDelivery{
+		{
+			ID:             7,
+			WebhookID:      "abc",
+			EventID:        "123",
+			EventType:      core.HistoryOperation_Delete,
+			Payload:        []byte(`{"event_id":"123"}`),
+			Status:         core.WebhookDeliveryStatus_Pending,
+			Attempts:       2,
+			ResponseStatus: 503,
+			LastError:      "unexpected response status 503",
+			NextAttemptAt:  leaseUntil,
+			CreatedAt:      now,
+			OutboxID:       "outbox-1",
+		},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,50 @@
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+// Notifiers hands each notification over to the notifier of its channel.
+type Notifiers map[core.ReminderChannel]core.Notifier
+
+func (n Notifiers) Notify(ctx context.Context, notification *core.Notification) error {
+	notifier, ok := n[notification.Channel]
+	if !ok {
+		return fmt.Errorf("no notifier for the %s channel", notification.Channel)
+	}
+	return notifier.Notify(ctx, notification)
+}
+
+// LogNotifier writes the notifications to the log, it stands in for the channels a deployment
+// doesn't have a notifier for.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (l *LogNotifier) Notify(_ context.Context, notification *core.Notification) error {
+	if notification.Agenda != nil {
+		slog.Info("notification",
+			"id", notification.ID,
+			"channel", notification.Channel,
+			"recipient_id", notification.RecipientID,
+			"agenda_date", notification.Agenda.Date.Format(core.DateLayout),
+			"agenda_items", len(notification.Agenda.Items),
+		)
+		return nil
+	}
+
+	slog.Info("notification",
+		"id", notification.ID,
+		"channel", notification.Channel,
+		"recipient_id", notification.RecipientID,
+		"event_id", notification.Event.ID,
+		"occurrence_at", notification.OccurrenceAt,
+	)
+	return nil
+}
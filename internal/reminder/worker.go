@@ -0,0 +1,266 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+const (
+	defaultWorkerInterval = 30 * time.Second
+	maxAttempts           = 5
+	retryBackoff          = time.Minute
+	maxRetryBackoff       = 30 * time.Minute
+
+	// missedOccurrenceGrace is how long after an occurrence started its reminders are still sent,
+	// i.e: when the worker was down at the time they were due
+	missedOccurrenceGrace = 5 * time.Minute
+
+	// lease must outlast a round: a reminder or a delivery claimed by a worker that went away is
+	// claimed again once its lease expires
+	lease          = time.Minute
+	claimBatchSize = 100
+)
+
+var (
+	errOccurrenceStarted = errors.New("the occurrence has already started")
+	errNotWanted         = errors.New("the recipient turned the channel off or muted the event")
+)
+
+// Worker fires the reminders of the upcoming occurrences and sends them through the notifier.
+//
+// Firing a reminder logs a delivery per recipient of each occurrence that is due, and reschedules
+// the reminder to its next occurrence. The deliveries are unique per recipient and occurrence, so
+// a reminder fires once no matter how many workers run or how often they restart. A delivery is
+// marked as sent once the notifier accepted it, it is only sent twice when a worker goes away in
+// between.
+//
+// The deliveries follow the notification preference of the recipient: they are skipped when the
+// recipient turned the channel off or muted the event, and held back until the quiet hours end.
+// They aren't held back for the digest, which would only send them once the occurrence started.
+type Worker struct {
+	reminders   core.ReminderRepository
+	events      core.EventRepository
+	preferences core.NotificationPreferenceRepository
+	notifier    core.Notifier
+	interval    time.Duration
+}
+
+func NewWorker(reminders core.ReminderRepository, events core.EventRepository, preferences core.NotificationPreferenceRepository, notifier core.Notifier, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = defaultWorkerInterval
+	}
+
+	return &Worker{
+		reminders:   reminders,
+		events:      events,
+		preferences: preferences,
+		notifier:    notifier,
+		interval:    interval,
+	}
+}
+
+// Run fires the due reminders and sends the due deliveries on every interval, until ctx is done.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		fired, err := w.FireDue(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Error(err.Error())
+		} else if fired > 0 {
+			slog.Debug("fired reminders", "count", fired)
+		}
+
+		sent, err := w.SendDue(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Error(err.Error())
+		} else if sent > 0 {
+			slog.Info("attempted reminder deliveries", "count", sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// FireDue fires the reminders that are due until none is left, and returns how many were fired.
+func (w *Worker) FireDue(ctx context.Context) (int, error) {
+	fired := 0
+	for {
+		now := time.Now().UTC()
+		reminders, err := w.reminders.ClaimDue(ctx, now, now.Add(lease), claimBatchSize)
+		if err != nil {
+			return fired, err
+		}
+
+		for index := range reminders {
+			err = w.Fire(ctx, &reminders[index], now)
+			if ctx.Err() != nil {
+				return fired, ctx.Err()
+			}
+			if err != nil {
+				// the reminder is claimed again once its lease expires
+				slog.Error("failed to fire reminder", "reminder_id", reminders[index].ID, "error", err.Error())
+				continue
+			}
+			fired++
+		}
+
+		if len(reminders) < claimBatchSize {
+			return fired, nil
+		}
+	}
+}
+
+// Fire logs the deliveries of the occurrences the reminder is due for at now, and reschedules it to
+// the occurrence after them.
+func (w *Worker) Fire(ctx context.Context, reminder *core.Reminder, now time.Time) error {
+	event, err := w.events.FindByID(ctx, reminder.EventID)
+	if errors.Is(err, core.ErrEventNotFound) {
+		// the event was deleted after the reminder was claimed, it is scheduled again once restored
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var deliveries []core.ReminderDelivery
+	var nextFireAt *time.Time
+	from := now.Add(-missedOccurrenceGrace)
+	for {
+		occurrence, ok := event.NextOccurrence(from)
+		if !ok {
+			break
+		}
+
+		fireAt := occurrence.Add(-reminder.Offset())
+		if fireAt.After(now) {
+			nextFireAt = &fireAt
+			break
+		}
+
+		for _, recipientID := range event.ReminderRecipients(reminder) {
+			deliveries = append(deliveries, core.ReminderDelivery{
+				EventID:       event.ID,
+				RecipientID:   recipientID,
+				Channel:       reminder.Channel,
+				OffsetMinutes: reminder.OffsetMinutes,
+				OccurrenceAt:  occurrence,
+				NextAttemptAt: now,
+			})
+		}
+		from = occurrence.Add(time.Second)
+	}
+
+	return w.reminders.Fire(ctx, reminder.ID, deliveries, nextFireAt)
+}
+
+// SendDue sends the deliveries that are due, and returns how many were attempted.
+func (w *Worker) SendDue(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	deliveries, err := w.reminders.ClaimDueDeliveries(ctx, now, now.Add(lease), claimBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for index := range deliveries {
+		err = w.Send(ctx, &deliveries[index])
+		if ctx.Err() != nil {
+			return index, ctx.Err()
+		}
+		if err != nil {
+			slog.Error("failed to send reminder", "delivery_id", deliveries[index].ID, "error", err.Error())
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// Send makes an attempt of the delivery and records its outcome. A failed attempt is retried after
+// a backoff that doubles on every attempt, the delivery fails once it runs out of attempts. It also
+// fails once its occurrence has started or its event is deleted, since the reminder is of no use then.
+// The deliveries the recipient doesn't want are skipped, and the ones due in their quiet hours are
+// held back until the quiet hours end.
+func (w *Worker) Send(ctx context.Context, delivery *core.ReminderDelivery) error {
+	if time.Now().After(delivery.OccurrenceAt.Add(missedOccurrenceGrace)) {
+		return w.giveUp(ctx, delivery, errOccurrenceStarted)
+	}
+
+	event, err := w.events.FindByID(ctx, delivery.EventID)
+	if errors.Is(err, core.ErrEventNotFound) {
+		return w.giveUp(ctx, delivery, err)
+	}
+	if err != nil {
+		// the delivery is claimed again once its lease expires
+		return err
+	}
+
+	preference, err := w.preferences.FindByUserID(ctx, delivery.RecipientID)
+	if err != nil {
+		return err
+	}
+	if !preference.Notifies(delivery.Channel, delivery.EventID) {
+		delivery.Status = core.ReminderDeliveryStatus_Skipped
+		delivery.LastError = errNotWanted.Error()
+		return w.reminders.UpdateDelivery(ctx, delivery)
+	}
+	// a delivery held back past the start of its occurrence fails on its next attempt
+	now := time.Now()
+	if quietUntil := preference.QuietUntil(now); quietUntil.After(now) {
+		delivery.NextAttemptAt = quietUntil
+		return w.reminders.UpdateDelivery(ctx, delivery)
+	}
+
+	err = w.notifier.Notify(ctx, &core.Notification{
+		ID:           strconv.FormatInt(delivery.ID, 10),
+		Channel:      delivery.Channel,
+		RecipientID:  delivery.RecipientID,
+		Event:        event,
+		OccurrenceAt: delivery.OccurrenceAt,
+	})
+	if ctx.Err() != nil {
+		// the worker is stopping, the delivery is claimed again once its lease expires
+		return ctx.Err()
+	}
+
+	now = time.Now()
+	delivery.Attempts++
+	switch {
+	case err == nil:
+		delivery.Status = core.ReminderDeliveryStatus_Sent
+		delivery.LastError = ""
+		delivery.SentAt = &now
+	case delivery.Attempts >= maxAttempts:
+		delivery.Status = core.ReminderDeliveryStatus_Failed
+		delivery.LastError = err.Error()
+	default:
+		delivery.Status = core.ReminderDeliveryStatus_Pending
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = now.Add(backoff(delivery.Attempts))
+	}
+
+	return w.reminders.UpdateDelivery(ctx, delivery)
+}
+
+func (w *Worker) giveUp(ctx context.Context, delivery *core.ReminderDelivery, reason error) error {
+	delivery.Status = core.ReminderDeliveryStatus_Failed
+	delivery.LastError = reason.Error()
+	return w.reminders.UpdateDelivery(ctx, delivery)
+}
+
+func backoff(attempts int) time.Duration {
+	backoff := retryBackoff
+	for i := 1; i < attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxRetryBackoff)
+}
@@ -0,0 +1,402 @@
+package reminder_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/mock"
+	"github.com/dzakaammar/event-scheduling-example/internal/reminder"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEvent() *core.Event {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	return &core.Event{
+		ID:        "123",
+		CreatedBy: "1",
+		Schedules: []core.Schedule{
+			{
+				ID:                "s1",
+				EventID:           "123",
+				StartTime:         start.Unix(),
+				DurationInMinutes: 60,
+				RecurringType:     core.RecurringType_Daily,
+				RecurringInterval: int64(24 * time.Hour.Seconds()),
+			},
+		},
+		Invitations: []core.Invitation{
+			{ID: "i2", EventID: "123", UserID: 2, Status: core.InvitationStatus_Confirmed},
+			{ID: "i3", EventID: "123", UserID: 3, Status: core.InvitationStatus_Declined},
+			{
+				ID: "i4", EventID: "123", UserID: 4, Status: core.InvitationStatus_Unknown,
+				Reminders: []core.Reminder{
+					{ID: "r4", EventID: "123", UserID: 4, OffsetMinutes: 60, Channel: core.ReminderChannel_Push},
+				},
+			},
+		},
+	}
+}
+
+func TestWorker_Fire(t *testing.T) {
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2026, 1, day, hour, minute, 0, 0, time.UTC)
+	}
+	delivery := func(recipientID string, channel core.ReminderChannel, offset int64, occurrence time.Time, firedAt time.Time) core.ReminderDelivery {
+		return core.ReminderDelivery{
+			EventID:       "123",
+			RecipientID:   recipientID,
+			Channel:       channel,
+			OffsetMinutes: offset,
+			OccurrenceAt:  occurrence,
+			NextAttemptAt: firedAt,
+		}
+	}
+	ptr := func(t time.Time) *time.Time {
+		return &t
+	}
+
+	tests := []struct {
+		name           string
+		reminder       core.Reminder
+		now            time.Time
+		event          func() *core.Event
+		findErr        error
+		wantFire       bool
+		wantDeliveries []core.ReminderDelivery
+		wantNextFireAt *time.Time
+	}{
+		{
+			name:     "OK - sent to the creator and to the attendees without reminders of their own",
+			reminder: core.Reminder{ID: "r1", EventID: "123", OffsetMinutes: 60, Channel: core.ReminderChannel_Email},
+			now:      now,
+			event:    newEvent,
+			wantFire: true,
+			wantDeliveries: []core.ReminderDelivery{
+				delivery("1", core.ReminderChannel_Email, 60, at(5, 10, 0), now),
+				delivery("2", core.ReminderChannel_Email, 60, at(5, 10, 0), now),
+			},
+			wantNextFireAt: ptr(at(6, 9, 0)),
+		},
+		{
+			name:     "OK - the reminder of an attendee",
+			reminder: core.Reminder{ID: "r4", EventID: "123", UserID: 4, OffsetMinutes: 60, Channel: core.ReminderChannel_Push},
+			now:      now,
+			event:    newEvent,
+			wantFire: true,
+			wantDeliveries: []core.ReminderDelivery{
+				delivery("4", core.ReminderChannel_Push, 60, at(5, 10, 0), now),
+			},
+			wantNextFireAt: ptr(at(6, 9, 0)),
+		},
+		{
+			name:           "OK - the attendee declined",
+			reminder:       core.Reminder{ID: "r3", EventID: "123", UserID: 3, OffsetMinutes: 60, Channel: core.ReminderChannel_Push},
+			now:            now,
+			event:          newEvent,
+			wantFire:       true,
+			wantNextFireAt: ptr(at(6, 9, 0)),
+		},
+		{
+			name:           "OK - not due yet, i.e: just written",
+			reminder:       core.Reminder{ID: "r1", EventID: "123", OffsetMinutes: 30, Channel: core.ReminderChannel_Email},
+			now:            now,
+			event:          newEvent,
+			wantFire:       true,
+			wantNextFireAt: ptr(at(5, 9, 30)),
+		},
+		{
+			name:     "OK - an offset longer than the interval fires for every occurrence within it",
+			reminder: core.Reminder{ID: "r1", EventID: "123", OffsetMinutes: 2 * 24 * 60, Channel: core.ReminderChannel_Email},
+			now:      now,
+			event:    newEvent,
+			wantFire: true,
+			wantDeliveries: []core.ReminderDelivery{
+				delivery("1", core.ReminderChannel_Email, 2*24*60, at(5, 10, 0), now),
+				delivery("2", core.ReminderChannel_Email, 2*24*60, at(5, 10, 0), now),
+				delivery("1", core.ReminderChannel_Email, 2*24*60, at(6, 10, 0), now),
+				delivery("2", core.ReminderChannel_Email, 2*24*60, at(6, 10, 0), now),
+			},
+			wantNextFireAt: ptr(at(5, 10, 0)),
+		},
+		{
+			name:     "OK - a missed reminder is still sent shortly after its occurrence started",
+			reminder: core.Reminder{ID: "r1", EventID: "123", OffsetMinutes: 10, Channel: core.ReminderChannel_Email},
+			now:      at(5, 10, 3),
+			event:    newEvent,
+			wantFire: true,
+			wantDeliveries: []core.ReminderDelivery{
+				delivery("1", core.ReminderChannel_Email, 10, at(5, 10, 0), at(5, 10, 3)),
+				delivery("2", core.ReminderChannel_Email, 10, at(5, 10, 0), at(5, 10, 3)),
+			},
+			wantNextFireAt: ptr(at(6, 9, 50)),
+		},
+		{
+			name:     "OK - no occurrence left",
+			reminder: core.Reminder{ID: "r1", EventID: "123", OffsetMinutes: 10, Channel: core.ReminderChannel_Email},
+			now:      now,
+			event: func() *core.Event {
+				event := newEvent()
+				event.Schedules[0].RecurringType = core.RecurringType_None
+				event.Schedules[0].RecurringInterval = 0
+				return event
+			},
+			wantFire: true,
+		},
+		{
+			name:     "OK - the event was deleted meanwhile",
+			reminder: core.Reminder{ID: "r1", EventID: "123", OffsetMinutes: 10, Channel: core.ReminderChannel_Email},
+			now:      now,
+			findErr:  core.ErrEventNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			events := mock.NewMockEventRepository(ctrl)
+			if tt.findErr != nil {
+				events.EXPECT().FindByID(gomock.Any(), "123").Return(nil, tt.findErr)
+			} else {
+				events.EXPECT().FindByID(gomock.Any(), "123").Return(tt.event(), nil)
+			}
+
+			reminders := mock.NewMockReminderRepository(ctrl)
+			if tt.wantFire {
+				reminders.EXPECT().Fire(gomock.Any(), tt.reminder.ID, tt.wantDeliveries, tt.wantNextFireAt).Return(nil)
+			}
+
+			w := reminder.NewWorker(reminders, events, mock.NewMockNotificationPreferenceRepository(ctrl), mock.NewMockNotifier(ctrl), time.Second)
+			err := w.Fire(context.Background(), &tt.reminder, tt.now)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWorker_FireDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reminders := mock.NewMockReminderRepository(ctrl)
+	reminders.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]core.Reminder{
+		{ID: "r1", EventID: "123", OffsetMinutes: 10, Channel: core.ReminderChannel_Email},
+		{ID: "r2", EventID: "456", OffsetMinutes: 10, Channel: core.ReminderChannel_Email},
+	}, nil)
+	// a reminder that fails is claimed again once its lease expires, the others still fire
+	reminders.EXPECT().Fire(gomock.Any(), "r2", gomock.Any(), gomock.Any()).Return(nil)
+
+	events := mock.NewMockEventRepository(ctrl)
+	events.EXPECT().FindByID(gomock.Any(), "123").Return(nil, errors.New("error")) //nolint:goerr113
+	events.EXPECT().FindByID(gomock.Any(), "456").Return(newEvent(), nil)
+
+	got, err := reminder.NewWorker(reminders, events, mock.NewMockNotificationPreferenceRepository(ctrl), mock.NewMockNotifier(ctrl), time.Second).FireDue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, got)
+}
+
+func TestWorker_Send(t *testing.T) {
+	occurrence := time.Now().Add(10 * time.Minute).UTC()
+	quietUntil := time.Now().UTC().Add(time.Hour).Truncate(time.Minute)
+	tests := []struct {
+		name            string
+		delivery        core.ReminderDelivery
+		eventsMock      func(events *mock.MockEventRepository)
+		preferencesMock func(preferences *mock.MockNotificationPreferenceRepository)
+		notifierMock    func(notifier *mock.MockNotifier)
+		wantUpdate      bool
+		wantStatus      core.ReminderDeliveryStatus
+		wantAttempts    int
+		wantRetryIn     time.Duration
+		wantHeldUntil   time.Time
+		wantErr         bool
+	}{
+		{
+			name:     "OK - sent",
+			delivery: core.ReminderDelivery{ID: 1, EventID: "123", RecipientID: "2", Channel: core.ReminderChannel_Email, OccurrenceAt: occurrence},
+			eventsMock: func(events *mock.MockEventRepository) {
+				events.EXPECT().FindByID(gomock.Any(), "123").Return(newEvent(), nil)
+			},
+			notifierMock: func(notifier *mock.MockNotifier) {
+				notifier.EXPECT().Notify(gomock.Any(), &core.Notification{
+					ID:           "1",
+					Channel:      core.ReminderChannel_Email,
+					RecipientID:  "2",
+					Event:        newEvent(),
+					OccurrenceAt: occurrence,
+				}).Return(nil)
+			},
+			wantUpdate:   true,
+			wantStatus:   core.ReminderDeliveryStatus_Sent,
+			wantAttempts: 1,
+		},
+		{
+			name:     "OK - retried later with a backoff",
+			delivery: core.ReminderDelivery{ID: 1, EventID: "123", RecipientID: "2", Channel: core.ReminderChannel_Email, OccurrenceAt: occurrence, Attempts: 1},
+			eventsMock: func(events *mock.MockEventRepository) {
+				events.EXPECT().FindByID(gomock.Any(), "123").Return(newEvent(), nil)
+			},
+			notifierMock: func(notifier *mock.MockNotifier) {
+				notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(errors.New("mail server is down")) //nolint:goerr113
+			},
+			wantUpdate:   true,
+			wantStatus:   core.ReminderDeliveryStatus_Pending,
+			wantAttempts: 2,
+			wantRetryIn:  2 * time.Minute,
+		},
+		{
+			name:     "OK - failed after the last attempt",
+			delivery: core.ReminderDelivery{ID: 1, EventID: "123", RecipientID: "2", Channel: core.ReminderChannel_Email, OccurrenceAt: occurrence, Attempts: 4},
+			eventsMock: func(events *mock.MockEventRepository) {
+				events.EXPECT().FindByID(gomock.Any(), "123").Return(newEvent(), nil)
+			},
+			notifierMock: func(notifier *mock.MockNotifier) {
+				notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(errors.New("mail server is down")) //nolint:goerr113
+			},
+			wantUpdate:   true,
+			wantStatus:   core.ReminderDeliveryStatus_Failed,
+			wantAttempts: 5,
+		},
+		{
+			name:     "OK - skipped when the recipient muted the event",
+			delivery: core.ReminderDelivery{ID: 1, EventID: "123", RecipientID: "2", Channel: core.ReminderChannel_Email, OccurrenceAt: occurrence},
+			eventsMock: func(events *mock.MockEventRepository) {
+				events.EXPECT().FindByID(gomock.Any(), "123").Return(newEvent(), nil)
+			},
+			preferencesMock: func(preferences *mock.MockNotificationPreferenceRepository) {
+				preference := core.DefaultNotificationPreference("2")
+				preference.MutedEventIDs = []string{"123"}
+				preferences.EXPECT().FindByUserID(gomock.Any(), "2").Return(preference, nil)
+			},
+			notifierMock: func(notifier *mock.MockNotifier) {},
+			wantUpdate:   true,
+			wantStatus:   core.ReminderDeliveryStatus_Skipped,
+		},
+		{
+			name:     "OK - skipped when the recipient turned the channel off",
+			delivery: core.ReminderDelivery{ID: 1, EventID: "123", RecipientID: "2", Channel: core.ReminderChannel_Push, OccurrenceAt: occurrence},
+			eventsMock: func(events *mock.MockEventRepository) {
+				events.EXPECT().FindByID(gomock.Any(), "123").Return(newEvent(), nil)
+			},
+			preferencesMock: func(preferences *mock.MockNotificationPreferenceRepository) {
+				preference := core.DefaultNotificationPreference("2")
+				preference.Channels = []core.ReminderChannel{core.ReminderChannel_Email}
+				preferences.EXPECT().FindByUserID(gomock.Any(), "2").Return(preference, nil)
+			},
+			notifierMock: func(notifier *mock.MockNotifier) {},
+			wantUpdate:   true,
+			wantStatus:   core.ReminderDeliveryStatus_Skipped,
+		},
+		{
+			name:     "OK - held back until the quiet hours end",
+			delivery: core.ReminderDelivery{ID: 1, EventID: "123", RecipientID: "2", Channel: core.ReminderChannel_Email, OccurrenceAt: occurrence, Status: core.ReminderDeliveryStatus_Pending},
+			eventsMock: func(events *mock.MockEventRepository) {
+				events.EXPECT().FindByID(gomock.Any(), "123").Return(newEvent(), nil)
+			},
+			preferencesMock: func(preferences *mock.MockNotificationPreferenceRepository) {
+				preference := core.DefaultNotificationPreference("2")
+				preference.QuietHoursStart = quietUntil.Add(-2 * time.Hour).Format("15:04")
+				preference.QuietHoursEnd = quietUntil.Format("15:04")
+				preferences.EXPECT().FindByUserID(gomock.Any(), "2").Return(preference, nil)
+			},
+			notifierMock:  func(notifier *mock.MockNotifier) {},
+			wantUpdate:    true,
+			wantStatus:    core.ReminderDeliveryStatus_Pending,
+			wantHeldUntil: quietUntil,
+		},
+		{
+			name:         "OK - failed once the occurrence started",
+			delivery:     core.ReminderDelivery{ID: 1, EventID: "123", RecipientID: "2", Channel: core.ReminderChannel_Email, OccurrenceAt: time.Now().Add(-time.Hour)},
+			eventsMock:   func(events *mock.MockEventRepository) {},
+			notifierMock: func(notifier *mock.MockNotifier) {},
+			wantUpdate:   true,
+			wantStatus:   core.ReminderDeliveryStatus_Failed,
+		},
+		{
+			name:     "OK - failed once the event is deleted",
+			delivery: core.ReminderDelivery{ID: 1, EventID: "123", RecipientID: "2", Channel: core.ReminderChannel_Email, OccurrenceAt: occurrence},
+			eventsMock: func(events *mock.MockEventRepository) {
+				events.EXPECT().FindByID(gomock.Any(), "123").Return(nil, core.ErrEventNotFound)
+			},
+			notifierMock: func(notifier *mock.MockNotifier) {},
+			wantUpdate:   true,
+			wantStatus:   core.ReminderDeliveryStatus_Failed,
+		},
+		{
+			name:     "Not OK - error finding the event",
+			delivery: core.ReminderDelivery{ID: 1, EventID: "123", RecipientID: "2", Channel: core.ReminderChannel_Email, OccurrenceAt: occurrence},
+			eventsMock: func(events *mock.MockEventRepository) {
+				events.EXPECT().FindByID(gomock.Any(), "123").Return(nil, errors.New("error")) //nolint:goerr113
+			},
+			notifierMock: func(notifier *mock.MockNotifier) {},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			events := mock.NewMockEventRepository(ctrl)
+			tt.eventsMock(events)
+			preferences := mock.NewMockNotificationPreferenceRepository(ctrl)
+			if tt.preferencesMock != nil {
+				tt.preferencesMock(preferences)
+			} else {
+				preferences.EXPECT().FindByUserID(gomock.Any(), gomock.Any()).AnyTimes().
+					Return(core.DefaultNotificationPreference(tt.delivery.RecipientID), nil)
+			}
+			notifier := mock.NewMockNotifier(ctrl)
+			tt.notifierMock(notifier)
+
+			reminders := mock.NewMockReminderRepository(ctrl)
+			if tt.wantUpdate {
+				reminders.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, delivery *core.ReminderDelivery) error {
+						assert.Equal(t, tt.wantStatus, delivery.Status)
+						assert.Equal(t, tt.wantAttempts, delivery.Attempts)
+						if tt.wantRetryIn > 0 {
+							assert.WithinDuration(t, time.Now().Add(tt.wantRetryIn), delivery.NextAttemptAt, time.Second)
+						}
+						switch {
+						case !tt.wantHeldUntil.IsZero():
+							assert.True(t, tt.wantHeldUntil.Equal(delivery.NextAttemptAt))
+							assert.Empty(t, delivery.LastError)
+						case tt.wantStatus == core.ReminderDeliveryStatus_Sent:
+							assert.NotNil(t, delivery.SentAt)
+						default:
+							assert.NotEmpty(t, delivery.LastError)
+						}
+						return nil
+					})
+			}
+
+			w := reminder.NewWorker(reminders, events, preferences, notifier, time.Second)
+			err := w.Send(context.Background(), &tt.delivery)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNotifiers_Notify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	email := mock.NewMockNotifier(ctrl)
+	email.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(nil)
+	notifiers := reminder.Notifiers{core.ReminderChannel_Email: email}
+
+	err := notifiers.Notify(context.Background(), &core.Notification{Channel: core.ReminderChannel_Email})
+	assert.NoError(t, err)
+
+	err = notifiers.Notify(context.Background(), &core.Notification{Channel: core.ReminderChannel_Push})
+	assert.Error(t, err)
+}
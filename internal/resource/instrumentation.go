@@ -0,0 +1,93 @@
+package resource
+
+import (
+	"context"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Instrumentation struct {
+	next   core.ResourceService
+	tracer trace.Tracer
+}
+
+func NewInstrumentation(next core.ResourceService) *Instrumentation {
+	return &Instrumentation{
+		next:   next,
+		tracer: otel.Tracer("resource-service"),
+	}
+}
+
+func (i *Instrumentation) CreateResource(ctx context.Context, req *core.CreateResourceRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "create-resource")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.CreateResource(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) UpdateResource(ctx context.Context, req *core.UpdateResourceRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "update-resource")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.UpdateResource(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) DeleteResource(ctx context.Context, req *core.DeleteResourceRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "delete-resource")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.DeleteResource(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) FindResourceByID(ctx context.Context, req *core.FindResourceByIDRequest) (*core.Resource, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-resource-by-id")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	resource, err := i.next.FindResourceByID(ctx, req)
+	return resource, err
+}
+
+func (i *Instrumentation) SearchResources(ctx context.Context, req *core.SearchResourcesRequest) ([]core.Resource, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "search-resources")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	resources, err := i.next.SearchResources(ctx, req)
+	return resources, err
+}
+
+func (i *Instrumentation) GetResourceFreeBusy(ctx context.Context, req *core.GetResourceFreeBusyRequest) ([]core.BusyPeriod, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "get-resource-free-busy")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	busy, err := i.next.GetResourceFreeBusy(ctx, req)
+	return busy, err
+}
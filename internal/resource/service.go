@@ -0,0 +1,152 @@
+package resource
+
+import (
+	"context"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+type Service struct {
+	resources core.ResourceRepository
+}
+
+func NewService(resources core.ResourceRepository) *Service {
+	return &Service{
+		resources: resources,
+	}
+}
+
+func (s *Service) CreateResource(ctx context.Context, req *core.CreateResourceRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	req.Resource.CreatedBy = req.ActorID
+	return s.resources.Store(ctx, req.Resource)
+}
+
+func (s *Service) UpdateResource(ctx context.Context, req *core.UpdateResourceRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	resource, err := s.findOwned(ctx, req.ActorID, req.Resource.ID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	resource.Name = req.Resource.Name
+	resource.Type = req.Resource.Type
+	resource.Capacity = req.Resource.Capacity
+	resource.Location = req.Resource.Location
+	resource.UpdatedAt = &now
+	return s.resources.Update(ctx, resource)
+}
+
+// DeleteResource deletes the resource along with its bookings, the events it was booked for are kept.
+func (s *Service) DeleteResource(ctx context.Context, req *core.DeleteResourceRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.findOwned(ctx, req.ActorID, req.ResourceID)
+	if err != nil {
+		return err
+	}
+
+	return s.resources.DeleteByID(ctx, req.ResourceID)
+}
+
+func (s *Service) FindResourceByID(ctx context.Context, req *core.FindResourceByIDRequest) (*core.Resource, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.resources.FindByID(ctx, req.ResourceID)
+}
+
+// SearchResources returns the resources of the type with at least the capacity, and when a window
+// is given, only the ones that aren't booked at any time of it.
+func (s *Service) SearchResources(ctx context.Context, req *core.SearchResourcesRequest) ([]core.Resource, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := s.resources.List(ctx, core.ResourceFilter{
+		Type:        req.Type,
+		MinCapacity: req.MinCapacity,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if req.AvailableFrom == nil || len(resources) == 0 {
+		return resources, nil
+	}
+
+	ids := make([]string, len(resources))
+	for index := range resources {
+		ids[index] = resources[index].ID
+	}
+
+	booked, err := s.resources.FindBookedSchedules(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make(map[string][]core.Schedule)
+	for _, schedule := range booked {
+		schedules[schedule.ResourceID] = append(schedules[schedule.ResourceID], schedule.Schedule)
+	}
+
+	available := []core.Resource{}
+	for _, resource := range resources {
+		if len(core.NewBusyPeriods(schedules[resource.ID], *req.AvailableFrom, *req.AvailableTo)) == 0 {
+			available = append(available, resource)
+		}
+	}
+	return available, nil
+}
+
+func (s *Service) GetResourceFreeBusy(ctx context.Context, req *core.GetResourceFreeBusyRequest) ([]core.BusyPeriod, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.resources.FindByID(ctx, req.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	booked, err := s.resources.FindBookedSchedules(ctx, []string{req.ResourceID})
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make([]core.Schedule, len(booked))
+	for index := range booked {
+		schedules[index] = booked[index].Schedule
+	}
+	return core.NewBusyPeriods(schedules, req.From, req.To), nil
+}
+
+// findOwned returns the resource if the actor created it. Resources are visible to every user, so
+// the others are denied rather than told it doesn't exist.
+func (s *Service) findOwned(ctx context.Context, actorID string, id string) (*core.Resource, error) {
+	resource, err := s.resources.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if resource.CreatedBy != actorID {
+		return nil, core.ErrPermissionDenied
+	}
+	return resource, nil
+}
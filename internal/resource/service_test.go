@@ -0,0 +1,339 @@
+package resource_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/mock"
+	"github.com/dzakaammar/event-scheduling-example/internal/resource"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestResource(id string, createdBy string) *core.Resource {
+	r := core.NewResource(createdBy)
+	r.ID = id
+	r.Name = "Room A"
+	r.Type = core.ResourceType_Room
+	r.Capacity = 8
+	return r
+}
+
+func TestService_CreateResource(t *testing.T) {
+	type fields struct {
+		repoMock func(ctrl *gomock.Controller) core.ResourceRepository
+	}
+	type args struct {
+		req *core.CreateResourceRequest
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "OK - created by the actor",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.ResourceRepository {
+					repo := mock.NewMockResourceRepository(ctrl)
+					repo.EXPECT().Store(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, r *core.Resource) error {
+							assert.Equal(t, "1", r.CreatedBy)
+							return nil
+						})
+					return repo
+				},
+			},
+			args: args{
+				req: &core.CreateResourceRequest{ActorID: "1", Resource: newTestResource("room1", "")},
+			},
+		},
+		{
+			name: "Not OK - unknown type",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.ResourceRepository {
+					return mock.NewMockResourceRepository(ctrl)
+				},
+			},
+			args: args{
+				req: &core.CreateResourceRequest{ActorID: "1", Resource: &core.Resource{Name: "Room A", Type: "DESK"}},
+			},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - error from repo",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.ResourceRepository {
+					repo := mock.NewMockResourceRepository(ctrl)
+					repo.EXPECT().Store(gomock.Any(), gomock.Any()).Return(errors.New("error")) //nolint:goerr113
+					return repo
+				},
+			},
+			args: args{
+				req: &core.CreateResourceRequest{ActorID: "1", Resource: newTestResource("room1", "")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			err := resource.NewService(tt.fields.repoMock(ctrl)).CreateResource(context.Background(), tt.args.req)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_UpdateResource(t *testing.T) {
+	type fields struct {
+		repoMock func(ctrl *gomock.Controller) core.ResourceRepository
+	}
+	type args struct {
+		req *core.UpdateResourceRequest
+	}
+	update := newTestResource("room1", "")
+	update.Name = "Room B"
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		wantErrIs error
+	}{
+		{
+			name: "OK - by the creator",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.ResourceRepository {
+					repo := mock.NewMockResourceRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "room1").Return(newTestResource("room1", "1"), nil)
+					repo.EXPECT().Update(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, r *core.Resource) error {
+							assert.Equal(t, "Room B", r.Name)
+							assert.Equal(t, "1", r.CreatedBy)
+							assert.NotNil(t, r.UpdatedAt)
+							return nil
+						})
+					return repo
+				},
+			},
+			args: args{
+				req: &core.UpdateResourceRequest{ActorID: "1", Resource: update},
+			},
+		},
+		{
+			name: "Not OK - not the creator",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.ResourceRepository {
+					repo := mock.NewMockResourceRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "room1").Return(newTestResource("room1", "1"), nil)
+					return repo
+				},
+			},
+			args: args{
+				req: &core.UpdateResourceRequest{ActorID: "2", Resource: update},
+			},
+			wantErrIs: core.ErrPermissionDenied,
+		},
+		{
+			name: "Not OK - not found",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.ResourceRepository {
+					repo := mock.NewMockResourceRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "room1").Return(nil, core.ErrResourceNotFound)
+					return repo
+				},
+			},
+			args: args{
+				req: &core.UpdateResourceRequest{ActorID: "1", Resource: update},
+			},
+			wantErrIs: core.ErrResourceNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			err := resource.NewService(tt.fields.repoMock(ctrl)).UpdateResource(context.Background(), tt.args.req)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_SearchResources(t *testing.T) {
+	type fields struct {
+		repoMock func(ctrl *gomock.Controller) core.ResourceRepository
+	}
+	type args struct {
+		req *core.SearchResourcesRequest
+	}
+	from := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	rooms := []core.Resource{*newTestResource("room1", "1"), *newTestResource("room2", "1")}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		want      []core.Resource
+		wantErrIs error
+	}{
+		{
+			name: "OK - without a window",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.ResourceRepository {
+					repo := mock.NewMockResourceRepository(ctrl)
+					repo.EXPECT().List(gomock.Any(), core.ResourceFilter{Type: core.ResourceType_Room, MinCapacity: 6}).Return(rooms, nil)
+					return repo
+				},
+			},
+			args: args{
+				req: &core.SearchResourcesRequest{ActorID: "1", Type: core.ResourceType_Room, MinCapacity: 6},
+			},
+			want: rooms,
+		},
+		{
+			name: "OK - the resources booked in the window are left out",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.ResourceRepository {
+					repo := mock.NewMockResourceRepository(ctrl)
+					repo.EXPECT().List(gomock.Any(), core.ResourceFilter{}).Return(rooms, nil)
+					repo.EXPECT().FindBookedSchedules(gomock.Any(), []string{"room1", "room2"}).Return([]core.BookedSchedule{
+						{ResourceID: "room1", Schedule: core.Schedule{StartTime: from.Add(30 * time.Minute).Unix(), DurationInMinutes: 60}},
+						// ends right when the window starts
+						{ResourceID: "room2", Schedule: core.Schedule{StartTime: from.Add(-time.Hour).Unix(), DurationInMinutes: 60}},
+					}, nil)
+					return repo
+				},
+			},
+			args: args{
+				req: &core.SearchResourcesRequest{ActorID: "1", AvailableFrom: &from, AvailableTo: &to},
+			},
+			want: rooms[1:],
+		},
+		{
+			name: "Not OK - the window has no end",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.ResourceRepository {
+					return mock.NewMockResourceRepository(ctrl)
+				},
+			},
+			args: args{
+				req: &core.SearchResourcesRequest{ActorID: "1", AvailableFrom: &from},
+			},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			got, err := resource.NewService(tt.fields.repoMock(ctrl)).SearchResources(context.Background(), tt.args.req)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_GetResourceFreeBusy(t *testing.T) {
+	type fields struct {
+		repoMock func(ctrl *gomock.Controller) core.ResourceRepository
+	}
+	type args struct {
+		req *core.GetResourceFreeBusyRequest
+	}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		want      []core.BusyPeriod
+		wantErrIs error
+	}{
+		{
+			name: "OK - overlapping bookings are merged",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.ResourceRepository {
+					repo := mock.NewMockResourceRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "room1").Return(newTestResource("room1", "1"), nil)
+					repo.EXPECT().FindBookedSchedules(gomock.Any(), []string{"room1"}).Return([]core.BookedSchedule{
+						{ResourceID: "room1", Schedule: core.Schedule{StartTime: from.Add(10 * time.Hour).Unix(), DurationInMinutes: 60}},
+						{ResourceID: "room1", Schedule: core.Schedule{StartTime: from.Add(9 * time.Hour).Unix(), DurationInMinutes: 90}},
+						{ResourceID: "room1", Schedule: core.Schedule{StartTime: from.Add(14 * time.Hour).Unix(), DurationInMinutes: 30}},
+					}, nil)
+					return repo
+				},
+			},
+			args: args{
+				req: &core.GetResourceFreeBusyRequest{ActorID: "2", ResourceID: "room1", From: from, To: from.Add(24 * time.Hour)},
+			},
+			want: []core.BusyPeriod{
+				{Start: from.Add(9 * time.Hour), End: from.Add(11 * time.Hour)},
+				{Start: from.Add(14 * time.Hour), End: from.Add(14*time.Hour + 30*time.Minute)},
+			},
+		},
+		{
+			name: "Not OK - the window is too long",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.ResourceRepository {
+					return mock.NewMockResourceRepository(ctrl)
+				},
+			},
+			args: args{
+				req: &core.GetResourceFreeBusyRequest{ActorID: "2", ResourceID: "room1", From: from, To: from.Add(core.MaxResourceWindow + time.Hour)},
+			},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - not found",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.ResourceRepository {
+					repo := mock.NewMockResourceRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "room1").Return(nil, core.ErrResourceNotFound)
+					return repo
+				},
+			},
+			args: args{
+				req: &core.GetResourceFreeBusyRequest{ActorID: "2", ResourceID: "room1", From: from, To: from.Add(time.Hour)},
+			},
+			wantErrIs: core.ErrResourceNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			got, err := resource.NewService(tt.fields.repoMock(ctrl)).GetResourceFreeBusy(context.Background(), tt.args.req)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package scheduling
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+const (
+	defaultIdempotencyKeyTTL = 24 * time.Hour
+	// idempotencyKeyLease is how long a pending key is held by its request, a retry takes the key
+	// over once it ran out, e.g: when the process died before it could release the key.
+	idempotencyKeyLease = time.Minute
+)
+
+type reservedIdempotencyKeyContextKey struct{}
+
+// withReservedIdempotencyKey hands the key over to Service.CreateEvent, which completes it in the
+// transaction that creates the event.
+func withReservedIdempotencyKey(ctx context.Context, key *core.IdempotencyKey) context.Context {
+	return context.WithValue(ctx, reservedIdempotencyKeyContextKey{}, key)
+}
+
+func reservedIdempotencyKey(ctx context.Context) *core.IdempotencyKey {
+	key, _ := ctx.Value(reservedIdempotencyKeyContextKey{}).(*core.IdempotencyKey)
+	return key
+}
+
+// Idempotency makes CreateEvent safe to retry. The first request carrying a key reserves it as
+// pending, and completes it with the created event in the same transaction. Later requests with
+// the same key and payload get the created event, or core.ErrIdempotencyKeyPending while the first
+// one still runs.
+type Idempotency struct {
+	core.SchedulingService
+	keyRepo core.IdempotencyKeyRepository
+	ttl     time.Duration
+}
+
+func NewIdempotency(next core.SchedulingService, keyRepo core.IdempotencyKeyRepository, ttl time.Duration) *Idempotency {
+	if ttl <= 0 {
+		ttl = defaultIdempotencyKeyTTL
+	}
+
+	return &Idempotency{
+		SchedulingService: next,
+		keyRepo:           keyRepo,
+		ttl:               ttl,
+	}
+}
+
+func (i *Idempotency) CreateEvent(ctx context.Context, req *core.CreateEventRequest) error {
+	if req.IdempotencyKey == "" {
+		return i.SchedulingService.CreateEvent(ctx, req)
+	}
+
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	// the database keeps microseconds, the reservation is matched on its creation time
+	now := time.Now().Truncate(time.Microsecond)
+	key := &core.IdempotencyKey{
+		ActorID:     req.ActorID,
+		Key:         req.IdempotencyKey,
+		RequestHash: req.Fingerprint(),
+		EventID:     req.Event.ID,
+		State:       core.IdempotencyKeyState_Pending,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(idempotencyKeyLease),
+	}
+
+	reserved, err := i.keyRepo.Reserve(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if !reserved {
+		return i.replay(ctx, req, key)
+	}
+
+	completed := *key
+	completed.State = core.IdempotencyKeyState_Completed
+	completed.Response = req.Event
+	completed.ExpiresAt = now.Add(i.ttl)
+
+	err = i.SchedulingService.CreateEvent(withReservedIdempotencyKey(ctx, &completed), req)
+	if err != nil {
+		// release the key, so that the client is able to retry the failed request
+		if releaseErr := i.keyRepo.Release(context.WithoutCancel(ctx), key); releaseErr != nil {
+			slog.Error("failed to release idempotency key", "error", releaseErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// replay answers the request with the event created by the request that reserved the key.
+func (i *Idempotency) replay(ctx context.Context, req *core.CreateEventRequest, key *core.IdempotencyKey) error {
+	existing, err := i.keyRepo.Find(ctx, key.ActorID, key.Key)
+	if err != nil {
+		return err
+	}
+
+	if existing.RequestHash != key.RequestHash {
+		return core.ErrIdempotencyKeyReused
+	}
+
+	if existing.State != core.IdempotencyKeyState_Completed {
+		return core.ErrIdempotencyKeyPending
+	}
+
+	if existing.Response == nil {
+		// completed before the response was stored along with the key
+		req.Event.ID = existing.EventID
+		return nil
+	}
+
+	*req.Event = *existing.Response
+	return nil
+}
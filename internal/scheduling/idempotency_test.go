@@ -0,0 +1,275 @@
+package scheduling_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/changefeed"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/mock"
+	"github.com/dzakaammar/event-scheduling-example/internal/scheduling"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var errCreateFailed = errors.New("create failed") //nolint:goerr113
+
+func newIdempotentCreateRequest(key string, title string) *core.CreateEventRequest {
+	event := core.NewEvent("1")
+	event.Title = title
+	event.Description = "test description"
+	event.Timezone = "Asia/Jakarta"
+	event.Schedules = []core.Schedule{
+		{
+			ID:                "sch-1",
+			EventID:           event.ID,
+			StartTime:         time.Now().Unix(),
+			DurationInMinutes: 60,
+			RecurringType:     core.RecurringType_None,
+		},
+	}
+
+	return &core.CreateEventRequest{
+		ActorID:        "1",
+		Event:          event,
+		IdempotencyKey: key,
+	}
+}
+
+func TestIdempotency_CreateEvent(t *testing.T) {
+	type fields struct {
+		nextMock    func(ctrl *gomock.Controller) core.SchedulingService
+		keyRepoMock func(ctrl *gomock.Controller, req *core.CreateEventRequest) core.IdempotencyKeyRepository
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		req         *core.CreateEventRequest
+		wantEventID string
+		wantErrIs   error
+	}{
+		{
+			name: "OK - without key",
+			fields: fields{
+				nextMock: func(ctrl *gomock.Controller) core.SchedulingService {
+					svc := mock.NewMockSchedulingService(ctrl)
+					svc.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+					return svc
+				},
+				keyRepoMock: func(ctrl *gomock.Controller, _ *core.CreateEventRequest) core.IdempotencyKeyRepository {
+					return mock.NewMockIdempotencyKeyRepository(ctrl)
+				},
+			},
+			req: newIdempotentCreateRequest("", "test"),
+		},
+		{
+			name: "OK - first request",
+			fields: fields{
+				nextMock: func(ctrl *gomock.Controller) core.SchedulingService {
+					svc := mock.NewMockSchedulingService(ctrl)
+					svc.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+					return svc
+				},
+				keyRepoMock: func(ctrl *gomock.Controller, req *core.CreateEventRequest) core.IdempotencyKeyRepository {
+					repo := mock.NewMockIdempotencyKeyRepository(ctrl)
+					repo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Times(1).
+						DoAndReturn(func(_ context.Context, key *core.IdempotencyKey) (bool, error) {
+							assert.Equal(t, req.Event.ID, key.EventID)
+							assert.Equal(t, req.Fingerprint(), key.RequestHash)
+							assert.Equal(t, core.IdempotencyKeyState_Pending, key.State)
+							assert.Equal(t, time.Minute, key.ExpiresAt.Sub(key.CreatedAt))
+							return true, nil
+						})
+					return repo
+				},
+			},
+			req: newIdempotentCreateRequest("retry-123", "test"),
+		},
+		{
+			name: "OK - replay returns the original event",
+			fields: fields{
+				nextMock: func(ctrl *gomock.Controller) core.SchedulingService {
+					svc := mock.NewMockSchedulingService(ctrl)
+					svc.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Times(0)
+					return svc
+				},
+				keyRepoMock: func(ctrl *gomock.Controller, req *core.CreateEventRequest) core.IdempotencyKeyRepository {
+					repo := mock.NewMockIdempotencyKeyRepository(ctrl)
+					repo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+					repo.EXPECT().Find(gomock.Any(), "1", "retry-123").Times(1).Return(&core.IdempotencyKey{
+						ActorID:     "1",
+						Key:         "retry-123",
+						RequestHash: req.Fingerprint(),
+						EventID:     "original",
+						State:       core.IdempotencyKeyState_Completed,
+						Response:    &core.Event{ID: "original", Title: "test", CalendarID: "cal1"},
+					}, nil)
+					return repo
+				},
+			},
+			req:         newIdempotentCreateRequest("retry-123", "test"),
+			wantEventID: "original",
+		},
+		{
+			name: "Not OK - the first request is still in progress",
+			fields: fields{
+				nextMock: func(ctrl *gomock.Controller) core.SchedulingService {
+					svc := mock.NewMockSchedulingService(ctrl)
+					svc.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Times(0)
+					return svc
+				},
+				keyRepoMock: func(ctrl *gomock.Controller, req *core.CreateEventRequest) core.IdempotencyKeyRepository {
+					repo := mock.NewMockIdempotencyKeyRepository(ctrl)
+					repo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+					repo.EXPECT().Find(gomock.Any(), "1", "retry-123").Times(1).Return(&core.IdempotencyKey{
+						ActorID:     "1",
+						Key:         "retry-123",
+						RequestHash: req.Fingerprint(),
+						EventID:     "original",
+						State:       core.IdempotencyKeyState_Pending,
+					}, nil)
+					return repo
+				},
+			},
+			req:       newIdempotentCreateRequest("retry-123", "test"),
+			wantErrIs: core.ErrIdempotencyKeyPending,
+		},
+		{
+			name: "Not OK - key reused with a different payload",
+			fields: fields{
+				nextMock: func(ctrl *gomock.Controller) core.SchedulingService {
+					svc := mock.NewMockSchedulingService(ctrl)
+					svc.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Times(0)
+					return svc
+				},
+				keyRepoMock: func(ctrl *gomock.Controller, _ *core.CreateEventRequest) core.IdempotencyKeyRepository {
+					repo := mock.NewMockIdempotencyKeyRepository(ctrl)
+					repo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+					repo.EXPECT().Find(gomock.Any(), "1", "retry-123").Times(1).Return(&core.IdempotencyKey{
+						ActorID:     "1",
+						Key:         "retry-123",
+						RequestHash: newIdempotentCreateRequest("retry-123", "other").Fingerprint(),
+						EventID:     "original",
+					}, nil)
+					return repo
+				},
+			},
+			req:       newIdempotentCreateRequest("retry-123", "test"),
+			wantErrIs: core.ErrIdempotencyKeyReused,
+		},
+		{
+			name: "Not OK - key reused with a different event id",
+			fields: fields{
+				nextMock: func(ctrl *gomock.Controller) core.SchedulingService {
+					svc := mock.NewMockSchedulingService(ctrl)
+					svc.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Times(0)
+					return svc
+				},
+				keyRepoMock: func(ctrl *gomock.Controller, _ *core.CreateEventRequest) core.IdempotencyKeyRepository {
+					first := newIdempotentCreateRequest("retry-123", "test")
+					first.Event.ID = "event-a"
+					first.EventIDChosen = true
+
+					repo := mock.NewMockIdempotencyKeyRepository(ctrl)
+					repo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+					repo.EXPECT().Find(gomock.Any(), "1", "retry-123").Times(1).Return(&core.IdempotencyKey{
+						ActorID:     "1",
+						Key:         "retry-123",
+						RequestHash: first.Fingerprint(),
+						EventID:     "event-a",
+						State:       core.IdempotencyKeyState_Completed,
+						Response:    first.Event,
+					}, nil)
+					return repo
+				},
+			},
+			req: func() *core.CreateEventRequest {
+				req := newIdempotentCreateRequest("retry-123", "test")
+				req.Event.ID = "event-b"
+				req.EventIDChosen = true
+				return req
+			}(),
+			wantErrIs: core.ErrIdempotencyKeyReused,
+		},
+		{
+			name: "Not OK - key is released when creating fails",
+			fields: fields{
+				nextMock: func(ctrl *gomock.Controller) core.SchedulingService {
+					svc := mock.NewMockSchedulingService(ctrl)
+					svc.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Times(1).Return(errCreateFailed)
+					return svc
+				},
+				keyRepoMock: func(ctrl *gomock.Controller, _ *core.CreateEventRequest) core.IdempotencyKeyRepository {
+					repo := mock.NewMockIdempotencyKeyRepository(ctrl)
+					var reserved *core.IdempotencyKey
+					repo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Times(1).
+						DoAndReturn(func(_ context.Context, key *core.IdempotencyKey) (bool, error) {
+							reserved = key
+							return true, nil
+						})
+					repo.EXPECT().Release(gomock.Any(), gomock.Any()).Times(1).
+						DoAndReturn(func(_ context.Context, key *core.IdempotencyKey) error {
+							assert.Same(t, reserved, key)
+							return nil
+						})
+					return repo
+				},
+			},
+			req:       newIdempotentCreateRequest("retry-123", "test"),
+			wantErrIs: errCreateFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			wantEventID := tt.req.Event.ID
+			if tt.wantEventID != "" {
+				wantEventID = tt.wantEventID
+			}
+
+			i := scheduling.NewIdempotency(tt.fields.nextMock(ctrl), tt.fields.keyRepoMock(ctrl, tt.req), 0)
+			err := i.CreateEvent(context.Background(), tt.req)
+			if tt.wantErrIs != nil {
+				assert.True(t, errors.Is(err, tt.wantErrIs))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, wantEventID, tt.req.Event.ID)
+		})
+	}
+}
+
+func TestIdempotency_CreateEvent_CompletesTheKeyWithTheEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := newIdempotentCreateRequest("retry-123", "test")
+
+	var reserved *core.IdempotencyKey
+	keyRepo := mock.NewMockIdempotencyKeyRepository(ctrl)
+	keyRepo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, key *core.IdempotencyKey) (bool, error) {
+			reserved = key
+			return true, nil
+		})
+
+	eventRepo := mock.NewMockEventRepository(ctrl)
+	eventRepo.EXPECT().Store(gomock.Any(), req.Event, gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, event *core.Event, journal core.Journal) error {
+			if assert.NotNil(t, journal.IdempotencyKey) {
+				assert.Equal(t, reserved.CreatedAt, journal.IdempotencyKey.CreatedAt)
+				assert.Equal(t, core.IdempotencyKeyState_Completed, journal.IdempotencyKey.State)
+				assert.Same(t, event, journal.IdempotencyKey.Response)
+				assert.Equal(t, 24*time.Hour, journal.IdempotencyKey.ExpiresAt.Sub(journal.IdempotencyKey.CreatedAt))
+			}
+			return nil
+		})
+
+	svc := scheduling.NewService(eventRepo, mock.NewMockEventHistoryRepository(ctrl), mock.NewMockCalendarRepository(ctrl), mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0))
+	err := scheduling.NewIdempotency(svc, keyRepo, 0).CreateEvent(context.Background(), req)
+	assert.NoError(t, err)
+}
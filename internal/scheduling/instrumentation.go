@@ -67,3 +67,124 @@ func (i *Instrumentation) FindEventByID(ctx context.Context, req *core.FindEvent
 	event, err := i.next.FindEventByID(ctx, req)
 	return event, err
 }
+
+func (i *Instrumentation) RestoreEvent(ctx context.Context, req *core.RestoreEventRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "restore-event")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.RestoreEvent(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) ListDeletedEvents(ctx context.Context, req *core.ListDeletedEventsRequest) ([]core.Event, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "list-deleted-events")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	events, err := i.next.ListDeletedEvents(ctx, req)
+	return events, err
+}
+
+func (i *Instrumentation) RespondInvitation(ctx context.Context, req *core.RespondInvitationRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "respond-invitation")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.RespondInvitation(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) ListWaitlist(ctx context.Context, req *core.ListWaitlistRequest) ([]core.Invitation, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "list-waitlist")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	waitlist, err := i.next.ListWaitlist(ctx, req)
+	return waitlist, err
+}
+
+func (i *Instrumentation) SetReminders(ctx context.Context, req *core.SetRemindersRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "set-reminders")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.SetReminders(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) ListEventHistory(ctx context.Context, req *core.ListEventHistoryRequest) (*core.EventHistoryPage, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "list-event-history")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	page, err := i.next.ListEventHistory(ctx, req)
+	return page, err
+}
+
+func (i *Instrumentation) ListEvents(ctx context.Context, req *core.ListEventsRequest) (*core.EventsPage, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "list-events")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	page, err := i.next.ListEvents(ctx, req)
+	return page, err
+}
+
+func (i *Instrumentation) SearchEvents(ctx context.Context, req *core.SearchEventsRequest) (*core.SearchEventsPage, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "search-events")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	page, err := i.next.SearchEvents(ctx, req)
+	return page, err
+}
+
+func (i *Instrumentation) BatchMutateEvents(ctx context.Context, req *core.BatchMutateEventsRequest) ([]core.BatchMutationResult, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "batch-mutate-events")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	results, err := i.next.BatchMutateEvents(ctx, req)
+	return results, err
+}
+
+// WatchEvents only traces the subscription, not the lifetime of the watch.
+func (i *Instrumentation) WatchEvents(ctx context.Context, req *core.WatchEventsRequest) (<-chan core.EventChange, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "watch-events")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	changes, err := i.next.WatchEvents(ctx, req)
+	return changes, err
+}
@@ -0,0 +1,73 @@
+package scheduling
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+// Purger permanently removes events that have stayed in the trash longer than the retention period,
+// and idempotency keys that have expired.
+type Purger struct {
+	eventRepo core.EventRepository
+	keyRepo   core.IdempotencyKeyRepository
+	// retention is zero when deleted events are kept forever
+	retention time.Duration
+	interval  time.Duration
+}
+
+const defaultPurgeInterval = time.Hour
+
+func NewPurger(eventRepo core.EventRepository, keyRepo core.IdempotencyKeyRepository, retention time.Duration, interval time.Duration) *Purger {
+	if interval <= 0 {
+		interval = defaultPurgeInterval
+	}
+
+	return &Purger{
+		eventRepo: eventRepo,
+		keyRepo:   keyRepo,
+		retention: retention,
+		interval:  interval,
+	}
+}
+
+// Run purges right away and then on every interval, until ctx is done.
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := p.Purge(ctx)
+		if err != nil {
+			slog.Error(err.Error())
+		} else if purged > 0 {
+			slog.Info("purged deleted events", "count", purged)
+		}
+
+		purged, err = p.PurgeIdempotencyKeys(ctx)
+		if err != nil {
+			slog.Error(err.Error())
+		} else if purged > 0 {
+			slog.Info("purged expired idempotency keys", "count", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Purger) Purge(ctx context.Context) (int64, error) {
+	if p.retention <= 0 {
+		return 0, nil
+	}
+	return p.eventRepo.PurgeDeleted(ctx, time.Now().Add(-p.retention))
+}
+
+func (p *Purger) PurgeIdempotencyKeys(ctx context.Context) (int64, error) {
+	return p.keyRepo.PurgeExpired(ctx, time.Now())
+}
@@ -0,0 +1,87 @@
+package scheduling_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/mock"
+	"github.com/dzakaammar/event-scheduling-example/internal/scheduling"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPurger_Purge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	retention := 24 * time.Hour
+	repo := mock.NewMockEventRepository(ctrl)
+	repo.EXPECT().PurgeDeleted(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, deletedBefore time.Time) (int64, error) {
+			assert.WithinDuration(t, time.Now().Add(-retention), deletedBefore, time.Second)
+			return 3, nil
+		})
+
+	got, err := scheduling.NewPurger(repo, mock.NewMockIdempotencyKeyRepository(ctrl), retention, time.Hour).Purge(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), got)
+}
+
+func TestPurger_Purge_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockEventRepository(ctrl)
+	repo.EXPECT().PurgeDeleted(gomock.Any(), gomock.Any()).Times(0)
+
+	got, err := scheduling.NewPurger(repo, mock.NewMockIdempotencyKeyRepository(ctrl), 0, time.Hour).Purge(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), got)
+}
+
+func TestPurger_PurgeIdempotencyKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	keyRepo := mock.NewMockIdempotencyKeyRepository(ctrl)
+	keyRepo.EXPECT().PurgeExpired(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, now time.Time) (int64, error) {
+			assert.WithinDuration(t, time.Now(), now, time.Second)
+			return 2, nil
+		})
+
+	got, err := scheduling.NewPurger(mock.NewMockEventRepository(ctrl), keyRepo, 0, time.Hour).PurgeIdempotencyKeys(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), got)
+}
+
+func TestPurger_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	repo := mock.NewMockEventRepository(ctrl)
+	repo.EXPECT().PurgeDeleted(gomock.Any(), gomock.Any()).MinTimes(2).
+		DoAndReturn(func(_ context.Context, _ time.Time) (int64, error) {
+			return 0, nil
+		})
+
+	keyRepo := mock.NewMockIdempotencyKeyRepository(ctrl)
+	keyRepo.EXPECT().PurgeExpired(gomock.Any(), gomock.Any()).MinTimes(2).Return(int64(0), nil)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scheduling.NewPurger(repo, keyRepo, time.Hour, 10*time.Millisecond).Run(ctx)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("purger did not stop after the context was cancelled")
+	}
+}
@@ -2,18 +2,27 @@ package scheduling
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/dzakaammar/event-scheduling-example/internal/core"
 )
 
 type Service struct {
-	eventRepo core.EventRepository
+	eventRepo    core.EventRepository
+	historyRepo  core.EventHistoryRepository
+	calendarRepo core.CalendarRepository
+	groupRepo    core.GroupRepository
+	changes      core.EventChangeBroker
 }
 
-func NewService(eventRepo core.EventRepository) *Service {
+func NewService(eventRepo core.EventRepository, historyRepo core.EventHistoryRepository, calendarRepo core.CalendarRepository, groupRepo core.GroupRepository, changes core.EventChangeBroker) *Service {
 	return &Service{
-		eventRepo: eventRepo,
+		eventRepo:    eventRepo,
+		historyRepo:  historyRepo,
+		calendarRepo: calendarRepo,
+		groupRepo:    groupRepo,
+		changes:      changes,
 	}
 }
 
@@ -23,10 +32,26 @@ func (e *Service) CreateEvent(ctx context.Context, req *core.CreateEventRequest)
 		return err
 	}
 
-	err = e.eventRepo.Store(ctx, req.Event)
+	onBehalfOf, err := e.authorizeCreate(ctx, req.ActorID, req.Event)
 	if err != nil {
 		return err
 	}
+
+	err = e.inviteGroups(ctx, req.ActorID, req.Event)
+	if err != nil {
+		return err
+	}
+
+	change := core.NewEventChange(req.Event.ID, req.ActorID, core.HistoryOperation_Create, nil, req.Event)
+	change.OnBehalfOf = onBehalfOf
+	journal := core.NewJournal(change, nil, req.Event)
+	journal.IdempotencyKey = reservedIdempotencyKey(ctx)
+	err = e.eventRepo.Store(ctx, req.Event, journal)
+	if err != nil {
+		return err
+	}
+
+	e.committed(change)
 	return nil
 }
 
@@ -36,15 +61,24 @@ func (e *Service) DeleteEventByID(ctx context.Context, req *core.DeleteEventByID
 		return err
 	}
 
-	_, err = e.eventRepo.FindByID(ctx, req.EventID)
+	event, err := e.eventRepo.FindByID(ctx, req.EventID)
+	if err != nil {
+		return err
+	}
+
+	onBehalfOf, err := e.authorizeWrite(ctx, req.ActorID, event)
 	if err != nil {
 		return err
 	}
 
-	err = e.eventRepo.DeleteByID(ctx, req.EventID)
+	change := core.NewEventChange(req.EventID, req.ActorID, core.HistoryOperation_Delete, event, nil)
+	change.OnBehalfOf = onBehalfOf
+	err = e.eventRepo.DeleteByID(ctx, req.EventID, core.NewJournal(change, event, nil))
 	if err != nil {
 		return err
 	}
+
+	e.committed(change)
 	return nil
 }
 
@@ -54,16 +88,43 @@ func (e *Service) UpdateEvent(ctx context.Context, req *core.UpdateEventRequest)
 		return err
 	}
 
-	now := time.Now()
-	req.Event.UpdatedAt = &now
+	before, err := e.eventRepo.FindByID(ctx, req.Event.ID)
+	if err != nil {
+		return err
+	}
+
+	onBehalfOf, err := e.authorizeWrite(ctx, req.ActorID, before)
+	if err != nil {
+		return err
+	}
+
+	err = e.inviteGroups(ctx, req.ActorID, req.Event)
+	if err != nil {
+		return err
+	}
+
+	promoted := prepareUpdate(req.Event, before, time.Now())
 
-	err = e.eventRepo.Update(ctx, req.Event)
+	change := core.NewEventChange(req.Event.ID, req.ActorID, core.HistoryOperation_Update, before, req.Event)
+	change.OnBehalfOf = onBehalfOf
+	err = e.eventRepo.Update(ctx, req.Event, core.NewPromotedJournal(change, before, req.Event, promoted))
 	if err != nil {
 		return err
 	}
+
+	e.committed(change)
 	return nil
 }
 
+// prepareUpdate carries over to the updated event what can't be changed by an update, and returns
+// the invitations promoted from the waitlist.
+func prepareUpdate(event, before *core.Event, now time.Time) []core.Invitation {
+	// the guests are invited by booking a slot, they can't be changed but are told about the update
+	event.Guests = before.Guests
+	event.UpdatedAt = &now
+	return event.KeepInvitations(before, now)
+}
+
 func (e *Service) FindEventByID(ctx context.Context, req *core.FindEventByIDRequest) (*core.Event, error) {
 	err := req.Validate()
 	if err != nil {
@@ -75,5 +136,500 @@ func (e *Service) FindEventByID(ctx context.Context, req *core.FindEventByIDRequ
 		return nil, err
 	}
 
+	freeBusy, err := e.authorizeRead(ctx, req.ActorID, event)
+	if err != nil {
+		return nil, err
+	}
+	if freeBusy {
+		busy := event.FreeBusy()
+		return &busy, nil
+	}
+
 	return event, nil
 }
+
+func (e *Service) RestoreEvent(ctx context.Context, req *core.RestoreEventRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	// the change needs the event to know who is involved in it
+	restored, err := e.eventRepo.FindDeletedByID(ctx, req.EventID)
+	if err != nil {
+		return err
+	}
+	restored.DeletedAt = nil
+
+	onBehalfOf, err := e.authorizeWrite(ctx, req.ActorID, restored)
+	if err != nil {
+		return err
+	}
+
+	change := core.NewEventChange(req.EventID, req.ActorID, core.HistoryOperation_Restore, nil, restored)
+	change.OnBehalfOf = onBehalfOf
+	// restoring doesn't change any field, the audit trail only records that it happened
+	err = e.eventRepo.RestoreByID(ctx, req.EventID, core.NewJournal(change, nil, nil))
+	if err != nil {
+		return err
+	}
+
+	e.committed(change)
+	return nil
+}
+
+func (e *Service) ListDeletedEvents(ctx context.Context, req *core.ListDeletedEventsRequest) ([]core.Event, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := e.eventRepo.FindDeletedByCreator(ctx, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (e *Service) RespondInvitation(ctx context.Context, req *core.RespondInvitationRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	userID, _ := core.ParseUserID(req.ActorID)
+
+	var change core.EventChange
+	err = e.eventRepo.RespondInvitation(ctx, req.EventID, func(event *core.Event) ([]core.Invitation, core.Journal, error) {
+		responded := *event
+		responded.Invitations = append([]core.Invitation(nil), event.Invitations...)
+
+		changed, err := responded.Respond(userID, req.Status, time.Now())
+		if err != nil {
+			return nil, core.Journal{}, err
+		}
+
+		change = core.NewEventChange(req.EventID, req.ActorID, core.HistoryOperation_RSVP, event, &responded)
+		return changed, core.NewPromotedJournal(change, event, &responded, changed[1:]), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	e.committed(change)
+	return nil
+}
+
+func (e *Service) ListWaitlist(ctx context.Context, req *core.ListWaitlistRequest) ([]core.Invitation, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := e.eventRepo.FindByID(ctx, req.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	freeBusy, err := e.authorizeRead(ctx, req.ActorID, event)
+	if err != nil {
+		return nil, err
+	}
+	if freeBusy {
+		return nil, core.ErrPermissionDenied
+	}
+
+	return event.Waitlist(), nil
+}
+
+// SetReminders replaces the reminders the attendee set on the event, which replace the reminders
+// of the event for them. Reminders are personal, so they are left out of the history and the changes.
+func (e *Service) SetReminders(ctx context.Context, req *core.SetRemindersRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	event, err := e.eventRepo.FindByID(ctx, req.EventID)
+	if err != nil {
+		return err
+	}
+
+	userID, _ := core.ParseUserID(req.ActorID)
+	if event.FindInvitation(userID) == nil {
+		return core.ErrInvitationNotFound
+	}
+
+	for index := range req.Reminders {
+		req.Reminders[index].EventID = req.EventID
+		req.Reminders[index].UserID = userID
+	}
+
+	return e.eventRepo.ReplaceReminders(ctx, req.EventID, userID, req.Reminders)
+}
+
+func (e *Service) ListEventHistory(ctx context.Context, req *core.ListEventHistoryRequest) (*core.EventHistoryPage, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := e.eventRepo.FindByID(ctx, req.EventID)
+	if errors.Is(err, core.ErrEventNotFound) {
+		// the history of an event in the trash is kept until it is purged
+		event, err = e.eventRepo.FindDeletedByID(ctx, req.EventID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	freeBusy, err := e.authorizeRead(ctx, req.ActorID, event)
+	if err != nil {
+		return nil, err
+	}
+	if freeBusy {
+		return nil, core.ErrPermissionDenied
+	}
+
+	// fetch one more entry than requested to know whether there is a next page
+	entries, err := e.historyRepo.FindByEventID(ctx, req.EventID, req.BeforeID, req.PageSize+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &core.EventHistoryPage{Entries: entries}
+	if len(entries) > req.PageSize {
+		page.Entries = entries[:req.PageSize]
+		page.NextBeforeID = page.Entries[req.PageSize-1].ID
+	}
+	return page, nil
+}
+
+func (e *Service) ListEvents(ctx context.Context, req *core.ListEventsRequest) (*core.EventsPage, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make(map[string]core.CalendarRole)
+	if req.CalendarID != "" {
+		role, err := e.calendarRepo.FindRole(ctx, req.CalendarID, req.ActorID)
+		if err != nil {
+			return nil, err
+		}
+		if role == "" {
+			return nil, core.ErrCalendarNotFound
+		}
+		roles[req.CalendarID] = role
+	}
+
+	// fetch one more event than requested to know whether there is a next page
+	events, err := e.eventRepo.List(ctx, core.EventFilter{
+		CreatedBy:     req.CreatedBy,
+		Attendee:      req.Attendee,
+		Title:         req.Title,
+		CreatedAfter:  req.CreatedAfter,
+		CreatedBefore: req.CreatedBefore,
+		Timezone:      req.Timezone,
+		CalendarID:    req.CalendarID,
+		Viewer:        req.ActorID,
+		After:         req.After,
+		Limit:         req.PageSize + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.redactFreeBusy(ctx, req.ActorID, events, roles)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &core.EventsPage{Events: events}
+	if len(events) > req.PageSize {
+		page.Events = events[:req.PageSize]
+		next := page.Events[req.PageSize-1].Cursor()
+		page.Next = &next
+	}
+	return page, nil
+}
+
+func (e *Service) SearchEvents(ctx context.Context, req *core.SearchEventsRequest) (*core.SearchEventsPage, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// fetch one more event than requested to know whether there is a next page
+	events, err := e.eventRepo.Search(ctx, req.ActorID, req.Query, req.Offset, req.PageSize+1)
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.redactFreeBusy(ctx, req.ActorID, events, make(map[string]core.CalendarRole))
+	if err != nil {
+		return nil, err
+	}
+
+	page := &core.SearchEventsPage{Events: events}
+	if len(events) > req.PageSize {
+		page.Events = events[:req.PageSize]
+		page.NextOffset = req.Offset + req.PageSize
+	}
+	return page, nil
+}
+
+// redactFreeBusy reduces the events the actor only sees through a calendar shared free/busy to when
+// they are busy. roles caches the role of the actor per calendar id.
+func (e *Service) redactFreeBusy(ctx context.Context, actorID string, events []core.Event, roles map[string]core.CalendarRole) error {
+	for index := range events {
+		if events[index].Involves(actorID) {
+			continue
+		}
+
+		role, ok := roles[events[index].CalendarID]
+		if !ok {
+			var err error
+			role, err = e.calendarRepo.FindRole(ctx, events[index].CalendarID, actorID)
+			if err != nil {
+				return err
+			}
+			roles[events[index].CalendarID] = role
+		}
+		if !role.Includes(core.CalendarRole_Read) {
+			events[index] = events[index].FreeBusy()
+		}
+	}
+	return nil
+}
+
+func (e *Service) BatchMutateEvents(ctx context.Context, req *core.BatchMutateEventsRequest) ([]core.BatchMutationResult, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]core.BatchMutationResult, len(req.Mutations))
+	for index := range req.Mutations {
+		results[index].EventID = req.Mutations[index].EventID()
+	}
+
+	if !req.Atomic {
+		for index := range req.Mutations {
+			results[index].Err = e.applyMutation(ctx, &req.Mutations[index])
+		}
+		return results, nil
+	}
+
+	err = e.applyMutationsAtomically(ctx, req, results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (e *Service) applyMutation(ctx context.Context, mutation *core.BatchMutation) error {
+	err := mutation.Validate()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case mutation.Create != nil:
+		return e.CreateEvent(ctx, mutation.Create)
+	case mutation.Update != nil:
+		return e.UpdateEvent(ctx, mutation.Update)
+	default:
+		return e.DeleteEventByID(ctx, mutation.Delete)
+	}
+}
+
+// applyMutationsAtomically writes the outcome of each mutation into results. Once one mutation
+// fails, the others are reported as aborted and none of them is applied. The returned error is
+// only set when the outcome of the batch is unknown, i.e: the commit failed.
+func (e *Service) applyMutationsAtomically(ctx context.Context, req *core.BatchMutateEventsRequest, results []core.BatchMutationResult) error {
+	mutations := make([]core.EventMutation, len(req.Mutations))
+	befores := make([]*core.Event, len(req.Mutations))
+	onBehalfOf := make([]string, len(req.Mutations))
+	now := time.Now()
+
+	failed := false
+	for index := range req.Mutations {
+		mutation := &req.Mutations[index]
+		err := mutation.Validate()
+		if err != nil {
+			results[index].Err = err
+			failed = true
+			continue
+		}
+
+		switch {
+		case mutation.Create != nil:
+			mutations[index] = core.EventMutation{Type: core.MutationType_Create, Event: mutation.Create.Event}
+		case mutation.Update != nil:
+			mutations[index] = core.EventMutation{Type: core.MutationType_Update, Event: mutation.Update.Event}
+		default:
+			mutations[index] = core.EventMutation{Type: core.MutationType_Delete, Event: &core.Event{ID: mutation.Delete.EventID}}
+		}
+
+		if mutations[index].Type == core.MutationType_Create {
+			onBehalfOf[index], err = e.authorizeCreate(ctx, req.ActorID, mutations[index].Event)
+		} else {
+			befores[index], err = e.eventRepo.FindByID(ctx, mutations[index].Event.ID)
+			if err == nil {
+				onBehalfOf[index], err = e.authorizeWrite(ctx, req.ActorID, befores[index])
+			}
+		}
+		if err == nil && mutations[index].Type != core.MutationType_Delete {
+			err = e.inviteGroups(ctx, req.ActorID, mutations[index].Event)
+		}
+		if err != nil {
+			results[index].Err = err
+			failed = true
+		}
+	}
+
+	changes := make([]core.EventChange, len(req.Mutations))
+	if !failed {
+		for index := range mutations {
+			event := mutations[index].Event
+			var promoted []core.Invitation
+			switch mutations[index].Type {
+			case core.MutationType_Create:
+				changes[index] = core.NewEventChange(event.ID, req.ActorID, core.HistoryOperation_Create, nil, event)
+			case core.MutationType_Update:
+				promoted = prepareUpdate(event, befores[index], now)
+				changes[index] = core.NewEventChange(event.ID, req.ActorID, core.HistoryOperation_Update, befores[index], event)
+			case core.MutationType_Delete:
+				changes[index] = core.NewEventChange(event.ID, req.ActorID, core.HistoryOperation_Delete, befores[index], nil)
+			}
+			changes[index].OnBehalfOf = onBehalfOf[index]
+			mutations[index].Journal = core.NewPromotedJournal(changes[index], befores[index], event, promoted)
+		}
+
+		err := e.eventRepo.Batch(ctx, mutations)
+		var batchErr *core.BatchError
+		switch {
+		case errors.As(err, &batchErr):
+			results[batchErr.Index].Err = batchErr.Err
+			failed = true
+		case err != nil:
+			return err
+		}
+	}
+
+	if failed {
+		for index := range results {
+			if results[index].Err == nil {
+				results[index].Err = core.ErrBatchAborted
+			}
+		}
+		return nil
+	}
+
+	for index := range changes {
+		e.committed(changes[index])
+	}
+	return nil
+}
+
+func (e *Service) WatchEvents(ctx context.Context, req *core.WatchEventsRequest) (<-chan core.EventChange, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return e.changes.Subscribe(ctx, req.After, func(change core.EventChange) bool {
+		return change.Involves(req.ActorID)
+	})
+}
+
+// committed publishes a committed change to the change feed. The change was also written to the
+// audit trail and the outbox along with the operation, consumers that can't afford to miss it are
+// fed by the outbox relay rather than the change feed.
+func (e *Service) committed(change core.EventChange) {
+	e.changes.Publish(change)
+}
+
+// authorizeWrite lets the creator of the event change it, and so can a delegate who can write to
+// its calendar. It returns on whose behalf the actor acts, empty for the creator.
+func (e *Service) authorizeWrite(ctx context.Context, actorID string, event *core.Event) (string, error) {
+	if event.CreatedBy == actorID {
+		return "", nil
+	}
+
+	role, err := e.calendarRepo.FindRole(ctx, event.CalendarID, actorID)
+	if err != nil {
+		return "", err
+	}
+	if !role.Includes(core.CalendarRole_Write) {
+		return "", core.ErrPermissionDenied
+	}
+	return event.CreatedBy, nil
+}
+
+// authorizeRead lets the creator and the attendees of the event see it, and so can whoever its
+// calendar is shared with. It reports whether the actor may only see when the event is busy.
+func (e *Service) authorizeRead(ctx context.Context, actorID string, event *core.Event) (bool, error) {
+	if event.Involves(actorID) {
+		return false, nil
+	}
+
+	role, err := e.calendarRepo.FindRole(ctx, event.CalendarID, actorID)
+	if err != nil {
+		return false, err
+	}
+	if role == "" {
+		return false, core.ErrEventNotFound
+	}
+	return !role.Includes(core.CalendarRole_Read), nil
+}
+
+// authorizeCreate lets a delegate who can write to the calendar the event is created in create it
+// on behalf of the owner of the calendar, who becomes its creator. It returns on whose behalf the
+// actor acts, empty when they create the event in a calendar of their own.
+func (e *Service) authorizeCreate(ctx context.Context, actorID string, event *core.Event) (string, error) {
+	if event.CalendarID == "" {
+		return "", nil
+	}
+
+	calendar, err := e.calendarRepo.FindByID(ctx, event.CalendarID)
+	if err != nil {
+		return "", err
+	}
+	if calendar.OwnerID == actorID {
+		return "", nil
+	}
+
+	role, err := e.calendarRepo.FindRole(ctx, calendar.ID, actorID)
+	if err != nil {
+		return "", err
+	}
+	if role == "" {
+		return "", core.ErrCalendarNotFound
+	}
+	if !role.Includes(core.CalendarRole_Write) {
+		return "", core.ErrPermissionDenied
+	}
+
+	event.CreatedBy = calendar.OwnerID
+	return calendar.OwnerID, nil
+}
+
+// inviteGroups expands the groups invited to the event into the invitations of their members,
+// linked back to the group. The actor can only invite the groups they own or are a member of.
+func (e *Service) inviteGroups(ctx context.Context, actorID string, event *core.Event) error {
+	for _, groupID := range event.Groups {
+		group, err := e.groupRepo.FindByID(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		if !group.VisibleTo(actorID) {
+			return core.ErrGroupNotFound
+		}
+
+		event.InviteGroup(group)
+	}
+	return nil
+}
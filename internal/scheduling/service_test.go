@@ -3,10 +3,12 @@ package scheduling_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/changefeed"
 	"github.com/dzakaammar/event-scheduling-example/internal/core"
 	"github.com/dzakaammar/event-scheduling-example/internal/mock"
 	"github.com/dzakaammar/event-scheduling-example/internal/scheduling"
@@ -19,7 +21,10 @@ func TestNewEventService(t *testing.T) {
 	defer ctrl.Finish()
 
 	type args struct {
-		eventRepo core.EventRepository
+		eventRepo    core.EventRepository
+		historyRepo  core.EventHistoryRepository
+		calendarRepo core.CalendarRepository
+		groupRepo    core.GroupRepository
 	}
 	tests := []struct {
 		name string
@@ -28,13 +33,16 @@ func TestNewEventService(t *testing.T) {
 		{
 			name: "OK",
 			args: args{
-				eventRepo: mock.NewMockEventRepository(ctrl),
+				eventRepo:    mock.NewMockEventRepository(ctrl),
+				historyRepo:  mock.NewMockEventHistoryRepository(ctrl),
+				calendarRepo: mock.NewMockCalendarRepository(ctrl),
+				groupRepo:    mock.NewMockGroupRepository(ctrl),
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := scheduling.NewService(tt.args.eventRepo)
+			got := scheduling.NewService(tt.args.eventRepo, tt.args.historyRepo, tt.args.calendarRepo, tt.args.groupRepo, changefeed.NewBroker(0))
 			assert.NotNil(t, got)
 		})
 	}
@@ -42,7 +50,9 @@ func TestNewEventService(t *testing.T) {
 
 func TestEventService_CreateEvent(t *testing.T) {
 	type fields struct {
-		eventRepoMock func(ctrl *gomock.Controller) core.EventRepository
+		eventRepoMock   func(ctrl *gomock.Controller) core.EventRepository
+		historyRepoMock func(ctrl *gomock.Controller) core.EventHistoryRepository
+		groupRepoMock   func(ctrl *gomock.Controller) core.GroupRepository
 	}
 	type args struct {
 		ctx context.Context
@@ -59,7 +69,7 @@ func TestEventService_CreateEvent(t *testing.T) {
 			fields: fields{
 				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
 					repo := mock.NewMockEventRepository(ctrl)
-					repo.EXPECT().Store(gomock.Any(), gomock.Any()).Return(nil)
+					repo.EXPECT().Store(gomock.Any(), gomock.Any(), journalOf(core.HistoryOperation_Create, core.DomainEventType_EventCreated)).Return(nil)
 					return repo
 				},
 			},
@@ -100,7 +110,7 @@ func TestEventService_CreateEvent(t *testing.T) {
 			fields: fields{
 				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
 					repo := mock.NewMockEventRepository(ctrl)
-					repo.EXPECT().Store(gomock.Any(), gomock.Any()).Return(internal.ErrValidationFailed)
+					repo.EXPECT().Store(gomock.Any(), gomock.Any(), gomock.Any()).Return(internal.ErrValidationFailed)
 					return repo
 				},
 			},
@@ -176,13 +186,127 @@ func TestEventService_CreateEvent(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Not OK - invalid event id",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.CreateEventRequest{
+					ActorID: "123",
+					Event: &core.Event{
+						ID:          "my/event",
+						Title:       "test",
+						Description: "test123",
+						Timezone:    "Asia/Jakarta",
+						Schedules: []core.Schedule{
+							{
+								ID:                "test123",
+								EventID:           "my/event",
+								StartTime:         time.Now().Unix(),
+								DurationInMinutes: 120,
+								RecurringType:     core.RecurringType_None,
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "OK - the members of the group are invited",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().Store(gomock.Any(), gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, event *core.Event, _ core.Journal) error {
+							assert.Len(t, event.Invitations, 2)
+							assert.Empty(t, event.Invitations[0].GroupID)
+							assert.Equal(t, int32(3), event.Invitations[1].UserID)
+							assert.Equal(t, "group1", event.Invitations[1].GroupID)
+							return nil
+						})
+					return repo
+				},
+				groupRepoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					repo := mock.NewMockGroupRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "group1").
+						Return(&core.Group{ID: "group1", OwnerID: "123", Members: []int32{2, 3}}, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.CreateEventRequest{
+					ActorID: "123",
+					Event: &core.Event{
+						ID:          "123",
+						Title:       "test",
+						Description: "test123",
+						Timezone:    "Asia/Jakarta",
+						Schedules: []core.Schedule{
+							{
+								ID:                "test123",
+								EventID:           "123",
+								StartTime:         time.Now().Unix(),
+								DurationInMinutes: 120,
+								RecurringType:     core.RecurringType_None,
+							},
+						},
+						Invitations: []core.Invitation{core.NewInvitation("123", 2)},
+						Groups:      []string{"group1"},
+					},
+				},
+			},
+		},
+		{
+			name: "Not OK - the group isn't visible to the actor",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					return mock.NewMockEventRepository(ctrl)
+				},
+				groupRepoMock: func(ctrl *gomock.Controller) core.GroupRepository {
+					repo := mock.NewMockGroupRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "group1").
+						Return(&core.Group{ID: "group1", OwnerID: "1", Members: []int32{2, 3}}, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.CreateEventRequest{
+					ActorID: "123",
+					Event: &core.Event{
+						ID:          "123",
+						Title:       "test",
+						Description: "test123",
+						Timezone:    "Asia/Jakarta",
+						Schedules: []core.Schedule{
+							{
+								ID:                "test123",
+								EventID:           "123",
+								StartTime:         time.Now().Unix(),
+								DurationInMinutes: 120,
+								RecurringType:     core.RecurringType_None,
+							},
+						},
+						Groups: []string{"group1"},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl))
+			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl), historyRepoMock(ctrl, tt.fields.historyRepoMock), mock.NewMockCalendarRepository(ctrl), groupRepoMock(ctrl, tt.fields.groupRepoMock), changefeed.NewBroker(0))
 			err := e.CreateEvent(tt.args.ctx, tt.args.req)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -195,7 +319,8 @@ func TestEventService_CreateEvent(t *testing.T) {
 
 func TestEventService_DeleteEventByID(t *testing.T) {
 	type fields struct {
-		eventRepoMock func(ctrl *gomock.Controller) core.EventRepository
+		eventRepoMock   func(ctrl *gomock.Controller) core.EventRepository
+		historyRepoMock func(ctrl *gomock.Controller) core.EventHistoryRepository
 	}
 	type args struct {
 		ctx context.Context
@@ -213,8 +338,8 @@ func TestEventService_DeleteEventByID(t *testing.T) {
 				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
 					repo := mock.NewMockEventRepository(ctrl)
 					repo.EXPECT().FindByID(gomock.Any(), gomock.Any()).Times(1).
-						Return(&core.Event{}, nil)
-					repo.EXPECT().DeleteByID(gomock.Any(), gomock.Any()).Times(1).
+						Return(&core.Event{CreatedBy: "test123"}, nil)
+					repo.EXPECT().DeleteByID(gomock.Any(), gomock.Any(), journalOf(core.HistoryOperation_Delete, core.DomainEventType_EventDeleted)).Times(1).
 						Return(nil)
 					return repo
 				},
@@ -253,8 +378,8 @@ func TestEventService_DeleteEventByID(t *testing.T) {
 				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
 					repo := mock.NewMockEventRepository(ctrl)
 					repo.EXPECT().FindByID(gomock.Any(), gomock.Any()).Times(1).
-						Return(&core.Event{}, nil)
-					repo.EXPECT().DeleteByID(gomock.Any(), gomock.Any()).Times(1).
+						Return(&core.Event{CreatedBy: "test123"}, nil)
+					repo.EXPECT().DeleteByID(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
 						Return(internal.ErrInvalidRequest)
 					return repo
 				},
@@ -291,7 +416,7 @@ func TestEventService_DeleteEventByID(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl))
+			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl), historyRepoMock(ctrl, tt.fields.historyRepoMock), mock.NewMockCalendarRepository(ctrl), mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0))
 			err := e.DeleteEventByID(tt.args.ctx, tt.args.req)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -304,7 +429,8 @@ func TestEventService_DeleteEventByID(t *testing.T) {
 
 func TestEventService_UpdateEvent(t *testing.T) {
 	type fields struct {
-		eventRepoMock func(ctrl *gomock.Controller) core.EventRepository
+		eventRepoMock   func(ctrl *gomock.Controller) core.EventRepository
+		historyRepoMock func(ctrl *gomock.Controller) core.EventHistoryRepository
 	}
 	type args struct {
 		ctx context.Context
@@ -321,7 +447,9 @@ func TestEventService_UpdateEvent(t *testing.T) {
 			fields: fields{
 				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
 					repo := mock.NewMockEventRepository(ctrl)
-					repo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).
+					repo.EXPECT().FindByID(gomock.Any(), gomock.Any()).Times(1).
+						Return(&core.Event{CreatedBy: "test123"}, nil)
+					repo.EXPECT().Update(gomock.Any(), gomock.Any(), journalOf(core.HistoryOperation_Update, core.DomainEventType_EventUpdated)).Times(1).
 						Return(nil)
 					return repo
 				},
@@ -367,12 +495,81 @@ func TestEventService_UpdateEvent(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "OK - the attendees keep their invitations and the raised capacity promotes the waitlist",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					earlier, later := time.Now().Add(-time.Hour), time.Now().Add(-time.Minute)
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "test123").Times(1).
+						Return(&core.Event{
+							ID:        "test123",
+							CreatedBy: "test123",
+							Capacity:  1,
+							Invitations: []core.Invitation{
+								{ID: "inv1", EventID: "test123", UserID: 2, Status: core.InvitationStatus_Confirmed, Token: "token1"},
+								{ID: "inv2", EventID: "test123", UserID: 3, Status: core.InvitationStatus_Waitlisted, Token: "token2", WaitlistedAt: &later},
+								{ID: "inv3", EventID: "test123", UserID: 4, Status: core.InvitationStatus_Waitlisted, Token: "token3", WaitlistedAt: &earlier},
+							},
+						}, nil)
+					repo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+						DoAndReturn(func(_ context.Context, event *core.Event, journal core.Journal) error {
+							assert.Len(t, event.Invitations, 3)
+							assert.Equal(t, "inv1", event.Invitations[0].ID)
+							assert.Equal(t, core.InvitationStatus_Confirmed, event.Invitations[0].Status)
+							// the earliest on the waitlist takes the new seat, the other one keeps their place
+							assert.Equal(t, "inv2", event.Invitations[1].ID)
+							assert.Equal(t, core.InvitationStatus_Waitlisted, event.Invitations[1].Status)
+							assert.Equal(t, &later, event.Invitations[1].WaitlistedAt)
+							assert.Equal(t, "inv3", event.Invitations[2].ID)
+							assert.Equal(t, core.InvitationStatus_Confirmed, event.Invitations[2].Status)
+							assert.Nil(t, event.Invitations[2].WaitlistedAt)
+
+							assert.Len(t, journal.Outbox, 2)
+							assert.True(t, domainEventOfType(core.DomainEventType_WaitlistPromoted).Matches(journal.Outbox[1]))
+							assert.Equal(t, []string{"4"}, journal.Outbox[1].Audience)
+							return nil
+						})
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.UpdateEventRequest{
+					ID:      "test123",
+					ActorID: "test123",
+					Event: &core.Event{
+						ID:          "test123",
+						Title:       "training",
+						Description: "description",
+						Timezone:    "Asia/Jakarta",
+						Capacity:    2,
+						Schedules: []core.Schedule{
+							{
+								ID:                "sch1",
+								EventID:           "test123",
+								StartTime:         time.Now().Unix(),
+								DurationInMinutes: 120,
+								RecurringType:     core.RecurringType_None,
+							},
+						},
+						Invitations: []core.Invitation{
+							core.NewInvitation("test123", 2),
+							core.NewInvitation("test123", 3),
+							core.NewInvitation("test123", 4),
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Not OK - error from repo",
 			fields: fields{
 				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
 					repo := mock.NewMockEventRepository(ctrl)
-					repo.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).
+					repo.EXPECT().FindByID(gomock.Any(), gomock.Any()).Times(1).
+						Return(&core.Event{CreatedBy: "test123"}, nil)
+					repo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
 						Return(internal.ErrInvalidRequest)
 					return repo
 				},
@@ -443,7 +640,7 @@ func TestEventService_UpdateEvent(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl))
+			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl), historyRepoMock(ctrl, tt.fields.historyRepoMock), mock.NewMockCalendarRepository(ctrl), mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0))
 			err := e.UpdateEvent(tt.args.ctx, tt.args.req)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -456,7 +653,8 @@ func TestEventService_UpdateEvent(t *testing.T) {
 
 func TestEventService_FindEventByID(t *testing.T) {
 	type fields struct {
-		eventRepoMock func(ctrl *gomock.Controller) core.EventRepository
+		eventRepoMock   func(ctrl *gomock.Controller) core.EventRepository
+		historyRepoMock func(ctrl *gomock.Controller) core.EventHistoryRepository
 	}
 	type args struct {
 		ctx context.Context
@@ -513,6 +711,7 @@ func TestEventService_FindEventByID(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				req: &core.FindEventByIDRequest{
+					ActorID: "2",
 					EventID: "123",
 				},
 			},
@@ -563,6 +762,7 @@ func TestEventService_FindEventByID(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				req: &core.FindEventByIDRequest{
+					ActorID: "2",
 					EventID: "123",
 				},
 			},
@@ -592,7 +792,7 @@ func TestEventService_FindEventByID(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl))
+			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl), historyRepoMock(ctrl, tt.fields.historyRepoMock), mock.NewMockCalendarRepository(ctrl), mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0))
 			got, err := e.FindEventByID(tt.args.ctx, tt.args.req)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -602,3 +802,1581 @@ func TestEventService_FindEventByID(t *testing.T) {
 		})
 	}
 }
+
+func TestEventService_RestoreEvent(t *testing.T) {
+	type fields struct {
+		eventRepoMock   func(ctrl *gomock.Controller) core.EventRepository
+		historyRepoMock func(ctrl *gomock.Controller) core.EventHistoryRepository
+	}
+	type args struct {
+		ctx context.Context
+		req *core.RestoreEventRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindDeletedByID(gomock.Any(), "123").Times(1).
+						Return(&core.Event{ID: "123", CreatedBy: "test123"}, nil)
+					repo.EXPECT().RestoreByID(gomock.Any(), "123", journalOf(core.HistoryOperation_Restore, core.DomainEventType_EventRestored)).Times(1).
+						Return(nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.RestoreEventRequest{
+					ActorID: "test123",
+					EventID: "123",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Not OK - not in the trash",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindDeletedByID(gomock.Any(), "123").Times(1).
+						Return(nil, core.ErrEventNotFound)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.RestoreEventRequest{
+					ActorID: "test123",
+					EventID: "123",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not OK - error from repo",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindDeletedByID(gomock.Any(), "123").Times(1).
+						Return(&core.Event{ID: "123", CreatedBy: "test123"}, nil)
+					repo.EXPECT().RestoreByID(gomock.Any(), "123", gomock.Any()).Times(1).
+						Return(core.ErrEventNotFound)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.RestoreEventRequest{
+					ActorID: "test123",
+					EventID: "123",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not OK - invalid request",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.RestoreEventRequest{
+					ActorID: "",
+					EventID: "123",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl), historyRepoMock(ctrl, tt.fields.historyRepoMock), mock.NewMockCalendarRepository(ctrl), mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0))
+			err := e.RestoreEvent(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEventService_ListDeletedEvents(t *testing.T) {
+	type fields struct {
+		eventRepoMock   func(ctrl *gomock.Controller) core.EventRepository
+		historyRepoMock func(ctrl *gomock.Controller) core.EventHistoryRepository
+	}
+	type args struct {
+		ctx context.Context
+		req *core.ListDeletedEventsRequest
+	}
+
+	deletedAt := time.Now()
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []core.Event
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindDeletedByCreator(gomock.Any(), "test123").Times(1).
+						Return([]core.Event{{ID: "123", DeletedAt: &deletedAt}}, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.ListDeletedEventsRequest{
+					ActorID: "test123",
+				},
+			},
+			want: []core.Event{{ID: "123", DeletedAt: &deletedAt}},
+		},
+		{
+			name: "Not OK - error from repo",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindDeletedByCreator(gomock.Any(), "test123").Times(1).
+						Return(nil, internal.ErrInvalidRequest)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.ListDeletedEventsRequest{
+					ActorID: "test123",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not OK - invalid request",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.ListDeletedEventsRequest{},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl), historyRepoMock(ctrl, tt.fields.historyRepoMock), mock.NewMockCalendarRepository(ctrl), mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0))
+			got, err := e.ListDeletedEvents(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestEventService_RespondInvitation(t *testing.T) {
+	type fields struct {
+		eventRepoMock   func(ctrl *gomock.Controller) core.EventRepository
+		historyRepoMock func(ctrl *gomock.Controller) core.EventHistoryRepository
+	}
+	type args struct {
+		ctx context.Context
+		req *core.RespondInvitationRequest
+	}
+	event := &core.Event{
+		ID: "123",
+		Invitations: []core.Invitation{
+			{
+				ID:      "invitation1",
+				EventID: "123",
+				UserID:  2,
+				Status:  core.InvitationStatus_Unknown,
+				Token:   "123",
+			},
+		},
+	}
+	earlier, later := time.Now().Add(-time.Hour), time.Now().Add(-time.Minute)
+	fullEvent := &core.Event{
+		ID:       "123",
+		Capacity: 1,
+		Invitations: []core.Invitation{
+			{ID: "invitation1", EventID: "123", UserID: 2, Status: core.InvitationStatus_Confirmed},
+			{ID: "invitation2", EventID: "123", UserID: 3, Status: core.InvitationStatus_Waitlisted, WaitlistedAt: &later},
+			{ID: "invitation3", EventID: "123", UserID: 4, Status: core.InvitationStatus_Waitlisted, WaitlistedAt: &earlier},
+			{ID: "invitation4", EventID: "123", UserID: 5, Status: core.InvitationStatus_Unknown},
+		},
+	}
+	// respondTo hands the event to the service the way the repository does once it is locked
+	respondTo := func(event *core.Event, check func(changed []core.Invitation, outbox []core.DomainEvent)) any {
+		return func(_ context.Context, _ string, respond func(event *core.Event) ([]core.Invitation, core.Journal, error)) error {
+			changed, journal, err := respond(event)
+			if err != nil {
+				return err
+			}
+			assert.Equal(t, core.HistoryOperation_RSVP, journal.History.Operation)
+			check(changed, journal.Outbox)
+			return nil
+		}
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().RespondInvitation(gomock.Any(), "123", gomock.Any()).Times(1).
+						DoAndReturn(respondTo(event, func(changed []core.Invitation, outbox []core.DomainEvent) {
+							assert.Len(t, changed, 1)
+							assert.Equal(t, "invitation1", changed[0].ID)
+							assert.Equal(t, core.InvitationStatus_Confirmed, changed[0].Status)
+							assert.NotNil(t, changed[0].UpdatedAt)
+							assert.Len(t, outbox, 1)
+							assert.True(t, domainEventOfType(core.DomainEventType_InvitationResponded).Matches(outbox[0]))
+							assert.Equal(t, "2", outbox[0].ActorID)
+							assert.Equal(t, core.InvitationStatus_Confirmed, outbox[0].Event.FindInvitation(2).Status)
+						}))
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.RespondInvitationRequest{
+					ActorID: "2",
+					EventID: "123",
+					Status:  core.InvitationStatus_Confirmed,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "OK - accepting a full event puts the actor on the waitlist",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().RespondInvitation(gomock.Any(), "123", gomock.Any()).Times(1).
+						DoAndReturn(respondTo(fullEvent, func(changed []core.Invitation, outbox []core.DomainEvent) {
+							assert.Len(t, changed, 1)
+							assert.Equal(t, "invitation4", changed[0].ID)
+							assert.Equal(t, core.InvitationStatus_Waitlisted, changed[0].Status)
+							assert.NotNil(t, changed[0].WaitlistedAt)
+							assert.Len(t, outbox, 1)
+							assert.True(t, domainEventOfType(core.DomainEventType_InvitationResponded).Matches(outbox[0]))
+						}))
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.RespondInvitationRequest{
+					ActorID: "5",
+					EventID: "123",
+					Status:  core.InvitationStatus_Confirmed,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "OK - declining confirms the first one on the waitlist",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().RespondInvitation(gomock.Any(), "123", gomock.Any()).Times(1).
+						DoAndReturn(respondTo(fullEvent, func(changed []core.Invitation, outbox []core.DomainEvent) {
+							assert.Len(t, changed, 2)
+							assert.Equal(t, core.InvitationStatus_Declined, changed[0].Status)
+							assert.Equal(t, "invitation3", changed[1].ID)
+							assert.Equal(t, core.InvitationStatus_Confirmed, changed[1].Status)
+							assert.Nil(t, changed[1].WaitlistedAt)
+							assert.Len(t, outbox, 2)
+							assert.True(t, domainEventOfType(core.DomainEventType_InvitationResponded).Matches(outbox[0]))
+							assert.True(t, domainEventOfType(core.DomainEventType_WaitlistPromoted).Matches(outbox[1]))
+							assert.Equal(t, []string{"4"}, outbox[1].Audience)
+							assert.Equal(t, []core.Invitation{fullEvent.Invitations[1]}, outbox[1].Event.Waitlist())
+						}))
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.RespondInvitationRequest{
+					ActorID: "2",
+					EventID: "123",
+					Status:  core.InvitationStatus_Declined,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Not OK - actor is not invited",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().RespondInvitation(gomock.Any(), "123", gomock.Any()).Times(1).
+						DoAndReturn(respondTo(event, func(_ []core.Invitation, _ []core.DomainEvent) {
+							t.Error("the response of an actor who isn't invited must not be written")
+						}))
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.RespondInvitationRequest{
+					ActorID: "5",
+					EventID: "123",
+					Status:  core.InvitationStatus_Declined,
+				},
+			},
+			wantErr:   true,
+			wantErrIs: core.ErrInvitationNotFound,
+		},
+		{
+			name: "Not OK - error from repo",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().RespondInvitation(gomock.Any(), "123", gomock.Any()).Times(1).
+						Return(internal.ErrInvalidRequest)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.RespondInvitationRequest{
+					ActorID: "2",
+					EventID: "123",
+					Status:  core.InvitationStatus_Declined,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not OK - invalid status",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.RespondInvitationRequest{
+					ActorID: "2",
+					EventID: "123",
+					Status:  core.InvitationStatus_Waitlisted,
+				},
+			},
+			wantErr:   true,
+			wantErrIs: internal.ErrValidationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl), historyRepoMock(ctrl, tt.fields.historyRepoMock), mock.NewMockCalendarRepository(ctrl), mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0))
+			err := e.RespondInvitation(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, core.InvitationStatus_Unknown, event.Invitations[0].Status, "fetched event must not be mutated")
+			assert.Equal(t, core.InvitationStatus_Confirmed, fullEvent.Invitations[0].Status, "fetched event must not be mutated")
+		})
+	}
+}
+
+func TestEventService_ListWaitlist(t *testing.T) {
+	earlier, later := time.Now().Add(-time.Hour), time.Now().Add(-time.Minute)
+	event := &core.Event{
+		ID:       "123",
+		Capacity: 1,
+		Invitations: []core.Invitation{
+			{ID: "invitation1", UserID: 2, Status: core.InvitationStatus_Confirmed},
+			{ID: "invitation2", UserID: 3, Status: core.InvitationStatus_Waitlisted, WaitlistedAt: &later},
+			{ID: "invitation3", UserID: 4, Status: core.InvitationStatus_Waitlisted, WaitlistedAt: &earlier},
+		},
+	}
+	tests := []struct {
+		name          string
+		eventRepoMock func(ctrl *gomock.Controller) core.EventRepository
+		req           *core.ListWaitlistRequest
+		want          []core.Invitation
+		wantErrIs     error
+	}{
+		{
+			name: "OK - the earliest to accept comes first",
+			eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+				repo := mock.NewMockEventRepository(ctrl)
+				repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(event, nil)
+				return repo
+			},
+			req:  &core.ListWaitlistRequest{ActorID: "2", EventID: "123"},
+			want: []core.Invitation{event.Invitations[2], event.Invitations[1]},
+		},
+		{
+			name: "Not OK - event not found",
+			eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+				repo := mock.NewMockEventRepository(ctrl)
+				repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(nil, core.ErrEventNotFound)
+				return repo
+			},
+			req:       &core.ListWaitlistRequest{ActorID: "2", EventID: "123"},
+			wantErrIs: core.ErrEventNotFound,
+		},
+		{
+			name: "Not OK - invalid request",
+			eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+				return mock.NewMockEventRepository(ctrl)
+			},
+			req:       &core.ListWaitlistRequest{},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			e := scheduling.NewService(tt.eventRepoMock(ctrl), mock.NewMockEventHistoryRepository(ctrl), mock.NewMockCalendarRepository(ctrl), mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0))
+			got, err := e.ListWaitlist(context.Background(), tt.req)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEventService_SetReminders(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		req *core.SetRemindersRequest
+	}
+	event := &core.Event{
+		ID: "123",
+		Invitations: []core.Invitation{
+			{
+				ID:      "invitation1",
+				EventID: "123",
+				UserID:  2,
+				Status:  core.InvitationStatus_Confirmed,
+			},
+		},
+	}
+	tests := []struct {
+		name          string
+		eventRepoMock func(ctrl *gomock.Controller) core.EventRepository
+		args          args
+		wantErr       bool
+		wantErrIs     error
+	}{
+		{
+			name: "OK",
+			eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+				repo := mock.NewMockEventRepository(ctrl)
+				repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).
+					Return(event, nil)
+				repo.EXPECT().ReplaceReminders(gomock.Any(), "123", int32(2), []core.Reminder{
+					{ID: "r1", EventID: "123", UserID: 2, OffsetMinutes: 30, Channel: core.ReminderChannel_Push},
+				}).Times(1).Return(nil)
+				return repo
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.SetRemindersRequest{
+					ActorID: "2",
+					EventID: "123",
+					Reminders: []core.Reminder{
+						{ID: "r1", OffsetMinutes: 30, Channel: core.ReminderChannel_Push},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "OK - clearing the reminders",
+			eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+				repo := mock.NewMockEventRepository(ctrl)
+				repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).
+					Return(event, nil)
+				repo.EXPECT().ReplaceReminders(gomock.Any(), "123", int32(2), gomock.Len(0)).Times(1).Return(nil)
+				return repo
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.SetRemindersRequest{
+					ActorID: "2",
+					EventID: "123",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Not OK - actor is not invited",
+			eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+				repo := mock.NewMockEventRepository(ctrl)
+				repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).
+					Return(event, nil)
+				return repo
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.SetRemindersRequest{
+					ActorID: "5",
+					EventID: "123",
+				},
+			},
+			wantErr:   true,
+			wantErrIs: core.ErrInvitationNotFound,
+		},
+		{
+			name: "Not OK - invalid channel",
+			eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+				return mock.NewMockEventRepository(ctrl)
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.SetRemindersRequest{
+					ActorID: "2",
+					EventID: "123",
+					Reminders: []core.Reminder{
+						{ID: "r1", OffsetMinutes: 30, Channel: "SMS"},
+					},
+				},
+			},
+			wantErr:   true,
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - error from repo",
+			eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+				repo := mock.NewMockEventRepository(ctrl)
+				repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).
+					Return(event, nil)
+				repo.EXPECT().ReplaceReminders(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+					Return(internal.ErrInvalidRequest)
+				return repo
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.SetRemindersRequest{
+					ActorID: "2",
+					EventID: "123",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			e := scheduling.NewService(tt.eventRepoMock(ctrl), historyRepoMock(ctrl, nil), mock.NewMockCalendarRepository(ctrl), mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0))
+			err := e.SetReminders(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEventService_ListEventHistory(t *testing.T) {
+	type fields struct {
+		eventRepoMock   func(ctrl *gomock.Controller) core.EventRepository
+		historyRepoMock func(ctrl *gomock.Controller) core.EventHistoryRepository
+	}
+	type args struct {
+		ctx context.Context
+		req *core.ListEventHistoryRequest
+	}
+	entries := []core.EventHistory{
+		{ID: 9, EventID: "123", Operation: core.HistoryOperation_Update},
+		{ID: 7, EventID: "123", Operation: core.HistoryOperation_Update},
+		{ID: 3, EventID: "123", Operation: core.HistoryOperation_Create},
+	}
+	eventRepoMock := func(ctrl *gomock.Controller) core.EventRepository {
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), "123").Times(1).Return(&core.Event{ID: "123", CreatedBy: "1"}, nil)
+		return repo
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *core.EventHistoryPage
+		wantErr bool
+	}{
+		{
+			name: "OK - has next page",
+			fields: fields{
+				eventRepoMock: eventRepoMock,
+				historyRepoMock: func(ctrl *gomock.Controller) core.EventHistoryRepository {
+					repo := mock.NewMockEventHistoryRepository(ctrl)
+					repo.EXPECT().FindByEventID(gomock.Any(), "123", int64(10), 3).Times(1).
+						Return(entries, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.ListEventHistoryRequest{
+					ActorID:  "1",
+					EventID:  "123",
+					PageSize: 2,
+					BeforeID: 10,
+				},
+			},
+			want: &core.EventHistoryPage{
+				Entries:      entries[:2],
+				NextBeforeID: 7,
+			},
+			wantErr: false,
+		},
+		{
+			name: "OK - last page",
+			fields: fields{
+				eventRepoMock: eventRepoMock,
+				historyRepoMock: func(ctrl *gomock.Controller) core.EventHistoryRepository {
+					repo := mock.NewMockEventHistoryRepository(ctrl)
+					repo.EXPECT().FindByEventID(gomock.Any(), "123", int64(0), core.DefaultPageSize+1).Times(1).
+						Return(entries, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.ListEventHistoryRequest{
+					ActorID: "1",
+					EventID: "123",
+				},
+			},
+			want: &core.EventHistoryPage{
+				Entries: entries,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Not OK - error from repo",
+			fields: fields{
+				eventRepoMock: eventRepoMock,
+				historyRepoMock: func(ctrl *gomock.Controller) core.EventHistoryRepository {
+					repo := mock.NewMockEventHistoryRepository(ctrl)
+					repo.EXPECT().FindByEventID(gomock.Any(), "123", int64(0), core.DefaultPageSize+1).Times(1).
+						Return(nil, internal.ErrInvalidRequest)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.ListEventHistoryRequest{
+					ActorID: "1",
+					EventID: "123",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:   "Not OK - invalid page size",
+			fields: fields{},
+			args: args{
+				ctx: context.Background(),
+				req: &core.ListEventHistoryRequest{
+					ActorID:  "1",
+					EventID:  "123",
+					PageSize: core.MaxPageSize + 1,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := core.EventRepository(mock.NewMockEventRepository(ctrl))
+			if tt.fields.eventRepoMock != nil {
+				repo = tt.fields.eventRepoMock(ctrl)
+			}
+
+			e := scheduling.NewService(repo, historyRepoMock(ctrl, tt.fields.historyRepoMock), mock.NewMockCalendarRepository(ctrl), mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0))
+			got, err := e.ListEventHistory(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEventService_ListEvents(t *testing.T) {
+	type fields struct {
+		eventRepoMock func(ctrl *gomock.Controller) core.EventRepository
+	}
+	type args struct {
+		ctx context.Context
+		req *core.ListEventsRequest
+	}
+	now := time.Now()
+	events := []core.Event{
+		{ID: "3", CreatedBy: "1", CreatedAt: now},
+		{ID: "2", CreatedBy: "1", CreatedAt: now},
+		{ID: "1", CreatedBy: "1", CreatedAt: now.Add(-time.Hour)},
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *core.EventsPage
+		wantErr bool
+	}{
+		{
+			name: "OK - has next page",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().List(gomock.Any(), core.EventFilter{
+						CreatedBy: "1",
+						Title:     "standup",
+						Viewer:    "1",
+						After:     &core.EventCursor{CreatedAt: now, ID: "4"},
+						Limit:     3,
+					}).Times(1).Return(events, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.ListEventsRequest{
+					ActorID:   "1",
+					CreatedBy: "1",
+					Title:     "standup",
+					PageSize:  2,
+					After:     &core.EventCursor{CreatedAt: now, ID: "4"},
+				},
+			},
+			want: &core.EventsPage{
+				Events: events[:2],
+				Next:   &core.EventCursor{CreatedAt: now, ID: "2"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "OK - last page",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().List(gomock.Any(), core.EventFilter{Viewer: "1", Limit: core.DefaultPageSize + 1}).Times(1).
+						Return(events, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.ListEventsRequest{ActorID: "1"},
+			},
+			want: &core.EventsPage{
+				Events: events,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Not OK - error from repo",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).
+						Return(nil, internal.ErrInvalidRequest)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.ListEventsRequest{ActorID: "1"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Not OK - invalid timezone",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.ListEventsRequest{
+					ActorID:  "1",
+					Timezone: "invalid",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Not OK - empty created range",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.ListEventsRequest{
+					ActorID:       "1",
+					CreatedAfter:  &now,
+					CreatedBefore: &now,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl), mock.NewMockEventHistoryRepository(ctrl), mock.NewMockCalendarRepository(ctrl), mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0))
+			got, err := e.ListEvents(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEventService_SearchEvents(t *testing.T) {
+	type fields struct {
+		eventRepoMock    func(ctrl *gomock.Controller) core.EventRepository
+		calendarRepoMock func(ctrl *gomock.Controller) core.CalendarRepository
+	}
+	type args struct {
+		ctx context.Context
+		req *core.SearchEventsRequest
+	}
+	events := []core.Event{{ID: "3", CreatedBy: "1"}, {ID: "2", CreatedBy: "1"}, {ID: "1", CreatedBy: "1"}}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *core.SearchEventsPage
+		wantErr bool
+	}{
+		{
+			name: "OK - has next page",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().Search(gomock.Any(), "1", "quarterly planning", 4, 3).Times(1).
+						Return(events, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.SearchEventsRequest{
+					ActorID:  "1",
+					Query:    " quarterly planning ",
+					PageSize: 2,
+					Offset:   4,
+				},
+			},
+			want: &core.SearchEventsPage{
+				Events:     events[:2],
+				NextOffset: 6,
+			},
+			wantErr: false,
+		},
+		{
+			name: "OK - last page",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().Search(gomock.Any(), "1", "planning", 0, core.DefaultPageSize+1).Times(1).
+						Return(events, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.SearchEventsRequest{
+					ActorID: "1",
+					Query:   "planning",
+				},
+			},
+			want: &core.SearchEventsPage{
+				Events: events,
+			},
+			wantErr: false,
+		},
+		{
+			name: "OK - the events of a calendar shared with the actor",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().Search(gomock.Any(), "1", "planning", 0, core.DefaultPageSize+1).Times(1).
+						Return([]core.Event{
+							{ID: "5", Title: "planning", CreatedBy: "2", CalendarID: "cal2"},
+							{ID: "4", Title: "planning review", CreatedBy: "2", CalendarID: "cal2"},
+						}, nil)
+					return repo
+				},
+				calendarRepoMock: func(ctrl *gomock.Controller) core.CalendarRepository {
+					repo := mock.NewMockCalendarRepository(ctrl)
+					repo.EXPECT().FindRole(gomock.Any(), "cal2", "1").Times(1).Return(core.CalendarRole_Read, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.SearchEventsRequest{
+					ActorID: "1",
+					Query:   "planning",
+				},
+			},
+			want: &core.SearchEventsPage{
+				Events: []core.Event{
+					{ID: "5", Title: "planning", CreatedBy: "2", CalendarID: "cal2"},
+					{ID: "4", Title: "planning review", CreatedBy: "2", CalendarID: "cal2"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Not OK - error from repo",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+						Return(nil, internal.ErrInvalidRequest)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.SearchEventsRequest{
+					ActorID: "1",
+					Query:   "planning",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Not OK - empty query",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.SearchEventsRequest{
+					ActorID: "1",
+					Query:   "  ",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Not OK - unauthenticated",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.SearchEventsRequest{
+					Query: "planning",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl), mock.NewMockEventHistoryRepository(ctrl), calendarRepoMock(ctrl, tt.fields.calendarRepoMock), mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0))
+			got, err := e.SearchEvents(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEventService_BatchMutateEvents(t *testing.T) {
+	type fields struct {
+		eventRepoMock   func(ctrl *gomock.Controller) core.EventRepository
+		historyRepoMock func(ctrl *gomock.Controller) core.EventHistoryRepository
+	}
+	type args struct {
+		ctx context.Context
+		req *core.BatchMutateEventsRequest
+	}
+	validEvent := func(id string) *core.Event {
+		return &core.Event{
+			ID:          id,
+			Title:       "test",
+			Description: "test123",
+			Timezone:    "Asia/Jakarta",
+			Schedules: []core.Schedule{
+				{
+					ID:                "schedule-" + id,
+					EventID:           id,
+					StartTime:         time.Now().Unix(),
+					DurationInMinutes: 60,
+					RecurringType:     core.RecurringType_None,
+				},
+			},
+		}
+	}
+	mutations := func() []core.BatchMutation {
+		return []core.BatchMutation{
+			{Create: &core.CreateEventRequest{ActorID: "1", Event: validEvent("new")}},
+			{Delete: &core.DeleteEventByIDRequest{ActorID: "1", EventID: "old"}},
+			{Create: &core.CreateEventRequest{ActorID: "1", Event: &core.Event{ID: "invalid"}}},
+		}
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantErrs []error
+		wantErr  bool
+	}{
+		{
+			name: "OK - best effort applies the valid mutations",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().Store(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+					repo.EXPECT().FindByID(gomock.Any(), "old").Times(1).Return(&core.Event{ID: "old", CreatedBy: "1"}, nil)
+					repo.EXPECT().DeleteByID(gomock.Any(), "old", gomock.Any()).Times(1).Return(nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.BatchMutateEventsRequest{
+					ActorID:   "1",
+					Mutations: mutations(),
+				},
+			},
+			wantErrs: []error{nil, nil, internal.ErrValidationFailed},
+		},
+		{
+			name: "OK - atomic applies every mutation in one batch",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "old").Times(1).Return(&core.Event{ID: "old", CreatedBy: "1"}, nil)
+					repo.EXPECT().Batch(gomock.Any(), gomock.Len(2)).Times(1).
+						DoAndReturn(func(_ context.Context, mutations []core.EventMutation) error {
+							// every mutation carries its history and its domain event into the transaction
+							assert.True(t, journalOf(core.HistoryOperation_Create, core.DomainEventType_EventCreated).Matches(mutations[0].Journal))
+							assert.True(t, journalOf(core.HistoryOperation_Delete, core.DomainEventType_EventDeleted).Matches(mutations[1].Journal))
+							return nil
+						})
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.BatchMutateEventsRequest{
+					ActorID:   "1",
+					Atomic:    true,
+					Mutations: mutations()[:2],
+				},
+			},
+			wantErrs: []error{nil, nil},
+		},
+		{
+			name: "OK - atomic update keeps the guests who booked the event",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "old").Times(1).Return(&core.Event{
+						ID:        "old",
+						CreatedBy: "1",
+						Guests:    []core.Guest{{EventID: "old", Name: "Guest", Email: "guest@example.com"}},
+					}, nil)
+					repo.EXPECT().Batch(gomock.Any(), gomock.Len(1)).Times(1).
+						DoAndReturn(func(_ context.Context, mutations []core.EventMutation) error {
+							assert.Equal(t, []core.Guest{{EventID: "old", Name: "Guest", Email: "guest@example.com"}}, mutations[0].Event.Guests)
+							assert.NotNil(t, mutations[0].Event.UpdatedAt)
+							return nil
+						})
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.BatchMutateEventsRequest{
+					ActorID: "1",
+					Atomic:  true,
+					Mutations: []core.BatchMutation{
+						{Update: &core.UpdateEventRequest{ActorID: "1", Event: validEvent("old")}},
+					},
+				},
+			},
+			wantErrs: []error{nil},
+		},
+		{
+			name: "Not OK - atomic aborts every mutation when one is invalid",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "old").Times(1).Return(&core.Event{ID: "old", CreatedBy: "1"}, nil)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.BatchMutateEventsRequest{
+					ActorID:   "1",
+					Atomic:    true,
+					Mutations: mutations(),
+				},
+			},
+			wantErrs: []error{core.ErrBatchAborted, core.ErrBatchAborted, internal.ErrValidationFailed},
+		},
+		{
+			name: "Not OK - atomic reports the mutation that failed in the repo",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), "old").Times(1).Return(&core.Event{ID: "old", CreatedBy: "1"}, nil)
+					repo.EXPECT().Batch(gomock.Any(), gomock.Any()).Times(1).
+						Return(&core.BatchError{Index: 1, Err: core.ErrEventNotFound})
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.BatchMutateEventsRequest{
+					ActorID:   "1",
+					Atomic:    true,
+					Mutations: mutations()[:2],
+				},
+			},
+			wantErrs: []error{core.ErrBatchAborted, core.ErrEventNotFound},
+		},
+		{
+			name: "Not OK - atomic commit fails",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					repo.EXPECT().Batch(gomock.Any(), gomock.Any()).Times(1).
+						Return(errors.New("commit failed")) //nolint:goerr113
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.BatchMutateEventsRequest{
+					ActorID:   "1",
+					Atomic:    true,
+					Mutations: mutations()[:1],
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not OK - empty batch",
+			fields: fields{
+				eventRepoMock: func(ctrl *gomock.Controller) core.EventRepository {
+					repo := mock.NewMockEventRepository(ctrl)
+					return repo
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &core.BatchMutateEventsRequest{
+					ActorID: "1",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			e := scheduling.NewService(tt.fields.eventRepoMock(ctrl), historyRepoMock(ctrl, tt.fields.historyRepoMock), mock.NewMockCalendarRepository(ctrl), mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0))
+			got, err := e.BatchMutateEvents(tt.args.ctx, tt.args.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got, len(tt.wantErrs))
+			for index, wantErr := range tt.wantErrs {
+				assert.Equal(t, tt.args.req.Mutations[index].EventID(), got[index].EventID)
+				if wantErr == nil {
+					assert.NoError(t, got[index].Err)
+					continue
+				}
+				assert.ErrorIs(t, got[index].Err, wantErr)
+			}
+		})
+	}
+}
+
+func historyRepoMock(ctrl *gomock.Controller, fn func(ctrl *gomock.Controller) core.EventHistoryRepository) core.EventHistoryRepository {
+	if fn == nil {
+		return mock.NewMockEventHistoryRepository(ctrl)
+	}
+	return fn(ctrl)
+}
+
+func calendarRepoMock(ctrl *gomock.Controller, fn func(ctrl *gomock.Controller) core.CalendarRepository) core.CalendarRepository {
+	if fn == nil {
+		return mock.NewMockCalendarRepository(ctrl)
+	}
+	return fn(ctrl)
+}
+
+func groupRepoMock(ctrl *gomock.Controller, fn func(ctrl *gomock.Controller) core.GroupRepository) core.GroupRepository {
+	if fn == nil {
+		return mock.NewMockGroupRepository(ctrl)
+	}
+	return fn(ctrl)
+}
+
+type journalMatcher struct {
+	op  core.HistoryOperation
+	typ core.DomainEventType
+}
+
+// journalOf matches a journal whose history has the operation and whose first domain event has the type.
+func journalOf(op core.HistoryOperation, typ core.DomainEventType) gomock.Matcher {
+	return journalMatcher{op: op, typ: typ}
+}
+
+func (m journalMatcher) Matches(x interface{}) bool {
+	j, ok := x.(core.Journal)
+	return ok && j.History != nil && j.History.Operation == m.op &&
+		len(j.Outbox) > 0 && domainEventOfType(m.typ).Matches(j.Outbox[0])
+}
+
+func (m journalMatcher) String() string {
+	return fmt.Sprintf("is journal with operation %v and domain event of type %v", m.op, m.typ)
+}
+
+type domainEventTypeMatcher core.DomainEventType
+
+func domainEventOfType(typ core.DomainEventType) gomock.Matcher {
+	return domainEventTypeMatcher(typ)
+}
+
+func (m domainEventTypeMatcher) Matches(x interface{}) bool {
+	e, ok := x.(core.DomainEvent)
+	return ok && e.Type == core.DomainEventType(m) && e.ID != ""
+}
+
+func (m domainEventTypeMatcher) String() string {
+	return fmt.Sprintf("is domain event of type %v", core.DomainEventType(m))
+}
+
+func TestEventService_WatchEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockEventRepository(ctrl)
+	repo.EXPECT().Store(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+	svc := scheduling.NewService(repo, mock.NewMockEventHistoryRepository(ctrl), mock.NewMockCalendarRepository(ctrl), mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0))
+
+	_, err := svc.WatchEvents(context.Background(), &core.WatchEventsRequest{})
+	assert.Error(t, err, "the actor is required")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes, err := svc.WatchEvents(ctx, &core.WatchEventsRequest{ActorID: "2"})
+	assert.NoError(t, err)
+
+	newEvent := func(createdBy string, attendees ...int32) *core.Event {
+		event := core.NewEvent(createdBy)
+		event.Title = "test"
+		event.Description = "test123"
+		event.Timezone = "Asia/Jakarta"
+		event.Schedules = []core.Schedule{
+			{
+				ID:                "test123",
+				EventID:           event.ID,
+				StartTime:         time.Now().Unix(),
+				DurationInMinutes: 60,
+				RecurringType:     core.RecurringType_None,
+			},
+		}
+		for _, attendee := range attendees {
+			event.Invitations = append(event.Invitations, core.NewInvitation(event.ID, attendee))
+		}
+		return event
+	}
+
+	unrelated := newEvent("1", 3)
+	assert.NoError(t, svc.CreateEvent(context.Background(), &core.CreateEventRequest{ActorID: "1", Event: unrelated}))
+	invited := newEvent("1", 2)
+	assert.NoError(t, svc.CreateEvent(context.Background(), &core.CreateEventRequest{ActorID: "1", Event: invited}))
+
+	select {
+	case change := <-changes:
+		assert.Equal(t, invited.ID, change.EventID)
+		assert.Equal(t, core.HistoryOperation_Create, change.Operation)
+		assert.Equal(t, "1", change.ActorID)
+		assert.Equal(t, uint64(2), change.Cursor.Sequence)
+	case <-time.After(time.Second):
+		t.Fatal("the change of the event the actor is invited to was not received")
+	}
+}
+
+func TestEventService_DelegatedAccess(t *testing.T) {
+	newEvent := func(createdBy string) *core.Event {
+		event := core.NewEvent(createdBy)
+		event.Title = "test"
+		event.Description = "test123"
+		event.Timezone = "Asia/Jakarta"
+		event.CalendarID = "cal1"
+		event.Schedules = []core.Schedule{
+			{
+				ID:                "test123",
+				EventID:           event.ID,
+				StartTime:         time.Now().Unix(),
+				DurationInMinutes: 60,
+				RecurringType:     core.RecurringType_None,
+			},
+		}
+		return event
+	}
+
+	t.Run("OK - a delegate with write access updates on behalf of the owner", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		event := newEvent("1")
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), event.ID).Return(event, nil)
+		repo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *core.Event, journal core.Journal) error {
+				assert.Equal(t, "2", journal.History.ActorID)
+				assert.Equal(t, "1", journal.History.OnBehalfOf)
+				return nil
+			})
+		calendars := mock.NewMockCalendarRepository(ctrl)
+		calendars.EXPECT().FindRole(gomock.Any(), "cal1", "2").Return(core.CalendarRole_Write, nil)
+
+		updated := newEvent("1")
+		updated.ID = event.ID
+		updated.Title = "updated"
+		err := scheduling.NewService(repo, mock.NewMockEventHistoryRepository(ctrl), calendars, mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0)).UpdateEvent(context.Background(), &core.UpdateEventRequest{
+			ID:      event.ID,
+			ActorID: "2",
+			Event:   updated,
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Not OK - a delegate with read access can't delete", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		event := newEvent("1")
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), event.ID).Return(event, nil)
+		calendars := mock.NewMockCalendarRepository(ctrl)
+		calendars.EXPECT().FindRole(gomock.Any(), "cal1", "2").Return(core.CalendarRole_Read, nil)
+
+		err := scheduling.NewService(repo, mock.NewMockEventHistoryRepository(ctrl), calendars, mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0)).DeleteEventByID(context.Background(), &core.DeleteEventByIDRequest{
+			ActorID: "2",
+			EventID: event.ID,
+		})
+		assert.ErrorIs(t, err, core.ErrPermissionDenied)
+	})
+
+	t.Run("OK - a delegate creates the event for the owner of the calendar", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		calendar := core.NewCalendar("1")
+		calendar.ID = "cal1"
+		calendars := mock.NewMockCalendarRepository(ctrl)
+		calendars.EXPECT().FindByID(gomock.Any(), "cal1").Return(calendar, nil)
+		calendars.EXPECT().FindRole(gomock.Any(), "cal1", "2").Return(core.CalendarRole_Manage, nil)
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().Store(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, e *core.Event, journal core.Journal) error {
+				assert.Equal(t, "1", e.CreatedBy)
+				assert.Equal(t, "2", journal.History.ActorID)
+				assert.Equal(t, "1", journal.History.OnBehalfOf)
+				return nil
+			})
+
+		err := scheduling.NewService(repo, mock.NewMockEventHistoryRepository(ctrl), calendars, mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0)).CreateEvent(context.Background(), &core.CreateEventRequest{
+			ActorID: "2",
+			Event:   newEvent("2"),
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("OK - free/busy access hides the details of the events", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		event := newEvent("1")
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().List(gomock.Any(), gomock.Any()).Return([]core.Event{*event}, nil)
+		calendars := mock.NewMockCalendarRepository(ctrl)
+		calendars.EXPECT().FindRole(gomock.Any(), "cal1", "2").Return(core.CalendarRole_FreeBusy, nil)
+
+		page, err := scheduling.NewService(repo, mock.NewMockEventHistoryRepository(ctrl), calendars, mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0)).ListEvents(context.Background(), &core.ListEventsRequest{
+			ActorID:    "2",
+			CalendarID: "cal1",
+			PageSize:   10,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, page.Events, 1)
+		assert.Empty(t, page.Events[0].Title)
+		assert.Empty(t, page.Events[0].Description)
+		assert.Equal(t, event.Schedules, page.Events[0].Schedules)
+	})
+
+	t.Run("OK - the events of each calendar are listed as its role allows", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		busy, shared, invited := newEvent("1"), newEvent("3"), newEvent("1")
+		shared.CalendarID = "cal2"
+		invited.Invitations = []core.Invitation{core.NewInvitation(invited.ID, 2)}
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().List(gomock.Any(), core.EventFilter{Viewer: "2", Limit: 11}).
+			Return([]core.Event{*busy, *shared, *invited}, nil)
+		calendars := mock.NewMockCalendarRepository(ctrl)
+		calendars.EXPECT().FindRole(gomock.Any(), "cal1", "2").Times(1).Return(core.CalendarRole_FreeBusy, nil)
+		calendars.EXPECT().FindRole(gomock.Any(), "cal2", "2").Times(1).Return(core.CalendarRole_Read, nil)
+
+		page, err := scheduling.NewService(repo, mock.NewMockEventHistoryRepository(ctrl), calendars, mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0)).ListEvents(context.Background(), &core.ListEventsRequest{
+			ActorID:  "2",
+			PageSize: 10,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []core.Event{busy.FreeBusy(), *shared, *invited}, page.Events)
+	})
+
+	t.Run("OK - free/busy access finds the event without its details", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		event := newEvent("1")
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), event.ID).Return(event, nil)
+		calendars := mock.NewMockCalendarRepository(ctrl)
+		calendars.EXPECT().FindRole(gomock.Any(), "cal1", "2").Return(core.CalendarRole_FreeBusy, nil)
+
+		got, err := scheduling.NewService(repo, mock.NewMockEventHistoryRepository(ctrl), calendars, mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0)).FindEventByID(context.Background(), &core.FindEventByIDRequest{
+			ActorID: "2",
+			EventID: event.ID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, event.FreeBusy(), *got)
+	})
+
+	t.Run("Not OK - the event isn't found by whom it isn't shared with", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		event := newEvent("1")
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), event.ID).Return(event, nil)
+		calendars := mock.NewMockCalendarRepository(ctrl)
+		calendars.EXPECT().FindRole(gomock.Any(), "cal1", "2").Return(core.CalendarRole(""), nil)
+
+		_, err := scheduling.NewService(repo, mock.NewMockEventHistoryRepository(ctrl), calendars, mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0)).ListWaitlist(context.Background(), &core.ListWaitlistRequest{
+			ActorID: "2",
+			EventID: event.ID,
+		})
+		assert.ErrorIs(t, err, core.ErrEventNotFound)
+	})
+
+	t.Run("Not OK - free/busy access can't read the history", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		event := newEvent("1")
+		repo := mock.NewMockEventRepository(ctrl)
+		repo.EXPECT().FindByID(gomock.Any(), event.ID).Return(event, nil)
+		calendars := mock.NewMockCalendarRepository(ctrl)
+		calendars.EXPECT().FindRole(gomock.Any(), "cal1", "2").Return(core.CalendarRole_FreeBusy, nil)
+
+		_, err := scheduling.NewService(repo, mock.NewMockEventHistoryRepository(ctrl), calendars, mock.NewMockGroupRepository(ctrl), changefeed.NewBroker(0)).ListEventHistory(context.Background(), &core.ListEventHistoryRequest{
+			ActorID: "2",
+			EventID: event.ID,
+		})
+		assert.ErrorIs(t, err, core.ErrPermissionDenied)
+	})
+}
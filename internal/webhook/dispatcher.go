@@ -0,0 +1,252 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+const (
+	defaultDispatchInterval = 5 * time.Second
+	defaultMaxAttempts      = 8
+	defaultRetryBackoff     = 30 * time.Second
+	maxRetryBackoff         = time.Hour
+
+	// deliveryTimeout bounds an attempt, deliveryLease must outlast it: a delivery claimed by a
+	// dispatcher that went away is claimed again once its lease expires
+	deliveryTimeout = 10 * time.Second
+	deliveryLease   = time.Minute
+	claimBatchSize  = 50
+)
+
+var errInternalAddress = errors.New("the webhook url resolves to a loopback, link-local or private address")
+
+// Dispatcher delivers the event changes to the webhooks subscribing to them. It is a publisher of
+// the outbox relay: the changes are logged as pending deliveries while their domain events are
+// claimed from the outbox, and then sent by polling the log, so that failed deliveries are retried
+// with an exponential backoff until they succeed or run out of attempts.
+type Dispatcher struct {
+	repo        core.WebhookRepository
+	client      *http.Client
+	interval    time.Duration
+	maxAttempts int
+	backoff     time.Duration
+}
+
+func NewDispatcher(repo core.WebhookRepository, client *http.Client, interval time.Duration, maxAttempts int, backoff time.Duration) *Dispatcher {
+	if client == nil {
+		client = newDeliveryClient()
+	}
+
+	if interval <= 0 {
+		interval = defaultDispatchInterval
+	}
+
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+
+	return &Dispatcher{
+		repo:        repo,
+		client:      client,
+		interval:    interval,
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+	}
+}
+
+// Run delivers the due deliveries on every interval, until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		delivered, err := d.DeliverDue(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Error(err.Error())
+		} else if delivered > 0 {
+			slog.Info("attempted webhook deliveries", "count", delivered)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Publish logs a pending delivery of the domain event for every webhook of the users involved in
+// it that subscribes to its operation. The deliveries are logged once per domain event, so that
+// relaying it again doesn't deliver it twice.
+func (d *Dispatcher) Publish(ctx context.Context, event core.DomainEvent) error {
+	operation := event.Type.Operation()
+	if operation == "" || len(event.Audience) == 0 {
+		return nil
+	}
+
+	p, err := newPayload(event)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.repo.EnqueueDeliveries(ctx, &core.WebhookDelivery{
+		EventID:   event.EventID,
+		EventType: operation,
+		Payload:   payload,
+		CreatedAt: event.OccurredAt,
+		OutboxID:  event.ID,
+	}, event.Audience)
+	return err
+}
+
+// DeliverDue attempts the deliveries that are due, and returns how many were attempted.
+func (d *Dispatcher) DeliverDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	deliveries, err := d.repo.ClaimDueDeliveries(ctx, now, now.Add(deliveryLease), claimBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for index := range deliveries {
+		wg.Add(1)
+		go func(delivery *core.WebhookDelivery) {
+			defer wg.Done()
+			if err := d.Deliver(ctx, delivery); err != nil && ctx.Err() == nil {
+				slog.Error("failed to deliver webhook", "delivery_id", delivery.ID, "error", err.Error())
+			}
+		}(&deliveries[index])
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+// Deliver makes an attempt of the delivery and logs its outcome. A failed attempt is retried
+// after a backoff that doubles on every attempt, the delivery fails once it runs out of attempts.
+func (d *Dispatcher) Deliver(ctx context.Context, delivery *core.WebhookDelivery) error {
+	webhook, err := d.repo.FindByID(ctx, delivery.WebhookID)
+	if errors.Is(err, core.ErrWebhookNotFound) {
+		// the webhook was deleted after the delivery was claimed, its deliveries are gone with it
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	responseStatus, err := d.send(ctx, webhook, delivery)
+	if ctx.Err() != nil {
+		// the dispatcher is stopping, the delivery is claimed again once its lease expires
+		return ctx.Err()
+	}
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.ResponseStatus = responseStatus
+	switch {
+	case err == nil:
+		delivery.Status = core.WebhookDeliveryStatus_Succeeded
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= d.maxAttempts:
+		delivery.Status = core.WebhookDeliveryStatus_Failed
+		delivery.LastError = err.Error()
+	default:
+		delivery.Status = core.WebhookDeliveryStatus_Pending
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = now.Add(d.retryBackoff(delivery.Attempts))
+	}
+
+	return d.repo.UpdateDelivery(ctx, delivery)
+}
+
+// send posts the payload to the webhook and returns the status of the response, any status
+// other than 2xx is a failure.
+func (d *Dispatcher) send(ctx context.Context, webhook *core.Webhook, delivery *core.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderID, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	// drain the body, so that the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("unexpected response status %d", res.StatusCode)
+	}
+	return res.StatusCode, nil
+}
+
+// newDeliveryClient returns a client that checks the address a webhook url resolved to right before
+// connecting, so that a name registered as public can't be pointed at an internal address later on.
+// Redirects go through the same check.
+func newDeliveryClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: deliveryTimeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if core.IsInternalAddr(addrPort.Addr()) {
+				return errInternalAddress
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: deliveryTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: deliveryTimeout,
+		},
+	}
+}
+
+func (d *Dispatcher) retryBackoff(attempts int) time.Duration {
+	backoff := d.backoff
+	for i := 1; i < attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxRetryBackoff)
+}
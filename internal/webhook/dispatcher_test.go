@@ -0,0 +1,295 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/mock"
+	"github.com/dzakaammar/event-scheduling-example/internal/webhook"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type receivedDelivery struct {
+	header http.Header
+	body   []byte
+}
+
+// newReceiver starts a webhook receiver that answers with the given statuses in turn, and
+// records the deliveries it receives.
+func newReceiver(t *testing.T, statuses ...int) (*httptest.Server, <-chan receivedDelivery) {
+	t.Helper()
+
+	received := make(chan receivedDelivery, len(statuses))
+	attempt := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- receivedDelivery{header: r.Header.Clone(), body: body}
+
+		w.WriteHeader(statuses[attempt])
+		attempt++
+	}))
+	t.Cleanup(srv.Close)
+	return srv, received
+}
+
+func newDelivery(webhookID string, attempts int) *core.WebhookDelivery {
+	return &core.WebhookDelivery{
+		ID:        42,
+		WebhookID: webhookID,
+		EventID:   "123",
+		EventType: core.HistoryOperation_Create,
+		Payload:   []byte(`{"event_id":"123","type":"CREATE"}`),
+		Status:    core.WebhookDeliveryStatus_Pending,
+		Attempts:  attempts,
+		CreatedAt: time.Now(),
+	}
+}
+
+func TestDispatcher_Deliver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	srv, received := newReceiver(t, http.StatusNoContent)
+	hook := newTestWebhook("1")
+	hook.URL = srv.URL
+
+	repo := mock.NewMockWebhookRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), hook.ID).Return(hook, nil)
+	repo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, d *core.WebhookDelivery) error {
+			assert.Equal(t, core.WebhookDeliveryStatus_Succeeded, d.Status)
+			assert.Equal(t, 1, d.Attempts)
+			assert.Equal(t, http.StatusNoContent, d.ResponseStatus)
+			assert.Empty(t, d.LastError)
+			assert.NotNil(t, d.DeliveredAt)
+			return nil
+		})
+
+	delivery := newDelivery(hook.ID, 0)
+	err := webhook.NewDispatcher(repo, srv.Client(), time.Hour, 3, time.Minute).Deliver(context.Background(), delivery)
+	require.NoError(t, err)
+
+	got := <-received
+	assert.Equal(t, delivery.Payload, got.body)
+	assert.Equal(t, "42", got.header.Get(webhook.HeaderID))
+	assert.Equal(t, "CREATE", got.header.Get(webhook.HeaderEvent))
+	assert.Equal(t, "application/json", got.header.Get("Content-Type"))
+	assert.NoError(t, webhook.Verify(hook.Secret, got.header, got.body, time.Minute))
+	assert.ErrorIs(t, webhook.Verify("another secret", got.header, got.body, time.Minute), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.Verify(hook.Secret, got.header, []byte(`{}`), time.Minute), webhook.ErrInvalidSignature)
+}
+
+func TestDispatcher_Deliver_Retry(t *testing.T) {
+	tests := []struct {
+		name          string
+		attempts      int
+		wantStatus    core.WebhookDeliveryStatus
+		wantNextAfter time.Duration
+	}{
+		{
+			name:          "first failure is retried after the backoff",
+			attempts:      0,
+			wantStatus:    core.WebhookDeliveryStatus_Pending,
+			wantNextAfter: time.Minute,
+		},
+		{
+			name:          "backoff doubles on every attempt",
+			attempts:      2,
+			wantStatus:    core.WebhookDeliveryStatus_Pending,
+			wantNextAfter: 4 * time.Minute,
+		},
+		{
+			name:       "last attempt fails the delivery",
+			attempts:   4,
+			wantStatus: core.WebhookDeliveryStatus_Failed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			srv, received := newReceiver(t, http.StatusServiceUnavailable)
+			hook := newTestWebhook("1")
+			hook.URL = srv.URL
+
+			repo := mock.NewMockWebhookRepository(ctrl)
+			repo.EXPECT().FindByID(gomock.Any(), hook.ID).Return(hook, nil)
+			repo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, d *core.WebhookDelivery) error {
+					assert.Equal(t, tt.wantStatus, d.Status)
+					assert.Equal(t, tt.attempts+1, d.Attempts)
+					assert.Equal(t, http.StatusServiceUnavailable, d.ResponseStatus)
+					assert.NotEmpty(t, d.LastError)
+					assert.Nil(t, d.DeliveredAt)
+					if tt.wantNextAfter > 0 {
+						assert.WithinDuration(t, time.Now().Add(tt.wantNextAfter), d.NextAttemptAt, time.Second)
+					}
+					return nil
+				})
+
+			dispatcher := webhook.NewDispatcher(repo, srv.Client(), time.Hour, 5, time.Minute)
+			err := dispatcher.Deliver(context.Background(), newDelivery(hook.ID, tt.attempts))
+			require.NoError(t, err)
+			<-received
+		})
+	}
+}
+
+func TestDispatcher_Deliver_Unreachable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	srv := httptest.NewServer(http.NotFoundHandler())
+	hook := newTestWebhook("1")
+	hook.URL = srv.URL
+	srv.Close()
+
+	repo := mock.NewMockWebhookRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), hook.ID).Return(hook, nil)
+	repo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, d *core.WebhookDelivery) error {
+			assert.Equal(t, core.WebhookDeliveryStatus_Pending, d.Status)
+			assert.Equal(t, 0, d.ResponseStatus)
+			assert.NotEmpty(t, d.LastError)
+			return nil
+		})
+
+	err := webhook.NewDispatcher(repo, nil, time.Hour, 3, time.Minute).Deliver(context.Background(), newDelivery(hook.ID, 0))
+	assert.NoError(t, err)
+}
+
+func TestDispatcher_Deliver_InternalAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the receiver listens on a loopback address, which the default client refuses to dial
+	srv, received := newReceiver(t, http.StatusNoContent)
+	hook := newTestWebhook("1")
+	hook.URL = srv.URL
+
+	repo := mock.NewMockWebhookRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), hook.ID).Return(hook, nil)
+	repo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, d *core.WebhookDelivery) error {
+			assert.Equal(t, core.WebhookDeliveryStatus_Pending, d.Status)
+			assert.Equal(t, 0, d.ResponseStatus)
+			assert.Contains(t, d.LastError, "private address")
+			return nil
+		})
+
+	err := webhook.NewDispatcher(repo, nil, time.Hour, 3, time.Minute).Deliver(context.Background(), newDelivery(hook.ID, 0))
+	assert.NoError(t, err)
+	assert.Empty(t, received)
+}
+
+func TestDispatcher_Deliver_DeletedWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockWebhookRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), "gone").Return(nil, core.ErrWebhookNotFound)
+	repo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).Times(0)
+
+	err := webhook.NewDispatcher(repo, nil, time.Hour, 3, time.Minute).Deliver(context.Background(), newDelivery("gone", 0))
+	assert.NoError(t, err)
+}
+
+func TestDispatcher_Publish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	event := &core.Event{
+		ID:          "123",
+		Title:       "Standup",
+		Description: "Daily standup",
+		Timezone:    "Asia/Jakarta",
+		CreatedBy:   "1",
+		CreatedAt:   time.Now(),
+		Schedules: []core.Schedule{
+			{ID: "s1", EventID: "123", StartTime: 1700000000, DurationInMinutes: 30, RecurringType: core.RecurringType_Every_Week},
+		},
+		Invitations: []core.Invitation{{EventID: "123", UserID: 2}},
+	}
+	domainEvent := core.NewDomainEvent(core.NewEventChange(event.ID, "1", core.HistoryOperation_Create, nil, event))
+
+	repo := mock.NewMockWebhookRepository(ctrl)
+	repo.EXPECT().EnqueueDeliveries(gomock.Any(), gomock.Any(), []string{"1", "2"}).
+		DoAndReturn(func(_ context.Context, d *core.WebhookDelivery, _ []string) (int64, error) {
+			assert.Equal(t, "123", d.EventID)
+			assert.Equal(t, core.HistoryOperation_Create, d.EventType)
+			assert.Equal(t, domainEvent.ID, d.OutboxID)
+
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal(d.Payload, &payload))
+			assert.Equal(t, "CREATE", payload["type"])
+			assert.Equal(t, "1", payload["actor_id"])
+
+			e := payload["event"].(map[string]any)
+			assert.Equal(t, "Standup", e["title"])
+			assert.Equal(t, []any{float64(2)}, e["attendees"])
+			schedule := e["schedule"].([]any)[0].(map[string]any)
+			assert.Equal(t, "EVERY_WEEK", schedule["recurring_type"])
+			assert.Equal(t, "2023-11-14T22:13:20Z", schedule["start_time"])
+			assert.Equal(t, "2023-11-14T22:43:20Z", schedule["end_time"])
+			return 2, nil
+		})
+
+	err := webhook.NewDispatcher(repo, nil, time.Hour, 3, time.Minute).Publish(context.Background(), domainEvent)
+	assert.NoError(t, err)
+}
+
+func TestDispatcher_Publish_NotAChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	event := &core.Event{ID: "123", CreatedBy: "1"}
+	change := core.NewEventChange(event.ID, "1", core.HistoryOperation_RSVP, event, event)
+
+	repo := mock.NewMockWebhookRepository(ctrl)
+	repo.EXPECT().EnqueueDeliveries(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	err := webhook.NewDispatcher(repo, nil, time.Hour, 3, time.Minute).Publish(context.Background(), core.NewWaitlistPromotedDomainEvent(change, 2))
+	assert.NoError(t, err)
+}
+
+func TestDispatcher_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	claimed := make(chan struct{}, 1)
+
+	repo := mock.NewMockWebhookRepository(ctrl)
+	repo.EXPECT().ClaimDueDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).MinTimes(1).
+		DoAndReturn(func(context.Context, time.Time, time.Time, int) ([]core.WebhookDelivery, error) {
+			select {
+			case claimed <- struct{}{}:
+			default:
+			}
+			return nil, nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		webhook.NewDispatcher(repo, nil, 10*time.Millisecond, 3, time.Minute).Run(ctx)
+	}()
+
+	select {
+	case <-claimed:
+	case <-time.After(time.Second):
+		t.Fatal("the due deliveries were not claimed")
+	}
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Instrumentation struct {
+	next   core.WebhookService
+	tracer trace.Tracer
+}
+
+func NewInstrumentation(next core.WebhookService) *Instrumentation {
+	return &Instrumentation{
+		next:   next,
+		tracer: otel.Tracer("webhook-service"),
+	}
+}
+
+func (i *Instrumentation) CreateWebhook(ctx context.Context, req *core.CreateWebhookRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "create-webhook")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.CreateWebhook(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) UpdateWebhook(ctx context.Context, req *core.UpdateWebhookRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "update-webhook")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.UpdateWebhook(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) DeleteWebhook(ctx context.Context, req *core.DeleteWebhookRequest) error {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "delete-webhook")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	err = i.next.DeleteWebhook(ctx, req)
+	return err
+}
+
+func (i *Instrumentation) FindWebhookByID(ctx context.Context, req *core.FindWebhookByIDRequest) (*core.Webhook, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "find-webhook-by-id")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	webhook, err := i.next.FindWebhookByID(ctx, req)
+	return webhook, err
+}
+
+func (i *Instrumentation) ListWebhooks(ctx context.Context, req *core.ListWebhooksRequest) ([]core.Webhook, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "list-webhooks")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	webhooks, err := i.next.ListWebhooks(ctx, req)
+	return webhooks, err
+}
+
+func (i *Instrumentation) ListWebhookDeliveries(ctx context.Context, req *core.ListWebhookDeliveriesRequest) (*core.WebhookDeliveryPage, error) {
+	var err error
+	ctx, span := i.tracer.Start(ctx, "list-webhook-deliveries")
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	page, err := i.next.ListWebhookDeliveries(ctx, req)
+	return page, err
+}
@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+// payload is the body of a delivery. Its fields follow the JSON names of the REST API.
+type payload struct {
+	EventID    string                `json:"event_id"`
+	Type       core.HistoryOperation `json:"type"`
+	ActorID    string                `json:"actor_id"`
+	OccurredAt time.Time             `json:"occurred_at"`
+	// Event is the event after the change, or before it when it was deleted
+	Event *eventPayload `json:"event,omitempty"`
+}
+
+type eventPayload struct {
+	ID            string            `json:"id"`
+	Title         string            `json:"title"`
+	Description   string            `json:"description"`
+	Timezone      string            `json:"timezone"`
+	CreatedBy     string            `json:"created_by"`
+	CreatedAt     time.Time         `json:"created_at"`
+	LastUpdatedAt *time.Time        `json:"last_updated_at,omitempty"`
+	DeletedAt     *time.Time        `json:"deleted_at,omitempty"`
+	Schedule      []schedulePayload `json:"schedule"`
+	Attendees     []int32           `json:"attendees"`
+}
+
+type schedulePayload struct {
+	ID            string    `json:"id"`
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+	IsFullDay     bool      `json:"is_full_day"`
+	RecurringType string    `json:"recurring_type"`
+}
+
+func newPayload(event core.DomainEvent) (*payload, error) {
+	p := &payload{
+		EventID:    event.EventID,
+		Type:       event.Type.Operation(),
+		ActorID:    event.ActorID,
+		OccurredAt: event.OccurredAt,
+	}
+
+	e := event.Event
+	if e == nil {
+		return p, nil
+	}
+
+	schedules := make([]schedulePayload, 0, len(e.Schedules))
+	for _, s := range e.Schedules {
+		st, err := s.StartTimeIn(e.Timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		schedules = append(schedules, schedulePayload{
+			ID:            s.ID,
+			StartTime:     st,
+			EndTime:       s.EndTimeFrom(st),
+			IsFullDay:     s.IsFullDay,
+			RecurringType: recurringTypeName(s.RecurringType),
+		})
+	}
+
+	attendees := make([]int32, len(e.Invitations))
+	for index, i := range e.Invitations {
+		attendees[index] = i.UserID
+	}
+
+	p.Event = &eventPayload{
+		ID:            e.ID,
+		Title:         e.Title,
+		Description:   e.Description,
+		Timezone:      e.Timezone,
+		CreatedBy:     e.CreatedBy,
+		CreatedAt:     e.CreatedAt,
+		LastUpdatedAt: e.UpdatedAt,
+		DeletedAt:     e.DeletedAt,
+		Schedule:      schedules,
+		Attendees:     attendees,
+	}
+	return p, nil
+}
+
+// recurringTypeName returns the name of the recurring type in the API.
+func recurringTypeName(rt core.RecurringType) string {
+	switch rt {
+	case core.RecurringType_Daily:
+		return "DAILY"
+	case core.RecurringType_Every_Week:
+		return "EVERY_WEEK"
+	default:
+		return "NONE"
+	}
+}
@@ -0,0 +1,126 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+)
+
+type Service struct {
+	repo core.WebhookRepository
+}
+
+func NewService(repo core.WebhookRepository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+func (s *Service) CreateWebhook(ctx context.Context, req *core.CreateWebhookRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	req.Webhook.OwnerID = req.ActorID
+	return s.repo.Store(ctx, req.Webhook)
+}
+
+func (s *Service) UpdateWebhook(ctx context.Context, req *core.UpdateWebhookRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	webhook, err := s.findOwned(ctx, req.ActorID, req.Webhook.ID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	webhook.URL = req.Webhook.URL
+	webhook.EventTypes = req.Webhook.EventTypes
+	if req.Webhook.Secret != "" {
+		webhook.Secret = req.Webhook.Secret
+	}
+	webhook.UpdatedAt = &now
+
+	err = webhook.Validate()
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Update(ctx, webhook)
+}
+
+func (s *Service) DeleteWebhook(ctx context.Context, req *core.DeleteWebhookRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.findOwned(ctx, req.ActorID, req.WebhookID)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.DeleteByID(ctx, req.WebhookID)
+}
+
+func (s *Service) FindWebhookByID(ctx context.Context, req *core.FindWebhookByIDRequest) (*core.Webhook, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.findOwned(ctx, req.ActorID, req.WebhookID)
+}
+
+func (s *Service) ListWebhooks(ctx context.Context, req *core.ListWebhooksRequest) ([]core.Webhook, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByOwner(ctx, req.ActorID)
+}
+
+func (s *Service) ListWebhookDeliveries(ctx context.Context, req *core.ListWebhookDeliveriesRequest) (*core.WebhookDeliveryPage, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.findOwned(ctx, req.ActorID, req.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	// fetch one more delivery than requested to know whether there is a next page
+	deliveries, err := s.repo.FindDeliveries(ctx, req.WebhookID, req.BeforeID, req.PageSize+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &core.WebhookDeliveryPage{Deliveries: deliveries}
+	if len(deliveries) > req.PageSize {
+		page.Deliveries = deliveries[:req.PageSize]
+		page.NextBeforeID = page.Deliveries[req.PageSize-1].ID
+	}
+	return page, nil
+}
+
+// findOwned returns the webhook only to its owner, it is reported as not found to anyone else
+// so that the IDs of the webhooks of other users can't be probed.
+func (s *Service) findOwned(ctx context.Context, actorID string, id string) (*core.Webhook, error) {
+	webhook, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook.OwnerID != actorID {
+		return nil, core.ErrWebhookNotFound
+	}
+	return webhook, nil
+}
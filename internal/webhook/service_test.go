@@ -0,0 +1,417 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dzakaammar/event-scheduling-example/internal"
+	"github.com/dzakaammar/event-scheduling-example/internal/core"
+	"github.com/dzakaammar/event-scheduling-example/internal/mock"
+	"github.com/dzakaammar/event-scheduling-example/internal/webhook"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestWebhook(ownerID string) *core.Webhook {
+	w := core.NewWebhook(ownerID)
+	w.URL = "https://rooms.example.com/hooks/events"
+	w.Secret = "0123456789abcdef"
+	w.EventTypes = []core.HistoryOperation{core.HistoryOperation_Create, core.HistoryOperation_Delete}
+	return w
+}
+
+func TestService_CreateWebhook(t *testing.T) {
+	type fields struct {
+		repoMock func(ctrl *gomock.Controller) core.WebhookRepository
+	}
+	type args struct {
+		req *core.CreateWebhookRequest
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.WebhookRepository {
+					repo := mock.NewMockWebhookRepository(ctrl)
+					repo.EXPECT().Store(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, w *core.Webhook) error {
+							assert.Equal(t, "1", w.OwnerID)
+							return nil
+						})
+					return repo
+				},
+			},
+			args: args{
+				req: &core.CreateWebhookRequest{ActorID: "1", Webhook: newTestWebhook("")},
+			},
+		},
+		{
+			name: "Not OK - not an http url",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.WebhookRepository {
+					return mock.NewMockWebhookRepository(ctrl)
+				},
+			},
+			args: args{
+				req: &core.CreateWebhookRequest{ActorID: "1", Webhook: func() *core.Webhook {
+					w := newTestWebhook("1")
+					w.URL = "ftp://rooms.example.com/hooks"
+					return w
+				}()},
+			},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - loopback address",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.WebhookRepository {
+					return mock.NewMockWebhookRepository(ctrl)
+				},
+			},
+			args: args{
+				req: &core.CreateWebhookRequest{ActorID: "1", Webhook: func() *core.Webhook {
+					w := newTestWebhook("1")
+					w.URL = "http://127.0.0.1:8080/hooks"
+					return w
+				}()},
+			},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - localhost",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.WebhookRepository {
+					return mock.NewMockWebhookRepository(ctrl)
+				},
+			},
+			args: args{
+				req: &core.CreateWebhookRequest{ActorID: "1", Webhook: func() *core.Webhook {
+					w := newTestWebhook("1")
+					w.URL = "http://localhost/hooks"
+					return w
+				}()},
+			},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - link-local address",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.WebhookRepository {
+					return mock.NewMockWebhookRepository(ctrl)
+				},
+			},
+			args: args{
+				req: &core.CreateWebhookRequest{ActorID: "1", Webhook: func() *core.Webhook {
+					w := newTestWebhook("1")
+					w.URL = "http://169.254.169.254/latest/meta-data"
+					return w
+				}()},
+			},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - private address",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.WebhookRepository {
+					return mock.NewMockWebhookRepository(ctrl)
+				},
+			},
+			args: args{
+				req: &core.CreateWebhookRequest{ActorID: "1", Webhook: func() *core.Webhook {
+					w := newTestWebhook("1")
+					w.URL = "https://10.0.0.7/hooks"
+					return w
+				}()},
+			},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - ipv6 loopback address",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.WebhookRepository {
+					return mock.NewMockWebhookRepository(ctrl)
+				},
+			},
+			args: args{
+				req: &core.CreateWebhookRequest{ActorID: "1", Webhook: func() *core.Webhook {
+					w := newTestWebhook("1")
+					w.URL = "http://[::1]/hooks"
+					return w
+				}()},
+			},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - short secret",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.WebhookRepository {
+					return mock.NewMockWebhookRepository(ctrl)
+				},
+			},
+			args: args{
+				req: &core.CreateWebhookRequest{ActorID: "1", Webhook: func() *core.Webhook {
+					w := newTestWebhook("1")
+					w.Secret = "secret"
+					return w
+				}()},
+			},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - unknown event type",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.WebhookRepository {
+					return mock.NewMockWebhookRepository(ctrl)
+				},
+			},
+			args: args{
+				req: &core.CreateWebhookRequest{ActorID: "1", Webhook: func() *core.Webhook {
+					w := newTestWebhook("1")
+					w.EventTypes = []core.HistoryOperation{""}
+					return w
+				}()},
+			},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+		{
+			name: "Not OK - error from repo",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.WebhookRepository {
+					repo := mock.NewMockWebhookRepository(ctrl)
+					repo.EXPECT().Store(gomock.Any(), gomock.Any()).Return(errors.New("error")) //nolint:goerr113
+					return repo
+				},
+			},
+			args: args{
+				req: &core.CreateWebhookRequest{ActorID: "1", Webhook: newTestWebhook("")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			err := webhook.NewService(tt.fields.repoMock(ctrl)).CreateWebhook(context.Background(), tt.args.req)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_UpdateWebhook(t *testing.T) {
+	type fields struct {
+		repoMock func(ctrl *gomock.Controller) core.WebhookRepository
+	}
+	stored := newTestWebhook("1")
+	tests := []struct {
+		name      string
+		fields    fields
+		req       *core.UpdateWebhookRequest
+		wantErrIs error
+	}{
+		{
+			name: "OK - keeps the secret when it isn't given",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.WebhookRepository {
+					repo := mock.NewMockWebhookRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), stored.ID).Return(newTestWebhookCopy(stored), nil)
+					repo.EXPECT().Update(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, w *core.Webhook) error {
+							assert.Equal(t, "https://payroll.example.com/hooks", w.URL)
+							assert.Equal(t, stored.Secret, w.Secret)
+							assert.Equal(t, []core.HistoryOperation{core.HistoryOperation_Update}, w.EventTypes)
+							assert.NotNil(t, w.UpdatedAt)
+							return nil
+						})
+					return repo
+				},
+			},
+			req: &core.UpdateWebhookRequest{
+				ActorID: "1",
+				Webhook: &core.Webhook{
+					ID:         stored.ID,
+					URL:        "https://payroll.example.com/hooks",
+					EventTypes: []core.HistoryOperation{core.HistoryOperation_Update},
+				},
+			},
+		},
+		{
+			name: "Not OK - webhook of another user",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.WebhookRepository {
+					repo := mock.NewMockWebhookRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), stored.ID).Return(newTestWebhookCopy(stored), nil)
+					return repo
+				},
+			},
+			req: &core.UpdateWebhookRequest{
+				ActorID: "2",
+				Webhook: &core.Webhook{
+					ID:         stored.ID,
+					URL:        "https://payroll.example.com/hooks",
+					EventTypes: []core.HistoryOperation{core.HistoryOperation_Update},
+				},
+			},
+			wantErrIs: core.ErrWebhookNotFound,
+		},
+		{
+			name: "Not OK - invalid url",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.WebhookRepository {
+					repo := mock.NewMockWebhookRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), stored.ID).Return(newTestWebhookCopy(stored), nil)
+					return repo
+				},
+			},
+			req: &core.UpdateWebhookRequest{
+				ActorID: "1",
+				Webhook: &core.Webhook{
+					ID:         stored.ID,
+					URL:        "not a url",
+					EventTypes: []core.HistoryOperation{core.HistoryOperation_Update},
+				},
+			},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			err := webhook.NewService(tt.fields.repoMock(ctrl)).UpdateWebhook(context.Background(), tt.req)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func newTestWebhookCopy(w *core.Webhook) *core.Webhook {
+	c := *w
+	return &c
+}
+
+func TestService_DeleteWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stored := newTestWebhook("1")
+	repo := mock.NewMockWebhookRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), stored.ID).Return(stored, nil).Times(2)
+	repo.EXPECT().DeleteByID(gomock.Any(), stored.ID).Return(nil).Times(1)
+
+	svc := webhook.NewService(repo)
+	err := svc.DeleteWebhook(context.Background(), &core.DeleteWebhookRequest{ActorID: "2", WebhookID: stored.ID})
+	assert.ErrorIs(t, err, core.ErrWebhookNotFound)
+
+	err = svc.DeleteWebhook(context.Background(), &core.DeleteWebhookRequest{ActorID: "1", WebhookID: stored.ID})
+	assert.NoError(t, err)
+}
+
+func TestService_ListWebhookDeliveries(t *testing.T) {
+	type fields struct {
+		repoMock func(ctrl *gomock.Controller) core.WebhookRepository
+	}
+	stored := newTestWebhook("1")
+	deliveries := func(ids ...int64) []core.WebhookDelivery {
+		res := make([]core.WebhookDelivery, len(ids))
+		for index, id := range ids {
+			res[index] = core.WebhookDelivery{ID: id, WebhookID: stored.ID, CreatedAt: time.Now()}
+		}
+		return res
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		req       *core.ListWebhookDeliveriesRequest
+		want      *core.WebhookDeliveryPage
+		wantErrIs error
+	}{
+		{
+			name: "OK - with a next page",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.WebhookRepository {
+					repo := mock.NewMockWebhookRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), stored.ID).Return(stored, nil)
+					repo.EXPECT().FindDeliveries(gomock.Any(), stored.ID, int64(10), 3).Return(deliveries(9, 8, 7), nil)
+					return repo
+				},
+			},
+			req:  &core.ListWebhookDeliveriesRequest{ActorID: "1", WebhookID: stored.ID, PageSize: 2, BeforeID: 10},
+			want: &core.WebhookDeliveryPage{Deliveries: deliveries(9, 8), NextBeforeID: 8},
+		},
+		{
+			name: "OK - last page",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.WebhookRepository {
+					repo := mock.NewMockWebhookRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), stored.ID).Return(stored, nil)
+					repo.EXPECT().FindDeliveries(gomock.Any(), stored.ID, int64(0), core.DefaultPageSize+1).Return(deliveries(1), nil)
+					return repo
+				},
+			},
+			req:  &core.ListWebhookDeliveriesRequest{ActorID: "1", WebhookID: stored.ID},
+			want: &core.WebhookDeliveryPage{Deliveries: deliveries(1)},
+		},
+		{
+			name: "Not OK - webhook of another user",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.WebhookRepository {
+					repo := mock.NewMockWebhookRepository(ctrl)
+					repo.EXPECT().FindByID(gomock.Any(), stored.ID).Return(stored, nil)
+					return repo
+				},
+			},
+			req:       &core.ListWebhookDeliveriesRequest{ActorID: "2", WebhookID: stored.ID},
+			wantErrIs: core.ErrWebhookNotFound,
+		},
+		{
+			name: "Not OK - invalid page size",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) core.WebhookRepository {
+					return mock.NewMockWebhookRepository(ctrl)
+				},
+			},
+			req:       &core.ListWebhookDeliveriesRequest{ActorID: "1", WebhookID: stored.ID, PageSize: core.MaxPageSize + 1},
+			wantErrIs: internal.ErrValidationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			got, err := webhook.NewService(tt.fields.repoMock(ctrl)).ListWebhookDeliveries(context.Background(), tt.req)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, len(tt.want.Deliveries), len(got.Deliveries))
+			for index := range tt.want.Deliveries {
+				assert.Equal(t, tt.want.Deliveries[index].ID, got.Deliveries[index].ID)
+			}
+			assert.Equal(t, tt.want.NextBeforeID, got.NextBeforeID)
+		})
+	}
+}
@@ -0,0 +1,3 @@
+ALTER TABLE "event" ADD COLUMN IF NOT EXISTS "deleted_at" TIMESTAMP NULL;
+
+CREATE INDEX IF NOT EXISTS "idx_event_deleted_at" ON "event" ("deleted_at") WHERE "deleted_at" IS NOT NULL;
\ No newline at end of file
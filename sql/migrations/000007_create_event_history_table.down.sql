@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "event_history";
+
+DROP FUNCTION IF EXISTS "reject_event_history_change";
\ No newline at end of file
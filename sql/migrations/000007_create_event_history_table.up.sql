@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS "event_history"(
+    "id" BIGSERIAL PRIMARY KEY,
+    "event_id" VARCHAR(50) NOT NULL,
+    "actor_id" VARCHAR(50) NOT NULL,
+    "operation" VARCHAR(20) NOT NULL,
+    "changes" JSONB NOT NULL DEFAULT '{}',
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS "idx_event_history_event_id" ON "event_history" ("event_id", "id" DESC);
+
+CREATE OR REPLACE FUNCTION "reject_event_history_change"() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'event_history is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "event_history_append_only"
+    BEFORE UPDATE OR DELETE ON "event_history"
+    FOR EACH ROW EXECUTE PROCEDURE "reject_event_history_change"();
\ No newline at end of file
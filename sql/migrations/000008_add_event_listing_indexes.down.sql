@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_invitation_user_id";
+DROP INDEX IF EXISTS "idx_invitation_event_id";
+DROP INDEX IF EXISTS "idx_schedule_event_id";
+DROP INDEX IF EXISTS "idx_event_created_at_id";
@@ -0,0 +1,4 @@
+CREATE INDEX IF NOT EXISTS "idx_event_created_at_id" ON "event" ("created_at" DESC, "id" DESC) WHERE "deleted_at" IS NULL;
+CREATE INDEX IF NOT EXISTS "idx_schedule_event_id" ON "schedule" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_invitation_event_id" ON "invitation" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_invitation_user_id" ON "invitation" ("user_id");
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_event_search_vector";
+
+ALTER TABLE "event" DROP COLUMN IF EXISTS "search_vector";
@@ -0,0 +1,5 @@
+ALTER TABLE "event" ADD COLUMN IF NOT EXISTS "search_vector" TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', "title"), 'A') || setweight(to_tsvector('english', "description"), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS "idx_event_search_vector" ON "event" USING GIN ("search_vector");
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "idempotency_key"(
+    "actor_id" VARCHAR(50) NOT NULL,
+    "key" VARCHAR(100) NOT NULL,
+    "request_hash" VARCHAR(64) NOT NULL,
+    "event_id" VARCHAR(50) NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "expires_at" TIMESTAMP NOT NULL,
+    PRIMARY KEY ("actor_id", "key")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_idempotency_key_expires_at" ON "idempotency_key" ("expires_at");
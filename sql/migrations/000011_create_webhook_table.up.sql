@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS "webhook"(
+    "id" VARCHAR(50) PRIMARY KEY,
+    "owner_id" VARCHAR(50) NOT NULL,
+    "url" VARCHAR(2048) NOT NULL,
+    "secret" VARCHAR(256) NOT NULL,
+    "event_types" JSONB NOT NULL DEFAULT '[]',
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "idx_webhook_owner_id" ON "webhook" ("owner_id");
+
+CREATE TABLE IF NOT EXISTS "webhook_delivery"(
+    "id" BIGSERIAL PRIMARY KEY,
+    "webhook_id" VARCHAR(50) NOT NULL REFERENCES "webhook" ("id") ON DELETE CASCADE,
+    "event_id" VARCHAR(50) NOT NULL,
+    "event_type" VARCHAR(20) NOT NULL,
+    "payload" TEXT NOT NULL,
+    "status" VARCHAR(20) NOT NULL,
+    "attempts" INTEGER NOT NULL DEFAULT 0,
+    "response_status" INTEGER NOT NULL DEFAULT 0,
+    "last_error" TEXT NOT NULL DEFAULT '',
+    "next_attempt_at" TIMESTAMP NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "delivered_at" TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivery_webhook_id" ON "webhook_delivery" ("webhook_id", "id" DESC);
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivery_due" ON "webhook_delivery" ("next_attempt_at") WHERE "status" = 'PENDING';
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS "outbox"(
+    "id" VARCHAR(50) PRIMARY KEY,
+    "type" VARCHAR(50) NOT NULL,
+    "event_id" VARCHAR(50) NOT NULL,
+    "actor_id" VARCHAR(50) NOT NULL,
+    "payload" JSONB NOT NULL,
+    "occurred_at" TIMESTAMP NOT NULL,
+    "attempts" INTEGER NOT NULL DEFAULT 0,
+    "last_error" TEXT NOT NULL DEFAULT '',
+    "next_attempt_at" TIMESTAMP NOT NULL,
+    "published_at" TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "idx_outbox_pending" ON "outbox" ("next_attempt_at") WHERE "published_at" IS NULL;
+CREATE INDEX IF NOT EXISTS "idx_outbox_published_at" ON "outbox" ("published_at") WHERE "published_at" IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "reminder_delivery";
+DROP TABLE IF EXISTS "reminder";
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS "reminder"(
+    "id" VARCHAR(50) PRIMARY KEY,
+    "event_id" VARCHAR(50) NOT NULL REFERENCES "event" ("id") ON DELETE CASCADE,
+    "user_id" INTEGER NOT NULL DEFAULT 0,
+    "offset_minutes" BIGINT NOT NULL,
+    "channel" VARCHAR(20) NOT NULL,
+    "next_fire_at" TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "idx_reminder_event_id" ON "reminder" ("event_id", "user_id");
+CREATE INDEX IF NOT EXISTS "idx_reminder_due" ON "reminder" ("next_fire_at") WHERE "next_fire_at" IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS "reminder_delivery"(
+    "id" BIGSERIAL PRIMARY KEY,
+    "event_id" VARCHAR(50) NOT NULL REFERENCES "event" ("id") ON DELETE CASCADE,
+    "recipient_id" VARCHAR(50) NOT NULL,
+    "channel" VARCHAR(20) NOT NULL,
+    "offset_minutes" BIGINT NOT NULL,
+    "occurrence_at" TIMESTAMP NOT NULL,
+    "status" VARCHAR(20) NOT NULL,
+    "attempts" INTEGER NOT NULL DEFAULT 0,
+    "last_error" TEXT NOT NULL DEFAULT '',
+    "next_attempt_at" TIMESTAMP NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "sent_at" TIMESTAMP,
+    CONSTRAINT "uq_reminder_delivery" UNIQUE ("event_id", "recipient_id", "channel", "offset_minutes", "occurrence_at")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_reminder_delivery_due" ON "reminder_delivery" ("next_attempt_at") WHERE "status" = 'PENDING';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "notification_digest_item";
+DROP TABLE IF EXISTS "event_mute";
+DROP TABLE IF EXISTS "notification_preference";
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS "notification_preference"(
+    "user_id" VARCHAR(50) PRIMARY KEY,
+    "channels" JSONB NOT NULL DEFAULT '[]',
+    "timezone" VARCHAR(50) NOT NULL,
+    "quiet_hours_start" VARCHAR(5) NOT NULL DEFAULT '',
+    "quiet_hours_end" VARCHAR(5) NOT NULL DEFAULT '',
+    "delivery" VARCHAR(20) NOT NULL,
+    "digest_time" VARCHAR(5) NOT NULL,
+    "updated_at" TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "event_mute"(
+    "user_id" VARCHAR(50) NOT NULL,
+    "event_id" VARCHAR(50) NOT NULL REFERENCES "event" ("id") ON DELETE CASCADE,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("user_id", "event_id")
+);
+
+CREATE TABLE IF NOT EXISTS "notification_digest_item"(
+    "id" BIGSERIAL PRIMARY KEY,
+    "user_id" VARCHAR(50) NOT NULL,
+    "dedup_key" VARCHAR(200) NOT NULL,
+    "subject" TEXT NOT NULL,
+    "body" TEXT NOT NULL,
+    "calendar_method" VARCHAR(20) NOT NULL DEFAULT '',
+    "calendar" TEXT NOT NULL DEFAULT '',
+    "send_after" TIMESTAMP NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT "uq_notification_digest_item" UNIQUE ("user_id", "dedup_key")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_notification_digest_item_due" ON "notification_digest_item" ("send_after");
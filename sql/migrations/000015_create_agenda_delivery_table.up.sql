@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS "agenda_delivery"(
+    "id" BIGSERIAL PRIMARY KEY,
+    "user_id" VARCHAR(50) NOT NULL,
+    "agenda_date" DATE NOT NULL,
+    "channel" VARCHAR(20) NOT NULL,
+    "status" VARCHAR(20) NOT NULL,
+    "attempts" INTEGER NOT NULL DEFAULT 0,
+    "last_error" TEXT NOT NULL DEFAULT '',
+    "next_attempt_at" TIMESTAMP NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "sent_at" TIMESTAMP,
+    CONSTRAINT "uq_agenda_delivery" UNIQUE ("user_id", "agenda_date", "channel")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_agenda_delivery_due" ON "agenda_delivery" ("next_attempt_at") WHERE "status" = 'PENDING';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "idx_event_calendar_id";
+
+ALTER TABLE "event" DROP COLUMN IF EXISTS "calendar_id";
+
+DROP TABLE IF EXISTS "calendar";
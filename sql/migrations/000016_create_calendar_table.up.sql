@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS "calendar"(
+    "id" VARCHAR(50) PRIMARY KEY,
+    "owner_id" VARCHAR(50) NOT NULL,
+    "name" VARCHAR(100) NOT NULL,
+    "color" VARCHAR(7) NOT NULL DEFAULT '',
+    "timezone" VARCHAR(50) NOT NULL,
+    "description" TEXT NOT NULL DEFAULT '',
+    "is_default" BOOLEAN NOT NULL DEFAULT FALSE,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "idx_calendar_owner_id" ON "calendar" ("owner_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "uq_calendar_default" ON "calendar" ("owner_id") WHERE "is_default";
+
+-- every creator gets a default calendar in the timezone of their latest event, which their
+-- existing events are put into
+INSERT INTO "calendar" ("id", "owner_id", "name", "timezone", "is_default")
+SELECT DISTINCT ON ("created_by")
+    md5('calendar:' || "created_by")::uuid::varchar,
+    "created_by",
+    'Default',
+    "timezone",
+    TRUE
+FROM
+    "event"
+ORDER BY
+    "created_by",
+    "created_at" DESC
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE "event" ADD COLUMN IF NOT EXISTS "calendar_id" VARCHAR(50) REFERENCES "calendar" ("id");
+
+UPDATE "event" SET "calendar_id" = md5('calendar:' || "created_by")::uuid::varchar WHERE "calendar_id" IS NULL;
+
+ALTER TABLE "event" ALTER COLUMN "calendar_id" SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS "idx_event_calendar_id" ON "event" ("calendar_id", "created_at" DESC, "id" DESC) WHERE "deleted_at" IS NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE "event_history" DROP COLUMN IF EXISTS "on_behalf_of";
+
+DROP TABLE IF EXISTS "calendar_acl";
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS "calendar_acl"(
+    "calendar_id" VARCHAR(50) NOT NULL REFERENCES "calendar" ("id") ON DELETE CASCADE,
+    "grantee_type" VARCHAR(10) NOT NULL,
+    "grantee_id" VARCHAR(50) NOT NULL,
+    "role" VARCHAR(20) NOT NULL,
+    "granted_by" VARCHAR(50) NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP,
+    PRIMARY KEY ("calendar_id", "grantee_type", "grantee_id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_calendar_acl_grantee" ON "calendar_acl" ("grantee_type", "grantee_id");
+
+-- the owner of the calendar when the operation was performed by a delegate, empty otherwise
+ALTER TABLE "event_history" ADD COLUMN IF NOT EXISTS "on_behalf_of" VARCHAR(50) NOT NULL DEFAULT '';
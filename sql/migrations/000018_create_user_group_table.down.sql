@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "idx_invitation_group_id";
+
+ALTER TABLE "invitation" DROP COLUMN IF EXISTS "group_id";
+
+DROP TABLE IF EXISTS "user_group_member";
+
+DROP TABLE IF EXISTS "user_group";
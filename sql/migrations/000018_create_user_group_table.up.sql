@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS "user_group"(
+    "id" VARCHAR(50) PRIMARY KEY,
+    "owner_id" VARCHAR(50) NOT NULL,
+    "name" VARCHAR(100) NOT NULL,
+    "description" TEXT NOT NULL DEFAULT '',
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "idx_user_group_owner_id" ON "user_group" ("owner_id");
+
+CREATE TABLE IF NOT EXISTS "user_group_member"(
+    "group_id" VARCHAR(50) NOT NULL REFERENCES "user_group" ("id") ON DELETE CASCADE,
+    "user_id" INTEGER NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("group_id", "user_id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_user_group_member_user_id" ON "user_group_member" ("user_id");
+
+-- the group the attendee was invited through, empty when they were invited on their own
+ALTER TABLE "invitation" ADD COLUMN IF NOT EXISTS "group_id" VARCHAR(50) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS "idx_invitation_group_id" ON "invitation" ("group_id") WHERE "group_id" <> '';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "resource_booking";
+
+DROP TABLE IF EXISTS "resource";
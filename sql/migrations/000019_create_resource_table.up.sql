@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS "resource"(
+    "id" VARCHAR(50) PRIMARY KEY,
+    "name" VARCHAR(100) NOT NULL,
+    "type" VARCHAR(20) NOT NULL,
+    "capacity" INTEGER NOT NULL DEFAULT 0,
+    "location" VARCHAR(200) NOT NULL DEFAULT '',
+    "created_by" VARCHAR(50) NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "idx_resource_type_capacity" ON "resource" ("type", "capacity");
+
+-- status is the one of the invitations: confirmed when the resource was free, declined otherwise
+CREATE TABLE IF NOT EXISTS "resource_booking"(
+    "event_id" VARCHAR(50) NOT NULL REFERENCES "event" ("id") ON DELETE CASCADE,
+    "resource_id" VARCHAR(50) NOT NULL REFERENCES "resource" ("id") ON DELETE CASCADE,
+    "status" SMALLINT NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("event_id", "resource_id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_resource_booking_resource_id" ON "resource_booking" ("resource_id");
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "guest";
+
+DROP TABLE IF EXISTS "booking";
+
+DROP TABLE IF EXISTS "booking_page";
@@ -0,0 +1,37 @@
+-- rules is the list of the availability rules, e.g: [{"weekday": 1, "start": "09:00", "end": "17:00"}]
+CREATE TABLE IF NOT EXISTS "booking_page"(
+    "id" VARCHAR(50) PRIMARY KEY,
+    "owner_id" VARCHAR(50) NOT NULL,
+    "title" VARCHAR(100) NOT NULL,
+    "description" TEXT NOT NULL DEFAULT '',
+    "timezone" VARCHAR(50) NOT NULL,
+    "duration" INTEGER NOT NULL,
+    "buffer_before" INTEGER NOT NULL DEFAULT 0,
+    "buffer_after" INTEGER NOT NULL DEFAULT 0,
+    "min_notice" INTEGER NOT NULL DEFAULT 0,
+    "max_per_day" INTEGER NOT NULL DEFAULT 0,
+    "rules" JSONB NOT NULL DEFAULT '[]',
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "idx_booking_page_owner_id" ON "booking_page" ("owner_id");
+
+CREATE TABLE IF NOT EXISTS "booking"(
+    "id" VARCHAR(50) PRIMARY KEY,
+    "page_id" VARCHAR(50) NOT NULL REFERENCES "booking_page" ("id") ON DELETE CASCADE,
+    "event_id" VARCHAR(50) NOT NULL UNIQUE REFERENCES "event" ("id") ON DELETE CASCADE,
+    "start_time" TIMESTAMP NOT NULL,
+    "guest_name" VARCHAR(100) NOT NULL,
+    "guest_email" VARCHAR(254) NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS "idx_booking_page_id_start_time" ON "booking" ("page_id", "start_time");
+
+CREATE TABLE IF NOT EXISTS "guest"(
+    "event_id" VARCHAR(50) NOT NULL REFERENCES "event" ("id") ON DELETE CASCADE,
+    "email" VARCHAR(254) NOT NULL,
+    "name" VARCHAR(100) NOT NULL,
+    PRIMARY KEY ("event_id", "email")
+);
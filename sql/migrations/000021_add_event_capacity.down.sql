@@ -0,0 +1,3 @@
+ALTER TABLE "invitation" DROP COLUMN IF EXISTS "waitlisted_at";
+
+ALTER TABLE "event" DROP COLUMN IF EXISTS "capacity";
@@ -0,0 +1,5 @@
+-- capacity is how many attendees can accept the invitation, 0 when it isn't limited
+ALTER TABLE "event" ADD COLUMN IF NOT EXISTS "capacity" INTEGER NOT NULL DEFAULT 0;
+
+-- waitlisted_at is set while the attendee is on the waitlist of the event, the waitlist is in its order
+ALTER TABLE "invitation" ADD COLUMN IF NOT EXISTS "waitlisted_at" TIMESTAMP NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE "idempotency_key" DROP COLUMN IF EXISTS "response";
+
+ALTER TABLE "idempotency_key" DROP COLUMN IF EXISTS "state";
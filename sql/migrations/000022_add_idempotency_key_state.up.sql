@@ -0,0 +1,5 @@
+-- state is PENDING while the request holding the key runs, expires_at is then the end of its lease.
+-- It becomes COMPLETED in the transaction that creates the event, along with the response replayed to retries.
+ALTER TABLE "idempotency_key" ADD COLUMN IF NOT EXISTS "state" VARCHAR(10) NOT NULL DEFAULT 'COMPLETED';
+
+ALTER TABLE "idempotency_key" ADD COLUMN IF NOT EXISTS "response" JSONB NOT NULL DEFAULT 'null';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_webhook_delivery_outbox_id";
+
+ALTER TABLE "webhook_delivery" DROP COLUMN IF EXISTS "outbox_id";
@@ -0,0 +1,5 @@
+-- outbox_id is the domain event a delivery was logged for, a domain event relayed again from the
+-- outbox doesn't log its deliveries twice
+ALTER TABLE "webhook_delivery" ADD COLUMN IF NOT EXISTS "outbox_id" VARCHAR(50) NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_webhook_delivery_outbox_id" ON "webhook_delivery" ("outbox_id", "webhook_id");
@@ -0,0 +1,8 @@
+package migrations
+
+import "embed"
+
+// FS holds the migration files so the server binary can migrate the schema on its own.
+//
+//go:embed *.sql
+var FS embed.FS
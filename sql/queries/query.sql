@@ -7,10 +7,12 @@ INSERT INTO
         timezone,
         created_by,
         created_at,
-        updated_at
+        updated_at,
+        calendar_id,
+        capacity
     )
 VALUES
-    ($1, $2, $3, $4, $5, $6, $7);
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9);
 
 -- name: CreateSchedule :exec
 INSERT INTO
@@ -28,24 +30,47 @@ VALUES
 
 -- name: CreateInvitation :exec
 INSERT INTO
-    invitation (id, event_id, user_id, token, status)
+    invitation (id, event_id, user_id, token, status, group_id)
 VALUES
-    ($1, $2, $3, $4, $5);
+    ($1, $2, $3, $4, $5, $6);
+
+-- name: DeleteEvent :execrows
+UPDATE
+    event
+SET
+    deleted_at = $2
+WHERE
+    id = $1
+    AND deleted_at IS NULL;
+
+-- name: RestoreEvent :execrows
+UPDATE
+    event
+SET
+    deleted_at = NULL
+WHERE
+    id = $1
+    AND deleted_at IS NOT NULL;
 
--- name: DeleteEvent :exec
+-- name: PurgeDeletedEvents :execrows
 DELETE FROM
     event
 WHERE
-    id = $1;
+    deleted_at IS NOT NULL
+    AND deleted_at < $1;
 
--- name: UpdateEvent :exec
+-- name: UpdateEvent :execrows
 UPDATE
     event
 SET
     title = $1,
     description = $2,
     timezone = $3,
-    updated_at = $4;
+    updated_at = $4,
+    capacity = $5
+WHERE
+    id = $6
+    AND deleted_at IS NULL;
 
 -- name: UpsertSchedule :exec
 INSERT INTO
@@ -69,14 +94,26 @@ SET
 
 -- name: UpsertInvitation :exec
 INSERT INTO
-    invitation (id, event_id, user_id, token, status)
+    invitation (
+        id,
+        event_id,
+        user_id,
+        token,
+        status,
+        group_id,
+        updated_at,
+        waitlisted_at
+    )
 VALUES
-    ($1, $2, $3, $4, $5) ON CONFLICT (id, event_id) DO
+    ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (id, event_id) DO
 UPDATE
 SET
     user_id = $3,
     token = $4,
-    status = $5;
+    status = $5,
+    group_id = $6,
+    updated_at = $7,
+    waitlisted_at = $8;
 
 -- name: FindEventByID :one
 SELECT
@@ -85,9 +122,225 @@ FROM
     event
 WHERE
     id = $1
+    AND deleted_at IS NULL
+LIMIT
+    1;
+
+-- name: LockEvent :one
+SELECT
+    *
+FROM
+    event
+WHERE
+    id = $1
+    AND deleted_at IS NULL FOR
+UPDATE;
+
+-- name: FindDeletedEventByID :one
+SELECT
+    *
+FROM
+    event
+WHERE
+    id = $1
+    AND deleted_at IS NOT NULL
 LIMIT
     1;
 
+-- name: FindDeletedEventsByCreator :many
+SELECT
+    *
+FROM
+    event
+WHERE
+    created_by = $1
+    AND deleted_at IS NOT NULL
+ORDER BY
+    deleted_at DESC;
+
+-- name: ListEvents :many
+SELECT
+    *
+FROM
+    event
+WHERE
+    deleted_at IS NULL
+    AND (
+        sqlc.narg('created_by')::varchar IS NULL
+        OR created_by = sqlc.narg('created_by')
+    )
+    AND (
+        sqlc.narg('attendee')::integer IS NULL
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                invitation
+            WHERE
+                invitation.event_id = event.id
+                AND invitation.user_id = sqlc.narg('attendee')
+        )
+    )
+    AND (
+        sqlc.narg('title')::text IS NULL
+        OR title ILIKE '%' || sqlc.narg('title') || '%'
+    )
+    AND (
+        sqlc.narg('created_after')::timestamp IS NULL
+        OR created_at >= sqlc.narg('created_after')
+    )
+    AND (
+        sqlc.narg('created_before')::timestamp IS NULL
+        OR created_at < sqlc.narg('created_before')
+    )
+    AND (
+        sqlc.narg('timezone')::varchar IS NULL
+        OR timezone = sqlc.narg('timezone')
+    )
+    AND (
+        sqlc.narg('calendar_id')::varchar IS NULL
+        OR calendar_id = sqlc.narg('calendar_id')
+    )
+    AND (
+        sqlc.narg('viewer')::varchar IS NULL
+        OR created_by = sqlc.narg('viewer')
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                invitation
+            WHERE
+                invitation.event_id = event.id
+                AND invitation.user_id = sqlc.arg('viewer_member_id')
+        )
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                calendar
+            WHERE
+                calendar.id = event.calendar_id
+                AND calendar.owner_id = sqlc.narg('viewer')
+        )
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                calendar_acl
+            WHERE
+                calendar_acl.calendar_id = event.calendar_id
+                AND (
+                    (
+                        calendar_acl.grantee_type = 'USER'
+                        AND calendar_acl.grantee_id = sqlc.narg('viewer')
+                    )
+                    OR (
+                        calendar_acl.grantee_type = 'GROUP'
+                        AND calendar_acl.grantee_id IN (
+                            SELECT
+                                group_id
+                            FROM
+                                user_group_member
+                            WHERE
+                                user_id = sqlc.arg('viewer_member_id')
+                        )
+                    )
+                )
+                AND (
+                    calendar_acl.role <> 'FREE_BUSY'
+                    OR (
+                        sqlc.narg('title')::text IS NULL
+                        AND sqlc.narg('attendee')::integer IS NULL
+                    )
+                )
+        )
+    )
+    AND (
+        sqlc.narg('cursor_created_at')::timestamp IS NULL
+        OR (created_at, id) < (
+            sqlc.narg('cursor_created_at'),
+            sqlc.narg('cursor_id')::varchar
+        )
+    )
+ORDER BY
+    created_at DESC,
+    id DESC
+LIMIT
+    sqlc.arg('page_size');
+
+-- name: SearchEvents :many
+SELECT
+    id,
+    title,
+    description,
+    timezone,
+    created_by,
+    created_at,
+    updated_at,
+    deleted_at,
+    calendar_id,
+    capacity,
+    ts_rank(search_vector, query) AS rank
+FROM
+    event,
+    websearch_to_tsquery('english', sqlc.arg('query')) query
+WHERE
+    deleted_at IS NULL
+    AND search_vector @@ query
+    AND (
+        created_by = sqlc.arg('actor_id')
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                invitation
+            WHERE
+                invitation.event_id = event.id
+                AND invitation.user_id::varchar = sqlc.arg('actor_id')
+        )
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                calendar
+            WHERE
+                calendar.id = event.calendar_id
+                AND calendar.owner_id = sqlc.arg('actor_id')
+        )
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                calendar_acl
+            WHERE
+                calendar_acl.calendar_id = event.calendar_id
+                AND calendar_acl.role <> 'FREE_BUSY'
+                AND (
+                    (
+                        calendar_acl.grantee_type = 'USER'
+                        AND calendar_acl.grantee_id = sqlc.arg('actor_id')
+                    )
+                    OR (
+                        calendar_acl.grantee_type = 'GROUP'
+                        AND calendar_acl.grantee_id IN (
+                            SELECT
+                                group_id
+                            FROM
+                                user_group_member
+                            WHERE
+                                user_id = sqlc.arg('actor_member_id')
+                        )
+                    )
+                )
+        )
+    )
+ORDER BY
+    rank DESC,
+    created_at DESC,
+    id DESC
+LIMIT
+    sqlc.arg('page_size') OFFSET sqlc.arg('page_offset');
+
 -- name: FindSchedulesByEventID :many
 SELECT
     *
@@ -102,4 +355,1147 @@ SELECT
 FROM
     invitation
 WHERE
-    event_id = $1;
\ No newline at end of file
+    event_id = $1;
+
+-- name: UpdateInvitationStatus :execrows
+UPDATE
+    invitation
+SET
+    status = $2,
+    updated_at = $3,
+    waitlisted_at = $4
+WHERE
+    id = $1;
+
+-- name: CreateEventHistory :exec
+INSERT INTO
+    event_history (
+        event_id,
+        actor_id,
+        operation,
+        changes,
+        created_at,
+        on_behalf_of
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6);
+
+-- name: FindEventHistoryByEventID :many
+SELECT
+    *
+FROM
+    event_history
+WHERE
+    event_id = $1
+    AND id < $2
+ORDER BY
+    id DESC
+LIMIT
+    $3;
+
+-- name: ReserveIdempotencyKey :execrows
+INSERT INTO
+    idempotency_key (
+        actor_id,
+        key,
+        request_hash,
+        event_id,
+        state,
+        created_at,
+        expires_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (actor_id, key) DO
+UPDATE
+SET
+    request_hash = EXCLUDED.request_hash,
+    event_id = EXCLUDED.event_id,
+    state = EXCLUDED.state,
+    response = 'null',
+    created_at = EXCLUDED.created_at,
+    expires_at = EXCLUDED.expires_at
+WHERE
+    idempotency_key.expires_at <= EXCLUDED.created_at;
+
+-- name: FindIdempotencyKey :one
+SELECT
+    *
+FROM
+    idempotency_key
+WHERE
+    actor_id = $1
+    AND key = $2
+LIMIT
+    1;
+
+-- name: CompleteIdempotencyKey :execrows
+UPDATE
+    idempotency_key
+SET
+    state = 'COMPLETED',
+    event_id = $4,
+    response = $5,
+    expires_at = $6
+WHERE
+    actor_id = $1
+    AND key = $2
+    AND created_at = $3
+    AND state = 'PENDING';
+
+-- name: ReleaseIdempotencyKey :exec
+DELETE FROM
+    idempotency_key
+WHERE
+    actor_id = $1
+    AND key = $2
+    AND created_at = $3
+    AND state = 'PENDING';
+
+-- name: PurgeExpiredIdempotencyKeys :execrows
+DELETE FROM
+    idempotency_key
+WHERE
+    expires_at <= $1;
+
+-- name: CreateWebhook :exec
+INSERT INTO
+    webhook (
+        id,
+        owner_id,
+        url,
+        secret,
+        event_types,
+        created_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6);
+
+-- name: UpdateWebhook :execrows
+UPDATE
+    webhook
+SET
+    url = $1,
+    secret = $2,
+    event_types = $3,
+    updated_at = $4
+WHERE
+    id = $5;
+
+-- name: DeleteWebhook :execrows
+DELETE FROM
+    webhook
+WHERE
+    id = $1;
+
+-- name: FindWebhookByID :one
+SELECT
+    *
+FROM
+    webhook
+WHERE
+    id = $1
+LIMIT
+    1;
+
+-- name: FindWebhooksByOwner :many
+SELECT
+    *
+FROM
+    webhook
+WHERE
+    owner_id = $1
+ORDER BY
+    created_at,
+    id;
+
+-- name: EnqueueWebhookDeliveries :execrows
+INSERT INTO
+    webhook_delivery (
+        webhook_id,
+        event_id,
+        event_type,
+        payload,
+        status,
+        next_attempt_at,
+        created_at,
+        outbox_id
+    )
+SELECT
+    webhook.id,
+    sqlc.arg('event_id')::varchar,
+    sqlc.arg('event_type')::varchar,
+    sqlc.arg('payload')::text,
+    sqlc.arg('status')::varchar,
+    sqlc.arg('created_at')::timestamp,
+    sqlc.arg('created_at')::timestamp,
+    sqlc.narg('outbox_id')::varchar
+FROM
+    webhook
+WHERE
+    webhook.event_types @> jsonb_build_array(sqlc.arg('event_type')::varchar)
+    AND webhook.owner_id IN (
+        SELECT
+            jsonb_array_elements_text(sqlc.arg('owner_ids')::jsonb)
+    ) ON CONFLICT (outbox_id, webhook_id) DO NOTHING;
+
+-- name: ClaimDueWebhookDeliveries :many
+UPDATE
+    webhook_delivery
+SET
+    next_attempt_at = sqlc.arg('lease_until')
+WHERE
+    id IN (
+        SELECT
+            id
+        FROM
+            webhook_delivery AS due
+        WHERE
+            due.status = sqlc.arg('status')
+            AND due.next_attempt_at <= sqlc.arg('now')
+        ORDER BY
+            due.next_attempt_at
+        LIMIT
+            sqlc.arg('limit') FOR UPDATE SKIP LOCKED
+    ) RETURNING *;
+
+-- name: UpdateWebhookDelivery :execrows
+UPDATE
+    webhook_delivery
+SET
+    status = $1,
+    attempts = $2,
+    response_status = $3,
+    last_error = $4,
+    next_attempt_at = $5,
+    delivered_at = $6
+WHERE
+    id = $7;
+
+-- name: FindWebhookDeliveries :many
+SELECT
+    *
+FROM
+    webhook_delivery
+WHERE
+    webhook_id = $1
+    AND id < $2
+ORDER BY
+    id DESC
+LIMIT
+    $3;
+
+-- name: CreateOutboxMessage :exec
+INSERT INTO
+    outbox (
+        id,
+        type,
+        event_id,
+        actor_id,
+        payload,
+        occurred_at,
+        next_attempt_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $6);
+
+-- name: ClaimPendingOutboxMessages :many
+UPDATE
+    outbox
+SET
+    next_attempt_at = sqlc.arg('lease_until')
+WHERE
+    id IN (
+        SELECT
+            id
+        FROM
+            outbox AS pending
+        WHERE
+            pending.published_at IS NULL
+            AND pending.next_attempt_at <= sqlc.arg('now')
+        ORDER BY
+            pending.occurred_at
+        LIMIT
+            sqlc.arg('limit') FOR UPDATE SKIP LOCKED
+    ) RETURNING *;
+
+-- name: MarkOutboxMessagePublished :execrows
+UPDATE
+    outbox
+SET
+    published_at = $1
+WHERE
+    id = $2;
+
+-- name: RetryOutboxMessage :execrows
+UPDATE
+    outbox
+SET
+    attempts = attempts + 1,
+    last_error = $1,
+    next_attempt_at = $2
+WHERE
+    id = $3;
+
+-- name: PurgePublishedOutboxMessages :execrows
+DELETE FROM
+    outbox
+WHERE
+    published_at < $1;
+
+-- name: CreateReminder :exec
+INSERT INTO
+    reminder (
+        id,
+        event_id,
+        user_id,
+        offset_minutes,
+        channel,
+        next_fire_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6);
+
+-- name: DeleteReminders :exec
+DELETE FROM
+    reminder
+WHERE
+    event_id = $1
+    AND user_id = $2;
+
+-- name: RescheduleReminders :exec
+UPDATE
+    reminder
+SET
+    next_fire_at = $1
+WHERE
+    event_id = $2;
+
+-- name: ClaimDueReminders :many
+UPDATE
+    reminder
+SET
+    next_fire_at = sqlc.arg('lease_until')
+WHERE
+    id IN (
+        SELECT
+            due.id
+        FROM
+            reminder AS due
+            JOIN event ON event.id = due.event_id
+        WHERE
+            event.deleted_at IS NULL
+            AND due.next_fire_at <= sqlc.arg('now')
+        ORDER BY
+            due.next_fire_at
+        LIMIT
+            sqlc.arg('limit') FOR UPDATE OF due SKIP LOCKED
+    ) RETURNING *;
+
+-- name: UpdateReminderNextFireAt :execrows
+UPDATE
+    reminder
+SET
+    next_fire_at = $1
+WHERE
+    id = $2;
+
+-- name: CreateReminderDelivery :execrows
+INSERT INTO
+    reminder_delivery (
+        event_id,
+        recipient_id,
+        channel,
+        offset_minutes,
+        occurrence_at,
+        status,
+        next_attempt_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT ON CONSTRAINT uq_reminder_delivery DO NOTHING;
+
+-- name: ClaimDueReminderDeliveries :many
+UPDATE
+    reminder_delivery
+SET
+    next_attempt_at = sqlc.arg('lease_until')
+WHERE
+    id IN (
+        SELECT
+            id
+        FROM
+            reminder_delivery AS due
+        WHERE
+            due.status = sqlc.arg('status')
+            AND due.next_attempt_at <= sqlc.arg('now')
+        ORDER BY
+            due.next_attempt_at
+        LIMIT
+            sqlc.arg('limit') FOR UPDATE SKIP LOCKED
+    ) RETURNING *;
+
+-- name: UpdateReminderDelivery :execrows
+UPDATE
+    reminder_delivery
+SET
+    status = $1,
+    attempts = $2,
+    last_error = $3,
+    next_attempt_at = $4,
+    sent_at = $5
+WHERE
+    id = $6;
+
+-- name: FindNotificationPreference :one
+SELECT
+    *
+FROM
+    notification_preference
+WHERE
+    user_id = $1
+LIMIT
+    1;
+
+-- name: FindMutedEventIDs :many
+SELECT
+    event_id
+FROM
+    event_mute
+WHERE
+    user_id = $1
+ORDER BY
+    created_at;
+
+-- name: UpsertNotificationPreference :exec
+INSERT INTO
+    notification_preference (
+        user_id,
+        channels,
+        timezone,
+        quiet_hours_start,
+        quiet_hours_end,
+        delivery,
+        digest_time,
+        updated_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (user_id) DO
+UPDATE
+SET
+    channels = EXCLUDED.channels,
+    timezone = EXCLUDED.timezone,
+    quiet_hours_start = EXCLUDED.quiet_hours_start,
+    quiet_hours_end = EXCLUDED.quiet_hours_end,
+    delivery = EXCLUDED.delivery,
+    digest_time = EXCLUDED.digest_time,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: CreateEventMute :exec
+INSERT INTO
+    event_mute (user_id, event_id)
+VALUES
+    ($1, $2) ON CONFLICT DO NOTHING;
+
+-- name: DeleteEventMute :exec
+DELETE FROM
+    event_mute
+WHERE
+    user_id = $1
+    AND event_id = $2;
+
+-- name: CreateNotificationDigestItem :exec
+INSERT INTO
+    notification_digest_item (
+        user_id,
+        dedup_key,
+        subject,
+        body,
+        calendar_method,
+        calendar,
+        send_after
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT ON CONSTRAINT uq_notification_digest_item DO NOTHING;
+
+-- name: ClaimDueNotificationDigestItems :many
+UPDATE
+    notification_digest_item
+SET
+    send_after = sqlc.arg('lease_until')
+WHERE
+    id IN (
+        SELECT
+            id
+        FROM
+            notification_digest_item AS due
+        WHERE
+            due.send_after <= sqlc.arg('now')
+        ORDER BY
+            due.user_id,
+            due.id
+        LIMIT
+            sqlc.arg('limit') FOR UPDATE SKIP LOCKED
+    ) RETURNING *;
+
+-- name: DeleteNotificationDigestItem :exec
+DELETE FROM
+    notification_digest_item
+WHERE
+    id = $1;
+
+-- name: ScheduleDueAgendaDeliveries :execrows
+INSERT INTO
+    agenda_delivery (user_id, agenda_date, channel, status, next_attempt_at)
+SELECT
+    recipient.user_id,
+    recipient.local_now::date,
+    channel.value,
+    sqlc.arg('status'),
+    sqlc.arg('now')
+FROM
+    (
+        SELECT
+            users.user_id,
+            (sqlc.arg('now')::timestamp AT TIME ZONE 'UTC') AT TIME ZONE COALESCE(preference.timezone, 'UTC') AS local_now,
+            COALESCE(preference.channels, sqlc.arg('default_channels')::jsonb) AS channels
+        FROM
+            (
+                SELECT
+                    created_by AS user_id
+                FROM
+                    event
+                WHERE
+                    deleted_at IS NULL
+                UNION
+                SELECT
+                    invitation.user_id::varchar AS user_id
+                FROM
+                    invitation
+                    JOIN event ON event.id = invitation.event_id
+                WHERE
+                    event.deleted_at IS NULL
+                    AND invitation.status <> sqlc.arg('declined')
+            ) AS users
+            LEFT JOIN notification_preference AS preference ON preference.user_id = users.user_id
+    ) AS recipient
+    CROSS JOIN jsonb_array_elements_text(recipient.channels) AS channel
+WHERE
+    recipient.local_now::time >= sqlc.arg('morning')::time
+    AND recipient.local_now::time < sqlc.arg('noon')::time ON CONFLICT ON CONSTRAINT uq_agenda_delivery DO NOTHING;
+
+-- name: ClaimDueAgendaDeliveries :many
+UPDATE
+    agenda_delivery
+SET
+    next_attempt_at = sqlc.arg('lease_until')
+WHERE
+    id IN (
+        SELECT
+            id
+        FROM
+            agenda_delivery AS due
+        WHERE
+            due.status = sqlc.arg('status')
+            AND due.next_attempt_at <= sqlc.arg('now')
+        ORDER BY
+            due.next_attempt_at
+        LIMIT
+            sqlc.arg('limit') FOR UPDATE SKIP LOCKED
+    ) RETURNING *;
+
+-- name: UpdateAgendaDelivery :execrows
+UPDATE
+    agenda_delivery
+SET
+    status = $1,
+    attempts = $2,
+    last_error = $3,
+    next_attempt_at = $4,
+    sent_at = $5
+WHERE
+    id = $6;
+
+-- name: CreateCalendar :exec
+INSERT INTO
+    calendar (
+        id,
+        owner_id,
+        name,
+        color,
+        timezone,
+        description,
+        is_default,
+        created_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: CreateDefaultCalendar :exec
+INSERT INTO
+    calendar (
+        id,
+        owner_id,
+        name,
+        color,
+        timezone,
+        description,
+        is_default,
+        created_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, TRUE, $7) ON CONFLICT (owner_id)
+WHERE
+    is_default DO NOTHING;
+
+-- name: UpdateCalendar :execrows
+UPDATE
+    calendar
+SET
+    name = $1,
+    color = $2,
+    timezone = $3,
+    description = $4,
+    updated_at = $5
+WHERE
+    id = $6;
+
+-- name: DeleteCalendar :execrows
+DELETE FROM
+    calendar
+WHERE
+    id = $1
+    AND NOT is_default;
+
+-- name: FindCalendarByID :one
+SELECT
+    *
+FROM
+    calendar
+WHERE
+    id = $1
+LIMIT
+    1;
+
+-- name: FindDefaultCalendar :one
+SELECT
+    *
+FROM
+    calendar
+WHERE
+    owner_id = $1
+    AND is_default
+LIMIT
+    1;
+
+-- name: FindCalendarsByOwner :many
+SELECT
+    *
+FROM
+    calendar
+WHERE
+    owner_id = $1
+ORDER BY
+    is_default DESC,
+    created_at,
+    id;
+
+-- name: MoveEvent :execrows
+UPDATE
+    event
+SET
+    calendar_id = $1,
+    updated_at = $2
+WHERE
+    id = $3
+    AND deleted_at IS NULL;
+
+-- name: LockCalendarEvents :many
+SELECT
+    *
+FROM
+    event
+WHERE
+    calendar_id = $1
+ORDER BY
+    id FOR
+UPDATE;
+
+-- name: MoveCalendarEvents :exec
+UPDATE
+    event
+SET
+    calendar_id = sqlc.arg('to_calendar_id')
+WHERE
+    calendar_id = sqlc.arg('from_calendar_id');
+
+-- name: UpsertCalendarGrant :exec
+INSERT INTO
+    calendar_acl (
+        calendar_id,
+        grantee_type,
+        grantee_id,
+        role,
+        granted_by,
+        created_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6) ON CONFLICT (calendar_id, grantee_type, grantee_id) DO
+UPDATE
+SET
+    role = EXCLUDED.role,
+    granted_by = EXCLUDED.granted_by,
+    updated_at = EXCLUDED.created_at;
+
+-- name: DeleteCalendarGrant :execrows
+DELETE FROM
+    calendar_acl
+WHERE
+    calendar_id = $1
+    AND grantee_type = $2
+    AND grantee_id = $3;
+
+-- name: FindCalendarGrants :many
+SELECT
+    *
+FROM
+    calendar_acl
+WHERE
+    calendar_id = $1
+ORDER BY
+    created_at,
+    grantee_type,
+    grantee_id;
+
+-- name: FindCalendarRoles :many
+SELECT
+    role
+FROM
+    calendar_acl
+WHERE
+    calendar_id = sqlc.arg('calendar_id')
+    AND grantee_type = 'USER'
+    AND grantee_id = sqlc.arg('user_id')
+UNION ALL
+SELECT
+    calendar_acl.role
+FROM
+    calendar_acl
+    JOIN user_group_member ON user_group_member.group_id = calendar_acl.grantee_id
+WHERE
+    calendar_acl.calendar_id = sqlc.arg('calendar_id')
+    AND calendar_acl.grantee_type = 'GROUP'
+    AND user_group_member.user_id = sqlc.arg('member_id')
+UNION ALL
+SELECT
+    'MANAGE'
+FROM
+    calendar
+WHERE
+    id = sqlc.arg('calendar_id')
+    AND owner_id = sqlc.arg('user_id');
+
+-- name: FindCalendarsSharedWith :many
+SELECT
+    calendar.*,
+    calendar_acl.role
+FROM
+    calendar
+    JOIN calendar_acl ON calendar_acl.calendar_id = calendar.id
+WHERE
+    calendar.owner_id <> sqlc.arg('user_id')
+    AND (
+        (
+            calendar_acl.grantee_type = 'USER'
+            AND calendar_acl.grantee_id = sqlc.arg('user_id')
+        )
+        OR (
+            calendar_acl.grantee_type = 'GROUP'
+            AND calendar_acl.grantee_id IN (
+                SELECT
+                    group_id
+                FROM
+                    user_group_member
+                WHERE
+                    user_id = sqlc.arg('member_id')
+            )
+        )
+    )
+ORDER BY
+    calendar.created_at,
+    calendar.id;
+
+-- name: CreateGroup :exec
+INSERT INTO
+    user_group (id, owner_id, name, description, created_at)
+VALUES
+    ($1, $2, $3, $4, $5);
+
+-- name: UpdateGroup :execrows
+UPDATE
+    user_group
+SET
+    name = $1,
+    description = $2,
+    updated_at = $3
+WHERE
+    id = $4;
+
+-- name: DeleteGroup :execrows
+DELETE FROM
+    user_group
+WHERE
+    id = $1;
+
+-- name: UnlinkGroupInvitations :exec
+UPDATE
+    invitation
+SET
+    group_id = ''
+WHERE
+    group_id = $1;
+
+-- name: DeleteGroupCalendarGrants :exec
+DELETE FROM
+    calendar_acl
+WHERE
+    grantee_type = 'GROUP'
+    AND grantee_id = $1;
+
+-- name: FindGroupByID :one
+SELECT
+    *
+FROM
+    user_group
+WHERE
+    id = $1
+LIMIT
+    1;
+
+-- name: FindGroupsByOwner :many
+SELECT
+    *
+FROM
+    user_group
+WHERE
+    owner_id = $1
+ORDER BY
+    created_at,
+    id;
+
+-- name: FindGroupMembers :many
+SELECT
+    user_id
+FROM
+    user_group_member
+WHERE
+    group_id = $1
+ORDER BY
+    created_at,
+    user_id;
+
+-- name: CreateGroupMember :exec
+INSERT INTO
+    user_group_member (group_id, user_id, created_at)
+VALUES
+    ($1, $2, $3) ON CONFLICT (group_id, user_id) DO NOTHING;
+
+-- name: DeleteGroupMember :exec
+DELETE FROM
+    user_group_member
+WHERE
+    group_id = $1
+    AND user_id = $2;
+
+-- name: DeleteGroupInvitation :execrows
+DELETE FROM
+    invitation
+WHERE
+    event_id = $1
+    AND user_id = $2
+    AND group_id = $3;
+
+-- name: FindUpcomingGroupEventIDs :many
+SELECT
+    DISTINCT event.id
+FROM
+    event
+    JOIN invitation ON invitation.event_id = event.id
+WHERE
+    invitation.group_id = sqlc.arg('group_id')
+    AND event.deleted_at IS NULL
+    AND EXISTS (
+        SELECT
+            1
+        FROM
+            schedule
+        WHERE
+            schedule.event_id = event.id
+            AND (
+                schedule.start_time > sqlc.arg('after')
+                OR schedule.recurring_type <> 'NONE'
+            )
+    )
+ORDER BY
+    event.id;
+
+-- name: CreateResource :exec
+INSERT INTO
+    resource (
+        id,
+        name,
+        type,
+        capacity,
+        location,
+        created_by,
+        created_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: UpdateResource :execrows
+UPDATE
+    resource
+SET
+    name = $1,
+    type = $2,
+    capacity = $3,
+    location = $4,
+    updated_at = $5
+WHERE
+    id = $6;
+
+-- name: DeleteResource :execrows
+DELETE FROM
+    resource
+WHERE
+    id = $1;
+
+-- name: FindResourceByID :one
+SELECT
+    *
+FROM
+    resource
+WHERE
+    id = $1
+LIMIT
+    1;
+
+-- name: LockResource :one
+SELECT
+    id
+FROM
+    resource
+WHERE
+    id = $1 FOR UPDATE;
+
+-- name: ListInvolvedEvents :many
+SELECT
+    *
+FROM
+    event
+WHERE
+    deleted_at IS NULL
+    AND (
+        created_by = sqlc.arg('created_by')
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                invitation
+            WHERE
+                invitation.event_id = event.id
+                AND invitation.user_id = sqlc.arg('user_id')
+                AND invitation.status <> sqlc.arg('declined')
+        )
+    )
+    AND EXISTS (
+        SELECT
+            1
+        FROM
+            schedule
+        WHERE
+            schedule.event_id = event.id
+            AND (
+                schedule.recurring_type <> 'NONE'
+                OR schedule.start_time + schedule.duration * 60 > sqlc.arg('window_start')
+            )
+            AND schedule.start_time < sqlc.arg('window_end')
+    )
+ORDER BY
+    created_at,
+    id;
+
+-- name: ListResources :many
+SELECT
+    *
+FROM
+    resource
+WHERE
+    (
+        sqlc.narg('type')::varchar IS NULL
+        OR type = sqlc.narg('type')
+    )
+    AND capacity >= sqlc.arg('min_capacity')
+ORDER BY
+    name,
+    id;
+
+-- name: FindResourceBookedSchedules :many
+SELECT
+    schedule.*
+FROM
+    resource_booking
+    JOIN event ON event.id = resource_booking.event_id
+    JOIN schedule ON schedule.event_id = resource_booking.event_id
+WHERE
+    resource_booking.resource_id = sqlc.arg('resource_id')
+    AND resource_booking.event_id <> sqlc.arg('event_id')
+    AND resource_booking.status = sqlc.arg('status')
+    AND event.deleted_at IS NULL;
+
+-- name: CreateResourceBooking :exec
+INSERT INTO
+    resource_booking (event_id, resource_id, status, created_at)
+VALUES
+    ($1, $2, $3, $4);
+
+-- name: DeleteResourceBookings :exec
+DELETE FROM
+    resource_booking
+WHERE
+    event_id = $1;
+
+-- name: CreateBookingPage :exec
+INSERT INTO
+    booking_page (
+        id,
+        owner_id,
+        title,
+        description,
+        timezone,
+        duration,
+        buffer_before,
+        buffer_after,
+        min_notice,
+        max_per_day,
+        rules,
+        created_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
+
+-- name: UpdateBookingPage :execrows
+UPDATE
+    booking_page
+SET
+    title = $1,
+    description = $2,
+    timezone = $3,
+    duration = $4,
+    buffer_before = $5,
+    buffer_after = $6,
+    min_notice = $7,
+    max_per_day = $8,
+    rules = $9,
+    updated_at = $10
+WHERE
+    id = $11;
+
+-- name: DeleteBookingPage :execrows
+DELETE FROM
+    booking_page
+WHERE
+    id = $1;
+
+-- name: FindBookingPageByID :one
+SELECT
+    *
+FROM
+    booking_page
+WHERE
+    id = $1
+LIMIT
+    1;
+
+-- name: FindBookingPagesByOwner :many
+SELECT
+    *
+FROM
+    booking_page
+WHERE
+    owner_id = $1
+ORDER BY
+    created_at,
+    id;
+
+-- name: FindUserBusySchedules :many
+SELECT
+    schedule.*
+FROM
+    schedule
+    JOIN event ON event.id = schedule.event_id
+WHERE
+    event.deleted_at IS NULL
+    AND (
+        event.created_by = sqlc.arg('created_by')
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                invitation
+            WHERE
+                invitation.event_id = event.id
+                AND invitation.user_id = sqlc.arg('user_id')
+                AND invitation.status <> sqlc.arg('declined')
+        )
+    )
+    AND (
+        schedule.recurring_type <> 'NONE'
+        OR schedule.start_time + schedule.duration * 60 > sqlc.arg('window_start')
+    )
+    AND schedule.start_time < sqlc.arg('window_end');
+
+-- name: FindBookedStarts :many
+SELECT
+    booking.start_time
+FROM
+    booking
+    JOIN event ON event.id = booking.event_id
+WHERE
+    booking.page_id = $1
+    AND booking.start_time >= $2
+    AND booking.start_time < $3
+    AND event.deleted_at IS NULL;
+
+-- name: LockBookingOwner :exec
+SELECT
+    pg_advisory_xact_lock(hashtext($1));
+
+-- name: CreateBooking :exec
+INSERT INTO
+    booking (
+        id,
+        page_id,
+        event_id,
+        start_time,
+        guest_name,
+        guest_email,
+        created_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: FindBookingByEventID :one
+SELECT
+    *
+FROM
+    booking
+WHERE
+    event_id = $1
+LIMIT
+    1;
+
+-- name: CreateGuest :exec
+INSERT INTO
+    guest (event_id, email, name)
+VALUES
+    ($1, $2, $3);
\ No newline at end of file